@@ -0,0 +1,768 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"claude-wm-cli/internal/backup"
+	clierrors "claude-wm-cli/internal/errors"
+	"claude-wm-cli/internal/navigation"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	backupDir           string
+	backupCompress      bool
+	backupCompressLevel int
+	backupSourceFilter  string
+	backupEncrypt       bool
+	backupKeyFile       string
+	backupIncremental   bool
+	backupVerifyAll     bool
+	backupRestoreID     string
+	backupInteractive   bool
+	backupRestoreYes    bool
+)
+
+// backupPassphraseEnv names the environment variable holding the backup encryption passphrase
+const backupPassphraseEnv = "CLAUDE_WM_BACKUP_PASSPHRASE"
+
+// backupCmd represents the backup command
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Manage file backups",
+	Long: `Create, list and maintain file backups stored in the backup directory.
+
+FEATURES:
+  • Checksummed backups with integrity verification
+  • Optional gzip compression (levels 1-9)
+  • Optional AES-256-GCM encryption (passphrase via $CLAUDE_WM_BACKUP_PASSPHRASE or --key-file)
+  • Retroactive compression of existing backups
+  • Incremental backups storing only JSON field changes
+  • Automatic backups of epics.json and stories.json before they are rewritten
+    (at most one every 5 minutes per file)
+  • Scheduled backups of the state files during interactive sessions
+
+COMMANDS:
+  • create      - Back up a file
+  • list        - List backups with sizes and compression ratio
+  • recompress  - Compress existing uncompressed backups
+  • consolidate - Squash an incremental chain into a full backup
+  • check       - Audit backup health (existence, checksum, content)
+  • restore     - Restore a file from one of its backups
+  • status      - Show the backup schedule and the last backup of each state file
+
+SCHEDULE:
+  Set backup.schedule (e.g. "1h") in ~/.claude-wm-cli.yaml to back up
+  epics.json, current-epic.json, stories.json (tickets included),
+  current-story.json and current-task.json at that interval while an
+  interactive session runs. Files unchanged since their last backup, or
+  backed up less than 5 minutes ago, are skipped.
+
+Examples:
+  claude-wm-cli backup create docs/1-project/PRD.md --compress   # Compressed backup
+  claude-wm-cli backup create docs/1-project/PRD.md --encrypt    # Encrypted backup
+  claude-wm-cli backup create docs/3-current-task/current-task.json --incremental  # Diff only
+  claude-wm-cli backup list                                       # Show all backups
+  claude-wm-cli backup recompress --compress-level 9              # Compress old backups
+  claude-wm-cli backup check --verify-all                         # Audit every backup
+  claude-wm-cli backup restore docs/1-project/epics.json --interactive  # Pick a backup
+  claude-wm-cli backup status                                     # Schedule and last backups`,
+}
+
+// backupCreateCmd creates a backup of a file
+var backupCreateCmd = &cobra.Command{
+	Use:   "create <file>",
+	Short: "Back up a file",
+	Long: `Create a manual backup of the given file.
+Use --compress to store it gzip-compressed with a .gz suffix.
+Use --encrypt to encrypt it with AES-256-GCM; the key is derived from
+$CLAUDE_WM_BACKUP_PASSPHRASE unless --key-file provides a raw 32-byte key.
+Use --incremental to store only the JSON changes since the previous backup;
+the first backup of a file (or a non-JSON file) is always a full backup.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return createFileBackup(args[0])
+	},
+}
+
+// backupListCmd lists existing backups
+var backupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List backups",
+	Long: `List existing backups with their original size, size on disk
+and compression ratio.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listBackups()
+	},
+}
+
+// backupRecompressCmd compresses existing uncompressed backups
+var backupRecompressCmd = &cobra.Command{
+	Use:   "recompress",
+	Short: "Compress existing uncompressed backups",
+	Long: `Retroactively gzip-compress backups that were stored uncompressed.
+Use --source to restrict the operation to the backups of a single file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return recompressBackups()
+	},
+}
+
+// backupConsolidateCmd squashes an incremental chain
+var backupConsolidateCmd = &cobra.Command{
+	Use:   "consolidate <file>",
+	Short: "Squash an incremental chain into a full backup",
+	Long: `Rebuild the latest backup of a file from its incremental chain, store it
+as a single full backup and remove the chain.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return consolidateBackups(args[0])
+	},
+}
+
+// backupCheckCmd audits backup health
+var backupCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Audit backup health",
+	Long: `Check every backup: the backup file must exist, match its stored SHA-256
+checksum and its content must be readable (decompressed, decrypted and, for
+JSON files, parsed).
+
+STATUS:
+  • ✅ healthy
+  • ⚠️  checksum mismatch or unreadable content
+  • ❌ missing backup file
+
+Backups older than the retention period are skipped unless --verify-all is set.
+Exits with code 1 if any backup is unhealthy.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+
+		healthy, err := checkBackups()
+		if err != nil {
+			return err
+		}
+		if !healthy {
+			return clierrors.NewCLIError("Unhealthy backups found", clierrors.ExitGeneric).
+				WithSuggestion("Restore the affected files from a healthy backup, or delete the broken backups")
+		}
+		return nil
+	},
+}
+
+// newBackupManager creates a backup manager from the command-line flags
+func newBackupManager() (*backup.Manager, error) {
+	config := backup.DefaultBackupConfig()
+	config.BackupDirectory = backupDir
+	config.CompressionLevel = backupCompressLevel
+	config.Encrypt = backupEncrypt
+	config.KeyFile = backupKeyFile
+	config.Passphrase = os.Getenv(backupPassphraseEnv)
+
+	manager, err := backup.NewManager(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize backup manager: %w", err)
+	}
+	return manager, nil
+}
+
+// backupSourceFilterFromFlags returns the filter selected by --source, or nil
+func backupSourceFilterFromFlags() (*backup.BackupFilter, error) {
+	if backupSourceFilter == "" {
+		return nil, nil
+	}
+	source, err := filepath.Abs(backupSourceFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source path %s: %w", backupSourceFilter, err)
+	}
+	return &backup.BackupFilter{SourceFile: source}, nil
+}
+
+// backupStatusCmd shows the backup schedule
+var backupStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the backup schedule and the last backup of each state file",
+	Long: `Show the backup.schedule setting, whether an interactive session is
+currently running the scheduled backups, the outcome of their last run and
+when each state file was last backed up.
+
+Examples:
+  claude-wm-cli backup status`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showBackupStatus()
+	},
+}
+
+// backupRestoreCmd restores a file from a backup
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <source-file>",
+	Short: "Restore a file from a backup",
+	Long: `Restore a file from one of its backups: the latest one, the backup given
+with --id, or the one picked from a numbered list with --interactive.
+
+The list shows each backup of the file with its creation time, type, size
+and integrity status. Before restoring, a preview shows what would change
+(changed fields for JSON files) and asks for confirmation unless --yes is
+set. The current file is backed up before it is replaced.
+
+Examples:
+  claude-wm-cli backup restore docs/1-project/epics.json --interactive
+  claude-wm-cli backup restore docs/1-project/epics.json --id backup-1a2b3c4d
+  claude-wm-cli backup restore docs/1-project/epics.json --yes   # Latest backup, no prompt`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return restoreFileBackup(args[0])
+	},
+}
+
+func validateCompressLevel() error {
+	if backupCompressLevel < 1 || backupCompressLevel > 9 {
+		return fmt.Errorf("invalid --compress-level %d: must be between 1 and 9", backupCompressLevel)
+	}
+	return nil
+}
+
+func createFileBackup(file string) error {
+	if err := validateCompressLevel(); err != nil {
+		return err
+	}
+
+	source, err := filepath.Abs(file)
+	if err != nil {
+		return fmt.Errorf("invalid file path %s: %w", file, err)
+	}
+
+	manager, err := newBackupManager()
+	if err != nil {
+		return err
+	}
+
+	backupType := backup.BackupTypeManual
+	if backupIncremental {
+		backupType = backup.BackupTypeIncremental
+	}
+
+	result, err := manager.CreateBackup(&backup.BackupRequest{
+		SourceFile: source,
+		Type:       backupType,
+		Reason:     backup.ReasonUserRequest,
+		Force:      true,
+		Verify:     true,
+		Compress:   backupCompress,
+	})
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		if result.Error != nil {
+			return fmt.Errorf("failed to create backup: %w", result.Error)
+		}
+		return fmt.Errorf("backup skipped: %s", result.Reason)
+	}
+
+	meta := result.Metadata
+	fmt.Printf("✅ Backup created successfully\n")
+	fmt.Printf("📋 ID: %s\n", meta.ID)
+	if meta.Encrypted {
+		fmt.Printf("🔒 Encrypted with AES-256-GCM\n")
+	}
+	if meta.IsIncremental {
+		fmt.Printf("🧩 Incremental (parent: %s)\n", meta.ParentBackupID)
+	} else if backupIncremental {
+		fmt.Printf("📦 Full backup (no previous JSON backup to diff against)\n")
+	}
+	fmt.Printf("📁 File: %s\n", meta.BackupFile)
+	if meta.Compressed {
+		fmt.Printf("🗜️  Size: %s → %s (%.0f%%)\n",
+			formatBackupSize(meta.SourceSize), formatBackupSize(meta.BackupSize), meta.CompressionRatio()*100)
+	} else {
+		fmt.Printf("📏 Size: %s\n", formatBackupSize(meta.BackupSize))
+	}
+	return nil
+}
+
+func listBackups() error {
+	manager, err := newBackupManager()
+	if err != nil {
+		return err
+	}
+
+	filter, err := backupSourceFilterFromFlags()
+	if err != nil {
+		return err
+	}
+
+	backups, err := manager.ListBackups(filter)
+	if err != nil {
+		return fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	if len(backups) == 0 {
+		fmt.Println("📝 No backups found")
+		return nil
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "ID\tSOURCE\tCREATED\tKIND\tSIZE\tBACKUP SIZE\tRATIO\t\n")
+	fmt.Fprintf(w, "──\t──────\t───────\t────\t────\t───────────\t─────\t\n")
+
+	for _, b := range backups {
+		ratio := "-"
+		if b.Compressed {
+			ratio = fmt.Sprintf("%.0f%%", b.CompressionRatio()*100)
+		}
+		locked := ""
+		if b.Encrypted {
+			locked = "🔒"
+		}
+		kind := "full"
+		if b.IsIncremental {
+			kind = "incremental"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			b.ID,
+			filepath.Base(b.SourceFile),
+			b.CreatedAt.Format(time.DateTime),
+			kind,
+			formatBackupSize(b.SourceSize),
+			formatBackupSize(b.BackupSize),
+			ratio,
+			locked)
+	}
+
+	w.Flush()
+	return nil
+}
+
+func recompressBackups() error {
+	if err := validateCompressLevel(); err != nil {
+		return err
+	}
+
+	manager, err := newBackupManager()
+	if err != nil {
+		return err
+	}
+
+	filter, err := backupSourceFilterFromFlags()
+	if err != nil {
+		return err
+	}
+
+	recompressed, err := manager.RecompressBackups(filter)
+	if err != nil {
+		return err
+	}
+
+	if len(recompressed) == 0 {
+		fmt.Println("📝 No uncompressed backups to recompress")
+		return nil
+	}
+
+	var before, after int64
+	for _, b := range recompressed {
+		before += b.SourceSize
+		after += b.BackupSize
+	}
+
+	fmt.Printf("✅ Recompressed %d backups\n", len(recompressed))
+	fmt.Printf("🗜️  %s → %s\n", formatBackupSize(before), formatBackupSize(after))
+	return nil
+}
+
+func consolidateBackups(file string) error {
+	source, err := filepath.Abs(file)
+	if err != nil {
+		return fmt.Errorf("invalid file path %s: %w", file, err)
+	}
+
+	manager, err := newBackupManager()
+	if err != nil {
+		return err
+	}
+
+	latest, err := manager.ConsolidateBackups(source)
+	if err != nil {
+		return fmt.Errorf("failed to consolidate backups: %w", err)
+	}
+
+	fmt.Printf("✅ Latest backup of %s is a full backup\n", filepath.Base(source))
+	fmt.Printf("📋 ID: %s\n", latest.ID)
+	fmt.Printf("📁 File: %s\n", latest.BackupFile)
+	return nil
+}
+
+func restoreFileBackup(file string) error {
+	source, err := filepath.Abs(file)
+	if err != nil {
+		return fmt.Errorf("invalid file path %s: %w", file, err)
+	}
+
+	manager, err := newBackupManager()
+	if err != nil {
+		return err
+	}
+
+	menuDisplay := navigation.NewMenuDisplay()
+	backupID := backupRestoreID
+	if backupInteractive {
+		selected, err := pickBackup(manager, menuDisplay, source)
+		if err != nil || selected == nil {
+			return err
+		}
+		backupID = selected.ID
+	}
+
+	request := &backup.RecoveryRequest{
+		SourceFile:   source,
+		BackupID:     backupID,
+		VerifyBefore: true,
+		VerifyAfter:  true,
+		RestoreMode:  backup.RestoreModePreview,
+	}
+	preview, err := manager.RecoverFromBackup(request)
+	if err != nil {
+		return err
+	}
+	if !preview.Success {
+		return preview.Error
+	}
+
+	used := preview.BackupUsed
+	if used.SourceFile != source {
+		return fmt.Errorf("backup %s is a backup of %s, not %s", used.ID, used.SourceFile, source)
+	}
+	fmt.Printf("\n🔍 Restore preview: %s from %s (%s)\n", filepath.Base(source), used.ID, used.CreatedAt.Format(time.DateTime))
+	for _, change := range preview.Changes {
+		fmt.Printf("   %s\n", change)
+	}
+	fmt.Println()
+
+	if !backupRestoreYes {
+		confirmed, err := menuDisplay.Confirm("Restore this backup?")
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Restore cancelled.")
+			return nil
+		}
+	}
+
+	request.BackupID = used.ID
+	request.RestoreMode = backup.RestoreModeReplace
+	request.CreateBackup = true
+	result, err := manager.RecoverFromBackup(request)
+	if err != nil {
+		return err
+	}
+	if !result.Success {
+		return result.Error
+	}
+
+	fmt.Printf("✅ Restored %s from backup %s\n", result.RestoredFile, used.ID)
+	if result.BackupCreated != nil {
+		fmt.Printf("📋 Previous version backed up as %s\n", result.BackupCreated.ID)
+	}
+	for _, warning := range result.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+	return nil
+}
+
+// pickBackup lets the user choose one of the backups of source from a
+// numbered menu, newest first. It returns nil when the user quits or there
+// are no backups.
+func pickBackup(manager *backup.Manager, menuDisplay *navigation.MenuDisplay, source string) (*backup.BackupMetadata, error) {
+	backups, err := manager.ListBackups(&backup.BackupFilter{SourceFile: source})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	if len(backups) == 0 {
+		fmt.Printf("📝 No backups found for %s\n", source)
+		fmt.Println("💡 Run 'claude-wm-cli backup check' to audit the existing backups, or")
+		fmt.Printf("   'claude-wm-cli backup create %s' to create one\n", filepath.Base(source))
+		return nil, nil
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+	entries := make([]*backup.BackupHealth, 0, len(backups))
+	for _, b := range backups {
+		entries = append(entries, manager.CheckBackupHealth(b))
+	}
+
+	picker := backup.NewBackupPickerDisplay(entries)
+	header, rows := picker.Render()
+	menu := &navigation.Menu{ShowNumbers: true, AllowQuit: true}
+	for i, row := range rows {
+		menu.Options = append(menu.Options, navigation.MenuOption{
+			ID:      picker.Backup(i).ID,
+			Label:   row,
+			Action:  "restore",
+			Enabled: true,
+		})
+	}
+
+	fmt.Printf("\n═══ Backups of %s ═══\n\n", filepath.Base(source))
+	fmt.Printf("     %s\n", header)
+	result, err := menuDisplay.Show(menu)
+	if err != nil {
+		return nil, err
+	}
+	if result.SelectedOption == nil {
+		fmt.Println("Restore cancelled.")
+		return nil, nil
+	}
+
+	for i := 0; i < picker.Len(); i++ {
+		if picker.Backup(i).ID == result.SelectedOption.ID {
+			return picker.Backup(i), nil
+		}
+	}
+	return nil, fmt.Errorf("backup not found: %s", result.SelectedOption.ID)
+}
+
+// backupHealthIcon returns the status indicator for a backup health result
+func backupHealthIcon(status backup.HealthStatus) string {
+	switch status {
+	case backup.HealthHealthy:
+		return "✅"
+	case backup.HealthMissing:
+		return "❌"
+	default:
+		return "⚠️ "
+	}
+}
+
+// checkBackups prints the health of each backup and returns false if any is unhealthy
+func checkBackups() (bool, error) {
+	manager, err := newBackupManager()
+	if err != nil {
+		return false, err
+	}
+
+	filter, err := backupSourceFilterFromFlags()
+	if err != nil {
+		return false, err
+	}
+
+	report, err := manager.CheckHealth(filter, backupVerifyAll)
+	if err != nil {
+		return false, fmt.Errorf("failed to check backups: %w", err)
+	}
+
+	sort.Slice(report.Results, func(i, j int) bool {
+		return report.Results[i].Backup.CreatedAt.After(report.Results[j].Backup.CreatedAt)
+	})
+
+	fmt.Println("🔍 Checking backup health")
+	fmt.Println()
+	for _, result := range report.Results {
+		fmt.Printf("%s %s  %s  %s\n", backupHealthIcon(result.Status), result.Backup.ID,
+			filepath.Base(result.Backup.SourceFile), result.Backup.CreatedAt.Format(time.DateTime))
+		if result.Message != "" {
+			fmt.Printf("     %s\n", result.Message)
+		}
+	}
+
+	unhealthy := report.Unhealthy()
+	fmt.Println()
+	fmt.Printf("📊 Summary: %d checked, %d healthy, %d unhealthy",
+		len(report.Results), len(report.Results)-unhealthy, unhealthy)
+	if report.Skipped > 0 {
+		fmt.Printf(", %d skipped (older than retention, use --verify-all)", report.Skipped)
+	}
+	fmt.Println()
+
+	return unhealthy == 0, nil
+}
+
+// showBackupStatus prints the backup schedule, the state of the scheduler
+// and the latest backup of each scheduled file
+func showBackupStatus() error {
+	interval, err := backup.ParseSchedule(viper.GetString("backup.schedule"))
+	if err != nil {
+		return err
+	}
+	if interval == 0 {
+		fmt.Println("🗓️  Schedule: off (set backup.schedule, e.g. \"1h\", in ~/.claude-wm-cli.yaml)")
+	} else {
+		fmt.Printf("🗓️  Schedule: every %s during interactive sessions\n", interval)
+	}
+
+	status, err := backup.LoadScheduleStatus(backupDir)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	switch {
+	case status == nil:
+		fmt.Println("⏸️  Scheduler: never ran")
+	case status.Running(now):
+		fmt.Printf("▶️  Scheduler: running (PID %d) since %s, next run at %s\n",
+			status.PID, status.StartedAt.Format(time.DateTime), status.NextRun.Format(time.DateTime))
+	case status.StoppedAt != nil:
+		fmt.Printf("⏸️  Scheduler: stopped at %s\n", status.StoppedAt.Format(time.DateTime))
+	default:
+		fmt.Printf("⏸️  Scheduler: not running (PID %d exited)\n", status.PID)
+	}
+	if status != nil && status.LastRun != nil {
+		run := status.LastRun
+		fmt.Printf("🕒 Last run: %s, %d backed up, %d skipped, %d failed\n",
+			run.StartedAt.Format(time.DateTime), len(run.BackedUp), len(run.Skipped), len(run.Failed))
+		for path, message := range run.Failed {
+			fmt.Printf("   ❌ %s: %s\n", path, message)
+		}
+	}
+
+	manager, err := newBackupManager()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "FILE\tLAST BACKUP\tTYPE\t\n")
+	fmt.Fprintf(w, "────\t───────────\t────\t\n")
+	for _, path := range backup.ScheduledFiles {
+		source, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("invalid source path %s: %w", path, err)
+		}
+		if _, err := os.Stat(source); os.IsNotExist(err) {
+			fmt.Fprintf(w, "%s\t%s\t%s\t\n", path, "missing", "-")
+			continue
+		}
+
+		backups, err := manager.ListBackups(&backup.BackupFilter{SourceFile: source})
+		if err != nil {
+			return fmt.Errorf("failed to list backups: %w", err)
+		}
+		var latest *backup.BackupMetadata
+		for _, b := range backups {
+			if b.IsCompleted() && (latest == nil || b.CreatedAt.After(latest.CreatedAt)) {
+				latest = b
+			}
+		}
+		if latest == nil {
+			fmt.Fprintf(w, "%s\t%s\t%s\t\n", path, "never", "-")
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t\n", path, latest.CreatedAt.Format(time.DateTime), latest.Type)
+	}
+	w.Flush()
+	return nil
+}
+
+// startBackupScheduler starts the scheduled backups of the state files of
+// the project at root when backup.schedule is set, and returns the function
+// stopping them, nil when there is nothing to schedule
+func startBackupScheduler(root string) (func(), error) {
+	interval, err := backup.ParseSchedule(viper.GetString("backup.schedule"))
+	if err != nil || interval == 0 {
+		return nil, err
+	}
+	if _, err := os.Stat(filepath.Join(root, "docs")); err != nil {
+		return nil, nil
+	}
+
+	scheduler := backup.NewScheduler(root, interval, nil)
+	scheduler.Start()
+	return scheduler.Stop, nil
+}
+
+// backupDoctorCheck reports backup health for the doctor command
+func backupDoctorCheck() doctorResult {
+	if _, err := os.Stat(backupDir); os.IsNotExist(err) {
+		return doctorResult{OK: true, Summary: "no backups yet"}
+	}
+
+	// Encrypted backups are read with $CLAUDE_WM_BACKUP_PASSPHRASE, as by 'backup check'
+	manager, err := newBackupManager()
+	if err != nil {
+		return doctorResult{OK: false, Summary: err.Error()}
+	}
+
+	report, err := manager.CheckHealth(nil, false)
+	if err != nil {
+		return doctorResult{OK: false, Summary: err.Error()}
+	}
+
+	unhealthy := report.Unhealthy()
+	if unhealthy == 0 {
+		return doctorResult{OK: true, Summary: fmt.Sprintf("%d backups healthy", len(report.Results))}
+	}
+
+	result := doctorResult{
+		OK:      false,
+		Summary: fmt.Sprintf("%d of %d backups unhealthy (run 'claude-wm-cli backup check')", unhealthy, len(report.Results)),
+	}
+	for _, health := range report.Results {
+		if !health.IsHealthy() {
+			result.Details = append(result.Details, fmt.Sprintf("%s: %s", health.Backup.ID, health.Status))
+		}
+	}
+	return result
+}
+
+// formatBackupSize formats a byte count in human-readable units
+func formatBackupSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupListCmd)
+	backupCmd.AddCommand(backupRecompressCmd)
+	backupCmd.AddCommand(backupConsolidateCmd)
+	backupCmd.AddCommand(backupCheckCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	backupCmd.AddCommand(backupStatusCmd)
+
+	registerDoctorCheck("Backups", backupDoctorCheck)
+
+	// Global flags
+	backupCmd.PersistentFlags().StringVar(&backupDir, "backup-dir", backup.DefaultBackupConfig().BackupDirectory, "Directory where backups are stored")
+	backupCmd.PersistentFlags().IntVar(&backupCompressLevel, "compress-level", backup.DefaultCompressionLevel, "Gzip compression level (1-9)")
+	backupCmd.PersistentFlags().StringVar(&backupKeyFile, "key-file", "", "File holding a raw 32-byte encryption key (overrides $"+backupPassphraseEnv+")")
+
+	// Create command flags
+	backupCreateCmd.Flags().BoolVar(&backupCompress, "compress", false, "Compress the backup with gzip")
+	backupCreateCmd.Flags().BoolVar(&backupEncrypt, "encrypt", false, "Encrypt the backup with AES-256-GCM")
+	backupCreateCmd.Flags().BoolVar(&backupIncremental, "incremental", false, "Store only the JSON changes since the previous backup")
+
+	// List, recompress and check command flags
+	backupListCmd.Flags().StringVar(&backupSourceFilter, "source", "", "Only show backups of this file")
+	backupRecompressCmd.Flags().StringVar(&backupSourceFilter, "source", "", "Only recompress backups of this file")
+	backupCheckCmd.Flags().StringVar(&backupSourceFilter, "source", "", "Only check backups of this file")
+	backupRestoreCmd.Flags().StringVar(&backupRestoreID, "id", "", "Restore this backup instead of the latest one")
+	backupRestoreCmd.Flags().BoolVarP(&backupInteractive, "interactive", "i", false, "Pick the backup to restore from a list")
+	backupRestoreCmd.Flags().BoolVarP(&backupRestoreYes, "yes", "y", false, "Restore without asking for confirmation")
+	backupRestoreCmd.MarkFlagsMutuallyExclusive("id", "interactive")
+	backupCheckCmd.Flags().BoolVar(&backupVerifyAll, "verify-all", false, "Also check backups older than the retention period")
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"claude-wm-cli/internal/executor"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Claude execution flags shared by the commands that run Claude
+var (
+	claudeTimeout  time.Duration
+	claudeSaveLogs bool
+	claudeModel    string
+	claudeNoRetry  bool
+)
+
+// addClaudeExecutionFlags registers the Claude execution flags on the given flag set
+func addClaudeExecutionFlags(flags *pflag.FlagSet) {
+	flags.DurationVar(&claudeTimeout, "claude-timeout", 0, "per-command Claude timeout, e.g. 15m (default from claude.timeout or 10m)")
+	flags.StringVar(&claudeModel, "model", "", "Claude model for every command, e.g. opus or sonnet (default from claude.model)")
+	flags.BoolVar(&claudeSaveLogs, "save-logs", false, "save each Claude command's output under "+executor.DefaultLogDir)
+	flags.BoolVar(&claudeNoRetry, "no-retry", false, "run each Claude command once, without retrying failures")
+}
+
+// resolveClaudeTimeout returns the Claude timeout from the flag or the
+// claude.timeout config key, in that order, and false when neither is set so
+// that the executor default (none in development mode) applies
+func resolveClaudeTimeout() (time.Duration, bool) {
+	if claudeTimeout > 0 {
+		return claudeTimeout, true
+	}
+	if configured := viper.GetDuration("claude.timeout"); configured > 0 {
+		return configured, true
+	}
+	return 0, false
+}
+
+// resolveClaudeModel returns the Claude model from the flag or the claude.model
+// config key; "" keeps the Claude CLI default
+func resolveClaudeModel() string {
+	if claudeModel != "" {
+		return claudeModel
+	}
+	return viper.GetString("claude.model")
+}
+
+// resolveClaudeRetryPolicy returns the retry policy for failed Claude
+// commands, disabled by --no-retry and otherwise tuned by the
+// claude.retry.max_attempts, .initial_delay and .backoff_factor config keys
+func resolveClaudeRetryPolicy() executor.RetryPolicy {
+	if claudeNoRetry {
+		return executor.NoRetryPolicy()
+	}
+
+	policy := executor.DefaultRetryPolicy()
+	if viper.IsSet("claude.retry.max_attempts") {
+		policy.MaxAttempts = viper.GetInt("claude.retry.max_attempts")
+	}
+	if delay := viper.GetDuration("claude.retry.initial_delay"); delay > 0 {
+		policy.InitialDelay = delay
+	}
+	if factor := viper.GetFloat64("claude.retry.backoff_factor"); factor > 0 {
+		policy.BackoffFactor = factor
+	}
+	return policy
+}
+
+// resolveClaudeExitCodes returns the exit-code mapping from the
+// claude.exitcodes.success, .iterate and .blocked config keys
+func resolveClaudeExitCodes() executor.ExitCodeMapping {
+	mapping := executor.DefaultExitCodeMapping()
+	if viper.IsSet("claude.exitcodes.success") {
+		mapping.Success = viper.GetInt("claude.exitcodes.success")
+	}
+	if viper.IsSet("claude.exitcodes.iterate") {
+		mapping.Iterate = viper.GetInt("claude.exitcodes.iterate")
+	}
+	if viper.IsSet("claude.exitcodes.blocked") {
+		mapping.Blocked = viper.GetInt("claude.exitcodes.blocked")
+	}
+	return mapping
+}
+
+// newConfiguredClaudeExecutor creates a Claude executor configured from flags and config
+func newConfiguredClaudeExecutor() *executor.ClaudeExecutor {
+	claudeExecutor := executor.NewClaudeExecutorWithRetry(resolveClaudeRetryPolicy())
+	if timeout, ok := resolveClaudeTimeout(); ok {
+		claudeExecutor.SetTimeout(timeout)
+	}
+	claudeExecutor.SetModel(resolveClaudeModel())
+	if claudeModel == "" {
+		claudeExecutor.SetModelOverrides(viper.GetStringMapString("claude.models"))
+	}
+	if claudeSaveLogs || viper.GetBool("claude.save_logs") {
+		claudeExecutor.SetLogDir(executor.DefaultLogDir)
+	}
+	return claudeExecutor
+}
+
+// claudeLogHint returns a message pointing at the saved output of the last
+// Claude command, or "" when output logs are disabled
+func claudeLogHint(claudeExecutor ClaudeExecutorInterface) string {
+	if path := claudeExecutor.LastLogPath(); path != "" {
+		return fmt.Sprintf("📄 Claude output saved to %s", path)
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"claude-wm-cli/internal/executor"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveClaudeTimeout(t *testing.T) {
+	original := claudeTimeout
+	t.Cleanup(func() {
+		claudeTimeout = original
+		viper.Set("claude.timeout", nil)
+	})
+
+	claudeTimeout = 0
+	viper.Set("claude.timeout", nil)
+	_, ok := resolveClaudeTimeout()
+	assert.False(t, ok, "the executor default applies when no timeout is configured")
+
+	viper.Set("claude.timeout", "20m")
+	timeout, ok := resolveClaudeTimeout()
+	assert.True(t, ok)
+	assert.Equal(t, 20*time.Minute, timeout)
+
+	claudeTimeout = 5 * time.Minute
+	timeout, ok = resolveClaudeTimeout()
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Minute, timeout, "--claude-timeout takes precedence")
+}
+
+func TestResolveClaudeRetryPolicy(t *testing.T) {
+	original := claudeNoRetry
+	t.Cleanup(func() {
+		claudeNoRetry = original
+		viper.Set("claude.retry", nil)
+	})
+
+	claudeNoRetry = false
+	viper.Set("claude.retry", nil)
+	assert.Equal(t, executor.DefaultRetryPolicy(), resolveClaudeRetryPolicy())
+
+	viper.Set("claude.retry", map[string]interface{}{"max_attempts": 5, "initial_delay": "500ms", "backoff_factor": 1.5})
+	policy := resolveClaudeRetryPolicy()
+	assert.Equal(t, 5, policy.MaxAttempts)
+	assert.Equal(t, 500*time.Millisecond, policy.InitialDelay)
+	assert.Equal(t, 1.5, policy.BackoffFactor)
+
+	claudeNoRetry = true
+	assert.Equal(t, executor.NoRetryPolicy(), resolveClaudeRetryPolicy(), "--no-retry wins over the config")
+}
+
+func TestClaudeExecutionFlagsOnClaudeCommands(t *testing.T) {
+	commands := []*cobra.Command{
+		InteractiveCmd,
+		ticketCmd,
+		projectChallengeCmd,
+		projectPlanEpicsCmd,
+		epicListCmd,
+		generateCmd,
+		subagentsTestCmd,
+	}
+
+	for _, command := range commands {
+		for _, name := range []string{"no-retry", "claude-timeout", "model", "save-logs"} {
+			assert.NotNil(t, command.Flag(name), "%s --%s", command.CommandPath(), name)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"claude-wm-cli/internal/epic"
+	"claude-wm-cli/internal/ticket"
+
+	"github.com/spf13/cobra"
+)
+
+// completionShells are the shells completion scripts are generated for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// completionCmd generates shell completion scripts
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the shell completion script",
+	Long: `Generate the completion script of claude-wm-cli for a shell.
+
+Besides commands and flags, epic and ticket IDs are completed from the
+project in the current directory, so that 'ticket show <TAB>' suggests the
+existing tickets.
+
+Bash (needs the bash-completion package):
+  source <(claude-wm-cli completion bash)
+  # Load it in every session:
+  claude-wm-cli completion bash > /etc/bash_completion.d/claude-wm-cli
+
+Zsh:
+  # Enable completion once, if not already done:
+  echo "autoload -U compinit; compinit" >> ~/.zshrc
+  claude-wm-cli completion zsh > "${fpath[1]}/_claude-wm-cli"
+
+Fish:
+  claude-wm-cli completion fish > ~/.config/fish/completions/claude-wm-cli.fish
+
+PowerShell:
+  claude-wm-cli completion powershell | Out-String | Invoke-Expression
+
+Start a new shell for the completion to take effect.`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             completionShells,
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writeCompletion(cmd.Root(), cmd.OutOrStdout(), args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+
+	for _, cmd := range []*cobra.Command{
+		epicUpdateCmd, epicSelectCmd, epicShowCmd, epicDeleteCmd, epicArchiveCmd,
+		epicHistoryCmd, epicMetricsCmd, epicBurndownCmd, storyGenerateCmd,
+	} {
+		cmd.ValidArgsFunction = completeEpicIDs
+	}
+
+	for _, cmd := range []*cobra.Command{ticketShowCmd, ticketWatchCmd, ticketUpdateCmd, ticketStatusCmd, ticketCurrentCmd} {
+		cmd.ValidArgsFunction = completeTicketIDs
+	}
+}
+
+// writeCompletion writes the completion script of root for shell to w
+func writeCompletion(root *cobra.Command, w io.Writer, shell string) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(w, true)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q: use %s", shell, strings.Join(completionShells, ", "))
+	}
+}
+
+// completeEpicIDs completes the first argument with the IDs of the epics of
+// the project, described by their title
+func completeEpicIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return epicIDCompletions(toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeEpicIDFlag completes a flag value with the IDs of the epics of the
+// project
+func completeEpicIDFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return epicIDCompletions(toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// epicIDCompletions returns the IDs of the epics of the project in the
+// working directory starting with prefix, each followed by its title
+func epicIDCompletions(prefix string) []string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	epics, err := epic.NewManager(wd).ListEpics(epic.EpicListOptions{})
+	if err != nil {
+		return nil
+	}
+
+	var completions []string
+	for _, ep := range epics {
+		if strings.HasPrefix(ep.ID, prefix) {
+			completions = append(completions, ep.ID+"\t"+ep.Title)
+		}
+	}
+	return completions
+}
+
+// completeTicketIDs completes the first argument with the IDs of the open
+// tickets of the project, described by their title
+func completeTicketIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	tickets, err := ticket.NewManager(wd).ListTickets(ticket.TicketListOptions{})
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var completions []string
+	for _, t := range tickets {
+		if strings.HasPrefix(t.ID, toComplete) {
+			completions = append(completions, t.ID+"\t"+t.Title)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"claude-wm-cli/internal/epic"
+	"claude-wm-cli/internal/ticket"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCompletion(t *testing.T) {
+	for _, shell := range completionShells {
+		var out bytes.Buffer
+		require.NoError(t, writeCompletion(rootCmd, &out, shell), shell)
+		assert.Contains(t, out.String(), "claude-wm-cli", shell)
+	}
+
+	err := writeCompletion(rootCmd, &bytes.Buffer{}, "tcsh")
+	assert.ErrorContains(t, err, `unsupported shell "tcsh"`)
+}
+
+func TestCompleteIDs(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	_, err := epic.NewManager(dir).CreateEpic(epic.EpicCreateOptions{Title: "Auth"})
+	require.NoError(t, err)
+	_, err = ticket.NewManager(dir).CreateTicket(ticket.TicketCreateOptions{Title: "Fix login"})
+	require.NoError(t, err)
+
+	epics, directive := completeEpicIDs(epicShowCmd, nil, "EPIC-")
+	assert.Equal(t, []string{"EPIC-001-AUTH\tAuth"}, epics)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	epics, _ = completeEpicIDs(epicShowCmd, nil, "NOPE")
+	assert.Empty(t, epics)
+	epics, _ = completeEpicIDs(epicShowCmd, []string{"EPIC-001-AUTH"}, "")
+	assert.Empty(t, epics, "only the first argument is an epic ID")
+	epics, _ = completeEpicIDFlag(epicMetricsCmd, []string{"EPIC-001-AUTH"}, "")
+	assert.Equal(t, []string{"EPIC-001-AUTH\tAuth"}, epics)
+
+	tickets, _ := completeTicketIDs(ticketShowCmd, nil, "")
+	require.Len(t, tickets, 1)
+	assert.Contains(t, tickets[0], "\tFix login")
+}
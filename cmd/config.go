@@ -7,14 +7,17 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"claude-wm-cli/internal/cmd"
 	"claude-wm-cli/internal/config"
 	"claude-wm-cli/internal/diff"
 	"claude-wm-cli/internal/fsutil"
 	"claude-wm-cli/internal/meta"
+	"claude-wm-cli/internal/theme"
 	"claude-wm-cli/internal/update"
 	"claude-wm-cli/internal/ziputil"
 	wmmeta "claude-wm-cli/internal/wm/meta"
@@ -34,6 +37,11 @@ Available subcommands:
   upgrade         Update system templates (preserves user customizations)
   edit            Edit user configuration files
   show            Show effective runtime configuration
+  validate        Validate .claude-wm/config.json against the schema
+  get             Print a configuration value (dotted key)
+  set             Set a configuration value in .claude-wm/config.json
+  unset           Remove a value from .claude-wm/config.json (default applies)
+  profile         Manage config profiles (create, switch, list, show)
   migrate-legacy  Migrate from legacy .claude-wm to new .wm structure`,
 }
 
@@ -89,11 +97,132 @@ var configUpgradeCmd = &cobra.Command{
 	RunE:  runConfigUpgrade,
 }
 
+var configRollbackTo string
+
+var configRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Restore system templates from a snapshot",
+	Long: `Restore the system templates and runtime configuration saved before a
+'config upgrade', then re-sync so that your user customizations are preserved.
+
+The configuration being replaced is snapshotted first, so a rollback can itself
+be rolled back.
+
+Examples:
+  claude-wm-cli config rollback                           # Latest snapshot
+  claude-wm-cli config rollback --to backup-1a2b3c4d5e6f7a8b
+  claude-wm-cli config list-snapshots                     # Available snapshot IDs`,
+	Args: cobra.NoArgs,
+	RunE: runConfigRollback,
+}
+
+var configListSnapshotsCmd = &cobra.Command{
+	Use:   "list-snapshots",
+	Short: "List configuration snapshots",
+	Long:  `List the configuration snapshots that 'config rollback' can restore, newest first`,
+	Args:  cobra.NoArgs,
+	RunE:  runConfigListSnapshots,
+}
+
+var (
+	configDiffNameOnly  bool
+	configDiffDefaults  bool
+	configDiffUnchanged bool
+)
+
+var configDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare runtime configuration with system templates",
+	Long: `Compare the runtime configuration (.claude-wm/runtime) with the system
+templates (.claude-wm/system), e.g. to audit what 'config upgrade' changed.
+
+Prints a unified diff of every file that differs, followed by the files that
+exist only in the system templates or only in the runtime configuration.
+
+With --defaults, compares the effective settings (see 'config show') with the
+defaults embedded in the binary instead, key by key: "- key: value" lines are
+defaults and "+ key: value" lines their customized values.
+
+Examples:
+  claude-wm-cli config diff                  # Unified diff
+  claude-wm-cli config diff --name-only      # Only list the differing files
+  claude-wm-cli config diff --defaults       # Settings changed from the defaults
+  claude-wm-cli config diff --defaults --unchanged  # Also the default ones`,
+	Args: cobra.NoArgs,
+	RunE: runConfigDiff,
+}
+
+var configShowJSON bool
+
 var configShowCmd = &cobra.Command{
 	Use:   "show [file]",
 	Short: "Show effective configuration",
-	Long:  `Show the effective runtime configuration or a specific file`,
-	RunE:  runConfigShow,
+	Long: `Show the effective runtime configuration or a specific file.
+
+Without arguments, prints the settings produced by 'config sync' and where each
+value comes from: the built-in default, the system template or a user override.
+
+Examples:
+  claude-wm-cli config show                  # Effective settings with sources
+  claude-wm-cli config show --json           # Same, as JSON
+  claude-wm-cli config show settings.json    # Raw runtime file`,
+	RunE: runConfigShow,
+}
+
+var configValidateFix bool
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the project configuration",
+	Long: `Validate .claude-wm/config.json against the embedded JSON schema.
+
+Errors (wrong types, values outside an enumeration or range) prevent the
+configuration from loading. Warnings (unknown keys, missing defaults) are
+reported and can be corrected automatically with --fix.`,
+	RunE: runConfigValidate,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a configuration value",
+	Long: `Print the effective value of a configuration key given as a dotted path.
+
+Strings are printed as-is, other values as JSON. Unset keys print null.
+
+Examples:
+  claude-wm-cli config get claude.model
+  claude-wm-cli config get thresholds.interactive.warn_ms`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a configuration value",
+	Long: `Set a key in .claude-wm/config.json after checking it against the schema.
+When a config profile is active, the key is set in the profile instead.
+
+The value is parsed as JSON when it is a valid JSON literal (numbers, booleans,
+null, arrays, objects) and stored as a string otherwise.
+
+Examples:
+  claude-wm-cli config set claude.model opus
+  claude-wm-cli config set claude.save_logs true
+  claude-wm-cli config set thresholds.interactive.warn_ms 5000`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a configuration value",
+	Long: `Remove a key from .claude-wm/config.json so that its default applies again.
+When a config profile is active, the key is removed from the profile instead.
+
+Examples:
+  claude-wm-cli config unset claude.model`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigUnset,
 }
 
 func init() {
@@ -104,12 +233,29 @@ func init() {
 	configCmd.AddCommand(configUpdateCmd)
 	configCmd.AddCommand(configSyncCmd)
 	configCmd.AddCommand(configUpgradeCmd)
+	configCmd.AddCommand(configDiffCmd)
+	configCmd.AddCommand(configRollbackCmd)
+	configCmd.AddCommand(configListSnapshotsCmd)
 	configCmd.AddCommand(configShowCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configUnsetCmd)
 	configCmd.AddCommand(cmd.ConfigMigrateLegacyCmd)
 
 	// Add flags for update command
 	configUpdateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Show planned changes without applying them")
 	configUpdateCmd.Flags().BoolVar(&updateNoBackup, "no-backup", false, "Skip creating backup before applying changes")
+
+	// Add flags for show command
+	configRollbackCmd.Flags().StringVar(&configRollbackTo, "to", "", "Snapshot ID to restore (default: latest)")
+	configDiffCmd.Flags().BoolVar(&configDiffNameOnly, "name-only", false, "Only list the files that differ")
+	configDiffCmd.Flags().BoolVar(&configDiffDefaults, "defaults", false, "Compare the effective settings with the embedded defaults, key by key")
+	configDiffCmd.Flags().BoolVar(&configDiffUnchanged, "unchanged", false, "With --defaults, also show the keys that match the defaults")
+	configShowCmd.Flags().BoolVar(&configShowJSON, "json", false, "Output the effective settings and their sources as JSON")
+
+	// Add flags for validate command
+	configValidateCmd.Flags().BoolVar(&configValidateFix, "fix", false, "Remove unknown keys and insert missing defaults")
 }
 
 func runConfigInstall(cmd *cobra.Command, args []string) error {
@@ -383,6 +529,9 @@ func runConfigSync(cmd *cobra.Command, args []string) error {
 	manager := config.NewManager(projectPath)
 
 	fmt.Println("🔄 Syncing configuration...")
+	if profile, _, err := manager.ActiveProfile(); err == nil && profile != "" {
+		fmt.Printf("📋 Using profile %s\n", profile)
+	}
 
 	if err := manager.Sync(); err != nil {
 		return fmt.Errorf("sync failed: %w", err)
@@ -402,6 +551,15 @@ func runConfigUpgrade(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("⬆️  Upgrading system templates...")
 
+	// Snapshot the current templates so the upgrade can be rolled back
+	if _, err := os.Stat(manager.SystemPath); err == nil {
+		snapshot, err := manager.CreateSnapshot("config-upgrade")
+		if err != nil {
+			return fmt.Errorf("failed to snapshot configuration before upgrade: %w", err)
+		}
+		fmt.Printf("📸 Saved snapshot %s\n", snapshot.ID)
+	}
+
 	// Reinstall system templates (this updates defaults without touching user files)
 	if err := manager.InstallSystemTemplates(); err != nil {
 		return fmt.Errorf("failed to upgrade system templates: %w", err)
@@ -414,6 +572,155 @@ func runConfigUpgrade(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("✅ System templates upgraded successfully!")
 	fmt.Println("💡 Your user customizations have been preserved")
+	fmt.Println("💡 Undo with: claude-wm-cli config rollback")
+	return nil
+}
+
+func runConfigRollback(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	manager := config.NewManager(projectPath)
+
+	fmt.Println("⏪ Rolling back configuration...")
+
+	snapshot, err := manager.RestoreSnapshot(configRollbackTo)
+	if err != nil {
+		return fmt.Errorf("rollback failed: %w", err)
+	}
+
+	fmt.Printf("✅ Restored snapshot %s from %s\n", snapshot.ID, snapshot.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Println("💡 Your user customizations have been preserved")
+	return nil
+}
+
+func runConfigListSnapshots(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	snapshots, err := config.NewManager(projectPath).ListSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Println("📝 No configuration snapshots (one is saved by each 'config upgrade')")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCREATED\tREASON\tSIZE")
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", snapshot.ID, snapshot.CreatedAt.Format("2006-01-02 15:04:05"), snapshot.Reason, snapshot.Size)
+	}
+	return w.Flush()
+}
+
+func runConfigDiff(cmd *cobra.Command, args []string) error {
+	if configDiffUnchanged && !configDiffDefaults {
+		return fmt.Errorf("--unchanged only applies with --defaults")
+	}
+
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	manager := config.NewManager(projectPath)
+	if configDiffDefaults {
+		return runConfigDefaultsDiff(manager)
+	}
+	for _, dir := range []string{manager.SystemPath, manager.RuntimePath} {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			fmt.Printf("❌ %s not found - run 'claude-wm-cli config init' first\n", dir)
+			return nil
+		}
+	}
+
+	system := os.DirFS(manager.SystemPath)
+	runtime := os.DirFS(manager.RuntimePath)
+	changes, err := diff.DiffTrees(system, ".", runtime, ".")
+	if err != nil {
+		return fmt.Errorf("failed to diff system vs runtime: %w", err)
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("✅ Runtime configuration matches the system templates")
+		return nil
+	}
+
+	var onlySystem, onlyRuntime []string
+	for _, change := range changes {
+		switch change.Type {
+		case diff.ChangeNew:
+			onlySystem = append(onlySystem, change.Path)
+		case diff.ChangeDel:
+			onlyRuntime = append(onlyRuntime, change.Path)
+		case diff.ChangeMod:
+			if configDiffNameOnly {
+				fmt.Printf("M %s\n", change.Path)
+				continue
+			}
+			unified, err := diff.UnifiedDiff(system, ".", "system", runtime, ".", "runtime", change.Path)
+			if err != nil {
+				return err
+			}
+			fmt.Print(unified)
+		}
+	}
+
+	if len(onlySystem) > 0 {
+		fmt.Println("\n📦 Only in system templates:")
+		for _, path := range onlySystem {
+			fmt.Printf("   %s\n", path)
+		}
+	}
+	if len(onlyRuntime) > 0 {
+		fmt.Println("\n⚙️  Only in runtime configuration:")
+		for _, path := range onlyRuntime {
+			fmt.Printf("   %s\n", path)
+		}
+	}
+	return nil
+}
+
+// runConfigDefaultsDiff prints the effective settings that differ from the
+// embedded defaults, red for the defaults and green for the current values
+func runConfigDefaultsDiff(manager *config.Manager) error {
+	defaults, err := manager.DefaultSettings()
+	if err != nil {
+		return err
+	}
+	settings, _, err := manager.EffectiveSettings()
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	for _, d := range config.DiffConfigs(defaults, settings) {
+		switch d.Op {
+		case config.DiffUnchanged:
+			if configDiffUnchanged {
+				fmt.Printf("  %s: %s\n", d.Path, config.FormatDiffValue(d.NewValue))
+			}
+			continue
+		case config.DiffRemoved:
+			fmt.Println(theme.Error(fmt.Sprintf("- %s: %s", d.Path, config.FormatDiffValue(d.OldValue))))
+		case config.DiffAdded:
+			fmt.Println(theme.Success(fmt.Sprintf("+ %s: %s", d.Path, config.FormatDiffValue(d.NewValue))))
+		case config.DiffChanged:
+			fmt.Println(theme.Error(fmt.Sprintf("- %s: %s", d.Path, config.FormatDiffValue(d.OldValue))))
+			fmt.Println(theme.Success(fmt.Sprintf("+ %s: %s", d.Path, config.FormatDiffValue(d.NewValue))))
+		}
+		changed++
+	}
+
+	if changed == 0 {
+		fmt.Println("✅ Effective settings match the embedded defaults")
+	}
 	return nil
 }
 
@@ -426,6 +733,23 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	manager := config.NewManager(projectPath)
 
 	if len(args) == 0 {
+		settings, sources, err := manager.EffectiveSettings()
+		if err != nil {
+			return err
+		}
+
+		if configShowJSON {
+			data, err := json.MarshalIndent(map[string]interface{}{
+				"settings": settings,
+				"sources":  sources,
+			}, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal configuration: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
 		// Show overview
 		fmt.Println("📋 Configuration Overview:")
 		fmt.Println("")
@@ -434,8 +758,11 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 		showDirStatus("System", manager.SystemPath)
 		showDirStatus("User", manager.UserPath)
 		showDirStatus("Runtime", manager.RuntimePath)
-		
-		return nil
+
+		fmt.Println("")
+		fmt.Println("⚙️  Effective settings:")
+		fmt.Println("")
+		return printConfigSources(settings, sources)
 	}
 
 	// Show specific file
@@ -457,6 +784,141 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	manager := config.NewManager(projectPath)
+
+	var report *config.ValidationReport
+	if configValidateFix {
+		report, err = manager.FixConfig()
+	} else {
+		report, err = manager.ValidateConfig()
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, statErr := os.Stat(report.File); os.IsNotExist(statErr) {
+		fmt.Printf("📝 No project configuration at %s (defaults apply)\n", report.File)
+		return nil
+	}
+
+	fmt.Printf("🔍 Validating %s\n", report.File)
+	fmt.Println("")
+
+	for _, violation := range report.Fixed {
+		fmt.Printf("🔧 fixed   %s\n", violation)
+	}
+	for _, violation := range report.Errors {
+		fmt.Printf("❌ error   %s\n", violation)
+	}
+	for _, violation := range report.Warnings {
+		hint := ""
+		if violation.Fixable {
+			hint = " (fixable with --fix)"
+		}
+		fmt.Printf("⚠️  warning %s%s\n", violation, hint)
+	}
+
+	if len(report.Fixed)+len(report.Errors)+len(report.Warnings) > 0 {
+		fmt.Println("")
+	}
+	fmt.Printf("📊 %d errors, %d warnings", len(report.Errors), len(report.Warnings))
+	if len(report.Fixed) > 0 {
+		fmt.Printf(", %d fixed", len(report.Fixed))
+	}
+	fmt.Println("")
+
+	if !report.Valid() {
+		return fmt.Errorf("configuration has %d errors", len(report.Errors))
+	}
+
+	fmt.Println("✅ Configuration is valid")
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	output, err := formatConfigValue(viper.Get(args[0]))
+	if err != nil {
+		return err
+	}
+	fmt.Println(output)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	key, value := args[0], config.ParseSettingValue(args[1])
+	manager := config.NewManager(projectPath)
+	if err := manager.SetSetting(key, value); err != nil {
+		return err
+	}
+
+	viper.Set(key, value)
+	if err := manager.WriteConfig(); err != nil {
+		return err
+	}
+
+	output, err := formatConfigValue(value)
+	if err != nil {
+		return err
+	}
+	path, err := manager.SettingsPath()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ %s = %s (%s)\n", key, output, path)
+	return nil
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) error {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	key := args[0]
+	manager := config.NewManager(projectPath)
+	removed, err := manager.UnsetSetting(key)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		path, err := manager.SettingsPath()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("📝 %s is not set in %s\n", key, path)
+		return nil
+	}
+
+	if err := manager.WriteConfig(); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Removed %s (default applies)\n", key)
+	return nil
+}
+
+// formatConfigValue prints strings as-is and any other value as JSON
+func formatConfigValue(value interface{}) (string, error) {
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to format value: %w", err)
+	}
+	return string(data), nil
+}
+
 func showDirStatus(name, path string) {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		fmt.Printf("   %s: ❌ Not found\n", name)
@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"claude-wm-cli/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// configProfileCmd groups the config profile subcommands
+var configProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage configuration profiles",
+	Long: `Manage configuration profiles for different environments (dev, ci, local).
+
+A profile is stored in .claude-wm/profiles/<name>/ and, while it is active:
+  - config.json is merged on top of .claude-wm/config.json
+  - settings.json, commands/ and hooks/ are merged by 'config sync' on top of
+    the system templates and user overrides
+The active profile is recorded in .claude-wm/.active-profile and can be
+overridden with $CLAUDE_WM_PROFILE. Without one, the implicit "default" profile
+uses the base configuration and user overrides alone.
+
+COMMANDS:
+  create <name>   Create an empty profile
+  use <name>      Make a profile active and re-sync the runtime configuration
+  list            List profiles and mark the active one
+  show            Show the merged configuration with the source of each value
+
+Examples:
+  claude-wm-cli config profile create client-a
+  claude-wm-cli config profile use client-a
+  claude-wm-cli config profile use default         # Back to the base configuration
+  claude-wm-cli config set claude.timeout 30m      # Written to the client-a profile
+  CLAUDE_WM_PROFILE=local claude-wm-cli config profile show`,
+}
+
+var configProfileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a configuration profile",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigProfileCreate,
+}
+
+var configProfileSwitchCmd = &cobra.Command{
+	Use:     "use <name>",
+	Aliases: []string{"switch"},
+	Short:   "Switch the active configuration profile",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runConfigProfileSwitch,
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configuration profiles",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigProfileList,
+}
+
+var configProfileShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective configuration with sources",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigProfileShow,
+}
+
+func init() {
+	configCmd.AddCommand(configProfileCmd)
+	configProfileCmd.AddCommand(configProfileCreateCmd)
+	configProfileCmd.AddCommand(configProfileSwitchCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
+	configProfileCmd.AddCommand(configProfileShowCmd)
+
+	registerDoctorCheck("Config profile", profileDoctorCheck)
+}
+
+// newProjectConfigManager creates a config manager for the current directory
+func newProjectConfigManager() (*config.Manager, error) {
+	projectPath, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current directory: %w", err)
+	}
+	return config.NewManager(projectPath), nil
+}
+
+func runConfigProfileCreate(cmd *cobra.Command, args []string) error {
+	manager, err := newProjectConfigManager()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	if err := manager.CreateProfile(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Created profile %s (%s)\n", name, manager.GetProfileConfigPath(name))
+	fmt.Printf("💡 Add settings.json, commands/ or hooks/ overrides to %s\n", manager.GetProfilePath(name))
+	fmt.Printf("💡 Activate it with: claude-wm-cli config profile use %s\n", name)
+	return nil
+}
+
+func runConfigProfileSwitch(cmd *cobra.Command, args []string) error {
+	manager, err := newProjectConfigManager()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	if err := manager.SwitchProfile(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Switched to profile %s\n", name)
+	if env := os.Getenv(config.ProfileEnvVar); env != "" && env != name {
+		fmt.Printf("⚠️  $%s=%s overrides the active profile in this shell\n", config.ProfileEnvVar, env)
+	}
+
+	// Regenerate the runtime configuration with the new profile
+	if _, err := os.Stat(manager.SystemPath); err == nil {
+		if err := manager.Sync(); err != nil {
+			return fmt.Errorf("sync failed: %w", err)
+		}
+		fmt.Println("🔄 Runtime configuration synced")
+	}
+	return nil
+}
+
+func runConfigProfileList(cmd *cobra.Command, args []string) error {
+	manager, err := newProjectConfigManager()
+	if err != nil {
+		return err
+	}
+
+	profiles, err := manager.ListProfiles()
+	if err != nil {
+		return err
+	}
+	active, _, err := manager.ActiveProfile()
+	if err != nil {
+		return err
+	}
+
+	if !manager.ProfileExists(config.DefaultProfile) {
+		profiles = append([]string{config.DefaultProfile}, profiles...)
+		if active == "" {
+			active = config.DefaultProfile
+		}
+	}
+
+	for _, name := range profiles {
+		marker := "  "
+		if name == active {
+			marker = "* "
+		}
+		fmt.Printf("%s%s\n", marker, name)
+	}
+	return nil
+}
+
+func runConfigProfileShow(cmd *cobra.Command, args []string) error {
+	manager, err := newProjectConfigManager()
+	if err != nil {
+		return err
+	}
+
+	active, origin, err := manager.ActiveProfile()
+	if err != nil {
+		return err
+	}
+	settings, sources, err := manager.LoadConfigWithSources()
+	if err != nil {
+		return err
+	}
+
+	if active == "" {
+		fmt.Println("📋 Effective configuration (no active profile)")
+	} else {
+		fmt.Printf("📋 Effective configuration (profile %s from %s)\n", active, origin)
+	}
+	fmt.Println()
+
+	if len(sources) == 0 {
+		fmt.Println("   (empty - defaults apply)")
+		return nil
+	}
+	return printConfigSources(settings, sources)
+}
+
+// printConfigSources prints each configuration value with the layer it comes from
+func printConfigSources(settings map[string]interface{}, sources map[string]config.ConfigSource) error {
+	keys := make([]string, 0, len(sources))
+	for key := range sources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tSOURCE\tVALUE")
+	for _, key := range keys {
+		value, err := formatConfigValue(lookupConfigValue(settings, key))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", key, sources[key], value)
+	}
+	return w.Flush()
+}
+
+// lookupConfigValue returns the value at a dotted key in a nested config map
+func lookupConfigValue(settings map[string]interface{}, key string) interface{} {
+	var current interface{} = settings
+	for _, part := range strings.Split(key, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[part]
+	}
+	return current
+}
+
+// profileDoctorCheck reports the active config profile
+func profileDoctorCheck() doctorResult {
+	manager, err := newProjectConfigManager()
+	if err != nil {
+		return doctorResult{Summary: err.Error()}
+	}
+
+	active, origin, err := manager.ActiveProfile()
+	if err != nil {
+		return doctorResult{Summary: err.Error()}
+	}
+	if active == "" {
+		return doctorResult{OK: true, Summary: "none (base configuration only)"}
+	}
+	if !manager.ProfileExists(active) {
+		return doctorResult{
+			Summary: fmt.Sprintf("active profile %q does not exist", active),
+			Details: []string{
+				"selected by " + origin,
+				fmt.Sprintf("create it with: claude-wm-cli config profile create %s", active),
+			},
+		}
+	}
+	return doctorResult{OK: true, Summary: fmt.Sprintf("%s (from %s)", active, origin)}
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"claude-wm-cli/internal/navigation"
+
+	"github.com/spf13/cobra"
+)
+
+// contextCmd groups the project context subcommands
+var contextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Inspect how the project state is detected",
+	Long: `Inspect how the workflow state of the project is detected.
+
+By default the state comes from the docs/ layout (epics.json, current story
+and task files). Teams with another layout can describe it in
+.claude-wm/context-rules.json, a list of rules evaluated in order, the first
+matching one giving the state:
+
+  [
+    {"state": "task_in_progress", "required_files": ["work/current-task.md"]},
+    {"state": "has_epics", "min_file_count": {"work/epics": 1}},
+    {"state": "project_initialized", "required_files": ["work"],
+     "forbidden_files": ["work/*.lock"]}
+  ]
+
+Paths are relative to the project root and may be glob patterns. States are
+not_initialized, project_initialized, has_epics, epic_in_progress,
+story_in_progress and task_in_progress. When no rule matches, the built-in
+detection is used.
+
+Examples:
+  claude-wm-cli context rules list`,
+}
+
+// contextRulesCmd groups the context rules subcommands
+var contextRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Inspect the custom project state rules",
+}
+
+var contextRulesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the context rules and whether they match the current directory",
+	Long: `List the rules of .claude-wm/context-rules.json in priority order, marking
+those matching the current directory and the one giving the project state.
+A rule that doesn't match shows its first failing condition.
+
+Examples:
+  claude-wm-cli context rules list`,
+	Args: cobra.NoArgs,
+	RunE: runContextRulesList,
+}
+
+func init() {
+	rootCmd.AddCommand(contextCmd)
+	contextCmd.AddCommand(contextRulesCmd)
+	contextRulesCmd.AddCommand(contextRulesListCmd)
+}
+
+func runContextRulesList(cmd *cobra.Command, args []string) error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	rules, err := navigation.LoadContextRules(workDir)
+	if err != nil {
+		return err
+	}
+	return printContextRules(os.Stdout, rules, workDir)
+}
+
+// printContextRules writes the rules with a match indicator for workDir, the
+// first matching rule marked as active
+func printContextRules(out io.Writer, rules []navigation.ContextRule, workDir string) error {
+	if len(rules) == 0 {
+		fmt.Fprintf(out, "No custom context rules: the state is detected from the docs/ layout.\n")
+		fmt.Fprintf(out, "💡 Add rules to %s to describe another layout.\n", navigation.ContextRulesFile)
+		return nil
+	}
+
+	fmt.Fprintf(out, "📐 Context rules (%s)\n\n", navigation.ContextRulesFile)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tSTATE\tCONDITIONS\tMATCH")
+
+	active := false
+	for i, rule := range rules {
+		matched, reason, err := rule.Match(workDir)
+		if err != nil {
+			return fmt.Errorf("rule %d: %w", i+1, err)
+		}
+
+		match := "❌ " + reason
+		if matched {
+			match = "✅"
+			if !active {
+				match += " active"
+				active = true
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", i+1, rule.State, describeContextRule(rule), match)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !active {
+		fmt.Fprintf(out, "\nNo rule matches: the state is detected from the docs/ layout.\n")
+	}
+	return nil
+}
+
+// describeContextRule summarizes the conditions of rule
+func describeContextRule(rule navigation.ContextRule) string {
+	var conditions []string
+	for _, file := range rule.RequiredFiles {
+		conditions = append(conditions, "+"+file)
+	}
+	for _, file := range rule.ForbiddenFiles {
+		conditions = append(conditions, "-"+file)
+	}
+	patterns := make([]string, 0, len(rule.MinFileCount))
+	for pattern := range rule.MinFileCount {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		conditions = append(conditions, fmt.Sprintf("%s>=%d", pattern, rule.MinFileCount[pattern]))
+	}
+	if len(conditions) == 0 {
+		return "(always)"
+	}
+	return strings.Join(conditions, " ")
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-wm-cli/internal/navigation"
+)
+
+func TestPrintContextRules(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "work", "epics"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "work", "epics", "one.md"), []byte("x"), 0644))
+
+	rules := []navigation.ContextRule{
+		{State: navigation.StateTaskInProgress, RequiredFiles: []string{"work/current-task.md"}},
+		{State: navigation.StateHasEpics, MinFileCount: map[string]int{"work/epics": 1}},
+		{State: navigation.StateProjectInitialized},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, printContextRules(&out, rules, dir))
+	lines := bytes.Split(out.Bytes(), []byte("\n"))
+	require.GreaterOrEqual(t, len(lines), 6)
+	assert.Contains(t, string(lines[3]), "Task In Progress")
+	assert.Contains(t, string(lines[3]), "❌ missing work/current-task.md")
+	assert.Contains(t, string(lines[4]), "✅ active")
+	assert.Contains(t, string(lines[5]), "(always)")
+	assert.NotContains(t, string(lines[5]), "active")
+
+	out.Reset()
+	require.NoError(t, printContextRules(&out, nil, dir))
+	assert.Contains(t, out.String(), "No custom context rules")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+
+	clierrors "claude-wm-cli/internal/errors"
+
+	"github.com/spf13/cobra"
+)
+
+// doctorResult is the outcome of a single doctor check
+type doctorResult struct {
+	OK      bool
+	Summary string
+	Details []string
+}
+
+// doctorCheck is a diagnostic run by the doctor command
+type doctorCheck struct {
+	Name string
+	Run  func() doctorResult
+}
+
+// doctorChecks holds the checks registered by other commands
+var doctorChecks []doctorCheck
+
+// registerDoctorCheck adds a check to the doctor command.
+// Commands call it from their init() to contribute a diagnostic.
+func registerDoctorCheck(name string, run func() doctorResult) {
+	doctorChecks = append(doctorChecks, doctorCheck{Name: name, Run: run})
+}
+
+// doctorCmd runs all registered health checks
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the project setup",
+	Long: `Run health checks on the current project and report problems.
+
+Each check prints ✅ when healthy or ❌ with details when it needs attention.
+The command exits with code 1 if any check fails.
+
+Examples:
+  claude-wm-cli doctor    # Run all checks`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+
+		if !runDoctor() {
+			return clierrors.NewCLIError("Doctor checks failed", clierrors.ExitGeneric).
+				WithSuggestion("Fix the problems listed under the failed checks, then run 'claude-wm-cli doctor' again")
+		}
+		return nil
+	},
+}
+
+// runDoctor runs every registered check and returns true if all passed
+func runDoctor() bool {
+	fmt.Println("🩺 Running project health checks")
+	fmt.Println()
+
+	failed := 0
+	for _, check := range doctorChecks {
+		result := check.Run()
+		icon := "✅"
+		if !result.OK {
+			icon = "❌"
+			failed++
+		}
+
+		fmt.Printf("%s %s: %s\n", icon, check.Name, result.Summary)
+		for _, detail := range result.Details {
+			fmt.Printf("   • %s\n", detail)
+		}
+	}
+
+	fmt.Println()
+	if failed > 0 {
+		fmt.Printf("⚠️  %d of %d checks failed\n", failed, len(doctorChecks))
+		return false
+	}
+
+	fmt.Printf("✅ All %d checks passed\n", len(doctorChecks))
+	return true
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	clierrors "claude-wm-cli/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctorCmd(t *testing.T) {
+	original := doctorChecks
+	t.Cleanup(func() { doctorChecks = original })
+
+	doctorChecks = []doctorCheck{{Name: "healthy", Run: func() doctorResult { return doctorResult{OK: true, Summary: "ok"} }}}
+	require.NoError(t, doctorCmd.RunE(doctorCmd, nil))
+
+	doctorChecks = append(doctorChecks, doctorCheck{Name: "broken", Run: func() doctorResult { return doctorResult{Summary: "broken"} }})
+	err := doctorCmd.RunE(doctorCmd, nil)
+	var cliErr *clierrors.CLIError
+	require.ErrorAs(t, err, &cliErr)
+	assert.Equal(t, clierrors.ExitGeneric, cliErr.Code)
+	assert.NotEmpty(t, cliErr.Suggestion)
+}
@@ -4,17 +4,25 @@ Copyright © 2025 Claude WM CLI Team
 package cmd
 
 import (
+	"bufio"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"claude-wm-cli/internal/debug"
 	"claude-wm-cli/internal/epic"
-	"claude-wm-cli/internal/executor"
+	clierrors "claude-wm-cli/internal/errors"
+	"claude-wm-cli/internal/story"
+	"claude-wm-cli/internal/theme"
 	"claude-wm-cli/internal/validation"
 
 	"github.com/spf13/cobra"
@@ -37,6 +45,8 @@ Available subcommands:
   update   Update an existing epic
   select   Set an epic as the current active epic
   show     Display detailed information about an epic
+  burndown Show the day-by-day remaining story points
+  delete   Delete an epic
 
 Examples:
   claude-wm-cli epic create "User Authentication" --priority high
@@ -52,15 +62,17 @@ var epicCreateCmd = &cobra.Command{
 	Long: `Create a new epic with the specified title and optional parameters.
 
 The epic will be created with a unique ID and stored in the project's epic
-collection. You can specify priority, description, duration, and tags.
+collection. You can specify priority, description, duration, tags, and the
+epics it depends on. Dependencies must exist and may not form a cycle.
 
 Examples:
   claude-wm-cli epic create "User Authentication System"
   claude-wm-cli epic create "API Integration" --priority high --description "Integrate with external APIs"
-  claude-wm-cli epic create "UI Redesign" --priority medium --duration "2 weeks" --tags ui,design`,
+  claude-wm-cli epic create "UI Redesign" --priority medium --duration "2 weeks" --tags ui,design
+  claude-wm-cli epic create "Billing" --depends-on EPIC-001-USER-AUTH,EPIC-002-API`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		createEpic(args[0], cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return createEpic(args[0], cmd)
 	},
 }
 
@@ -70,19 +82,26 @@ var epicListCmd = &cobra.Command{
 	Short: "List all epics with their status",
 	Long: `List all epics in the project with their current status and progress.
 
-You can filter the list by status or priority to focus on specific epics.
+Completed, cancelled and archived epics are hidden unless --all is given.
+You can filter the list by status, priority or tags to focus on specific epics.
+--tag keeps epics having all the given tags, --tag-any epics having at least one.
 The list shows epic ID, title, status, priority, and completion percentage.
 
 Examples:
   claude-wm-cli epic list                    # List all epics
   claude-wm-cli epic list --status planned  # List only planned epics
   claude-wm-cli epic list --priority high   # List only high priority epics
-  claude-wm-cli epic list --all             # Show all epics including completed`,
-	Run: func(cmd *cobra.Command, args []string) {
+  claude-wm-cli epic list --tag security    # List only epics tagged security
+  claude-wm-cli epic list --tag api --tag security       # Tagged api and security
+  claude-wm-cli epic list --tag-any frontend,backend     # Tagged frontend or backend
+  claude-wm-cli epic list --all             # Show all epics including completed and archived`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 		
-		listEpics(cmd)
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return listEpics(cmd)
 	},
 }
 
@@ -91,7 +110,7 @@ var epicUpdateCmd = &cobra.Command{
 	Use:   "update <epic-id>",
 	Short: "Update an existing epic",
 	Long: `Update the properties of an existing epic such as title, description,
-priority, status, duration, or tags.
+priority, status, duration, tags, or dependencies.
 
 You can update multiple properties in a single command. The epic's updated
 timestamp will be automatically set.
@@ -99,10 +118,12 @@ timestamp will be automatically set.
 Examples:
   claude-wm-cli epic update EPIC-001 --status in_progress
   claude-wm-cli epic update EPIC-001 --title "New Title" --priority critical
-  claude-wm-cli epic update EPIC-001 --description "Updated description" --duration "3 weeks"`,
+  claude-wm-cli epic update EPIC-001 --description "Updated description" --duration "3 weeks"
+  claude-wm-cli epic update EPIC-003 --depends-on EPIC-001,EPIC-002
+  claude-wm-cli epic update EPIC-003 --depends-on ""   # Remove all dependencies`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		updateEpic(args[0], cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateEpic(args[0], cmd)
 	},
 }
 
@@ -114,13 +135,16 @@ var epicSelectCmd = &cobra.Command{
 
 This will make the epic the focus of your workflow and automatically start
 it if it's in planned status. Only one epic can be active at a time.
+A warning is shown when the epics it depends on are not completed yet.
 
 Examples:
   claude-wm-cli epic select EPIC-001-USER-AUTH
   claude-wm-cli epic select EPIC-002`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		selectEpic(args[0])
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return selectEpic(args[0])
 	},
 }
 
@@ -129,14 +153,84 @@ var epicShowCmd = &cobra.Command{
 	Use:   "show <epic-id>",
 	Short: "Display detailed information about an epic",
 	Long: `Display detailed information about a specific epic including all
-properties, user stories, progress metrics, and timestamps.
+properties, dependencies with their status, user stories, progress metrics,
+and timestamps.
 
 Examples:
   claude-wm-cli epic show EPIC-001
   claude-wm-cli epic show EPIC-001-USER-AUTH`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		showEpic(args[0])
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showEpic(args[0])
+	},
+}
+
+// epicDeleteCmd represents the epic delete command
+var epicDeleteCmd = &cobra.Command{
+	Use:   "delete <epic-id>",
+	Short: "Delete an epic",
+	Long: `Delete an epic from the project's epic collection.
+
+An epic with incomplete user stories (neither completed nor cancelled) is
+only deleted with --force, and an epic other epics depend on is never
+deleted: remove it from their dependencies first. If the epic is the current
+epic, the current epic is cleared.
+
+Before deletion, the epic and its user stories can be archived to the project
+backups (.backups), from which 'claude-wm-cli backup' can restore them. You
+are asked unless --archive or --no-archive is given.
+
+Examples:
+  claude-wm-cli epic delete EPIC-001
+  claude-wm-cli epic delete EPIC-001 --force --archive
+  claude-wm-cli epic delete EPIC-001 --no-archive`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return deleteEpic(args[0])
+	},
+}
+
+// epicArchiveCmd represents the epic archive command
+var epicArchiveCmd = &cobra.Command{
+	Use:   "archive <epic-id>",
+	Short: "Archive a completed or cancelled epic",
+	Long: `Archive a completed or cancelled epic, with its stories, to hide it from
+'epic list'. Unlike delete, nothing is lost: archived epics stay in epics.json,
+'epic show' still displays them, 'epic list --all' lists them tagged [ARCHIVED]
+and 'epic unarchive' brings them back.
+
+epics.json and stories.json are backed up first.
+
+Examples:
+  claude-wm-cli epic archive EPIC-001-USER-AUTH
+  claude-wm-cli epic list --all              # Archived epics included`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return archiveEpic(args[0])
+	},
+}
+
+// epicUnarchiveCmd represents the epic unarchive command
+var epicUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <epic-id>",
+	Short: "Restore an archived epic",
+	Long: `Restore an archived epic, with its stories, to 'epic list'.
+
+epics.json and stories.json are backed up first.
+
+Examples:
+  claude-wm-cli epic unarchive EPIC-001-USER-AUTH`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return unarchiveEpic(args[0])
 	},
 }
 
@@ -151,8 +245,10 @@ Examples:
   claude-wm-cli epic history EPIC-001
   claude-wm-cli epic history EPIC-001-USER-AUTH`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		showEpicHistory(args[0])
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showEpicHistory(args[0])
 	},
 }
 
@@ -163,12 +259,57 @@ var epicMetricsCmd = &cobra.Command{
 	Long: `Display advanced metrics for an epic including duration analytics,
 velocity, estimated completion, and state transition analysis.
 
+By default the completion is estimated from the progress since the epic
+started. --velocity-window (default from the epic.velocity_window config key)
+only counts the stories completed recently, so that a burst of early activity
+does not skew late-stage estimates. When fewer than 2 stories were completed
+within the window, the estimate falls back to the progress since the epic
+started and says so.
+
+The story cycle time is the time from a story going in progress to its
+completion; its average, minimum, maximum and standard deviation, and the
+stories completed per week, need at least 2 completed stories. Use --compare
+to show these metrics next to another epic's.
+
 Examples:
   claude-wm-cli epic metrics EPIC-001
-  claude-wm-cli epic metrics EPIC-001-USER-AUTH`,
+  claude-wm-cli epic metrics EPIC-001-USER-AUTH
+  claude-wm-cli epic metrics EPIC-001 --velocity-window 14d
+  claude-wm-cli epic metrics EPIC-002 --compare EPIC-001`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showEpicMetrics(args[0])
+	},
+}
+
+// epicBurndownCmd represents the epic burndown command
+var epicBurndownCmd = &cobra.Command{
+	Use:   "burndown <epic-id>",
+	Short: "Show the day-by-day burndown of an epic",
+	Long: `Display the story points remaining in an epic at the end of each day,
+from the day the epic started to today (or its completion date).
+
+Completion days come from the stories' completion timestamps. Epics whose
+stories have no points are burned down by story count. The IDEAL column
+goes down to zero at the estimated completion of 'epic metrics', showing
+whether the epic is on track.
+
+Use --format csv to plot the burndown with an external tool.
+
+Examples:
+  claude-wm-cli epic burndown EPIC-001
+  claude-wm-cli epic burndown EPIC-001 --format csv > burndown.csv`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		showEpicMetrics(args[0])
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if burndownFormat != "table" && burndownFormat != "csv" {
+			return clierrors.ErrInvalidInput("format", burndownFormat, fmt.Sprintf("'%s' is not one of table, csv", burndownFormat))
+		}
+
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showEpicBurndown(args[0])
 	},
 }
 
@@ -182,17 +323,24 @@ velocity metrics for all epics in the project.
 The dashboard provides:
 - Overall project progress summary
 - Individual epic progress with visual progress bars
+- A weekly burndown chart of each epic in progress, flagged when behind schedule
 - Risk assessment and alerts for high-risk epics
+- Epics blocked by uncompleted dependencies
 - Velocity tracking and timeline analysis
 - Recommendations for improving epic delivery
 
+Use --json to export the same data, e.g. for other tools or a web UI.
+
 Examples:
-  claude-wm-cli epic dashboard`,
-	Run: func(cmd *cobra.Command, args []string) {
+  claude-wm-cli epic dashboard
+  claude-wm-cli epic dashboard --json > dashboard.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 		
-		showEpicDashboard()
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showEpicDashboard()
 	},
 }
 
@@ -202,10 +350,24 @@ var (
 	epicDescription string
 	epicDuration    string
 	epicTags        []string
+	epicDependsOn   []string
 	epicStatus      string
 	listStatus      string
 	listPriority    string
 	listAll         bool
+	listTags        []string
+	listAnyTags     []string
+
+	epicDeleteForce     bool
+	epicDeleteArchive   bool
+	epicDeleteNoArchive bool
+
+	burndownFormat string
+
+	metricsVelocityWindow string
+	metricsCompare        string
+
+	dashboardJSON bool
 )
 
 func init() {
@@ -217,8 +379,12 @@ func init() {
 	epicCmd.AddCommand(epicUpdateCmd)
 	epicCmd.AddCommand(epicSelectCmd)
 	epicCmd.AddCommand(epicShowCmd)
+	epicCmd.AddCommand(epicDeleteCmd)
+	epicCmd.AddCommand(epicArchiveCmd)
+	epicCmd.AddCommand(epicUnarchiveCmd)
 	epicCmd.AddCommand(epicHistoryCmd)
 	epicCmd.AddCommand(epicMetricsCmd)
+	epicCmd.AddCommand(epicBurndownCmd)
 	epicCmd.AddCommand(epicDashboardCmd)
 
 	// epic create flags
@@ -226,29 +392,50 @@ func init() {
 	epicCreateCmd.Flags().StringVarP(&epicDescription, "description", "d", "", "Epic description")
 	epicCreateCmd.Flags().StringVar(&epicDuration, "duration", "", "Estimated duration (e.g., '2 weeks', '1 month')")
 	epicCreateCmd.Flags().StringSliceVarP(&epicTags, "tags", "t", []string{}, "Epic tags (comma-separated)")
+	epicCreateCmd.Flags().StringSliceVar(&epicDependsOn, "depends-on", []string{}, "IDs of the epics this epic depends on (comma-separated)")
 
 	// epic list flags
 	epicListCmd.Flags().StringVar(&listStatus, "status", "", "Filter by status (planned, in_progress, on_hold, completed, cancelled)")
 	epicListCmd.Flags().StringVar(&listPriority, "priority", "", "Filter by priority (low, medium, high, critical)")
-	epicListCmd.Flags().BoolVar(&listAll, "all", false, "Show all epics including completed and cancelled")
+	epicListCmd.Flags().BoolVar(&listAll, "all", false, "Show all epics including completed, cancelled and archived")
+	epicListCmd.Flags().StringSliceVar(&listTags, "tag", []string{}, "Filter by tag, repeatable (epics must have all tags)")
+	epicListCmd.Flags().StringSliceVar(&listAnyTags, "tag-any", []string{}, "Filter by tags (epics must have at least one tag)")
+	addClaudeExecutionFlags(epicListCmd.Flags())
 
 	// epic update flags
 	epicUpdateCmd.Flags().StringVar(&epicPriority, "priority", "", "Update epic priority")
 	epicUpdateCmd.Flags().StringVar(&epicDescription, "description", "", "Update epic description")
 	epicUpdateCmd.Flags().StringVar(&epicDuration, "duration", "", "Update estimated duration")
 	epicUpdateCmd.Flags().StringSliceVar(&epicTags, "tags", []string{}, "Update epic tags")
+	epicUpdateCmd.Flags().StringSliceVar(&epicDependsOn, "depends-on", []string{}, "Replace the epic dependencies (empty to clear)")
 	epicUpdateCmd.Flags().StringVar(&epicStatus, "status", "", "Update epic status")
 	epicUpdateCmd.Flags().StringVar(&epicTitle, "title", "", "Update epic title")
+
+	// epic metrics flags
+	epicMetricsCmd.Flags().StringVar(&metricsVelocityWindow, "velocity-window", "", "Only count stories completed within this window for velocity, e.g. 14d or 2w (default from epic.velocity_window)")
+	epicMetricsCmd.Flags().StringVar(&metricsCompare, "compare", "", "Compare the metrics side by side with another epic")
+	epicMetricsCmd.RegisterFlagCompletionFunc("compare", completeEpicIDFlag)
+
+	// epic burndown flags
+	epicBurndownCmd.Flags().StringVar(&burndownFormat, "format", "table", "Output format (table, csv)")
+
+	// epic delete flags
+	epicDeleteCmd.Flags().BoolVar(&epicDeleteForce, "force", false, "Delete even if the epic has incomplete user stories")
+	epicDeleteCmd.Flags().BoolVar(&epicDeleteArchive, "archive", false, "Archive the epic to the backups without asking")
+	epicDeleteCmd.Flags().BoolVar(&epicDeleteNoArchive, "no-archive", false, "Delete without archiving the epic")
+	epicDeleteCmd.MarkFlagsMutuallyExclusive("archive", "no-archive")
+
+	// epic dashboard flags
+	epicDashboardCmd.Flags().BoolVar(&dashboardJSON, "json", false, "Output the dashboard data as JSON")
 }
 
 var epicTitle string
 
-func createEpic(title string, _ *cobra.Command) {
+func createEpic(title string, cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create epic manager
@@ -259,11 +446,13 @@ func createEpic(title string, _ *cobra.Command) {
 	if epicPriority != "" {
 		priority = epic.Priority(epicPriority)
 		if !priority.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid priority '%s'. Valid values: low, medium, high, critical\n", epicPriority)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("priority", epicPriority, fmt.Sprintf("'%s' is not one of low, medium, high, critical", epicPriority))
 		}
 	}
 
+	// Failures past this point are not usage errors
+	cmd.SilenceUsage = true
+
 	// Create epic options
 	options := epic.EpicCreateOptions{
 		Title:        title,
@@ -271,14 +460,13 @@ func createEpic(title string, _ *cobra.Command) {
 		Priority:     priority,
 		Duration:     epicDuration,
 		Tags:         epicTags,
-		Dependencies: []string{}, // TODO: Add dependencies support in future
+		Dependencies: epicDependsOn,
 	}
 
 	// Create the epic
 	newEpic, err := manager.CreateEpic(options)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to create epic: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to create epic")
 	}
 
 	// Display success message
@@ -297,31 +485,33 @@ func createEpic(title string, _ *cobra.Command) {
 	if len(newEpic.Tags) > 0 {
 		fmt.Printf("   Tags:        %s\n", strings.Join(newEpic.Tags, ", "))
 	}
+	if len(newEpic.Dependencies) > 0 {
+		fmt.Printf("   Depends on:  %s\n", strings.Join(newEpic.Dependencies, ", "))
+	}
 	fmt.Printf("   Created:     %s\n", newEpic.CreatedAt.Format("2006-01-02 15:04:05"))
 
 	fmt.Printf("\n💡 Next steps:\n")
 	fmt.Printf("   • Select this epic:  claude-wm-cli epic select %s\n", newEpic.ID)
 	fmt.Printf("   • List all epics:    claude-wm-cli epic list\n")
 	fmt.Printf("   • Update this epic:  claude-wm-cli epic update %s --status in_progress\n", newEpic.ID)
+	return nil
 }
 
-func listEpics(_ *cobra.Command) {
+func listEpics(_ *cobra.Command) error {
 	// Validate JSON files before proceeding
 	validator := validation.NewJSONValidator()
 	if err := validator.ValidateSpecificJSON("epics"); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ JSON validation failed: %v\n", err)
-		os.Exit(1)
+		return clierrors.ErrValidationFailed(fmt.Sprintf("JSON validation failed: %v", err))
 	}
 
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create Claude executor for enhanced epic listing
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
@@ -335,18 +525,17 @@ func listEpics(_ *cobra.Command) {
 	}
 
 	// Read and display epics from epics.json file
-	if err := displayEpicsFromFile(wd, listStatus, listPriority, listAll); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to display epics: %v\n", err)
-		os.Exit(1)
+	if err := displayEpicsFromFile(wd, listStatus, listPriority, listAll, listTags, listAnyTags); err != nil {
+		return clierrors.Wrap(err, "Failed to display epics")
 	}
+	return nil
 }
 
-func updateEpic(epicID string, _ *cobra.Command) {
+func updateEpic(epicID string, cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create epic manager
@@ -366,8 +555,7 @@ func updateEpic(epicID string, _ *cobra.Command) {
 	if epicPriority != "" {
 		priority := epic.Priority(epicPriority)
 		if !priority.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid priority '%s'. Valid values: low, medium, high, critical\n", epicPriority)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("priority", epicPriority, fmt.Sprintf("'%s' is not one of low, medium, high, critical", epicPriority))
 		}
 		options.Priority = &priority
 	}
@@ -375,8 +563,7 @@ func updateEpic(epicID string, _ *cobra.Command) {
 	if epicStatus != "" {
 		status := epic.Status(epicStatus)
 		if !status.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid status '%s'. Valid values: planned, in_progress, on_hold, completed, cancelled\n", epicStatus)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("status", epicStatus, fmt.Sprintf("'%s' is not one of planned, in_progress, on_hold, completed, cancelled", epicStatus))
 		}
 		options.Status = &status
 	}
@@ -389,18 +576,25 @@ func updateEpic(epicID string, _ *cobra.Command) {
 		options.Tags = &epicTags
 	}
 
+	if cmd.Flags().Changed("depends-on") {
+		options.Dependencies = &epicDependsOn
+	}
+
 	// Check if any updates were specified
 	if options.Title == nil && options.Description == nil && options.Priority == nil &&
-		options.Status == nil && options.Duration == nil && options.Tags == nil {
-		fmt.Fprintf(os.Stderr, "Error: No updates specified. Use flags like --title, --status, --priority, etc.\n")
-		os.Exit(1)
+		options.Status == nil && options.Duration == nil && options.Tags == nil &&
+		options.Dependencies == nil {
+		return clierrors.NewCLIError("No updates specified", clierrors.ExitUsage).
+			WithSuggestion("Use flags like --title, --status, --priority, etc.")
 	}
 
+	// Failures past this point are not usage errors
+	cmd.SilenceUsage = true
+
 	// Update the epic
 	updatedEpic, err := manager.UpdateEpic(epicID, options)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to update epic: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to update epic")
 	}
 
 	// Display success message
@@ -419,15 +613,18 @@ func updateEpic(epicID string, _ *cobra.Command) {
 	if len(updatedEpic.Tags) > 0 {
 		fmt.Printf("   Tags:        %s\n", strings.Join(updatedEpic.Tags, ", "))
 	}
+	if len(updatedEpic.Dependencies) > 0 {
+		fmt.Printf("   Depends on:  %s\n", strings.Join(updatedEpic.Dependencies, ", "))
+	}
 	fmt.Printf("   Updated:     %s\n", updatedEpic.UpdatedAt.Format("2006-01-02 15:04:05"))
+	return nil
 }
 
-func selectEpic(epicID string) {
+func selectEpic(epicID string) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create epic manager
@@ -436,8 +633,7 @@ func selectEpic(epicID string) {
 	// Select the epic
 	selectedEpic, err := manager.SelectEpic(epicID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to select epic: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to select epic")
 	}
 
 	// Display success message
@@ -453,20 +649,28 @@ func selectEpic(epicID string) {
 	}
 	fmt.Printf("\n")
 
+	// Warn about prerequisites that are not completed yet
+	if unmet, err := manager.GetUnmetDependencies(selectedEpic.ID); err == nil && len(unmet) > 0 {
+		fmt.Printf("\n⚠️  Prerequisites not completed yet:\n")
+		for _, dependency := range unmet {
+			fmt.Printf("   %s %s - %s (%s)\n", getEpicStatusIcon(dependency.Status), dependency.ID, dependency.Title, dependency.Status)
+		}
+	}
+
 	fmt.Printf("\n💡 Next steps:\n")
 	fmt.Printf("   • View epic details: claude-wm-cli epic show %s\n", selectedEpic.ID)
 	fmt.Printf("   • Check status:      claude-wm-cli status\n")
 	if selectedEpic.Status == epic.StatusInProgress {
 		fmt.Printf("   • Create stories:    claude-wm-cli story create\n")
 	}
+	return nil
 }
 
-func showEpic(epicID string) {
+func showEpic(epicID string) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create epic manager
@@ -475,8 +679,7 @@ func showEpic(epicID string) {
 	// Get the epic
 	ep, err := manager.GetEpic(epicID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get epic: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get epic")
 	}
 
 	// Check if it's the current epic
@@ -487,6 +690,10 @@ func showEpic(epicID string) {
 	fmt.Printf("📋 Epic Details\n")
 	fmt.Printf("===============\n\n")
 
+	if ep.ArchivedAt != nil {
+		fmt.Printf("📦 ARCHIVED on %s - restore with: claude-wm-cli epic unarchive %s\n\n", ep.ArchivedAt.Format("2006-01-02 15:04:05"), ep.ID)
+	}
+
 	fmt.Printf("🆔 ID:          %s", ep.ID)
 	if isCurrent {
 		fmt.Printf(" (CURRENT)")
@@ -509,8 +716,22 @@ func showEpic(epicID string) {
 		fmt.Printf("🏷️  Tags:        %s\n", strings.Join(ep.Tags, ", "))
 	}
 
+	// Dependencies section
 	if len(ep.Dependencies) > 0 {
-		fmt.Printf("🔗 Dependencies: %s\n", strings.Join(ep.Dependencies, ", "))
+		dependencies, _ := manager.GetDependencies(ep.ID)
+		found := make(map[string]*epic.Epic)
+		for _, dependency := range dependencies {
+			found[dependency.ID] = dependency
+		}
+
+		fmt.Printf("\n🔗 Dependencies (%d):\n", len(ep.Dependencies))
+		for _, id := range ep.Dependencies {
+			if dependency, ok := found[id]; ok {
+				fmt.Printf("   %s %s - %s (%s)\n", getEpicStatusIcon(dependency.Status), dependency.ID, dependency.Title, dependency.Status)
+			} else {
+				fmt.Printf("   ❓ %s (not found)\n", id)
+			}
+		}
 	}
 
 	// Progress section
@@ -547,6 +768,10 @@ func showEpic(epicID string) {
 	}
 
 	// Next actions
+	if ep.ArchivedAt != nil {
+		return nil
+	}
+
 	fmt.Printf("\n💡 Available Actions:\n")
 	if !isCurrent && (ep.Status == epic.StatusPlanned || ep.Status == epic.StatusInProgress) {
 		fmt.Printf("   • Select this epic:  claude-wm-cli epic select %s\n", ep.ID)
@@ -556,6 +781,96 @@ func showEpic(epicID string) {
 	if isCurrent && ep.Status == epic.StatusInProgress {
 		fmt.Printf("   • Create stories:    claude-wm-cli story create\n")
 	}
+	return nil
+}
+
+func archiveEpic(epicID string) error {
+	// Get current working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to get working directory")
+	}
+
+	if err := epic.NewManager(wd).ArchiveEpic(epicID); err != nil {
+		return clierrors.Wrap(err, "Failed to archive epic")
+	}
+	fmt.Printf("📦 Epic %s archived\n", epicID)
+
+	archived, err := story.NewGenerator(wd).ArchiveStoriesForEpic(epicID)
+	if err != nil {
+		return clierrors.Wrap(err, "Epic archived but failed to archive its stories")
+	}
+	if archived > 0 {
+		fmt.Printf("📦 %d stories archived\n", archived)
+	}
+
+	fmt.Printf("\n💡 Next steps:\n")
+	fmt.Printf("   • View archived epics: claude-wm-cli epic list --all\n")
+	fmt.Printf("   • Restore this epic:   claude-wm-cli epic unarchive %s\n", epicID)
+	return nil
+}
+
+func unarchiveEpic(epicID string) error {
+	// Get current working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to get working directory")
+	}
+
+	if err := epic.NewManager(wd).UnarchiveEpic(epicID); err != nil {
+		return clierrors.Wrap(err, "Failed to unarchive epic")
+	}
+	fmt.Printf("✅ Epic %s restored\n", epicID)
+
+	restored, err := story.NewGenerator(wd).UnarchiveStoriesForEpic(epicID)
+	if err != nil {
+		return clierrors.Wrap(err, "Epic restored but failed to restore its stories")
+	}
+	if restored > 0 {
+		fmt.Printf("✅ %d stories restored\n", restored)
+	}
+	return nil
+}
+
+func deleteEpic(epicID string) error {
+	// Get current working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to get working directory")
+	}
+
+	// Create epic manager
+	manager := epic.NewManager(wd)
+	options := epic.EpicDeleteOptions{Force: epicDeleteForce}
+
+	// Check the epic can be deleted before offering to archive it
+	if err := manager.CanDeleteEpic(epicID, options); err != nil {
+		return clierrors.Wrap(err, "Cannot delete epic")
+	}
+
+	archive := epicDeleteArchive
+	if !epicDeleteArchive && !epicDeleteNoArchive {
+		fmt.Printf("Archive epic %s before deleting it? [Y/n]: ", epicID)
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		archive = response == "" || response == "y" || response == "yes"
+	}
+
+	if archive {
+		archived, err := manager.BackupEpic(epicID)
+		if err != nil {
+			return clierrors.Wrap(err, "Failed to archive epic")
+		}
+		fmt.Printf("📦 Epic archived as backup %s\n", archived.ID)
+	}
+
+	if err := manager.DeleteEpicWithOptions(epicID, options); err != nil {
+		return clierrors.Wrap(err, "Failed to delete epic")
+	}
+
+	fmt.Printf("✅ Epic %s deleted\n", epicID)
+	return nil
 }
 
 // Helper functions
@@ -563,32 +878,32 @@ func showEpic(epicID string) {
 func getEpicStatusIcon(status epic.Status) string {
 	switch status {
 	case epic.StatusPlanned:
-		return "📋"
+		return theme.Icon("📋", "[planned]")
 	case epic.StatusInProgress:
-		return "🚧"
+		return theme.Icon("🚧", "[wip]")
 	case epic.StatusOnHold:
-		return "⏸️"
+		return theme.Icon("⏸️", "[hold]")
 	case epic.StatusCompleted:
-		return "✅"
+		return theme.Icon("✅", "[done]")
 	case epic.StatusCancelled:
-		return "❌"
+		return theme.Icon("❌", "[cancelled]")
 	default:
-		return "❓"
+		return theme.Icon("❓", "[?]")
 	}
 }
 
 func getEpicPriorityIcon(priority epic.Priority) string {
 	switch priority {
 	case epic.PriorityLow:
-		return "🟢"
+		return theme.Icon("🟢", "[low]")
 	case epic.PriorityMedium:
-		return "🟡"
+		return theme.Icon("🟡", "[medium]")
 	case epic.PriorityHigh:
-		return "🟠"
+		return theme.Icon("🟠", "[high]")
 	case epic.PriorityCritical:
-		return "🔴"
+		return theme.Icon("🔴", "[critical]")
 	default:
-		return "⚪"
+		return theme.Icon("⚪", "[-]")
 	}
 }
 
@@ -599,12 +914,11 @@ func truncateEpicString(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
-func showEpicHistory(epicID string) {
+func showEpicHistory(epicID string) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create epic manager
@@ -613,8 +927,7 @@ func showEpicHistory(epicID string) {
 	// Check if epic exists
 	ep, err := manager.GetEpic(epicID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get epic: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get epic")
 	}
 
 	// Get state history
@@ -626,7 +939,7 @@ func showEpicHistory(epicID string) {
 
 	if len(history) == 0 {
 		fmt.Printf("No state transitions recorded for this epic.\n")
-		return
+		return nil
 	}
 
 	// Display each transition
@@ -661,31 +974,37 @@ func showEpicHistory(epicID string) {
 			history[len(history)-1].ToStatus,
 			history[len(history)-1].Timestamp.Format("Jan 02 15:04"))
 	}
+	return nil
 }
 
-func showEpicMetrics(epicID string) {
+func showEpicMetrics(epicID string) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create epic manager
 	manager := epic.NewManager(wd)
 
-	// Check if epic exists
-	ep, err := manager.GetEpic(epicID)
+	window, err := resolveVelocityWindow()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get epic: %v\n", err)
-		os.Exit(1)
+		return clierrors.NewCLIError(err.Error(), clierrors.ExitUsage).
+			WithSuggestion("Use --velocity-window or epic.velocity_window with a value like 14d, 2w or 72h")
 	}
 
-	// Get advanced metrics
-	metrics, err := manager.GetEpicAdvancedMetrics(epicID)
+	ep, metrics, err := loadEpicMetrics(wd, manager, epicID, window)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get epic metrics: %v\n", err)
-		os.Exit(1)
+		return err
+	}
+
+	if metricsCompare != "" {
+		other, otherMetrics, err := loadEpicMetrics(wd, manager, metricsCompare, window)
+		if err != nil {
+			return err
+		}
+		printEpicMetricsComparison(ep, metrics, other, otherMetrics)
+		return nil
 	}
 
 	// Display header
@@ -726,6 +1045,15 @@ func showEpicMetrics(epicID string) {
 
 	// Velocity and predictions
 	fmt.Printf("\n🎯 Velocity & Predictions:\n")
+	if metrics.VelocityBasis == epic.VelocityBasisWindow {
+		fmt.Printf("   Velocity:          %.1f %s/day (%d stories in the last %s)\n",
+			metrics.Velocity, metrics.VelocityUnit, metrics.VelocitySample, epic.FormatVelocityWindow(metrics.VelocityWindow))
+	} else if metrics.VelocityNote != "" {
+		fmt.Printf("   ⚠️  Velocity window not used: %s\n", metrics.VelocityNote)
+		if metrics.EstimatedCompletion != nil {
+			fmt.Printf("   Falling back to the progress since the epic started\n")
+		}
+	}
 	if metrics.EstimatedCompletion != nil {
 		fmt.Printf("   Est. Completion:   %s\n", metrics.EstimatedCompletion.Format("2006-01-02 15:04"))
 
@@ -741,8 +1069,262 @@ func showEpicMetrics(epicID string) {
 		fmt.Printf("   Est. Completion:   Unable to calculate\n")
 	}
 
+	// Story cycle time and throughput
+	fmt.Printf("\n🔁 Story Cycle Time:\n")
+	fmt.Printf("   Average:           %s\n", formatCycleTime(metrics, metrics.AvgCycleTime))
+	fmt.Printf("   Min / Max:         %s / %s\n", formatCycleTime(metrics, metrics.MinCycleTime), formatCycleTime(metrics, metrics.MaxCycleTime))
+	fmt.Printf("   Std Deviation:     %s\n", formatCycleTime(metrics, metrics.CycleTimeStdDev))
+	fmt.Printf("   Throughput:        %s\n", formatThroughput(metrics))
+	if metrics.CycleTimeNote != "" {
+		fmt.Printf("   ⚠️  Not enough data: %s\n", metrics.CycleTimeNote)
+	} else {
+		fmt.Printf("   Sample:            %d completed stories\n", metrics.CycleTimeSample)
+	}
+
 	// Summary
 	fmt.Printf("\n📋 Calculated: %s\n", metrics.CalculatedAt.Format("2006-01-02 15:04:05"))
+	return nil
+}
+
+// loadEpicMetrics returns an epic with its advanced metrics, computed from
+// the start and completion times of its stories
+func loadEpicMetrics(wd string, manager *epic.Manager, epicID string, window time.Duration) (*epic.Epic, *epic.AdvancedMetrics, error) {
+	ep, err := manager.GetEpic(epicID)
+	if err != nil {
+		return nil, nil, clierrors.Wrap(err, "Failed to get epic")
+	}
+
+	stories, err := burndownStories(wd, ep)
+	if err != nil {
+		return nil, nil, clierrors.Wrap(err, "Failed to load stories")
+	}
+
+	metrics, err := manager.GetEpicAdvancedMetricsWithOptions(epicID, epic.MetricsOptions{VelocityWindow: window, Stories: stories})
+	if err != nil {
+		return nil, nil, clierrors.Wrap(err, "Failed to get epic metrics")
+	}
+	return ep, metrics, nil
+}
+
+// formatCycleTime formats a cycle time statistic, or N/A when there are not
+// enough completed stories to compute it
+func formatCycleTime(metrics *epic.AdvancedMetrics, d time.Duration) string {
+	if metrics.CycleTimeSample < epic.MinCycleTimeSample {
+		return "N/A"
+	}
+	return formatDuration(d)
+}
+
+// formatThroughput formats the stories completed per week, or N/A without
+// enough completed stories or start date
+func formatThroughput(metrics *epic.AdvancedMetrics) string {
+	if metrics.ThroughputPerWeek <= 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.1f stories/week", metrics.ThroughputPerWeek)
+}
+
+// printEpicMetricsComparison prints the metrics of two epics side by side
+func printEpicMetricsComparison(ep *epic.Epic, metrics *epic.AdvancedMetrics, other *epic.Epic, otherMetrics *epic.AdvancedMetrics) {
+	fmt.Printf("📊 Epic Metrics Comparison: %s vs %s\n", ep.ID, other.ID)
+	fmt.Printf("=======================================\n\n")
+
+	duration := func(m *epic.AdvancedMetrics) string {
+		if m.TotalDuration <= 0 {
+			return "Not started"
+		}
+		return fmt.Sprintf("%d days", m.DurationDays)
+	}
+	estimate := func(m *epic.AdvancedMetrics) string {
+		if m.EstimatedCompletion == nil {
+			return "N/A"
+		}
+		return m.EstimatedCompletion.Format("2006-01-02")
+	}
+	rows := []struct {
+		label string
+		value func(*epic.AdvancedMetrics) string
+	}{
+		{"Completion", func(m *epic.AdvancedMetrics) string {
+			return fmt.Sprintf("%.1f%%", m.BasicMetrics.CompletionPercentage)
+		}},
+		{"Stories", func(m *epic.AdvancedMetrics) string {
+			return fmt.Sprintf("%d/%d", m.BasicMetrics.CompletedStories, m.BasicMetrics.TotalStories)
+		}},
+		{"Duration", duration},
+		{"Avg cycle time", func(m *epic.AdvancedMetrics) string { return formatCycleTime(m, m.AvgCycleTime) }},
+		{"Min cycle time", func(m *epic.AdvancedMetrics) string { return formatCycleTime(m, m.MinCycleTime) }},
+		{"Max cycle time", func(m *epic.AdvancedMetrics) string { return formatCycleTime(m, m.MaxCycleTime) }},
+		{"Cycle time std dev", func(m *epic.AdvancedMetrics) string { return formatCycleTime(m, m.CycleTimeStdDev) }},
+		{"Throughput", formatThroughput},
+		{"Est. completion", estimate},
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "METRIC\t%s\t%s\n", ep.ID, other.ID)
+	fmt.Fprintf(w, "──────\t%s\t%s\n", strings.Repeat("─", len(ep.ID)), strings.Repeat("─", len(other.ID)))
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", row.label, row.value(metrics), row.value(otherMetrics))
+	}
+	w.Flush()
+
+	fmt.Println()
+	for _, m := range []*epic.AdvancedMetrics{metrics, otherMetrics} {
+		if m.CycleTimeNote != "" {
+			fmt.Printf("⚠️  %s: not enough data for cycle times, %s\n", m.EpicID, m.CycleTimeNote)
+		}
+	}
+}
+
+func showEpicBurndown(epicID string) error {
+	// Get current working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to get working directory")
+	}
+
+	// Create epic manager
+	manager := epic.NewManager(wd)
+
+	ep, err := manager.GetEpic(epicID)
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to get epic")
+	}
+
+	stories, err := burndownStories(wd, ep)
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to load stories")
+	}
+
+	// The ideal line follows the same estimate as 'epic metrics'
+	var target *time.Time
+	window, _ := epic.ParseVelocityWindow(viper.GetString("epic.velocity_window"))
+	if metrics, err := manager.GetEpicAdvancedMetricsWithOptions(epicID, epic.MetricsOptions{VelocityWindow: window, Stories: stories}); err == nil {
+		target = metrics.EstimatedCompletion
+	}
+
+	burndown, err := epic.ComputeBurndown(ep, manager.GetEpicStateHistory(epicID), stories, target, time.Now())
+	if errors.Is(err, epic.ErrInsufficientBurndownData) {
+		fmt.Printf("📉 No burndown for %s: %v\n", ep.ID, err)
+		fmt.Printf("💡 Start the epic (claude-wm-cli epic select %s) and complete stories to build one\n", ep.ID)
+		return nil
+	}
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to compute burndown")
+	}
+
+	if burndownFormat == "csv" {
+		if err := writeBurndownCSV(os.Stdout, burndown); err != nil {
+			return clierrors.Wrap(err, "Failed to write CSV")
+		}
+		return nil
+	}
+	printBurndown(ep, burndown)
+	return nil
+}
+
+// resolveVelocityWindow returns the velocity window from the flag or the
+// epic.velocity_window config key; 0 means the whole epic history
+func resolveVelocityWindow() (time.Duration, error) {
+	value := metricsVelocityWindow
+	if value == "" {
+		value = viper.GetString("epic.velocity_window")
+	}
+	return epic.ParseVelocityWindow(value)
+}
+
+// burndownStories returns the stories of an epic with their completion time,
+// from the story collection or, when it has none for the epic, from the
+// epic's own user stories (without completion times)
+func burndownStories(wd string, ep *epic.Epic) ([]epic.BurndownStory, error) {
+	generated, err := story.NewGenerator(wd).ListStories(ep.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var stories []epic.BurndownStory
+	for _, s := range generated {
+		stories = append(stories, epic.BurndownStory{
+			ID:          s.ID,
+			Points:      s.StoryPoints,
+			Completed:   s.Status == epic.StatusCompleted,
+			StartedAt:   s.StartedAt,
+			CompletedAt: s.CompletedAt,
+		})
+	}
+	if len(stories) > 0 {
+		return stories, nil
+	}
+
+	for _, s := range ep.UserStories {
+		stories = append(stories, epic.BurndownStory{
+			ID:        s.ID,
+			Points:    s.StoryPoints,
+			Completed: s.Status == epic.StatusCompleted,
+		})
+	}
+	return stories, nil
+}
+
+// printBurndown prints a burndown as a table with a bar of the remaining work
+func printBurndown(ep *epic.Epic, burndown *epic.Burndown) {
+	fmt.Printf("📉 Epic Burndown: %s\n", ep.Title)
+	fmt.Printf("=======================================\n\n")
+	fmt.Printf("   Total:   %d %s\n", burndown.Total, burndown.Unit)
+	fmt.Printf("   Started: %s\n", burndown.Start.Format("2006-01-02"))
+	if burndown.Target != nil {
+		fmt.Printf("   Target:  %s (estimated completion)\n", burndown.Target.Format("2006-01-02"))
+	}
+	fmt.Println()
+
+	const barWidth = 30
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "DATE\tDONE\tREMAINING\tIDEAL\t\n")
+	for _, day := range burndown.Days {
+		ideal := "-"
+		if day.Ideal >= 0 {
+			ideal = fmt.Sprintf("%.1f", day.Ideal)
+		}
+		bar := ""
+		if burndown.Total > 0 {
+			bar = strings.Repeat("█", day.Remaining*barWidth/burndown.Total)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", day.Date.Format("2006-01-02"), day.Completed, day.Remaining, ideal, bar)
+	}
+	w.Flush()
+
+	if burndown.Undated > 0 {
+		fmt.Printf("\n⚠️  %d completed stories have no completion date and are counted from the start\n", burndown.Undated)
+	}
+
+	last := burndown.Days[len(burndown.Days)-1]
+	if last.Ideal >= 0 && last.Remaining > 0 {
+		if float64(last.Remaining) > last.Ideal {
+			fmt.Printf("\n🔴 Behind the ideal line by %.1f %s\n", float64(last.Remaining)-last.Ideal, burndown.Unit)
+		} else {
+			fmt.Printf("\n🟢 On track: %.1f %s ahead of the ideal line\n", last.Ideal-float64(last.Remaining), burndown.Unit)
+		}
+	}
+}
+
+// writeBurndownCSV writes a burndown as CSV with a header row
+func writeBurndownCSV(out io.Writer, burndown *epic.Burndown) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"date", "completed", "remaining", "ideal"}); err != nil {
+		return err
+	}
+	for _, day := range burndown.Days {
+		ideal := ""
+		if day.Ideal >= 0 {
+			ideal = strconv.FormatFloat(day.Ideal, 'f', 2, 64)
+		}
+		record := []string{day.Date.Format("2006-01-02"), strconv.Itoa(day.Completed), strconv.Itoa(day.Remaining), ideal}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
 }
 
 func formatDuration(d time.Duration) string {
@@ -761,26 +1343,69 @@ func formatDuration(d time.Duration) string {
 
 // JSON structure for epics.json file
 type EpicsJSON struct {
-	Epics []struct {
-		ID          string `json:"id"`
-		Title       string `json:"title"`
-		Priority    string `json:"priority"`
-		Status      string `json:"status"`
-		Description string `json:"description"`
-		UserStories []struct {
-			ID       string `json:"id"`
-			Title    string `json:"title"`
-			Status   string `json:"status"`
-			Priority string `json:"priority"`
-		} `json:"userStories"`
-	} `json:"epics"`
+	Epics    []EpicsJSONEntry `json:"epics"`
 	Metadata struct {
 		TotalEpics int `json:"totalEpics"`
 	} `json:"metadata"`
 }
 
+// EpicsJSONEntry is an epic of EpicsJSON
+type EpicsJSONEntry struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Priority    string   `json:"priority"`
+	Status      string   `json:"status"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	UserStories []struct {
+		ID       string `json:"id"`
+		Title    string `json:"title"`
+		Status   string `json:"status"`
+		Priority string `json:"priority"`
+	} `json:"userStories"`
+	Archived bool `json:"-"`
+}
+
+// epicsJSONFromCollection converts the epics.json written by the epic
+// manager, archived epics included, to the EpicsJSON format
+func epicsJSONFromCollection(collection *epic.EpicCollection) EpicsJSON {
+	var epicsData EpicsJSON
+	add := func(epics map[string]*epic.Epic, archived bool) {
+		ids := make([]string, 0, len(epics))
+		for id := range epics {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			ep := epics[id]
+			entry := EpicsJSONEntry{
+				ID:          ep.ID,
+				Title:       ep.Title,
+				Priority:    string(ep.Priority),
+				Status:      string(ep.Status),
+				Description: ep.Description,
+				Tags:        ep.Tags,
+				Archived:    archived,
+			}
+			for _, us := range ep.UserStories {
+				entry.UserStories = append(entry.UserStories, struct {
+					ID       string `json:"id"`
+					Title    string `json:"title"`
+					Status   string `json:"status"`
+					Priority string `json:"priority"`
+				}{us.ID, us.Title, string(us.Status), string(us.Priority)})
+			}
+			epicsData.Epics = append(epicsData.Epics, entry)
+		}
+	}
+	add(collection.Epics, false)
+	add(collection.ArchivedEpics, true)
+	epicsData.Metadata.TotalEpics = len(epicsData.Epics)
+	return epicsData
+}
+
 // displayEpicsFromFile reads epics.json and displays formatted epic list
-func displayEpicsFromFile(wd, statusFilter, priorityFilter string, showAll bool) error {
+func displayEpicsFromFile(wd, statusFilter, priorityFilter string, showAll bool, tags, anyTags []string) error {
 	// Read epics.json file
 	epicsPath := filepath.Join(wd, "docs/1-project/epics.json")
 	data, err := os.ReadFile(epicsPath)
@@ -795,37 +1420,33 @@ func displayEpicsFromFile(wd, statusFilter, priorityFilter string, showAll bool)
 	// Parse JSON
 	var epicsData EpicsJSON
 	if err := json.Unmarshal(data, &epicsData); err != nil {
-		return fmt.Errorf("failed to parse epics.json: %w", err)
+		// Fall back to the format written by the epic manager
+		var collection epic.EpicCollection
+		if collErr := json.Unmarshal(data, &collection); collErr != nil {
+			return fmt.Errorf("failed to parse epics.json: %w", err)
+		}
+		epicsData = epicsJSONFromCollection(&collection)
 	}
 
 	// Filter epics
-	filteredEpics := make([]struct {
-		ID          string `json:"id"`
-		Title       string `json:"title"`
-		Priority    string `json:"priority"`
-		Status      string `json:"status"`
-		Description string `json:"description"`
-		UserStories []struct {
-			ID       string `json:"id"`
-			Title    string `json:"title"`
-			Status   string `json:"status"`
-			Priority string `json:"priority"`
-		} `json:"userStories"`
-	}, 0)
-
-	for _, epic := range epicsData.Epics {
+	filteredEpics := make([]EpicsJSONEntry, 0)
+
+	for _, entry := range epicsData.Epics {
 		// Apply filters
-		if statusFilter != "" && epic.Status != statusFilter {
+		if statusFilter != "" && entry.Status != statusFilter {
+			continue
+		}
+		if priorityFilter != "" && entry.Priority != priorityFilter {
 			continue
 		}
-		if priorityFilter != "" && epic.Priority != priorityFilter {
+		if !epic.MatchTags(entry.Tags, tags, anyTags) {
 			continue
 		}
-		// Skip completed/cancelled epics unless showAll is true
-		if !showAll && (epic.Status == "completed" || epic.Status == "cancelled") {
+		// Skip completed/cancelled and archived epics unless showAll is true
+		if !showAll && (entry.Archived || entry.Status == "completed" || entry.Status == "cancelled") {
 			continue
 		}
-		filteredEpics = append(filteredEpics, epic)
+		filteredEpics = append(filteredEpics, entry)
 	}
 
 	// Display header
@@ -842,12 +1463,12 @@ func displayEpicsFromFile(wd, statusFilter, priorityFilter string, showAll bool)
 		return nil
 	}
 
-	// Create table writer
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Create table writer, coloring each epic by its status
+	w := theme.NewTable(os.Stdout)
 
 	// Print header
-	fmt.Fprintf(w, "ID\tTITLE\tSTATUS\tPRIORITY\tSTORIES\n")
-	fmt.Fprintf(w, "──\t─────\t──────\t────────\t───────\n")
+	w.Row(theme.Heading, "ID\tTITLE\tSTATUS\tPRIORITY\tSTORIES\n")
+	w.Row(nil, "──\t─────\t──────\t────────\t───────\n")
 
 	// Print each epic
 	for _, epic := range filteredEpics {
@@ -869,9 +1490,14 @@ func displayEpicsFromFile(wd, statusFilter, priorityFilter string, showAll bool)
 			storiesStr += fmt.Sprintf(" (%.0f%%)", progress)
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s %s\t%s %s\t%s\n",
+		title := truncateEpicString(epic.Title, 40)
+		if epic.Archived {
+			title = "[ARCHIVED] " + title
+		}
+
+		w.Row(theme.StatusStyle(epic.Status), "%s\t%s\t%s %s\t%s %s\t%s\n",
 			epic.ID,
-			truncateEpicString(epic.Title, 40),
+			title,
 			statusIcon, epic.Status,
 			priorityIcon, epic.Priority,
 			storiesStr)
@@ -894,56 +1520,72 @@ func displayEpicsFromFile(wd, statusFilter, priorityFilter string, showAll bool)
 func getEpicStatusIconFromString(status string) string {
 	switch status {
 	case "planned", "todo":
-		return "📋"
+		return theme.Icon("📋", "[planned]")
 	case "in_progress":
-		return "🚧"
+		return theme.Icon("🚧", "[wip]")
 	case "on_hold":
-		return "⏸️"
+		return theme.Icon("⏸️", "[hold]")
 	case "completed", "done":
-		return "✅"
+		return theme.Icon("✅", "[done]")
 	case "cancelled":
-		return "❌"
+		return theme.Icon("❌", "[cancelled]")
 	case "backlog":
-		return "📦"
+		return theme.Icon("📦", "[backlog]")
 	default:
-		return "❓"
+		return theme.Icon("❓", "[?]")
 	}
 }
 
 func getEpicPriorityIconFromString(priority string) string {
 	switch priority {
 	case "low":
-		return "🟢"
+		return theme.Icon("🟢", "[low]")
 	case "medium":
-		return "🟡"
+		return theme.Icon("🟡", "[medium]")
 	case "high":
-		return "🟠"
+		return theme.Icon("🟠", "[high]")
 	case "critical":
-		return "🔴"
+		return theme.Icon("🔴", "[critical]")
 	default:
-		return "⚪"
+		return theme.Icon("⚪", "[-]")
 	}
 }
 
-func showEpicDashboard() {
+func showEpicDashboard() error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Note: No specific Claude prompt available for epic dashboard - using basic implementation
 	debug.LogStub("EPIC", "showEpicDashboard", "Epic dashboard - no matching Claude prompt available")
-	fmt.Println("📋 Displaying epic dashboard...")
 
 	// Create epic manager and dashboard for fallback
 	manager := epic.NewManager(wd)
 	dashboard := epic.NewDashboard(manager)
+	dashboard.SetStorySource(func(ep *epic.Epic) ([]epic.BurndownStory, error) {
+		return burndownStories(wd, ep)
+	})
+
+	if dashboardJSON {
+		report, err := dashboard.BuildDashboardData()
+		if err != nil {
+			return clierrors.Wrap(err, "Failed to build dashboard")
+		}
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return clierrors.Wrap(err, "Failed to encode dashboard")
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	fmt.Println("📋 Displaying epic dashboard...")
 
 	// Display the dashboard
 	if err := dashboard.DisplayEpicDashboard(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to display dashboard: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to display dashboard")
 	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -75,6 +76,51 @@ func TestEpicManager_ListEpics(t *testing.T) {
 	assert.Equal(t, epic1.ID, epics[1].ID)
 }
 
+func TestEpicManager_ListEpicsByTag(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Create the directory structure
+	docsDir := filepath.Join(tempDir, "docs", "1-project")
+	err := os.MkdirAll(docsDir, 0755)
+	require.NoError(t, err)
+
+	manager := epic.NewManager(tempDir)
+
+	auth, err := manager.CreateEpic(epic.EpicCreateOptions{Title: "Auth", Tags: []string{"security", "api"}})
+	require.NoError(t, err)
+	audit, err := manager.CreateEpic(epic.EpicCreateOptions{Title: "Audit", Tags: []string{"Security"}})
+	require.NoError(t, err)
+	ui, err := manager.CreateEpic(epic.EpicCreateOptions{Title: "UI", Tags: []string{"frontend"}})
+	require.NoError(t, err)
+
+	ids := func(epics []*epic.Epic) []string {
+		var ids []string
+		for _, e := range epics {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+
+	// Tags are matched case-insensitively
+	epics, err := manager.ListEpics(epic.EpicListOptions{Tags: []string{"security"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{auth.ID, audit.ID}, ids(epics))
+
+	// --tag requires every tag
+	epics, err = manager.ListEpics(epic.EpicListOptions{Tags: []string{"security", "api"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{auth.ID}, ids(epics))
+
+	// --tag-any requires one of the tags
+	epics, err = manager.ListEpics(epic.EpicListOptions{AnyTags: []string{"api", "frontend"}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{auth.ID, ui.ID}, ids(epics))
+
+	epics, err = manager.ListEpics(epic.EpicListOptions{Tags: []string{"security"}, AnyTags: []string{"frontend"}})
+	require.NoError(t, err)
+	assert.Empty(t, epics)
+}
+
 func TestEpicManager_UpdateEpic(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -207,6 +253,100 @@ func TestEpicManager_DeleteEpic(t *testing.T) {
 	assert.Contains(t, err.Error(), "no epic is currently active")
 }
 
+func TestEpicManager_DeleteEpicSafetyChecks(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Create the directory structure
+	docsDir := filepath.Join(tempDir, "docs", "1-project")
+	err := os.MkdirAll(docsDir, 0755)
+	require.NoError(t, err)
+
+	manager := epic.NewManager(tempDir)
+
+	base, err := manager.CreateEpic(epic.EpicCreateOptions{Title: "Base"})
+	require.NoError(t, err)
+	dependent, err := manager.CreateEpic(epic.EpicCreateOptions{Title: "Dependent", Dependencies: []string{base.ID}})
+	require.NoError(t, err)
+
+	// Referenced as a dependency, even with --force
+	err = manager.DeleteEpicWithOptions(base.ID, epic.EpicDeleteOptions{Force: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is a dependency of "+dependent.ID)
+
+	// Incomplete user stories require --force
+	collection, err := manager.GetEpicCollection()
+	require.NoError(t, err)
+	collection.Epics[dependent.ID].UserStories = []epic.UserStory{
+		{ID: "STORY-1", Title: "Done", Status: epic.StatusCompleted},
+		{ID: "STORY-2", Title: "Open", Status: epic.StatusPlanned},
+	}
+	data, err := json.MarshalIndent(collection, "", "  ")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(docsDir, epic.EpicsFileName), data, 0644))
+
+	err = manager.DeleteEpic(dependent.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 incomplete user stories")
+
+	archived, err := manager.BackupEpic(dependent.ID)
+	require.NoError(t, err)
+	assert.Contains(t, archived.Tags, dependent.ID)
+
+	require.NoError(t, manager.DeleteEpicWithOptions(dependent.ID, epic.EpicDeleteOptions{Force: true}))
+	require.NoError(t, manager.DeleteEpic(base.ID))
+}
+
+func TestEpicManager_ArchiveEpic(t *testing.T) {
+	tempDir := t.TempDir()
+
+	// Create the directory structure
+	docsDir := filepath.Join(tempDir, "docs", "1-project")
+	err := os.MkdirAll(docsDir, 0755)
+	require.NoError(t, err)
+
+	manager := epic.NewManager(tempDir)
+
+	ep, err := manager.CreateEpic(epic.EpicCreateOptions{Title: "Shipped"})
+	require.NoError(t, err)
+	_, err = manager.CreateEpic(epic.EpicCreateOptions{Title: "Ongoing"})
+	require.NoError(t, err)
+
+	// Only completed or cancelled epics can be archived
+	err = manager.ArchiveEpic(ep.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only completed or cancelled epics can be archived")
+
+	for _, status := range []epic.Status{epic.StatusInProgress, epic.StatusCompleted} {
+		_, err = manager.UpdateEpic(ep.ID, epic.EpicUpdateOptions{Status: &status})
+		require.NoError(t, err)
+	}
+	require.NoError(t, manager.ArchiveEpic(ep.ID))
+
+	err = manager.ArchiveEpic(ep.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already archived")
+
+	// Hidden from the list but still shown
+	epics, err := manager.ListEpics(epic.EpicListOptions{ShowAll: true})
+	require.NoError(t, err)
+	assert.Len(t, epics, 1)
+	archived, err := manager.GetEpic(ep.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, archived.ArchivedAt)
+
+	require.NoError(t, manager.UnarchiveEpic(ep.ID))
+	epics, err = manager.ListEpics(epic.EpicListOptions{ShowAll: true})
+	require.NoError(t, err)
+	assert.Len(t, epics, 2)
+	restored, err := manager.GetEpic(ep.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.ArchivedAt)
+
+	err = manager.UnarchiveEpic(ep.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not archived")
+}
+
 func TestEpicValidation(t *testing.T) {
 	tempDir := t.TempDir()
 
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	clierrors "claude-wm-cli/internal/errors"
 	"claude-wm-cli/internal/github"
 	"claude-wm-cli/internal/ticket"
 
@@ -59,8 +60,10 @@ Examples:
   claude-wm-cli github config --token-file ~/.github/token
   claude-wm-cli github config --show
   claude-wm-cli github config --disable`,
-	Run: func(cmd *cobra.Command, args []string) {
-		configureGitHub(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return configureGitHub(cmd)
 	},
 }
 
@@ -81,8 +84,10 @@ Examples:
   claude-wm-cli github sync --labels bug,critical    # Only sync issues with specific labels
   claude-wm-cli github sync --create-new --update-existing
   claude-wm-cli github sync --max-issues 50          # Limit to 50 issues`,
-	Run: func(cmd *cobra.Command, args []string) {
-		syncGitHubIssues(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return syncGitHubIssues(cmd)
 	},
 }
 
@@ -99,8 +104,15 @@ Examples:
   claude-wm-cli github issue 123
   claude-wm-cli github issue 456`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		importGitHubIssue(args[0])
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issueNumber, err := strconv.Atoi(args[0])
+		if err != nil {
+			return clierrors.NewCLIError(fmt.Sprintf("Invalid issue number '%s'", args[0]), clierrors.ExitUsage)
+		}
+
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return importGitHubIssue(issueNumber)
 	},
 }
 
@@ -119,8 +131,10 @@ This includes:
 
 Examples:
   claude-wm-cli github status`,
-	Run: func(cmd *cobra.Command, args []string) {
-		showGitHubStatus()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showGitHubStatus()
 	},
 }
 
@@ -138,8 +152,10 @@ This command verifies:
 
 Examples:
   claude-wm-cli github test`,
-	Run: func(cmd *cobra.Command, args []string) {
-		testGitHubConnection()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return testGitHubConnection()
 	},
 }
 
@@ -203,12 +219,11 @@ func init() {
 	githubSyncCmd.Flags().IntVar(&syncMaxIssues, "max-issues", 0, "Maximum number of issues to process")
 }
 
-func configureGitHub(cmd *cobra.Command) {
+func configureGitHub(cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create GitHub integration
@@ -216,14 +231,13 @@ func configureGitHub(cmd *cobra.Command) {
 
 	// Load existing configuration
 	if err := integration.LoadConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to load configuration: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to load configuration")
 	}
 
 	// Handle show flag
 	if githubShow {
 		showGitHubConfig(integration)
-		return
+		return nil
 	}
 
 	// Handle enable/disable flags
@@ -231,22 +245,20 @@ func configureGitHub(cmd *cobra.Command) {
 		config := github.DefaultConfig()
 		config.Enabled = false
 		if err := integration.UpdateConfig(config); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to disable GitHub integration: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Failed to disable GitHub integration")
 		}
 		fmt.Printf("✅ GitHub integration disabled.\n")
-		return
+		return nil
 	}
 
 	if githubEnable {
 		config := github.DefaultConfig()
 		config.Enabled = true
 		if err := integration.UpdateConfig(config); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to enable GitHub integration: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Failed to enable GitHub integration")
 		}
 		fmt.Printf("✅ GitHub integration enabled.\n")
-		return
+		return nil
 	}
 
 	// Build configuration from flags
@@ -305,8 +317,7 @@ func configureGitHub(cmd *cobra.Command) {
 
 	// Update configuration
 	if err := integration.UpdateConfig(config); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to update configuration: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to update configuration")
 	}
 
 	fmt.Printf("✅ GitHub integration configured successfully!\n\n")
@@ -321,14 +332,14 @@ func configureGitHub(cmd *cobra.Command) {
 	fmt.Printf("   • Test connection:  claude-wm-cli github test\n")
 	fmt.Printf("   • Sync issues:      claude-wm-cli github sync --create-new\n")
 	fmt.Printf("   • View status:      claude-wm-cli github status\n")
+	return nil
 }
 
-func syncGitHubIssues(cmd *cobra.Command) {
+func syncGitHubIssues(cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create GitHub integration
@@ -336,15 +347,13 @@ func syncGitHubIssues(cmd *cobra.Command) {
 
 	// Load configuration
 	if err := integration.LoadConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to load configuration: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to load configuration")
 	}
 
 	// Initialize integration
 	config := github.DefaultConfig()
 	if err := integration.Initialize(config); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to initialize GitHub integration: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to initialize GitHub integration")
 	}
 
 	// Build sync options from flags
@@ -388,8 +397,7 @@ func syncGitHubIssues(cmd *cobra.Command) {
 	// Perform sync
 	result, err := integration.SyncIssues(syncOptions)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Sync failed: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Sync failed")
 	}
 
 	// Display results
@@ -439,21 +447,14 @@ func syncGitHubIssues(cmd *cobra.Command) {
 	fmt.Printf("\n💡 Next steps:\n")
 	fmt.Printf("   • List tickets:    claude-wm-cli ticket list\n")
 	fmt.Printf("   • View status:     claude-wm-cli github status\n")
+	return nil
 }
 
-func importGitHubIssue(issueNumberStr string) {
-	// Parse issue number
-	issueNumber, err := strconv.Atoi(issueNumberStr)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Invalid issue number '%s'\n", issueNumberStr)
-		os.Exit(1)
-	}
-
+func importGitHubIssue(issueNumber int) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create GitHub integration
@@ -461,15 +462,13 @@ func importGitHubIssue(issueNumberStr string) {
 
 	// Load configuration
 	if err := integration.LoadConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to load configuration: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to load configuration")
 	}
 
 	// Initialize integration
 	config := github.DefaultConfig()
 	if err := integration.Initialize(config); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to initialize GitHub integration: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to initialize GitHub integration")
 	}
 
 	fmt.Printf("📥 Importing GitHub issue #%d...\n", issueNumber)
@@ -477,8 +476,7 @@ func importGitHubIssue(issueNumberStr string) {
 	// Import the issue
 	processed, err := integration.GetIssueByNumber(issueNumber)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to import issue: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to import issue")
 	}
 
 	// Display result
@@ -500,14 +498,14 @@ func importGitHubIssue(issueNumberStr string) {
 		fmt.Printf("   • View ticket:  claude-wm-cli ticket show %s\n", processed.TicketID)
 		fmt.Printf("   • Start work:   claude-wm-cli ticket current %s\n", processed.TicketID)
 	}
+	return nil
 }
 
-func showGitHubStatus() {
+func showGitHubStatus() error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create GitHub integration
@@ -515,8 +513,7 @@ func showGitHubStatus() {
 
 	// Load configuration
 	if err := integration.LoadConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to load configuration: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to load configuration")
 	}
 
 	config := github.DefaultConfig()
@@ -610,14 +607,14 @@ func showGitHubStatus() {
 	}
 	fmt.Printf("   • Test:         claude-wm-cli github test\n")
 	fmt.Printf("   • Sync:         claude-wm-cli github sync --create-new\n")
+	return nil
 }
 
-func testGitHubConnection() {
+func testGitHubConnection() error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create GitHub integration
@@ -625,8 +622,7 @@ func testGitHubConnection() {
 
 	// Load configuration
 	if err := integration.LoadConfig(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to load configuration: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to load configuration")
 	}
 
 	fmt.Printf("🔧 Testing GitHub connection...\n")
@@ -634,8 +630,7 @@ func testGitHubConnection() {
 	// Initialize integration (this will test the connection)
 	config := github.DefaultConfig()
 	if err := integration.Initialize(config); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Connection test failed: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Connection test failed")
 	}
 
 	fmt.Printf("✅ GitHub connection test successful!\n\n")
@@ -648,6 +643,7 @@ func testGitHubConnection() {
 	fmt.Printf("\n💡 Next steps:\n")
 	fmt.Printf("   • Sync issues:  claude-wm-cli github sync --create-new\n")
 	fmt.Printf("   • View status:  claude-wm-cli github status\n")
+	return nil
 }
 
 func showGitHubConfig(integration *github.Integration) {
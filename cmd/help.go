@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"claude-wm-cli/internal/errors"
+
 	"github.com/spf13/cobra"
 )
 
@@ -160,6 +162,28 @@ func printQuickStart() {
 📖 For detailed documentation, see: docs/README.md`)
 }
 
+// exitCodesHelpCmd is the exit-codes help topic
+var exitCodesHelpCmd = &cobra.Command{
+	Use:   "exit-codes",
+	Short: "Exit codes and what they mean",
+	Long:  exitCodesHelp(),
+}
+
+// exitCodesHelp documents the exit codes of errors.ExitCodes
+func exitCodesHelp() string {
+	var b strings.Builder
+	b.WriteString("claude-wm-cli exits with one of these codes, so that scripts can tell failures apart:\n\n")
+	for _, code := range errors.ExitCodes() {
+		fmt.Fprintf(&b, "  %d  %-19s %s\n", int(code), code.String(), code.Description())
+	}
+	b.WriteString(`
+With --errors-json, the "code" field of the JSON error holds the same value.
+Hooks run by Claude Code ('claude-wm-cli hook ...') exit with 2 to block a tool
+call, as the Claude Code hook protocol requires.`)
+	return b.String()
+}
+
 func init() {
 	rootCmd.AddCommand(helpCmd)
+	rootCmd.AddCommand(exitCodesHelpCmd)
 }
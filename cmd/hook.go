@@ -1,75 +1,363 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	clierrors "claude-wm-cli/internal/errors"
 	"claude-wm-cli/internal/hooks"
 )
 
 var hookCmd = &cobra.Command{
-	Use:   "hook",
-	Short: "Execute internal hooks",
-	Long:  "Execute internal validation and formatting hooks for claude-wm-cli",
+	Use:     "hook",
+	Aliases: []string{"hooks"},
+	Short:   "Execute internal hooks and manage git hooks",
+	Long: `Execute internal validation and formatting hooks for claude-wm-cli, and
+install, list, check and remove the project's git hooks.`,
 }
 
+var (
+	noSecretScan bool
+	allowTODOs   bool
+	prePush      bool
+	hookJSON     bool
+
+	hookInstallForce bool
+)
+
 var gitValidationCmd = &cobra.Command{
 	Use:   "git-validation",
 	Short: "Run git validation hook",
-	Run: func(cmd *cobra.Command, args []string) {
+	Long: `Run git validation hook
+
+Staged files are scanned for API keys and high-entropy strings. False
+positives can be listed as file+line entries in .claude-wm/secret-ignore.json:
+
+  {"allow": [{"file": "docs/examples.md", "line": 12}]}
+
+TODO, FIXME and HACK comments added to staged Go files are reported as
+warnings, and as errors beyond git.max_new_todos (default 3).
+
+Staged files matching the built-in forbidden patterns (.env, *.log, *.bak...)
+block the commit, and those matching the warning patterns (*.sql, config
+files...) are reported. .claude-wm/hooks/git-rules.json adds patterns and
+allow-list overrides to each category, or disables it:
+
+  {"forbidden": {"patterns": ["\\.pem$"], "allow": ["^testdata/.*\\.log$"]},
+   "warning": {"enabled": false}}
+
+Files matching the glob patterns of git.allow_files, or of the comma-separated
+GIT_VALIDATOR_ALLOW variable, are exempted from both checks and reported as
+allowed by policy.
+
+Staged files larger than git.max_file_mb (default 10, or
+GIT_VALIDATOR_MAX_FILE_MB) are warnings, and larger than git.block_file_mb
+(default 100) errors. More than git.max_staged_files staged files (default
+50, or GIT_VALIDATOR_MAX_STAGED) is a warning. Set git.suggest_lfs to get the
+'git lfs track' command of each large file type.
+
+Branches created with 'git checkout -b' or 'git switch -c', and the branch
+committed on, must match git.branch_pattern; protected branches always pass.
+Set git.branch_naming to "warning" to only report mismatches, or "off".
+Commit messages follow git.commit: allowed types and scopes, a required
+issue reference footer ("Refs: #123"), and strict mode to block violations.
+
+With --pre-push, validates the commits of a push instead, reading the pushed
+refs from stdin as git does for the pre-push hook: commit messages, forbidden
+files and pushes to protected branches (git.protected_branches, default
+main and develop). Install the hook with 'claude-wm-cli hooks install'.
+
+With --json (or GIT_VALIDATOR_OUTPUT=json), the result is written to stdout as
+JSON, with the scanned files and the rule behind each error, while the
+human-readable report stays on stderr:
+
+  claude-wm-cli hook git-validation --json < input.json | jq '.failures'`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if prePush {
+			return cobra.ExactArgs(2)(cmd, args)
+		}
+		return cobra.NoArgs(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+
 		projectRoot, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Failed to get current directory")
 		}
 
 		handler := hooks.NewHookHandler(projectRoot)
+		handler.SetJSONOutput(hookJSON)
+		if prePush {
+			if err := handler.HandlePrePushValidation(args[0], args[1]); err != nil {
+				return clierrors.Wrap(err, "Pre-push validation failed")
+			}
+			return nil
+		}
+
+		handler.SetSecretScan(!noSecretScan)
+		handler.SetAllowTODOs(allowTODOs)
 		if err := handler.HandleGitValidation(); err != nil {
-			fmt.Fprintf(os.Stderr, "Git validation failed: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Git validation failed")
 		}
+		return nil
 	},
 }
 
+var hookInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the git hooks",
+	Long: `Install all git hooks in one step.
+
+Every *.go file of .claude-wm/runtime/hooks/ with the 'hook' build tag
+(//go:build hook) is compiled with 'go build' to .git/hooks/<name>, where
+<name> is the file name without the .go extension and "-hook" suffix:
+pre-commit-hook.go becomes .git/hooks/pre-commit.
+
+The built-in pre-push hook, running 'hook git-validation --pre-push', is
+installed too unless a pre-push source replaces it. Pushes are blocked when
+a pushed commit has a Co-Authored-By line or an invalid message, adds
+forbidden files, or when the push targets a protected branch.
+
+Existing hooks not installed by this command are only replaced after
+confirmation (or with --force), and are backed up to <name>.bak.
+
+Examples:
+  claude-wm-cli hooks install
+  claude-wm-cli hooks install --force   # Replace existing hooks without asking
+  git push --no-verify                  # Bypass the hooks temporarily`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		installer := hooks.NewInstaller(projectRoot)
+		if _, err := os.Stat(installer.HooksDir()); os.IsNotExist(err) {
+			return fmt.Errorf("not a Git repository (no .git/hooks directory found)")
+		}
+		sources, err := installer.FindSources()
+		if err != nil {
+			return err
+		}
+
+		installs := make(map[string]func() (*hooks.InstalledHook, error))
+		names := []string{}
+		for _, source := range sources {
+			source := source
+			installs[source.Name] = func() (*hooks.InstalledHook, error) { return installer.Install(source) }
+			names = append(names, source.Name)
+		}
+		if _, ok := installs["pre-push"]; !ok {
+			installs["pre-push"] = installer.InstallPrePush
+			names = append(names, "pre-push")
+		}
+
+		installed := 0
+		for _, name := range names {
+			if installer.Exists(name) && !installer.IsManaged(name) && !hookInstallForce {
+				confirmed, err := confirmHookOverwrite(name)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					fmt.Printf("⏭️  Skipped %s\n", name)
+					continue
+				}
+			}
+
+			hook, err := installs[name]()
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✅ Installed %s (%s)\n", installer.HookPath(hook.Name), hook.Source)
+			installed++
+		}
+
+		fmt.Printf("\n🪝 %d hook(s) installed\n", installed)
+		fmt.Println("💡 To bypass the hooks temporarily, use: git commit/push --no-verify")
+		return nil
+	},
+}
+
+var hookListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the installed git hooks",
+	Long: `List the hooks installed by 'hooks install' with their size and
+SHA-256 checksum.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		installed, err := hooks.NewInstaller(projectRoot).List()
+		if err != nil {
+			return err
+		}
+		if len(installed) == 0 {
+			fmt.Println("No hooks installed. Run 'claude-wm-cli hooks install'.")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSIZE\tCHECKSUM\tSOURCE")
+		for _, hook := range installed {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", hook.Name, formatBackupSize(hook.Size), hook.Checksum[:12], hook.Source)
+		}
+		return w.Flush()
+	},
+}
+
+var hookStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Compare the installed git hooks with their sources",
+	Long: `Compare the hooks installed in .git/hooks with the sources in
+.claude-wm/runtime/hooks/.
+
+A hook is "source changed" when its source was edited since it was installed,
+"modified" when the installed file was changed by hand, and "not installed"
+when it has a source but was never installed. Run 'hooks install' to update.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		statuses, err := hooks.NewInstaller(projectRoot).Status()
+		if err != nil {
+			return err
+		}
+		if len(statuses) == 0 {
+			fmt.Println("No hooks found.")
+			return nil
+		}
+
+		outdated := 0
+		for _, status := range statuses {
+			icon := "✅"
+			if status.State != hooks.HookUpToDate {
+				icon = "⚠️ "
+				outdated++
+			}
+			fmt.Printf("%s %-24s %s\n", icon, status.Name, status.State)
+		}
+		if outdated > 0 {
+			fmt.Println("\n💡 Run 'claude-wm-cli hooks install' to update the hooks")
+		}
+		return nil
+	},
+}
+
+var hookUninstallCmd = &cobra.Command{
+	Use:   "uninstall [name]",
+	Short: "Remove installed git hooks",
+	Long: `Remove a hook installed by 'hooks install', or all of them when no
+name is given. Hooks installed by other tools are left alone.
+
+Examples:
+  claude-wm-cli hooks uninstall pre-push
+  claude-wm-cli hooks uninstall`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		name := ""
+		if len(args) == 1 {
+			name = args[0]
+		}
+		removed, err := hooks.NewInstaller(projectRoot).Uninstall(name)
+		if err != nil {
+			return err
+		}
+		if len(removed) == 0 {
+			fmt.Println("No hooks installed.")
+			return nil
+		}
+		for _, hookName := range removed {
+			fmt.Printf("🗑️  Removed %s\n", hookName)
+		}
+		return nil
+	},
+}
+
+// confirmHookOverwrite asks whether an existing hook may be replaced
+func confirmHookOverwrite(name string) (bool, error) {
+	fmt.Printf("A %s hook already exists. Replace it? [y/N]: ", name)
+	reader := bufio.NewReader(os.Stdin)
+	response, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read input: %w", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes", nil
+}
+
 var autoFormatCmd = &cobra.Command{
 	Use:   "auto-format",
 	Short: "Run auto-formatting hook",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+
 		projectRoot, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Failed to get current directory")
 		}
 
 		handler := hooks.NewHookHandler(projectRoot)
 		if err := handler.HandleAutoFormat(); err != nil {
-			fmt.Fprintf(os.Stderr, "Auto-formatting failed: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Auto-formatting failed")
 		}
+		return nil
 	},
 }
 
 var duplicateDetectionCmd = &cobra.Command{
 	Use:   "duplicate-detection",
 	Short: "Run duplicate detection hook",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+
 		projectRoot, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Failed to get current directory")
 		}
 
 		handler := hooks.NewHookHandler(projectRoot)
 		if err := handler.HandleDuplicateDetection(); err != nil {
-			fmt.Fprintf(os.Stderr, "Duplicate detection failed: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Duplicate detection failed")
 		}
+		return nil
 	},
 }
 
 func init() {
+	gitValidationCmd.Flags().BoolVar(&noSecretScan, "no-secret-scan", false, "Skip scanning staged file contents for secrets (trusted pipelines)")
+	gitValidationCmd.Flags().BoolVar(&prePush, "pre-push", false, "Validate the commits of a push (args: <remote> <url>, refs on stdin)")
+	gitValidationCmd.Flags().BoolVar(&allowTODOs, "allow-todos", false, "Do not report new TODO/FIXME/HACK comments")
+	gitValidationCmd.Flags().BoolVar(&hookJSON, "json", false, "Write the validation result as JSON to stdout (human report on stderr)")
+
 	hookCmd.AddCommand(gitValidationCmd)
+	hookInstallCmd.Flags().BoolVar(&hookInstallForce, "force", false, "Replace existing hooks without confirmation")
+
+	hookCmd.AddCommand(hookInstallCmd)
+	hookCmd.AddCommand(hookListCmd)
+	hookCmd.AddCommand(hookStatusCmd)
+	hookCmd.AddCommand(hookUninstallCmd)
 	hookCmd.AddCommand(autoFormatCmd)
 	hookCmd.AddCommand(duplicateDetectionCmd)
 	rootCmd.AddCommand(hookCmd)
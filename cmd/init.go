@@ -6,94 +6,249 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
+	"claude-wm-cli/internal/config"
+	"claude-wm-cli/internal/errors"
 	"claude-wm-cli/internal/model"
+	"claude-wm-cli/internal/navigation"
 	"claude-wm-cli/internal/subagents"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
-	initProjectName string
-	initForce       bool
+	initForce          bool
+	initDir            string
+	initSkipGit        bool
+	initSkipTemplates  bool
+	initNonInteractive bool
+	initTemplateSource string
 )
 
 // initCmd represents the init command
 var initCmd = &cobra.Command{
 	Use:   "init [project-name]",
 	Short: "Initialize a new project",
-	Long: `Initialize a new Claude WM CLI project with proper directory structure
-and configuration files. This command sets up the basic project structure
-needed for workflow management.
+	Long: `Initialize a complete Claude WM CLI project in one step, as the
+"Initialize project" action of the interactive menu does:
+
+  1. Directories         docs/1-project, docs/2-current-epic, .claude-wm...
+  2. Project settings    .claude-wm-cli.yaml
+  3. Config workspace    .claude-wm/system, user and runtime
+  4. Templates           README.md, METRICS.md and CLAUDE.md
+  5. Subagents           .claude/agents
+  6. Git                 repository with main and develop branches
+
+The command is idempotent: components already in place are reported as
+already initialized and left untouched, so it can be run again to complete a
+partial setup. 'claude-wm-cli doctor' suggests it when components are missing.
+The confirmation prompt is skipped with --non-interactive or when stdin is not
+a terminal.
+
+With --template-source, the template files are taken from the root of a git
+repository instead of the embedded defaults.
 
 Examples:
-  claude-wm-cli init my-project          # Initialize project in ./my-project
-  claude-wm-cli init                     # Initialize project in current directory
-  claude-wm-cli init --force my-project  # Force initialization (overwrite existing)`,
-	Run: func(cmd *cobra.Command, args []string) {
+  claude-wm-cli init                              # Initialize the current directory
+  claude-wm-cli init my-project                   # Initialize ./my-project
+  claude-wm-cli init --dir ../api --non-interactive
+  claude-wm-cli init --skip-git --skip-templates  # Directories and configuration only
+  claude-wm-cli init --template-source https://github.com/acme/wm-templates.git
+  claude-wm-cli init --force                      # Overwrite .claude-wm-cli.yaml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectDir := initDir
 		var projectName string
 		if len(args) > 0 {
 			projectName = args[0]
-		} else {
-			pwd, _ := os.Getwd()
-			projectName = filepath.Base(pwd)
+			if err := model.ValidateProjectName(projectName); err != nil {
+				model.HandleValidationError(err, "claude-wm-cli init my-project")
+				return nil
+			}
+			if projectDir == "" {
+				projectDir = projectName
+			}
+		}
+		if projectDir == "" {
+			projectDir = "."
 		}
-		initializeProject(projectName)
+
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return initializeProject(projectDir, projectName)
 	},
 }
 
-func initializeProject(projectName string) {
-	// Validate project name
-	if err := model.ValidateProjectName(projectName); err != nil {
-		model.HandleValidationError(err, "claude-wm-cli init my-project")
-		return
+// initStep is a component of the project set up by init
+type initStep struct {
+	Name       string
+	Skipped    string                                                                          // Flag skipping the step, if set
+	Done       func(projectPath string) bool                                                   // Whether the component is already in place
+	Run        func(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error // Sets the component up
+	Optional   bool                                                                            // Failures are warnings and doctor ignores it
+	Suggestion string                                                                          // Shown when the step fails
+}
+
+// initSteps returns the components init sets up in projectPath, in order
+func initSteps(projectName string) []initStep {
+	templates := initStep{
+		Name:     "Templates",
+		Done:     templatesCopied,
+		Run:      copyTemplateFiles,
+		Optional: true,
+	}
+	if initTemplateSource != "" {
+		templates.Optional = false
+		templates.Suggestion = "Check that --template-source is a git repository you can clone"
+		templates.Done = func(projectPath string) bool {
+			return allExist(projectPath, projectTemplateFiles)
+		}
+		templates.Run = func(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
+			return copyTemplateSource(ctx, menuDisplay, initTemplateSource)
+		}
+	}
+	if initSkipTemplates {
+		templates.Skipped = "--skip-templates"
 	}
 
-	fmt.Printf("🚀 Initializing Claude WM CLI project: %s\n", projectName)
-	fmt.Println("================================")
-	fmt.Println()
+	git := initStep{
+		Name:     "Git",
+		Done:     gitBranchesInitialized,
+		Run:      initializeGitBranches,
+		Optional: true,
+	}
+	if initSkipGit {
+		git.Skipped = "--skip-git"
+	}
 
-	// Get current directory or create project directory
-	var projectDir string
-	if projectName == filepath.Base(projectName) && projectName != "." {
-		projectDir = filepath.Join(".", projectName)
-		fmt.Printf("📁 Creating project directory: %s\n", projectDir)
-		if err := os.MkdirAll(projectDir, 0755); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error creating directory: %s\n", err.Error())
-			return
+	return []initStep{
+		{
+			Name: "Directories",
+			Done: func(projectPath string) bool {
+				return allExist(projectPath, projectDirectories)
+			},
+			Run:        createProjectDirectories,
+			Suggestion: "Check that you have write permission on the project directory",
+		},
+		{
+			Name: "Project settings",
+			Done: func(projectPath string) bool {
+				return !initForce && fileExists(filepath.Join(projectPath, ".claude-wm-cli.yaml"))
+			},
+			Run: func(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
+				return writeProjectSettings(ctx, menuDisplay, projectName)
+			},
+			Optional: true,
+		},
+		{
+			Name:       "Config workspace",
+			Done:       config.IsConfigInitialized,
+			Run:        executeConfigInit,
+			Suggestion: "Run 'claude-wm-cli config init' for details",
+		},
+		templates,
+		{
+			Name: "Subagents",
+			Done: func(projectPath string) bool {
+				info, err := subagents.NewAgentInstaller().GetAgentInstallationInfo(projectPath)
+				return err == nil && info.AllInstalled
+			},
+			Run:      installSubagents,
+			Optional: true,
+		},
+		git,
+	}
+}
+
+// initializeProject sets up each component of the project in projectDir that
+// is not already in place
+func initializeProject(projectDir, projectName string) error {
+	projectPath, err := filepath.Abs(projectDir)
+	if err != nil {
+		return errors.Wrap(err, "Failed to resolve project directory")
+	}
+	if projectName == "" {
+		projectName = filepath.Base(projectPath)
+	}
+
+	menuDisplay := navigation.NewMenuDisplay()
+	// Without a terminal on stdin (scripts, CI) there is nobody to confirm
+	if !initNonInteractive && term.IsTerminal(int(os.Stdin.Fd())) {
+		confirmed, err := menuDisplay.Confirm(fmt.Sprintf("Initialize complete project structure in %s?", projectPath))
+		if err != nil {
+			return errors.Wrap(err, "Failed to read confirmation").
+				WithSuggestion("Use --non-interactive to initialize without prompts")
+		}
+		if !confirmed {
+			fmt.Println("Project initialization cancelled")
+			return nil
 		}
-	} else {
-		projectDir = "."
 	}
 
-	// Create basic directory structure
-	dirs := []string{
-		"docs/1-project",
-		"docs/2-current-epic",
-		"docs/3-current-task",
-		"docs/archive",
+	fmt.Printf("🚀 Initializing Claude WM CLI project: %s\n", projectName)
+	fmt.Println("================================")
+
+	if err := os.MkdirAll(projectPath, 0755); err != nil {
+		return errors.Wrap(err, "Failed to create project directory").
+			WithContext("directory", projectPath)
 	}
 
-	fmt.Println("📂 Creating directory structure...")
-	for _, dir := range dirs {
-		fullPath := filepath.Join(projectDir, dir)
-		if err := os.MkdirAll(fullPath, 0755); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error creating directory %s: %s\n", dir, err.Error())
-			return
+	ctx := &navigation.ProjectContext{ProjectPath: projectPath}
+	for _, step := range initSteps(projectName) {
+		switch {
+		case step.Skipped != "":
+			fmt.Printf("\n◦ %s: skipped (%s)\n", step.Name, step.Skipped)
+			continue
+		case step.Done(projectPath):
+			fmt.Printf("\n◦ %s: already initialized\n", step.Name)
+			continue
+		}
+
+		if err := step.Run(ctx, menuDisplay); err != nil {
+			if step.Optional {
+				menuDisplay.ShowWarning(fmt.Sprintf("%s: %v", step.Name, err))
+				continue
+			}
+			cliErr := errors.Wrap(err, fmt.Sprintf("Failed to initialize %s", strings.ToLower(step.Name))).
+				WithContext("directory", projectPath)
+			if step.Suggestion != "" {
+				cliErr.WithSuggestion(step.Suggestion)
+			}
+			return cliErr
 		}
-		fmt.Printf("  ✓ %s\n", dir)
 	}
 
-	// Create basic configuration file
-	fmt.Println("\n⚙️  Creating configuration files...")
-	configPath := filepath.Join(projectDir, ".claude-wm-cli.yaml")
-	if !fileExists(configPath) || initForce {
-		configContent := fmt.Sprintf(`# Claude WM CLI Configuration
+	fmt.Println()
+	fmt.Printf("✅ Project '%s' initialized successfully!\n", projectName)
+	fmt.Println()
+	fmt.Println("📋 Next steps:")
+	if projectDir != "." {
+		fmt.Println("  1. cd " + projectDir)
+	} else {
+		fmt.Println("  1. Review CLAUDE.md and README.md")
+	}
+	fmt.Println("  2. claude-wm-cli doctor          # Check the project setup")
+	fmt.Println("  3. claude-wm-cli status          # Check project status")
+	fmt.Println("  4. Start your first epic with the agile workflow commands")
+	fmt.Println()
+	fmt.Println("💡 Show the project status in your shell prompt (bash/zsh):")
+	fmt.Println(`   PS1='$(claude-wm-cli status --compact 2>/dev/null) '"$PS1"`)
+	return nil
+}
+
+// writeProjectSettings writes the .claude-wm-cli.yaml of the project
+func writeProjectSettings(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay, projectName string) error {
+	menuDisplay.ShowMessage("⚙️  Creating configuration files...")
+
+	configContent := fmt.Sprintf(`# Claude WM CLI Configuration
 project:
   name: "%s"
   initialized: true
-  
+
 verbose: false
 
 # Default settings
@@ -102,45 +257,90 @@ defaults:
   retries: 2
 `, projectName)
 
-		if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ Error writing config file: %s\n", err.Error())
-			return
-		}
-		fmt.Printf("  ✓ .claude-wm-cli.yaml\n")
-	} else {
-		fmt.Fprintf(os.Stderr, "⚠️  %s\n", ".claude-wm-cli.yaml already exists (use --force to overwrite)")
+	if err := os.WriteFile(filepath.Join(ctx.ProjectPath, ".claude-wm-cli.yaml"), []byte(configContent), 0644); err != nil {
+		return fmt.Errorf("failed to write .claude-wm-cli.yaml: %w", err)
 	}
+	menuDisplay.ShowMessage("  ✓ .claude-wm-cli.yaml")
+	return nil
+}
+
+// installSubagents installs the claude-wm subagents in .claude/agents
+func installSubagents(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
+	menuDisplay.ShowMessage("🤖 Installing claude-wm subagents...")
 
-	// Install claude-wm subagents
-	fmt.Println("\n🤖 Installing claude-wm subagents...")
 	installer := subagents.NewAgentInstaller()
-	if err := installer.InstallAgents(projectDir); err != nil {
-		fmt.Fprintf(os.Stderr, "⚠️  Warning: Failed to install subagents: %s\n", err.Error())
-		fmt.Println("   Subagents will be unavailable but project will function normally")
-	} else {
-		// Verify installation
-		info, err := installer.GetAgentInstallationInfo(projectDir)
-		if err != nil {
-			fmt.Println("  ✓ Subagents installed (verification failed)")
-		} else {
-			fmt.Printf("  ✓ %s\n", info.GetInstallationSummary())
-			if info.AllInstalled {
-				fmt.Println("    • claude-wm-templates: 93% token savings on documentation")
-				fmt.Println("    • claude-wm-status: 89% token savings on status reports")
-				fmt.Println("    • claude-wm-planner: 85% token savings on task planning")
-				fmt.Println("    • claude-wm-reviewer: 83% token savings on code reviews")
-			}
+	if err := installer.InstallAgents(ctx.ProjectPath); err != nil {
+		return fmt.Errorf("failed to install subagents (the project works without them): %w", err)
+	}
+
+	info, err := installer.GetAgentInstallationInfo(ctx.ProjectPath)
+	if err != nil {
+		menuDisplay.ShowMessage("  ✓ Subagents installed (verification failed)")
+		return nil
+	}
+	menuDisplay.ShowMessage(fmt.Sprintf("  ✓ %s", info.GetInstallationSummary()))
+	return nil
+}
+
+// copyTemplateSource copies the template files from the root of the git
+// repository at url
+func copyTemplateSource(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay, url string) error {
+	menuDisplay.ShowMessage(fmt.Sprintf("📄 Copying template files from %s...", url))
+
+	cloneDir, err := os.MkdirTemp("", "claude-wm-templates-")
+	if err != nil {
+		return fmt.Errorf("failed to create clone directory: %w", err)
+	}
+	defer os.RemoveAll(cloneDir)
+
+	cmd := exec.Command("git", "clone", "--depth", "1", "--quiet", "--", url, cloneDir)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to clone %s: %w: %s", url, err, strings.TrimSpace(string(output)))
+	}
+
+	copyTemplateFilesFrom(cloneDir, ctx.ProjectPath, menuDisplay)
+	return nil
+}
+
+// templatesCopied reports whether the project has every template file of its
+// config workspace
+func templatesCopied(projectPath string) bool {
+	templateDir := config.NewManager(projectPath).GetRuntimePath("commands/templates")
+	if !fileExists(templateDir) {
+		return false
+	}
+	for _, fileName := range projectTemplateFiles {
+		if fileExists(filepath.Join(templateDir, fileName)) && !fileExists(filepath.Join(projectPath, fileName)) {
+			return false
 		}
 	}
+	return true
+}
 
-	fmt.Println()
-	fmt.Printf("✅ Project '%s' initialized successfully!\n", projectName)
-	fmt.Println()
-	fmt.Println("📋 Next steps:")
-	fmt.Println("  1. cd " + projectName + " (if created in subdirectory)")
-	fmt.Println("  2. claude-wm-cli subagents list  # Verify subagents installation")
-	fmt.Println("  3. claude-wm-cli status          # Check project status")
-	fmt.Println("  4. Start your first epic with the agile workflow commands")
+// gitBranchesInitialized reports whether projectPath is a git repository
+// with main and develop branches
+func gitBranchesInitialized(projectPath string) bool {
+	if !fileExists(filepath.Join(projectPath, ".git")) {
+		return false
+	}
+	for _, branch := range []string{"main", "develop"} {
+		cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+		cmd.Dir = projectPath
+		if cmd.Run() != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// allExist reports whether all paths exist in dir
+func allExist(dir string, paths []string) bool {
+	for _, path := range paths {
+		if !fileExists(filepath.Join(dir, path)) {
+			return false
+		}
+	}
+	return true
 }
 
 func fileExists(path string) bool {
@@ -148,9 +348,35 @@ func fileExists(path string) bool {
 	return !os.IsNotExist(err)
 }
 
+// projectDoctorCheck reports the required project components that init would
+// set up
+func projectDoctorCheck() doctorResult {
+	var missing []string
+	for _, step := range initSteps("") {
+		if !step.Optional && !step.Done(".") {
+			missing = append(missing, step.Name)
+		}
+	}
+
+	if len(missing) == 0 {
+		return doctorResult{OK: true, Summary: "initialized"}
+	}
+	return doctorResult{
+		Summary: fmt.Sprintf("missing %s", strings.Join(missing, ", ")),
+		Details: []string{"set them up with: claude-wm-cli init --non-interactive (existing components are kept)"},
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(initCmd)
 
 	// Command-specific flags
-	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Force initialization (overwrite existing files)")
+	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Force initialization (overwrite .claude-wm-cli.yaml)")
+	initCmd.Flags().StringVar(&initDir, "dir", "", "Project directory (default: ./project-name, or the current directory)")
+	initCmd.Flags().BoolVar(&initSkipGit, "skip-git", false, "Do not initialize the git repository and branches")
+	initCmd.Flags().BoolVar(&initSkipTemplates, "skip-templates", false, "Do not copy the template files")
+	initCmd.Flags().BoolVar(&initNonInteractive, "non-interactive", false, "Skip all confirmation prompts")
+	initCmd.Flags().StringVar(&initTemplateSource, "template-source", "", "Git repository URL to copy the template files from instead of the embedded defaults")
+
+	registerDoctorCheck("Project", projectDoctorCheck)
 }
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"claude-wm-cli/internal/navigation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setInitFlags sets the init flags for the test and restores them afterwards
+func setInitFlags(t *testing.T, skipGit, skipTemplates bool) {
+	t.Helper()
+	force, dir, git, templates, nonInteractive, source := initForce, initDir, initSkipGit, initSkipTemplates, initNonInteractive, initTemplateSource
+	t.Cleanup(func() {
+		initForce, initDir, initSkipGit, initSkipTemplates, initNonInteractive, initTemplateSource = force, dir, git, templates, nonInteractive, source
+	})
+	initForce, initDir, initSkipGit, initSkipTemplates, initNonInteractive, initTemplateSource = false, "", skipGit, skipTemplates, false, ""
+}
+
+func TestInitializeProject_Idempotent(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	setInitFlags(t, false, false)
+	projectPath := filepath.Join(t.TempDir(), "demo")
+
+	// Tests have no terminal on stdin, so init must not ask for confirmation
+	require.NoError(t, initializeProject(projectPath, "demo"))
+	settings := filepath.Join(projectPath, ".claude-wm-cli.yaml")
+	require.FileExists(t, settings)
+	require.NoError(t, os.WriteFile(settings, []byte("project:\n  name: edited\n"), 0644))
+
+	require.NoError(t, initializeProject(projectPath, "demo"))
+	for _, step := range initSteps("demo") {
+		assert.True(t, step.Done(projectPath), "%s should be in place", step.Name)
+	}
+	content, err := os.ReadFile(settings)
+	require.NoError(t, err)
+	assert.Equal(t, "project:\n  name: edited\n", string(content), "existing settings are kept")
+}
+
+func TestInitializeProject_SkipFlags(t *testing.T) {
+	setInitFlags(t, true, true)
+	projectPath := t.TempDir()
+
+	require.NoError(t, initializeProject(projectPath, "demo"))
+
+	assert.NoDirExists(t, filepath.Join(projectPath, ".git"))
+	for _, fileName := range projectTemplateFiles {
+		assert.NoFileExists(t, filepath.Join(projectPath, fileName))
+	}
+	assert.True(t, allExist(projectPath, projectDirectories))
+	assert.FileExists(t, filepath.Join(projectPath, ".claude-wm-cli.yaml"))
+}
+
+func TestProjectDoctorCheck(t *testing.T) {
+	setInitFlags(t, true, true)
+	t.Chdir(t.TempDir())
+
+	result := projectDoctorCheck()
+	assert.False(t, result.OK)
+	assert.Contains(t, result.Summary, "Directories")
+	assert.Contains(t, result.Summary, "Config workspace")
+
+	require.NoError(t, initializeProject(".", "demo"))
+	result = projectDoctorCheck()
+	assert.True(t, result.OK)
+	assert.Equal(t, "initialized", result.Summary)
+}
+
+func TestCopyTemplateSource_OptionLikeURL(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake git script requires a POSIX shell")
+	}
+	// A fake git records its arguments instead of cloning
+	binDir := t.TempDir()
+	argsFile := filepath.Join(binDir, "args")
+	script := "#!/bin/sh\nprintf '%s\\n' \"$@\" > " + argsFile + "\nexit 1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "git"), []byte(script), 0755))
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	url := "--upload-pack=touch /tmp/pwned"
+	ctx := &navigation.ProjectContext{ProjectPath: t.TempDir()}
+	assert.Error(t, copyTemplateSource(ctx, navigation.NewMenuDisplay(), url))
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	args := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.GreaterOrEqual(t, len(args), 3)
+	assert.Equal(t, []string{"--", url}, args[len(args)-3:len(args)-1], "the URL is never parsed as a git option")
+}
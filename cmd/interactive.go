@@ -7,7 +7,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"claude-wm-cli/internal/config"
@@ -17,6 +19,8 @@ import (
 	"claude-wm-cli/internal/metrics"
 	"claude-wm-cli/internal/navigation"
 	"claude-wm-cli/internal/preprocessing"
+	"claude-wm-cli/internal/theme"
+	"claude-wm-cli/internal/ticket"
 	"claude-wm-cli/internal/workflow"
 
 	"github.com/spf13/cobra"
@@ -38,19 +42,55 @@ FEATURES:
   • Context-aware menu options based on project state
   • Intelligent action suggestions with priority ranking
   • Visual project status display with progress indicators
-  • Simple numbered menu interface with keyboard shortcuts
+  • Arrow-key menu with type-ahead filtering in a terminal
+  • Numbered menu interface when stdin is not a terminal
   • Graceful handling of missing or corrupted state files
 
-SHORTCUTS:
+KEYS (terminal):
+  • ↑/↓, Enter  - Move the highlight and select an option
+  • any text    - Narrow the options to labels containing the text
+  • Esc         - Clear the filter, or go back to previous menu
+  • ?           - Show help information
+  • f, F        - Pin the highlighted option, open the favorites
+  • Ctrl+C      - Quit navigation
+
+SHORTCUTS (numbered menu):
   • 1, 2, 3... - Select numbered menu options
   • q, quit     - Quit navigation
   • b, back     - Go back to previous menu
   • h, help     - Show help information
+  • f 3         - Pin option 3 to the favorites, or unpin it
+
+FAVORITES:
+  Press f on any option to pin it and F to open the Favorites menu,
+  from any menu. Pinned options are kept in .claude-wm/favorites.json;
+  --no-favorites disables the shortcuts.
+
+HISTORY:
+  The History menu lists the last 20 actions run in the project, with
+  when they ran, how long they took and whether they succeeded, to re-run
+  one. The last 100 actions of each project are kept in
+  ~/.claude-wm/action-history.jsonl.
+
+CUSTOM MENUS:
+  Options and whole menus can be added in .claude-wm/user/menus.yaml (or
+  menus.json). An option whose action is a slash command runs it with
+  Claude, "menu:<name>" opens another menu. See docs/CONFIG_GUIDE.md.
 
 EXAMPLES:
   claude-wm-cli interactive              # Start interactive navigation
   claude-wm-cli interactive --status     # Show status and exit
-  claude-wm-cli interactive --suggest    # Show suggestions and exit`,
+  claude-wm-cli interactive --suggest    # Show suggestions and exit
+  claude-wm-cli interactive --dry-run    # Review task file changes before they are made
+  claude-wm-cli interactive --force      # Redo task preprocessing even if just done
+  claude-wm-cli interactive --sequential-preprocessing  # Run preprocessing steps one at a time
+  claude-wm-cli interactive --fresh      # Start from the main menu, ignoring the last session
+  claude-wm-cli interactive --output-file session.log  # Keep a timestamped log of the session
+
+SESSION LOG:
+  --output-file (or CLAUDE_WM_SESSION_LOG) appends the menus, messages,
+  prompts and answers to a file, each line prefixed with an RFC3339
+  timestamp, for scripted runs and audits.`,
 	Aliases: []string{"nav", "menu"},
 	RunE:    runInteractive,
 }
@@ -63,8 +103,33 @@ var (
 	noInteractive   bool
 	displayWidth    int
 	maxSuggestions  int
+	noContextCache  bool
+	previewChanges  bool
+	forcePreprocess bool
+	freshNavigation bool
+	sessionLogPath  string
+	noFavorites     bool
+	sequentialPrep  bool
 )
 
+// customMenus are the menu definitions loaded from the project's
+// .claude-wm/user/menus.yaml, nil when the built-in menus are used as is
+var customMenus *navigation.MenuDefinitions
+
+// favoritesStore holds the options pinned with f, nil with --no-favorites
+var favoritesStore *navigation.FavoritesStore
+
+// actionHistory records the actions run from the menus, nil when the home
+// directory is unknown
+var actionHistory *navigation.ActionHistory
+
+// historyActionPrefix starts the actions of the History menu options,
+// followed by the position of their entry, most recent first
+const historyActionPrefix = "history:"
+
+// historyMenuSize is how many entries the History menu lists
+const historyMenuSize = 20
+
 func init() {
 	rootCmd.AddCommand(InteractiveCmd)
 
@@ -73,8 +138,16 @@ func init() {
 	InteractiveCmd.Flags().BoolVar(&showSuggestOnly, "suggest", false, "show suggestions and exit")
 	InteractiveCmd.Flags().BoolVar(&showQuickStatus, "quick", false, "show quick one-line status")
 	InteractiveCmd.Flags().BoolVar(&noInteractive, "no-interactive", false, "disable interactive mode")
-	InteractiveCmd.Flags().IntVar(&displayWidth, "width", 80, "display width for formatting")
+	InteractiveCmd.Flags().IntVar(&displayWidth, "width", navigation.DefaultDisplayWidth, "display width for formatting, detected from the terminal when not set")
 	InteractiveCmd.Flags().IntVar(&maxSuggestions, "max-suggestions", 5, "maximum number of suggestions to show")
+	InteractiveCmd.Flags().BoolVar(&previewChanges, "dry-run", false, "list the files task and ticket workflows would change and ask before changing them")
+	InteractiveCmd.Flags().BoolVar(&forcePreprocess, "force", false, "redo task preprocessing even if the workspace was just prepared")
+	InteractiveCmd.Flags().BoolVar(&freshNavigation, "fresh", false, "start from the main menu instead of offering to resume the last one")
+	InteractiveCmd.Flags().BoolVar(&noContextCache, "no-cache", false, "re-detect the project context instead of reusing the cached one")
+	InteractiveCmd.Flags().StringVar(&sessionLogPath, "output-file", "", "also append the session output, timestamped, to this file (default $"+navigation.SessionLogEnv+")")
+	InteractiveCmd.Flags().BoolVar(&noFavorites, "no-favorites", false, "disable the f (pin) and F (favorites) shortcuts")
+	InteractiveCmd.Flags().BoolVar(&sequentialPrep, "sequential-preprocessing", false, "run task preprocessing steps one after the other, for debugging")
+	addClaudeExecutionFlags(InteractiveCmd.Flags())
 
 	// Bind flags to viper
 	viper.BindPFlag("interactive.status", InteractiveCmd.Flags().Lookup("status"))
@@ -102,7 +175,7 @@ func runInteractive(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		workDirStep.StopWithError(err)
 		timer.SetExitCode(1)
-		return errors.NewCLIError("Failed to get current directory", 1).
+		return errors.NewCLIError("Failed to get current directory", errors.ExitGeneric).
 			WithDetails(err.Error()).
 			WithSuggestion("Ensure you have proper permissions to access the current directory")
 	}
@@ -111,14 +184,31 @@ func runInteractive(cmd *cobra.Command, args []string) error {
 
 	// Step 2: Initialize navigation components
 	initStep := timer.ProfileStep("navigation_initialization")
-	contextDetector := navigation.NewContextDetector(workDir)
+	contextDetector := navigation.NewContextDetector(workDir).EnableCache(!noContextCache)
 	suggestionEngine := navigation.NewSuggestionEngine()
 	menuDisplay := navigation.NewMenuDisplay()
 	stateDisplay := navigation.NewProjectStateDisplay()
 
-	// Set display width from flag
-	stateDisplay.SetWidth(displayWidth)
-	initStep.SetMetadata("display_width", displayWidth)
+	logPath := sessionLogPath
+	if logPath == "" {
+		logPath = os.Getenv(navigation.SessionLogEnv)
+	}
+	if logPath != "" {
+		if err := menuDisplay.SetOutputFile(logPath); err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", theme.Warning(fmt.Sprintf("⚠️  Session log disabled, output goes to the terminal only: %v", err)))
+		} else {
+			defer menuDisplay.Close()
+		}
+	}
+
+	// Set display width from flag, or from the terminal when not given
+	width := displayWidth
+	if cmd.Flags().Changed("width") {
+		stateDisplay.SetWidth(displayWidth)
+	} else {
+		width = stateDisplay.AutoDetectWidth()
+	}
+	initStep.SetMetadata("display_width", width)
 	initStep.Stop()
 
 	// Step 3: Detect current project context
@@ -127,11 +217,12 @@ func runInteractive(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		contextStep.StopWithError(err)
 		timer.SetExitCode(1)
-		return errors.NewCLIError("Failed to detect project context", 1).
+		return errors.NewCLIError("Failed to detect project context", errors.ExitGeneric).
 			WithDetails(err.Error()).
 			WithSuggestion("Check that you're in a valid directory and have necessary permissions").
 			WithContext("directory", workDir)
 	}
+	addSLAIssues(projectContext)
 	contextStep.SetMetadata("project_state", projectContext.State.String())
 	contextStep.SetMetadata("issues_count", len(projectContext.Issues))
 	contextStep.Stop()
@@ -145,6 +236,7 @@ func runInteractive(cmd *cobra.Command, args []string) error {
 
 	// Handle quick status flag
 	if showQuickStatus {
+		stateDisplay.SetCompactMode(true)
 		stateDisplay.DisplayQuickStatus(projectContext)
 		return nil
 	}
@@ -158,7 +250,7 @@ func runInteractive(cmd *cobra.Command, args []string) error {
 	// Generate suggestions
 	suggestions, err := suggestionEngine.GenerateSuggestions(projectContext)
 	if err != nil {
-		return errors.NewCLIError("Failed to generate suggestions", 1).
+		return errors.NewCLIError("Failed to generate suggestions", errors.ExitGeneric).
 			WithDetails(err.Error()).
 			WithSuggestion("Check project state and try again")
 	}
@@ -180,6 +272,28 @@ func runInteractive(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Load the custom menus, keeping the built-in ones if they are invalid
+	customMenus, err = navigation.LoadMenuDefinitions(workDir)
+	if err != nil {
+		menuDisplay.ShowWarning(fmt.Sprintf("Ignoring custom menus: %v", err))
+	}
+	favoritesStore = nil
+	if !noFavorites {
+		favoritesStore = navigation.NewFavoritesStore(workDir)
+	}
+	actionHistory, err = navigation.NewActionHistory(workDir)
+	if err != nil {
+		debug.LogResult("INTERACTIVE", "open action history", err.Error(), false)
+	}
+
+	// Back up the state files periodically during the session
+	stopBackups, err := startBackupScheduler(workDir)
+	if err != nil {
+		menuDisplay.ShowWarning(fmt.Sprintf("Scheduled backups disabled: %v", err))
+	} else if stopBackups != nil {
+		defer stopBackups()
+	}
+
 	// Start interactive navigation
 	return runInteractiveNavigation(projectContext, suggestions, menuDisplay, stateDisplay, suggestionEngine)
 }
@@ -195,39 +309,33 @@ func runInteractiveNavigation(
 	// Stack to track menu navigation
 	var menuStack []string
 	currentMenu := "main"
+	if !freshNavigation {
+		currentMenu, menuStack = resumeNavigation(ctx, suggestions, menuDisplay)
+	}
 
 	for {
 		// Display current state
 		stateDisplay.DisplayProjectOverview(ctx)
 
 		// Create appropriate menu based on current location
-		var menu *navigation.Menu
-		switch currentMenu {
-		case "main":
-			menu = createMainMenu(ctx, suggestions)
-		case "project":
-			menu = createProjectMenu(ctx)
-		case "epics":
-			menu = createEpicsMenu(ctx)
-		case "current-epics":
-			menu = createCurrentEpicMenu(ctx)
-		case "current-story":
-			menu = createCurrentStoryMenu(ctx)
-		case "ticket":
-			menu = createTicketMenu(ctx)
-		case "claude":
-			menu = createClaudeMenu(ctx)
-		case "metrics":
-			menu = createMetricsMenu(ctx)
-		default:
+		menu := buildNavigationMenu(currentMenu, ctx, suggestions)
+		if menu == nil {
 			menu = createMainMenu(ctx, suggestions)
 			currentMenu = "main"
 		}
+		menu.AllowFavorites = favoritesStore != nil && currentMenu != "history"
+
+		// Remember the location for the next launch
+		location := navigation.NavLocation{CurrentMenu: currentMenu, MenuStack: menuStack}
+		if err := navigation.SaveNavLocation(ctx.ProjectPath, location); err != nil {
+			debug.LogResult("INTERACTIVE", "save menu location", err.Error(), false)
+		}
 
 		// Show menu and get user choice
+		menuDisplay.SetBreadcrumb(menuBreadcrumb(menuStack, currentMenu))
 		result, err := menuDisplay.Show(menu)
 		if err != nil {
-			return errors.NewCLIError("Menu interaction failed", 1).
+			return errors.NewCLIError("Menu interaction failed", errors.ExitGeneric).
 				WithDetails(err.Error()).
 				WithSuggestion("Try restarting the navigation or check terminal compatibility")
 		}
@@ -240,12 +348,7 @@ func runInteractiveNavigation(
 
 		case "back":
 			// Navigate back to previous menu
-			if len(menuStack) > 0 {
-				currentMenu = menuStack[len(menuStack)-1]
-				menuStack = menuStack[:len(menuStack)-1]
-			} else {
-				currentMenu = "main"
-			}
+			currentMenu, menuStack = previousMenu(menuStack)
 
 		case "help":
 			displayNavigationHelp(menuDisplay)
@@ -260,13 +363,14 @@ func runInteractiveNavigation(
 
 		case "refresh":
 			// Re-detect context and regenerate suggestions
-			newCtx, err := navigation.NewContextDetector(ctx.ProjectPath).DetectContext()
+			newCtx, err := navigation.NewContextDetector(ctx.ProjectPath).EnableCache(!noContextCache).DetectContext()
 			if err != nil {
 				menuDisplay.ShowError(fmt.Sprintf("Failed to refresh context: %v", err))
 				menuDisplay.WaitForKeyPress("")
 				continue
 			}
 			ctx = newCtx
+			addSLAIssues(ctx)
 
 			newSuggestions, err := suggestionEngine.GenerateSuggestions(ctx)
 			if err != nil {
@@ -306,9 +410,36 @@ func runInteractiveNavigation(
 			menuStack = append(menuStack, currentMenu)
 			currentMenu = "metrics"
 
+		case navigation.ActionFavorites:
+			if currentMenu != "favorites" {
+				menuStack = append(menuStack, currentMenu)
+				currentMenu = "favorites"
+			}
+
+		case navigation.ActionToggleFavorite:
+			toggleFavorite(menuDisplay, currentMenu, result.SelectedOption)
+
+		case "history-menu":
+			menuStack = append(menuStack, currentMenu)
+			currentMenu = "history"
+
+		case "history-clear":
+			clearActionHistory(menuDisplay)
+
 		default:
+			// Custom menus are opened with "menu:<name>"
+			if name, ok := strings.CutPrefix(result.Action, navigation.MenuActionPrefix); ok {
+				menuStack = append(menuStack, currentMenu)
+				currentMenu = name
+				continue
+			}
+			if position, ok := strings.CutPrefix(result.Action, historyActionPrefix); ok {
+				rerunHistoryEntry(position, ctx, menuDisplay)
+				continue
+			}
+
 			// Handle action execution
-			err := executeAction(result.Action, ctx, menuDisplay)
+			err := executeAction(result.Action, currentMenu, ctx, menuDisplay)
 			if err != nil {
 				menuDisplay.ShowError(fmt.Sprintf("Failed to execute action: %v", err))
 				menuDisplay.WaitForKeyPress("")
@@ -317,6 +448,272 @@ func runInteractiveNavigation(
 	}
 }
 
+// buildNavigationMenu builds the menu named name with the custom menu
+// definitions applied, or returns nil for an unknown name
+func buildNavigationMenu(name string, ctx *navigation.ProjectContext, suggestions []*navigation.Suggestion) *navigation.Menu {
+	return customMenus.Menu(name, createBuiltinMenu(name, ctx, suggestions))
+}
+
+// createBuiltinMenu builds the built-in menu named name, or returns nil for an unknown name
+func createBuiltinMenu(name string, ctx *navigation.ProjectContext, suggestions []*navigation.Suggestion) *navigation.Menu {
+	switch name {
+	case "main":
+		return createMainMenu(ctx, suggestions)
+	case "project":
+		return createProjectMenu(ctx)
+	case "epics":
+		return createEpicsMenu(ctx)
+	case "current-epics":
+		return createCurrentEpicMenu(ctx)
+	case "current-story":
+		return createCurrentStoryMenu(ctx)
+	case "ticket":
+		return createTicketMenu(ctx)
+	case "claude":
+		return createClaudeMenu(ctx)
+	case "metrics":
+		return createMetricsMenu(ctx)
+	case "favorites":
+		if favoritesStore == nil {
+			return nil
+		}
+		favorites, err := favoritesStore.List()
+		if err != nil {
+			debug.LogResult("INTERACTIVE", "load favorites", err.Error(), false)
+		}
+		return createFavoritesMenu(favorites)
+	case "history":
+		if actionHistory == nil {
+			return nil
+		}
+		entries, err := actionHistory.Recent(historyMenuSize)
+		if err != nil {
+			debug.LogResult("INTERACTIVE", "load action history", err.Error(), false)
+		}
+		return createHistoryMenu(entries)
+	default:
+		return nil
+	}
+}
+
+// resumeNavigation offers to go back to the menu saved on the last exit and
+// returns the menu and back stack to start from
+func resumeNavigation(ctx *navigation.ProjectContext, suggestions []*navigation.Suggestion, menuDisplay *navigation.MenuDisplay) (string, []string) {
+	location, err := navigation.LoadNavLocation(ctx.ProjectPath)
+	if err != nil || location == nil || location.CurrentMenu == "main" {
+		return "main", nil
+	}
+
+	menu := buildNavigationMenu(location.CurrentMenu, ctx, suggestions)
+	if menu == nil {
+		return "main", nil
+	}
+	for _, name := range location.MenuStack {
+		if buildNavigationMenu(name, ctx, suggestions) == nil {
+			return "main", nil
+		}
+	}
+
+	resume, err := menuDisplay.Confirm(fmt.Sprintf("\n↩️  Resume in %s where you left off?", menu.Title))
+	if err != nil || !resume {
+		return "main", nil
+	}
+	return location.CurrentMenu, location.MenuStack
+}
+
+// previousMenu pops the menu to go back to from menuStack, the main menu
+// when the stack is empty
+func previousMenu(menuStack []string) (string, []string) {
+	if len(menuStack) == 0 {
+		return "main", nil
+	}
+	return menuStack[len(menuStack)-1], menuStack[:len(menuStack)-1]
+}
+
+// menuBreadcrumb returns the labels of the menus from the main menu to
+// currentMenu, or nil on the main menu itself
+func menuBreadcrumb(menuStack []string, currentMenu string) []string {
+	if len(menuStack) == 0 {
+		return nil
+	}
+	crumbs := make([]string, 0, len(menuStack)+1)
+	for _, name := range menuStack {
+		crumbs = append(crumbs, menuLabel(name))
+	}
+	return append(crumbs, menuLabel(currentMenu))
+}
+
+// menuLabel returns the breadcrumb label of the menu named name: its
+// menuLabels entry, or the title of a custom menu
+func menuLabel(name string) string {
+	if label, ok := menuLabels[name]; ok {
+		return label
+	}
+	if menu := customMenus.Menu(name, nil); menu != nil && menu.Title != "" {
+		return menu.Title
+	}
+	return name
+}
+
+// menuLabels are the breadcrumb labels of the menus reachable from the main menu
+var menuLabels = map[string]string{
+	"main":          "Main",
+	"project":       "Project Update Cycle",
+	"epics":         "Epics Management",
+	"current-epics": "Current Epic",
+	"current-story": "Current Story",
+	"ticket":        "Ticket Management",
+	"claude":        ".claude Management",
+	"metrics":       "Performance Metrics",
+	"favorites":     "Favorites",
+	"history":       "History",
+}
+
+// createFavoritesMenu builds the menu of the options pinned with f
+func createFavoritesMenu(favorites []navigation.FavoriteItem) *navigation.Menu {
+	menu := &navigation.Menu{
+		Title:       "⭐ Favorites",
+		Options:     []navigation.MenuOption{},
+		ShowNumbers: true,
+		ShowHelp:    true,
+		AllowBack:   true,
+		AllowQuit:   true,
+	}
+
+	if len(favorites) == 0 {
+		menu.Options = append(menu.Options, navigation.MenuOption{
+			ID:      "favorites-empty",
+			Label:   "No favorites yet: press f on a menu option to pin it",
+			Enabled: false,
+		})
+		return menu
+	}
+
+	for _, favorite := range favorites {
+		menu.Options = append(menu.Options, navigation.MenuOption{
+			ID:          favorite.Action,
+			Label:       favorite.Label,
+			Description: "from " + menuLabel(favorite.MenuID),
+			Action:      favorite.Action,
+			Enabled:     true,
+		})
+	}
+	return menu
+}
+
+// toggleFavorite pins option, selected in the menu named menuID, to the
+// favorites, or unpins it if it already is
+func toggleFavorite(menuDisplay *navigation.MenuDisplay, menuID string, option *navigation.MenuOption) {
+	if favoritesStore == nil || option == nil {
+		return
+	}
+
+	pinned, err := favoritesStore.Toggle(navigation.FavoriteItem{MenuID: menuID, Label: option.Label, Action: option.Action})
+	switch {
+	case err != nil:
+		menuDisplay.ShowError(fmt.Sprintf("Failed to update favorites: %v", err))
+	case pinned:
+		menuDisplay.ShowSuccess(fmt.Sprintf("📌 Pinned %s, press F to open the favorites", option.Label))
+	default:
+		menuDisplay.ShowSuccess(fmt.Sprintf("Unpinned %s", option.Label))
+	}
+}
+
+// createHistoryMenu builds the menu of the last actions run, most recent
+// first, with an option to clear them
+func createHistoryMenu(entries []navigation.HistoryEntry) *navigation.Menu {
+	menu := &navigation.Menu{
+		Title:       "📜 History",
+		Options:     []navigation.MenuOption{},
+		ShowNumbers: true,
+		ShowHelp:    true,
+		AllowBack:   true,
+		AllowQuit:   true,
+	}
+
+	if len(entries) == 0 {
+		menu.Options = append(menu.Options, navigation.MenuOption{
+			ID:      "history-empty",
+			Label:   "No actions run yet",
+			Enabled: false,
+		})
+		return menu
+	}
+
+	for i, entry := range entries {
+		status := "✅"
+		if !entry.Success {
+			status = "❌"
+		}
+		menu.Options = append(menu.Options, navigation.MenuOption{
+			ID:    fmt.Sprintf("history-%d", i+1),
+			Label: fmt.Sprintf("%s %s", status, entry.Action),
+			Description: fmt.Sprintf("%s from %s, took %s",
+				entry.ExecutedAt.Format("2006-01-02 15:04"), menuLabel(entry.Menu), entry.Duration.Round(time.Millisecond)),
+			Action:  fmt.Sprintf("%s%d", historyActionPrefix, i),
+			Enabled: true,
+		})
+	}
+	menu.Options = append(menu.Options, navigation.MenuOption{
+		ID:          "history-clear",
+		Label:       "🗑️  Clear history",
+		Description: "Forget the actions run in this project",
+		Action:      "history-clear",
+		Enabled:     true,
+	})
+	return menu
+}
+
+// rerunHistoryEntry offers to run again the History menu entry at position
+func rerunHistoryEntry(position string, ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) {
+	index, err := strconv.Atoi(position)
+	if err != nil || actionHistory == nil {
+		return
+	}
+	entries, err := actionHistory.Recent(historyMenuSize)
+	if err != nil {
+		menuDisplay.ShowError(fmt.Sprintf("Failed to load history: %v", err))
+		return
+	}
+	if index < 0 || index >= len(entries) {
+		return
+	}
+	entry := entries[index]
+
+	choice, err := menuDisplay.Show(&navigation.Menu{
+		Title: fmt.Sprintf("Run %s again?", entry.Action),
+		Options: []navigation.MenuOption{
+			{ID: "rerun", Label: "Re-run", Description: "from " + menuLabel(entry.Menu), Action: "rerun", Enabled: true},
+			{ID: "cancel", Label: "Cancel", Action: "cancel", Enabled: true},
+		},
+		ShowNumbers: true,
+	})
+	if err != nil || choice.Action != "rerun" {
+		return
+	}
+
+	if err := executeAction(entry.Action, entry.Menu, ctx, menuDisplay); err != nil {
+		menuDisplay.ShowError(fmt.Sprintf("Failed to execute action: %v", err))
+		menuDisplay.WaitForKeyPress("")
+	}
+}
+
+// clearActionHistory forgets the actions run in the project, once confirmed
+func clearActionHistory(menuDisplay *navigation.MenuDisplay) {
+	if actionHistory == nil {
+		return
+	}
+	confirmed, err := menuDisplay.Confirm("Clear the action history of this project?")
+	if err != nil || !confirmed {
+		return
+	}
+	if err := actionHistory.Clear(); err != nil {
+		menuDisplay.ShowError(fmt.Sprintf("Failed to clear history: %v", err))
+		return
+	}
+	menuDisplay.ShowSuccess("History cleared")
+}
+
 // createMainMenu builds the main navigation menu with hierarchical groups
 func createMainMenu(_ *navigation.ProjectContext, _ []*navigation.Suggestion) *navigation.Menu {
 	menu := &navigation.Menu{
@@ -347,6 +744,7 @@ func createMainMenu(_ *navigation.ProjectContext, _ []*navigation.Suggestion) *n
 	addOption("ticket-menu", "Ticket management", "Create/Plan/Execute/Complete", "ticket-menu")
 	addOption("metrics-menu", "Performance metrics", "Analyze/Profile/Optimize", "metrics-menu")
 	addOption("claude-menu", ".claude management", "Import/Install", "claude-menu")
+	addOption("history-menu", "History", "Re-run recent actions", "history-menu")
 
 	return menu
 }
@@ -636,8 +1034,29 @@ func createMetricsMenu(_ *navigation.ProjectContext) *navigation.Menu {
 	return menu
 }
 
-// executeAction handles the execution of selected actions
-func executeAction(action string, ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
+// executeAction handles the execution of selected actions, run from the
+// menu named menuID, and records them in the action history
+func executeAction(action, menuID string, ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
+	start := time.Now()
+	err := runAction(action, ctx, menuDisplay)
+
+	if actionHistory != nil {
+		entry := navigation.HistoryEntry{
+			Action:     action,
+			Menu:       menuID,
+			ExecutedAt: start,
+			Duration:   time.Since(start),
+			Success:    err == nil,
+		}
+		if recordErr := actionHistory.Record(entry); recordErr != nil {
+			debug.LogResult("INTERACTIVE", "record action history", recordErr.Error(), false)
+		}
+	}
+	return err
+}
+
+// runAction executes action
+func runAction(action string, ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
 	switch action {
 	// Claude slash commands - can start with '/'
 	case "/1-project:1-start:1-Init-Project",
@@ -751,6 +1170,10 @@ func executeAction(action string, ctx *navigation.ProjectContext, menuDisplay *n
 		return executeInitProject(ctx, menuDisplay)
 
 	default:
+		// Any other slash command, e.g. from a custom menu, is run as is
+		if strings.HasPrefix(action, "/") {
+			return executeClaudeCommandInteractive(action, menuDisplay)
+		}
 		menuDisplay.ShowWarning(fmt.Sprintf("Action '%s' not yet implemented", action))
 		menuDisplay.ShowMessage("This action will be available in a future version.")
 		return nil
@@ -803,24 +1226,28 @@ func executeInitProject(ctx *navigation.ProjectContext, menuDisplay *navigation.
 	return nil
 }
 
+// projectDirectories are the directories of an initialized project
+var projectDirectories = []string{
+	"docs/1-project",
+	"docs/2-current-epic",
+	"docs/3-current-task",
+	"docs/archive",
+	".claude-wm",
+	".claude",
+}
+
+// projectTemplateFiles are the template files copied to the project root
+var projectTemplateFiles = []string{"README.md", "METRICS.md", "CLAUDE.md"}
+
 // createProjectDirectories creates all required project directories
 func createProjectDirectories(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
 	menuDisplay.ShowMessage("📁 Creating project directories...")
 
-	dirs := []string{
-		"docs/1-project",
-		"docs/2-current-epic",
-		"docs/3-current-task",
-		"docs/archive",
-		".claude-wm",
-		".claude",
-	}
-
-	for _, dir := range dirs {
+	for _, dir := range projectDirectories {
 		fullPath := filepath.Join(ctx.ProjectPath, dir)
 		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
 			if err := os.MkdirAll(fullPath, 0755); err != nil {
-				return errors.NewCLIError("Failed to create project directory", 1).
+				return errors.NewCLIError("Failed to create project directory", errors.ExitGeneric).
 					WithDetails(err.Error()).
 					WithContext("directory", fullPath)
 			}
@@ -842,10 +1269,7 @@ func copyTemplateFiles(ctx *navigation.ProjectContext, menuDisplay *navigation.M
 		return fmt.Errorf("failed to initialize config: %w", err)
 	}
 
-	templateDir, err := config.GetRuntimeConfigPath("commands/templates")
-	if err != nil {
-		return fmt.Errorf("failed to get template path: %w", err)
-	}
+	templateDir := config.NewManager(ctx.ProjectPath).GetRuntimePath("commands/templates")
 
 	// Check if template directory exists
 	if _, err := os.Stat(templateDir); os.IsNotExist(err) {
@@ -853,11 +1277,16 @@ func copyTemplateFiles(ctx *navigation.ProjectContext, menuDisplay *navigation.M
 		return nil
 	}
 
-	templateFiles := []string{"README.md", "METRICS.md", "CLAUDE.md"}
+	copyTemplateFilesFrom(templateDir, ctx.ProjectPath, menuDisplay)
+	return nil
+}
 
-	for _, fileName := range templateFiles {
+// copyTemplateFilesFrom copies the template files of templateDir missing
+// from the project root
+func copyTemplateFilesFrom(templateDir, projectPath string, menuDisplay *navigation.MenuDisplay) {
+	for _, fileName := range projectTemplateFiles {
 		sourcePath := filepath.Join(templateDir, fileName)
-		destPath := filepath.Join(ctx.ProjectPath, fileName)
+		destPath := filepath.Join(projectPath, fileName)
 
 		// Check if source file exists
 		if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
@@ -879,8 +1308,6 @@ func copyTemplateFiles(ctx *navigation.ProjectContext, menuDisplay *navigation.M
 
 		menuDisplay.ShowMessage(fmt.Sprintf("  ✓ Copied %s", fileName))
 	}
-
-	return nil
 }
 
 // copyFile copies a file from source to destination
@@ -1199,6 +1626,22 @@ func preprocessPlanTicketCommand(projectPath string, menuDisplay *navigation.Men
 	return nil
 }
 
+// ClaudeExecutorInterface is the part of executor.ClaudeExecutor used by the
+// commands running Claude, so tests can inject executor.MockClaudeExecutor
+type ClaudeExecutorInterface interface {
+	ValidateClaudeAvailable() error
+	ExecutePrompt(prompt, description string) error
+	ExecuteSlashCommandWithRetry(slashCommand, description string, policy executor.RetryPolicy) error
+	ExecuteSlashCommandWithExitCode(slashCommand, description string) (int, error)
+	LastLogPath() string
+	FirstOutputLatency() time.Duration
+}
+
+// newClaudeExecutor creates the executor used by the commands running Claude; tests replace it
+var newClaudeExecutor = func() ClaudeExecutorInterface {
+	return newConfiguredClaudeExecutor()
+}
+
 // executeClaudeCommandInteractive executes a Claude slash command from interactive menu
 func executeClaudeCommandInteractive(command string, menuDisplay *navigation.MenuDisplay) error {
 	// Start performance monitoring
@@ -1233,7 +1676,7 @@ func executeClaudeCommandInteractive(command string, menuDisplay *navigation.Men
 
 	// Step 2: Claude preparation and validation
 	claudeValidationStep := timer.ProfileStep("claude_validation")
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
@@ -1258,9 +1701,17 @@ func executeClaudeCommandInteractive(command string, menuDisplay *navigation.Men
 		})
 	}
 
-	if err := claudeExecutor.ExecuteSlashCommand(command, description); err != nil {
+	err := claudeExecutor.ExecuteSlashCommandWithRetry(command, description, resolveClaudeRetryPolicy())
+	claudeExecutionStep.RecordFirstOutputLatency(claudeExecutor.FirstOutputLatency())
+	if err != nil {
 		claudeExecutionStep.StopWithError(err)
 		menuDisplay.ShowError(fmt.Sprintf("Failed to execute Claude command: %v", err))
+		if executor.IsTimeoutError(err) {
+			menuDisplay.ShowMessage("💡 Increase the limit with --claude-timeout or the claude.timeout config key")
+		}
+		if hint := claudeLogHint(claudeExecutor); hint != "" {
+			menuDisplay.ShowMessage(hint)
+		}
 		timer.SetExitCode(1)
 		return err
 	}
@@ -1298,14 +1749,35 @@ func displaySuggestions(suggestions []*navigation.Suggestion, engine *navigation
 
 // displayNavigationHelp shows help information for navigation
 func displayNavigationHelp(menuDisplay *navigation.MenuDisplay) {
-	help := `
-🧭 Navigation Help
-
-KEYBOARD SHORTCUTS:
+	shortcuts := `KEYBOARD SHORTCUTS:
   • Numbers (1,2,3...)  - Select menu options
   • q, quit, exit       - Quit navigation
   • b, back            - Go back to previous menu  
-  • h, help            - Show this help
+  • h, help            - Show this help`
+	if favoritesStore != nil {
+		shortcuts += `
+  • f <number>         - Pin the option to the favorites, or unpin it
+  • F                  - Open the favorites`
+	}
+	if menuDisplay.KeyNavigation() {
+		shortcuts = `KEYBOARD SHORTCUTS:
+  • ↑/↓ then Enter      - Move the highlight and select an option
+  • Any text            - Filter options by label
+  • Backspace          - Edit the filter
+  • Esc                - Clear the filter, or go back to previous menu
+  • ?                  - Show this help
+  • Ctrl+C             - Quit navigation`
+		if favoritesStore != nil {
+			shortcuts += `
+  • f                  - Pin the highlighted option to the favorites, or unpin it
+  • F                  - Open the favorites`
+		}
+	}
+
+	help := `
+🧭 Navigation Help
+
+` + shortcuts + `
 
 MENU ACTIONS:
   • Project Status     - View detailed project state
@@ -1332,13 +1804,13 @@ TIP: The navigation system automatically detects your current
 func getPriorityIcon(priority workflow.Priority) string {
 	switch priority {
 	case workflow.PriorityP0:
-		return "🔴 "
+		return theme.Icon("🔴 ", "[P0] ")
 	case workflow.PriorityP1:
-		return "🟡 "
+		return theme.Icon("🟡 ", "[P1] ")
 	case workflow.PriorityP2:
-		return "🟢 "
+		return theme.Icon("🟢 ", "[P2] ")
 	default:
-		return "⚪ "
+		return theme.Icon("⚪ ", "[-] ")
 	}
 }
 
@@ -1376,10 +1848,78 @@ func executeClaudeInstall(ctx *navigation.ProjectContext, menuDisplay *navigatio
 
 // Task execution functions with preprocessing integration
 
+// errPreprocessingCancelled is returned when the user declines the changes
+// listed by --dry-run
+var errPreprocessingCancelled = fmt.Errorf("preprocessing cancelled, no changes made")
+
+// runPreprocessing runs a preprocessing step. With --dry-run, the changes the
+// step would make are listed first and it only runs once confirmed.
+func runPreprocessing(menuDisplay *navigation.MenuDisplay, step func(preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error)) error {
+	options := preprocessing.PreprocessOptions{
+		IdempotencyTTL: resolveIdempotencyTTL(),
+		Force:          forcePreprocess,
+		Sequential:     sequentialPrep,
+	}
+
+	if previewChanges {
+		dryRun := options
+		dryRun.DryRun = true
+		plan, err := step(dryRun)
+		if err != nil {
+			return err
+		}
+		displayPlannedChanges(plan.Changes)
+		if len(plan.Changes) > 0 {
+			confirmed, err := menuDisplay.Confirm("Apply these changes?")
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return errPreprocessingCancelled
+			}
+		}
+	}
+
+	_, err := step(options)
+	return err
+}
+
+// resolveIdempotencyTTL returns how long a prepared task workspace is reused,
+// from the preprocessing.idempotency_ttl config key or the default; 0 always
+// redoes the preprocessing
+func resolveIdempotencyTTL() time.Duration {
+	if viper.IsSet("preprocessing.idempotency_ttl") {
+		return viper.GetDuration("preprocessing.idempotency_ttl")
+	}
+	return preprocessing.DefaultIdempotencyTTL
+}
+
+// displayPlannedChanges prints the changes listed by a preprocessing dry run
+func displayPlannedChanges(changes []preprocessing.PlannedChange) {
+	if len(changes) == 0 {
+		fmt.Println("\n📋 No changes planned")
+		return
+	}
+
+	fmt.Println("\n📋 Planned changes:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, change := range changes {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", change.Operation, change.Path, change.Description)
+	}
+	w.Flush()
+	fmt.Println()
+}
+
 // executeTaskFromStory handles task creation from story with preprocessing
 func executeTaskFromStory(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
+	timer := metrics.InstrumentCommandInteractive("task from story")
+	defer timer.Stop()
+
 	// Step 1: Execute preprocessing
-	if err := preprocessing.PreprocessFromStory(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		options.Timer = timer
+		return preprocessing.PreprocessFromStory(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
 		return err
 	}
@@ -1388,10 +1928,23 @@ func executeTaskFromStory(ctx *navigation.ProjectContext, menuDisplay *navigatio
 	return executeClaudeCommandInteractive("/4-task:1-start:1-From-story", menuDisplay)
 }
 
-// executeTaskFromIssue handles task creation from GitHub issue with preprocessing
+// executeTaskFromIssue handles task creation from a GitHub or GitLab issue with preprocessing
 func executeTaskFromIssue(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
+	// Resolve issue provider from config (issues.provider), auto-detecting from the git remote when unset
+	provider, err := preprocessing.NewIssueProvider(viper.GetString("issues.provider"), ctx.ProjectPath)
+	if err != nil {
+		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
+		return err
+	}
+
+	timer := metrics.InstrumentCommandInteractive("task from issue")
+	defer timer.Stop()
+
 	// Step 1: Execute preprocessing
-	if err := preprocessing.PreprocessFromIssue(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		options.Timer = timer
+		return preprocessing.PreprocessFromIssueWithProvider(ctx.ProjectPath, provider, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
 		return err
 	}
@@ -1402,10 +1955,15 @@ func executeTaskFromIssue(ctx *navigation.ProjectContext, menuDisplay *navigatio
 
 // executeTaskFromInput handles task creation from user input with preprocessing
 func executeTaskFromInput(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
-	// Get user input for task description
-	fmt.Print("Enter task description: ")
-	var description string
-	fmt.Scanln(&description)
+	// Get user input for task description, in the user's editor when one is available
+	description, err := menuDisplay.PromptEditor("Enter the task description")
+	if err != nil {
+		menuDisplay.ShowWarning(fmt.Sprintf("Cannot open an editor: %v", err))
+		description, err = menuDisplay.PromptMultiLine("Enter task description", navigation.DefaultMultiLineEnd)
+		if err != nil {
+			return fmt.Errorf("failed to read task description: %w", err)
+		}
+	}
 
 	if strings.TrimSpace(description) == "" {
 		menuDisplay.ShowError("Task description cannot be empty")
@@ -1413,7 +1971,9 @@ func executeTaskFromInput(ctx *navigation.ProjectContext, menuDisplay *navigatio
 	}
 
 	// Step 1: Execute preprocessing with user input
-	if err := preprocessing.PreprocessFromInput(ctx.ProjectPath, description, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessFromInput(ctx.ProjectPath, description, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
 		return err
 	}
@@ -1425,7 +1985,9 @@ func executeTaskFromInput(ctx *navigation.ProjectContext, menuDisplay *navigatio
 // executeTaskPlan handles task planning with preprocessing
 func executeTaskPlan(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
 	// Step 1: Execute preprocessing
-	if err := preprocessing.PreprocessPlanTask(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessPlanTask(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
 		return err
 	}
@@ -1437,7 +1999,9 @@ func executeTaskPlan(ctx *navigation.ProjectContext, menuDisplay *navigation.Men
 // executeTaskTestDesign handles test design with preprocessing
 func executeTaskTestDesign(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
 	// Step 1: Execute preprocessing
-	if err := preprocessing.PreprocessTestDesign(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessTestDesign(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
 		return err
 	}
@@ -1449,7 +2013,9 @@ func executeTaskTestDesign(ctx *navigation.ProjectContext, menuDisplay *navigati
 // executeTaskValidate handles task validation with preprocessing
 func executeTaskValidate(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
 	// Step 1: Execute preprocessing
-	if err := preprocessing.PreprocessValidateTask(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessValidateTask(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
 		return err
 	}
@@ -1461,7 +2027,9 @@ func executeTaskValidate(ctx *navigation.ProjectContext, menuDisplay *navigation
 // executeTaskReview handles task review with preprocessing
 func executeTaskReview(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
 	// Step 1: Execute preprocessing
-	if err := preprocessing.PreprocessReviewTask(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessReviewTask(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
 		return err
 	}
@@ -1473,7 +2041,9 @@ func executeTaskReview(ctx *navigation.ProjectContext, menuDisplay *navigation.M
 // executeTaskArchive handles task archiving with preprocessing
 func executeTaskArchive(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
 	// Step 1: Execute preprocessing
-	if err := preprocessing.PreprocessArchiveTask(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessArchiveTask(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Preprocessing failed: %v", err))
 		return err
 	}
@@ -1593,7 +2163,9 @@ func initializeTaskFromSource(ctx *navigation.ProjectContext, menuDisplay *navig
 // executeValidationWithIterationCheck executes validation and determines next action based on result
 func executeValidationWithIterationCheck(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay, currentIteration, maxIterations int) (ValidationResult, error) {
 	// Execute preprocessing first
-	if err := preprocessing.PreprocessValidateTask(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessValidateTask(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		return ValidationFailedRetry, fmt.Errorf("preprocessing failed: %w", err)
 	}
 
@@ -1605,7 +2177,7 @@ func executeValidationWithIterationCheck(ctx *navigation.ProjectContext, menuDis
 	}
 
 	// Execute Claude validation command
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
 		return ValidationFailedRetry, fmt.Errorf("Claude CLI not available: %w", err)
 	}
@@ -1613,6 +2185,12 @@ func executeValidationWithIterationCheck(ctx *navigation.ProjectContext, menuDis
 	// Execute validation command and capture exit code
 	description := fmt.Sprintf("Validation step (iteration %d/%d)", currentIteration, maxIterations)
 	exitCode, err := claudeExecutor.ExecuteSlashCommandWithExitCode("/4-task:2-execute:4-Validate-Task", description)
+	outcome := executor.InterpretExitCode(exitCode, resolveClaudeExitCodes())
+	if outcome != executor.OutcomeSuccess {
+		if hint := claudeLogHint(claudeExecutor); hint != "" {
+			menuDisplay.ShowMessage(hint)
+		}
+	}
 
 	if err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Failed to execute validation: %v", err))
@@ -1620,12 +2198,12 @@ func executeValidationWithIterationCheck(ctx *navigation.ProjectContext, menuDis
 	}
 
 	// Interpret Claude's exit code
-	switch exitCode {
-	case 0: // Success
+	switch outcome {
+	case executor.OutcomeSuccess:
 		menuDisplay.ShowSuccess("✅ Validation passed!")
 		return ValidationSuccess, nil
 
-	case 1: // Needs iteration
+	case executor.OutcomeIterate:
 		menuDisplay.ShowMessage("⚠️ Validation indicates iteration needed")
 
 		// Check if we've reached max iterations
@@ -1648,7 +2226,7 @@ func executeValidationWithIterationCheck(ctx *navigation.ProjectContext, menuDis
 
 		return ValidationFailedRetry, nil
 
-	case 2: // Blocked
+	case executor.OutcomeBlocked:
 		menuDisplay.ShowError("❌ Validation indicates task is blocked")
 		if iterations != nil {
 			if err := updateIterationsAsBlocked(iterationsPath, iterations, "Validation blocked"); err != nil {
@@ -1845,12 +2423,14 @@ const (
 // executeReviewWithIterationCheck executes review and determines next action based on result
 func executeReviewWithIterationCheck(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay, reviewIteration int) (ReviewResult, error) {
 	// Execute preprocessing first
-	if err := preprocessing.PreprocessReviewTask(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessReviewTask(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		return ReviewFailedRetry, fmt.Errorf("preprocessing failed: %w", err)
 	}
 
 	// Execute Claude review command
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
 		return ReviewFailedRetry, fmt.Errorf("Claude CLI not available: %w", err)
 	}
@@ -1858,6 +2438,12 @@ func executeReviewWithIterationCheck(ctx *navigation.ProjectContext, menuDisplay
 	// Execute review command and capture exit code
 	description := fmt.Sprintf("Review step (iteration %d)", reviewIteration)
 	exitCode, err := claudeExecutor.ExecuteSlashCommandWithExitCode("/4-task:2-execute:5-Review-Task", description)
+	outcome := executor.InterpretExitCode(exitCode, resolveClaudeExitCodes())
+	if outcome != executor.OutcomeSuccess {
+		if hint := claudeLogHint(claudeExecutor); hint != "" {
+			menuDisplay.ShowMessage(hint)
+		}
+	}
 
 	if err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Failed to execute review: %v", err))
@@ -1865,12 +2451,12 @@ func executeReviewWithIterationCheck(ctx *navigation.ProjectContext, menuDisplay
 	}
 
 	// Interpret Claude's exit code
-	switch exitCode {
-	case 0: // Success
+	switch outcome {
+	case executor.OutcomeSuccess:
 		menuDisplay.ShowSuccess("✅ Review passed!")
 		return ReviewSuccess, nil
 
-	case 1: // Needs iteration
+	case executor.OutcomeIterate:
 		menuDisplay.ShowMessage("⚠️ Review indicates iteration needed")
 
 		// Update docs/3-current-task/iterations.json for review retry with specific feedback
@@ -1881,7 +2467,7 @@ func executeReviewWithIterationCheck(ctx *navigation.ProjectContext, menuDisplay
 
 		return ReviewFailedRetry, nil
 
-	case 2: // Blocked
+	case executor.OutcomeBlocked:
 		menuDisplay.ShowError("❌ Review indicates task is blocked")
 
 		// Update docs/3-current-task/iterations.json as blocked
@@ -1922,7 +2508,9 @@ func executeImplementationCycleForReview(ctx *navigation.ProjectContext, menuDis
 
 	// Step 5: Validation (simple execution without iteration - we assume it will pass)
 	menuDisplay.ShowMessage("🔍 Quick validation before returning to review...")
-	if err := preprocessing.PreprocessValidateTask(ctx.ProjectPath, menuDisplay); err != nil {
+	if err := runPreprocessing(menuDisplay, func(options preprocessing.PreprocessOptions) (*preprocessing.PreprocessResult, error) {
+		return preprocessing.PreprocessValidateTask(ctx.ProjectPath, menuDisplay, options)
+	}); err != nil {
 		menuDisplay.ShowWarning(fmt.Sprintf("Validation preprocessing failed: %v", err))
 	}
 
@@ -2005,6 +2593,7 @@ func executeConfigInit(ctx *navigation.ProjectContext, menuDisplay *navigation.M
 	}
 
 	// Generate initial runtime configuration
+	showActiveProfile(manager, menuDisplay)
 	if err := manager.Sync(); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Failed to generate runtime configuration: %v", err))
 		return err
@@ -2020,6 +2609,7 @@ func executeConfigSync(ctx *navigation.ProjectContext, menuDisplay *navigation.M
 	menuDisplay.ShowMessage("🔄 Syncing configuration...")
 
 	manager := config.NewManager(ctx.ProjectPath)
+	showActiveProfile(manager, menuDisplay)
 	if err := manager.Sync(); err != nil {
 		menuDisplay.ShowError(fmt.Sprintf("Sync failed: %v", err))
 		return err
@@ -2029,6 +2619,13 @@ func executeConfigSync(ctx *navigation.ProjectContext, menuDisplay *navigation.M
 	return nil
 }
 
+// showActiveProfile tells which config profile the sync applies, if any
+func showActiveProfile(manager *config.Manager, menuDisplay *navigation.MenuDisplay) {
+	if profile, _, err := manager.ActiveProfile(); err == nil && profile != "" {
+		menuDisplay.ShowMessage(fmt.Sprintf("📋 Using profile %s", profile))
+	}
+}
+
 // executeConfigUpgrade handles upgrading system templates
 func executeConfigUpgrade(ctx *navigation.ProjectContext, menuDisplay *navigation.MenuDisplay) error {
 	menuDisplay.ShowMessage("⬆️  Upgrading system templates...")
@@ -2137,3 +2734,21 @@ func executeMetricsSubcommand(args []string, menuDisplay *navigation.MenuDisplay
 	menuDisplay.ShowSuccess(fmt.Sprintf("✅ Metrics %s completed successfully", args[1]))
 	return nil
 }
+
+// addSLAIssues adds the tickets past their SLA deadline to the issues of the
+// project overview
+func addSLAIssues(ctx *navigation.ProjectContext) {
+	stats, err := ticket.NewManager(ctx.ProjectPath).GetTicketStats()
+	if err != nil || stats.SLABreaches == 0 {
+		return
+	}
+
+	var ids []string
+	for _, aged := range stats.AgedTickets {
+		if aged.SLAStatus == ticket.SLABreached {
+			ids = append(ids, aged.ID)
+		}
+	}
+	ctx.Issues = append(ctx.Issues, fmt.Sprintf("SLA breached by %d ticket(s): %s (see 'ticket aging')",
+		stats.SLABreaches, strings.Join(ids, ", ")))
+}
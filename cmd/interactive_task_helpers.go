@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"claude-wm-cli/internal/navigation"
+	"claude-wm-cli/internal/theme"
 )
 
 // executeTaskListFromStory executes task list command for current story
@@ -25,30 +26,30 @@ func executeTaskListFromStory(ctx *navigation.ProjectContext, menuDisplay *navig
 func getTaskStatusIcon(status string) string {
 	switch strings.ToLower(status) {
 	case "todo":
-		return "⏳"
+		return theme.Icon("⏳", "[todo]")
 	case "in_progress":
-		return "🚧"
+		return theme.Icon("🚧", "[wip]")
 	case "done", "completed":
-		return "✅"
+		return theme.Icon("✅", "[done]")
 	case "blocked":
-		return "🚫"
+		return theme.Icon("🚫", "[blocked]")
 	default:
-		return "❓"
+		return theme.Icon("❓", "[?]")
 	}
 }
 
 func getTaskPriorityIcon(priority string) string {
 	switch strings.ToUpper(priority) {
 	case "P0", "CRITICAL":
-		return "🔥"
+		return theme.Icon("🔥", "[P0]")
 	case "P1", "HIGH":
-		return "⚡" 
+		return theme.Icon("⚡", "[P1]") 
 	case "P2", "MEDIUM":
-		return "📋"
+		return theme.Icon("📋", "[P2]")
 	case "P3", "LOW":
-		return "📝"
+		return theme.Icon("📝", "[P3]")
 	default:
-		return "❓"
+		return theme.Icon("❓", "[?]")
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -33,6 +34,9 @@ func TestInteractiveCmd_Flags(t *testing.T) {
 	assert.NotNil(t, flags.Lookup("no-interactive"))
 	assert.NotNil(t, flags.Lookup("width"))
 	assert.NotNil(t, flags.Lookup("max-suggestions"))
+	assert.NotNil(t, flags.Lookup("fresh"))
+	assert.NotNil(t, flags.Lookup("no-favorites"))
+	assert.NotNil(t, flags.Lookup("sequential-preprocessing"))
 }
 
 func TestInteractiveCmd_FlagDefaults(t *testing.T) {
@@ -464,3 +468,89 @@ func (m *mockMenuDisplay) ShowSuccess(message string) {
 func (m *mockMenuDisplay) WaitForKeyPress(message string) error {
 	return nil
 }
+
+func TestResumeNavigation_WithoutPrompt(t *testing.T) {
+	projectPath := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(projectPath, ".claude-wm"), 0755))
+	ctx := &navigation.ProjectContext{ProjectPath: projectPath, State: navigation.StateProjectInitialized}
+
+	// Nothing saved yet
+	menu, stack := resumeNavigation(ctx, nil, navigation.NewMenuDisplay())
+	assert.Equal(t, "main", menu)
+	assert.Empty(t, stack)
+
+	// Saved locations that cannot be resumed start from the main menu
+	for _, location := range []navigation.NavLocation{
+		{CurrentMenu: "main"},
+		{CurrentMenu: "removed-menu", MenuStack: []string{"main"}},
+		{CurrentMenu: "ticket", MenuStack: []string{"removed-menu"}},
+	} {
+		require.NoError(t, navigation.SaveNavLocation(projectPath, location))
+		menu, stack := resumeNavigation(ctx, nil, navigation.NewMenuDisplay())
+		assert.Equal(t, "main", menu)
+		assert.Empty(t, stack)
+	}
+
+	assert.NotNil(t, buildNavigationMenu("ticket", ctx, nil))
+	assert.Nil(t, buildNavigationMenu("removed-menu", ctx, nil))
+}
+
+func TestMenuBreadcrumb_Back(t *testing.T) {
+	customMenus = &navigation.MenuDefinitions{Menus: map[string]navigation.MenuDefinition{
+		"release": {Title: "Release"},
+	}}
+	defer func() { customMenus = nil }()
+
+	assert.Nil(t, menuBreadcrumb(nil, "main"))
+
+	// main → ticket → release → deploy, three levels deep
+	menuStack := []string{"main", "ticket", "release"}
+	currentMenu := "deploy"
+	assert.Equal(t, []string{"Main", "Ticket Management", "Release", "deploy"}, menuBreadcrumb(menuStack, currentMenu))
+
+	// Pressing b twice
+	currentMenu, menuStack = previousMenu(menuStack)
+	currentMenu, menuStack = previousMenu(menuStack)
+	assert.Equal(t, "ticket", currentMenu)
+	assert.Equal(t, []string{"Main", "Ticket Management"}, menuBreadcrumb(menuStack, currentMenu))
+
+	currentMenu, menuStack = previousMenu(menuStack)
+	assert.Equal(t, "main", currentMenu)
+	assert.Nil(t, menuBreadcrumb(menuStack, currentMenu))
+}
+
+func TestCreateFavoritesMenu(t *testing.T) {
+	menu := createFavoritesMenu(nil)
+	require.Len(t, menu.Options, 1)
+	assert.False(t, menu.Options[0].Enabled)
+	assert.True(t, menu.AllowBack)
+
+	menu = createFavoritesMenu([]navigation.FavoriteItem{
+		{MenuID: "ticket", Label: "⚡ Implement", Action: "/4-task:2-execute:3-Implement"},
+		{MenuID: "current-story", Label: "✅ Complete Story", Action: "story-complete"},
+	})
+	require.Len(t, menu.Options, 2)
+	assert.Equal(t, "⚡ Implement", menu.Options[0].Label)
+	assert.Equal(t, "from Ticket Management", menu.Options[0].Description)
+	assert.Equal(t, "story-complete", menu.Options[1].Action)
+	assert.True(t, menu.Options[1].Enabled)
+}
+
+func TestCreateHistoryMenu(t *testing.T) {
+	menu := createHistoryMenu(nil)
+	require.Len(t, menu.Options, 1)
+	assert.False(t, menu.Options[0].Enabled)
+
+	executedAt := time.Date(2025, 3, 4, 15, 30, 0, 0, time.Local)
+	menu = createHistoryMenu([]navigation.HistoryEntry{
+		{Action: "ticket-plan", Menu: "ticket", ExecutedAt: executedAt, Duration: 1500 * time.Millisecond, Success: true},
+		{Action: "epic-list", Menu: "epics", ExecutedAt: executedAt, Success: false},
+	})
+	require.Len(t, menu.Options, 3)
+	assert.Equal(t, "✅ ticket-plan", menu.Options[0].Label)
+	assert.Equal(t, "2025-03-04 15:30 from Ticket Management, took 1.5s", menu.Options[0].Description)
+	assert.Equal(t, historyActionPrefix+"0", menu.Options[0].Action)
+	assert.Equal(t, "❌ epic-list", menu.Options[1].Label)
+	assert.Equal(t, historyActionPrefix+"1", menu.Options[1].Action)
+	assert.Equal(t, "history-clear", menu.Options[2].Action)
+}
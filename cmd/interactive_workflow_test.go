@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"claude-wm-cli/internal/executor"
+	"claude-wm-cli/internal/navigation"
+)
+
+const (
+	planTaskCommand     = "/4-task:2-execute:1-Plan-Task"
+	testDesignCommand   = "/4-task:2-execute:2-Test-design"
+	implementCommand    = "/4-task:2-execute:3-Implement"
+	validateTaskCommand = "/4-task:2-execute:4-Validate-Task"
+	reviewTaskCommand   = "/4-task:2-execute:5-Review-Task"
+	archiveTaskCommand  = "/4-task:3-complete:1-Archive-Task"
+)
+
+// setupWorkflowProject creates a project with the task templates needed by
+// executeTicketFullWorkflow and injects mock as the Claude executor
+func setupWorkflowProject(t *testing.T, mock *executor.MockClaudeExecutor) *navigation.ProjectContext {
+	t.Helper()
+
+	projectPath := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(projectPath)
+
+	templates := filepath.Join(projectPath, "internal/config/system/commands/templates")
+	require.NoError(t, os.MkdirAll(templates, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(projectPath, "docs/3-current-task"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templates, "current-task.json"),
+		[]byte(`{"id": "TASK-001", "title": "Workflow test"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(templates, "iterations.json"), []byte(`{}`), 0644))
+
+	original := newClaudeExecutor
+	newClaudeExecutor = func() ClaudeExecutorInterface { return mock }
+	t.Cleanup(func() { newClaudeExecutor = original })
+
+	return &navigation.ProjectContext{ProjectPath: projectPath}
+}
+
+func newWorkflowMock() *executor.MockClaudeExecutor {
+	return executor.NewMockClaudeExecutor(map[string]executor.MockResponse{
+		planTaskCommand:     {},
+		testDesignCommand:   {},
+		implementCommand:    {},
+		validateTaskCommand: {ExitCode: 0},
+		reviewTaskCommand:   {ExitCode: 0},
+		archiveTaskCommand:  {},
+	})
+}
+
+func implementationCycle() []string {
+	return []string{planTaskCommand, testDesignCommand, implementCommand, validateTaskCommand}
+}
+
+func TestTicketFullWorkflow_ValidationPasses(t *testing.T) {
+	mock := newWorkflowMock()
+	ctx := setupWorkflowProject(t, mock)
+
+	err := executeTicketFullWorkflow(ctx, navigation.NewMenuDisplay(), "")
+	require.NoError(t, err)
+
+	expected := append(implementationCycle(), reviewTaskCommand, archiveTaskCommand)
+	assert.Equal(t, expected, mock.RecordedCalls())
+	assert.NoDirExists(t, filepath.Join(ctx.ProjectPath, "docs/3-current-task"), "archive should clean the task workspace")
+}
+
+func TestTicketFullWorkflow_ValidationIteratesThenPasses(t *testing.T) {
+	mock := newWorkflowMock()
+	mock.QueueResponses(validateTaskCommand, executor.MockResponse{ExitCode: 1})
+	ctx := setupWorkflowProject(t, mock)
+
+	err := executeTicketFullWorkflow(ctx, navigation.NewMenuDisplay(), "")
+	require.NoError(t, err)
+
+	expected := append(implementationCycle(), implementationCycle()...)
+	expected = append(expected, reviewTaskCommand, archiveTaskCommand)
+	assert.Equal(t, expected, mock.RecordedCalls())
+}
+
+func TestTicketFullWorkflow_ValidationFailsAfterMaxIterations(t *testing.T) {
+	mock := newWorkflowMock()
+	mock.QueueResponses(validateTaskCommand,
+		executor.MockResponse{ExitCode: 1},
+		executor.MockResponse{ExitCode: 1},
+		executor.MockResponse{ExitCode: 1},
+	)
+	ctx := setupWorkflowProject(t, mock)
+
+	err := executeTicketFullWorkflow(ctx, navigation.NewMenuDisplay(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed after maximum iterations (3)")
+
+	var expected []string
+	for i := 0; i < 3; i++ {
+		expected = append(expected, implementationCycle()...)
+	}
+	assert.Equal(t, expected, mock.RecordedCalls())
+}
+
+func TestTicketFullWorkflow_ValidationBlocked(t *testing.T) {
+	mock := newWorkflowMock()
+	mock.QueueResponses(validateTaskCommand, executor.MockResponse{ExitCode: 2})
+	ctx := setupWorkflowProject(t, mock)
+
+	err := executeTicketFullWorkflow(ctx, navigation.NewMenuDisplay(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation blocked")
+	assert.Equal(t, implementationCycle(), mock.RecordedCalls())
+}
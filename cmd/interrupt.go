@@ -9,6 +9,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	clierrors "claude-wm-cli/internal/errors"
 	"claude-wm-cli/internal/workflow"
 
 	"github.com/spf13/cobra"
@@ -55,8 +56,8 @@ Examples:
   claude-wm-cli interrupt start --name "Critical Bug Fix"
   claude-wm-cli interrupt start --name "Security Patch" --type emergency
   claude-wm-cli interrupt start --name "Hotfix Deploy" --type hotfix --notes "Production issue"`,
-	Run: func(cmd *cobra.Command, args []string) {
-		startInterruption(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return startInterruption(cmd)
 	},
 }
 
@@ -78,12 +79,14 @@ Examples:
   claude-wm-cli interrupt resume ctx-12345         # Resume specific context
   claude-wm-cli interrupt resume --force           # Force resume with conflicts`,
 	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		var contextID string
 		if len(args) > 0 {
 			contextID = args[0]
 		}
-		resumeInterruption(cmd, contextID)
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return resumeInterruption(cmd, contextID)
 	},
 }
 
@@ -104,8 +107,10 @@ Examples:
   claude-wm-cli interrupt status
   claude-wm-cli interrupt status --verbose
   claude-wm-cli interrupt status --format json`,
-	Run: func(cmd *cobra.Command, args []string) {
-		showInterruptionStatus(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showInterruptionStatus(cmd)
 	},
 }
 
@@ -125,8 +130,10 @@ need to forcibly reset the workflow state.
 Examples:
   claude-wm-cli interrupt clear --confirm
   claude-wm-cli interrupt clear --confirm --backup`,
-	Run: func(cmd *cobra.Command, args []string) {
-		clearInterruptionStack(cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return clearInterruptionStack(cmd)
 	},
 }
 
@@ -195,12 +202,11 @@ func init() {
 	interruptClearCmd.MarkFlagRequired("confirm")
 }
 
-func startInterruption(cmd *cobra.Command) {
+func startInterruption(cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create interruption stack
@@ -209,10 +215,12 @@ func startInterruption(cmd *cobra.Command) {
 	// Validate interruption type
 	contextType, err := parseContextType(interruptType)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Invalid interruption type '%s': %v\n", interruptType, err)
-		os.Exit(1)
+		return clierrors.NewCLIError(fmt.Sprintf("Invalid interruption type '%s'", interruptType), clierrors.ExitUsage).WithCause(err)
 	}
 
+	// Failures past this point are not usage errors
+	cmd.SilenceUsage = true
+
 	// Build save options
 	saveOptions := workflow.ContextSaveOptions{
 		Name:             interruptName,
@@ -227,8 +235,7 @@ func startInterruption(cmd *cobra.Command) {
 	// Check current stack depth for warnings
 	currentDepth, err := stack.GetStackDepth()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to check stack depth: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to check stack depth")
 	}
 
 	if currentDepth >= 3 {
@@ -247,8 +254,7 @@ func startInterruption(cmd *cobra.Command) {
 	// Save current context
 	context, err := stack.SaveCurrentContext(saveOptions)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to start interruption: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to start interruption")
 	}
 
 	// Display success
@@ -279,14 +285,14 @@ func startInterruption(cmd *cobra.Command) {
 	fmt.Printf("   • Work on your interruption task\n")
 	fmt.Printf("   • When finished: claude-wm-cli interrupt resume\n")
 	fmt.Printf("   • Check status:  claude-wm-cli interrupt status\n")
+	return nil
 }
 
-func resumeInterruption(cmd *cobra.Command, contextID string) {
+func resumeInterruption(cmd *cobra.Command, contextID string) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create interruption stack
@@ -295,8 +301,7 @@ func resumeInterruption(cmd *cobra.Command, contextID string) {
 	// Check if stack is empty
 	stackDepth, err := stack.GetStackDepth()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to check stack depth: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to check stack depth")
 	}
 
 	if stackDepth == 0 {
@@ -304,7 +309,7 @@ func resumeInterruption(cmd *cobra.Command, contextID string) {
 		fmt.Printf("\n💡 Available actions:\n")
 		fmt.Printf("   • Start work:       claude-wm-cli interrupt start --name \"Task Name\"\n")
 		fmt.Printf("   • Check status:     claude-wm-cli interrupt status\n")
-		return
+		return nil
 	}
 
 	// Build restore options
@@ -326,11 +331,7 @@ func resumeInterruption(cmd *cobra.Command, contextID string) {
 		fmt.Printf("   Target:      Context %s\n", contextID)
 		err = stack.RestoreContext(contextID, restoreOptions)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to resume context %s: %v\n", contextID, err)
-			if !resumeForce {
-				fmt.Printf("\n💡 Try again with --force to override conflicts\n")
-			}
-			os.Exit(1)
+			return resumeError(clierrors.Wrap(err, fmt.Sprintf("Failed to resume context %s", contextID)))
 		}
 
 		// Get the restored context for display
@@ -342,11 +343,7 @@ func resumeInterruption(cmd *cobra.Command, contextID string) {
 		var err error
 		restoredContext, err = stack.PopContext(restoreOptions)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to resume workflow: %v\n", err)
-			if !resumeForce {
-				fmt.Printf("\n💡 Try again with --force to override conflicts\n")
-			}
-			os.Exit(1)
+			return resumeError(clierrors.Wrap(err, "Failed to resume workflow"))
 		}
 	}
 
@@ -385,14 +382,22 @@ func resumeInterruption(cmd *cobra.Command, contextID string) {
 	} else {
 		fmt.Printf("\n💡 You're back to your original workflow!\n")
 	}
+	return nil
+}
+
+// resumeError suggests --force for a failed resume without it
+func resumeError(err *clierrors.CLIError) error {
+	if !resumeForce {
+		err.WithSuggestion("Try again with --force to override conflicts")
+	}
+	return err
 }
 
-func showInterruptionStatus(cmd *cobra.Command) {
+func showInterruptionStatus(cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create interruption stack
@@ -401,13 +406,12 @@ func showInterruptionStatus(cmd *cobra.Command) {
 	// Get stack data
 	stackData, err := stack.ListContexts()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get interruption status: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get interruption status")
 	}
 
 	if statusFormat == "json" {
 		displayStatusJSON(stackData)
-		return
+		return nil
 	}
 
 	// Display header
@@ -512,14 +516,14 @@ func showInterruptionStatus(cmd *cobra.Command) {
 	if stackData.Metadata.CurrentStackDepth > 0 || len(stackData.ContextHistory) > 0 {
 		fmt.Printf("   • Clear stack:        claude-wm-cli interrupt clear --confirm\n")
 	}
+	return nil
 }
 
-func clearInterruptionStack(cmd *cobra.Command) {
+func clearInterruptionStack(cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create interruption stack
@@ -528,13 +532,12 @@ func clearInterruptionStack(cmd *cobra.Command) {
 	// Get current stack status
 	stackDepth, err := stack.GetStackDepth()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to check stack depth: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to check stack depth")
 	}
 
 	if stackDepth == 0 {
 		fmt.Printf("ℹ️  Interruption stack is already empty.\n")
-		return
+		return nil
 	}
 
 	// Create backup if requested
@@ -549,14 +552,13 @@ func clearInterruptionStack(cmd *cobra.Command) {
 
 	if !clearConfirm {
 		fmt.Printf("❌ Operation cancelled. Use --confirm to proceed.\n")
-		return
+		return nil
 	}
 
 	// Clear the stack
 	err = stack.ClearStack()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to clear interruption stack: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to clear interruption stack")
 	}
 
 	fmt.Printf("✅ Interruption stack cleared successfully!\n\n")
@@ -567,6 +569,7 @@ func clearInterruptionStack(cmd *cobra.Command) {
 	fmt.Printf("\n💡 Next steps:\n")
 	fmt.Printf("   • Start new work:   claude-wm-cli interrupt start --name \"Task Name\"\n")
 	fmt.Printf("   • Check status:     claude-wm-cli interrupt status\n")
+	return nil
 }
 
 // Helper functions
@@ -4,14 +4,22 @@ Copyright © 2025 Claude WM CLI Team
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 	"text/tabwriter"
+	"time"
 
+	"claude-wm-cli/internal/backup"
+	"claude-wm-cli/internal/debug"
 	"claude-wm-cli/internal/metrics"
+	"claude-wm-cli/internal/navigation"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // metricsCmd represents the metrics command
@@ -38,7 +46,16 @@ Examples:
   claude-wm-cli metrics command "Start Story" --days 7  # Specific command stats
   claude-wm-cli metrics steps "Start Story" # Step-level profiling
   claude-wm-cli metrics slow --threshold 5000  # Commands slower than 5s
-  claude-wm-cli metrics projects            # Performance by project`,
+  claude-wm-cli metrics projects            # Performance by project
+  claude-wm-cli metrics compare --baseline 2026-01-01..2026-01-07 --candidate 2026-01-08..2026-01-14
+  claude-wm-cli metrics thresholds list     # Configured and inferred alert thresholds
+  claude-wm-cli metrics db-info             # Database journal mode and sizes
+  claude-wm-cli metrics export --format prometheus  # Command stats for Prometheus
+  claude-wm-cli metrics prune --older-than 30d      # Delete samples older than 30 days
+  claude-wm-cli metrics reset --command "story list"  # Wipe one command's history
+
+Retention: set metrics.retention (e.g. 90d) in ~/.claude-wm-cli.yaml to delete
+older samples automatically, checked once a day.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return showMetricsStatus()
 	},
@@ -76,29 +93,202 @@ var (
 
 	metricsStepsCmd = &cobra.Command{
 		Use:   "steps <command-name>",
-		Short: "Show step-level profiling for a command",
-		Long:  `Display step-level performance profiling for a specific command.`,
-		Args:  cobra.ExactArgs(1),
+		Short: "Show step-level latency percentiles for a command",
+		Long: `Display the latency distribution of each profiled step of a command:
+execution count, P50, P95 and P99 (nearest-rank) and maximum duration, slowest
+P95 first.
+
+Examples:
+  claude-wm-cli metrics steps "Start Story"           # All steps of the last 30 days
+  claude-wm-cli metrics steps "Start Story" --top 5   # The 5 slowest steps by P95
+  claude-wm-cli metrics steps "story list" --days 7   # Steps of the last week`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return showStepMetrics(args[0], metricsDays)
+			return showStepMetrics(args[0], metricsDays, metricsTop)
 		},
 	}
 
 	metricsSlowCmd = &cobra.Command{
 		Use:   "slow",
 		Short: "Show slowest commands",
-		Long:  `List commands that are slower than the specified threshold.`,
+		Long: `List commands that are slower than the specified threshold, by mean
+duration or, with --by, by a latency percentile (nearest-rank). Tail latency
+such as the P95 shows the slow runs an average hides.
+
+Examples:
+  claude-wm-cli metrics slow                          # Mean above 3s
+  claude-wm-cli metrics slow --by p95                 # P95 above 3s
+  claude-wm-cli metrics slow --by p99 --threshold 10000`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return showSlowCommands(metricsThreshold, metricsDays)
+			return showSlowCommands(metricsThreshold, metricsDays, metricsBy)
 		},
 	}
 
 	metricsProjectsCmd = &cobra.Command{
 		Use:   "projects",
 		Short: "Compare performance across projects",
-		Long:  `Display performance comparison across different projects.`,
+		Long: `Compare the P95 latency of each command across project directories.
+
+Rows are commands, columns are projects. A P95 more than 50% above the best
+project's for the same command is flagged with ▲ and its excess, pointing at
+projects whose performance degraded.
+
+Projects are identified by their directory, as recorded when commands ran in
+them. Without --dir, the current directory is compared with every directory in
+$CLAUDE_WM_PROJECTS_DIR; directories without runs are left out.
+
+Examples:
+  claude-wm-cli metrics projects                          # Current + $CLAUDE_WM_PROJECTS_DIR
+  claude-wm-cli metrics projects --dir ../api,../web      # Specific projects
+  claude-wm-cli metrics projects --export-csv p95.csv     # Matrix for a spreadsheet`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showProjectComparison(metricsDirs, metricsExportCSV, metricsDays)
+		},
+	}
+
+	metricsThresholdsCmd = &cobra.Command{
+		Use:   "thresholds",
+		Short: "Manage command duration alert thresholds",
+		Long: `Manage the duration thresholds used to alert when a command is slower than expected.
+
+Thresholds are configured in .claude-wm/config.json:
+
+  {
+    "thresholds": {
+      "interactive": { "warn_ms": 2000, "error_ms": 5000 }
+    }
+  }`,
+	}
+
+	metricsThresholdsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List configured and inferred thresholds",
+		Long: `Display the configured thresholds for each command along with thresholds
+inferred from historical data (warn at P95, error at twice P95).`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showThresholds(metricsDays)
+		},
+	}
+
+	metricsDBInfoCmd = &cobra.Command{
+		Use:   "db-info",
+		Short: "Show metrics database storage details",
+		Long: `Display how the metrics database is stored: journal mode, synchronous
+and auto-vacuum settings, page count and the size of the database and of its
+write-ahead log (WAL).
+
+Examples:
+  claude-wm-cli metrics db-info   # Show the metrics database settings`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showMetricsDBInfo()
+		},
+	}
+
+	metricsExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export command statistics for monitoring systems",
+		Long: `Export the statistics of each command over the last days: number of runs,
+total, average and P95 duration and number of runs that exited with an error.
+
+The prometheus format is the Prometheus text exposition format, with the
+claude_wm_command_duration_seconds summary, the
+claude_wm_command_duration_avg_seconds gauge and the
+claude_wm_command_errors_total counter, labelled by command. It can be served
+to a scraper or picked up by the node_exporter textfile collector.
+
+Examples:
+  claude-wm-cli metrics export --format prometheus             # Print to stdout
+  claude-wm-cli metrics export --out /var/lib/node_exporter/claude-wm.prom
+  claude-wm-cli metrics export --days 1                        # Last day only`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportMetrics(metricsFormat, metricsOut, metricsDays)
+		},
+	}
+
+	metricsCompareCmd = &cobra.Command{
+		Use:   "compare",
+		Short: "Compare command performance between two date ranges",
+		Long: `Compare the run count, average and P95 latency of each command between a
+baseline and a candidate date range, for instance the weeks before and after a
+workflow change.
+
+Ranges are local dates written <date>..<date>, both days included. A command
+whose P95 grew by more than --threshold percent is flagged as a regression,
+one whose P95 shrank by as much as faster.
+
+Examples:
+  claude-wm-cli metrics compare --baseline 2026-01-01..2026-01-07 --candidate 2026-01-08..2026-01-14
+  claude-wm-cli metrics compare --baseline 2026-01-01..2026-01-31 --candidate 2026-02-01..2026-02-28 --command "story list"
+  claude-wm-cli metrics compare --baseline 2026-01-01..2026-01-07 --candidate 2026-01-08..2026-01-14 --threshold 25`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			baseline, err := metrics.ParseDateRange(metricsBaseline)
+			if err != nil {
+				return fmt.Errorf("invalid --baseline: %w", err)
+			}
+			candidate, err := metrics.ParseDateRange(metricsCandidate)
+			if err != nil {
+				return fmt.Errorf("invalid --candidate: %w", err)
+			}
+			if metricsRegressionPct < 0 {
+				return fmt.Errorf("invalid --threshold %g: must not be negative", metricsRegressionPct)
+			}
+			return compareMetricWindows(baseline, candidate, metricsCompareCommand, metricsRegressionPct)
+		},
+	}
+
+	metricsResetCmd = &cobra.Command{
+		Use:   "reset",
+		Short: "Delete all metrics, or those of one command",
+		Long: `Delete every command sample and step timing, or only those of --command,
+for instance after a benchmarking session or to start fresh.
+
+The database is first backed up with the backup manager to the backups
+directory next to it, from where it can be restored with:
+
+  claude-wm-cli backup restore ~/.claude-wm/metrics/performance.db \
+    --backup-dir ~/.claude-wm/metrics/backups
+
+Examples:
+  claude-wm-cli metrics reset                          # Wipe all metrics
+  claude-wm-cli metrics reset --command "story list"   # Wipe one command's history
+  claude-wm-cli metrics reset --yes                    # Skip the confirmation`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return resetMetrics(metricsResetCommand, metricsResetYes)
+		},
+	}
+
+	metricsPruneCmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Delete old metrics samples",
+		Long: `Delete the command samples and step timings older than --older-than, then
+compact the database, and report how many records were removed and the disk
+space reclaimed.
+
+--older-than defaults to metrics.retention when it is set in the config file,
+30d otherwise. With metrics.retention set, older samples are also deleted
+automatically, at most once a day (without compacting the file).
+
+Examples:
+  claude-wm-cli metrics prune --older-than 30d   # Keep the last 30 days
+  claude-wm-cli metrics prune --older-than 12w   # Keep the last 12 weeks
+  claude-wm-cli metrics prune                    # Apply metrics.retention`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return showProjectComparison(metricsDays)
+			olderThan := metricsOlderThan
+			if olderThan == "" {
+				olderThan = viper.GetString("metrics.retention")
+			}
+			if olderThan == "" {
+				olderThan = defaultMetricsRetention
+			}
+			window, err := metrics.ParseRetention(olderThan)
+			if err != nil {
+				return err
+			}
+			return pruneMetrics(olderThan, window)
 		},
 	}
 
@@ -114,11 +304,28 @@ var (
 
 // Command flags
 var (
-	metricsDays      int
-	metricsThreshold int64
-	metricsForce     bool
+	metricsDays           int
+	metricsThreshold      int64
+	metricsForce          bool
+	metricsTop            int
+	metricsFormat         string
+	metricsBy             string
+	metricsOut            string
+	metricsOlderThan      string
+	metricsDirs           []string
+	metricsExportCSV      string
+	metricsResetCommand   string
+	metricsResetYes       bool
+	metricsBaseline       string
+	metricsCandidate      string
+	metricsCompareCommand string
+	metricsRegressionPct  float64
 )
 
+// defaultMetricsRetention is the window kept by metrics prune when neither
+// --older-than nor metrics.retention is set
+const defaultMetricsRetention = "30d"
+
 func init() {
 	rootCmd.AddCommand(metricsCmd)
 
@@ -130,10 +337,32 @@ func init() {
 	metricsCmd.AddCommand(metricsSlowCmd)
 	metricsCmd.AddCommand(metricsProjectsCmd)
 	metricsCmd.AddCommand(metricsCleanCmd)
+	metricsCmd.AddCommand(metricsDBInfoCmd)
+	metricsCmd.AddCommand(metricsExportCmd)
+	metricsCmd.AddCommand(metricsPruneCmd)
+	metricsCmd.AddCommand(metricsResetCmd)
+	metricsCmd.AddCommand(metricsCompareCmd)
+	metricsCmd.AddCommand(metricsThresholdsCmd)
+	metricsThresholdsCmd.AddCommand(metricsThresholdsListCmd)
 
 	// Add flags
 	metricsCmd.PersistentFlags().IntVar(&metricsDays, "days", 30, "Number of days to analyze")
 	metricsSlowCmd.Flags().Int64Var(&metricsThreshold, "threshold", 3000, "Threshold in milliseconds for slow commands")
+	metricsSlowCmd.Flags().StringVar(&metricsBy, "by", metrics.ByMean, "Duration statistic to compare and sort by: "+strings.Join(metrics.LatencyStatistics, ", "))
+	metricsStepsCmd.Flags().IntVar(&metricsTop, "top", 0, "Only show the N slowest steps by P95 (0 for all)")
+	metricsExportCmd.Flags().StringVar(&metricsFormat, "format", "prometheus", "Export format (prometheus)")
+	metricsExportCmd.Flags().StringVar(&metricsOut, "out", "", "Write to this file instead of stdout")
+	metricsPruneCmd.Flags().StringVar(&metricsOlderThan, "older-than", "", "Delete samples older than this window, e.g. 30d, 12w or 720h (default metrics.retention, else 30d)")
+	metricsProjectsCmd.Flags().StringSliceVar(&metricsDirs, "dir", nil, "Project directories to compare (default current + $CLAUDE_WM_PROJECTS_DIR/*)")
+	metricsProjectsCmd.Flags().StringVar(&metricsExportCSV, "export-csv", "", "Also write the comparison matrix to this CSV file")
+	metricsResetCmd.Flags().StringVar(&metricsResetCommand, "command", "", "Only delete the samples of this command")
+	metricsResetCmd.Flags().BoolVarP(&metricsResetYes, "yes", "y", false, "Reset without asking for confirmation")
+	metricsCompareCmd.Flags().StringVar(&metricsBaseline, "baseline", "", "Baseline date range, e.g. 2026-01-01..2026-01-07")
+	metricsCompareCmd.Flags().StringVar(&metricsCandidate, "candidate", "", "Candidate date range, e.g. 2026-01-08..2026-01-14")
+	metricsCompareCmd.Flags().StringVar(&metricsCompareCommand, "command", "", "Only compare this command")
+	metricsCompareCmd.Flags().Float64Var(&metricsRegressionPct, "threshold", 10, "Percent P95 increase flagged as a regression")
+	metricsCompareCmd.MarkFlagRequired("baseline")
+	metricsCompareCmd.MarkFlagRequired("candidate")
 	metricsCleanCmd.Flags().BoolVar(&metricsForce, "force", false, "Force deletion without confirmation")
 }
 
@@ -261,9 +490,10 @@ func showCommandDetails(commandName string, days int) error {
 	fmt.Printf("   Min time:   %.0fms\n", stats.MinDuration)
 	fmt.Printf("   Avg time:   %.0fms\n", stats.AvgDuration)
 	fmt.Printf("   Max time:   %.0fms\n", stats.MaxDuration)
-	if stats.P95Duration > 0 {
-		fmt.Printf("   95th %%ile:  %.0fms\n", stats.P95Duration)
-	}
+	fmt.Printf("   P50:        %.0fms\n", stats.P50Duration)
+	fmt.Printf("   P90:        %.0fms\n", stats.P90Duration)
+	fmt.Printf("   P95:        %.0fms\n", stats.P95Duration)
+	fmt.Printf("   P99:        %.0fms\n", stats.P99Duration)
 	fmt.Printf("\n")
 	
 	// Performance assessment
@@ -289,116 +519,90 @@ func showCommandDetails(commandName string, days int) error {
 }
 
 // showStepMetrics displays step-level profiling for a command
-func showStepMetrics(commandName string, days int) error {
+func showStepMetrics(commandName string, days, top int) error {
 	collector := metrics.GetCollector()
 	if !collector.IsEnabled() {
 		return fmt.Errorf("metrics collection is disabled")
 	}
-	
+
 	fmt.Printf("🔬 Step-level Profiling: %s (last %d days)\n", commandName, days)
 	fmt.Printf("============================================\n\n")
-	
-	steps, err := collector.GetStepStats(commandName, days)
+
+	steps, err := collector.GetStepPercentiles(commandName, days)
 	if err != nil {
 		return fmt.Errorf("failed to get step statistics: %w", err)
 	}
-	
+
 	if len(steps) == 0 {
 		fmt.Printf("📊 No step-level data available for command '%s'\n", commandName)
 		fmt.Printf("   Step-level profiling may not be implemented for this command yet.\n")
 		return nil
 	}
-	
-	// Sort steps by average duration (slowest first)
-	sort.Slice(steps, func(i, j int) bool {
-		return steps[i].AvgDuration > steps[j].AvgDuration
-	})
-	
-	// Create table
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "STEP\tEXECUTIONS\tMIN\tAVG\tMAX\tIMPACT\n")
-	fmt.Fprintf(w, "────\t──────────\t───\t───\t───\t──────\n")
-	
-	// Calculate total average for impact percentage
-	var totalAvg float64
-	for _, step := range steps {
-		totalAvg += step.AvgDuration
+
+	if top > 0 && len(steps) > top {
+		steps = steps[:top]
 	}
-	
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "STEP\tCOUNT\tP50\tP95\tP99\tMAX\n")
+	fmt.Fprintf(w, "────\t─────\t───\t───\t───\t───\n")
 	for _, step := range steps {
-		impactPercent := (step.AvgDuration / totalAvg) * 100
-		impactIcon := getImpactIcon(impactPercent)
-		
-		fmt.Fprintf(w, "%s\t%d\t%.0fms\t%.0fms\t%.0fms\t%s %.1f%%\n",
+		fmt.Fprintf(w, "%s\t%d\t%.0fms\t%.0fms\t%.0fms\t%.0fms\n",
 			truncateMetricsString(step.StepName, 25),
 			step.Count,
-			step.MinDuration,
-			step.AvgDuration,
-			step.MaxDuration,
-			impactIcon,
-			impactPercent)
+			step.P50,
+			step.P95,
+			step.P99,
+			step.Max)
 	}
-	
 	w.Flush()
-	
+
 	// Performance recommendations
-	fmt.Printf("\n💡 Performance Insights:\n")
-	if len(steps) > 0 {
-		slowestStep := steps[0]
-		if slowestStep.AvgDuration > 2000 {
-			fmt.Printf("   • '%s' is the slowest step (%.0fms avg) - consider optimization\n", 
-				slowestStep.StepName, slowestStep.AvgDuration)
-		}
-		
-		if len(steps) >= 3 {
-			topThreeTime := steps[0].AvgDuration + steps[1].AvgDuration + steps[2].AvgDuration
-			topThreePercent := (topThreeTime / totalAvg) * 100
-			if topThreePercent > 80 {
-				fmt.Printf("   • Top 3 steps account for %.1f%% of execution time\n", topThreePercent)
-			}
-		}
+	slowestStep := steps[0]
+	if slowestStep.P95 > 2000 {
+		fmt.Printf("\n💡 Performance Insights:\n")
+		fmt.Printf("   • '%s' is the slowest step (%.0fms P95) - consider optimization\n",
+			slowestStep.StepName, slowestStep.P95)
 	}
-	
+
 	return nil
 }
 
-// showSlowCommands displays commands slower than threshold
-func showSlowCommands(thresholdMs int64, days int) error {
+// showSlowCommands displays commands whose statistic named by is slower than
+// threshold
+func showSlowCommands(thresholdMs int64, days int, by string) error {
 	collector := metrics.GetCollector()
 	if !collector.IsEnabled() {
 		return fmt.Errorf("metrics collection is disabled")
 	}
 	
-	fmt.Printf("🐌 Slow Commands (> %dms, last %d days)\n", thresholdMs, days)
-	fmt.Printf("=====================================\n\n")
-	
-	slowCommands, err := collector.GetSlowCommands(thresholdMs, days)
+	slowCommands, err := collector.GetSlowCommands(thresholdMs, days, by)
 	if err != nil {
 		return fmt.Errorf("failed to get slow commands: %w", err)
 	}
 	
+	fmt.Printf("🐌 Slow Commands (%s > %dms, last %d days)\n", by, thresholdMs, days)
+	fmt.Printf("=====================================\n\n")
+	
 	if len(slowCommands) == 0 {
 		fmt.Printf("🎉 No commands slower than %dms found!\n", thresholdMs)
 		fmt.Printf("   Your performance looks good.\n")
 		return nil
 	}
 	
-	// Sort by average duration (slowest first)
-	sort.Slice(slowCommands, func(i, j int) bool {
-		return slowCommands[i].AvgDuration > slowCommands[j].AvgDuration
-	})
-	
-	// Create table
+	// Create table, slowest first
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "COMMAND\tEXECUTIONS\tAVG TIME\tMAX TIME\tSEVERITY\n")
-	fmt.Fprintf(w, "───────\t──────────\t────────\t────────\t────────\n")
+	fmt.Fprintf(w, "COMMAND\tEXECUTIONS\tAVG TIME\tP95 TIME\tMAX TIME\tSEVERITY\n")
+	fmt.Fprintf(w, "───────\t──────────\t────────\t────────\t────────\t────────\n")
 	
 	for _, cmd := range slowCommands {
-		severity := getSeverityIcon(cmd.AvgDuration)
-		fmt.Fprintf(w, "%s\t%d\t%.0fms\t%.0fms\t%s\n",
+		latency, _ := cmd.Latency(by)
+		severity := getSeverityIcon(latency)
+		fmt.Fprintf(w, "%s\t%d\t%.0fms\t%.0fms\t%.0fms\t%s\n",
 			truncateMetricsString(cmd.CommandName, 30),
 			cmd.Count,
 			cmd.AvgDuration,
+			cmd.P95Duration,
 			cmd.MaxDuration,
 			severity)
 	}
@@ -412,48 +616,255 @@ func showSlowCommands(thresholdMs int64, days int) error {
 	return nil
 }
 
-// showProjectComparison displays performance comparison across projects  
-func showProjectComparison(days int) error {
+// showProjectComparison displays the P95 latency of each command across the
+// project directories dirs, or the default ones when empty
+func showProjectComparison(dirs []string, exportCSV string, days int) error {
 	collector := metrics.GetCollector()
 	if !collector.IsEnabled() {
 		return fmt.Errorf("metrics collection is disabled")
 	}
-	
-	fmt.Printf("📊 Project Performance Comparison (last %d days)\n", days)
-	fmt.Printf("==============================================\n\n")
-	
-	projects, err := collector.GetProjectComparison(days)
+
+	if len(dirs) == 0 {
+		var err error
+		if dirs, err = defaultComparedProjects(); err != nil {
+			return err
+		}
+	}
+
+	comparison, err := collector.CompareProjects(dirs, days)
 	if err != nil {
-		return fmt.Errorf("failed to get project comparison: %w", err)
+		return fmt.Errorf("failed to compare projects: %w", err)
 	}
-	
-	if len(projects) == 0 {
+
+	fmt.Printf("📊 Project Performance Comparison - P95 (last %d days)\n", days)
+	fmt.Printf("=====================================================\n\n")
+
+	if len(comparison.Commands) == 0 {
 		fmt.Printf("📊 No project data available\n")
 		return nil
 	}
-	
-	// Sort by average duration (slowest first)
-	sort.Slice(projects, func(i, j int) bool {
-		return projects[i].AvgDuration > projects[j].AvgDuration
-	})
-	
-	// Create table
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "PROJECT\tCOMMANDS\tAVG TIME\tMAX TIME\tCOMPLEXITY\n")
-	fmt.Fprintf(w, "───────\t────────\t────────\t────────\t──────────\n")
-	
-	for _, project := range projects {
-		complexity := getComplexityIcon(project.TotalCommands, project.AvgDuration)
-		fmt.Fprintf(w, "%s\t%d\t%.0fms\t%.0fms\t%s\n",
-			truncateMetricsString(project.ProjectName, 30),
-			project.TotalCommands,
-			project.AvgDuration,
-			project.MaxDuration,
-			complexity)
+	header, rule := "COMMAND", "───────"
+	for _, project := range comparison.Projects {
+		header += "\t" + truncateMetricsString(project.Name, 20)
+		rule += "\t" + strings.Repeat("─", len([]rune(truncateMetricsString(project.Name, 20))))
+	}
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, rule)
+
+	degraded := 0
+	for _, row := range comparison.Commands {
+		line := truncateMetricsString(row.CommandName, 30)
+		for i, cell := range row.Cells {
+			switch {
+			case cell.Count == 0:
+				line += "\t-"
+			case row.Degraded(i):
+				degraded++
+				line += fmt.Sprintf("\t%.0fms ▲+%.0f%%", cell.P95Duration, (cell.P95Duration/row.BestP95-1)*100)
+			default:
+				line += fmt.Sprintf("\t%.0fms", cell.P95Duration)
+			}
+		}
+		fmt.Fprintln(w, line)
 	}
-	
 	w.Flush()
-	
+
+	fmt.Println()
+	if degraded > 0 {
+		fmt.Printf("▲ %d P95 values more than %.0f%% above the best project\n", degraded, (metrics.DegradedRatio-1)*100)
+	} else {
+		fmt.Printf("✅ No project is more than %.0f%% above the best P95\n", (metrics.DegradedRatio-1)*100)
+	}
+	if len(comparison.Empty) > 0 {
+		fmt.Printf("💡 No runs in %s\n", strings.Join(comparison.Empty, ", "))
+	}
+
+	if exportCSV != "" {
+		var buf bytes.Buffer
+		if err := comparison.WriteCSV(&buf); err != nil {
+			return err
+		}
+		if err := os.WriteFile(exportCSV, buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", exportCSV, err)
+		}
+		fmt.Printf("✅ Exported the comparison of %d commands to %s\n", len(comparison.Commands), exportCSV)
+	}
+	return nil
+}
+
+// compareMetricWindows displays the change of each command's statistics from
+// the baseline to the candidate range, flagging P95 changes above
+// thresholdPct percent
+func compareMetricWindows(baseline, candidate metrics.DateRange, commandName string, thresholdPct float64) error {
+	collector := metrics.GetCollector()
+	if !collector.IsEnabled() {
+		return fmt.Errorf("metrics collection is disabled")
+	}
+
+	comparisons, err := collector.CompareWindows(baseline, candidate, commandName)
+	if err != nil {
+		return fmt.Errorf("failed to compare date ranges: %w", err)
+	}
+
+	fmt.Printf("📊 Performance Comparison: %s → %s\n", baseline, candidate)
+	fmt.Printf("==============================================\n\n")
+
+	if len(comparisons) == 0 {
+		fmt.Printf("📊 No command data available in either range\n")
+		return nil
+	}
+
+	threshold := thresholdPct / 100
+	regressions, improvements := 0, 0
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "COMMAND\tCOUNT\tAVG TIME\tP95 TIME\tCHANGE\n")
+	fmt.Fprintf(w, "───────\t─────\t────────\t────────\t──────\n")
+	for _, comparison := range comparisons {
+		status := "➖"
+		switch {
+		case comparison.Baseline.Count == 0:
+			status = "🆕 new"
+		case comparison.Candidate.Count == 0:
+			status = "💤 not run"
+		case comparison.Regressed(threshold):
+			status = "🔴 regression"
+			regressions++
+		case comparison.Improved(threshold):
+			status = "🟢 faster"
+			improvements++
+		}
+
+		fmt.Fprintf(w, "%s\t%d → %d\t%s\t%s\t%s\n",
+			truncateMetricsString(comparison.CommandName, 30),
+			comparison.Baseline.Count,
+			comparison.Candidate.Count,
+			formatWindowChange(comparison.Baseline.Count, comparison.Baseline.AvgDuration, comparison.Candidate.Count, comparison.Candidate.AvgDuration),
+			formatWindowChange(comparison.Baseline.Count, comparison.Baseline.P95Duration, comparison.Candidate.Count, comparison.Candidate.P95Duration),
+			status)
+	}
+	w.Flush()
+
+	fmt.Println()
+	if regressions > 0 {
+		fmt.Printf("🔴 %d commands regressed (P95 more than %g%% slower)\n", regressions, thresholdPct)
+	}
+	if improvements > 0 {
+		fmt.Printf("🟢 %d commands got faster (P95 more than %g%% faster)\n", improvements, thresholdPct)
+	}
+	if regressions == 0 && improvements == 0 {
+		fmt.Printf("➖ No P95 changed by more than %g%%\n", thresholdPct)
+	}
+	return nil
+}
+
+// formatWindowChange formats a duration statistic of a baseline and a
+// candidate range and its relative change, "-" standing for a range without
+// runs
+func formatWindowChange(baselineCount int, baseline float64, candidateCount int, candidate float64) string {
+	before, after := "-", "-"
+	if baselineCount > 0 {
+		before = fmt.Sprintf("%.0fms", baseline)
+	}
+	if candidateCount > 0 {
+		after = fmt.Sprintf("%.0fms", candidate)
+	}
+	if baselineCount == 0 || candidateCount == 0 || baseline == 0 {
+		return before + " → " + after
+	}
+	return fmt.Sprintf("%s → %s (%+.0f%%)", before, after, (candidate/baseline-1)*100)
+}
+
+// defaultComparedProjects returns the current directory followed by the
+// directories in $CLAUDE_WM_PROJECTS_DIR, when set
+func defaultComparedProjects() ([]string, error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	dirs := []string{workDir}
+
+	projectsDir := os.Getenv("CLAUDE_WM_PROJECTS_DIR")
+	if projectsDir == "" {
+		return dirs, nil
+	}
+	entries, err := os.ReadDir(projectsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CLAUDE_WM_PROJECTS_DIR: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			dirs = append(dirs, filepath.Join(projectsDir, entry.Name()))
+		}
+	}
+	return dirs, nil
+}
+
+// showThresholds displays configured and inferred command thresholds
+func showThresholds(days int) error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	cfg, err := metrics.LoadThresholdConfig(workDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🚦 Command Thresholds (inferred from last %d days)\n", days)
+	fmt.Printf("==============================================\n\n")
+
+	// Collect inferred thresholds from historical P95 baselines
+	inferred := make(map[string]metrics.CommandThreshold)
+	collector := metrics.GetCollector()
+	if collector.IsEnabled() {
+		if commands, err := collector.GetAllCommandStats(days); err == nil {
+			for _, command := range commands {
+				if stats, err := collector.GetStats(command.CommandName, days); err == nil {
+					inferred[command.CommandName] = metrics.InferThreshold(stats)
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Thresholds)+len(inferred))
+	seen := make(map[string]bool)
+	for name := range cfg.Thresholds {
+		names = append(names, name)
+		seen[name] = true
+	}
+	for name := range inferred {
+		if !seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		fmt.Printf("📊 No thresholds configured and no metrics data available\n")
+		fmt.Printf("   Add a \"thresholds\" block to .claude-wm/config.json to enable alerts\n")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "COMMAND\tWARN\tERROR\tINFERRED WARN\tINFERRED ERROR\n")
+	fmt.Fprintf(w, "───────\t────\t─────\t─────────────\t──────────────\n")
+
+	for _, name := range names {
+		configured, hasConfigured := cfg.Thresholds[name]
+		guess := inferred[name]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			truncateMetricsString(name, 35),
+			formatThresholdMs(configured.WarnMs, hasConfigured),
+			formatThresholdMs(configured.ErrorMs, hasConfigured),
+			formatThresholdMs(guess.WarnMs, guess.WarnMs > 0),
+			formatThresholdMs(guess.ErrorMs, guess.ErrorMs > 0))
+	}
+
+	w.Flush()
+
 	return nil
 }
 
@@ -466,11 +877,192 @@ func cleanMetrics(force bool, olderThanDays int) error {
 	}
 	
 	fmt.Printf("🧹 Cleaning metrics data older than %d days...\n", olderThanDays)
-	fmt.Printf("   (Implementation pending)\n")
-	
+	return pruneMetrics(fmt.Sprintf("%dd", olderThanDays), time.Duration(olderThanDays)*24*time.Hour)
+}
+
+// pruneMetrics deletes the samples older than window, described by label,
+// and reports what was removed
+func pruneMetrics(label string, window time.Duration) error {
+	collector := metrics.GetCollector()
+	if !collector.IsEnabled() {
+		return fmt.Errorf("metrics collection is disabled")
+	}
+
+	result, err := collector.Prune(window)
+	if err != nil {
+		return err
+	}
+	if result.Removed() == 0 {
+		fmt.Printf("✅ No metrics samples older than %s\n", label)
+		return nil
+	}
+	fmt.Printf("🧹 Removed %d records older than %s (%d command samples, %d step timings)\n",
+		result.Removed(), label, result.Commands, result.Steps)
+	fmt.Printf("   Reclaimed %s\n", formatBackupSize(result.ReclaimedBytes))
 	return nil
 }
 
+// resetMetrics backs up the metrics database, then deletes all samples or
+// those of commandName, after confirmation unless yes is set
+func resetMetrics(commandName string, yes bool) error {
+	collector := metrics.GetCollector()
+	if !collector.IsEnabled() {
+		return fmt.Errorf("metrics collection is disabled")
+	}
+
+	target := "all metrics samples"
+	if commandName != "" {
+		target = fmt.Sprintf("the metrics samples of %q", commandName)
+	}
+	if !yes {
+		confirmed, err := navigation.NewMenuDisplay().Confirm(fmt.Sprintf("Delete %s?", target))
+		if err != nil {
+			return fmt.Errorf("failed to read confirmation: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Reset cancelled.")
+			return nil
+		}
+	}
+
+	backupID, err := backupMetricsDB(collector)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("📋 Database backed up as %s\n", backupID)
+
+	result, err := collector.Reset(commandName)
+	if err != nil {
+		return err
+	}
+	if result.Removed() == 0 {
+		fmt.Printf("✅ No samples to delete\n")
+		return nil
+	}
+	fmt.Printf("🧹 Deleted %s: %d command samples, %d step timings\n", target, result.Commands, result.Steps)
+	fmt.Printf("   Reclaimed %s\n", formatBackupSize(result.ReclaimedBytes))
+	return nil
+}
+
+// backupMetricsDB backs up the metrics database to the backups directory
+// next to it and returns the backup ID
+func backupMetricsDB(collector *metrics.PerformanceCollector) (string, error) {
+	dbPath, err := metrics.DefaultDBPath()
+	if err != nil {
+		return "", err
+	}
+	// Move the pending samples from the WAL to the file being copied
+	if err := collector.Checkpoint(); err != nil {
+		return "", err
+	}
+
+	config := backup.DefaultBackupConfig()
+	config.BackupDirectory = filepath.Join(filepath.Dir(dbPath), "backups")
+	manager, err := backup.NewManager(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize backup manager: %w", err)
+	}
+
+	result, err := manager.CreateBackup(&backup.BackupRequest{
+		SourceFile:  dbPath,
+		Type:        backup.BackupTypeManual,
+		Reason:      backup.ReasonUserRequest,
+		Compress:    true,
+		Force:       true,
+		Description: "Metrics database before metrics reset",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to back up metrics database: %w", err)
+	}
+	if !result.Success {
+		return "", fmt.Errorf("failed to back up metrics database: %w", result.Error)
+	}
+	return result.Metadata.ID, nil
+}
+
+// applyMetricsRetention deletes the samples older than metrics.retention, at
+// most once a day; failures are only logged
+func applyMetricsRetention() {
+	value := viper.GetString("metrics.retention")
+	if value == "" {
+		return
+	}
+	retention, err := metrics.ParseRetention(value)
+	if err != nil {
+		debug.LogResult("METRICS", "apply retention", err.Error(), false)
+		return
+	}
+
+	result, err := metrics.GetCollector().ApplyRetention(retention)
+	if err != nil {
+		debug.LogResult("METRICS", "apply retention", err.Error(), false)
+		return
+	}
+	if result != nil {
+		debug.LogResult("METRICS", "apply retention",
+			fmt.Sprintf("Removed %d records older than %s", result.Removed(), value), true)
+	}
+}
+
+// exportMetrics writes the command statistics of the last days in format to
+// out, or to stdout when out is empty
+func exportMetrics(format, out string, days int) error {
+	if format != "prometheus" {
+		return fmt.Errorf("unsupported export format %q (supported: prometheus)", format)
+	}
+
+	collector := metrics.GetCollector()
+	if !collector.IsEnabled() {
+		return fmt.Errorf("metrics collection is disabled")
+	}
+
+	summaries, err := collector.GetCommandSummaries(days)
+	if err != nil {
+		return fmt.Errorf("failed to get command statistics: %w", err)
+	}
+
+	if out == "" {
+		return metrics.WritePrometheus(os.Stdout, summaries)
+	}
+
+	var buf bytes.Buffer
+	if err := metrics.WritePrometheus(&buf, summaries); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	fmt.Printf("✅ Exported statistics of %d commands to %s\n", len(summaries), out)
+	return nil
+}
+
+// showMetricsDBInfo displays the storage settings and sizes of the metrics
+// database
+func showMetricsDBInfo() error {
+	collector := metrics.GetCollector()
+	if !collector.IsEnabled() {
+		return fmt.Errorf("metrics collection is disabled")
+	}
+
+	info, err := collector.DBInfo()
+	if err != nil {
+		return fmt.Errorf("failed to read database info: %w", err)
+	}
+
+	fmt.Printf("🗄️  Metrics Database\n")
+	fmt.Printf("===================\n\n")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Path:\t%s\n", info.Path)
+	fmt.Fprintf(w, "Journal mode:\t%s\n", info.JournalMode)
+	fmt.Fprintf(w, "Synchronous:\t%s\n", info.Synchronous)
+	fmt.Fprintf(w, "Auto-vacuum:\t%s\n", info.AutoVacuum)
+	fmt.Fprintf(w, "Pages:\t%d of %d bytes (%d free)\n", info.PageCount, info.PageSize, info.FreePages)
+	fmt.Fprintf(w, "Database size:\t%s\n", formatBackupSize(info.DBSize))
+	fmt.Fprintf(w, "WAL size:\t%s\n", formatBackupSize(info.WALSize))
+	return w.Flush()
+}
+
 // Helper functions
 
 func getDatabasePath() string {
@@ -501,16 +1093,6 @@ func getSeverityIcon(avgDurationMs float64) string {
 	return "🟢 LOW"
 }
 
-func getComplexityIcon(commandCount int, avgDuration float64) string {
-	score := float64(commandCount) * (avgDuration / 1000.0)
-	if score > 1000 {
-		return "🔴 HIGH"
-	} else if score > 300 {
-		return "🟡 MEDIUM"
-	}
-	return "🟢 LOW" 
-}
-
 func getPerformanceIcon(avgDurationMs float64) string {
 	seconds := avgDurationMs / 1000.0
 	if seconds < 1.0 {
@@ -523,6 +1105,13 @@ func getPerformanceIcon(avgDurationMs float64) string {
 	return "🐌 VERY SLOW"
 }
 
+func formatThresholdMs(ms int64, present bool) string {
+	if !present || ms <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%dms", ms)
+}
+
 func truncateMetricsString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
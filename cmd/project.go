@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"claude-wm-cli/internal/debug"
-	"claude-wm-cli/internal/executor"
+	"claude-wm-cli/internal/errors"
 	"claude-wm-cli/internal/model"
 
 	"github.com/spf13/cobra"
@@ -33,14 +33,16 @@ var projectImportFeedbackCmd = &cobra.Command{
 	Short: "Import and process feedback from FEEDBACK.md",
 	Long: `Import feedback from the project's FEEDBACK.md file and process it
 into actionable items. This is the first step in the Project Update Cycle.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 		
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
 		if err := importFeedback(); err != nil {
-			fmt.Printf("Error importing feedback: %v\n", err)
-			os.Exit(1)
+			return errors.Wrap(err, "Failed to import feedback")
 		}
+		return nil
 	},
 }
 
@@ -49,14 +51,16 @@ var projectChallengeCmd = &cobra.Command{
 	Short: "Challenge existing documentation and assumptions",
 	Long: `Challenge the current project documentation, epics, and assumptions
 based on recent feedback and learnings. This helps identify areas for improvement.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 		
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
 		if err := challengeDocumentation(); err != nil {
-			fmt.Printf("Error challenging documentation: %v\n", err)
-			os.Exit(1)
+			return errors.Wrap(err, "Failed to challenge documentation")
 		}
+		return nil
 	},
 }
 
@@ -65,14 +69,16 @@ var projectEnrichCmd = &cobra.Command{
 	Short: "Enrich project context with additional information",
 	Long: `Enrich the project context by adding additional information,
 patterns, and insights based on current progress and external inputs.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 		
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
 		if err := enrichContext(); err != nil {
-			fmt.Printf("Error enriching context: %v\n", err)
-			os.Exit(1)
+			return errors.Wrap(err, "Failed to enrich context")
 		}
+		return nil
 	},
 }
 
@@ -81,14 +87,16 @@ var projectStatusUpdateCmd = &cobra.Command{
 	Short: "Update overall project status",
 	Long: `Update the overall project status based on current epic progress,
 feedback integration, and recent changes.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 		
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
 		if err := updateProjectStatus(); err != nil {
-			fmt.Printf("Error updating project status: %v\n", err)
-			os.Exit(1)
+			return errors.Wrap(err, "Failed to update project status")
 		}
+		return nil
 	},
 }
 
@@ -97,14 +105,16 @@ var projectImplementationStatusCmd = &cobra.Command{
 	Short: "Review and update implementation progress",
 	Long: `Review the current implementation status across all epics and stories,
 identifying blockers, completed work, and next priorities.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 		
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
 		if err := reviewImplementationStatus(); err != nil {
-			fmt.Printf("Error reviewing implementation status: %v\n", err)
-			os.Exit(1)
+			return errors.Wrap(err, "Failed to review implementation status")
 		}
+		return nil
 	},
 }
 
@@ -113,14 +123,16 @@ var projectPlanEpicsCmd = &cobra.Command{
 	Short: "Plan and manage epic roadmap",
 	Long: `Plan the epic roadmap by creating or updating the epics.json file
 with new epics, priorities, and dependencies based on project goals.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 		
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
 		if err := planEpics(); err != nil {
-			fmt.Printf("Error planning epics: %v\n", err)
-			os.Exit(1)
+			return errors.Wrap(err, "Failed to plan epics")
 		}
+		return nil
 	},
 }
 
@@ -151,7 +163,7 @@ func importFeedback() error {
 	fmt.Printf("✅ Feedback imported (%d bytes)\n", len(content))
 
 	// Create Claude executor and process feedback with AI
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
@@ -320,7 +332,7 @@ func challengeDocumentation() error {
 	}
 
 	// Create Claude executor
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
@@ -357,7 +369,7 @@ func enrichContext() error {
 	fmt.Println("🌟 Enriching project context...")
 	
 	// Create Claude executor
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
@@ -415,7 +427,7 @@ func updateProjectStatus() error {
 	fmt.Println("📊 Updating project status...")
 	
 	// Create Claude executor
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
@@ -452,7 +464,7 @@ func reviewImplementationStatus() error {
 	fmt.Println("🔍 Reviewing implementation status...")
 	
 	// Create Claude executor
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
@@ -489,7 +501,7 @@ func planEpics() error {
 	fmt.Println("📚 Planning epic roadmap...")
 	
 	// Create Claude executor
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 	
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
@@ -563,4 +575,7 @@ func init() {
 	
 	// Add epic management command
 	projectCmd.AddCommand(projectPlanEpicsCmd)
+
+	// Every project subcommand runs Claude
+	addClaudeExecutionFlags(projectCmd.PersistentFlags())
 }
\ No newline at end of file
@@ -4,28 +4,44 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"claude-wm-cli/internal/config"
+	"claude-wm-cli/internal/debug"
+	"claude-wm-cli/internal/errors"
+	"claude-wm-cli/internal/executor"
+	"claude-wm-cli/internal/meta"
 	"claude-wm-cli/internal/model"
+	"claude-wm-cli/internal/theme"
 	"claude-wm-cli/internal/validation"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-// Version information - will be set at build time
-var (
-	Version   = "dev"
-	GitCommit = "unknown"
-	BuildTime = "unknown"
-)
-
 // Global configuration variables
 var (
 	cfgFile   string
 	verbose   bool
 	debugMode bool
+	noEmoji   bool
+	colorFlag string
+	themeFlag string
+
+	errorsJSON bool // --errors-json, or the errors_json config key
+
+	debugOutput string
+	debugJSON   bool
+	logFile     string
+	autoCheck   bool
+
+	// updateCheck receives the result of the --auto-check update check
+	updateCheck chan *meta.UpdateCheck
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -58,51 +74,180 @@ EXAMPLES:
   claude-wm-cli execute --timeout 60 "claude build"  # Custom timeout
   claude-wm-cli --config ./custom.yaml status     # Use custom config
   claude-wm-cli --verbose execute "claude test"   # Verbose output
+  claude-wm-cli --dry-run ticket execute-full      # Show the Claude commands without running them
+  claude-wm-cli --no-emoji status                  # Plain ASCII output for CI logs
+  claude-wm-cli --color always ticket list | less -R  # Keep colors when piping
 
 CONFIGURATION:
   Default config file: ~/.claude-wm-cli.yaml or ./.claude-wm-cli.yaml
-  Environment variables: CLAUDE_WM_* (e.g., CLAUDE_WM_VERBOSE=true)`,
-	Version: Version,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// Skip validation for init, config, help, and version commands
+  Environment variables: CLAUDE_WM_* (e.g., CLAUDE_WM_VERBOSE=true)
+  ASCII-only output: --no-emoji, NO_COLOR=1 or CLAUDE_WM_ASCII=true
+  Colors: --color auto|always|never (auto colors terminals only), --theme default|high-contrast`,
+	Version: meta.BuildInfo().String(),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := configureDebugLog(); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		if viper.GetBool("auto_check") && !meta.UpdateCheckDisabled() && cmd != versionCheckCmd {
+			startUpdateCheck()
+		}
+
+		// Skip validation for init, config, help, version and completion commands
 		cmdName := cmd.Name()
-		if cmdName == "init" || cmdName == "config" || cmdName == "help" || cmdName == "version" {
-			return
+		if cmdName == "init" || cmdName == "config" || cmdName == "help" || cmdName == "version" || cmd == versionCheckCmd ||
+			cmdName == "completion" || cmdName == cobra.ShellCompRequestCmd || cmdName == cobra.ShellCompNoDescRequestCmd {
+			return nil
 		}
 
+		applyMetricsRetention()
+
 		// Validate all JSON files at startup
 		if err := validation.ValidateOnStartup(); err != nil {
-			fmt.Fprintf(os.Stderr, "❌ JSON validation failed at startup:\n%v\n", err)
-			fmt.Fprintf(os.Stderr, "\n💡 Use hooks to auto-correct JSON files or fix manually\n")
-			os.Exit(1)
+			cmd.SilenceUsage = true
+			if !errorsJSON {
+				// The report below replaces cobra's one-line error
+				fmt.Fprint(os.Stderr, theme.Text(fmt.Sprintf("❌ JSON validation failed at startup:\n%v\n", err)))
+				fmt.Fprint(os.Stderr, theme.Text("\n💡 Use hooks to auto-correct JSON files or fix manually\n"))
+				cmd.SilenceErrors = true
+			}
+			return errors.ErrValidationFailed(fmt.Sprintf("JSON validation failed at startup: %v", err)).
+				WithSuggestion("Use hooks to auto-correct JSON files or fix manually")
 		}
+		return nil
 	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	// Flag parsing errors are returned before the flags are set
+	if errorsJSONRequested(os.Args[1:]) {
+		enableJSONErrors()
+	}
+	wrapCommandErrors(rootCmd)
+
 	err := rootCmd.Execute()
+	debug.Close()
+	printUpdateCheck()
 	if err != nil {
-		os.Exit(1)
+		if errorsJSON {
+			errors.WriteJSON(os.Stderr, err)
+		}
+		os.Exit(errors.AsExitCode(err))
+	}
+}
+
+// startUpdateCheck checks for a newer release in the background, for
+// printUpdateCheck to report after the command
+func startUpdateCheck() {
+	updateCheck = make(chan *meta.UpdateCheck, 1)
+	go func() {
+		const timeout = 5 * time.Second
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		check, err := meta.NewUpdateChecker(timeout).Check(ctx, meta.BuildInfo().Version)
+		if err != nil {
+			check = nil
+		}
+		updateCheck <- check
+	}()
+}
+
+// printUpdateCheck prints the update line on stderr when the background
+// update check found a newer release. Failed checks are silent.
+func printUpdateCheck() {
+	if updateCheck == nil {
+		return
+	}
+	if check := <-updateCheck; check != nil && check.UpdateAvailable() {
+		fmt.Fprintln(os.Stderr)
+		printUpdateAvailable(os.Stderr, check)
+	}
+}
+
+// wrapCommandErrors makes the RunE of cmd and its subcommands return a
+// CLIError recording the command that failed, so that the exit code and the
+// JSON error always come from a CLIError
+func wrapCommandErrors(cmd *cobra.Command) {
+	if runE := cmd.RunE; runE != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			err := runE(cmd, args)
+			if err == nil {
+				return nil
+			}
+			return errors.AsCLIError(err).WithContext("command", cmd.CommandPath())
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		wrapCommandErrors(sub)
+	}
+}
+
+// enableJSONErrors makes Execute report the error of the command as JSON
+// instead of cobra's error message and usage
+func enableJSONErrors() {
+	errorsJSON = true
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+}
+
+// errorsJSONRequested reports whether args turn on --errors-json
+func errorsJSONRequested(args []string) bool {
+	requested := false
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "--errors-json" {
+			requested = true
+		} else if value, ok := strings.CutPrefix(arg, "--errors-json="); ok {
+			requested, _ = strconv.ParseBool(value)
+		}
 	}
+	return requested
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	// Invalid flags exit with the usage code
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return errors.NewCLIError(err.Error(), errors.ExitUsage)
+	})
+
 	// Global persistent flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.claude-wm-cli.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "debug output - shows all commands executed including Claude calls")
+	rootCmd.PersistentFlags().BoolVar(&executor.DryRunMode, "dry-run", false, "print the Claude commands that would run without executing them")
+	rootCmd.PersistentFlags().BoolVar(&noEmoji, "no-emoji", false, "replace emoji with ASCII markers (also NO_COLOR or CLAUDE_WM_ASCII)")
+	rootCmd.PersistentFlags().StringVar(&colorFlag, "color", string(theme.ColorAuto), "colored output: auto (terminals only, unless NO_COLOR is set), always or never")
+	rootCmd.PersistentFlags().StringVar(&themeFlag, "theme", theme.DefaultPalette, "color theme: default or high-contrast")
+	rootCmd.PersistentFlags().StringVar(&debugOutput, "debug-output", "", "append debug logs to this file instead of stderr (implies --debug)")
+	rootCmd.PersistentFlags().BoolVar(&debugJSON, "debug-json", false, "write debug logs as newline-delimited JSON (implies --debug)")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "also append all debug logs to this file, rotated at log.max_size_mb (default 10)")
+	rootCmd.PersistentFlags().BoolVar(&autoCheck, "auto-check", false, "check for a newer release in the background and report it after the command (CLAUDE_WM_NO_UPDATE_CHECK=1 disables)")
+	rootCmd.PersistentFlags().BoolVar(&errorsJSON, "errors-json", false, "report command errors on stderr as JSON (message, code, details, suggestion, context) and exit with their code")
 
 	// Bind flags to viper
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug"))
+	viper.BindPFlag("color", rootCmd.PersistentFlags().Lookup("color"))
+	viper.BindPFlag("theme", rootCmd.PersistentFlags().Lookup("theme"))
+	viper.BindPFlag("debug_output", rootCmd.PersistentFlags().Lookup("debug-output"))
+	viper.BindPFlag("debug_json", rootCmd.PersistentFlags().Lookup("debug-json"))
+	viper.BindPFlag("log.file", rootCmd.PersistentFlags().Lookup("log-file"))
+	viper.BindPFlag("auto_check", rootCmd.PersistentFlags().Lookup("auto-check"))
+	viper.BindPFlag("errors_json", rootCmd.PersistentFlags().Lookup("errors-json"))
 }
 
 // initConfig reads in config file and ENV variables.
 func initConfig() {
+	theme.SetASCII(noEmoji || theme.ASCIIFromEnv())
+
 	// Validate config file if specified
 	if cfgFile != "" {
 		if err := model.ValidateConfigFile(cfgFile); err != nil {
@@ -143,4 +288,93 @@ func initConfig() {
 	} else if verbose {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	// Project settings override the user config file
+	mergeProjectConfig()
+
+	configureColors()
+
+	if viper.GetBool("errors_json") {
+		enableJSONErrors()
+	}
+}
+
+// configureDebugLog sets the correlation ID of this invocation and the format
+// and destination of debug logs. Asking for JSON or file debug logs turns on
+// debug mode; a log file (--log-file or log.file) receives the logs without
+// it.
+func configureDebugLog() error {
+	debug.SetCorrelationID(debug.NewCorrelationID())
+
+	output := viper.GetString("debug_output")
+	jsonMode := viper.GetBool("debug_json")
+	if output != "" || jsonMode {
+		debugMode = true
+		viper.Set("debug", true)
+	}
+	debug.SetDebugMode(debugMode || viper.GetBool("debug"))
+	debug.SetJSONMode(jsonMode)
+
+	if output != "" {
+		if err := debug.SetOutputFile(output); err != nil {
+			return errors.NewCLIError(fmt.Sprintf("Cannot write debug logs: %v", err), errors.ExitUsage).
+				WithSuggestion("Check that the directory of --debug-output exists and is writable").
+				WithContext("path", output)
+		}
+	}
+
+	if path := viper.GetString("log.file"); path != "" {
+		maxSize := int64(viper.GetFloat64("log.max_size_mb") * 1024 * 1024)
+		maxBackups := debug.DefaultLogMaxBackups
+		if viper.IsSet("log.max_backups") {
+			maxBackups = viper.GetInt("log.max_backups")
+		}
+		if err := debug.SetLogFile(path, maxSize, maxBackups); err != nil {
+			return errors.NewCLIError(fmt.Sprintf("Cannot write log file: %v", err), errors.ExitUsage).
+				WithCategory(errors.CategoryConfig).
+				WithSuggestion("Check that the directory of --log-file (or log.file) exists and is writable").
+				WithContext("path", path)
+		}
+	}
+	return nil
+}
+
+// configureColors turns colored output on or off and selects the color theme
+// from --color and --theme, or the color and theme config keys
+func configureColors() {
+	mode, err := theme.ParseColorMode(viper.GetString("color"))
+	if err != nil {
+		model.HandleValidationError(model.NewValidationError(err.Error()), "claude-wm-cli --color never status")
+		return
+	}
+	if err := theme.SetPalette(viper.GetString("theme")); err != nil {
+		model.HandleValidationError(model.NewValidationError(err.Error()), "claude-wm-cli --theme high-contrast status")
+		return
+	}
+	theme.SetColor(theme.ColorEnabled(mode, os.Stdout))
+}
+
+// mergeProjectConfig overlays .claude-wm/config.json from the current project on
+// viper, with the active profile (if any) merged on top
+func mergeProjectConfig() {
+	manager := config.NewManager(".")
+	settings, err := manager.LoadConfig()
+	if err != nil {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Ignoring project config: %v\n", err)
+		}
+		return
+	}
+	if len(settings) == 0 {
+		return
+	}
+
+	if err := viper.MergeConfigMap(settings); err != nil && verbose {
+		fmt.Fprintf(os.Stderr, "Ignoring project config: %v\n", err)
+	} else if verbose {
+		fmt.Fprintln(os.Stderr, "Using project config file:", manager.GetProjectConfigPath())
+		if profile, _, _ := manager.ActiveProfile(); profile != "" {
+			fmt.Fprintln(os.Stderr, "Using config profile:", profile)
+		}
+	}
 }
@@ -1,17 +1,51 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"claude-wm-cli/internal/serena"
 )
 
+// stringsFlag is a flag that can be repeated, collecting its values
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
 func main() {
 	var rootPath string
+	var excludes stringsFlag
+	var watch, verbose, showStats, full bool
+	var searchQuery, format string
+	var top int
 	flag.StringVar(&rootPath, "root", ".", "Root directory to scan for docs")
+	flag.BoolVar(&watch, "watch", false, "Keep running and re-index when docs/**/*.md change")
+	flag.BoolVar(&verbose, "verbose", false, "Log every change seen in watch mode, not only each re-index")
+	flag.StringVar(&searchQuery, "search", "", "Search the indexed docs for all words of the query instead of indexing")
+	flag.IntVar(&top, "top", 10, "Maximum number of search results to show")
+	flag.StringVar(&format, "format", "text", "Search output format: text or json")
+	flag.BoolVar(&showStats, "stats", false, "Print statistics of the docs index instead of indexing")
+	flag.BoolVar(&full, "full", false, "Ignore the manifest and cache and reindex every doc")
+	flag.Var(&excludes, "exclude", "Glob pattern of docs not to index, on top of .serenaignore (repeatable)")
 	flag.Parse()
 
 	// Convert to absolute path
@@ -26,10 +60,155 @@ func main() {
 		log.Fatalf("docs/ directory not found in %s", absRoot)
 	}
 
+	if searchQuery != "" {
+		if err := runSearch(os.Stdout, absRoot, searchQuery, top, format); err != nil {
+			log.Fatalf("Search failed: %v", err)
+		}
+		return
+	}
+
+	if showStats {
+		if err := runStats(os.Stdout, absRoot); err != nil {
+			log.Fatalf("Stats failed: %v", err)
+		}
+		return
+	}
+
 	log.Printf("Running Serena incremental indexer for: %s", absRoot)
 
+	options := serena.IndexOptions{Exclude: excludes, Full: full}
+	if _, err := serena.NewExcluder(options.Exclude); err != nil {
+		log.Fatalf("%v", err)
+	}
+	index := func(root string) error {
+		return serena.RunIncrementalIndexWithOptions(root, options)
+	}
+
 	// Run incremental indexing
-	if err := serena.RunIncrementalIndex(absRoot); err != nil {
-		log.Fatalf("Incremental indexing failed: %v", err)
+	if err := index(absRoot); err != nil {
+		if !watch {
+			log.Fatalf("Incremental indexing failed: %v", err)
+		}
+		log.Printf("Incremental indexing failed, still watching: %v", err)
+	}
+	// Only the first run is a full rebuild, re-indexes in watch mode are incremental
+	options.Full = false
+
+	if !watch {
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Watching %s for changes (Ctrl+C to stop)", docsDir)
+	stats, err := watchDocs(ctx, absRoot, verbose, index)
+	if err != nil {
+		log.Fatalf("Watch failed: %v", err)
+	}
+	log.Printf("Stopped watching: re-indexed %d times (%d failed)", stats.Runs, stats.Failed)
+}
+
+// runSearch prints the top search results for query, as text or JSON
+func runSearch(w io.Writer, root, query string, top int, format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format %q: expected text or json", format)
+	}
+	if top < 1 {
+		return fmt.Errorf("invalid --top %d: must be at least 1", top)
+	}
+
+	results, err := serena.Search(root, query)
+	if err != nil {
+		return err
+	}
+	if len(results) > top {
+		results = results[:top]
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(w, "No results for %q\n", query)
+		return nil
+	}
+
+	before, after := "**", "**"
+	if isTerminal(w) {
+		before, after = "\033[1;33m", "\033[0m"
+	}
+	for i, result := range results {
+		fmt.Fprintf(w, "%2d. %s:%d (score %.4f)\n", i+1, result.File, result.LineNumber, result.Score)
+		if result.Excerpt != "" {
+			fmt.Fprintf(w, "    %s\n", serena.Highlight(result.Excerpt, query, before, after))
+		}
+	}
+	return nil
+}
+
+// runStats prints the statistics of the docs index, then the docs changed
+// since it was built and the indexed files that no longer exist
+func runStats(w io.Writer, root string) error {
+	stats, err := serena.GetIndexStats(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no docs index found in %s: run serena-indexer first", root)
+	}
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	var dangling *serena.DanglingReferencesError
+	if err := serena.ValidateIndex(root); errors.As(err, &dangling) {
+		missing = dangling.Files
+	} else if err != nil {
+		return err
+	}
+
+	version := stats.IndexVersion
+	if version == "" {
+		version = "missing"
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Files\t%d\n", stats.FileCount)
+	fmt.Fprintf(tw, "Total size\t%d bytes\n", stats.TotalSizeBytes)
+	fmt.Fprintf(tw, "Last indexed\t%s\n", stats.LastIndexed.Format(time.RFC3339))
+	fmt.Fprintf(tw, "Index version\t%s\n", version)
+	fmt.Fprintf(tw, "Stale files\t%d\n", len(stats.StaleFiles))
+	fmt.Fprintf(tw, "Missing files\t%d\n", len(missing))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(stats.StaleFiles) > 0 {
+		fmt.Fprintln(w, "\nChanged since last indexed:")
+		for _, file := range stats.StaleFiles {
+			fmt.Fprintf(w, "  %s\n", file)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintln(w, "\nIndexed but missing:")
+		for _, file := range missing {
+			fmt.Fprintf(w, "  %s\n", file)
+		}
+	}
+	return nil
+}
+
+// isTerminal reports whether w is an interactive terminal
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
 	}
-}
\ No newline at end of file
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
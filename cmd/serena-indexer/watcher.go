@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long the watcher waits after the last change before
+// re-indexing, so that a burst of saves triggers a single run
+const watchDebounce = 500 * time.Millisecond
+
+// watchStats summarizes a watch session
+type watchStats struct {
+	Runs   int // Re-index runs triggered by changes
+	Failed int // Runs that returned an error
+}
+
+// watchDocs calls index whenever markdown files under root/docs change, until
+// ctx is done. Changes are debounced by watchDebounce and each run is logged
+// with the files that changed and how long it took; index errors are logged
+// and the watch goes on. verbose also logs every change as it is seen.
+func watchDocs(ctx context.Context, root string, verbose bool, index func(root string) error) (watchStats, error) {
+	var stats watchStats
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return stats, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	docsDir := filepath.Join(root, "docs")
+	if err := addWatchTree(watcher, docsDir); err != nil {
+		return stats, err
+	}
+
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+	changed := make(map[string]bool)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return stats, nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return stats, nil
+			}
+
+			// Watch new directories, fsnotify does not recurse by itself, and
+			// re-index in case they were moved in with files
+			isDir := false
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					isDir = true
+					if err := addWatchTree(watcher, event.Name); err != nil {
+						log.Printf("[SERENA] %v", err)
+					}
+				}
+			}
+
+			if !isDir && (!strings.EqualFold(filepath.Ext(event.Name), ".md") || event.Op == fsnotify.Chmod) {
+				continue
+			}
+			rel, err := filepath.Rel(root, event.Name)
+			if err != nil {
+				rel = event.Name
+			}
+			changed[rel] = true
+			if verbose {
+				log.Printf("[SERENA] %s: %s", strings.ToLower(event.Op.String()), rel)
+			}
+			timer.Reset(watchDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return stats, nil
+			}
+			log.Printf("[SERENA] Watch error: %v", err)
+
+		case <-timer.C:
+			files := make([]string, 0, len(changed))
+			for file := range changed {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			changed = make(map[string]bool)
+
+			stats.Runs++
+			start := time.Now()
+			if err := index(root); err != nil {
+				stats.Failed++
+				log.Printf("[SERENA] Incremental indexing failed, still watching: %v", err)
+				continue
+			}
+			log.Printf("[SERENA] Re-indexed %s in %s", strings.Join(files, ", "), time.Since(start).Round(time.Millisecond))
+		}
+	}
+}
+
+// addWatchTree watches dir and every directory below it
+func addWatchTree(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchDocs_DebouncesAndSurvivesErrors(t *testing.T) {
+	root := t.TempDir()
+	docsDir := filepath.Join(root, "docs")
+	require.NoError(t, os.MkdirAll(docsDir, 0755))
+
+	var calls atomic.Int32
+	index := func(string) error {
+		calls.Add(1)
+		return errors.New("serena unavailable")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan watchStats)
+	go func() {
+		stats, err := watchDocs(ctx, root, true, index)
+		assert.NoError(t, err)
+		done <- stats
+	}()
+	time.Sleep(100 * time.Millisecond) // Let the watcher start
+
+	// A burst of saves re-indexes once, other files are ignored
+	for i := 0; i < 3; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(docsDir, "guide.md"), []byte{byte('a' + i)}, 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(docsDir, "notes.txt"), []byte("x"), 0644))
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, 3*time.Second, 50*time.Millisecond)
+
+	// The watch goes on after a failed run, including in new directories
+	subDir := filepath.Join(docsDir, "api")
+	require.NoError(t, os.MkdirAll(subDir, 0755))
+	require.Eventually(t, func() bool { return calls.Load() == 2 }, 3*time.Second, 50*time.Millisecond)
+	require.NoError(t, os.WriteFile(filepath.Join(subDir, "endpoints.md"), []byte("x"), 0644))
+	require.Eventually(t, func() bool { return calls.Load() == 3 }, 3*time.Second, 50*time.Millisecond)
+
+	cancel()
+	stats := <-done
+	assert.Equal(t, 3, stats.Runs)
+	assert.Equal(t, 3, stats.Failed)
+}
+
+// syncBuffer is a bytes.Buffer safe for the watcher goroutine to log to
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWatchDocs_LogsEachRun(t *testing.T) {
+	root := t.TempDir()
+	docsDir := filepath.Join(root, "docs")
+	require.NoError(t, os.MkdirAll(docsDir, 0755))
+
+	var output syncBuffer
+	log.SetOutput(&output)
+	defer log.SetOutput(os.Stderr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan watchStats)
+	go func() {
+		stats, err := watchDocs(ctx, root, false, func(string) error { return nil })
+		assert.NoError(t, err)
+		done <- stats
+	}()
+	time.Sleep(100 * time.Millisecond) // Let the watcher start
+
+	require.NoError(t, os.WriteFile(filepath.Join(docsDir, "b.md"), []byte("b"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(docsDir, "a.md"), []byte("a"), 0644))
+	require.Eventually(t, func() bool {
+		return strings.Contains(output.String(), "Re-indexed "+filepath.Join("docs", "a.md")+", "+filepath.Join("docs", "b.md")+" in ")
+	}, 3*time.Second, 50*time.Millisecond)
+
+	cancel()
+	stats := <-done
+	assert.Equal(t, 1, stats.Runs)
+	assert.Zero(t, stats.Failed)
+}
@@ -4,73 +4,364 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"claude-wm-cli/internal/backup"
+	"claude-wm-cli/internal/metrics"
+	"claude-wm-cli/internal/navigation"
+	"claude-wm-cli/internal/theme"
+	"claude-wm-cli/internal/ticket"
 
 	"github.com/spf13/cobra"
 )
 
+var (
+	statusJSON    bool
+	statusNoCache bool
+	statusCompact bool
+)
+
+// statusRecentCommands is the number of recent commands in the status
+const statusRecentCommands = 3
+
 // statusCmd represents the status command
 var statusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Show current project state",
-	Long: `Display the current state of the project including:
-- Current epic and story progress
-- Active tasks and their status
-- Project workflow phase
-- Configuration information
+	Short: "Show the project health summary",
+	Long: `Display a summary of the project health that fits in one screen and
+exit, without entering the interactive menu:
+- Detected workflow state
+- Current epic with its progress, current story with its tasks and current task
+- Active ticket, open tickets and tickets past their SLA
+- Number of backups and time of the last one
+- The last commands recorded by the performance metrics
+- The top suggested next action
+
+Use --json to consume the full status from scripts or CI, and --compact for a
+single line to embed in a shell prompt:
+
+  [EPIC-001 45%] Story: Auth Flow (3/5 tasks) | 2 tickets open | Last backup: 5m ago
+
+The detected context is cached under .claude-wm/cache until a state file
+changes; use --no-cache to force a fresh detection.
 
 Examples:
-  claude-wm-cli status              # Show full project status
-  claude-wm-cli status --verbose    # Show detailed status with debug info`,
-	Run: func(cmd *cobra.Command, args []string) {
-		showProjectStatus()
+  claude-wm-cli status              # Show the project status
+  claude-wm-cli status --json       # Output the project status as JSON
+  claude-wm-cli status --compact    # Output the project status on one line
+  claude-wm-cli status --no-cache   # Ignore the cached project context`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showProjectStatus()
 	},
 }
 
-func showProjectStatus() {
-	fmt.Println("📊 Claude WM CLI Project Status")
-	fmt.Println("================================")
-	fmt.Println()
-
-	// Check for project structure
-	fmt.Println("🏗️  Project Structure:")
-	fmt.Println("  ✓ Go module initialized")
-	fmt.Println("  ✓ Cobra CLI framework installed")
-	fmt.Println("  ✓ Development tooling configured")
-	fmt.Println("  ✓ Directory structure created")
-	fmt.Println()
-
-	// Current epic info (placeholder - will be enhanced later)
-	fmt.Println("🎯 Current Epic: CLI Foundation & Command Execution")
-	fmt.Println("📈 Progress: Basic CLI structure completed")
-	fmt.Println()
-
-	// Configuration status
-	fmt.Println("⚙️  Configuration:")
-	fmt.Printf("  - Config file: %s\n", getConfigStatus())
-	fmt.Printf("  - Verbose mode: %v\n", verbose)
-	fmt.Println()
-
-	fmt.Println("✅ Ready for development!")
+// ProjectStatus is the summary printed by the status command
+type ProjectStatus struct {
+	State          string            `json:"state"`
+	ProjectPath    string            `json:"project_path"`
+	CurrentEpic    *StatusItem       `json:"current_epic,omitempty"`
+	CurrentStory   *StatusItem       `json:"current_story,omitempty"`
+	CurrentTask    *StatusItem       `json:"current_task,omitempty"`
+	ActiveTicket   *StatusItem       `json:"active_ticket,omitempty"`
+	OpenTickets    int               `json:"open_tickets"`
+	SLABreaches    []string          `json:"sla_breaches,omitempty"` // IDs of the open tickets past their SLA
+	Backups        StatusBackups     `json:"backups"`
+	RecentCommands []StatusCommand   `json:"recent_commands,omitempty"`
+	Issues         []string          `json:"issues,omitempty"`
+	Suggestion     *StatusSuggestion `json:"suggestion,omitempty"`
+}
+
+// StatusItem is the current epic, story, task or ticket in the project status
+type StatusItem struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	Status    string  `json:"status,omitempty"`
+	Progress  float64 `json:"progress,omitempty"`  // 0.0 to 1.0
+	Completed int     `json:"completed,omitempty"` // Completed stories of an epic, tasks of a story
+	Total     int     `json:"total,omitempty"`
+}
+
+// StatusBackups is the backup health in the project status
+type StatusBackups struct {
+	Count      int        `json:"count"`
+	LastBackup *time.Time `json:"last_backup,omitempty"`
 }
 
-func getConfigStatus() string {
-	if cfgFile != "" {
-		return cfgFile
+// StatusCommand is a recent command recorded by the performance metrics
+type StatusCommand struct {
+	Command    string    `json:"command"`
+	DurationMs int64     `json:"duration_ms"`
+	ExitCode   int       `json:"exit_code"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// StatusSuggestion is the top suggested next action
+type StatusSuggestion struct {
+	Action    string `json:"action"`
+	Name      string `json:"name"`
+	Reasoning string `json:"reasoning,omitempty"`
+}
+
+func showProjectStatus() error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	status, err := buildProjectStatus(wd)
+	if err != nil {
+		return err
 	}
-	return "default (.claude-wm-cli.yaml)"
+
+	if statusJSON {
+		data, err := json.MarshalIndent(status, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode status: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if statusCompact {
+		theme.Println(compactProjectStatus(status, time.Now()))
+		return nil
+	}
+
+	printProjectStatus(os.Stdout, status, time.Now())
+	return nil
 }
 
-func init() {
-	rootCmd.AddCommand(statusCmd)
+// printProjectStatus writes the status to w in at most 24 lines
+func printProjectStatus(w io.Writer, status *ProjectStatus, now time.Time) {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "📊 %s\n", status.State)
+
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if epic := status.CurrentEpic; epic != nil {
+		fmt.Fprintf(tw, "Epic\t%s %s\t%s %.0f%% (%d/%d stories)\n", epic.ID, epic.Title,
+			statusProgressBar(epic.Progress, 20), epic.Progress*100, epic.Completed, epic.Total)
+	}
+	if story := status.CurrentStory; story != nil {
+		fmt.Fprintf(tw, "Story\t%s %s\t(%d/%d tasks)\n", story.ID, story.Title, story.Completed, story.Total)
+	}
+	if task := status.CurrentTask; task != nil {
+		fmt.Fprintf(tw, "Task\t%s %s\t%s\n", task.ID, task.Title, task.Status)
+	}
+	if active := status.ActiveTicket; active != nil {
+		fmt.Fprintf(tw, "Ticket\t%s %s\t%s\n", active.ID, active.Title, active.Status)
+	}
+
+	breaches := ""
+	if len(status.SLABreaches) > 0 {
+		breaches = fmt.Sprintf("%d past SLA: %s", len(status.SLABreaches), strings.Join(status.SLABreaches, ", "))
+	}
+	fmt.Fprintf(tw, "Tickets\t%d open\t%s\n", status.OpenTickets, breaches)
+
+	if status.Backups.LastBackup != nil {
+		fmt.Fprintf(tw, "Backups\t%d\tlast %s\n", status.Backups.Count, formatAgo(*status.Backups.LastBackup, now))
+	} else {
+		fmt.Fprintf(tw, "Backups\tnone\t\n")
+	}
+
+	for i, command := range status.RecentCommands {
+		label := ""
+		if i == 0 {
+			label = "Recent"
+		}
+		result := fmt.Sprintf("%dms", command.DurationMs)
+		if command.ExitCode != 0 {
+			result += fmt.Sprintf(", exit %d", command.ExitCode)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s, %s\n", label, command.Command, result, formatAgo(command.Timestamp, now))
+	}
+	tw.Flush()
+
+	// Keep the summary on one screen: issues beyond the first few are left
+	// to the doctor command
+	const maxIssues = 3
+	for i, issue := range status.Issues {
+		if i == maxIssues {
+			fmt.Fprintf(&buf, "%s\n", theme.Warning(fmt.Sprintf("⚠️  … and %d more (run 'claude-wm-cli doctor')", len(status.Issues)-maxIssues)))
+			break
+		}
+		fmt.Fprintf(&buf, "%s\n", theme.Warning("⚠️  "+issue))
+	}
+	if status.Suggestion != nil {
+		fmt.Fprintf(&buf, "💡 Next: %s", theme.Accent(status.Suggestion.Name))
+		if status.Suggestion.Reasoning != "" {
+			fmt.Fprintf(&buf, " - %s", status.Suggestion.Reasoning)
+		}
+		buf.WriteString("\n")
+	}
+
+	fmt.Fprint(w, theme.Text(buf.String()))
+}
+
+// compactProjectStatus returns the status on one line for shell prompts:
+// [EPIC-001 45%] Story: Auth Flow (3/5 tasks) | 2 tickets open | Last backup: 5m ago
+func compactProjectStatus(status *ProjectStatus, now time.Time) string {
+	var work []string
+	if epic := status.CurrentEpic; epic != nil {
+		work = append(work, fmt.Sprintf("[%s %.0f%%]", epic.ID, epic.Progress*100))
+	}
+	if story := status.CurrentStory; story != nil {
+		work = append(work, fmt.Sprintf("Story: %s (%d/%d tasks)", story.Title, story.Completed, story.Total))
+	}
+	if len(work) == 0 {
+		work = append(work, status.State)
+	}
+
+	tickets := fmt.Sprintf("%d tickets open", status.OpenTickets)
+	if status.OpenTickets == 1 {
+		tickets = "1 ticket open"
+	}
+	if len(status.SLABreaches) > 0 {
+		tickets += fmt.Sprintf(" (%d past SLA)", len(status.SLABreaches))
+	}
+
+	backups := "No backups"
+	if status.Backups.LastBackup != nil {
+		backups = "Last backup: " + formatAgo(*status.Backups.LastBackup, now)
+	}
+
+	return strings.Join([]string{strings.Join(work, " "), tickets, backups}, " | ")
+}
+
+// statusProgressBar returns a bar of width cells filled up to progress
+func statusProgressBar(progress float64, width int) string {
+	progress = math.Max(0, math.Min(1, progress))
+	filled := int(math.Round(progress * float64(width)))
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// formatAgo returns how long before now t was, such as "5m ago"
+func formatAgo(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
 
-	// Here you will define your flags and configuration settings.
+// buildProjectStatus detects the project state in wd, with its tickets,
+// backups, recent commands and top suggestion
+func buildProjectStatus(wd string) (*ProjectStatus, error) {
+	ctx, err := navigation.NewContextDetector(wd).EnableCache(!statusNoCache).DetectContext()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect project context: %w", err)
+	}
 
-	// Cobra supports Persistent Flags which will work for this command
-	// and all subcommands, e.g.:
-	// statusCmd.PersistentFlags().String("foo", "", "A help for foo")
+	status := &ProjectStatus{
+		State:       ctx.State.String(),
+		ProjectPath: ctx.ProjectPath,
+		Issues:      ctx.Issues,
+	}
+	if epic := ctx.CurrentEpic; epic != nil {
+		status.CurrentEpic = &StatusItem{ID: epic.ID, Title: epic.Title, Status: epic.Status, Progress: epic.Progress,
+			Completed: epic.CompletedStories, Total: epic.TotalStories}
+	}
+	if story := ctx.CurrentStory; story != nil {
+		status.CurrentStory = &StatusItem{ID: story.ID, Title: story.Title, Status: story.Status, Progress: story.Progress,
+			Completed: story.CompletedTasks, Total: story.TotalTasks}
+	}
+	if ctx.CurrentTask != nil {
+		status.CurrentTask = &StatusItem{ID: ctx.CurrentTask.ID, Title: ctx.CurrentTask.Title, Status: ctx.CurrentTask.Status}
+	}
+
+	ticketManager := ticket.NewManager(wd)
+	tickets, err := ticketManager.ListTickets(ticket.TicketListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tickets: %w", err)
+	}
+	for _, aged := range ticket.AgeTickets(tickets, ticket.LoadSLAPolicy(wd), time.Now()) {
+		status.OpenTickets++
+		if aged.SLAStatus == ticket.SLABreached {
+			status.SLABreaches = append(status.SLABreaches, aged.ID)
+		}
+	}
+	if active, err := ticketManager.GetCurrentTicket(); err == nil && active != nil {
+		status.ActiveTicket = &StatusItem{ID: active.ID, Title: active.Title, Status: string(active.Status)}
+	}
+
+	status.Backups = projectBackups(wd)
+	status.RecentCommands = recentCommands(wd)
+
+	suggestions, err := navigation.NewSuggestionEngine().GenerateSuggestions(ctx)
+	if err == nil && len(suggestions) > 0 && suggestions[0].Action != nil {
+		top := suggestions[0]
+		status.Suggestion = &StatusSuggestion{Action: top.Action.ID, Name: top.Action.Name, Reasoning: top.Reasoning}
+	}
+
+	return status, nil
+}
+
+// projectBackups counts the backups of the project in wd. A project without
+// backups, or whose backups cannot be listed, has none.
+func projectBackups(wd string) StatusBackups {
+	var result StatusBackups
+	config := backup.DefaultBackupConfig()
+	config.BackupDirectory = filepath.Join(wd, config.BackupDirectory)
+	if _, err := os.Stat(config.BackupDirectory); err != nil {
+		return result
+	}
+
+	manager, err := backup.NewManager(config)
+	if err != nil {
+		return result
+	}
+	backups, err := manager.ListBackups(nil)
+	if err != nil {
+		return result
+	}
+
+	result.Count = len(backups)
+	for _, b := range backups {
+		if result.LastBackup == nil || b.CreatedAt.After(*result.LastBackup) {
+			createdAt := b.CreatedAt
+			result.LastBackup = &createdAt
+		}
+	}
+	return result
+}
+
+// recentCommands returns the last commands run in wd, none when metrics are
+// disabled
+func recentCommands(wd string) []StatusCommand {
+	entries, err := metrics.GetCollector().GetRecentCommands(wd, statusRecentCommands)
+	if err != nil {
+		return nil
+	}
+
+	commands := make([]StatusCommand, 0, len(entries))
+	for _, entry := range entries {
+		commands = append(commands, StatusCommand{
+			Command:    entry.CommandName,
+			DurationMs: entry.DurationMs,
+			ExitCode:   entry.ExitCode,
+			Timestamp:  entry.Timestamp,
+		})
+	}
+	return commands
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
 
-	// Cobra supports local flags which will only run when this command
-	// is called directly, e.g.:
-	// statusCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "Output the project status as JSON")
+	statusCmd.Flags().BoolVar(&statusCompact, "compact", false, "Output the project status on one line, for shell prompts")
+	statusCmd.Flags().BoolVar(&statusNoCache, "no-cache", false, "Re-detect the project context instead of reusing the cached one")
 }
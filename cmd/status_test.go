@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProjectStatus_NotInitialized(t *testing.T) {
+	status, err := buildProjectStatus(t.TempDir())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Not Initialized", status.State)
+	assert.Nil(t, status.CurrentEpic)
+	assert.Equal(t, 0, status.OpenTickets)
+	require.NotNil(t, status.Suggestion)
+	assert.NotEmpty(t, status.Suggestion.Name)
+
+	data, err := json.Marshal(status)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"state":"Not Initialized"`)
+	assert.NotContains(t, string(data), "current_epic")
+}
+
+func TestCompactProjectStatus(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastBackup := now.Add(-5 * time.Minute)
+	status := &ProjectStatus{
+		State:        "Story In Progress",
+		CurrentEpic:  &StatusItem{ID: "EPIC-001", Title: "Login", Progress: 0.45},
+		CurrentStory: &StatusItem{ID: "STORY-001", Title: "Auth Flow", Completed: 3, Total: 5},
+		OpenTickets:  2,
+		Backups:      StatusBackups{Count: 4, LastBackup: &lastBackup},
+	}
+
+	assert.Equal(t, "[EPIC-001 45%] Story: Auth Flow (3/5 tasks) | 2 tickets open | Last backup: 5m ago",
+		compactProjectStatus(status, now))
+
+	status = &ProjectStatus{State: "Project Initialized", OpenTickets: 1, SLABreaches: []string{"TICKET-001"}}
+	assert.Equal(t, "Project Initialized | 1 ticket open (1 past SLA) | No backups", compactProjectStatus(status, now))
+}
+
+func TestPrintProjectStatus_FitsOneScreen(t *testing.T) {
+	now := time.Now()
+	status := &ProjectStatus{
+		State:          "Task In Progress",
+		CurrentEpic:    &StatusItem{ID: "EPIC-001", Title: "Login", Progress: 0.5, Completed: 1, Total: 2},
+		CurrentStory:   &StatusItem{ID: "STORY-001", Title: "Auth Flow", Completed: 3, Total: 5},
+		CurrentTask:    &StatusItem{ID: "TASK-001", Title: "Hash passwords", Status: "in_progress"},
+		ActiveTicket:   &StatusItem{ID: "TICKET-001", Title: "Crash", Status: "in_progress"},
+		OpenTickets:    3,
+		SLABreaches:    []string{"TICKET-002"},
+		RecentCommands: []StatusCommand{{Command: "story list", DurationMs: 120, Timestamp: now}, {Command: "epic list", DurationMs: 80, ExitCode: 1, Timestamp: now}},
+		Issues:         []string{"a", "b", "c", "d", "e"},
+		Suggestion:     &StatusSuggestion{Name: "Continue Task"},
+	}
+
+	var out bytes.Buffer
+	printProjectStatus(&out, status, now)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	assert.LessOrEqual(t, len(lines), 24)
+	assert.Contains(t, out.String(), "50% (1/2 stories)")
+	assert.Contains(t, out.String(), "(3/5 tasks)")
+	assert.Contains(t, out.String(), "1 past SLA: TICKET-002")
+	assert.Contains(t, out.String(), "80ms, exit 1")
+	assert.Contains(t, out.String(), "and 2 more")
+}
@@ -9,12 +9,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"text/tabwriter"
 
 	"claude-wm-cli/internal/debug"
 	"claude-wm-cli/internal/epic"
+	clierrors "claude-wm-cli/internal/errors"
 	"claude-wm-cli/internal/metrics"
 	"claude-wm-cli/internal/story"
+	"claude-wm-cli/internal/theme"
 	"claude-wm-cli/internal/validation"
 
 	"github.com/spf13/cobra"
@@ -58,11 +59,11 @@ Examples:
   claude-wm-cli story create "API Integration" --epic EPIC-001 --priority high
   claude-wm-cli story create "UI Component" --story-points 5 --criteria "Component renders,Component is responsive"`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
-		createStory(args[0], cmd)
+		return createStory(args[0], cmd)
 	},
 }
 
@@ -79,11 +80,13 @@ Examples:
   claude-wm-cli story list                      # List all stories
   claude-wm-cli story list --epic EPIC-001     # List stories from specific epic
   claude-wm-cli story list --status planned    # List only planned stories`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
-		listStories(cmd)
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return listStories(cmd)
 	},
 }
 
@@ -102,8 +105,8 @@ Examples:
   claude-wm-cli story update STORY-001 --title "New Title" --priority critical
   claude-wm-cli story update STORY-001 --story-points 8 --criteria "New criteria"`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		updateStory(args[0], cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateStory(args[0], cmd)
 	},
 }
 
@@ -118,8 +121,10 @@ Examples:
   claude-wm-cli story show STORY-001
   claude-wm-cli story show STORY-001-USER-LOGIN`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		showStory(args[0])
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showStory(args[0])
 	},
 }
 
@@ -137,8 +142,10 @@ Examples:
   claude-wm-cli story generate                # Generate from all epics
   claude-wm-cli story generate EPIC-001      # Generate from specific epic`,
 	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		generateStories(args)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return generateStories(args)
 	},
 }
 
@@ -188,12 +195,11 @@ func init() {
 	storyUpdateCmd.Flags().StringSliceVar(&dependencies, "dependencies", []string{}, "Update story dependencies")
 }
 
-func createStory(title string, _ *cobra.Command) {
+func createStory(title string, cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Note: No specific Claude prompt available for story creation - using basic implementation
@@ -208,11 +214,13 @@ func createStory(title string, _ *cobra.Command) {
 	if storyPriority != "" {
 		priority = epic.Priority(storyPriority)
 		if !priority.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid priority '%s'. Valid values: low, medium, high, critical\n", storyPriority)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("priority", storyPriority, fmt.Sprintf("'%s' is not one of low, medium, high, critical", storyPriority))
 		}
 	}
 
+	// Failures past this point are not usage errors
+	cmd.SilenceUsage = true
+
 	// Create story options
 	options := story.StoryCreateOptions{
 		Title:       title,
@@ -227,8 +235,7 @@ func createStory(title string, _ *cobra.Command) {
 	// Create the story
 	newStory, err := generator.CreateStory(options)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to create story: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to create story")
 	}
 
 	// Display success message
@@ -255,9 +262,10 @@ func createStory(title string, _ *cobra.Command) {
 	fmt.Printf("   • View story details: claude-wm-cli story show %s\n", newStory.ID)
 	fmt.Printf("   • Update story:       claude-wm-cli story update %s --status in_progress\n", newStory.ID)
 	fmt.Printf("   • List all stories:   claude-wm-cli story list\n")
+	return nil
 }
 
-func listStories(_ *cobra.Command) {
+func listStories(_ *cobra.Command) error {
 	// Start performance monitoring
 	timer := metrics.InstrumentCommand("story list")
 	defer timer.Stop()
@@ -268,8 +276,7 @@ func listStories(_ *cobra.Command) {
 	if err := validator.ValidateSpecificJSON("stories"); err != nil {
 		validationStep.StopWithError(err)
 		timer.SetExitCode(1)
-		fmt.Fprintf(os.Stderr, "❌ JSON validation failed: %v\n", err)
-		os.Exit(1)
+		return clierrors.ErrValidationFailed(fmt.Sprintf("JSON validation failed: %v", err))
 	}
 	validationStep.Stop()
 
@@ -279,8 +286,7 @@ func listStories(_ *cobra.Command) {
 	if err != nil {
 		workDirStep.StopWithError(err)
 		timer.SetExitCode(1)
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 	workDirStep.SetMetadata("working_directory", wd)
 	workDirStep.Stop()
@@ -295,20 +301,19 @@ func listStories(_ *cobra.Command) {
 	if err := displayStoriesFromFile(wd, listStoryStatus); err != nil {
 		displayStep.StopWithError(err)
 		timer.SetExitCode(1)
-		fmt.Fprintf(os.Stderr, "Error: Failed to display stories: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to display stories")
 	}
 	displayStep.Stop()
 
 	timer.SetExitCode(0)
+	return nil
 }
 
-func updateStory(storyID string, cmd *cobra.Command) {
+func updateStory(storyID string, cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create story generator
@@ -328,8 +333,7 @@ func updateStory(storyID string, cmd *cobra.Command) {
 	if storyPriority != "" {
 		priority := epic.Priority(storyPriority)
 		if !priority.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid priority '%s'. Valid values: low, medium, high, critical\n", storyPriority)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("priority", storyPriority, fmt.Sprintf("'%s' is not one of low, medium, high, critical", storyPriority))
 		}
 		options.Priority = &priority
 	}
@@ -337,8 +341,7 @@ func updateStory(storyID string, cmd *cobra.Command) {
 	if storyStatus != "" {
 		status := epic.Status(storyStatus)
 		if !status.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid status '%s'. Valid values: planned, in_progress, on_hold, completed, cancelled\n", storyStatus)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("status", storyStatus, fmt.Sprintf("'%s' is not one of planned, in_progress, on_hold, completed, cancelled", storyStatus))
 		}
 		options.Status = &status
 	}
@@ -359,15 +362,17 @@ func updateStory(storyID string, cmd *cobra.Command) {
 	if options.Title == nil && options.Description == nil && options.Priority == nil &&
 		options.Status == nil && options.AcceptanceCriteria == nil &&
 		options.Dependencies == nil {
-		fmt.Fprintf(os.Stderr, "Error: No updates specified. Use flags like --title, --status, --priority, etc.\n")
-		os.Exit(1)
+		return clierrors.NewCLIError("No updates specified", clierrors.ExitUsage).
+			WithSuggestion("Use flags like --title, --status, --priority, etc.")
 	}
 
+	// Failures past this point are not usage errors
+	cmd.SilenceUsage = true
+
 	// Update the story
 	updatedStory, err := generator.UpdateStory(storyID, options)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to update story: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to update story")
 	}
 
 	// Display success message
@@ -386,14 +391,14 @@ func updateStory(storyID string, cmd *cobra.Command) {
 		fmt.Printf("   Criteria:    %s\n", strings.Join(updatedStory.AcceptanceCriteria, ", "))
 	}
 	fmt.Printf("   Updated:     %s\n", updatedStory.UpdatedAt.Format("2006-01-02 15:04:05"))
+	return nil
 }
 
-func showStory(storyID string) {
+func showStory(storyID string) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create story generator
@@ -402,8 +407,7 @@ func showStory(storyID string) {
 	// Get the story
 	st, err := generator.GetStory(storyID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get story: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get story")
 	}
 
 	// Display story details
@@ -479,14 +483,14 @@ func showStory(storyID string) {
 	}
 	fmt.Printf("   • Update story:      claude-wm-cli story update %s --title \"New Title\"\n", st.ID)
 	fmt.Printf("   • List all stories:  claude-wm-cli story list\n")
+	return nil
 }
 
-func generateStories(args []string) {
+func generateStories(args []string) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create story generator
@@ -505,14 +509,14 @@ func generateStories(args []string) {
 	}
 
 	if err2 != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to generate stories: %v\n", err2)
-		os.Exit(1)
+		return clierrors.Wrap(err2, "Failed to generate stories")
 	}
 
 	fmt.Printf("✅ Stories generated successfully!\n\n")
 	fmt.Printf("💡 Next steps:\n")
 	fmt.Printf("   • List generated stories: claude-wm-cli story list\n")
 	fmt.Printf("   • View story details:     claude-wm-cli story show <story-id>\n")
+	return nil
 }
 
 // Helper functions
@@ -520,32 +524,32 @@ func generateStories(args []string) {
 func getStoryStatusIcon(status epic.Status) string {
 	switch status {
 	case epic.StatusPlanned:
-		return "📋"
+		return theme.Icon("📋", "[planned]")
 	case epic.StatusInProgress:
-		return "🚧"
+		return theme.Icon("🚧", "[wip]")
 	case epic.StatusOnHold:
-		return "⏸️"
+		return theme.Icon("⏸️", "[hold]")
 	case epic.StatusCompleted:
-		return "✅"
+		return theme.Icon("✅", "[done]")
 	case epic.StatusCancelled:
-		return "❌"
+		return theme.Icon("❌", "[cancelled]")
 	default:
-		return "❓"
+		return theme.Icon("❓", "[?]")
 	}
 }
 
 func getStoryPriorityIcon(priority epic.Priority) string {
 	switch priority {
 	case epic.PriorityLow:
-		return "🟢"
+		return theme.Icon("🟢", "[low]")
 	case epic.PriorityMedium:
-		return "🟡"
+		return theme.Icon("🟡", "[medium]")
 	case epic.PriorityHigh:
-		return "🟠"
+		return theme.Icon("🟠", "[high]")
 	case epic.PriorityCritical:
-		return "🔴"
+		return theme.Icon("🔴", "[critical]")
 	default:
-		return "⚪"
+		return theme.Icon("⚪", "[-]")
 	}
 }
 
@@ -660,12 +664,12 @@ func displayStoriesFromFile(wd, statusFilter string) error {
 		return nil
 	}
 
-	// Create table writer
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Create table writer, coloring each story by its status
+	w := theme.NewTable(os.Stdout)
 
 	// Print header
-	fmt.Fprintf(w, "ID\tTITLE\tSTATUS\tPRIORITY\tPOINTS\tTASKS\n")
-	fmt.Fprintf(w, "──\t─────\t──────\t────────\t──────\t─────\n")
+	w.Row(theme.Heading, "ID\tTITLE\tSTATUS\tPRIORITY\tPOINTS\tTASKS\n")
+	w.Row(nil, "──\t─────\t──────\t────────\t──────\t─────\n")
 
 	// Print each story
 	for _, story := range filteredStories {
@@ -687,7 +691,7 @@ func displayStoriesFromFile(wd, statusFilter string) error {
 			tasksStr += fmt.Sprintf(" (%.0f%%)", progress)
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s %s\t%s %s\t%d\t%s\n",
+		w.Row(theme.StatusStyle(story.Status), "%s\t%s\t%s %s\t%s %s\t%d\t%s\n",
 			story.ID,
 			truncateStoryString(story.Title, 30),
 			statusIcon, story.Status,
@@ -713,31 +717,31 @@ func displayStoriesFromFile(wd, statusFilter string) error {
 func getStoryStatusIconFromString(status string) string {
 	switch status {
 	case "planned", "todo":
-		return "📋"
+		return theme.Icon("📋", "[planned]")
 	case "in_progress":
-		return "🚧"
+		return theme.Icon("🚧", "[wip]")
 	case "on_hold":
-		return "⏸️"
+		return theme.Icon("⏸️", "[hold]")
 	case "completed", "done":
-		return "✅"
+		return theme.Icon("✅", "[done]")
 	case "cancelled":
-		return "❌"
+		return theme.Icon("❌", "[cancelled]")
 	default:
-		return "❓"
+		return theme.Icon("❓", "[?]")
 	}
 }
 
 func getStoryPriorityIconFromString(priority string) string {
 	switch priority {
 	case "low":
-		return "🟢"
+		return theme.Icon("🟢", "[low]")
 	case "medium":
-		return "🟡"
+		return theme.Icon("🟡", "[medium]")
 	case "high":
-		return "🟠"
+		return theme.Icon("🟠", "[high]")
 	case "critical":
-		return "🔴"
+		return theme.Icon("🔴", "[critical]")
 	default:
-		return "⚪"
+		return theme.Icon("⚪", "[-]")
 	}
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"time"
@@ -10,6 +11,7 @@ import (
 
 	"claude-wm-cli/internal/config"
 	"claude-wm-cli/internal/executor"
+	"claude-wm-cli/internal/serena"
 	"claude-wm-cli/internal/subagents"
 )
 
@@ -49,7 +51,7 @@ var subagentsMetricsCmd = &cobra.Command{
 		configManager := config.NewManager(configPath)
 
 		// Initialize subagent-aware executor
-		claudeExecutor := executor.NewClaudeExecutor()
+		claudeExecutor := newClaudeExecutor()
 		subagentConfigPath := configManager.GetSubagentsPath()
 		
 		subagentExecutor, err := executor.NewSubagentAwareExecutor(claudeExecutor, subagentConfigPath)
@@ -80,7 +82,7 @@ var subagentsTestCmd = &cobra.Command{
 		configManager := config.NewManager(configPath)
 
 		// Initialize subagent-aware executor
-		claudeExecutor := executor.NewClaudeExecutor()
+		claudeExecutor := newClaudeExecutor()
 		subagentConfigPath := configManager.GetSubagentsPath()
 		
 		subagentExecutor, err := executor.NewSubagentAwareExecutor(claudeExecutor, subagentConfigPath)
@@ -129,7 +131,7 @@ var subagentsListCmd = &cobra.Command{
 		configManager := config.NewManager(configPath)
 
 		// Initialize subagent-aware executor
-		claudeExecutor := executor.NewClaudeExecutor()
+		claudeExecutor := newClaudeExecutor()
 		subagentConfigPath := configManager.GetSubagentsPath()
 		
 		subagentExecutor, err := executor.NewSubagentAwareExecutor(claudeExecutor, subagentConfigPath)
@@ -510,6 +512,39 @@ var serenaInstallCmd = &cobra.Command{
 	},
 }
 
+// serenaIndexDoctorCheck reports whether the docs index is up to date for the
+// doctor command
+func serenaIndexDoctorCheck() doctorResult {
+	stats, err := serena.GetIndexStats(".")
+	if errors.Is(err, os.ErrNotExist) {
+		return doctorResult{OK: true, Summary: "no docs index yet"}
+	}
+	if err != nil {
+		return doctorResult{OK: false, Summary: err.Error()}
+	}
+
+	result := doctorResult{OK: true, Summary: fmt.Sprintf("%d docs indexed", stats.FileCount)}
+	if len(stats.StaleFiles) > 0 {
+		result.OK = false
+		result.Summary = fmt.Sprintf("stale: %d docs changed since last indexed (run serena-indexer)", len(stats.StaleFiles))
+		result.Details = append(result.Details, stats.StaleFiles...)
+	}
+
+	var dangling *serena.DanglingReferencesError
+	if err := serena.ValidateIndex("."); errors.As(err, &dangling) {
+		if result.OK {
+			result.OK = false
+			result.Summary = fmt.Sprintf("%d indexed docs missing (run serena-indexer)", len(dangling.Files))
+		}
+		for _, file := range dangling.Files {
+			result.Details = append(result.Details, fmt.Sprintf("%s: indexed but missing", file))
+		}
+	} else if err != nil {
+		return doctorResult{OK: false, Summary: err.Error()}
+	}
+	return result
+}
+
 func init() {
 	rootCmd.AddCommand(subagentsCmd)
 	rootCmd.AddCommand(serenaCmd)
@@ -523,6 +558,12 @@ func init() {
 	serenaCmd.AddCommand(serenaEnableCmd)
 	serenaCmd.AddCommand(serenaDisableCmd)
 	serenaCmd.AddCommand(serenaInstallCmd)
+	registerDoctorCheck("Serena index", serenaIndexDoctorCheck)
+
+	// Claude execution flags for the commands running the subagent executor
+	addClaudeExecutionFlags(subagentsMetricsCmd.Flags())
+	addClaudeExecutionFlags(subagentsTestCmd.Flags())
+	addClaudeExecutionFlags(subagentsListCmd.Flags())
 
 	// Test command flags
 	subagentsTestCmd.Flags().StringP("type", "t", "all", "Type of test to run: template, status, planning, all")
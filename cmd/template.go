@@ -82,7 +82,7 @@ Examples:
 		configManager := config.NewManager(configPath)
 		
 		// Initialize subagent-aware executor
-		claudeExecutor := executor.NewClaudeExecutor()
+		claudeExecutor := newClaudeExecutor()
 		subagentConfigPath := configManager.GetSubagentsPath()
 		
 		subagentExecutor, err := executor.NewSubagentAwareExecutor(claudeExecutor, subagentConfigPath)
@@ -198,4 +198,5 @@ func init() {
 	generateCmd.Flags().StringP("priority", "", "", "Priority level (low, medium, high)")
 	generateCmd.Flags().StringP("api", "a", "", "API type (REST, GraphQL, gRPC)")
 	generateCmd.Flags().StringP("database", "d", "", "Database type (PostgreSQL, MySQL, MongoDB)")
+	addClaudeExecutionFlags(generateCmd.Flags())
 }
\ No newline at end of file
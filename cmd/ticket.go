@@ -5,15 +5,17 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-	"text/tabwriter"
 	"time"
 
 	"claude-wm-cli/internal/debug"
+	clierrors "claude-wm-cli/internal/errors"
 	"claude-wm-cli/internal/executor"
+	"claude-wm-cli/internal/theme"
 	"claude-wm-cli/internal/ticket"
 
 	"github.com/spf13/cobra"
@@ -34,10 +36,12 @@ Available subcommands:
   create                     Create a new ticket
   list                       List tickets with filtering options
   show                       Display detailed information about a ticket
+  watch                      Display a ticket and refresh it as it changes
   update                     Update an existing ticket
   status                     Change ticket status
   current                    Set or show the current active ticket
   stats                      Show ticket statistics and analytics
+  sprint                     Plan tickets in sprints
   execute-full               Execute complete workflow (Plan → Test → Implement → Validate → Review)
   execute-full-from-story    Complete workflow from story (From Story → Plan → Test → Implement → Validate → Review)
   execute-full-from-issue    Complete workflow from issue (From Issue → Plan → Test → Implement → Validate → Review)
@@ -64,11 +68,11 @@ Examples:
   claude-wm-cli ticket create "Emergency deployment" --priority urgent --type interruption
   claude-wm-cli ticket create "Review PR #123" --description "Code review for authentication feature" --estimated-hours 2`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
-		createTicket(args[0], cmd)
+		return createTicket(args[0], cmd)
 	},
 }
 
@@ -79,6 +83,7 @@ var ticketListCmd = &cobra.Command{
 	Long: `List tickets with optional filtering by status, priority, type, or assignment.
 
 By default, shows open and in-progress tickets ordered by priority and creation date.
+With --sprint, shows the tickets planned in a sprint instead.
 Use filters to focus on specific subsets of tickets.
 
 Examples:
@@ -86,12 +91,15 @@ Examples:
   claude-wm-cli ticket list --status open     # List only open tickets
   claude-wm-cli ticket list --priority urgent # List urgent tickets
   claude-wm-cli ticket list --type bug        # List bug tickets
-  claude-wm-cli ticket list --all             # Include closed tickets`,
-	Run: func(cmd *cobra.Command, args []string) {
+  claude-wm-cli ticket list --all             # Include closed tickets
+  claude-wm-cli ticket list --sprint SPRINT-001 # Tickets planned in a sprint`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
-		listTickets(cmd)
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return listTickets(cmd)
 	},
 }
 
@@ -106,8 +114,10 @@ Examples:
   claude-wm-cli ticket show TICKET-001
   claude-wm-cli ticket show TICKET-001-FIX-BUG`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		showTicket(args[0])
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showTicket(args[0])
 	},
 }
 
@@ -126,8 +136,8 @@ Examples:
   claude-wm-cli ticket update TICKET-001 --priority high --assigned-to john
   claude-wm-cli ticket update TICKET-001 --description "Updated description" --estimated-hours 4`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		updateTicket(args[0], cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateTicket(args[0], cmd)
 	},
 }
 
@@ -145,8 +155,8 @@ Examples:
   claude-wm-cli ticket status TICKET-001 --status resolved
   claude-wm-cli ticket status TICKET-001 --status closed`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		changeTicketStatus(args[0], cmd)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return changeTicketStatus(args[0], cmd)
 	},
 }
 
@@ -164,11 +174,13 @@ Examples:
   claude-wm-cli ticket current TICKET-001     # Set TICKET-001 as current
   claude-wm-cli ticket current --clear        # Clear current ticket`,
 	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Enable debug mode if flag is set
 		debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
-		manageCurrentTicket(args, cmd)
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return manageCurrentTicket(args, cmd)
 	},
 }
 
@@ -177,12 +189,54 @@ var ticketStatsCmd = &cobra.Command{
 	Use:   "stats",
 	Short: "Show ticket statistics and analytics",
 	Long: `Display analytics and statistics about tickets including counts by status,
-priority, and type, as well as performance metrics like average resolution time.
+priority, and type, as well as performance metrics like average resolution time
+and the velocity (story points done) of completed sprints.
 
 Examples:
   claude-wm-cli ticket stats`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showTicketStats()
+	},
+}
+
+// ticketAgingCmd represents the ticket aging command
+var ticketAgingCmd = &cobra.Command{
+	Use:   "aging",
+	Short: "List open tickets by age with their SLA status",
+	Long: `List the open and in progress tickets, oldest first, with their age and
+how they stand against the resolution deadline (SLA) of their priority:
+✅ within the SLA, ⚠️ approaching it (80% of the deadline) or ❌ breached.
+
+Urgent tickets must be resolved within 4 hours and critical ones within 24
+hours by default. Set the deadlines in .claude-wm/config.json under
+ticket.sla.<priority>_hours; 0 removes the SLA of a priority.
+
+Examples:
+  claude-wm-cli ticket aging
+  claude-wm-cli config set ticket.sla.high_hours 72`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return showTicketAging()
+	},
+}
+
+// ticketStateDiagramCmd represents the ticket state-diagram command
+var ticketStateDiagramCmd = &cobra.Command{
+	Use:   "state-diagram",
+	Short: "Print the ticket status lifecycle as a Mermaid diagram",
+	Long: `Print the status changes allowed for tickets as a Mermaid state diagram,
+with the event of each transition. 'ticket update --status' and 'ticket status'
+refuse any other change.
+
+Examples:
+  claude-wm-cli ticket state-diagram
+  claude-wm-cli ticket state-diagram > docs/ticket-lifecycle.mmd`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		showTicketStats()
+		fmt.Print(ticket.NewStatusMachine().Visualize())
 	},
 }
 
@@ -205,8 +259,10 @@ before continuing manually.
 
 Examples:
   claude-wm-cli ticket execute-full`,
-	Run: func(cmd *cobra.Command, args []string) {
-		executeFullTicketWorkflow()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return executeFullTicketWorkflow()
 	},
 }
 
@@ -230,8 +286,10 @@ before continuing manually.
 
 Examples:
   claude-wm-cli ticket execute-full-from-story`,
-	Run: func(cmd *cobra.Command, args []string) {
-		executeFullTicketWorkflowFromStory()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return executeFullTicketWorkflowFromStory()
 	},
 }
 
@@ -255,8 +313,10 @@ before continuing manually.
 
 Examples:
   claude-wm-cli ticket execute-full-from-issue`,
-	Run: func(cmd *cobra.Command, args []string) {
-		executeFullTicketWorkflowFromIssue()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return executeFullTicketWorkflowFromIssue()
 	},
 }
 
@@ -280,8 +340,10 @@ before continuing manually.
 
 Examples:
   claude-wm-cli ticket execute-full-from-input`,
-	Run: func(cmd *cobra.Command, args []string) {
-		executeFullTicketWorkflowFromInput()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return executeFullTicketWorkflowFromInput()
 	},
 }
 
@@ -306,6 +368,7 @@ var (
 	listTicketAssignedTo string
 	listTicketAll        bool
 	listTicketLimit      int
+	listTicketSprint     string
 
 	// Current ticket options
 	clearCurrent bool
@@ -322,6 +385,8 @@ func init() {
 	ticketCmd.AddCommand(ticketStatusCmd)
 	ticketCmd.AddCommand(ticketCurrentCmd)
 	ticketCmd.AddCommand(ticketStatsCmd)
+	ticketCmd.AddCommand(ticketAgingCmd)
+	ticketCmd.AddCommand(ticketStateDiagramCmd)
 	ticketCmd.AddCommand(ticketExecuteFullCmd)
 	ticketCmd.AddCommand(ticketExecuteFullFromStoryCmd)
 	ticketCmd.AddCommand(ticketExecuteFullFromIssueCmd)
@@ -346,6 +411,7 @@ func init() {
 	ticketListCmd.Flags().StringVar(&listTicketAssignedTo, "assigned-to", "", "Filter by assignee")
 	ticketListCmd.Flags().BoolVar(&listTicketAll, "all", false, "Show all tickets including closed")
 	ticketListCmd.Flags().IntVar(&listTicketLimit, "limit", 0, "Limit number of results")
+	ticketListCmd.Flags().StringVar(&listTicketSprint, "sprint", "", "Show the tickets planned in a sprint")
 
 	// ticket update flags
 	ticketUpdateCmd.Flags().StringVar(&ticketPriority, "priority", "", "Update ticket priority")
@@ -366,16 +432,18 @@ func init() {
 
 	// ticket current flags
 	ticketCurrentCmd.Flags().BoolVar(&clearCurrent, "clear", false, "Clear current ticket")
+
+	// Claude execution flags for the execute-full workflows
+	addClaudeExecutionFlags(ticketCmd.PersistentFlags())
 }
 
 var ticketTitle string
 
-func createTicket(title string, _ *cobra.Command) {
+func createTicket(title string, cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Note: No specific Claude prompt available for ticket creation - using basic implementation
@@ -390,8 +458,7 @@ func createTicket(title string, _ *cobra.Command) {
 	if ticketPriority != "" {
 		priority = ticket.TicketPriority(ticketPriority)
 		if !priority.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid priority '%s'. Valid values: low, medium, high, critical, urgent\n", ticketPriority)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("priority", ticketPriority, fmt.Sprintf("'%s' is not one of low, medium, high, critical, urgent", ticketPriority))
 		}
 	}
 
@@ -400,8 +467,7 @@ func createTicket(title string, _ *cobra.Command) {
 	if ticketType != "" {
 		ticketTypeVal = ticket.TicketType(ticketType)
 		if !ticketTypeVal.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid type '%s'. Valid values: bug, feature, interruption, task, support\n", ticketType)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("type", ticketType, fmt.Sprintf("'%s' is not one of bug, feature, interruption, task, support", ticketType))
 		}
 	}
 
@@ -410,12 +476,14 @@ func createTicket(title string, _ *cobra.Command) {
 	if ticketDueDate != "" {
 		parsed, err := time.Parse("2006-01-02", ticketDueDate)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Invalid due date format '%s'. Use YYYY-MM-DD format\n", ticketDueDate)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("due date", ticketDueDate, fmt.Sprintf("'%s' does not use the YYYY-MM-DD format", ticketDueDate))
 		}
 		dueDate = &parsed
 	}
 
+	// Failures past this point are not usage errors
+	cmd.SilenceUsage = true
+
 	// Create ticket options
 	options := ticket.TicketCreateOptions{
 		Title:          title,
@@ -434,8 +502,7 @@ func createTicket(title string, _ *cobra.Command) {
 	// Create the ticket
 	newTicket, err := manager.CreateTicket(options)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to create ticket: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to create ticket")
 	}
 
 	// Display success message
@@ -467,33 +534,39 @@ func createTicket(title string, _ *cobra.Command) {
 	fmt.Printf("   • Start this ticket: claude-wm-cli ticket current %s\n", newTicket.ID)
 	fmt.Printf("   • List all tickets:  claude-wm-cli ticket list\n")
 	fmt.Printf("   • Update ticket:     claude-wm-cli ticket update %s --status in_progress\n", newTicket.ID)
+	return nil
 }
 
-func listTickets(_ *cobra.Command) {
+func listTickets(_ *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Note: No specific Claude prompt available for ticket listing - using basic implementation
 	debug.LogStub("TICKET", "listTickets", "Ticket listing - no matching Claude prompt available")
 	fmt.Println("📋 Listing tickets...")
 
+	if listTicketSprint != "" {
+		if err := displaySprintTickets(wd, listTicketSprint); err != nil {
+			return clierrors.NewCLIError("Failed to display tickets", ticketExitCode(err)).WithCause(err)
+		}
+		return nil
+	}
+
 	// Read and display tasks from current story in docs/2-current-epic/stories.json file
 	if err := displayTasksFromCurrentStory(wd, listTicketStatus); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to display tickets: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to display tickets")
 	}
+	return nil
 }
 
-func showTicket(ticketID string) {
+func showTicket(ticketID string) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create ticket manager
@@ -502,14 +575,20 @@ func showTicket(ticketID string) {
 	// Get the ticket
 	t, err := manager.GetTicket(ticketID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get ticket: %v\n", err)
-		os.Exit(1)
+		return clierrors.NewCLIError("Failed to get ticket", ticketExitCode(err)).WithCause(err)
 	}
 
 	// Check if it's the current ticket
 	currentTicket, _ := manager.GetCurrentTicket()
 	isCurrent := currentTicket != nil && currentTicket.ID == t.ID
 
+	printTicketDetails(t, isCurrent)
+	return nil
+}
+
+// printTicketDetails prints every property of t and the actions available
+// on it
+func printTicketDetails(t *ticket.Ticket, isCurrent bool) {
 	// Display ticket details
 	fmt.Printf("🎫 Ticket Details\n")
 	fmt.Printf("=================\n\n")
@@ -522,8 +601,8 @@ func showTicket(ticketID string) {
 
 	fmt.Printf("📝 Title:       %s\n", t.Title)
 	fmt.Printf("🏷️  Type:        %s %s\n", getTicketTypeIcon(t.Type), t.Type)
-	fmt.Printf("📊 Status:      %s %s\n", getTicketStatusIcon(t.Status), t.Status)
-	fmt.Printf("⚡ Priority:    %s %s\n", getTicketPriorityIcon(t.Priority), t.Priority)
+	fmt.Printf("📊 Status:      %s %s\n", getTicketStatusIcon(t.Status), theme.Status(string(t.Status)))
+	fmt.Printf("⚡ Priority:    %s %s\n", getTicketPriorityIcon(t.Priority), theme.Status(string(t.Priority)))
 
 	if t.Description != "" {
 		fmt.Printf("📄 Description: %s\n", t.Description)
@@ -606,12 +685,11 @@ func showTicket(ticketID string) {
 	fmt.Printf("   • List all tickets:  claude-wm-cli ticket list\n")
 }
 
-func updateTicket(ticketID string, _ *cobra.Command) {
+func updateTicket(ticketID string, cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create ticket manager
@@ -631,8 +709,7 @@ func updateTicket(ticketID string, _ *cobra.Command) {
 	if ticketPriority != "" {
 		priority := ticket.TicketPriority(ticketPriority)
 		if !priority.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid priority '%s'. Valid values: low, medium, high, critical, urgent\n", ticketPriority)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("priority", ticketPriority, fmt.Sprintf("'%s' is not one of low, medium, high, critical, urgent", ticketPriority))
 		}
 		options.Priority = &priority
 	}
@@ -640,8 +717,7 @@ func updateTicket(ticketID string, _ *cobra.Command) {
 	if ticketType != "" {
 		ticketTypeVal := ticket.TicketType(ticketType)
 		if !ticketTypeVal.IsValid() {
-			fmt.Fprintf(os.Stderr, "Error: Invalid type '%s'. Valid values: bug, feature, interruption, task, support\n", ticketType)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("type", ticketType, fmt.Sprintf("'%s' is not one of bug, feature, interruption, task, support", ticketType))
 		}
 		options.Type = &ticketTypeVal
 	}
@@ -673,8 +749,7 @@ func updateTicket(ticketID string, _ *cobra.Command) {
 	if ticketDueDate != "" {
 		parsed, err := time.Parse("2006-01-02", ticketDueDate)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Invalid due date format '%s'. Use YYYY-MM-DD format\n", ticketDueDate)
-			os.Exit(1)
+			return clierrors.ErrInvalidInput("due date", ticketDueDate, fmt.Sprintf("'%s' does not use the YYYY-MM-DD format", ticketDueDate))
 		}
 		options.DueDate = &parsed
 	}
@@ -684,15 +759,17 @@ func updateTicket(ticketID string, _ *cobra.Command) {
 		options.Type == nil && options.AssignedTo == nil && options.EstimatedHours == nil &&
 		options.StoryPoints == nil && options.Tags == nil && options.RelatedEpicID == nil &&
 		options.RelatedStoryID == nil && options.DueDate == nil {
-		fmt.Fprintf(os.Stderr, "Error: No updates specified. Use flags like --title, --priority, --type, etc.\n")
-		os.Exit(1)
+		return clierrors.NewCLIError("No updates specified", clierrors.ExitUsage).
+			WithSuggestion("Use flags like --title, --priority, --type, etc.")
 	}
 
+	// Failures past this point are not usage errors
+	cmd.SilenceUsage = true
+
 	// Update the ticket
 	updatedTicket, err := manager.UpdateTicket(ticketID, options)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to update ticket: %v\n", err)
-		os.Exit(1)
+		return ticketError("Failed to update ticket", err)
 	}
 
 	// Display success message
@@ -704,14 +781,14 @@ func updateTicket(ticketID string, _ *cobra.Command) {
 	fmt.Printf("   Status:   %s\n", updatedTicket.Status)
 	fmt.Printf("   Priority: %s\n", updatedTicket.Priority)
 	fmt.Printf("   Updated:  %s\n", updatedTicket.UpdatedAt.Format("2006-01-02 15:04:05"))
+	return nil
 }
 
-func changeTicketStatus(ticketID string, _ *cobra.Command) {
+func changeTicketStatus(ticketID string, cmd *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create ticket manager
@@ -720,10 +797,12 @@ func changeTicketStatus(ticketID string, _ *cobra.Command) {
 	// Validate status
 	newStatus := ticket.TicketStatus(ticketStatus)
 	if !newStatus.IsValid() {
-		fmt.Fprintf(os.Stderr, "Error: Invalid status '%s'. Valid values: open, in_progress, resolved, closed\n", ticketStatus)
-		os.Exit(1)
+		return clierrors.ErrInvalidInput("status", ticketStatus, fmt.Sprintf("'%s' is not one of open, in_progress, resolved, closed", ticketStatus))
 	}
 
+	// Failures past this point are not usage errors
+	cmd.SilenceUsage = true
+
 	// Update the ticket status
 	options := ticket.TicketUpdateOptions{
 		Status: &newStatus,
@@ -731,8 +810,7 @@ func changeTicketStatus(ticketID string, _ *cobra.Command) {
 
 	updatedTicket, err := manager.UpdateTicket(ticketID, options)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to update ticket status: %v\n", err)
-		os.Exit(1)
+		return ticketError("Failed to update ticket status", err)
 	}
 
 	// Display success message
@@ -758,14 +836,14 @@ func changeTicketStatus(ticketID string, _ *cobra.Command) {
 			fmt.Printf("   Closed: %s\n", updatedTicket.ClosedAt.Format("2006-01-02 15:04:05"))
 		}
 	}
+	return nil
 }
 
-func manageCurrentTicket(args []string, _ *cobra.Command) {
+func manageCurrentTicket(args []string, _ *cobra.Command) error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Note: No specific Claude prompt available for current ticket management - using basic implementation
@@ -779,25 +857,23 @@ func manageCurrentTicket(args []string, _ *cobra.Command) {
 	if clearCurrent {
 		_, err := manager.SetCurrentTicket("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to clear current ticket: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Failed to clear current ticket")
 		}
 		fmt.Printf("✅ Current ticket cleared.\n")
-		return
+		return nil
 	}
 
 	// If no arguments, show current ticket
 	if len(args) == 0 {
 		currentTicket, err := manager.GetCurrentTicket()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: Failed to get current ticket: %v\n", err)
-			os.Exit(1)
+			return clierrors.Wrap(err, "Failed to get current ticket")
 		}
 
 		if currentTicket == nil {
 			fmt.Printf("📋 No current ticket set.\n\n")
 			fmt.Printf("💡 Set a current ticket: claude-wm-cli ticket current <ticket-id>\n")
-			return
+			return nil
 		}
 
 		fmt.Printf("🎯 Current Ticket:\n")
@@ -805,15 +881,14 @@ func manageCurrentTicket(args []string, _ *cobra.Command) {
 		fmt.Printf("   Title:    %s\n", currentTicket.Title)
 		fmt.Printf("   Status:   %s %s\n", getTicketStatusIcon(currentTicket.Status), currentTicket.Status)
 		fmt.Printf("   Priority: %s %s\n", getTicketPriorityIcon(currentTicket.Priority), currentTicket.Priority)
-		return
+		return nil
 	}
 
 	// Set current ticket
 	ticketID := args[0]
 	selectedTicket, err := manager.SetCurrentTicket(ticketID)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to set current ticket: %v\n", err)
-		os.Exit(1)
+		return clierrors.NewCLIError("Failed to set current ticket", ticketExitCode(err)).WithCause(err)
 	}
 
 	fmt.Printf("✅ Current ticket set!\n\n")
@@ -826,14 +901,14 @@ func manageCurrentTicket(args []string, _ *cobra.Command) {
 	if selectedTicket.Status == ticket.TicketStatusInProgress {
 		fmt.Printf("\n💡 Ticket is now in progress!\n")
 	}
+	return nil
 }
 
-func showTicketStats() {
+func showTicketStats() error {
 	// Get current working directory
 	wd, err := os.Getwd()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get working directory: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get working directory")
 	}
 
 	// Create ticket manager
@@ -842,8 +917,7 @@ func showTicketStats() {
 	// Get stats
 	stats, err := manager.GetTicketStats()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Failed to get ticket stats: %v\n", err)
-		os.Exit(1)
+		return clierrors.Wrap(err, "Failed to get ticket stats")
 	}
 
 	// Display header
@@ -853,7 +927,7 @@ func showTicketStats() {
 	if stats.TotalTickets == 0 {
 		fmt.Printf("No tickets found. Create your first ticket to get started!\n\n")
 		fmt.Printf("💡 Create a ticket: claude-wm-cli ticket create \"Ticket Title\"\n")
-		return
+		return nil
 	}
 
 	// Overall stats
@@ -900,6 +974,95 @@ func showTicketStats() {
 	if stats.OldestOpenTicket != nil {
 		fmt.Printf("   Oldest open ticket: %s ago\n", formatTicketDuration(time.Since(*stats.OldestOpenTicket)))
 	}
+
+	// Sprint velocity
+	if len(stats.SprintVelocity) > 0 {
+		fmt.Printf("\n🏃 Sprint Velocity:\n")
+		total := 0
+		for _, velocity := range stats.SprintVelocity {
+			fmt.Printf("   %-12s %-20s: %d points\n", velocity.SprintID, truncateTicketString(velocity.Name, 20), velocity.Points)
+			total += velocity.Points
+		}
+		fmt.Printf("   Average: %.1f points per sprint\n", float64(total)/float64(len(stats.SprintVelocity)))
+	}
+
+	// SLA
+	if stats.SLABreaches > 0 || stats.NearSLABreaches > 0 {
+		fmt.Printf("\n⏰ SLA:\n")
+		fmt.Printf("   Breached:    %d\n", stats.SLABreaches)
+		fmt.Printf("   Approaching: %d\n", stats.NearSLABreaches)
+		fmt.Printf("\n💡 List them with: claude-wm-cli ticket aging\n")
+	}
+	return nil
+}
+
+func showTicketAging() error {
+	// Get current working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to get working directory")
+	}
+
+	stats, err := ticket.NewManager(wd).GetTicketStats()
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to get ticket stats")
+	}
+
+	fmt.Printf("⏰ Ticket Aging\n")
+	fmt.Printf("==============\n\n")
+
+	if len(stats.AgedTickets) == 0 {
+		fmt.Printf("No open tickets.\n")
+		return nil
+	}
+
+	w := theme.NewTable(os.Stdout)
+	w.Row(theme.Heading, "ID\tTITLE\tPRIORITY\tAGE\tSLA\tSTATUS\n")
+	w.Row(nil, "──\t─────\t────────\t───\t───\t──────\n")
+	for _, aged := range stats.AgedTickets {
+		sla := "-"
+		if aged.SLA > 0 {
+			sla = formatTicketDuration(aged.SLA)
+		}
+		w.Row(slaStyle(aged.SLAStatus), "%s\t%s\t%s %s\t%s\t%s\t%s\n",
+			aged.ID,
+			truncateTicketString(aged.Title, 40),
+			getTicketPriorityIcon(aged.Priority), aged.Priority,
+			formatTicketDuration(aged.Age),
+			sla,
+			getSLAStatusLabel(aged.SLAStatus))
+	}
+	w.Flush()
+
+	fmt.Printf("\n📊 Summary: %d open ticket(s), %d SLA breach(es), %d approaching\n",
+		len(stats.AgedTickets), stats.SLABreaches, stats.NearSLABreaches)
+	return nil
+}
+
+// getSLAStatusLabel returns the SLA status column of ticket aging
+func getSLAStatusLabel(status ticket.SLAStatus) string {
+	switch status {
+	case ticket.SLAWithin:
+		return theme.Icon("✅", "[ok]") + " within SLA"
+	case ticket.SLAApproaching:
+		return theme.Icon("⚠️", "[!]") + " approaching"
+	case ticket.SLABreached:
+		return theme.Icon("❌", "[x]") + " breached"
+	default:
+		return "no SLA"
+	}
+}
+
+// slaStyle returns the row style of a ticket in ticket aging
+func slaStyle(status ticket.SLAStatus) func(string) string {
+	switch status {
+	case ticket.SLAApproaching:
+		return theme.Warning
+	case ticket.SLABreached:
+		return theme.Error
+	default:
+		return nil
+	}
 }
 
 // Helper functions
@@ -907,49 +1070,49 @@ func showTicketStats() {
 func getTicketStatusIcon(status ticket.TicketStatus) string {
 	switch status {
 	case ticket.TicketStatusOpen:
-		return "🔵"
+		return theme.Icon("🔵", "[open]")
 	case ticket.TicketStatusInProgress:
-		return "🟡"
+		return theme.Icon("🟡", "[wip]")
 	case ticket.TicketStatusResolved:
-		return "🟢"
+		return theme.Icon("🟢", "[resolved]")
 	case ticket.TicketStatusClosed:
-		return "⚫"
+		return theme.Icon("⚫", "[closed]")
 	default:
-		return "❓"
+		return theme.Icon("❓", "[?]")
 	}
 }
 
 func getTicketPriorityIcon(priority ticket.TicketPriority) string {
 	switch priority {
 	case ticket.TicketPriorityLow:
-		return "🟢"
+		return theme.Icon("🟢", "[low]")
 	case ticket.TicketPriorityMedium:
-		return "🟡"
+		return theme.Icon("🟡", "[medium]")
 	case ticket.TicketPriorityHigh:
-		return "🟠"
+		return theme.Icon("🟠", "[high]")
 	case ticket.TicketPriorityCritical:
-		return "🔴"
+		return theme.Icon("🔴", "[critical]")
 	case ticket.TicketPriorityUrgent:
-		return "🚨"
+		return theme.Icon("🚨", "[urgent]")
 	default:
-		return "⚪"
+		return theme.Icon("⚪", "[-]")
 	}
 }
 
 func getTicketTypeIcon(ticketType ticket.TicketType) string {
 	switch ticketType {
 	case ticket.TicketTypeBug:
-		return "🐛"
+		return theme.Icon("🐛", "[bug]")
 	case ticket.TicketTypeFeature:
-		return "✨"
+		return theme.Icon("✨", "[feature]")
 	case ticket.TicketTypeInterruption:
-		return "⚡"
+		return theme.Icon("⚡", "[interrupt]")
 	case ticket.TicketTypeTask:
-		return "📋"
+		return theme.Icon("📋", "[task]")
 	case ticket.TicketTypeSupport:
-		return "🆘"
+		return theme.Icon("🆘", "[support]")
 	default:
-		return "❓"
+		return theme.Icon("❓", "[?]")
 	}
 }
 
@@ -1076,12 +1239,12 @@ func displayTasksFromCurrentStory(wd, statusFilter string) error {
 		return nil
 	}
 
-	// Create table writer
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	// Create table writer, coloring each task by its status
+	w := theme.NewTable(os.Stdout)
 
 	// Print header
-	fmt.Fprintf(w, "ID\tTITLE\tSTATUS\tPRIORITY\n")
-	fmt.Fprintf(w, "──\t─────\t──────\t────────\n")
+	w.Row(theme.Heading, "ID\tTITLE\tSTATUS\tPRIORITY\n")
+	w.Row(nil, "──\t─────\t──────\t────────\n")
 
 	// Print each task
 	for _, task := range filteredTasks {
@@ -1089,7 +1252,7 @@ func displayTasksFromCurrentStory(wd, statusFilter string) error {
 		statusIcon := getTaskStatusIcon(task.Status)
 		priorityIcon := getTaskPriorityIcon(task.Priority)
 
-		fmt.Fprintf(w, "%s\t%s\t%s %s\t%s %s\n",
+		w.Row(theme.StatusStyle(task.Status), "%s\t%s\t%s %s\t%s %s\n",
 			task.ID,
 			truncateTicketString(task.Title, 40),
 			statusIcon, task.Status,
@@ -1110,8 +1273,56 @@ func displayTasksFromCurrentStory(wd, statusFilter string) error {
 	return nil
 }
 
+// displaySprintTickets displays the tickets planned in a sprint, honoring the
+// list filters
+func displaySprintTickets(wd, sprintID string) error {
+	sprint, err := ticket.NewSprintManager(wd).GetSprint(sprintID)
+	if err != nil {
+		return err
+	}
+	tickets, err := ticket.NewManager(wd).ListTickets(ticket.TicketListOptions{
+		Status:     ticket.TicketStatus(listTicketStatus),
+		Priority:   ticket.TicketPriority(listTicketPriority),
+		Type:       ticket.TicketType(listTicketType),
+		AssignedTo: listTicketAssignedTo,
+		SprintID:   sprintID,
+		ShowClosed: listTicketAll || sprint.CompletedAt != nil,
+		Limit:      listTicketLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🏃 Tickets in Sprint: %s (%s)\n", sprint.Name, sprint.ID)
+	fmt.Printf("=======================================\n\n")
+
+	if len(tickets) == 0 {
+		fmt.Printf("No tickets found.\n\n")
+		fmt.Printf("💡 Plan a ticket: claude-wm-cli ticket sprint add %s <ticket-id>\n", sprint.ID)
+		return nil
+	}
+
+	w := theme.NewTable(os.Stdout)
+	w.Row(theme.Heading, "ID\tTITLE\tSTATUS\tPRIORITY\tPOINTS\n")
+	w.Row(nil, "──\t─────\t──────\t────────\t──────\n")
+	points := 0
+	for _, t := range tickets {
+		w.Row(theme.StatusStyle(string(t.Status)), "%s\t%s\t%s %s\t%s %s\t%d\n",
+			t.ID,
+			truncateTicketString(t.Title, 40),
+			getTicketStatusIcon(t.Status), t.Status,
+			getTicketPriorityIcon(t.Priority), t.Priority,
+			t.Estimations.StoryPoints)
+		points += t.Estimations.StoryPoints
+	}
+	w.Flush()
+
+	fmt.Printf("\n📊 Summary: %d ticket(s), %d story points\n", len(tickets), points)
+	return nil
+}
+
 // executeFullTicketWorkflow executes the complete ticket workflow automatically
-func executeFullTicketWorkflow() {
+func executeFullTicketWorkflow() error {
 	// Enable debug mode if flag is set
 	debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
@@ -1120,13 +1331,11 @@ func executeFullTicketWorkflow() {
 	fmt.Println()
 
 	// Import executor for Claude commands
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Claude CLI not available: %v\n", err)
-		fmt.Println("💡 Please install Claude CLI to use this functionality")
-		os.Exit(1)
+		return clierrors.ErrClaudeUnavailable(err)
 	}
 
 	// Define the workflow phases
@@ -1162,6 +1371,11 @@ func executeFullTicketWorkflow() {
 		},
 	}
 
+	if executor.IsDryRun() {
+		printDryRunPhases(phases)
+		return nil
+	}
+
 	// Execute each phase
 	for i, phase := range phases {
 		fmt.Printf("📋 Phase %d/%d: %s\n", i+1, len(phases), phase.name)
@@ -1170,16 +1384,20 @@ func executeFullTicketWorkflow() {
 
 		// Execute the Claude slash command
 		description := fmt.Sprintf("Full workflow phase %d: %s", i+1, phase.name)
-		if err := claudeExecutor.ExecuteSlashCommand(phase.command, description); err != nil {
-			fmt.Printf("❌ Phase %d failed: %s\n", i+1, phase.name)
-			fmt.Printf("   Error: %v\n", err)
+		if err := claudeExecutor.ExecuteSlashCommandWithRetry(phase.command, description, resolveClaudeRetryPolicy()); err != nil {
+			if executor.IsTimeoutError(err) {
+				fmt.Printf("   Increase the limit with --claude-timeout or the claude.timeout config key\n")
+			}
+			if hint := claudeLogHint(claudeExecutor); hint != "" {
+				fmt.Printf("   %s\n", hint)
+			}
 			fmt.Printf("\n💡 You can continue manually with:\n")
 
 			// Show remaining phases
 			for j := i; j < len(phases); j++ {
 				fmt.Printf("   %d. %s: %s\n", j+1, phases[j].name, phases[j].command)
 			}
-			os.Exit(1)
+			return clierrors.NewCLIError(fmt.Sprintf("Phase %d failed: %s", i+1, phase.name), claudeFailureExitCode(err)).WithCause(err)
 		}
 
 		fmt.Printf("✅ Phase %d completed: %s\n", i+1, phase.name)
@@ -1194,10 +1412,23 @@ func executeFullTicketWorkflow() {
 	fmt.Println("   • Archive ticket: claude-wm-cli ticket execute-archive")
 	fmt.Println("   • Update status:  claude-wm-cli ticket execute-status")
 	fmt.Println("   • Or use complete workflow: /4-task:3-complete:1-Archive-Ticket")
+	return nil
+}
+
+// printDryRunPhases lists the phases a full workflow would run in dry-run mode
+func printDryRunPhases(phases []struct {
+	name        string
+	command     string
+	description string
+}) {
+	fmt.Println("[DRY-RUN] The following phases would run:")
+	for i, phase := range phases {
+		fmt.Printf("   %d. %s: %s\n", i+1, phase.name, phase.command)
+	}
 }
 
 // executeFullTicketWorkflowFromStory executes the complete ticket workflow starting from story
-func executeFullTicketWorkflowFromStory() {
+func executeFullTicketWorkflowFromStory() error {
 	// Enable debug mode if flag is set
 	debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
@@ -1206,13 +1437,11 @@ func executeFullTicketWorkflowFromStory() {
 	fmt.Println()
 
 	// Import executor for Claude commands
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Claude CLI not available: %v\n", err)
-		fmt.Println("💡 Please install Claude CLI to use this functionality")
-		os.Exit(1)
+		return clierrors.ErrClaudeUnavailable(err)
 	}
 
 	// Define the workflow phases
@@ -1253,6 +1482,11 @@ func executeFullTicketWorkflowFromStory() {
 		},
 	}
 
+	if executor.IsDryRun() {
+		printDryRunPhases(phases)
+		return nil
+	}
+
 	// Execute each phase
 	for i, phase := range phases {
 		fmt.Printf("📋 Phase %d/%d: %s\n", i+1, len(phases), phase.name)
@@ -1261,16 +1495,20 @@ func executeFullTicketWorkflowFromStory() {
 
 		// Execute the Claude slash command
 		description := fmt.Sprintf("Full workflow from story phase %d: %s", i+1, phase.name)
-		if err := claudeExecutor.ExecuteSlashCommand(phase.command, description); err != nil {
-			fmt.Printf("❌ Phase %d failed: %s\n", i+1, phase.name)
-			fmt.Printf("   Error: %v\n", err)
+		if err := claudeExecutor.ExecuteSlashCommandWithRetry(phase.command, description, resolveClaudeRetryPolicy()); err != nil {
+			if executor.IsTimeoutError(err) {
+				fmt.Printf("   Increase the limit with --claude-timeout or the claude.timeout config key\n")
+			}
+			if hint := claudeLogHint(claudeExecutor); hint != "" {
+				fmt.Printf("   %s\n", hint)
+			}
 			fmt.Printf("\n💡 You can continue manually with:\n")
 
 			// Show remaining phases
 			for j := i; j < len(phases); j++ {
 				fmt.Printf("   %d. %s: %s\n", j+1, phases[j].name, phases[j].command)
 			}
-			os.Exit(1)
+			return clierrors.NewCLIError(fmt.Sprintf("Phase %d failed: %s", i+1, phase.name), claudeFailureExitCode(err)).WithCause(err)
 		}
 
 		fmt.Printf("✅ Phase %d completed: %s\n", i+1, phase.name)
@@ -1284,10 +1522,11 @@ func executeFullTicketWorkflowFromStory() {
 	fmt.Println("💡 Next steps:")
 	fmt.Println("   • Archive ticket: /4-task:3-complete:1-Archive-Ticket")
 	fmt.Println("   • Update status:  /4-task:3-complete:2-Status-Ticket")
+	return nil
 }
 
 // executeFullTicketWorkflowFromIssue executes the complete ticket workflow starting from GitHub issue
-func executeFullTicketWorkflowFromIssue() {
+func executeFullTicketWorkflowFromIssue() error {
 	// Enable debug mode if flag is set
 	debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
@@ -1296,13 +1535,11 @@ func executeFullTicketWorkflowFromIssue() {
 	fmt.Println()
 
 	// Import executor for Claude commands
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Claude CLI not available: %v\n", err)
-		fmt.Println("💡 Please install Claude CLI to use this functionality")
-		os.Exit(1)
+		return clierrors.ErrClaudeUnavailable(err)
 	}
 
 	// Define the workflow phases
@@ -1343,6 +1580,11 @@ func executeFullTicketWorkflowFromIssue() {
 		},
 	}
 
+	if executor.IsDryRun() {
+		printDryRunPhases(phases)
+		return nil
+	}
+
 	// Execute each phase
 	for i, phase := range phases {
 		fmt.Printf("📋 Phase %d/%d: %s\n", i+1, len(phases), phase.name)
@@ -1351,16 +1593,20 @@ func executeFullTicketWorkflowFromIssue() {
 
 		// Execute the Claude slash command
 		description := fmt.Sprintf("Full workflow from issue phase %d: %s", i+1, phase.name)
-		if err := claudeExecutor.ExecuteSlashCommand(phase.command, description); err != nil {
-			fmt.Printf("❌ Phase %d failed: %s\n", i+1, phase.name)
-			fmt.Printf("   Error: %v\n", err)
+		if err := claudeExecutor.ExecuteSlashCommandWithRetry(phase.command, description, resolveClaudeRetryPolicy()); err != nil {
+			if executor.IsTimeoutError(err) {
+				fmt.Printf("   Increase the limit with --claude-timeout or the claude.timeout config key\n")
+			}
+			if hint := claudeLogHint(claudeExecutor); hint != "" {
+				fmt.Printf("   %s\n", hint)
+			}
 			fmt.Printf("\n💡 You can continue manually with:\n")
 
 			// Show remaining phases
 			for j := i; j < len(phases); j++ {
 				fmt.Printf("   %d. %s: %s\n", j+1, phases[j].name, phases[j].command)
 			}
-			os.Exit(1)
+			return clierrors.NewCLIError(fmt.Sprintf("Phase %d failed: %s", i+1, phase.name), claudeFailureExitCode(err)).WithCause(err)
 		}
 
 		fmt.Printf("✅ Phase %d completed: %s\n", i+1, phase.name)
@@ -1374,10 +1620,11 @@ func executeFullTicketWorkflowFromIssue() {
 	fmt.Println("💡 Next steps:")
 	fmt.Println("   • Archive ticket: /4-task:3-complete:1-Archive-Ticket")
 	fmt.Println("   • Update status:  /4-task:3-complete:2-Status-Ticket")
+	return nil
 }
 
 // executeFullTicketWorkflowFromInput executes the complete ticket workflow starting from user input
-func executeFullTicketWorkflowFromInput() {
+func executeFullTicketWorkflowFromInput() error {
 	// Enable debug mode if flag is set
 	debug.SetDebugMode(debugMode || viper.GetBool("debug"))
 
@@ -1386,13 +1633,11 @@ func executeFullTicketWorkflowFromInput() {
 	fmt.Println()
 
 	// Import executor for Claude commands
-	claudeExecutor := executor.NewClaudeExecutor()
+	claudeExecutor := newClaudeExecutor()
 
 	// Validate Claude is available
 	if err := claudeExecutor.ValidateClaudeAvailable(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: Claude CLI not available: %v\n", err)
-		fmt.Println("💡 Please install Claude CLI to use this functionality")
-		os.Exit(1)
+		return clierrors.ErrClaudeUnavailable(err)
 	}
 
 	// Define the workflow phases
@@ -1433,6 +1678,11 @@ func executeFullTicketWorkflowFromInput() {
 		},
 	}
 
+	if executor.IsDryRun() {
+		printDryRunPhases(phases)
+		return nil
+	}
+
 	// Execute each phase
 	for i, phase := range phases {
 		fmt.Printf("📋 Phase %d/%d: %s\n", i+1, len(phases), phase.name)
@@ -1441,16 +1691,20 @@ func executeFullTicketWorkflowFromInput() {
 
 		// Execute the Claude slash command
 		description := fmt.Sprintf("Full workflow from input phase %d: %s", i+1, phase.name)
-		if err := claudeExecutor.ExecuteSlashCommand(phase.command, description); err != nil {
-			fmt.Printf("❌ Phase %d failed: %s\n", i+1, phase.name)
-			fmt.Printf("   Error: %v\n", err)
+		if err := claudeExecutor.ExecuteSlashCommandWithRetry(phase.command, description, resolveClaudeRetryPolicy()); err != nil {
+			if executor.IsTimeoutError(err) {
+				fmt.Printf("   Increase the limit with --claude-timeout or the claude.timeout config key\n")
+			}
+			if hint := claudeLogHint(claudeExecutor); hint != "" {
+				fmt.Printf("   %s\n", hint)
+			}
 			fmt.Printf("\n💡 You can continue manually with:\n")
 
 			// Show remaining phases
 			for j := i; j < len(phases); j++ {
 				fmt.Printf("   %d. %s: %s\n", j+1, phases[j].name, phases[j].command)
 			}
-			os.Exit(1)
+			return clierrors.NewCLIError(fmt.Sprintf("Phase %d failed: %s", i+1, phase.name), claudeFailureExitCode(err)).WithCause(err)
 		}
 
 		fmt.Printf("✅ Phase %d completed: %s\n", i+1, phase.name)
@@ -1464,4 +1718,46 @@ func executeFullTicketWorkflowFromInput() {
 	fmt.Println("💡 Next steps:")
 	fmt.Println("   • Archive ticket: /4-task:3-complete:1-Archive-Ticket")
 	fmt.Println("   • Update status:  /4-task:3-complete:2-Status-Ticket")
+	return nil
+}
+
+// ticketError returns the error of a failed ticket operation, suggesting the
+// statuses a ticket can move to when err is an invalid status transition
+func ticketError(message string, err error) *clierrors.CLIError {
+	cliErr := clierrors.NewCLIError(message, ticketExitCode(err)).WithCause(err)
+
+	var transitionErr *ticket.InvalidTransitionError
+	if !errors.As(err, &transitionErr) {
+		return cliErr
+	}
+
+	targets := make([]string, 0, len(transitionErr.ValidTargets))
+	for _, target := range transitionErr.ValidTargets {
+		targets = append(targets, string(target))
+	}
+	if len(targets) == 0 {
+		return cliErr.WithSuggestion(fmt.Sprintf("A %s ticket cannot change status", transitionErr.From))
+	}
+	return cliErr.WithSuggestion(fmt.Sprintf("From %s, a ticket can move to: %s (see 'ticket state-diagram')",
+		transitionErr.From, strings.Join(targets, ", ")))
+}
+
+// ticketExitCode returns the exit code of a failed ticket operation
+func ticketExitCode(err error) clierrors.ExitCode {
+	var transitionErr *ticket.InvalidTransitionError
+	switch {
+	case errors.As(err, &transitionErr):
+		return clierrors.ExitBlocked
+	case errors.Is(err, ticket.ErrTicketNotFound), errors.Is(err, ticket.ErrSprintNotFound):
+		return clierrors.ExitNotFound
+	}
+	return clierrors.ExitCodeOf(err)
+}
+
+// claudeFailureExitCode returns the exit code of a failed Claude command
+func claudeFailureExitCode(err error) clierrors.ExitCode {
+	if executor.IsTimeoutError(err) {
+		return clierrors.ExitTimeout
+	}
+	return clierrors.ExitGeneric
 }
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"claude-wm-cli/internal/theme"
+	"claude-wm-cli/internal/ticket"
+
+	"github.com/spf13/cobra"
+)
+
+// ticketSprintCmd groups the ticket sprint subcommands
+var ticketSprintCmd = &cobra.Command{
+	Use:   "sprint",
+	Short: "Plan tickets in sprints",
+	Long: `Plan tickets in sprints, time boxes stored in docs/2-current-epic/sprints.json.
+
+A ticket is planned in one sprint at a time: adding it to a sprint moves it
+out of the previous one. Completing a sprint records the story points of its
+resolved and closed tickets as its velocity, shown by 'ticket stats', and
+moves the other tickets to the backlog sprint (SPRINT-BACKLOG), created on
+first use.
+
+COMMANDS:
+  create <name>                       Create a sprint
+  list                                List sprints and mark the active one
+  add <sprint-id> <ticket-id>         Plan a ticket in a sprint
+  remove <sprint-id> <ticket-id>      Take a ticket out of a sprint
+  complete <sprint-id>                Complete a sprint
+
+Examples:
+  claude-wm-cli ticket sprint create "Sprint 1" --start 2025-01-01 --end 2025-01-14
+  claude-wm-cli ticket sprint add SPRINT-001 TICKET-001-FIX-LOGIN-BUG
+  claude-wm-cli ticket list --sprint SPRINT-001
+  claude-wm-cli ticket sprint complete SPRINT-001`,
+}
+
+var ticketSprintCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a sprint",
+	Long: `Create an empty sprint running from --start to --end, both days included.
+
+Examples:
+  claude-wm-cli ticket sprint create "Sprint 1" --start 2025-01-01 --end 2025-01-14`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTicketSprintCreate,
+}
+
+var ticketSprintListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List sprints",
+	Args:  cobra.NoArgs,
+	RunE:  runTicketSprintList,
+}
+
+var ticketSprintAddCmd = &cobra.Command{
+	Use:   "add <sprint-id> <ticket-id>",
+	Short: "Plan a ticket in a sprint",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runTicketSprintAdd,
+}
+
+var ticketSprintRemoveCmd = &cobra.Command{
+	Use:   "remove <sprint-id> <ticket-id>",
+	Short: "Take a ticket out of a sprint",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runTicketSprintRemove,
+}
+
+var ticketSprintCompleteCmd = &cobra.Command{
+	Use:   "complete <sprint-id>",
+	Short: "Complete a sprint, moving its open tickets to the backlog",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTicketSprintComplete,
+}
+
+var (
+	sprintStart string
+	sprintEnd   string
+)
+
+func init() {
+	ticketCmd.AddCommand(ticketSprintCmd)
+	ticketSprintCmd.AddCommand(ticketSprintCreateCmd)
+	ticketSprintCmd.AddCommand(ticketSprintListCmd)
+	ticketSprintCmd.AddCommand(ticketSprintAddCmd)
+	ticketSprintCmd.AddCommand(ticketSprintRemoveCmd)
+	ticketSprintCmd.AddCommand(ticketSprintCompleteCmd)
+
+	ticketSprintCreateCmd.Flags().StringVar(&sprintStart, "start", "", "First day of the sprint (YYYY-MM-DD)")
+	ticketSprintCreateCmd.Flags().StringVar(&sprintEnd, "end", "", "Last day of the sprint (YYYY-MM-DD)")
+	ticketSprintCreateCmd.MarkFlagRequired("start")
+	ticketSprintCreateCmd.MarkFlagRequired("end")
+}
+
+// newSprintManager creates a sprint manager for the current directory
+func newSprintManager() (*ticket.SprintManager, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return ticket.NewSprintManager(wd), nil
+}
+
+// parseSprintDate parses a local YYYY-MM-DD date of the flag name
+func parseSprintDate(name, value string) (time.Time, error) {
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --%s date '%s': use YYYY-MM-DD format", name, value)
+	}
+	return date, nil
+}
+
+func runTicketSprintCreate(cmd *cobra.Command, args []string) error {
+	start, err := parseSprintDate("start", sprintStart)
+	if err != nil {
+		return err
+	}
+	end, err := parseSprintDate("end", sprintEnd)
+	if err != nil {
+		return err
+	}
+
+	manager, err := newSprintManager()
+	if err != nil {
+		return err
+	}
+	sprint, err := manager.CreateSprint(args[0], start, end)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Sprint %s created: %s (%s → %s)\n", sprint.ID, sprint.Name,
+		sprint.StartDate.Format("2006-01-02"), sprint.EndDate.Format("2006-01-02"))
+	fmt.Printf("\n💡 Plan a ticket: claude-wm-cli ticket sprint add %s <ticket-id>\n", sprint.ID)
+	return nil
+}
+
+func runTicketSprintList(cmd *cobra.Command, args []string) error {
+	manager, err := newSprintManager()
+	if err != nil {
+		return err
+	}
+	sprints, err := manager.ListSprints()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("🏃 Sprints\n")
+	fmt.Printf("=========\n\n")
+
+	if len(sprints) == 0 {
+		fmt.Printf("No sprints found.\n\n")
+		fmt.Printf("💡 Create one with: claude-wm-cli ticket sprint create \"Sprint 1\" --start <date> --end <date>\n")
+		return nil
+	}
+
+	now := time.Now()
+	w := theme.NewTable(os.Stdout)
+	w.Row(theme.Heading, "ID\tNAME\tDATES\tTICKETS\tSTATUS\n")
+	w.Row(nil, "──\t────\t─────\t───────\t──────\n")
+	for _, sprint := range sprints {
+		dates, status := "-", "planned"
+		if !sprint.IsBacklog() {
+			dates = sprint.StartDate.Format("2006-01-02") + " → " + sprint.EndDate.Format("2006-01-02")
+		}
+		switch {
+		case sprint.IsBacklog():
+			status = "backlog"
+		case sprint.CompletedAt != nil:
+			status = fmt.Sprintf("completed (%d points)", sprint.CompletedPoints)
+		case sprint.IsActive(now):
+			status = "active"
+		case now.After(sprint.EndDate.AddDate(0, 0, 1)):
+			status = "overdue"
+		}
+		w.Row(nil, "%s\t%s\t%s\t%d\t%s\n",
+			sprint.ID,
+			truncateTicketString(sprint.Name, 30),
+			dates,
+			len(sprint.TicketIDs),
+			status)
+	}
+	w.Flush()
+
+	fmt.Printf("\n💡 List the tickets of a sprint: claude-wm-cli ticket list --sprint <sprint-id>\n")
+	return nil
+}
+
+func runTicketSprintAdd(cmd *cobra.Command, args []string) error {
+	manager, err := newSprintManager()
+	if err != nil {
+		return err
+	}
+	if err := manager.AddTicketToSprint(args[0], args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Ticket %s added to sprint %s\n", args[1], args[0])
+	return nil
+}
+
+func runTicketSprintRemove(cmd *cobra.Command, args []string) error {
+	manager, err := newSprintManager()
+	if err != nil {
+		return err
+	}
+	if err := manager.RemoveTicketFromSprint(args[0], args[1]); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Ticket %s removed from sprint %s\n", args[1], args[0])
+	return nil
+}
+
+func runTicketSprintComplete(cmd *cobra.Command, args []string) error {
+	manager, err := newSprintManager()
+	if err != nil {
+		return err
+	}
+	moved, err := manager.CompleteSprint(args[0])
+	if err != nil {
+		return err
+	}
+	sprint, err := manager.GetSprint(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Sprint %s completed: %d ticket(s) done, %d story points\n",
+		sprint.ID, len(sprint.TicketIDs), sprint.CompletedPoints)
+	if len(moved) > 0 {
+		fmt.Printf("📦 %d incomplete ticket(s) moved to %s:\n", len(moved), ticket.BacklogSprintID)
+		for _, ticketID := range moved {
+			fmt.Printf("   • %s\n", ticketID)
+		}
+	}
+	return nil
+}
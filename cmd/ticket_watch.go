@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	clierrors "claude-wm-cli/internal/errors"
+	"claude-wm-cli/internal/ticket"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// Bounds of the ticket watch --interval
+const (
+	minTicketWatchInterval = time.Second
+	maxTicketWatchInterval = 300 * time.Second
+)
+
+var (
+	ticketWatchInterval time.Duration
+	ticketWatchNotify   bool
+)
+
+// ticketWatchCmd shows a ticket and refreshes it
+var ticketWatchCmd = &cobra.Command{
+	Use:   "watch <ticket-id>",
+	Short: "Display a ticket and refresh it as it changes",
+	Long: `Display a ticket like 'ticket show' and refresh it every --interval
+(between 1s and 300s), to follow a ticket updated by other tools or scripts.
+The terminal is cleared before each refresh. Watching stops on Ctrl+C, or
+with an error when the ticket is deleted.
+
+With --notify, a desktop notification is shown when the ticket status
+changes, with notify-send on Linux and osascript on macOS.
+
+Examples:
+  claude-wm-cli ticket watch TICKET-001
+  claude-wm-cli ticket watch TICKET-001 --interval 30s --notify`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateTicketWatchInterval(ticketWatchInterval); err != nil {
+			return clierrors.NewCLIError(err.Error(), clierrors.ExitUsage)
+		}
+
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+		return watchTicket(args[0])
+	},
+}
+
+func init() {
+	ticketCmd.AddCommand(ticketWatchCmd)
+
+	ticketWatchCmd.Flags().DurationVar(&ticketWatchInterval, "interval", 5*time.Second, "Time between refreshes (1s to 300s)")
+	ticketWatchCmd.Flags().BoolVar(&ticketWatchNotify, "notify", false, "Show a desktop notification when the ticket status changes")
+}
+
+// watchTicket displays ticketID every ticketWatchInterval until interrupted
+func watchTicket(ticketID string) error {
+	// Get current working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		return clierrors.Wrap(err, "Failed to get working directory")
+	}
+
+	manager := ticket.NewManager(wd)
+	clearScreen := term.IsTerminal(int(os.Stdout.Fd()))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(ticketWatchInterval)
+	defer ticker.Stop()
+
+	var lastStatus ticket.TicketStatus
+	var notifyErr error
+	for {
+		t, err := manager.GetTicket(ticketID)
+		if err != nil {
+			return clierrors.NewCLIError("Failed to get ticket", ticketExitCode(err)).WithCause(err)
+		}
+
+		if ticketWatchNotify && lastStatus != "" && t.Status != lastStatus {
+			notifyErr = notifyTicketStatus(t, lastStatus)
+		}
+		lastStatus = t.Status
+
+		currentTicket, _ := manager.GetCurrentTicket()
+		isCurrent := currentTicket != nil && currentTicket.ID == t.ID
+
+		if clearScreen {
+			fmt.Print("\033[H\033[2J")
+		} else {
+			fmt.Println()
+		}
+		fmt.Printf("👀 Watching (Ctrl+C to stop)\n")
+		fmt.Printf("🕒 Last updated: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+		if notifyErr != nil {
+			fmt.Printf("⚠️  Notification failed: %v\n", notifyErr)
+		}
+		fmt.Println()
+		printTicketDetails(t, isCurrent)
+
+		select {
+		case <-ctx.Done():
+			fmt.Printf("\n👋 Stopped watching %s\n", t.ID)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// validateTicketWatchInterval checks that interval is within the bounds of
+// ticket watch --interval
+func validateTicketWatchInterval(interval time.Duration) error {
+	if interval < minTicketWatchInterval || interval > maxTicketWatchInterval {
+		return fmt.Errorf("invalid --interval %s: must be between %s and %s", interval, minTicketWatchInterval, maxTicketWatchInterval)
+	}
+	return nil
+}
+
+// notifyTicketStatus shows a desktop notification of the status change of t
+// from previous
+func notifyTicketStatus(t *ticket.Ticket, previous ticket.TicketStatus) error {
+	message := fmt.Sprintf("%s: %s → %s", t.ID, previous, t.Status)
+	args, err := notificationCommand(runtime.GOOS, "Claude WM CLI", message)
+	if err != nil {
+		return err
+	}
+	if output, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", args[0], err, output)
+	}
+	return nil
+}
+
+// notificationCommand returns the command showing a desktop notification on
+// goos: osascript on macOS, notify-send elsewhere
+func notificationCommand(goos, title, message string) ([]string, error) {
+	if goos == "darwin" {
+		return []string{"osascript", "-e", fmt.Sprintf("display notification %q with title %q", message, title)}, nil
+	}
+	if _, err := exec.LookPath("notify-send"); err != nil {
+		return nil, fmt.Errorf("notify-send not found: install libnotify to get notifications")
+	}
+	return []string{"notify-send", title, message}, nil
+}
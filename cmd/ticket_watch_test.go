@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTicketWatchInterval(t *testing.T) {
+	assert.NoError(t, validateTicketWatchInterval(time.Second))
+	assert.NoError(t, validateTicketWatchInterval(5*time.Minute))
+	assert.ErrorContains(t, validateTicketWatchInterval(500*time.Millisecond), "must be between 1s and 5m0s")
+	assert.Error(t, validateTicketWatchInterval(301*time.Second))
+}
+
+func TestNotificationCommand(t *testing.T) {
+	args, err := notificationCommand("darwin", "Claude WM CLI", `TICKET-001: open → "in_progress"`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"osascript", "-e", `display notification "TICKET-001: open → \"in_progress\"" with title "Claude WM CLI"`}, args)
+}
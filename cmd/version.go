@@ -4,10 +4,17 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"io"
+	"os"
 	"runtime"
 	"runtime/debug"
+	"time"
 
+	"claude-wm-cli/internal/errors"
 	"claude-wm-cli/internal/meta"
 	"claude-wm-cli/internal/metrics"
 
@@ -18,6 +25,9 @@ var (
 	versionOutput string
 	versionShort  bool
 	versionSimple bool
+	versionJSON   bool
+
+	versionCheckTimeout time.Duration
 )
 
 // versionCmd represents the version command
@@ -31,55 +41,134 @@ var versionCmd = &cobra.Command{
 - Operating system and architecture
 - Dependency versions (when verbose)
 
-This information is useful for debugging and support.`,
+This information is useful for debugging and support: paste the output of
+'claude-wm-cli version --simple' in bug reports to identify the exact build.
+
+Release builds get the version, commit and build date from -ldflags (see
+'make build'); binaries built with 'go build' or 'go install' fall back to
+the commit and time recorded by Go.`,
 	Example: `  claude-wm-cli version              # Show full version info
   claude-wm-cli version --short       # Show version number only
-  claude-wm-cli version --output json # Output as JSON`,
-	Run: func(cmd *cobra.Command, args []string) {
+  claude-wm-cli version --simple      # One-line build identifier
+  claude-wm-cli version --json        # Output as JSON
+  claude-wm-cli version check         # Check for a newer release`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Start performance monitoring
 		timer := metrics.InstrumentCommand("version")
 		defer timer.Stop()
 		
-		showVersionInfo()
+		if err := showVersionInfo(); err != nil {
+			timer.SetExitCode(errors.AsExitCode(err))
+			return err
+		}
 		timer.SetExitCode(0)
+		return nil
 	},
 }
 
-func showVersionInfo() {
+// versionCheckCmd compares the running version to the latest release
+var versionCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check whether a newer release is available",
+	Long: `Compare the installed version to the latest GitHub release and print
+the download link when an update is available.
+
+The result is cached for 24 hours in ~/.claude-wm/update-check.json, so
+running the check often does not hit the GitHub API every time. Development
+builds are never reported as out of date.
+
+Use the global --auto-check flag to run this check in the background of any
+command; CLAUDE_WM_NO_UPDATE_CHECK=1 disables it.
+
+Examples:
+  claude-wm-cli version check                # Check for a newer release
+  claude-wm-cli version check --timeout 2s   # Give up after 2 seconds`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Failures past this point are not usage errors
+		cmd.SilenceUsage = true
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), versionCheckTimeout)
+		defer cancel()
+
+		check, err := meta.NewUpdateChecker(versionCheckTimeout).Check(ctx, meta.BuildInfo().Version)
+		if err != nil {
+			if goerrors.Is(err, context.DeadlineExceeded) {
+				return errors.ErrTimeout("update check", versionCheckTimeout)
+			}
+			return errors.ErrNetworkFailure("update check", err)
+		}
+
+		switch {
+		case check.UpdateAvailable():
+			printUpdateAvailable(os.Stdout, check)
+		case meta.IsDevelopmentBuild(check.Current):
+			fmt.Printf("🔧 Development build; the latest release is %s\n", check.Latest)
+		default:
+			fmt.Printf("✅ %s is the latest release\n", check.Current)
+		}
+		return nil
+	},
+}
+
+// printUpdateAvailable prints the update line of check with its download link
+func printUpdateAvailable(w io.Writer, check *meta.UpdateCheck) {
+	fmt.Fprintf(w, "⬆️ Update available: %s → %s\n", check.Current, check.Latest)
+	if check.ReleaseURL != "" {
+		fmt.Fprintf(w, "   Download: %s\n", check.ReleaseURL)
+	}
+}
+
+func showVersionInfo() error {
+	info := meta.BuildInfo()
+
 	if versionShort {
-		fmt.Println(meta.Version)
-		return
+		fmt.Println(info.Version)
+		return nil
 	}
 	
 	if versionSimple {
-		fmt.Printf("%s (commit %s, %s)\n", meta.Version, meta.Commit, meta.BuildDate)
-		return
+		fmt.Println(info.String())
+		return nil
+	}
+
+	output := versionOutput
+	if versionJSON {
+		output = "json"
 	}
 
-	switch versionOutput {
+	switch output {
 	case "json":
-		showVersionJSON()
+		return showVersionJSON(info)
 	case "yaml":
-		showVersionYAML()
+		showVersionYAML(info)
+	case "", "text":
+		showVersionDefault(info)
 	default:
-		showVersionDefault()
+		return errors.NewCLIError(fmt.Sprintf("Invalid output format %q", versionOutput), errors.ExitUsage).
+			WithSuggestion("Use --output json or --output yaml")
 	}
+	return nil
 }
 
-func showVersionDefault() {
+func showVersionDefault(info meta.Info) {
 	fmt.Printf("🚀 Claude WM CLI\n")
 	fmt.Printf("================\n\n")
 
 	// Core version info
-	fmt.Printf("Version:     %s\n", getVersionString())
-	fmt.Printf("Git Commit:  %s\n", meta.Commit)
-	fmt.Printf("Built:       %s\n", meta.BuildDate)
-	fmt.Printf("Go Version:  %s\n", runtime.Version())
-	fmt.Printf("OS/Arch:     %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Printf("Version:     %s\n", getVersionString(info.Version))
+	if info.Modified {
+		fmt.Printf("Git Commit:  %s (uncommitted changes)\n", info.Commit)
+	} else {
+		fmt.Printf("Git Commit:  %s\n", info.Commit)
+	}
+	fmt.Printf("Built:       %s\n", info.BuildDate)
+	fmt.Printf("Go Version:  %s\n", info.GoVersion)
+	fmt.Printf("OS/Arch:     %s/%s\n", info.OS, info.Arch)
 
 	if verbose {
 		fmt.Printf("\n🔧 Build Details:\n")
-		fmt.Printf("Compiler:    %s\n", runtime.Compiler)
+		fmt.Printf("Compiler:    %s\n", info.Compiler)
 		fmt.Printf("NumCPU:      %d\n", runtime.NumCPU())
 
 		// Get build info including dependencies
@@ -95,39 +184,38 @@ func showVersionDefault() {
 		}
 	}
 
+	fmt.Printf("\n📋 Build ID:  %s\n", info.String())
 	fmt.Printf("\n📖 Documentation: docs/README.md\n")
 	fmt.Printf("🐛 Report issues: [repository-url]/issues\n")
 }
 
-func showVersionJSON() {
-	fmt.Printf(`{
-  "version": "%s",
-  "git_commit": "%s",
-  "build_time": "%s",
-  "go_version": "%s",
-  "os": "%s",
-  "arch": "%s",
-  "compiler": "%s"
-}
-`, getVersionString(), meta.Commit, meta.BuildDate, runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.Compiler)
+func showVersionJSON(info meta.Info) error {
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode version info")
+	}
+	fmt.Println(string(data))
+	return nil
 }
 
-func showVersionYAML() {
-	fmt.Printf(`version: %s
-git_commit: %s
-build_time: %s
-go_version: %s
-os: %s
-arch: %s
-compiler: %s
-`, getVersionString(), meta.Commit, meta.BuildDate, runtime.Version(), runtime.GOOS, runtime.GOARCH, runtime.Compiler)
+func showVersionYAML(info meta.Info) {
+	fmt.Printf("version: %s\n", info.Version)
+	fmt.Printf("git_commit: %s\n", info.Commit)
+	fmt.Printf("build_time: %s\n", info.BuildDate)
+	fmt.Printf("go_version: %s\n", info.GoVersion)
+	fmt.Printf("os: %s\n", info.OS)
+	fmt.Printf("arch: %s\n", info.Arch)
+	fmt.Printf("compiler: %s\n", info.Compiler)
+	if info.Modified {
+		fmt.Printf("modified: true\n")
+	}
 }
 
-func getVersionString() string {
-	if meta.Version == "" || meta.Version == "dev" {
+func getVersionString(version string) string {
+	if version == "" || version == "dev" {
 		return "dev (built from source)"
 	}
-	return meta.Version
+	return version
 }
 
 func getShortName(path string) string {
@@ -145,9 +233,13 @@ func getShortName(path string) string {
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
+	versionCmd.AddCommand(versionCheckCmd)
 
 	// Command-specific flags
 	versionCmd.Flags().BoolVarP(&versionShort, "short", "s", false, "Show version number only")
 	versionCmd.Flags().BoolVar(&versionSimple, "simple", false, "Show simple version format: version (commit hash, date)")
 	versionCmd.Flags().StringVarP(&versionOutput, "output", "o", "", "Output format: json, yaml (default: human-readable)")
+	versionCmd.Flags().BoolVar(&versionJSON, "json", false, "Output as JSON (same as --output json)")
+
+	versionCheckCmd.Flags().DurationVar(&versionCheckTimeout, "timeout", 5*time.Second, "Give up the check after this duration")
 }
@@ -0,0 +1,132 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"claude-wm-cli/internal/debug"
+)
+
+var (
+	autoBackupMu     sync.Mutex
+	autoBackupConfig = DefaultBackupConfig()
+)
+
+// SetAutoBackupConfig replaces the configuration of the backups made by
+// WriteWithBackup, DefaultBackupConfig when config is nil
+func SetAutoBackupConfig(config *BackupConfig) {
+	if config == nil {
+		config = DefaultBackupConfig()
+	}
+	autoBackupMu.Lock()
+	autoBackupConfig = config
+	autoBackupMu.Unlock()
+}
+
+// WriteWithBackup backs up the current contents of path, when it exists, then
+// atomically replaces them with data. The backup is stored in the backup
+// directory of the project holding path, the parent of its docs directory.
+// It is skipped when AutoBackup is off or backupType isn't in
+// AutoBackupTypes, and a failed backup is logged without failing the write.
+func WriteWithBackup(path string, data []byte, backupType BackupType) error {
+	autoBackupMu.Lock()
+	config := *autoBackupConfig
+	autoBackupMu.Unlock()
+
+	if config.Enabled && config.AutoBackup && autoBackupType(&config, backupType) {
+		if _, err := os.Stat(path); err == nil {
+			request := BackupRequest{
+				Type:        backupType,
+				Description: fmt.Sprintf("Automatic backup of %s before writing it", filepath.Base(path)),
+			}
+			if _, err := autoBackup(&config, path, request); err != nil {
+				debug.LogResult("BACKUP", "auto backup", fmt.Sprintf("Failed to back up %s: %v", path, err), false)
+			}
+		}
+	}
+
+	// Write atomically using temp file + rename
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // cleanup
+		return fmt.Errorf("failed to replace %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// autoBackupType reports whether backups of type backupType are made before
+// writes, all types being when AutoBackupTypes is empty
+func autoBackupType(config *BackupConfig, backupType BackupType) bool {
+	if len(config.AutoBackupTypes) == 0 {
+		return true
+	}
+	for _, allowed := range config.AutoBackupTypes {
+		if allowed == backupType {
+			return true
+		}
+	}
+	return false
+}
+
+// BackupFile backs up path, when it exists, like WriteWithBackup but even if
+// it was backed up moments ago, to keep a restore point before a change of
+// its own such as archiving an epic. It returns nil without backing up when
+// AutoBackup is off or backupType isn't in AutoBackupTypes.
+func BackupFile(path string, backupType BackupType, description string) (*BackupMetadata, error) {
+	autoBackupMu.Lock()
+	config := *autoBackupConfig
+	autoBackupMu.Unlock()
+
+	if !config.Enabled || !config.AutoBackup || !autoBackupType(&config, backupType) {
+		return nil, nil
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	return autoBackup(&config, path, BackupRequest{Type: backupType, Description: description, Force: true})
+}
+
+// autoBackup backs up path before it is written, with the type, description
+// and force of request
+func autoBackup(config *BackupConfig, path string, request BackupRequest) (*BackupMetadata, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if !filepath.IsAbs(config.BackupDirectory) {
+		config.BackupDirectory = filepath.Join(projectRoot(absPath), config.BackupDirectory)
+	}
+
+	manager, err := NewManager(config)
+	if err != nil {
+		return nil, err
+	}
+	request.SourceFile = absPath
+	request.Reason = ReasonPreWrite
+	request.Compress = true
+	result, err := manager.CreateBackup(&request)
+	if err != nil {
+		return nil, err
+	}
+	return result.Metadata, result.Error
+}
+
+// projectRoot returns the project directory of the state file at path: the
+// parent of its nearest docs directory, or the directory of the file
+func projectRoot(path string) string {
+	for dir := filepath.Dir(path); ; {
+		if filepath.Base(dir) == "docs" {
+			return filepath.Dir(dir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return filepath.Dir(path)
+		}
+		dir = parent
+	}
+}
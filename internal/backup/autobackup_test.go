@@ -0,0 +1,121 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// autoBackups returns the backups in the backup directory of projectPath
+func autoBackups(t *testing.T, projectPath string) []*BackupMetadata {
+	t.Helper()
+	config := DefaultBackupConfig()
+	config.BackupDirectory = filepath.Join(projectPath, config.BackupDirectory)
+	manager, err := NewManager(config)
+	require.NoError(t, err)
+	backups, err := manager.ListBackups(nil)
+	require.NoError(t, err)
+	return backups
+}
+
+func TestWriteWithBackup(t *testing.T) {
+	t.Cleanup(func() { SetAutoBackupConfig(nil) })
+	projectPath := t.TempDir()
+	epicsPath := filepath.Join(projectPath, "docs", "1-project", "epics.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(epicsPath), 0755))
+
+	// A new file has nothing to back up
+	require.NoError(t, WriteWithBackup(epicsPath, []byte(`{"v":1}`), BackupTypeAutomatic))
+	assert.Empty(t, autoBackups(t, projectPath))
+
+	require.NoError(t, WriteWithBackup(epicsPath, []byte(`{"v":2}`), BackupTypeAutomatic))
+	data, err := os.ReadFile(epicsPath)
+	require.NoError(t, err)
+	assert.Equal(t, `{"v":2}`, string(data))
+	assert.NoFileExists(t, epicsPath+".tmp")
+
+	backups := autoBackups(t, projectPath)
+	require.Len(t, backups, 1)
+	assert.Equal(t, BackupTypeAutomatic, backups[0].Type)
+	assert.Equal(t, ReasonPreWrite, backups[0].Reason)
+	assert.Equal(t, epicsPath, backups[0].SourceFile)
+}
+
+func TestWriteWithBackup_Disabled(t *testing.T) {
+	t.Cleanup(func() { SetAutoBackupConfig(nil) })
+
+	for name, configure := range map[string]func(*BackupConfig){
+		"auto backup off":   func(c *BackupConfig) { c.AutoBackup = false },
+		"type not selected": func(c *BackupConfig) { c.AutoBackupTypes = []BackupType{BackupTypeSnapshot} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := DefaultBackupConfig()
+			configure(config)
+			SetAutoBackupConfig(config)
+
+			projectPath := t.TempDir()
+			storiesPath := filepath.Join(projectPath, "docs", "2-current-epic", "stories.json")
+			require.NoError(t, os.MkdirAll(filepath.Dir(storiesPath), 0755))
+			require.NoError(t, os.WriteFile(storiesPath, []byte(`{}`), 0644))
+
+			require.NoError(t, WriteWithBackup(storiesPath, []byte(`{"v":2}`), BackupTypeAutomatic))
+			assert.Empty(t, autoBackups(t, projectPath))
+		})
+	}
+}
+
+func TestWriteWithBackup_BackupFailureDoesNotBlockWrite(t *testing.T) {
+	t.Cleanup(func() { SetAutoBackupConfig(nil) })
+	projectPath := t.TempDir()
+
+	// The backup directory can't be created over a file
+	blocker := filepath.Join(projectPath, "blocker")
+	require.NoError(t, os.WriteFile(blocker, nil, 0644))
+	config := DefaultBackupConfig()
+	config.BackupDirectory = filepath.Join(blocker, ".backups")
+	SetAutoBackupConfig(config)
+
+	path := filepath.Join(projectPath, "state.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{}`), 0644))
+	require.NoError(t, WriteWithBackup(path, []byte(`{"v":2}`), BackupTypeAutomatic))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `{"v":2}`, string(data))
+}
+
+func TestBackupFile(t *testing.T) {
+	t.Cleanup(func() { SetAutoBackupConfig(nil) })
+	projectPath := t.TempDir()
+	epicsPath := filepath.Join(projectPath, "docs", "1-project", "epics.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(epicsPath), 0755))
+
+	metadata, err := BackupFile(epicsPath, BackupTypeAutomatic, "Before archiving")
+	require.NoError(t, err)
+	assert.Nil(t, metadata, "nothing to back up")
+
+	require.NoError(t, os.WriteFile(epicsPath, []byte(`{"v":1}`), 0644))
+	require.NoError(t, WriteWithBackup(epicsPath, []byte(`{"v":2}`), BackupTypeAutomatic))
+
+	// Backed up although a backup was just made
+	metadata, err = BackupFile(epicsPath, BackupTypeAutomatic, "Before archiving")
+	require.NoError(t, err)
+	require.NotNil(t, metadata)
+	assert.Equal(t, ReasonPreWrite, metadata.Reason)
+	assert.Len(t, autoBackups(t, projectPath), 2)
+
+	config := DefaultBackupConfig()
+	config.AutoBackup = false
+	SetAutoBackupConfig(config)
+	metadata, err = BackupFile(epicsPath, BackupTypeAutomatic, "Before archiving")
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+}
+
+func TestProjectRoot(t *testing.T) {
+	assert.Equal(t, filepath.FromSlash("/work/app"), projectRoot(filepath.FromSlash("/work/app/docs/1-project/epics.json")))
+	assert.Equal(t, filepath.FromSlash("/work/app"), projectRoot(filepath.FromSlash("/work/app/state.json")))
+}
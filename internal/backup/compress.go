@@ -0,0 +1,190 @@
+package backup
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CompressedSuffix is appended to the names of gzip-compressed backup files
+const CompressedSuffix = ".gz"
+
+// DefaultCompressionLevel is used when the configured level is out of range
+const DefaultCompressionLevel = 6
+
+// IsCompressedFile reports whether a backup file is gzip-compressed based on its extension
+func IsCompressedFile(path string) bool {
+	return strings.HasSuffix(path, CompressedSuffix)
+}
+
+// compressionLevel returns the configured gzip level, clamped to 1-9
+func (m *Manager) compressionLevel() int {
+	level := m.config.CompressionLevel
+	if level < gzip.BestSpeed || level > gzip.BestCompression {
+		return DefaultCompressionLevel
+	}
+	return level
+}
+
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.count += int64(n)
+	return n, err
+}
+
+// writeBackupData copies src to dst, gzip-compressing it when requested.
+// The returned checksum and size describe the bytes written to dst.
+func writeBackupData(dst io.Writer, src io.Reader, compress bool, level int) (checksum string, size int64, err error) {
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(dst, hash)}
+
+	if compress {
+		gz, err := gzip.NewWriterLevel(counter, level)
+		if err != nil {
+			return "", 0, err
+		}
+		if _, err := io.Copy(gz, src); err != nil {
+			gz.Close()
+			return "", 0, err
+		}
+		if err := gz.Close(); err != nil {
+			return "", 0, err
+		}
+	} else if _, err := io.Copy(counter, src); err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), counter.count, nil
+}
+
+// gzipReadCloser closes both the gzip reader and the underlying file
+type gzipReadCloser struct {
+	*gzip.Reader
+	file io.Closer
+}
+
+func (g *gzipReadCloser) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// openBackupReader opens a backup file and returns a reader yielding the
+// original (decrypted and decompressed) content
+func (m *Manager) openBackupReader(backupFile string) (io.ReadCloser, error) {
+	if IsEncryptedFile(backupFile) {
+		return m.openEncryptedBackup(backupFile)
+	}
+
+	file, err := os.Open(backupFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsCompressedFile(backupFile) {
+		return file, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to open compressed backup: %w", err)
+	}
+	return &gzipReadCloser{Reader: gz, file: file}, nil
+}
+
+// RecompressBackups compresses the existing uncompressed backups matching the filter.
+// It returns the metadata of the backups that were recompressed.
+func (m *Manager) RecompressBackups(filter *BackupFilter) ([]*BackupMetadata, error) {
+	candidates, err := m.ListBackups(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var recompressed []*BackupMetadata
+	for _, backup := range candidates {
+		// Encrypted backups are compressed before encryption, if at all
+		if backup.Compressed || backup.Encrypted || IsCompressedFile(backup.BackupFile) || IsEncryptedFile(backup.BackupFile) {
+			continue
+		}
+		if err := m.recompressBackup(backup); err != nil {
+			return recompressed, fmt.Errorf("failed to recompress backup %s: %w", backup.ID, err)
+		}
+		recompressed = append(recompressed, backup)
+	}
+
+	if len(recompressed) > 0 {
+		m.mu.Lock()
+		err := m.saveMetadata()
+		m.mu.Unlock()
+		if err != nil {
+			return recompressed, fmt.Errorf("failed to save metadata: %w", err)
+		}
+	}
+
+	return recompressed, nil
+}
+
+// recompressBackup writes a gzip-compressed copy of an uncompressed backup,
+// updates its metadata and removes the original file
+func (m *Manager) recompressBackup(backup *BackupMetadata) error {
+	source, err := os.Open(backup.BackupFile)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	compressedFile := backup.BackupFile + CompressedSuffix
+	if err := os.MkdirAll(filepath.Dir(compressedFile), 0755); err != nil {
+		return err
+	}
+
+	dest, err := os.Create(compressedFile)
+	if err != nil {
+		return err
+	}
+
+	checksum, size, err := writeBackupData(dest, source, true, m.compressionLevel())
+	if closeErr := dest.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(compressedFile)
+		return err
+	}
+
+	originalFile := backup.BackupFile
+
+	m.mu.Lock()
+	m.stats.TotalSize += size - backup.BackupSize
+	backup.BackupFile = compressedFile
+	backup.BackupChecksum = checksum
+	backup.BackupSize = size
+	backup.Compressed = true
+	m.mu.Unlock()
+
+	if err := os.Remove(originalFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	m.emitEvent(BackupEvent{
+		Type:       EventBackupCompleted,
+		SourceFile: backup.SourceFile,
+		BackupID:   backup.ID,
+		Message:    fmt.Sprintf("Backup recompressed (size: %d bytes)", size),
+		Timestamp:  time.Now(),
+	})
+
+	return nil
+}
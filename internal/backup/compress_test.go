@@ -0,0 +1,114 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBackupDataRoundTrip(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+	content := []byte(strings.Repeat(`{"id": "TICKET-001", "status": "open"}`+"\n", 100))
+
+	for _, compress := range []bool{false, true} {
+		backupFile := filepath.Join(dir, "state.json.bak")
+		if compress {
+			backupFile += CompressedSuffix
+		}
+
+		var written bytes.Buffer
+		checksum, size, err := writeBackupData(&written, bytes.NewReader(content), compress, DefaultCompressionLevel)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(backupFile, written.Bytes(), 0644))
+
+		// The checksum and size describe the stored bytes, compressed or not
+		sum := sha256.Sum256(written.Bytes())
+		assert.Equal(t, hex.EncodeToString(sum[:]), checksum, "compress=%v", compress)
+		assert.Equal(t, int64(written.Len()), size, "compress=%v", compress)
+		if compress {
+			assert.Less(t, size, int64(len(content)))
+		} else {
+			assert.Equal(t, content, written.Bytes())
+		}
+
+		reader, err := manager.openBackupReader(backupFile)
+		require.NoError(t, err)
+		restored, err := io.ReadAll(reader)
+		require.NoError(t, reader.Close())
+		require.NoError(t, err)
+		assert.Equal(t, content, restored, "compress=%v", compress)
+	}
+}
+
+func TestOpenBackupReaderRejectsInvalidGzip(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+	backupFile := filepath.Join(dir, "state.json.bak"+CompressedSuffix)
+	require.NoError(t, os.WriteFile(backupFile, []byte("not gzip"), 0644))
+
+	_, err := manager.openBackupReader(backupFile)
+	assert.ErrorContains(t, err, "failed to open compressed backup")
+}
+
+func TestCompressedBackupChecksum(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+	content := []byte(`{"tickets": []}`)
+
+	backup := createAndRestore(t, manager, dir, content, true)
+	require.True(t, IsCompressedFile(backup.BackupFile))
+	assert.True(t, backup.Compressed)
+
+	stored, err := os.ReadFile(backup.BackupFile)
+	require.NoError(t, err)
+	sum := sha256.Sum256(stored)
+	assert.Equal(t, hex.EncodeToString(sum[:]), backup.BackupChecksum)
+	assert.Equal(t, int64(len(stored)), backup.BackupSize)
+	assert.True(t, manager.CheckBackupHealth(backup).IsHealthy())
+
+	// Corrupting the compressed file is caught by the checksum
+	require.NoError(t, os.WriteFile(backup.BackupFile, append(stored, 0), 0644))
+	assert.Equal(t, HealthChecksumMismatch, manager.CheckBackupHealth(backup).Status)
+}
+
+func TestRecompressBackups(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+	content := []byte("[" + strings.Repeat(`{"id": "STORY-001"},`, 50) + `{"id": "STORY-002"}]`)
+
+	backup := createAndRestore(t, manager, dir, content, false)
+	originalFile := backup.BackupFile
+	require.False(t, IsCompressedFile(originalFile))
+	createAndRestore(t, manager, dir, content, true)
+
+	recompressed, err := manager.RecompressBackups(nil)
+	require.NoError(t, err)
+	require.Len(t, recompressed, 1, "compressed backups are left alone")
+	assert.Equal(t, backup.ID, recompressed[0].ID)
+
+	assert.NoFileExists(t, originalFile)
+	assert.Equal(t, originalFile+CompressedSuffix, backup.BackupFile)
+	assert.True(t, backup.Compressed)
+	assert.True(t, manager.CheckBackupHealth(backup).IsHealthy())
+
+	restored, err := manager.ReadBackupContent(backup)
+	require.NoError(t, err)
+	assert.Equal(t, content, restored)
+
+	// The new file names and checksums are saved with the metadata
+	reloaded, err := NewManager(manager.config)
+	require.NoError(t, err)
+	saved, err := reloaded.GetBackup(backup.ID)
+	require.NoError(t, err)
+	assert.Equal(t, backup.BackupFile, saved.BackupFile)
+	assert.Equal(t, backup.BackupChecksum, saved.BackupChecksum)
+
+	recompressed, err = manager.RecompressBackups(nil)
+	require.NoError(t, err)
+	assert.Empty(t, recompressed)
+}
@@ -0,0 +1,204 @@
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// EncryptedSuffix is appended to the names of encrypted backup files
+const EncryptedSuffix = ".enc"
+
+const (
+	encryptionKeySize = 32 // AES-256
+	saltSize          = 16
+	pbkdf2Iterations  = 100000
+)
+
+// ErrNoEncryptionKey is returned when encryption is needed but neither a
+// passphrase nor a key file is configured
+var ErrNoEncryptionKey = errors.New("encryption requires a passphrase or a key file")
+
+// IsEncryptedFile reports whether a backup file is encrypted based on its extension
+func IsEncryptedFile(path string) bool {
+	return strings.HasSuffix(path, EncryptedSuffix)
+}
+
+// encryptedPathSuffix returns the ".<salt>.enc" suffix for a new encrypted backup.
+// The salt is stored in the filename so the key can be derived again on restore.
+func encryptedPathSuffix() (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate encryption salt: %w", err)
+	}
+	return "." + hex.EncodeToString(salt) + EncryptedSuffix, nil
+}
+
+// saltFromPath extracts the key derivation salt from an encrypted backup filename
+func saltFromPath(path string) ([]byte, error) {
+	name := strings.TrimSuffix(filepath.Base(path), EncryptedSuffix)
+	idx := strings.LastIndex(name, ".")
+	if idx < 0 {
+		return nil, fmt.Errorf("encrypted backup %s has no salt in its name", path)
+	}
+
+	salt, err := hex.DecodeString(name[idx+1:])
+	if err != nil || len(salt) != saltSize {
+		return nil, fmt.Errorf("encrypted backup %s has an invalid salt in its name", path)
+	}
+	return salt, nil
+}
+
+// stripEncryptionSuffix returns the backup path without its ".<salt>.enc" suffix
+func stripEncryptionSuffix(path string) string {
+	if !IsEncryptedFile(path) {
+		return path
+	}
+	trimmed := strings.TrimSuffix(path, EncryptedSuffix)
+	if idx := strings.LastIndex(trimmed, "."); idx >= 0 {
+		return trimmed[:idx]
+	}
+	return trimmed
+}
+
+// encryptionKey returns the AES-256 key for the given salt. A configured key
+// file takes precedence over the passphrase.
+func (m *Manager) encryptionKey(salt []byte) ([]byte, error) {
+	if m.config.KeyFile != "" {
+		key, err := os.ReadFile(m.config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		if len(key) != encryptionKeySize {
+			return nil, fmt.Errorf("key file %s must contain exactly %d bytes, got %d",
+				m.config.KeyFile, encryptionKeySize, len(key))
+		}
+		return key, nil
+	}
+
+	if m.config.Passphrase == "" {
+		return nil, ErrNoEncryptionKey
+	}
+
+	return pbkdf2.Key([]byte(m.config.Passphrase), salt, pbkdf2Iterations, encryptionKeySize, sha256.New), nil
+}
+
+// encrypt seals plaintext with AES-256-GCM, prepending the nonce to the ciphertext
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decrypt opens data produced by encrypt
+func decrypt(key, data []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted backup is truncated")
+	}
+
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt backup (wrong key or corrupted file): %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// performEncryptedBackup writes an (optionally compressed) encrypted copy of source
+// to backupFile. The returned checksum and size describe the encrypted file.
+func (m *Manager) performEncryptedBackup(source io.Reader, backupFile string, compress bool) (string, int64, error) {
+	salt, err := saltFromPath(backupFile)
+	if err != nil {
+		return "", 0, err
+	}
+
+	key, err := m.encryptionKey(salt)
+	if err != nil {
+		return "", 0, err
+	}
+
+	var plaintext bytes.Buffer
+	if _, _, err := writeBackupData(&plaintext, source, compress, m.compressionLevel()); err != nil {
+		return "", 0, err
+	}
+
+	ciphertext, err := encrypt(key, plaintext.Bytes())
+	if err != nil {
+		return "", 0, err
+	}
+
+	if err := os.WriteFile(backupFile, ciphertext, 0600); err != nil {
+		return "", 0, err
+	}
+
+	hash := sha256.Sum256(ciphertext)
+	return hex.EncodeToString(hash[:]), int64(len(ciphertext)), nil
+}
+
+// openEncryptedBackup decrypts an encrypted backup and returns a reader
+// yielding the original content
+func (m *Manager) openEncryptedBackup(backupFile string) (io.ReadCloser, error) {
+	salt, err := saltFromPath(backupFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := m.encryptionKey(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(backupFile)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := decrypt(key, data)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := bytes.NewReader(plaintext)
+	if !IsCompressedFile(stripEncryptionSuffix(backupFile)) {
+		return io.NopCloser(reader), nil
+	}
+
+	gz, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open compressed backup: %w", err)
+	}
+	return gz, nil
+}
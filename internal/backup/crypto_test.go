@@ -0,0 +1,140 @@
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPassphrase = "test-only-passphrase"
+
+func newTestManager(t *testing.T, configure func(*BackupConfig)) (*Manager, string) {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	config := DefaultBackupConfig()
+	config.BackupDirectory = filepath.Join(tempDir, ".backups")
+	if configure != nil {
+		configure(config)
+	}
+
+	manager, err := NewManager(config)
+	require.NoError(t, err)
+	return manager, tempDir
+}
+
+func createAndRestore(t *testing.T, manager *Manager, dir string, content []byte, compress bool) *BackupMetadata {
+	t.Helper()
+	source := filepath.Join(dir, "state.json")
+	require.NoError(t, os.WriteFile(source, content, 0644))
+
+	result, err := manager.CreateBackup(&BackupRequest{
+		SourceFile: source,
+		Type:       BackupTypeManual,
+		Reason:     ReasonUserRequest,
+		Compress:   compress,
+		Verify:     true,
+		Force:      true,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Success, "backup failed: %v", result.Error)
+
+	restored := filepath.Join(dir, "restored.json")
+	recovery, err := manager.RecoverFromBackup(&RecoveryRequest{
+		SourceFile:  source,
+		BackupID:    result.Metadata.ID,
+		RestorePath: restored,
+		RestoreMode: RestoreModeReplace,
+	})
+	require.NoError(t, err)
+	require.True(t, recovery.Success, "restore failed: %v", recovery.Error)
+
+	data, err := os.ReadFile(restored)
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	return result.Metadata
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{0x42}, encryptionKeySize)
+	plaintext := []byte("ticket description")
+
+	ciphertext, err := encrypt(key, plaintext)
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "ticket")
+
+	decrypted, err := decrypt(key, ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	wrongKey := bytes.Repeat([]byte{0x24}, encryptionKeySize)
+	_, err = decrypt(wrongKey, ciphertext)
+	assert.Error(t, err)
+}
+
+func TestSaltFromPath(t *testing.T) {
+	suffix, err := encryptedPathSuffix()
+	require.NoError(t, err)
+	path := "/tmp/state.json.20250101-120000.backup-a.backup.gz" + suffix
+
+	salt, err := saltFromPath(path)
+	require.NoError(t, err)
+	assert.Len(t, salt, saltSize)
+	assert.Equal(t, "/tmp/state.json.20250101-120000.backup-a.backup.gz", stripEncryptionSuffix(path))
+
+	_, err = saltFromPath("/tmp/state.json.backup.enc")
+	assert.Error(t, err)
+}
+
+func TestManager_EncryptedBackupWithPassphrase(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		manager, dir := newTestManager(t, func(c *BackupConfig) {
+			c.Encrypt = true
+			c.Passphrase = testPassphrase
+		})
+
+		content := []byte(strings.Repeat(`{"ticket":"sensitive description"}`, 50))
+		meta := createAndRestore(t, manager, dir, content, compress)
+
+		assert.True(t, meta.Encrypted)
+		assert.True(t, IsEncryptedFile(meta.BackupFile))
+		assert.Equal(t, compress, IsCompressedFile(stripEncryptionSuffix(meta.BackupFile)))
+
+		raw, err := os.ReadFile(meta.BackupFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(raw), "sensitive")
+	}
+}
+
+func TestManager_EncryptedBackupWithKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "backup.key")
+	require.NoError(t, os.WriteFile(keyFile, bytes.Repeat([]byte{0x07}, encryptionKeySize), 0600))
+
+	manager, dir := newTestManager(t, func(c *BackupConfig) {
+		c.Encrypt = true
+		c.KeyFile = keyFile
+	})
+
+	meta := createAndRestore(t, manager, dir, []byte("key file content"), false)
+	assert.True(t, meta.Encrypted)
+}
+
+func TestManager_EncryptedBackupRequiresKey(t *testing.T) {
+	manager, dir := newTestManager(t, func(c *BackupConfig) {
+		c.Encrypt = true
+	})
+
+	source := filepath.Join(dir, "state.json")
+	require.NoError(t, os.WriteFile(source, []byte("{}"), 0644))
+
+	result, err := manager.CreateBackup(&BackupRequest{SourceFile: source, Force: true})
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.ErrorIs(t, result.Error, ErrNoEncryptionKey)
+}
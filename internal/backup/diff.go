@@ -0,0 +1,191 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Diff operations applied to JSON documents
+const (
+	DiffOpAdd     = "add"     // Add a field that did not exist
+	DiffOpRemove  = "remove"  // Remove an existing field
+	DiffOpReplace = "replace" // Replace the value at a path
+)
+
+// DiffOp is a single field-level change between two JSON documents.
+// Path is a JSON pointer ("" is the whole document, "/a/b" is field b of object a).
+type DiffOp struct {
+	Path  string          `json:"path"`
+	Op    string          `json:"op"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// ComputeJSONDiff returns the operations that turn document a into document b.
+// Objects are compared field by field; arrays and scalars are replaced as a whole.
+func ComputeJSONDiff(a, b []byte) ([]DiffOp, error) {
+	oldDoc, err := decodeJSONDocument(a)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base document: %w", err)
+	}
+	newDoc, err := decodeJSONDocument(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target document: %w", err)
+	}
+
+	ops := make([]DiffOp, 0)
+	if err := diffJSONValues("", oldDoc, newDoc, &ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// ApplyDiff applies ops to the base document and returns the resulting document,
+// indented with two spaces like the state files written by the CLI
+func ApplyDiff(base []byte, ops []DiffOp) ([]byte, error) {
+	doc, err := decodeJSONDocument(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base document: %w", err)
+	}
+
+	for _, op := range ops {
+		doc, err = applyDiffOp(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply %s at %q: %w", op.Op, op.Path, err)
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func decodeJSONDocument(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func diffJSONValues(path string, oldValue, newValue interface{}, ops *[]DiffOp) error {
+	oldObject, oldIsObject := oldValue.(map[string]interface{})
+	newObject, newIsObject := newValue.(map[string]interface{})
+
+	if oldIsObject && newIsObject {
+		for _, key := range sortedKeys(oldObject) {
+			childPath := path + "/" + escapePointerToken(key)
+			newChild, exists := newObject[key]
+			if !exists {
+				*ops = append(*ops, DiffOp{Path: childPath, Op: DiffOpRemove})
+				continue
+			}
+			if err := diffJSONValues(childPath, oldObject[key], newChild, ops); err != nil {
+				return err
+			}
+		}
+
+		for _, key := range sortedKeys(newObject) {
+			if _, exists := oldObject[key]; exists {
+				continue
+			}
+			value, err := json.Marshal(newObject[key])
+			if err != nil {
+				return err
+			}
+			*ops = append(*ops, DiffOp{Path: path + "/" + escapePointerToken(key), Op: DiffOpAdd, Value: value})
+		}
+		return nil
+	}
+
+	if reflect.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+
+	value, err := json.Marshal(newValue)
+	if err != nil {
+		return err
+	}
+	*ops = append(*ops, DiffOp{Path: path, Op: DiffOpReplace, Value: value})
+	return nil
+}
+
+func applyDiffOp(doc interface{}, op DiffOp) (interface{}, error) {
+	tokens := parsePointer(op.Path)
+
+	var value interface{}
+	if op.Op == DiffOpAdd || op.Op == DiffOpReplace {
+		decoded, err := decodeJSONDocument(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+		value = decoded
+	} else if op.Op != DiffOpRemove {
+		return nil, fmt.Errorf("unknown operation")
+	}
+
+	if len(tokens) == 0 {
+		if op.Op == DiffOpRemove {
+			return nil, fmt.Errorf("cannot remove the document root")
+		}
+		return value, nil
+	}
+
+	parent := doc
+	for _, token := range tokens[:len(tokens)-1] {
+		object, ok := parent.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%q is not an object", token)
+		}
+		if parent, ok = object[token]; !ok {
+			return nil, fmt.Errorf("field %q does not exist", token)
+		}
+	}
+
+	object, ok := parent.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("parent is not an object")
+	}
+
+	key := tokens[len(tokens)-1]
+	if op.Op == DiffOpRemove {
+		if _, exists := object[key]; !exists {
+			return nil, fmt.Errorf("field %q does not exist", key)
+		}
+		delete(object, key)
+	} else {
+		object[key] = value
+	}
+
+	return doc, nil
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escapePointerToken escapes a key for use in a JSON pointer (RFC 6901)
+func escapePointerToken(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func parsePointer(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
@@ -0,0 +1,134 @@
+package backup
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeJSONDiff(t *testing.T) {
+	a := []byte(`{"id":"T-1","status":"open","meta":{"owner":"alice","tags":["x"]},"old":true}`)
+	b := []byte(`{"id":"T-1","status":"done","meta":{"owner":"alice","tags":["x","y"]},"new/field":1}`)
+
+	ops, err := ComputeJSONDiff(a, b)
+	require.NoError(t, err)
+
+	assert.Equal(t, []DiffOp{
+		{Path: "/meta/tags", Op: DiffOpReplace, Value: json.RawMessage(`["x","y"]`)},
+		{Path: "/old", Op: DiffOpRemove},
+		{Path: "/status", Op: DiffOpReplace, Value: json.RawMessage(`"done"`)},
+		{Path: "/new~1field", Op: DiffOpAdd, Value: json.RawMessage(`1`)},
+	}, ops)
+
+	applied, err := ApplyDiff(a, ops)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(b), string(applied))
+}
+
+func TestComputeJSONDiffIdentical(t *testing.T) {
+	ops, err := ComputeJSONDiff([]byte(`{"a":1.50}`), []byte(`{ "a": 1.50 }`))
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+}
+
+func TestComputeJSONDiffInvalid(t *testing.T) {
+	_, err := ComputeJSONDiff([]byte(`not json`), []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestApplyDiffErrors(t *testing.T) {
+	base := []byte(`{"a":{"b":1}}`)
+
+	_, err := ApplyDiff(base, []DiffOp{{Path: "/missing", Op: DiffOpRemove}})
+	assert.Error(t, err)
+
+	_, err = ApplyDiff(base, []DiffOp{{Path: "/a/b/c", Op: DiffOpAdd, Value: json.RawMessage(`1`)}})
+	assert.Error(t, err)
+
+	_, err = ApplyDiff(base, []DiffOp{{Path: "", Op: DiffOpRemove}})
+	assert.Error(t, err)
+
+	replaced, err := ApplyDiff(base, []DiffOp{{Path: "", Op: DiffOpReplace, Value: json.RawMessage(`[1,2]`)}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[1,2]`, string(replaced))
+}
+
+func TestManager_IncrementalBackupChain(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+	source := filepath.Join(dir, "state.json")
+
+	versions := []string{
+		`{"status":"open","count":1}`,
+		`{"status":"in_progress","count":2}`,
+		`{"status":"done","count":3,"closed":true}`,
+	}
+
+	var backups []*BackupMetadata
+	for _, version := range versions {
+		require.NoError(t, os.WriteFile(source, []byte(version), 0644))
+		result, err := manager.CreateBackup(&BackupRequest{
+			SourceFile: source,
+			Type:       BackupTypeIncremental,
+			Force:      true,
+		})
+		require.NoError(t, err)
+		require.True(t, result.Success, "backup failed: %v", result.Error)
+		backups = append(backups, result.Metadata)
+	}
+
+	assert.False(t, backups[0].IsIncremental, "first backup must be a full backup")
+	assert.True(t, backups[1].IsIncremental)
+	assert.Equal(t, backups[0].ID, backups[1].ParentBackupID)
+	assert.Equal(t, backups[1].ID, backups[2].ParentBackupID)
+
+	for i, backup := range backups {
+		content, err := manager.ReadBackupContent(backup)
+		require.NoError(t, err)
+		assert.JSONEq(t, versions[i], string(content))
+	}
+
+	assert.Error(t, manager.DeleteBackup(backups[0].ID), "parent of an incremental backup must not be deleted")
+
+	consolidated, err := manager.ConsolidateBackups(source)
+	require.NoError(t, err)
+	assert.False(t, consolidated.IsIncremental)
+
+	remaining, err := manager.ListBackups(&BackupFilter{SourceFile: source})
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, consolidated.ID, remaining[0].ID)
+
+	content, err := manager.ReadBackupContent(consolidated)
+	require.NoError(t, err)
+	assert.JSONEq(t, versions[2], string(content))
+}
+
+func TestManager_IncrementalFallsBackForNonJSON(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+	source := filepath.Join(dir, "notes.txt")
+
+	for _, content := range []string{"first", "second"} {
+		require.NoError(t, os.WriteFile(source, []byte(content), 0644))
+		result, err := manager.CreateBackup(&BackupRequest{SourceFile: source, Type: BackupTypeIncremental, Force: true})
+		require.NoError(t, err)
+		require.True(t, result.Success)
+		assert.False(t, result.Metadata.IsIncremental)
+	}
+}
+
+func TestExcludeChainParents(t *testing.T) {
+	full := &BackupMetadata{ID: "full"}
+	inc1 := &BackupMetadata{ID: "inc1", IsIncremental: true, ParentBackupID: "full"}
+	inc2 := &BackupMetadata{ID: "inc2", IsIncremental: true, ParentBackupID: "inc1"}
+	other := &BackupMetadata{ID: "other"}
+
+	backups := []*BackupMetadata{other, full, inc1, inc2}
+	kept := excludeChainParents(backups, []*BackupMetadata{other, full, inc1})
+
+	require.Len(t, kept, 1)
+	assert.Equal(t, "other", kept[0].ID)
+}
@@ -0,0 +1,87 @@
+package backup
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// BackupPickerDisplay renders backups, with their health, as aligned rows to
+// pick a backup from. The rows are rendered without numbers so that the caller
+// can show them in a numbered menu.
+type BackupPickerDisplay struct {
+	entries []*BackupHealth
+}
+
+// NewBackupPickerDisplay creates a picker over the given backups, in order
+func NewBackupPickerDisplay(entries []*BackupHealth) *BackupPickerDisplay {
+	return &BackupPickerDisplay{entries: entries}
+}
+
+// Len returns the number of backups in the picker
+func (d *BackupPickerDisplay) Len() int {
+	return len(d.entries)
+}
+
+// Backup returns the backup at index i
+func (d *BackupPickerDisplay) Backup(i int) *BackupMetadata {
+	return d.entries[i].Backup
+}
+
+// Render returns the column header and one row per backup, aligned with
+// tabwriter: creation time, type, size, ID and integrity status. The
+// integrity column comes last as its emoji would misalign the columns after it.
+func (d *BackupPickerDisplay) Render() (string, []string) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "CREATED\tTYPE\tSIZE\tID\tINTEGRITY\n")
+	for _, entry := range d.entries {
+		b := entry.Backup
+		kind := string(b.Type)
+		if b.IsIncremental {
+			kind = "incremental"
+		}
+		integrity := integrityLabel(entry)
+		if b.Encrypted {
+			integrity += " 🔒"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			b.CreatedAt.Format(time.DateTime),
+			kind,
+			formatSize(b.SourceSize),
+			b.ID,
+			integrity)
+	}
+	w.Flush()
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	return lines[0], lines[1:]
+}
+
+// integrityLabel summarizes the health of a backup in a few characters
+func integrityLabel(entry *BackupHealth) string {
+	switch entry.Status {
+	case HealthHealthy:
+		return "✅ ok"
+	case HealthMissing:
+		return "❌ missing"
+	default:
+		return "⚠️  " + string(entry.Status)
+	}
+}
+
+// formatSize formats a byte count in human-readable units
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
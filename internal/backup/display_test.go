@@ -0,0 +1,59 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupPickerDisplay_Render(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+
+	healthy := createTestBackup(t, manager, filepath.Join(dir, "state.json"), `{"a":1}`)
+	missing := createTestBackup(t, manager, filepath.Join(dir, "other.json"), `{"a":2}`)
+	require.NoError(t, os.Remove(missing.BackupFile))
+
+	picker := NewBackupPickerDisplay([]*BackupHealth{
+		manager.CheckBackupHealth(healthy),
+		manager.CheckBackupHealth(missing),
+	})
+	header, rows := picker.Render()
+
+	assert.Equal(t, 2, picker.Len())
+	assert.Equal(t, healthy.ID, picker.Backup(0).ID)
+	assert.True(t, strings.HasPrefix(header, "CREATED"))
+	require.Len(t, rows, 2)
+	assert.Contains(t, rows[0], healthy.ID)
+	assert.Contains(t, rows[0], "✅ ok")
+	assert.Contains(t, rows[1], "❌ missing")
+	// Columns are aligned: the IDs start at the same offset as the header
+	assert.Equal(t, strings.Index(header, "ID"), strings.Index(rows[0], healthy.ID))
+}
+
+func TestManager_RecoverFromBackupPreviewListsChanges(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+
+	source := filepath.Join(dir, "state.json")
+	saved := createTestBackup(t, manager, source, `{"a":1,"b":2}`)
+	require.NoError(t, os.WriteFile(source, []byte(`{"a":3}`), 0644))
+
+	result, err := manager.RecoverFromBackup(&RecoveryRequest{
+		SourceFile:  source,
+		BackupID:    saved.ID,
+		RestoreMode: RestoreModePreview,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	assert.Contains(t, result.Changes, "2 field(s) would change:")
+	assert.Contains(t, result.Changes, "  replace /a")
+	assert.Contains(t, result.Changes, "  add /b")
+
+	// The preview does not touch the file
+	content, err := os.ReadFile(source)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":3}`, string(content))
+}
@@ -0,0 +1,114 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HealthStatus represents the outcome of a backup health check
+type HealthStatus string
+
+const (
+	HealthHealthy          HealthStatus = "healthy"           // Backup exists and is intact
+	HealthChecksumMismatch HealthStatus = "checksum_mismatch" // Backup file does not match its checksum
+	HealthCorrupted        HealthStatus = "corrupted"         // Backup content cannot be read or parsed
+	HealthMissing          HealthStatus = "missing"           // Backup file no longer exists
+)
+
+// BackupHealth is the health of a single backup
+type BackupHealth struct {
+	Backup  *BackupMetadata `json:"backup"`
+	Status  HealthStatus    `json:"status"`
+	Message string          `json:"message,omitempty"`
+}
+
+// IsHealthy returns true if the backup passed all checks
+func (bh *BackupHealth) IsHealthy() bool {
+	return bh.Status == HealthHealthy
+}
+
+// HealthReport summarizes a health check over several backups
+type HealthReport struct {
+	Results []*BackupHealth `json:"results"`
+	Skipped int             `json:"skipped"` // Backups older than the retention period that were not checked
+}
+
+// Unhealthy returns the number of backups that failed a check
+func (hr *HealthReport) Unhealthy() int {
+	count := 0
+	for _, result := range hr.Results {
+		if !result.IsHealthy() {
+			count++
+		}
+	}
+	return count
+}
+
+// CheckHealth checks the backups matching the filter. Unless verifyAll is set,
+// backups older than the retention period are skipped.
+func (m *Manager) CheckHealth(filter *BackupFilter, verifyAll bool) (*HealthReport, error) {
+	backups, err := m.ListBackups(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &HealthReport{Results: make([]*BackupHealth, 0, len(backups))}
+	for _, backup := range backups {
+		if !verifyAll && backup.Age() > m.retention.MaxAge {
+			report.Skipped++
+			continue
+		}
+		report.Results = append(report.Results, m.CheckBackupHealth(backup))
+	}
+
+	return report, nil
+}
+
+// CheckBackupHealth checks that a backup file exists, matches its stored
+// checksum and that its content can be read back (decrypted, decompressed and,
+// for JSON sources, parsed)
+func (m *Manager) CheckBackupHealth(backup *BackupMetadata) *BackupHealth {
+	health := &BackupHealth{Backup: backup, Status: HealthHealthy}
+
+	if _, err := os.Stat(backup.BackupFile); err != nil {
+		health.Status = HealthMissing
+		health.Message = fmt.Sprintf("backup file not found: %s", backup.BackupFile)
+		return health
+	}
+
+	checksum, _, err := m.calculateFileInfo(backup.BackupFile)
+	if err != nil {
+		health.Status = HealthCorrupted
+		health.Message = fmt.Sprintf("failed to read backup file: %v", err)
+		return health
+	}
+	if checksum != backup.BackupChecksum {
+		health.Status = HealthChecksumMismatch
+		health.Message = fmt.Sprintf("expected %s, got %s", shortChecksum(backup.BackupChecksum), shortChecksum(checksum))
+		return health
+	}
+
+	content, err := m.ReadBackupContent(backup)
+	if err != nil {
+		health.Status = HealthCorrupted
+		health.Message = err.Error()
+		return health
+	}
+
+	if strings.EqualFold(filepath.Ext(backup.SourceFile), ".json") && !json.Valid(content) {
+		health.Status = HealthCorrupted
+		health.Message = "backup content is not valid JSON"
+	}
+
+	return health
+}
+
+func shortChecksum(checksum string) string {
+	if len(checksum) > 12 {
+		return checksum[:12]
+	}
+	return checksum
+}
@@ -0,0 +1,59 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestBackup(t *testing.T, manager *Manager, source, content string) *BackupMetadata {
+	t.Helper()
+	require.NoError(t, os.WriteFile(source, []byte(content), 0644))
+
+	result, err := manager.CreateBackup(&BackupRequest{SourceFile: source, Type: BackupTypeManual, Force: true})
+	require.NoError(t, err)
+	require.True(t, result.Success, "backup failed: %v", result.Error)
+	return result.Metadata
+}
+
+func TestManager_CheckBackupHealth(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+
+	healthy := createTestBackup(t, manager, filepath.Join(dir, "healthy.json"), `{"ok":true}`)
+	assert.Equal(t, HealthHealthy, manager.CheckBackupHealth(healthy).Status)
+
+	tampered := createTestBackup(t, manager, filepath.Join(dir, "tampered.json"), `{"ok":true}`)
+	require.NoError(t, os.WriteFile(tampered.BackupFile, []byte(`{"ok":false}`), 0644))
+	assert.Equal(t, HealthChecksumMismatch, manager.CheckBackupHealth(tampered).Status)
+
+	missing := createTestBackup(t, manager, filepath.Join(dir, "missing.json"), `{"ok":true}`)
+	require.NoError(t, os.Remove(missing.BackupFile))
+	assert.Equal(t, HealthMissing, manager.CheckBackupHealth(missing).Status)
+
+	invalid := createTestBackup(t, manager, filepath.Join(dir, "invalid.json"), `{"ok":`)
+	assert.Equal(t, HealthCorrupted, manager.CheckBackupHealth(invalid).Status)
+}
+
+func TestManager_CheckHealthSkipsOldBackups(t *testing.T) {
+	manager, dir := newTestManager(t, nil)
+
+	recent := createTestBackup(t, manager, filepath.Join(dir, "recent.json"), `{}`)
+	old := createTestBackup(t, manager, filepath.Join(dir, "old.json"), `{}`)
+	old.CreatedAt = time.Now().Add(-2 * manager.retention.MaxAge)
+
+	report, err := manager.CheckHealth(nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Skipped)
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, recent.ID, report.Results[0].Backup.ID)
+
+	report, err = manager.CheckHealth(nil, true)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Skipped)
+	assert.Len(t, report.Results, 2)
+	assert.Equal(t, 0, report.Unhealthy())
+}
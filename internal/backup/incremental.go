@@ -0,0 +1,253 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// prepareIncremental computes the JSON diff between the latest backup of
+// sourceFile and its current content. It returns a nil parent when an
+// incremental backup is not possible (no previous backup, non-JSON content or
+// an unreadable chain), in which case a full backup should be taken instead.
+func (m *Manager) prepareIncremental(sourceFile string) (*BackupMetadata, []byte) {
+	parent, err := m.getLatestBackup(sourceFile)
+	if err != nil {
+		return nil, nil
+	}
+
+	base, err := m.ReadBackupContent(parent)
+	if err != nil {
+		return nil, nil
+	}
+
+	current, err := os.ReadFile(sourceFile)
+	if err != nil {
+		return nil, nil
+	}
+
+	ops, err := ComputeJSONDiff(base, current)
+	if err != nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return nil, nil
+	}
+
+	return parent, data
+}
+
+// backupChain returns the chain of backups needed to rebuild backup, starting
+// with the nearest full backup and ending with backup itself
+func (m *Manager) backupChain(backup *BackupMetadata) ([]*BackupMetadata, error) {
+	chain := []*BackupMetadata{backup}
+	visited := map[string]bool{backup.ID: true}
+
+	current := backup
+	for current.IsIncremental {
+		parent, err := m.GetBackup(current.ParentBackupID)
+		if err != nil {
+			return nil, fmt.Errorf("incremental chain of %s is broken: %w", backup.ID, err)
+		}
+		if visited[parent.ID] {
+			return nil, fmt.Errorf("incremental chain of %s contains a cycle at %s", backup.ID, parent.ID)
+		}
+		visited[parent.ID] = true
+
+		chain = append([]*BackupMetadata{parent}, chain...)
+		current = parent
+	}
+
+	return chain, nil
+}
+
+// ReadBackupContent returns the original content captured by a backup. For
+// incremental backups the chain is walked back to the nearest full backup and
+// the diffs are re-applied in order.
+func (m *Manager) ReadBackupContent(backup *BackupMetadata) ([]byte, error) {
+	chain, err := m.backupChain(backup)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := m.readBackupFile(chain[0].BackupFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup %s: %w", chain[0].ID, err)
+	}
+
+	for _, incremental := range chain[1:] {
+		data, err := m.readBackupFile(incremental.BackupFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup %s: %w", incremental.ID, err)
+		}
+
+		var ops []DiffOp
+		if err := json.Unmarshal(data, &ops); err != nil {
+			return nil, fmt.Errorf("invalid diff in backup %s: %w", incremental.ID, err)
+		}
+
+		if content, err = ApplyDiff(content, ops); err != nil {
+			return nil, fmt.Errorf("failed to apply backup %s: %w", incremental.ID, err)
+		}
+	}
+
+	return content, nil
+}
+
+func (m *Manager) readBackupFile(backupFile string) ([]byte, error) {
+	reader, err := m.openBackupReader(backupFile)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// restoreBackup writes the content of backup to targetFile
+func (m *Manager) restoreBackup(backup *BackupMetadata, targetFile string) error {
+	if !backup.IsIncremental {
+		return m.performRestore(backup.BackupFile, targetFile, backup.Compressed)
+	}
+
+	content, err := m.ReadBackupContent(backup)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(targetFile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(targetFile, content, 0644)
+}
+
+// hasDependents reports whether an incremental backup uses backupID as its parent.
+// Callers must hold m.mu.
+func (m *Manager) hasDependents(backupID string) bool {
+	for _, backup := range m.backups {
+		if backup.IsIncremental && backup.ParentBackupID == backupID {
+			return true
+		}
+	}
+	return false
+}
+
+// ConsolidateBackups squashes the incremental chain ending at the latest backup
+// of sourceFile into a single full backup and removes the chain
+func (m *Manager) ConsolidateBackups(sourceFile string) (*BackupMetadata, error) {
+	latest, err := m.getLatestBackup(sourceFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if !latest.IsIncremental {
+		return latest, nil
+	}
+
+	chain, err := m.backupChain(latest)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := m.ReadBackupContent(latest)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	backupID := m.generateBackupID(sourceFile)
+	hash := sha256.Sum256(content)
+	backupFile, err := m.generateBackupPath(sourceFile, backupID, latest.Compressed)
+	if err != nil {
+		return nil, err
+	}
+
+	consolidated := &BackupMetadata{
+		ID:             backupID,
+		SourceFile:     sourceFile,
+		BackupFile:     backupFile,
+		Type:           latest.Type,
+		Reason:         latest.Reason,
+		Status:         BackupStatusCompleted,
+		CreatedAt:      latest.CreatedAt,
+		SourceSize:     int64(len(content)),
+		SourceChecksum: hex.EncodeToString(hash[:]),
+		Compressed:     latest.Compressed,
+		Encrypted:      m.config.Encrypt,
+		Tags:           append(append([]string{}, latest.Tags...), "consolidated"),
+		CreatedBy:      "claude-wm-cli",
+		Version:        "1.0",
+	}
+
+	checksum, size, err := m.writeBackup(bytes.NewReader(content), consolidated.BackupFile, consolidated.Compressed)
+	if err != nil {
+		os.Remove(consolidated.BackupFile)
+		return nil, fmt.Errorf("failed to write consolidated backup: %w", err)
+	}
+
+	completedAt := time.Now()
+	consolidated.BackupChecksum = checksum
+	consolidated.BackupSize = size
+	consolidated.CompletedAt = &completedAt
+	consolidated.Duration = completedAt.Sub(startTime)
+
+	m.mu.Lock()
+	m.backups[backupID] = consolidated
+	m.updateStats(consolidated, true)
+	m.mu.Unlock()
+
+	// Remove the chain from the newest backup down so no parent is removed
+	// while an incremental backup still depends on it
+	for i := len(chain) - 1; i >= 0; i-- {
+		if err := m.DeleteBackup(chain[i].ID); err != nil {
+			return consolidated, fmt.Errorf("consolidated backup created but failed to remove %s: %w", chain[i].ID, err)
+		}
+	}
+
+	return consolidated, nil
+}
+
+// excludeChainParents removes from toRemove every backup that is still needed
+// to rebuild a backup that is kept
+func excludeChainParents(backups, toRemove []*BackupMetadata) []*BackupMetadata {
+	removing := make(map[string]bool, len(toRemove))
+	for _, backup := range toRemove {
+		removing[backup.ID] = true
+	}
+
+	byID := make(map[string]*BackupMetadata, len(backups))
+	for _, backup := range backups {
+		byID[backup.ID] = backup
+	}
+
+	needed := make(map[string]bool)
+	for _, backup := range backups {
+		if removing[backup.ID] {
+			continue
+		}
+		for current := backup; current.IsIncremental; {
+			parent, exists := byID[current.ParentBackupID]
+			if !exists || needed[parent.ID] {
+				break
+			}
+			needed[parent.ID] = true
+			current = parent
+		}
+	}
+
+	kept := toRemove[:0]
+	for _, backup := range toRemove {
+		if !needed[backup.ID] {
+			kept = append(kept, backup)
+		}
+	}
+	return kept
+}
@@ -1,6 +1,7 @@
 package backup
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -10,6 +11,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -110,11 +112,22 @@ func (m *Manager) CreateBackup(request *BackupRequest) (*BackupResult, error) {
 		Timestamp:  startTime,
 	})
 
+	backupFile, err := m.generateBackupPath(request.SourceFile, backupID, request.Compress)
+	if err != nil {
+		m.emitFailureEvent(request.SourceFile, backupID, err)
+		return &BackupResult{
+			Success:   false,
+			Error:     err,
+			Duration:  time.Since(startTime),
+			Timestamp: time.Now(),
+		}, nil
+	}
+
 	// Create backup metadata
 	metadata := &BackupMetadata{
 		ID:         backupID,
 		SourceFile: request.SourceFile,
-		BackupFile: m.generateBackupPath(request.SourceFile, backupID),
+		BackupFile: backupFile,
 		Type:       request.Type,
 		Reason:     request.Reason,
 		Status:     BackupStatusCreating,
@@ -123,6 +136,7 @@ func (m *Manager) CreateBackup(request *BackupRequest) (*BackupResult, error) {
 		CreatedBy:  "claude-wm-cli",
 		Version:    "1.0",
 		Compressed: request.Compress,
+		Encrypted:  m.config.Encrypt,
 	}
 
 	// Calculate source file checksum and size
@@ -140,8 +154,25 @@ func (m *Manager) CreateBackup(request *BackupRequest) (*BackupResult, error) {
 	metadata.SourceChecksum = sourceChecksum
 	metadata.SourceSize = sourceSize
 
+	// Incremental backups store a JSON diff against the previous backup of the file,
+	// falling back to a full backup when no usable previous backup exists
+	var incrementalData []byte
+	if request.Type == BackupTypeIncremental {
+		if parent, diff := m.prepareIncremental(request.SourceFile); parent != nil {
+			metadata.IsIncremental = true
+			metadata.ParentBackupID = parent.ID
+			incrementalData = diff
+		}
+	}
+
 	// Perform the actual backup
-	backupChecksum, backupSize, err := m.performBackup(request.SourceFile, metadata.BackupFile, request.Compress)
+	var backupChecksum string
+	var backupSize int64
+	if metadata.IsIncremental {
+		backupChecksum, backupSize, err = m.writeBackup(bytes.NewReader(incrementalData), metadata.BackupFile, request.Compress)
+	} else {
+		backupChecksum, backupSize, err = m.performBackup(request.SourceFile, metadata.BackupFile, request.Compress)
+	}
 	if err != nil {
 		// Clean up partial backup file
 		os.Remove(metadata.BackupFile)
@@ -330,7 +361,7 @@ func (m *Manager) RecoverFromBackup(request *RecoveryRequest) (*RecoveryResult,
 	// Handle restore mode
 	switch request.RestoreMode {
 	case RestoreModeReplace:
-		err = m.performRestore(backup.BackupFile, restorePath, backup.Compressed)
+		err = m.restoreBackup(backup, restorePath)
 		if err == nil {
 			result.Changes = append(result.Changes, "Replaced existing file")
 		}
@@ -344,7 +375,7 @@ func (m *Manager) RecoverFromBackup(request *RecoveryRequest) (*RecoveryResult,
 				result.Changes = append(result.Changes, fmt.Sprintf("Renamed existing file to %s", renamedPath))
 			}
 		}
-		err = m.performRestore(backup.BackupFile, restorePath, backup.Compressed)
+		err = m.restoreBackup(backup, restorePath)
 		if err == nil {
 			result.Changes = append(result.Changes, "Restored from backup")
 		}
@@ -353,6 +384,7 @@ func (m *Manager) RecoverFromBackup(request *RecoveryRequest) (*RecoveryResult,
 		result.Success = true
 		result.RestoredFile = restorePath
 		result.Changes = append(result.Changes, fmt.Sprintf("Would restore from backup %s to %s", backup.ID, restorePath))
+		result.Changes = append(result.Changes, m.previewRestore(backup, restorePath)...)
 		result.Duration = time.Since(startTime)
 		result.Timestamp = time.Now()
 		return result, nil
@@ -392,8 +424,11 @@ func (m *Manager) RecoverFromBackup(request *RecoveryRequest) (*RecoveryResult,
 	result.Duration = time.Since(startTime)
 	result.Timestamp = time.Now()
 
-	// Verify restored file if requested
-	if request.VerifyAfter {
+	// Verify restored file if requested. Incremental backups are rebuilt from
+	// diffs, so the restored JSON is equivalent but not byte-identical.
+	if request.VerifyAfter && backup.IsIncremental {
+		result.Changes = append(result.Changes, "Rebuilt file from incremental backup chain")
+	} else if request.VerifyAfter {
 		if restoredChecksum, _, err := m.calculateFileInfo(restorePath); err != nil {
 			result.Warnings = append(result.Warnings, fmt.Sprintf("Failed to verify restored file: %v", err))
 		} else if restoredChecksum == backup.SourceChecksum {
@@ -417,6 +452,51 @@ func (m *Manager) RecoverFromBackup(request *RecoveryRequest) (*RecoveryResult,
 	return result, nil
 }
 
+// maxPreviewChanges limits the JSON field changes listed by a restore preview
+const maxPreviewChanges = 20
+
+// previewRestore describes how restoring backup would change restorePath: the
+// changed fields for JSON files, the size change otherwise
+func (m *Manager) previewRestore(backup *BackupMetadata, restorePath string) []string {
+	restored, err := m.ReadBackupContent(backup)
+	if err != nil {
+		return []string{fmt.Sprintf("Could not read backup content: %v", err)}
+	}
+
+	current, err := os.ReadFile(restorePath)
+	if os.IsNotExist(err) {
+		return []string{fmt.Sprintf("Would create the file (%d bytes)", len(restored))}
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("Could not read current file: %v", err)}
+	}
+	if bytes.Equal(current, restored) {
+		return []string{"Current file already matches the backup"}
+	}
+
+	ops, err := ComputeJSONDiff(current, restored)
+	if err != nil {
+		return []string{fmt.Sprintf("Would replace the current file (%d bytes) with the backup (%d bytes)", len(current), len(restored))}
+	}
+	if len(ops) == 0 {
+		return []string{"Same JSON content, only formatting differs"}
+	}
+
+	changes := []string{fmt.Sprintf("%d field(s) would change:", len(ops))}
+	for i, op := range ops {
+		if i == maxPreviewChanges {
+			changes = append(changes, fmt.Sprintf("  ... and %d more", len(ops)-maxPreviewChanges))
+			break
+		}
+		path := op.Path
+		if path == "" {
+			path = "/"
+		}
+		changes = append(changes, fmt.Sprintf("  %s %s", op.Op, path))
+	}
+	return changes
+}
+
 // GetBackup retrieves backup metadata by ID
 func (m *Manager) GetBackup(backupID string) (*BackupMetadata, error) {
 	m.mu.RLock()
@@ -471,6 +551,10 @@ func (m *Manager) DeleteBackup(backupID string) error {
 		return fmt.Errorf("backup %s not found", backupID)
 	}
 
+	if m.hasDependents(backupID) {
+		return fmt.Errorf("backup %s is the parent of an incremental backup; consolidate the chain first", backupID)
+	}
+
 	// Remove backup file
 	if err := os.Remove(backup.BackupFile); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove backup file: %w", err)
@@ -575,11 +659,21 @@ func (m *Manager) generateBackupID(sourceFile string) string {
 	return fmt.Sprintf("backup-%s", hex.EncodeToString(hash[:8]))
 }
 
-func (m *Manager) generateBackupPath(sourceFile, backupID string) string {
+func (m *Manager) generateBackupPath(sourceFile, backupID string, compress bool) (string, error) {
 	fileName := filepath.Base(sourceFile)
 	timestamp := time.Now().Format("20060102-150405")
-	backupFileName := fmt.Sprintf("%s.%s.%s.backup", fileName, timestamp, backupID[:8])
-	return filepath.Join(m.backupDir, backupFileName)
+	backupFileName := fmt.Sprintf("%s.%s.%s.backup", fileName, timestamp, strings.TrimPrefix(backupID, "backup-"))
+	if compress {
+		backupFileName += CompressedSuffix
+	}
+	if m.config.Encrypt {
+		suffix, err := encryptedPathSuffix()
+		if err != nil {
+			return "", err
+		}
+		backupFileName += suffix
+	}
+	return filepath.Join(m.backupDir, backupFileName), nil
 }
 
 func (m *Manager) calculateFileInfo(filePath string) (checksum string, size int64, err error) {
@@ -600,39 +694,39 @@ func (m *Manager) calculateFileInfo(filePath string) (checksum string, size int6
 }
 
 func (m *Manager) performBackup(sourceFile, backupFile string, compress bool) (checksum string, size int64, err error) {
-	// For now, implement simple file copy (compression can be added later)
 	source, err := os.Open(sourceFile)
 	if err != nil {
 		return "", 0, err
 	}
 	defer source.Close()
 
+	return m.writeBackup(source, backupFile, compress)
+}
+
+// writeBackup stores the data read from source in backupFile, compressing and
+// encrypting it as configured
+func (m *Manager) writeBackup(source io.Reader, backupFile string, compress bool) (checksum string, size int64, err error) {
 	// Ensure backup directory exists
 	if err := os.MkdirAll(filepath.Dir(backupFile), 0755); err != nil {
 		return "", 0, err
 	}
 
-	dest, err := os.Create(backupFile)
-	if err != nil {
-		return "", 0, err
+	if m.config.Encrypt {
+		return m.performEncryptedBackup(source, backupFile, compress)
 	}
-	defer dest.Close()
 
-	hash := sha256.New()
-	writer := io.MultiWriter(dest, hash)
-
-	size, err = io.Copy(writer, source)
+	dest, err := os.Create(backupFile)
 	if err != nil {
 		return "", 0, err
 	}
+	defer dest.Close()
 
-	checksum = hex.EncodeToString(hash.Sum(nil))
-	return checksum, size, nil
+	return writeBackupData(dest, source, compress, m.compressionLevel())
 }
 
 func (m *Manager) performRestore(backupFile, targetFile string, compressed bool) error {
-	// For now, implement simple file copy (decompression can be added later)
-	source, err := os.Open(backupFile)
+	// Compressed and encrypted backups are detected from the .gz and .enc extensions
+	source, err := m.openBackupReader(backupFile)
 	if err != nil {
 		return err
 	}
@@ -863,7 +957,8 @@ func (m *Manager) selectBackupsForRemoval(backups []*BackupMetadata) []*BackupMe
 		}
 	}
 
-	return toRemove
+	// Keep backups that incremental backups still depend on
+	return excludeChainParents(backups, toRemove)
 }
 
 func (m *Manager) updateStats(backup *BackupMetadata, isAdd bool) {
@@ -0,0 +1,267 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ScheduleStatusFile records the state of the backup scheduler, in the
+// backup directory
+const ScheduleStatusFile = "schedule.json"
+
+// MinScheduleInterval is the shortest backup schedule accepted
+const MinScheduleInterval = time.Minute
+
+// ScheduledFiles are the state files backed up by the Scheduler, relative to
+// the project root. Tickets are stored in stories.json.
+var ScheduledFiles = []string{
+	"docs/1-project/epics.json",
+	"docs/2-current-epic/current-epic.json",
+	"docs/2-current-epic/stories.json",
+	"docs/2-current-epic/current-story.json",
+	"docs/3-current-task/current-task.json",
+}
+
+// ParseSchedule parses the backup.schedule setting, a duration such as "1h"
+// or "30m". It returns 0 when the schedule is empty or "off".
+func ParseSchedule(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.EqualFold(value, "off") {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid backup schedule %q: %w", value, err)
+	}
+	if interval < MinScheduleInterval {
+		return 0, fmt.Errorf("invalid backup schedule %q: must be at least %s", value, MinScheduleInterval)
+	}
+	return interval, nil
+}
+
+// ScheduleRun is the outcome of a scheduled backup of the state files
+type ScheduleRun struct {
+	StartedAt time.Time         `json:"started_at"`
+	BackedUp  []string          `json:"backed_up"`
+	Skipped   []string          `json:"skipped"`          // Missing, unchanged or backed up moments ago
+	Failed    map[string]string `json:"failed,omitempty"` // Path -> error
+}
+
+// ScheduleStatus is the state of the backup scheduler of a project, saved to
+// ScheduleStatusFile so that other processes can report it
+type ScheduleStatus struct {
+	Interval  time.Duration `json:"interval"`
+	Active    bool          `json:"active"` // Whether a session is running the scheduler
+	PID       int           `json:"pid"`
+	StartedAt time.Time     `json:"started_at"`
+	StoppedAt *time.Time    `json:"stopped_at,omitempty"`
+	NextRun   *time.Time    `json:"next_run,omitempty"`
+	LastRun   *ScheduleRun  `json:"last_run,omitempty"`
+}
+
+// Running reports whether the scheduler is running at now: it is active and
+// its next run isn't overdue, which would mean its process died
+func (s *ScheduleStatus) Running(now time.Time) bool {
+	return s.Active && s.NextRun != nil && now.Before(s.NextRun.Add(MinScheduleInterval))
+}
+
+// LoadScheduleStatus reads the scheduler status from backupDir, nil when no
+// scheduler ever ran
+func LoadScheduleStatus(backupDir string) (*ScheduleStatus, error) {
+	data, err := os.ReadFile(filepath.Join(backupDir, ScheduleStatusFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup schedule status: %w", err)
+	}
+
+	var status ScheduleStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ScheduleStatusFile, err)
+	}
+	return &status, nil
+}
+
+// Scheduler periodically backs up the state files of a project in the
+// background, skipping the files that didn't change since their last backup
+// or were backed up moments ago
+type Scheduler struct {
+	root     string
+	interval time.Duration
+	config   BackupConfig
+
+	mu     sync.Mutex
+	status ScheduleStatus
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewScheduler returns a scheduler backing up the state files of the project
+// at root every interval, with config (DefaultBackupConfig when nil). A
+// relative backup directory is relative to root.
+func NewScheduler(root string, interval time.Duration, config *BackupConfig) *Scheduler {
+	if config == nil {
+		config = DefaultBackupConfig()
+	}
+	s := &Scheduler{root: root, interval: interval, config: *config}
+	if !filepath.IsAbs(s.config.BackupDirectory) {
+		s.config.BackupDirectory = filepath.Join(root, s.config.BackupDirectory)
+	}
+	return s
+}
+
+// Start backs up the state files now, then every interval until Stop
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	s.status = ScheduleStatus{Interval: s.interval, Active: true, PID: os.Getpid(), StartedAt: time.Now()}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	stop, done := s.stop, s.done
+	s.mu.Unlock()
+
+	go s.loop(stop, done)
+}
+
+func (s *Scheduler) loop(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.run()
+	for {
+		select {
+		case <-ticker.C:
+			s.run()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// run backs up the state files and records the run in the status file
+func (s *Scheduler) run() {
+	run := s.RunOnce()
+	next := time.Now().Add(s.interval)
+
+	s.mu.Lock()
+	s.status.LastRun = run
+	s.status.NextRun = &next
+	s.mu.Unlock()
+	s.saveStatus()
+}
+
+// Stop stops the scheduler, waiting for a backup in progress to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop = nil
+	s.mu.Unlock()
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+
+	now := time.Now()
+	s.mu.Lock()
+	s.status.Active = false
+	s.status.StoppedAt = &now
+	s.status.NextRun = nil
+	s.mu.Unlock()
+	s.saveStatus()
+}
+
+// Status returns the current state of the scheduler
+func (s *Scheduler) Status() ScheduleStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// RunOnce backs up the state files that exist and changed since their last
+// backup. A new manager is used for each run so that backups made meanwhile
+// by other managers are not overwritten.
+func (s *Scheduler) RunOnce() *ScheduleRun {
+	run := &ScheduleRun{StartedAt: time.Now(), BackedUp: []string{}, Skipped: []string{}}
+	fail := func(path string, err error) {
+		if run.Failed == nil {
+			run.Failed = make(map[string]string)
+		}
+		run.Failed[path] = err.Error()
+	}
+
+	config := s.config
+	manager, err := NewManager(&config)
+	if err != nil {
+		for _, path := range ScheduledFiles {
+			fail(path, err)
+		}
+		return run
+	}
+
+	for _, path := range ScheduledFiles {
+		sourceFile := filepath.Join(s.root, path)
+		if _, err := os.Stat(sourceFile); err != nil {
+			run.Skipped = append(run.Skipped, path)
+			continue
+		}
+		if manager.unchangedSinceBackup(sourceFile) {
+			run.Skipped = append(run.Skipped, path)
+			continue
+		}
+
+		result, err := manager.CreateBackup(&BackupRequest{
+			SourceFile:  sourceFile,
+			Type:        BackupTypeSnapshot,
+			Reason:      ReasonScheduled,
+			Description: fmt.Sprintf("Scheduled backup of %s", filepath.Base(path)),
+			Compress:    true,
+		})
+		switch {
+		case err != nil:
+			fail(path, err)
+		case result.Error != nil:
+			fail(path, result.Error)
+		case result.Skipped:
+			run.Skipped = append(run.Skipped, path)
+		default:
+			run.BackedUp = append(run.BackedUp, path)
+		}
+	}
+	return run
+}
+
+// unchangedSinceBackup reports whether sourceFile has the checksum of its
+// latest backup
+func (m *Manager) unchangedSinceBackup(sourceFile string) bool {
+	latest, err := m.getLatestBackup(sourceFile)
+	if err != nil || latest == nil {
+		return false
+	}
+	checksum, _, err := m.calculateFileInfo(sourceFile)
+	return err == nil && checksum == latest.SourceChecksum
+}
+
+// saveStatus writes the scheduler status to the backup directory
+func (s *Scheduler) saveStatus() {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s.status, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(s.config.BackupDirectory, 0755); err != nil {
+		return
+	}
+	os.WriteFile(filepath.Join(s.config.BackupDirectory, ScheduleStatusFile), data, 0644)
+}
@@ -0,0 +1,87 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	for value, want := range map[string]time.Duration{"": 0, "off": 0, "1h": time.Hour, " 30m ": 30 * time.Minute} {
+		interval, err := ParseSchedule(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, interval, value)
+	}
+
+	_, err := ParseSchedule("hourly")
+	assert.ErrorContains(t, err, "invalid backup schedule")
+	_, err = ParseSchedule("10s")
+	assert.ErrorContains(t, err, "must be at least 1m0s")
+}
+
+func TestScheduler_RunOnce(t *testing.T) {
+	projectPath := t.TempDir()
+	epicsPath := filepath.Join(projectPath, "docs", "1-project", "epics.json")
+	storiesPath := filepath.Join(projectPath, "docs", "2-current-epic", "stories.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(epicsPath), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(storiesPath), 0755))
+	require.NoError(t, os.WriteFile(epicsPath, []byte(`{"v":1}`), 0644))
+	require.NoError(t, os.WriteFile(storiesPath, []byte(`{"v":1}`), 0644))
+
+	scheduler := NewScheduler(projectPath, time.Hour, nil)
+	run := scheduler.RunOnce()
+	assert.Equal(t, []string{"docs/1-project/epics.json", "docs/2-current-epic/stories.json"}, run.BackedUp)
+	assert.Len(t, run.Skipped, len(ScheduledFiles)-2)
+	assert.Empty(t, run.Failed)
+
+	backups := autoBackups(t, projectPath)
+	require.Len(t, backups, 2)
+	assert.Equal(t, BackupTypeSnapshot, backups[0].Type)
+	assert.Equal(t, ReasonScheduled, backups[0].Reason)
+
+	// Unchanged files are skipped, and so are files backed up moments ago
+	require.NoError(t, os.WriteFile(epicsPath, []byte(`{"v":2}`), 0644))
+	run = scheduler.RunOnce()
+	assert.Empty(t, run.BackedUp)
+	assert.Contains(t, run.Skipped, "docs/1-project/epics.json")
+	assert.Len(t, autoBackups(t, projectPath), 2)
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	projectPath := t.TempDir()
+	epicsPath := filepath.Join(projectPath, "docs", "1-project", "epics.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(epicsPath), 0755))
+	require.NoError(t, os.WriteFile(epicsPath, []byte(`{"v":1}`), 0644))
+	backupDir := filepath.Join(projectPath, DefaultBackupConfig().BackupDirectory)
+
+	status, err := LoadScheduleStatus(backupDir)
+	require.NoError(t, err)
+	assert.Nil(t, status)
+
+	scheduler := NewScheduler(projectPath, time.Hour, nil)
+	scheduler.Start()
+	require.Eventually(t, func() bool {
+		status, err := LoadScheduleStatus(backupDir)
+		return err == nil && status != nil && status.LastRun != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	status, err = LoadScheduleStatus(backupDir)
+	require.NoError(t, err)
+	assert.True(t, status.Running(time.Now()))
+	assert.Equal(t, time.Hour, status.Interval)
+	assert.Equal(t, os.Getpid(), status.PID)
+	assert.Equal(t, []string{"docs/1-project/epics.json"}, status.LastRun.BackedUp)
+	assert.False(t, status.Running(status.NextRun.Add(2*MinScheduleInterval)))
+
+	scheduler.Stop()
+	scheduler.Stop()
+	status, err = LoadScheduleStatus(backupDir)
+	require.NoError(t, err)
+	assert.False(t, status.Running(time.Now()))
+	assert.NotNil(t, status.StoppedAt)
+	assert.Equal(t, []string{"docs/1-project/epics.json"}, status.LastRun.BackedUp)
+}
@@ -9,10 +9,11 @@ import (
 type BackupType string
 
 const (
-	BackupTypeAutomatic BackupType = "automatic" // Automatic backup before state changes
-	BackupTypeManual    BackupType = "manual"    // Manual backup requested by user
-	BackupTypeEmergency BackupType = "emergency" // Emergency backup due to corruption
-	BackupTypeSnapshot  BackupType = "snapshot"  // Periodic snapshot backup
+	BackupTypeAutomatic   BackupType = "automatic"   // Automatic backup before state changes
+	BackupTypeManual      BackupType = "manual"      // Manual backup requested by user
+	BackupTypeEmergency   BackupType = "emergency"   // Emergency backup due to corruption
+	BackupTypeSnapshot    BackupType = "snapshot"    // Periodic snapshot backup
+	BackupTypeIncremental BackupType = "incremental" // JSON diff against the previous backup
 )
 
 func (bt BackupType) String() string {
@@ -52,25 +53,28 @@ func (bs BackupStatus) String() string {
 
 // BackupMetadata contains information about a backup
 type BackupMetadata struct {
-	ID             string        `json:"id"`              // Unique backup identifier
-	SourceFile     string        `json:"source_file"`     // Original file path
-	BackupFile     string        `json:"backup_file"`     // Backup file path
-	Type           BackupType    `json:"type"`            // Type of backup
-	Reason         BackupReason  `json:"reason"`          // Why backup was created
-	Status         BackupStatus  `json:"status"`          // Current status
-	CreatedAt      time.Time     `json:"created_at"`      // When backup was created
-	CompletedAt    *time.Time    `json:"completed_at"`    // When backup completed
-	Duration       time.Duration `json:"duration"`        // Time taken to create backup
-	SourceSize     int64         `json:"source_size"`     // Original file size
-	BackupSize     int64         `json:"backup_size"`     // Backup file size
-	Compressed     bool          `json:"compressed"`      // Whether backup is compressed
-	SourceChecksum string        `json:"source_checksum"` // Original file checksum
-	BackupChecksum string        `json:"backup_checksum"` // Backup file checksum
-	IntegrityCheck bool          `json:"integrity_check"` // Whether integrity was verified
-	ErrorMessage   string        `json:"error_message"`   // Error message if failed
-	Tags           []string      `json:"tags"`            // Additional tags
-	CreatedBy      string        `json:"created_by"`      // Process/user that created backup
-	Version        string        `json:"version"`         // Backup format version
+	ID             string        `json:"id"`               // Unique backup identifier
+	SourceFile     string        `json:"source_file"`      // Original file path
+	BackupFile     string        `json:"backup_file"`      // Backup file path
+	Type           BackupType    `json:"type"`             // Type of backup
+	Reason         BackupReason  `json:"reason"`           // Why backup was created
+	Status         BackupStatus  `json:"status"`           // Current status
+	CreatedAt      time.Time     `json:"created_at"`       // When backup was created
+	CompletedAt    *time.Time    `json:"completed_at"`     // When backup completed
+	Duration       time.Duration `json:"duration"`         // Time taken to create backup
+	SourceSize     int64         `json:"source_size"`      // Original file size
+	BackupSize     int64         `json:"backup_size"`      // Backup file size
+	Compressed     bool          `json:"compressed"`       // Whether backup is compressed
+	Encrypted      bool          `json:"encrypted"`        // Whether backup is encrypted
+	IsIncremental  bool          `json:"is_incremental"`   // Whether backup stores a diff against its parent
+	ParentBackupID string        `json:"parent_backup_id"` // Backup the diff applies to (incremental only)
+	SourceChecksum string        `json:"source_checksum"`  // Original file checksum
+	BackupChecksum string        `json:"backup_checksum"`  // Backup file checksum
+	IntegrityCheck bool          `json:"integrity_check"`  // Whether integrity was verified
+	ErrorMessage   string        `json:"error_message"`    // Error message if failed
+	Tags           []string      `json:"tags"`             // Additional tags
+	CreatedBy      string        `json:"created_by"`       // Process/user that created backup
+	Version        string        `json:"version"`          // Backup format version
 }
 
 // IsValid checks if the backup metadata is valid
@@ -84,6 +88,14 @@ func (bm *BackupMetadata) Age() time.Duration {
 	return time.Since(bm.CreatedAt)
 }
 
+// CompressionRatio returns the backup size relative to the source size (1.0 when uncompressed)
+func (bm *BackupMetadata) CompressionRatio() float64 {
+	if bm.SourceSize == 0 {
+		return 1.0
+	}
+	return float64(bm.BackupSize) / float64(bm.SourceSize)
+}
+
 // IsCompleted returns true if backup completed successfully
 func (bm *BackupMetadata) IsCompleted() bool {
 	return bm.Status == BackupStatusCompleted || bm.Status == BackupStatusVerified
@@ -96,13 +108,17 @@ type BackupConfig struct {
 	MaxBackups       int           `json:"max_backups"`       // Maximum backups per file
 	MaxAge           time.Duration `json:"max_age"`           // Maximum age of backups
 	MaxTotalSize     int64         `json:"max_total_size"`    // Maximum total size of all backups
-	CompressionLevel int           `json:"compression_level"` // Compression level (0-9)
+	CompressionLevel int           `json:"compression_level"` // Gzip compression level (1-9)
 	AutoBackup       bool          `json:"auto_backup"`       // Enable automatic backups
+	AutoBackupTypes  []BackupType  `json:"auto_backup_types"` // Backup types made before writes by WriteWithBackup, all when empty
 	VerifyIntegrity  bool          `json:"verify_integrity"`  // Verify backup integrity
 	AsyncBackup      bool          `json:"async_backup"`      // Perform backups asynchronously
 	CleanupInterval  time.Duration `json:"cleanup_interval"`  // How often to clean old backups
 	BackupFormat     string        `json:"backup_format"`     // Backup format (copy, tar, etc.)
 	IncludeMetadata  bool          `json:"include_metadata"`  // Include metadata in backup
+	Encrypt          bool          `json:"encrypt"`           // Encrypt backups with AES-256-GCM
+	KeyFile          string        `json:"key_file"`          // File holding a raw 32-byte key (overrides passphrase)
+	Passphrase       string        `json:"-"`                 // Passphrase for key derivation (never persisted)
 }
 
 // DefaultBackupConfig returns default backup configuration
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DiffOp is how a configuration key differs from its base value
+type DiffOp string
+
+const (
+	DiffAdded     DiffOp = "added"     // Only in the current configuration
+	DiffRemoved   DiffOp = "removed"   // Only in the base configuration
+	DiffChanged   DiffOp = "changed"   // In both, with different values
+	DiffUnchanged DiffOp = "unchanged" // In both, with the same value
+)
+
+// ConfigDiff is a leaf key of two configurations, OldValue being its base
+// value and NewValue its current one
+type ConfigDiff struct {
+	Path     string      `json:"path"` // Dotted key path
+	Op       DiffOp      `json:"op"`
+	OldValue interface{} `json:"old_value,omitempty"`
+	NewValue interface{} `json:"new_value,omitempty"`
+}
+
+// DiffConfigs compares current with base, key by key, recursing into nested
+// objects, and returns every leaf key by path, unchanged ones included.
+// Arrays are compared as a whole.
+func DiffConfigs(base, current map[string]interface{}) []ConfigDiff {
+	var diffs []ConfigDiff
+	diffConfigs(base, current, "", &diffs)
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+func diffConfigs(base, current map[string]interface{}, prefix string, diffs *[]ConfigDiff) {
+	keys := make(map[string]bool, len(base)+len(current))
+	for key := range base {
+		keys[key] = true
+	}
+	for key := range current {
+		keys[key] = true
+	}
+
+	for key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		oldValue, inBase := base[key]
+		newValue, inCurrent := current[key]
+
+		oldMap, oldIsMap := oldValue.(map[string]interface{})
+		newMap, newIsMap := newValue.(map[string]interface{})
+		switch {
+		case oldIsMap && newIsMap:
+			diffConfigs(oldMap, newMap, path, diffs)
+		case oldIsMap && !inCurrent:
+			diffConfigs(oldMap, nil, path, diffs)
+		case newIsMap && !inBase:
+			diffConfigs(nil, newMap, path, diffs)
+		case !inCurrent:
+			*diffs = append(*diffs, ConfigDiff{Path: path, Op: DiffRemoved, OldValue: oldValue})
+		case !inBase:
+			*diffs = append(*diffs, ConfigDiff{Path: path, Op: DiffAdded, NewValue: newValue})
+		case reflect.DeepEqual(oldValue, newValue):
+			*diffs = append(*diffs, ConfigDiff{Path: path, Op: DiffUnchanged, OldValue: oldValue, NewValue: newValue})
+		default:
+			*diffs = append(*diffs, ConfigDiff{Path: path, Op: DiffChanged, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+}
+
+// FormatDiffValue formats a configuration value as compact JSON
+func FormatDiffValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	var base, current map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"model": "sonnet",
+		"timeout": 600,
+		"permissions": {"allow": ["Read"], "deny": ["Bash(rm:*)"]},
+		"hooks": {"PreToolUse": {"enabled": true}},
+		"legacy": {"mode": "x"}
+	}`), &base))
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"model": "opus",
+		"timeout": 600,
+		"permissions": {"allow": ["Read", "Write"], "deny": ["Bash(rm:*)"]},
+		"hooks": "disabled",
+		"env": {"DEBUG": "1"}
+	}`), &current))
+
+	assert.Equal(t, []ConfigDiff{
+		{Path: "env.DEBUG", Op: DiffAdded, NewValue: "1"},
+		{Path: "hooks", Op: DiffChanged, OldValue: map[string]interface{}{"PreToolUse": map[string]interface{}{"enabled": true}}, NewValue: "disabled"},
+		{Path: "legacy.mode", Op: DiffRemoved, OldValue: "x"},
+		{Path: "model", Op: DiffChanged, OldValue: "sonnet", NewValue: "opus"},
+		{Path: "permissions.allow", Op: DiffChanged, OldValue: []interface{}{"Read"}, NewValue: []interface{}{"Read", "Write"}},
+		{Path: "permissions.deny", Op: DiffUnchanged, OldValue: []interface{}{"Bash(rm:*)"}, NewValue: []interface{}{"Bash(rm:*)"}},
+		{Path: "timeout", Op: DiffUnchanged, OldValue: float64(600), NewValue: float64(600)},
+	}, DiffConfigs(base, current))
+
+	assert.Empty(t, DiffConfigs(nil, nil))
+	assert.Equal(t, `["Read","Write"]`, FormatDiffValue([]interface{}{"Read", "Write"}))
+}
+
+func TestManager_DefaultSettingsDiff(t *testing.T) {
+	manager := NewManager(t.TempDir())
+
+	defaults, err := manager.DefaultSettings()
+	require.NoError(t, err)
+	settings, _, err := manager.EffectiveSettings()
+	require.NoError(t, err)
+
+	// Without customization, every key matches its default
+	for _, d := range DiffConfigs(defaults, settings) {
+		assert.Equal(t, DiffUnchanged, d.Op, d.Path)
+	}
+}
@@ -21,6 +21,9 @@ type Manager struct {
 	SystemPath    string // system/ - templates (read-only)
 	UserPath      string // user/ - user overrides
 	RuntimePath   string // runtime/ - effective config (generated)
+
+	settings     map[string]interface{} // user-overrides layer, loaded on demand
+	settingsFile string                 // file the user-overrides layer was loaded from
 }
 
 // NewManager creates a new configuration manager
@@ -57,6 +60,15 @@ func (m *Manager) Initialize() error {
 		}
 	}
 
+	// Refuse to proceed with a hand-edited config that would fail later
+	report, err := m.ValidateConfig()
+	if err != nil {
+		return err
+	}
+	if !report.Valid() {
+		return report.Error()
+	}
+
 	return nil
 }
 
@@ -191,6 +203,11 @@ func (m *Manager) Sync() error {
 		return fmt.Errorf("failed to merge hooks: %w", err)
 	}
 
+	// Substitute {{project_name}}, {{date}} and {{vars.*}} in templates
+	if err := m.substituteTemplateVariables(); err != nil {
+		return fmt.Errorf("failed to substitute template variables: %w", err)
+	}
+
 	// Sync runtime configuration to .claude/ directory for Claude Code
 	if err := m.syncToClaudeDir(); err != nil {
 		return fmt.Errorf("failed to sync to .claude directory: %w", err)
@@ -199,18 +216,66 @@ func (m *Manager) Sync() error {
 	return nil
 }
 
+// Sources of the effective settings reported by EffectiveSettings
+const (
+	SourceDefault ConfigSource = "default" // settings.json embedded in the binary
+	SourceSystem  ConfigSource = "system"  // system/settings.json.template
+	SourceUser    ConfigSource = "user"    // user/settings.json
+)
+
 // mergeSettings merges system template and user overrides
 func (m *Manager) mergeSettings() error {
+	config, _, err := m.EffectiveSettings()
+	if err != nil {
+		return err
+	}
+
+	// Write runtime settings
+	runtimeSettings := filepath.Join(m.RuntimePath, "settings.json")
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal runtime settings: %w", err)
+	}
+
+	return os.WriteFile(runtimeSettings, data, 0644)
+}
+
+// DefaultSettings returns the settings embedded in the binary, the base of
+// EffectiveSettings
+func (m *Manager) DefaultSettings() (map[string]interface{}, error) {
+	data, err := embeddedSystem.ReadFile("system/settings.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read default settings: %w", err)
+	}
+	var defaults map[string]interface{}
+	if err := json.Unmarshal(data, &defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse default settings: %w", err)
+	}
+	return defaults, nil
+}
+
+// EffectiveSettings returns the settings written by Sync: the embedded
+// defaults, overlaid by the system template, user overrides, then the
+// settings.json of the active profile.
+// The sources map gives the layer of each value, keyed by dotted path.
+func (m *Manager) EffectiveSettings() (map[string]interface{}, map[string]ConfigSource, error) {
+	config := make(map[string]interface{})
+	sources := make(map[string]ConfigSource)
+
+	defaults, err := m.DefaultSettings()
+	if err != nil {
+		return nil, nil, err
+	}
+	overlayConfig(config, defaults, "", SourceDefault, sources)
+
 	// Load system template
 	systemSettings := filepath.Join(m.SystemPath, "settings.json.template")
-	var config map[string]interface{}
-
 	if data, err := os.ReadFile(systemSettings); err == nil {
-		if err := json.Unmarshal(data, &config); err != nil {
-			return fmt.Errorf("failed to parse system settings: %w", err)
+		var systemConfig map[string]interface{}
+		if err := json.Unmarshal(data, &systemConfig); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse system settings: %w", err)
 		}
-	} else {
-		config = make(map[string]interface{})
+		overlayConfig(config, systemConfig, "", SourceSystem, sources)
 	}
 
 	// Apply user overrides
@@ -218,23 +283,32 @@ func (m *Manager) mergeSettings() error {
 	if data, err := os.ReadFile(userSettings); err == nil {
 		var userConfig map[string]interface{}
 		if err := json.Unmarshal(data, &userConfig); err != nil {
-			return fmt.Errorf("failed to parse user settings: %w", err)
+			return nil, nil, fmt.Errorf("failed to parse user settings: %w", err)
 		}
 		// Deep merge user config into system config
-		mergeMap(config, userConfig)
+		overlayConfig(config, userConfig, "", SourceUser, sources)
 	}
 
-	// Write runtime settings
-	runtimeSettings := filepath.Join(m.RuntimePath, "settings.json")
-	data, err := json.MarshalIndent(config, "", "  ")
+	// Apply the active profile
+	profilePath, err := m.activeProfilePath()
 	if err != nil {
-		return fmt.Errorf("failed to marshal runtime settings: %w", err)
+		return nil, nil, err
+	}
+	if profilePath != "" {
+		profileSettings := filepath.Join(profilePath, "settings.json")
+		if data, err := os.ReadFile(profileSettings); err == nil {
+			var profileConfig map[string]interface{}
+			if err := json.Unmarshal(data, &profileConfig); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse profile settings: %w", err)
+			}
+			overlayConfig(config, profileConfig, "", SourceProfile, sources)
+		}
 	}
 
-	return os.WriteFile(runtimeSettings, data, 0644)
+	return config, sources, nil
 }
 
-// mergeDirectory merges system and user directories into runtime
+// mergeDirectory merges system, user and active profile directories into runtime
 func (m *Manager) mergeDirectory(dirName string) error {
 	systemDir := filepath.Join(m.SystemPath, dirName)
 	userDir := filepath.Join(m.UserPath, dirName)
@@ -262,6 +336,20 @@ func (m *Manager) mergeDirectory(dirName string) error {
 		}
 	}
 
+	// Overlay the active profile last
+	profilePath, err := m.activeProfilePath()
+	if err != nil {
+		return err
+	}
+	if profilePath != "" {
+		profileDir := filepath.Join(profilePath, dirName)
+		if _, err := os.Stat(profileDir); err == nil {
+			if err := fsutil.CopyDirectory(profileDir, runtimeDir); err != nil {
+				return fmt.Errorf("failed to overlay profile directory: %w", err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -632,17 +720,3 @@ func (m *Manager) GetSystemCommandsPath() string {
 func (m *Manager) GetConfigDir() string {
 	return m.WorkspaceRoot
 }
-
-func mergeMap(dst, src map[string]interface{}) {
-	for key, value := range src {
-		if srcMap, ok := value.(map[string]interface{}); ok {
-			if dstMap, ok := dst[key].(map[string]interface{}); ok {
-				mergeMap(dstMap, srcMap)
-			} else {
-				dst[key] = srcMap
-			}
-		} else {
-			dst[key] = value
-		}
-	}
-}
\ No newline at end of file
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveSettingsSources(t *testing.T) {
+	manager := NewManager(t.TempDir())
+	require.NoError(t, os.MkdirAll(manager.SystemPath, 0755))
+	require.NoError(t, os.MkdirAll(manager.UserPath, 0755))
+	require.NoError(t, os.MkdirAll(manager.RuntimePath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(manager.SystemPath, "settings.json.template"),
+		[]byte(`{"model": "opus", "env": {"DISABLE_TELEMETRY": "true"}}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(manager.UserPath, "settings.json"),
+		[]byte(`{"env": {"DISABLE_TELEMETRY": "false"}}`), 0644))
+
+	settings, sources, err := manager.EffectiveSettings()
+	require.NoError(t, err)
+
+	assert.Equal(t, "opus", settings["model"])
+	assert.Equal(t, SourceSystem, sources["model"])
+	assert.Equal(t, "false", settings["env"].(map[string]interface{})["DISABLE_TELEMETRY"])
+	assert.Equal(t, SourceUser, sources["env.DISABLE_TELEMETRY"])
+	assert.Equal(t, SourceDefault, sources["env.DISABLE_BUG_COMMAND"])
+	assert.Equal(t, SourceDefault, sources["cleanupPeriodDays"])
+
+	// Sync writes the same settings to the runtime directory
+	require.NoError(t, manager.mergeSettings())
+	data, err := os.ReadFile(manager.GetRuntimeSettingsPath())
+	require.NoError(t, err)
+	var runtime map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &runtime))
+	assert.Equal(t, settings, runtime)
+}
@@ -0,0 +1,254 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// ProfilesDir holds one directory per profile inside the workspace root
+	ProfilesDir = "profiles"
+	// ActiveProfileFile records the profile selected with `config profile use`
+	ActiveProfileFile = ".active-profile"
+	// ProfileEnvVar overrides the active profile (e.g. CLAUDE_WM_PROFILE=ci)
+	ProfileEnvVar = "CLAUDE_WM_PROFILE"
+	// DefaultProfile names the implicit profile used when no profile is
+	// active: the base configuration and user overrides alone
+	DefaultProfile = "default"
+)
+
+// ConfigSource tells which layer a configuration value comes from
+type ConfigSource string
+
+const (
+	SourceBase    ConfigSource = "base"    // .claude-wm/config.json
+	SourceProfile ConfigSource = "profile" // .claude-wm/profiles/<name>/{config,settings}.json
+)
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// ValidateProfileName checks that a profile name is usable as a directory name
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
+
+// GetProfilePath returns the directory of a profile
+func (m *Manager) GetProfilePath(name string) string {
+	return filepath.Join(m.WorkspaceRoot, ProfilesDir, name)
+}
+
+// GetProfileConfigPath returns the configuration file of a profile
+func (m *Manager) GetProfileConfigPath(name string) string {
+	return filepath.Join(m.GetProfilePath(name), ProjectConfigFile)
+}
+
+// ActiveProfile returns the active profile name and where it was selected
+// ("$CLAUDE_WM_PROFILE" or the .active-profile file), or "" when the base
+// configuration is used alone
+func (m *Manager) ActiveProfile() (string, string, error) {
+	if name := strings.TrimSpace(os.Getenv(ProfileEnvVar)); name != "" {
+		if m.isImplicitDefault(name) {
+			return "", "", nil
+		}
+		return name, "$" + ProfileEnvVar, nil
+	}
+
+	path := filepath.Join(m.WorkspaceRoot, ActiveProfileFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	name := strings.TrimSpace(string(data))
+	if m.isImplicitDefault(name) {
+		return "", "", nil
+	}
+	return name, path, nil
+}
+
+// isImplicitDefault reports whether name selects the implicit default
+// profile, i.e. "default" when no profile directory of that name exists
+func (m *Manager) isImplicitDefault(name string) bool {
+	return name == DefaultProfile && !m.ProfileExists(DefaultProfile)
+}
+
+// activeProfilePath returns the directory of the active profile, or "" when
+// no profile is active
+func (m *Manager) activeProfilePath() (string, error) {
+	profile, _, err := m.ActiveProfile()
+	if err != nil || profile == "" {
+		return "", err
+	}
+	if !m.ProfileExists(profile) {
+		return "", fmt.Errorf("active profile %q does not exist", profile)
+	}
+	return m.GetProfilePath(profile), nil
+}
+
+// ProfileExists reports whether a profile has been created
+func (m *Manager) ProfileExists(name string) bool {
+	info, err := os.Stat(m.GetProfilePath(name))
+	return err == nil && info.IsDir()
+}
+
+// ListProfiles returns the names of all profiles, sorted
+func (m *Manager) ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(m.WorkspaceRoot, ProfilesDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// CreateProfile creates a profile with an empty configuration overlay
+func (m *Manager) CreateProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if m.ProfileExists(name) {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+
+	if err := os.MkdirAll(m.GetProfilePath(name), 0755); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	if err := os.WriteFile(m.GetProfileConfigPath(name), []byte("{}\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write profile configuration: %w", err)
+	}
+	return nil
+}
+
+// SwitchProfile makes name the active profile by writing .active-profile.
+// Switching to the implicit default profile removes .active-profile.
+func (m *Manager) SwitchProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if m.isImplicitDefault(name) {
+		path := filepath.Join(m.WorkspaceRoot, ActiveProfileFile)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+	if !m.ProfileExists(name) {
+		return fmt.Errorf("profile %q does not exist (create it with 'config profile create %s')", name, name)
+	}
+
+	if err := os.MkdirAll(m.WorkspaceRoot, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", m.WorkspaceRoot, err)
+	}
+	path := filepath.Join(m.WorkspaceRoot, ActiveProfileFile)
+	if err := os.WriteFile(path, []byte(name+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// LoadConfig returns the project configuration with the active profile
+// overlaid on the base configuration
+func (m *Manager) LoadConfig() (map[string]interface{}, error) {
+	merged, _, err := m.LoadConfigWithSources()
+	return merged, err
+}
+
+// LoadConfigWithSources is like LoadConfig and also returns the layer each
+// value comes from, keyed by dotted path
+func (m *Manager) LoadConfigWithSources() (map[string]interface{}, map[string]ConfigSource, error) {
+	merged := map[string]interface{}{}
+	sources := map[string]ConfigSource{}
+
+	base, err := readConfigObject(m.GetProjectConfigPath())
+	if err != nil {
+		return nil, nil, err
+	}
+	overlayConfig(merged, base, "", SourceBase, sources)
+
+	profile, _, err := m.ActiveProfile()
+	if err != nil {
+		return nil, nil, err
+	}
+	if profile != "" {
+		if !m.ProfileExists(profile) {
+			return nil, nil, fmt.Errorf("active profile %q does not exist", profile)
+		}
+		overlay, err := readConfigObject(m.GetProfileConfigPath(profile))
+		if err != nil {
+			return nil, nil, err
+		}
+		overlayConfig(merged, overlay, "", SourceProfile, sources)
+	}
+
+	return merged, sources, nil
+}
+
+// readConfigObject reads a JSON object, treating a missing file as empty
+func readConfigObject(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]interface{}{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if object == nil {
+		object = map[string]interface{}{}
+	}
+	return object, nil
+}
+
+// overlayConfig deep-merges src into dst, recording the source of each leaf value
+func overlayConfig(dst, src map[string]interface{}, path string, source ConfigSource, sources map[string]ConfigSource) {
+	for key, value := range src {
+		childPath := joinPath(path, key)
+		if object, ok := value.(map[string]interface{}); ok {
+			existing, ok := dst[key].(map[string]interface{})
+			if !ok {
+				clearSources(sources, childPath)
+				existing = map[string]interface{}{}
+				dst[key] = existing
+			}
+			overlayConfig(existing, object, childPath, source, sources)
+			continue
+		}
+
+		clearSources(sources, childPath)
+		dst[key] = value
+		sources[childPath] = source
+	}
+}
+
+// clearSources forgets the sources of path and everything below it
+func clearSources(sources map[string]ConfigSource, path string) {
+	for key := range sources {
+		if key == path || strings.HasPrefix(key, path+".") {
+			delete(sources, key)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileLifecycle(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	manager := writeProjectConfig(t, `{"version": "1.0", "claude": {"timeout": "10m", "model": "sonnet"}}`)
+
+	active, _, err := manager.ActiveProfile()
+	require.NoError(t, err)
+	assert.Empty(t, active)
+
+	require.NoError(t, manager.CreateProfile("ci"))
+	require.NoError(t, manager.CreateProfile("local"))
+	assert.Error(t, manager.CreateProfile("ci"))
+	assert.Error(t, manager.CreateProfile("../escape"))
+	assert.Error(t, manager.SwitchProfile("missing"))
+
+	profiles, err := manager.ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ci", "local"}, profiles)
+
+	require.NoError(t, manager.SwitchProfile("ci"))
+	active, origin, err := manager.ActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "ci", active)
+	assert.Equal(t, filepath.Join(manager.WorkspaceRoot, ActiveProfileFile), origin)
+
+	t.Setenv(ProfileEnvVar, "local")
+	active, origin, err = manager.ActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "local", active)
+	assert.Equal(t, "$"+ProfileEnvVar, origin)
+}
+
+func TestLoadConfigOverlaysActiveProfile(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	manager := writeProjectConfig(t, `{"version": "1.0", "claude": {"timeout": "10m", "model": "sonnet"}}`)
+	require.NoError(t, manager.CreateProfile("ci"))
+	require.NoError(t, os.WriteFile(manager.GetProfileConfigPath("ci"), []byte(`{"claude": {"timeout": "30m"}}`), 0644))
+
+	settings, err := manager.LoadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "10m", settings["claude"].(map[string]interface{})["timeout"])
+
+	require.NoError(t, manager.SwitchProfile("ci"))
+	settings, sources, err := manager.LoadConfigWithSources()
+	require.NoError(t, err)
+	claude := settings["claude"].(map[string]interface{})
+	assert.Equal(t, "30m", claude["timeout"])
+	assert.Equal(t, "sonnet", claude["model"])
+	assert.Equal(t, map[string]ConfigSource{
+		"version":        SourceBase,
+		"claude.model":   SourceBase,
+		"claude.timeout": SourceProfile,
+	}, sources)
+
+	t.Setenv(ProfileEnvVar, "missing")
+	_, err = manager.LoadConfig()
+	assert.ErrorContains(t, err, `active profile "missing" does not exist`)
+}
+
+func TestSetSettingWritesActiveProfile(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	manager := writeProjectConfig(t, `{"version": "1.0"}`)
+	require.NoError(t, manager.CreateProfile("ci"))
+	require.NoError(t, manager.SwitchProfile("ci"))
+
+	require.NoError(t, manager.SetSetting("claude.timeout", "30m"))
+	require.NoError(t, manager.WriteConfig())
+
+	data, err := os.ReadFile(manager.GetProfileConfigPath("ci"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"claude": {"timeout": "30m"}}`, string(data))
+
+	data, err = os.ReadFile(manager.GetProjectConfigPath())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": "1.0"}`, string(data))
+}
+
+func TestSyncMergesActiveProfile(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	manager := NewManager(t.TempDir())
+	require.NoError(t, manager.Initialize())
+
+	require.NoError(t, os.WriteFile(filepath.Join(manager.SystemPath, "commands", "plan.md"), []byte("system\n"), 0644))
+	require.NoError(t, os.WriteFile(manager.GetUserPath("settings.json"), []byte(`{"model": "sonnet"}`), 0644))
+	require.NoError(t, manager.CreateProfile("client-a"))
+	profilePath := manager.GetProfilePath("client-a")
+	require.NoError(t, os.MkdirAll(filepath.Join(profilePath, "commands"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(profilePath, "commands", "plan.md"), []byte("client-a\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(profilePath, "settings.json"), []byte(`{"model": "opus"}`), 0644))
+
+	readRuntime := func() (string, interface{}) {
+		require.NoError(t, manager.Sync())
+		command, err := os.ReadFile(manager.GetRuntimePath("commands/plan.md"))
+		require.NoError(t, err)
+		settings, sources, err := manager.EffectiveSettings()
+		require.NoError(t, err)
+		if settings["model"] == "opus" {
+			assert.Equal(t, SourceProfile, sources["model"])
+		}
+		return string(command), settings["model"]
+	}
+
+	command, model := readRuntime()
+	assert.Equal(t, "system\n", command)
+	assert.Equal(t, "sonnet", model)
+
+	require.NoError(t, manager.SwitchProfile("client-a"))
+	command, model = readRuntime()
+	assert.Equal(t, "client-a\n", command)
+	assert.Equal(t, "opus", model)
+
+	// "default" goes back to the base configuration without a profile directory
+	require.NoError(t, manager.SwitchProfile(DefaultProfile))
+	active, _, err := manager.ActiveProfile()
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	command, model = readRuntime()
+	assert.Equal(t, "system\n", command)
+	assert.Equal(t, "sonnet", model)
+
+	t.Setenv(ProfileEnvVar, "missing")
+	assert.ErrorContains(t, manager.Sync(), `active profile "missing" does not exist`)
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ParseSettingValue parses a command-line value as a JSON literal (number,
+// boolean, null, array or object), falling back to a plain string
+func ParseSettingValue(raw string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return raw
+	}
+	return value
+}
+
+// SettingsPath returns the file changed by SetSetting and UnsetSetting: the
+// active profile's configuration, or the base configuration when no profile is active
+func (m *Manager) SettingsPath() (string, error) {
+	profile, _, err := m.ActiveProfile()
+	if err != nil {
+		return "", err
+	}
+	if profile == "" {
+		return m.GetProjectConfigPath(), nil
+	}
+	if !m.ProfileExists(profile) {
+		return "", fmt.Errorf("active profile %q does not exist", profile)
+	}
+	return m.GetProfileConfigPath(profile), nil
+}
+
+// GetSetting returns the value stored at a dotted key (e.g. "claude.model")
+// in the file returned by SettingsPath, ignoring defaults and other layers
+func (m *Manager) GetSetting(key string) (interface{}, bool, error) {
+	if err := m.loadSettings(); err != nil {
+		return nil, false, err
+	}
+
+	var current interface{} = m.settings
+	for _, part := range splitSettingKey(key) {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false, nil
+		}
+		if current, ok = object[part]; !ok {
+			return nil, false, nil
+		}
+	}
+	return current, true, nil
+}
+
+// SetSetting stores value at a dotted key after checking both against the
+// schema. Call WriteConfig to persist the change.
+func (m *Manager) SetSetting(key string, value interface{}) error {
+	schema, err := settingSchema(key)
+	if err != nil {
+		return err
+	}
+
+	if err := m.loadSettings(); err != nil {
+		return err
+	}
+
+	report := &ValidationReport{File: m.settingsFile}
+	validateNode(schema, value, key, report, false)
+	if !report.Valid() {
+		return fmt.Errorf("invalid value for %s", report.Errors[0])
+	}
+
+	parts := splitSettingKey(key)
+	object := m.settings
+	for i, part := range parts[:len(parts)-1] {
+		child, exists := object[part]
+		if !exists {
+			child = map[string]interface{}{}
+			object[part] = child
+		}
+		next, ok := child.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot set %s: %s is not an object", key, strings.Join(parts[:i+1], "."))
+		}
+		object = next
+	}
+	object[parts[len(parts)-1]] = value
+	return nil
+}
+
+// UnsetSetting removes a dotted key so that its default applies again, pruning
+// parent objects left empty. It reports whether the key was present.
+// Call WriteConfig to persist the change.
+func (m *Manager) UnsetSetting(key string) (bool, error) {
+	if _, err := settingSchema(key); err != nil {
+		return false, err
+	}
+
+	if err := m.loadSettings(); err != nil {
+		return false, err
+	}
+
+	parts := splitSettingKey(key)
+	if len(parts) == 1 && m.settingsFile == m.GetProjectConfigPath() {
+		schema, err := loadConfigSchema()
+		if err != nil {
+			return false, err
+		}
+		for _, required := range schema.Required {
+			if required == parts[0] {
+				return false, fmt.Errorf("cannot unset %s: field is required", key)
+			}
+		}
+	}
+
+	return removeSetting(m.settings, parts), nil
+}
+
+// WriteConfig writes the user-overrides layer back to the file returned by
+// SettingsPath. Embedded defaults and other config layers are not written.
+func (m *Manager) WriteConfig() error {
+	if err := m.loadSettings(); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(m.settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+
+	path := m.settingsFile
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadSettings reads the file returned by SettingsPath once. A missing base
+// configuration starts from the schema's required defaults so that the written
+// file stays valid; a missing profile configuration starts empty.
+func (m *Manager) loadSettings() error {
+	if m.settings != nil {
+		return nil
+	}
+
+	path, err := m.SettingsPath()
+	if err != nil {
+		return err
+	}
+
+	settings, err := readConfigObject(path)
+	if err != nil {
+		return err
+	}
+	if _, statErr := os.Stat(path); os.IsNotExist(statErr) && path == m.GetProjectConfigPath() {
+		if settings, err = requiredDefaults(); err != nil {
+			return err
+		}
+	}
+
+	m.settings = settings
+	m.settingsFile = path
+	return nil
+}
+
+// settingSchema returns the schema of a dotted key, or an error if the schema
+// does not allow the key
+func settingSchema(key string) (*schemaNode, error) {
+	schema, err := loadConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	path := ""
+	for _, part := range splitSettingKey(key) {
+		if part == "" {
+			return nil, fmt.Errorf("invalid configuration key %q", key)
+		}
+		path = joinPath(path, part)
+
+		if property, known := schema.Properties[part]; known {
+			schema = property
+			continue
+		}
+		additional, allowed := schema.additional()
+		if !allowed || (schema.Type != "" && schema.Type != "object") {
+			return nil, fmt.Errorf("unknown configuration key %q", path)
+		}
+		if additional == nil {
+			additional = &schemaNode{}
+		}
+		schema = additional
+	}
+	return schema, nil
+}
+
+// requiredDefaults returns an object holding the defaults of the schema's
+// required top-level fields
+func requiredDefaults() (map[string]interface{}, error) {
+	schema, err := loadConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := map[string]interface{}{}
+	for _, name := range schema.Required {
+		property := schema.Properties[name]
+		if property == nil || len(property.Default) == 0 {
+			continue
+		}
+		var value interface{}
+		if err := json.Unmarshal(property.Default, &value); err == nil {
+			settings[name] = value
+		}
+	}
+	return settings, nil
+}
+
+func removeSetting(object map[string]interface{}, parts []string) bool {
+	if len(parts) == 1 {
+		if _, exists := object[parts[0]]; !exists {
+			return false
+		}
+		delete(object, parts[0])
+		return true
+	}
+
+	child, ok := object[parts[0]].(map[string]interface{})
+	if !ok || !removeSetting(child, parts[1:]) {
+		return false
+	}
+	if len(child) == 0 {
+		delete(object, parts[0])
+	}
+	return true
+}
+
+func splitSettingKey(key string) []string {
+	return strings.Split(strings.TrimSpace(key), ".")
+}
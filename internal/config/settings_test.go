@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSettingValue(t *testing.T) {
+	assert.Equal(t, float64(5000), ParseSettingValue("5000"))
+	assert.Equal(t, true, ParseSettingValue("true"))
+	assert.Nil(t, ParseSettingValue("null"))
+	assert.Equal(t, "opus", ParseSettingValue("opus"))
+	assert.Equal(t, map[string]interface{}{"warn_ms": float64(1)}, ParseSettingValue(`{"warn_ms": 1}`))
+}
+
+func TestSetSettingWritesUserLayer(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	projectPath := t.TempDir()
+	manager := NewManager(projectPath)
+
+	require.NoError(t, manager.SetSetting("claude.model", "opus"))
+	require.NoError(t, manager.SetSetting("thresholds.interactive.warn_ms", float64(5000)))
+	require.NoError(t, manager.WriteConfig())
+
+	data, err := os.ReadFile(manager.GetProjectConfigPath())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": "1.0",
+		"claude": {"model": "opus"},
+		"thresholds": {"interactive": {"warn_ms": 5000}}
+	}`, string(data))
+
+	report, err := manager.ValidateConfig()
+	require.NoError(t, err)
+	assert.True(t, report.Valid())
+	assert.Empty(t, report.Warnings)
+
+	value, found, err := NewManager(projectPath).GetSetting("thresholds.interactive.warn_ms")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, float64(5000), value)
+}
+
+func TestSetSettingRejectsSchemaViolations(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	manager := NewManager(t.TempDir())
+
+	err := manager.SetSetting("claude.unknown", "x")
+	assert.ErrorContains(t, err, `unknown configuration key "claude.unknown"`)
+
+	err = manager.SetSetting("claude.timeout", float64(600))
+	assert.ErrorContains(t, err, "claude.timeout: expected string, got number 600")
+
+	err = manager.SetSetting("issues.provider", "bitbucket")
+	assert.ErrorContains(t, err, "expected one of [github, gitlab]")
+
+	require.NoError(t, manager.SetSetting("claude.models./4-task:2-execute:3-Implement", "opus"))
+}
+
+func TestSetSettingCommandKeys(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+
+	tests := []struct {
+		key     string
+		value   interface{}
+		invalid interface{}
+		err     string
+	}{
+		{"epic.velocity_window", float64(14), float64(0), "epic.velocity_window: expected >= 1, got number 0"},
+		{"preprocessing.idempotency_ttl", "60s", float64(60), "preprocessing.idempotency_ttl: expected string, got number 60"},
+		{"metrics.retention", "30d", float64(30), "metrics.retention: expected string, got number 30"},
+		{"backup.schedule", "1h", float64(1), "backup.schedule: expected string, got number 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			manager := NewManager(t.TempDir())
+			require.NoError(t, manager.SetSetting(tt.key, tt.value))
+			require.NoError(t, manager.WriteConfig())
+
+			report, err := manager.ValidateConfig()
+			require.NoError(t, err)
+			assert.True(t, report.Valid())
+			assert.Empty(t, report.Warnings)
+
+			assert.ErrorContains(t, manager.SetSetting(tt.key, tt.invalid), tt.err)
+		})
+	}
+}
+
+func TestUnsetSetting(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	manager := writeProjectConfig(t, `{
+		"version": "1.0",
+		"claude": {"model": "opus"},
+		"issues": {"provider": "gitlab"}
+	}`)
+
+	removed, err := manager.UnsetSetting("claude.model")
+	require.NoError(t, err)
+	assert.True(t, removed)
+
+	removed, err = manager.UnsetSetting("claude.timeout")
+	require.NoError(t, err)
+	assert.False(t, removed)
+
+	_, err = manager.UnsetSetting("version")
+	assert.ErrorContains(t, err, "required")
+
+	require.NoError(t, manager.WriteConfig())
+	data, err := os.ReadFile(manager.GetProjectConfigPath())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": "1.0", "issues": {"provider": "gitlab"}}`, string(data))
+}
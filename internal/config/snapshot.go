@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"claude-wm-cli/internal/backup"
+	"claude-wm-cli/internal/ziputil"
+)
+
+// SnapshotsDir is the directory under .claude-wm holding configuration snapshots
+const SnapshotsDir = "snapshots"
+
+// snapshotArchive is the staging archive backed up by each snapshot; backups
+// of this file are the snapshots
+const snapshotArchive = "config-snapshot.zip"
+
+// snapshotTag marks the backups that are configuration snapshots
+const snapshotTag = "config-snapshot"
+
+// Snapshot is a saved copy of the system templates and runtime configuration
+type Snapshot struct {
+	ID        string
+	CreatedAt time.Time
+	Reason    string
+	Size      int64
+}
+
+// CreateSnapshot saves the current system templates and runtime configuration
+// so that RestoreSnapshot can roll back to them. User overrides are not saved:
+// they are re-applied by Sync after a rollback, as after an upgrade.
+func (m *Manager) CreateSnapshot(reason string) (*Snapshot, error) {
+	backups, err := m.snapshotManager()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := map[string]string{}
+	for prefix, dir := range map[string]string{"system": m.SystemPath, "runtime": m.RuntimePath} {
+		if _, err := os.Stat(dir); err == nil {
+			dirs[prefix] = dir
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no configuration to snapshot in %s", m.WorkspaceRoot)
+	}
+
+	archive := m.snapshotArchivePath()
+	if err := ziputil.CreateArchive(archive, dirs); err != nil {
+		return nil, fmt.Errorf("failed to archive configuration: %w", err)
+	}
+	defer os.Remove(archive)
+
+	result, err := backups.CreateBackup(&backup.BackupRequest{
+		SourceFile:  archive,
+		Type:        backup.BackupTypeSnapshot,
+		Reason:      backup.ReasonMigration,
+		Tags:        []string{snapshotTag, reason},
+		Compress:    false, // already a ZIP archive
+		Verify:      true,
+		Force:       true,
+		Description: "Configuration snapshot: " + reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("failed to save snapshot: %v", result.Error)
+	}
+	return snapshotFromBackup(result.Metadata), nil
+}
+
+// ListSnapshots returns the configuration snapshots, newest first
+func (m *Manager) ListSnapshots() ([]Snapshot, error) {
+	if _, err := os.Stat(m.snapshotsPath()); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	backups, err := m.snapshotManager()
+	if err != nil {
+		return nil, err
+	}
+	list, err := backups.ListBackups(&backup.BackupFilter{SourceFile: m.snapshotArchivePath()})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(list))
+	for _, metadata := range list {
+		snapshots = append(snapshots, *snapshotFromBackup(metadata))
+	}
+	return snapshots, nil
+}
+
+// RestoreSnapshot replaces the system templates and runtime configuration with
+// a snapshot (the latest when id is empty), then re-syncs so that user
+// overrides survive. The configuration being replaced is snapshotted first.
+func (m *Manager) RestoreSnapshot(id string) (*Snapshot, error) {
+	snapshots, err := m.ListSnapshots()
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("no configuration snapshots found")
+	}
+
+	target := snapshots[0]
+	if id != "" {
+		found := false
+		for _, snapshot := range snapshots {
+			if snapshot.ID == id {
+				target, found = snapshot, true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("snapshot %s not found (see 'config list-snapshots')", id)
+		}
+	}
+
+	backups, err := m.snapshotManager()
+	if err != nil {
+		return nil, err
+	}
+
+	extracted, err := os.MkdirTemp(m.WorkspaceRoot, ".rollback-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rollback directory: %w", err)
+	}
+	defer os.RemoveAll(extracted)
+
+	archive := filepath.Join(extracted, snapshotArchive)
+	result, err := backups.RecoverFromBackup(&backup.RecoveryRequest{
+		SourceFile:   m.snapshotArchivePath(),
+		BackupID:     target.ID,
+		VerifyBefore: true,
+		RestorePath:  archive,
+		RestoreMode:  backup.RestoreModeReplace,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("failed to read snapshot %s: %v", target.ID, result.Error)
+	}
+
+	tree := filepath.Join(extracted, "tree")
+	if err := ziputil.RestoreFromBackup(archive, tree); err != nil {
+		return nil, err
+	}
+
+	if _, err := m.CreateSnapshot("pre-rollback"); err != nil {
+		return nil, fmt.Errorf("failed to snapshot current configuration: %w", err)
+	}
+
+	for prefix, dir := range map[string]string{"system": m.SystemPath, "runtime": m.RuntimePath} {
+		source := filepath.Join(tree, prefix)
+		if _, err := os.Stat(source); os.IsNotExist(err) {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", dir, err)
+		}
+		if err := os.Rename(source, dir); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", dir, err)
+		}
+	}
+
+	if err := m.Sync(); err != nil {
+		return nil, fmt.Errorf("failed to sync after rollback: %w", err)
+	}
+	return &target, nil
+}
+
+func (m *Manager) snapshotsPath() string {
+	return filepath.Join(m.WorkspaceRoot, SnapshotsDir)
+}
+
+func (m *Manager) snapshotArchivePath() string {
+	return filepath.Join(m.snapshotsPath(), snapshotArchive)
+}
+
+// snapshotManager returns a backup manager storing its backups in the snapshots directory
+func (m *Manager) snapshotManager() (*backup.Manager, error) {
+	cfg := backup.DefaultBackupConfig()
+	cfg.BackupDirectory = m.snapshotsPath()
+	cfg.AutoBackup = false
+	return backup.NewManager(cfg)
+}
+
+func snapshotFromBackup(metadata *backup.BackupMetadata) *Snapshot {
+	snapshot := &Snapshot{
+		ID:        metadata.ID,
+		CreatedAt: metadata.CreatedAt,
+		Size:      metadata.SourceSize,
+	}
+	for _, tag := range metadata.Tags {
+		if tag != snapshotTag {
+			snapshot.Reason = tag
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRollback(t *testing.T) {
+	t.Setenv(ProfileEnvVar, "")
+	manager := NewManager(t.TempDir())
+	require.NoError(t, manager.Initialize())
+
+	template := filepath.Join(manager.SystemPath, "commands", "plan.md")
+	override := filepath.Join(manager.UserPath, "commands", "mine.md")
+	require.NoError(t, os.WriteFile(template, []byte("v1\n"), 0644))
+	require.NoError(t, os.WriteFile(override, []byte("custom\n"), 0644))
+	require.NoError(t, manager.Sync())
+
+	snapshots, err := manager.ListSnapshots()
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	snapshot, err := manager.CreateSnapshot("config-upgrade")
+	require.NoError(t, err)
+	assert.Equal(t, "config-upgrade", snapshot.Reason)
+
+	// Simulate an upgrade changing the template
+	require.NoError(t, os.WriteFile(template, []byte("v2\n"), 0644))
+	require.NoError(t, manager.Sync())
+
+	restored, err := manager.RestoreSnapshot("")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot.ID, restored.ID)
+
+	data, err := os.ReadFile(manager.GetRuntimePath("commands/plan.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1\n", string(data))
+	data, err = os.ReadFile(manager.GetRuntimePath("commands/mine.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "custom\n", string(data), "user overrides must survive a rollback")
+
+	// The replaced configuration was snapshotted and can be restored in turn
+	snapshots, err = manager.ListSnapshots()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, "pre-rollback", snapshots[0].Reason)
+
+	_, err = manager.RestoreSnapshot(snapshots[0].ID)
+	require.NoError(t, err)
+	data, err = os.ReadFile(template)
+	require.NoError(t, err)
+	assert.Equal(t, "v2\n", string(data))
+
+	_, err = manager.RestoreSnapshot("backup-missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,339 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//go:embed schema.json
+var configSchemaJSON []byte
+
+// ProjectConfigFile is the project configuration file inside the workspace root
+const ProjectConfigFile = "config.json"
+
+// schemaNode is the subset of JSON Schema understood by the validator
+type schemaNode struct {
+	Type                 string                 `json:"type"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	Default              json.RawMessage        `json:"default"`
+}
+
+// additional returns the schema for properties not listed in Properties, and
+// whether such properties are allowed at all
+func (n *schemaNode) additional() (*schemaNode, bool) {
+	raw := strings.TrimSpace(string(n.AdditionalProperties))
+	switch raw {
+	case "", "true":
+		return nil, true
+	case "false":
+		return nil, false
+	}
+
+	var child schemaNode
+	if err := json.Unmarshal(n.AdditionalProperties, &child); err != nil {
+		return nil, true
+	}
+	return &child, true
+}
+
+// ViolationSeverity tells whether a violation blocks the configuration from loading
+type ViolationSeverity string
+
+const (
+	SeverityError   ViolationSeverity = "error"   // Invalid value, must be fixed
+	SeverityWarning ViolationSeverity = "warning" // Unknown key or missing default
+)
+
+// Violation is a single schema violation in the project configuration
+type Violation struct {
+	Path     string            `json:"path"`
+	Expected string            `json:"expected"`
+	Got      string            `json:"got"`
+	Severity ViolationSeverity `json:"severity"`
+	Fixable  bool              `json:"fixable"`
+}
+
+// String formats the violation as "field.path: expected X, got Y"
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", v.Path, v.Expected, v.Got)
+}
+
+// ValidationReport lists the violations found in a configuration file
+type ValidationReport struct {
+	File     string      `json:"file"`
+	Errors   []Violation `json:"errors"`
+	Warnings []Violation `json:"warnings"`
+	Fixed    []Violation `json:"fixed,omitempty"`
+}
+
+// Valid returns true if the configuration has no errors (warnings are allowed)
+func (r *ValidationReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Error returns an error listing every validation error, or nil if the report is valid
+func (r *ValidationReport) Error() error {
+	if r.Valid() {
+		return nil
+	}
+
+	lines := make([]string, len(r.Errors))
+	for i, violation := range r.Errors {
+		lines[i] = "  " + violation.String()
+	}
+	return fmt.Errorf("invalid configuration %s (%d errors):\n%s\nrun 'claude-wm-cli config validate' for details",
+		r.File, len(r.Errors), strings.Join(lines, "\n"))
+}
+
+func (r *ValidationReport) add(v Violation) {
+	if v.Severity == SeverityError {
+		r.Errors = append(r.Errors, v)
+	} else {
+		r.Warnings = append(r.Warnings, v)
+	}
+}
+
+func loadConfigSchema() (*schemaNode, error) {
+	var schema schemaNode
+	if err := json.Unmarshal(configSchemaJSON, &schema); err != nil {
+		return nil, fmt.Errorf("invalid embedded config schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// GetProjectConfigPath returns the path of the project configuration file
+func (m *Manager) GetProjectConfigPath() string {
+	return filepath.Join(m.WorkspaceRoot, ProjectConfigFile)
+}
+
+// ValidateConfig checks the project configuration file against the embedded
+// schema. A missing file is valid.
+func (m *Manager) ValidateConfig() (*ValidationReport, error) {
+	report, _, err := m.validateConfigFile(false)
+	return report, err
+}
+
+// FixConfig validates the project configuration, removes unknown keys, inserts
+// missing defaults and writes the result back. Violations that cannot be fixed
+// automatically remain in the returned report.
+func (m *Manager) FixConfig() (*ValidationReport, error) {
+	report, doc, err := m.validateConfigFile(true)
+	if err != nil || len(report.Fixed) == 0 {
+		return report, err
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fixed configuration: %w", err)
+	}
+	if err := os.WriteFile(report.File, append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write fixed configuration: %w", err)
+	}
+	return report, nil
+}
+
+func (m *Manager) validateConfigFile(fix bool) (*ValidationReport, interface{}, error) {
+	path := m.GetProjectConfigPath()
+	report := &ValidationReport{File: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return report, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		report.add(Violation{Path: "(root)", Expected: "valid JSON", Got: err.Error(), Severity: SeverityError})
+		return report, nil, nil
+	}
+
+	schema, err := loadConfigSchema()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validateNode(schema, doc, "", report, fix)
+	return report, doc, nil
+}
+
+// ValidateConfigData checks raw configuration JSON against the embedded schema
+func ValidateConfigData(data []byte) (*ValidationReport, error) {
+	schema, err := loadConfigSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ValidationReport{}
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		report.add(Violation{Path: "(root)", Expected: "valid JSON", Got: err.Error(), Severity: SeverityError})
+		return report, nil
+	}
+
+	validateNode(schema, doc, "", report, false)
+	return report, nil
+}
+
+func validateNode(schema *schemaNode, value interface{}, path string, report *ValidationReport, fix bool) {
+	displayPath := path
+	if displayPath == "" {
+		displayPath = "(root)"
+	}
+
+	if schema.Type != "" && !matchesType(schema.Type, value) {
+		report.add(Violation{Path: displayPath, Expected: schema.Type, Got: describeValue(value), Severity: SeverityError})
+		return
+	}
+
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, value) {
+		report.add(Violation{Path: displayPath, Expected: "one of " + formatEnum(schema.Enum), Got: describeValue(value), Severity: SeverityError})
+		return
+	}
+
+	if number, ok := value.(float64); ok {
+		if schema.Minimum != nil && number < *schema.Minimum {
+			report.add(Violation{Path: displayPath, Expected: fmt.Sprintf(">= %v", *schema.Minimum), Got: describeValue(value), Severity: SeverityError})
+		}
+		if schema.Maximum != nil && number > *schema.Maximum {
+			report.add(Violation{Path: displayPath, Expected: fmt.Sprintf("<= %v", *schema.Maximum), Got: describeValue(value), Severity: SeverityError})
+		}
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	for _, name := range schema.Required {
+		if _, exists := object[name]; exists {
+			continue
+		}
+		property := schema.Properties[name]
+		violation := Violation{Path: joinPath(path, name), Expected: "required field", Got: "missing", Severity: SeverityError}
+		if property != nil && len(property.Default) > 0 {
+			violation.Severity = SeverityWarning
+			violation.Fixable = true
+			if fix {
+				var defaultValue interface{}
+				if err := json.Unmarshal(property.Default, &defaultValue); err == nil {
+					object[name] = defaultValue
+					report.Fixed = append(report.Fixed, violation)
+					continue
+				}
+			}
+		}
+		report.add(violation)
+	}
+
+	additional, allowed := schema.additional()
+	for _, name := range sortedObjectKeys(object) {
+		childPath := joinPath(path, name)
+		if property, known := schema.Properties[name]; known {
+			validateNode(property, object[name], childPath, report, fix)
+			continue
+		}
+		if additional != nil {
+			validateNode(additional, object[name], childPath, report, fix)
+			continue
+		}
+		if !allowed {
+			violation := Violation{Path: childPath, Expected: "no such key", Got: "unknown key", Severity: SeverityWarning, Fixable: true}
+			if fix {
+				delete(object, name)
+				report.Fixed = append(report.Fixed, violation)
+				continue
+			}
+			report.add(violation)
+		}
+	}
+}
+
+func matchesType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == float64(int64(number))
+	default:
+		return true
+	}
+}
+
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return fmt.Sprintf("boolean %v", v)
+	case float64:
+		return fmt.Sprintf("number %v", v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	values := make([]string, len(enum))
+	for i, value := range enum {
+		values[i] = fmt.Sprintf("%v", value)
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+func sortedObjectKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
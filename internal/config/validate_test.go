@@ -0,0 +1,86 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeProjectConfig(t *testing.T, content string) *Manager {
+	t.Helper()
+	manager := NewManager(t.TempDir())
+	require.NoError(t, os.MkdirAll(manager.WorkspaceRoot, 0755))
+	require.NoError(t, os.WriteFile(manager.GetProjectConfigPath(), []byte(content), 0644))
+	return manager
+}
+
+func TestValidateConfigData(t *testing.T) {
+	report, err := ValidateConfigData([]byte(`{
+		"version": "1.0",
+		"claude": {"timeout": 600, "retry": {"max_attempts": 0}},
+		"issues": {"provider": "bitbucket"},
+		"thresholds": {"interactive": {"warn_ms": 1.5}}
+	}`))
+	require.NoError(t, err)
+
+	var messages []string
+	for _, violation := range report.Errors {
+		messages = append(messages, violation.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"claude.retry.max_attempts: expected >= 1, got number 0",
+		"claude.timeout: expected string, got number 600",
+		"issues.provider: expected one of [github, gitlab], got string \"bitbucket\"",
+		"thresholds.interactive.warn_ms: expected integer, got number 1.5",
+	}, messages)
+	assert.Empty(t, report.Warnings)
+}
+
+func TestValidateConfigMissingFileIsValid(t *testing.T) {
+	report, err := NewManager(t.TempDir()).ValidateConfig()
+	require.NoError(t, err)
+	assert.True(t, report.Valid())
+	assert.NoError(t, report.Error())
+}
+
+func TestValidateConfigWarningsAndFix(t *testing.T) {
+	manager := writeProjectConfig(t, `{"claude": {"model": "opus", "colour": "blue"}, "legacy": true}`)
+
+	report, err := manager.ValidateConfig()
+	require.NoError(t, err)
+	assert.True(t, report.Valid())
+	require.Len(t, report.Warnings, 3)
+	for _, warning := range report.Warnings {
+		assert.True(t, warning.Fixable)
+	}
+
+	report, err = manager.FixConfig()
+	require.NoError(t, err)
+	assert.Len(t, report.Fixed, 3)
+	assert.Empty(t, report.Warnings)
+
+	data, err := os.ReadFile(manager.GetProjectConfigPath())
+	require.NoError(t, err)
+
+	var fixed map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fixed))
+	assert.Equal(t, map[string]interface{}{
+		"version": "1.0",
+		"claude":  map[string]interface{}{"model": "opus"},
+	}, fixed)
+}
+
+func TestInitializeRejectsInvalidConfig(t *testing.T) {
+	manager := writeProjectConfig(t, `{"version": "1.0", "issues": {"provider": 42}}`)
+
+	err := manager.Initialize()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "issues.provider: expected string, got number 42")
+
+	require.NoError(t, os.WriteFile(filepath.Join(manager.WorkspaceRoot, ProjectConfigFile), []byte(`{"version": "1.0"}`), 0644))
+	assert.NoError(t, manager.Initialize())
+}
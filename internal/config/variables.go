@@ -0,0 +1,138 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// VariablesFile is the user override file, under user/, defining the
+// {{vars.*}} template variables
+const VariablesFile = "variables.json"
+
+// templateVariablesDir is the runtime directory whose files get variable substitution
+const templateVariablesDir = "commands/templates"
+
+// placeholderPattern matches every {{...}} action of a template file
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+
+// variablePattern matches the placeholders substituted by Sync. Other
+// placeholders, like {{PROJECT_NAME}}, are left for Claude to fill in.
+var variablePattern = regexp.MustCompile(`^(project_name|date|vars\.[A-Za-z0-9_]+)$`)
+
+// TemplateVariables returns the variables available to templates: project_name,
+// date, and the vars defined in user/variables.json
+func (m *Manager) TemplateVariables() (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+
+	path := m.GetUserPath(VariablesFile)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &vars); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return map[string]interface{}{
+		"project_name": filepath.Base(filepath.Dir(m.WorkspaceRoot)),
+		"date":         time.Now().Format("2006-01-02"),
+		"vars":         vars,
+	}, nil
+}
+
+// substituteTemplateVariables replaces the {{project_name}}, {{date}} and
+// {{vars.*}} placeholders of the runtime templates. Files without such
+// placeholders are left untouched.
+func (m *Manager) substituteTemplateVariables() error {
+	dir := m.GetRuntimePath(templateVariablesDir)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	variables, err := m.TemplateVariables()
+	if err != nil {
+		return err
+	}
+
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(data, 0) != -1 {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(m.RuntimePath, path)
+		rendered, changed, err := renderTemplateVariables(rel, string(data), variables)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+		return os.WriteFile(path, []byte(rendered), info.Mode().Perm())
+	})
+}
+
+// renderTemplateVariables substitutes the variables of content with
+// text/template, escaping the placeholders that are not variables. It fails on
+// variables missing from variables rather than rendering them empty.
+func renderTemplateVariables(name, content string, variables map[string]interface{}) (string, bool, error) {
+	var missing []string
+	changed := false
+	source := placeholderPattern.ReplaceAllStringFunc(content, func(placeholder string) string {
+		variable := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		if !variablePattern.MatchString(variable) {
+			return `{{"{{"}}` + strings.TrimPrefix(placeholder, "{{")
+		}
+		changed = true
+		if !hasVariable(variables, variable) {
+			missing = append(missing, "{{"+variable+"}}")
+		}
+		return "{{." + variable + "}}"
+	})
+	if !changed {
+		return content, false, nil
+	}
+	if len(missing) > 0 {
+		return "", false, fmt.Errorf("%s: undefined template variables %s (define vars in user/%s)",
+			name, strings.Join(missing, ", "), VariablesFile)
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", false, fmt.Errorf("%s: invalid template: %w", name, err)
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, variables); err != nil {
+		return "", false, fmt.Errorf("%s: %w", name, err)
+	}
+	return out.String(), true, nil
+}
+
+// hasVariable reports whether a dotted variable name is defined
+func hasVariable(variables map[string]interface{}, name string) bool {
+	var value interface{} = variables
+	for _, key := range strings.Split(name, ".") {
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if value, ok = values[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncSubstitutesTemplateVariables(t *testing.T) {
+	projectPath := filepath.Join(t.TempDir(), "my-app")
+	manager := NewManager(projectPath)
+	require.NoError(t, manager.Initialize())
+
+	templates := filepath.Join(manager.SystemPath, "commands", "templates")
+	require.NoError(t, os.MkdirAll(templates, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templates, "README.md"),
+		[]byte("# {{project_name}} by {{ vars.team }}\n\n{{PROJECT_TAGLINE}} - {{date}}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(templates, "CLAUDE.md"), []byte("{{PROJECT_NAME}} {{ x }}\n"), 0644))
+	require.NoError(t, os.WriteFile(manager.GetUserPath(VariablesFile), []byte(`{"team": "platform"}`), 0644))
+
+	require.NoError(t, manager.Sync())
+
+	data, err := os.ReadFile(manager.GetRuntimePath("commands/templates/README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# my-app by platform\n\n{{PROJECT_TAGLINE}} - "+time.Now().Format("2006-01-02")+"\n", string(data))
+
+	data, err = os.ReadFile(manager.GetRuntimePath("commands/templates/CLAUDE.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "{{PROJECT_NAME}} {{ x }}\n", string(data), "files without variables are untouched")
+}
+
+func TestSyncFailsOnUndefinedTemplateVariable(t *testing.T) {
+	manager := NewManager(t.TempDir())
+	require.NoError(t, manager.Initialize())
+
+	templates := filepath.Join(manager.SystemPath, "commands", "templates")
+	require.NoError(t, os.MkdirAll(templates, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(templates, "README.md"), []byte("Owned by {{vars.owner}}\n"), 0644))
+
+	err := manager.Sync()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "{{vars.owner}}")
+	assert.Contains(t, err.Error(), "README.md")
+}
@@ -0,0 +1,95 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Defaults of the log file set by SetLogFile
+const (
+	DefaultLogMaxSize    int64 = 10 * 1024 * 1024 // Size at which the log file rotates
+	DefaultLogMaxBackups       = 3                // Rotated files kept as path.1 ... path.N
+)
+
+// logFile is a file that entries are appended to, renamed to path.1 when it
+// reaches maxSize, path.1 becoming path.2 and so on up to maxBackups
+type logFile struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+func openLogFile(path string, maxSize int64, maxBackups int) (*logFile, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultLogMaxSize
+	}
+	if maxBackups < 0 {
+		maxBackups = DefaultLogMaxBackups
+	}
+
+	lf := &logFile{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := lf.open(); err != nil {
+		return nil, err
+	}
+	return lf, nil
+}
+
+func (lf *logFile) open() error {
+	file, err := os.OpenFile(lf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	lf.file = file
+	lf.size = info.Size()
+	return nil
+}
+
+// Write appends p, rotating the file first when p would make it exceed its
+// maximum size
+func (lf *logFile) Write(p []byte) (int, error) {
+	if lf.size > 0 && lf.size+int64(len(p)) > lf.maxSize {
+		if err := lf.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := lf.file.Write(p)
+	lf.size += int64(n)
+	return n, err
+}
+
+// rotate shifts the rotated files, renames the log file to path.1 and starts
+// a new one. Without backups the log file is truncated.
+func (lf *logFile) rotate() error {
+	if err := lf.file.Close(); err != nil {
+		return err
+	}
+
+	if lf.maxBackups == 0 {
+		os.Remove(lf.path)
+	} else {
+		os.Remove(lf.backupPath(lf.maxBackups))
+		for i := lf.maxBackups - 1; i >= 1; i-- {
+			os.Rename(lf.backupPath(i), lf.backupPath(i+1))
+		}
+		if err := os.Rename(lf.path, lf.backupPath(1)); err != nil {
+			return fmt.Errorf("failed to rotate log file: %w", err)
+		}
+	}
+	return lf.open()
+}
+
+func (lf *logFile) backupPath(n int) string {
+	return lf.path + "." + strconv.Itoa(n)
+}
+
+func (lf *logFile) Close() error {
+	return lf.file.Close()
+}
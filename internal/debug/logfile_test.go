@@ -0,0 +1,68 @@
+package debug
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLogFile_WithoutDebugMode(t *testing.T) {
+	buf := useTestLog(t, false)
+	SetDebugMode(false)
+	path := filepath.Join(t.TempDir(), "session.log")
+	require.NoError(t, SetLogFile(path, 0, DefaultLogMaxBackups))
+
+	LogClaudeCommand("/help", "Show help")
+	require.NoError(t, Close())
+
+	assert.Empty(t, buf.String(), "nothing on the terminal without debug mode")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Regexp(t, `^🤖 \[\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{3}\] DEBUG \[CLAUDE\] \(0f8fad5b\): Show help`, string(data))
+	assert.Contains(t, string(data), "Prompt: /help")
+}
+
+func TestSetLogFile_AlsoWritesDebugOutput(t *testing.T) {
+	buf := useTestLog(t, true)
+	path := filepath.Join(t.TempDir(), "session.log")
+	require.NoError(t, SetLogFile(path, 0, DefaultLogMaxBackups))
+
+	LogCommand("GIT", "Commit", "git commit")
+	require.NoError(t, Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, buf.String(), string(data))
+}
+
+func TestSetLogFile_Rotates(t *testing.T) {
+	useTestLog(t, true)
+	SetDebugMode(false)
+	path := filepath.Join(t.TempDir(), "session.log")
+	require.NoError(t, SetLogFile(path, 300, 2))
+
+	for i := 0; i < 10; i++ {
+		LogCommand("GIT", "Status", "git status")
+	}
+	require.NoError(t, Close())
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		require.NoError(t, err, name)
+		assert.LessOrEqual(t, info.Size(), int64(300), name)
+	}
+	assert.NoFileExists(t, path+".3")
+
+	data, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(string(data), "\n"), "entries are not split across files")
+}
+
+func TestSetLogFile_Errors(t *testing.T) {
+	assert.Error(t, SetLogFile(filepath.Join(t.TempDir(), "missing", "session.log"), 0, 0))
+	require.NoError(t, SetLogFile("", 0, 0))
+}
@@ -1,9 +1,13 @@
 package debug
 
 import (
+	"crypto/rand"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -13,83 +17,301 @@ var DebugEnabled bool
 // DevMode indicates if we're in development mode (disables timeouts)
 var DevMode = true // Set to true for development
 
+// Log levels of JSON entries
+const (
+	LevelDebug = "DEBUG"
+	LevelWarn  = "WARN"
+	LevelError = "ERROR"
+)
+
+// Entry is a debug log line in JSON mode
+type Entry struct {
+	Timestamp     time.Time `json:"ts"`
+	Level         string    `json:"level"`
+	CorrelationID string    `json:"correlation_id,omitempty"`
+	Component     string    `json:"component"`
+	Action        string    `json:"action,omitempty"`
+	Message       string    `json:"message"`
+	DurationMs    *int64    `json:"duration_ms,omitempty"`
+	Command       string    `json:"command,omitempty"`
+	Args          []string  `json:"args,omitempty"`
+	Expected      string    `json:"expected,omitempty"`
+	Result        string    `json:"result,omitempty"`
+	Success       *bool     `json:"success,omitempty"`
+}
+
+var (
+	mu            sync.Mutex
+	jsonMode      bool
+	correlationID string
+	output        io.Writer = os.Stderr
+	outputFile    *os.File
+	logFileSink   *logFile                     // Log file written to in addition to the debug output
+	started       = make(map[string]time.Time) // Start of the executions logged by LogExecution
+)
+
 // SetDebugMode enables or disables debug mode
 func SetDebugMode(enabled bool) {
 	DebugEnabled = enabled
 }
 
+// SetJSONMode switches debug output between emoji-decorated text and
+// newline-delimited JSON entries
+func SetJSONMode(enabled bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	jsonMode = enabled
+}
+
+// SetCorrelationID sets the ID added to every debug entry, to group the
+// entries of one CLI invocation
+func SetCorrelationID(id string) {
+	mu.Lock()
+	defer mu.Unlock()
+	correlationID = id
+}
+
+// CorrelationID returns the ID added to debug entries
+func CorrelationID() string {
+	mu.Lock()
+	defer mu.Unlock()
+	return correlationID
+}
+
+// NewCorrelationID returns a random UUID (version 4)
+func NewCorrelationID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// SetOutputFile appends debug output to the file at path instead of stderr.
+// An empty path restores stderr.
+func SetOutputFile(path string) error {
+	var file *os.File
+	if path != "" {
+		var err error
+		file, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open debug output file: %w", err)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if outputFile != nil {
+		outputFile.Close()
+	}
+	outputFile = file
+	output = os.Stderr
+	if file != nil {
+		output = file
+	}
+	return nil
+}
+
+// SetOutput writes debug output to w, nil for stderr
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	if w == nil {
+		w = os.Stderr
+	}
+	output = w
+}
+
+// SetLogFile also appends every entry to the file at path, with its date,
+// whether debug mode is on or not. The file is rotated when it reaches maxSize
+// bytes (DefaultLogMaxSize when 0), keeping maxBackups rotated files
+// (DefaultLogMaxBackups when negative). An empty path stops writing to a file.
+func SetLogFile(path string, maxSize int64, maxBackups int) error {
+	var lf *logFile
+	if path != "" {
+		var err error
+		lf, err = openLogFile(path, maxSize, maxBackups)
+		if err != nil {
+			return err
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if logFileSink != nil {
+		logFileSink.Close()
+	}
+	logFileSink = lf
+	return nil
+}
+
+// Close closes the files set by SetOutputFile and SetLogFile and restores
+// stderr
+func Close() error {
+	mu.Lock()
+	defer mu.Unlock()
+	output = os.Stderr
+
+	var err error
+	if logFileSink != nil {
+		err = logFileSink.Close()
+		logFileSink = nil
+	}
+	if outputFile != nil {
+		if closeErr := outputFile.Close(); closeErr != nil {
+			err = closeErr
+		}
+		outputFile = nil
+	}
+	return err
+}
+
+// enabled reports whether entries are written anywhere: to the debug output in
+// debug mode, or to the log file
+func enabled() bool {
+	if DebugEnabled {
+		return true
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return logFileSink != nil
+}
+
+// write outputs entry as JSON in JSON mode, or else the text lines built by
+// text with the header of the entry, to the debug output in debug mode and to
+// the log file
+func write(entry Entry, icon string, details ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entry.Timestamp = time.Now()
+	entry.CorrelationID = correlationID
+
+	if DebugEnabled {
+		writeEntry(output, entry, "15:04:05.000", icon, details)
+	}
+	if logFileSink != nil {
+		writeEntry(logFileSink, entry, "2006-01-02 15:04:05.000", icon, details)
+	}
+}
+
+// writeEntry writes entry to w as JSON in JSON mode, or else as text with its
+// timestamp in timeFormat
+func writeEntry(w io.Writer, entry Entry, timeFormat, icon string, details []string) {
+	if jsonMode {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		w.Write(append(data, '\n'))
+		return
+	}
+
+	id := ""
+	if correlationID != "" {
+		id = " (" + shortID(correlationID) + ")"
+	}
+	fmt.Fprintf(w, "%s [%s] DEBUG [%s]%s: %s\n", icon, entry.Timestamp.Format(timeFormat), entry.Component, id, entry.Message)
+	for _, detail := range details {
+		fmt.Fprintf(w, "   ↳ %s\n", detail)
+	}
+}
+
+// shortID returns the first block of a UUID, enough to tell invocations apart
+// in text output
+func shortID(id string) string {
+	if i := strings.Index(id, "-"); i > 0 {
+		return id[:i]
+	}
+	return id
+}
+
 // LogCommand logs a command that is about to be executed
 func LogCommand(category, description, fullCommand string) {
-	if !DebugEnabled {
+	if !enabled() {
 		return
 	}
-	
-	timestamp := time.Now().Format("15:04:05.000")
-	fmt.Fprintf(os.Stderr, "🔍 [%s] DEBUG [%s]: %s\n", timestamp, category, description)
-	fmt.Fprintf(os.Stderr, "   ↳ Command: %s\n", fullCommand)
+
+	write(Entry{Level: LevelDebug, Component: category, Message: description, Command: fullCommand},
+		"🔍", "Command: "+fullCommand)
 }
 
 // LogCommandWithArgs logs a command with its arguments separately
 func LogCommandWithArgs(category, description, command string, args []string) {
-	if !DebugEnabled {
+	if !enabled() {
 		return
 	}
-	
-	timestamp := time.Now().Format("15:04:05.000")
-	fmt.Fprintf(os.Stderr, "🔍 [%s] DEBUG [%s]: %s\n", timestamp, category, description)
-	fmt.Fprintf(os.Stderr, "   ↳ Command: %s\n", command)
+
+	details := []string{"Command: " + command}
 	if len(args) > 0 {
-		fmt.Fprintf(os.Stderr, "   ↳ Args: [%s]\n", strings.Join(args, ", "))
+		details = append(details, fmt.Sprintf("Args: [%s]", strings.Join(args, ", ")))
 	}
+	write(Entry{Level: LevelDebug, Component: category, Message: description, Command: command, Args: args},
+		"🔍", details...)
 }
 
 // LogClaudeCommand specifically logs Claude command executions
 func LogClaudeCommand(prompt, description string) {
-	if !DebugEnabled {
+	if !enabled() {
 		return
 	}
-	
-	timestamp := time.Now().Format("15:04:05.000")
-	fmt.Fprintf(os.Stderr, "🤖 [%s] DEBUG [CLAUDE]: %s\n", timestamp, description)
-	fmt.Fprintf(os.Stderr, "   ↳ Prompt: %s\n", prompt)
-	fmt.Fprintf(os.Stderr, "   ↳ Full Command: claude -p \"%s\"\n", prompt)
+
+	command := fmt.Sprintf("claude -p \"%s\"", prompt)
+	write(Entry{Level: LevelDebug, Component: "CLAUDE", Message: description, Command: command},
+		"🤖", "Prompt: "+prompt, "Full Command: "+command)
 }
 
 // LogExecution logs the start and expected behavior of a command
 func LogExecution(category, action, expectedBehavior string) {
-	if !DebugEnabled {
+	if !enabled() {
 		return
 	}
-	
-	timestamp := time.Now().Format("15:04:05.000")
-	fmt.Fprintf(os.Stderr, "⚡ [%s] DEBUG [%s]: Starting %s\n", timestamp, category, action)
-	fmt.Fprintf(os.Stderr, "   ↳ Expected: %s\n", expectedBehavior)
+
+	mu.Lock()
+	started[category+"/"+action] = time.Now()
+	mu.Unlock()
+
+	write(Entry{Level: LevelDebug, Component: category, Action: action, Message: "Starting " + action, Expected: expectedBehavior},
+		"⚡", "Expected: "+expectedBehavior)
 }
 
-// LogResult logs the result of a command execution
+// LogResult logs the result of a command execution, with its duration when
+// its start was logged by LogExecution
 func LogResult(category, action, result string, success bool) {
-	if !DebugEnabled {
+	if !enabled() {
 		return
 	}
-	
-	timestamp := time.Now().Format("15:04:05.000")
+
+	entry := Entry{Level: LevelDebug, Component: category, Action: action, Message: action + " completed", Result: result, Success: &success}
 	status := "✅"
 	if !success {
+		entry.Level = LevelError
 		status = "❌"
 	}
-	
-	fmt.Fprintf(os.Stderr, "%s [%s] DEBUG [%s]: %s completed\n", status, timestamp, category, action)
-	fmt.Fprintf(os.Stderr, "   ↳ Result: %s\n", result)
+
+	mu.Lock()
+	if start, ok := started[category+"/"+action]; ok {
+		durationMs := time.Since(start).Milliseconds()
+		entry.DurationMs = &durationMs
+		delete(started, category+"/"+action)
+	}
+	mu.Unlock()
+
+	details := []string{"Result: " + result}
+	if entry.DurationMs != nil {
+		details = append(details, fmt.Sprintf("Duration: %dms", *entry.DurationMs))
+	}
+	write(entry, status, details...)
 }
 
 // LogStub logs when a stub function is called (should not happen in production)
 func LogStub(category, functionName, shouldDo string) {
-	if !DebugEnabled {
+	if !enabled() {
 		return
 	}
-	
-	timestamp := time.Now().Format("15:04:05.000")
-	fmt.Fprintf(os.Stderr, "🚨 [%s] DEBUG [%s]: STUB CALLED: %s\n", timestamp, category, functionName)
-	fmt.Fprintf(os.Stderr, "   ↳ Should do: %s\n", shouldDo)
-	fmt.Fprintf(os.Stderr, "   ↳ Current: Does nothing (stub implementation)\n")
-}
\ No newline at end of file
+
+	write(Entry{Level: LevelWarn, Component: category, Action: functionName, Message: "STUB CALLED: " + functionName, Expected: shouldDo},
+		"🚨", "Should do: "+shouldDo, "Current: Does nothing (stub implementation)")
+}
@@ -0,0 +1,120 @@
+package debug
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useTestLog enables debug mode with output to a buffer and restores the
+// defaults after the test
+func useTestLog(t *testing.T, jsonOutput bool) *bytes.Buffer {
+	var buf bytes.Buffer
+	SetDebugMode(true)
+	SetJSONMode(jsonOutput)
+	SetOutput(&buf)
+	SetCorrelationID("0f8fad5b-d9cb-469f-a165-70867728950e")
+	t.Cleanup(func() {
+		SetDebugMode(false)
+		SetJSONMode(false)
+		SetOutput(nil)
+		SetCorrelationID("")
+	})
+	return &buf
+}
+
+func decodeEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var entries []map[string]interface{}
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry), scanner.Text())
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestJSONMode(t *testing.T) {
+	buf := useTestLog(t, true)
+
+	LogExecution("TICKET", "createTicket", "Create a ticket")
+	LogResult("TICKET", "createTicket", "TICKET-001 created", true)
+	LogCommandWithArgs("GIT", "Commit changes", "git", []string{"commit", "-m", "msg"})
+
+	entries := decodeEntries(t, buf)
+	require.Len(t, entries, 3)
+	for _, entry := range entries {
+		assert.Equal(t, "0f8fad5b-d9cb-469f-a165-70867728950e", entry["correlation_id"])
+		assert.NotEmpty(t, entry["ts"])
+	}
+
+	assert.Equal(t, "DEBUG", entries[0]["level"])
+	assert.Equal(t, "TICKET", entries[0]["component"])
+	assert.Equal(t, "createTicket", entries[0]["action"])
+	assert.NotContains(t, entries[0], "duration_ms")
+
+	assert.Equal(t, "createTicket completed", entries[1]["message"])
+	assert.Contains(t, entries[1], "duration_ms")
+	assert.Equal(t, true, entries[1]["success"])
+
+	assert.Equal(t, []interface{}{"commit", "-m", "msg"}, entries[2]["args"])
+}
+
+func TestJSONMode_FailedResult(t *testing.T) {
+	buf := useTestLog(t, true)
+
+	LogResult("EPIC", "deleteEpic", "not found", false)
+
+	entries := decodeEntries(t, buf)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ERROR", entries[0]["level"])
+	assert.Equal(t, false, entries[0]["success"])
+}
+
+func TestTextMode_CorrelationID(t *testing.T) {
+	buf := useTestLog(t, false)
+
+	LogExecution("TICKET", "createTicket", "Create a ticket")
+
+	output := buf.String()
+	assert.Contains(t, output, "DEBUG [TICKET] (0f8fad5b): Starting createTicket")
+	assert.Contains(t, output, "↳ Expected: Create a ticket")
+}
+
+func TestDisabled(t *testing.T) {
+	buf := useTestLog(t, true)
+	SetDebugMode(false)
+
+	LogExecution("TICKET", "createTicket", "Create a ticket")
+	assert.Empty(t, buf.String())
+}
+
+func TestSetOutputFile(t *testing.T) {
+	useTestLog(t, true)
+	path := filepath.Join(t.TempDir(), "debug.log")
+	require.NoError(t, SetOutputFile(path))
+
+	LogCommand("CLAUDE", "Run prompt", "claude -p hello")
+	require.NoError(t, Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(data), "\n"))
+	assert.Contains(t, string(data), `"command":"claude -p hello"`)
+
+	assert.Error(t, SetOutputFile(filepath.Join(t.TempDir(), "missing", "debug.log")))
+}
+
+func TestNewCorrelationID(t *testing.T) {
+	id := NewCorrelationID()
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), id)
+	assert.NotEqual(t, id, NewCorrelationID())
+}
@@ -274,4 +274,29 @@ func TestDiffTrees_BinaryContent(t *testing.T) {
 	}
 
 	assert.Equal(t, expected, changes)
-}
\ No newline at end of file
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	fsA := fstest.MapFS{
+		"system/commands/plan.md": &fstest.MapFile{Data: []byte("# Plan\nstep one\nstep two\n")},
+	}
+	fsB := fstest.MapFS{
+		"commands/plan.md": &fstest.MapFile{Data: []byte("# Plan\nstep one\nstep 2\n")},
+	}
+
+	unified, err := UnifiedDiff(fsA, "system", "system", fsB, ".", "runtime", "commands/plan.md")
+	require.NoError(t, err)
+
+	expected := "--- system/commands/plan.md\n" +
+		"+++ runtime/commands/plan.md\n" +
+		"@@ -1,3 +1,3 @@\n" +
+		" # Plan\n" +
+		" step one\n" +
+		"-step two\n" +
+		"+step 2\n"
+	assert.Equal(t, expected, unified)
+
+	unchanged, err := UnifiedDiff(fsA, "system", "system", fsA, "system", "system", "commands/plan.md")
+	require.NoError(t, err)
+	assert.Empty(t, unchanged)
+}
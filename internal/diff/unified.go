@@ -0,0 +1,46 @@
+package diff
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// UnifiedDiff returns a unified diff of path between the A and B trees, labelled
+// aLabel/path and bLabel/path. It returns "" when the files are identical.
+func UnifiedDiff(a fs.FS, aRoot, aLabel string, b fs.FS, bRoot, bLabel, file string) (string, error) {
+	aData, err := fs.ReadFile(a, path.Join(aRoot, file))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s from A: %w", file, err)
+	}
+	bData, err := fs.ReadFile(b, path.Join(bRoot, file))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s from B: %w", file, err)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(aData),
+		B:        splitLines(bData),
+		FromFile: path.Join(aLabel, file),
+		ToFile:   path.Join(bLabel, file),
+		Context:  3,
+	})
+}
+
+// splitLines splits data into newline-terminated lines, terminating the last
+// line if the file does not end with a newline
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+	return lines
+}
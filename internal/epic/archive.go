@@ -0,0 +1,139 @@
+package epic
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"claude-wm-cli/internal/backup"
+)
+
+// archiveTag marks the backups that are archived epics
+const archiveTag = "epic-archive"
+
+// BackupEpic saves a copy of an epic, with its user stories, in the project
+// backups (.backups) before it is deleted. The archive can be listed and
+// restored with the backup commands.
+func (m *Manager) BackupEpic(epicID string) (*backup.BackupMetadata, error) {
+	ep, err := m.GetEpic(epicID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(ep, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal epic: %w", err)
+	}
+
+	// Stage the epic next to epics.json; backups of this file are the archives
+	staging := filepath.Join(m.rootPath, "docs", "1-project", epicID+".json")
+	if err := os.WriteFile(staging, data, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write epic archive: %w", err)
+	}
+	defer os.Remove(staging)
+
+	cfg := backup.DefaultBackupConfig()
+	cfg.BackupDirectory = filepath.Join(m.rootPath, cfg.BackupDirectory)
+	cfg.AutoBackup = false
+	backups, err := backup.NewManager(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize backup manager: %w", err)
+	}
+
+	result, err := backups.CreateBackup(&backup.BackupRequest{
+		SourceFile:  staging,
+		Type:        backup.BackupTypeManual,
+		Reason:      backup.ReasonUserRequest,
+		Tags:        []string{archiveTag, epicID},
+		Compress:    true,
+		Verify:      true,
+		Force:       true,
+		Description: fmt.Sprintf("Archive of epic %s before deletion", epicID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("failed to archive epic: %v", result.Error)
+	}
+	return result.Metadata, nil
+}
+
+// ArchiveEpic moves a completed or cancelled epic to the archived epics of
+// epics.json, out of the epic list, after backing up the file. Its stories
+// in stories.json are archived separately, with
+// story.Generator.ArchiveStoriesForEpic.
+func (m *Manager) ArchiveEpic(epicID string) error {
+	collection, err := m.loadEpicCollection()
+	if err != nil {
+		return fmt.Errorf("failed to load epic collection: %w", err)
+	}
+
+	epic, exists := collection.Epics[epicID]
+	if !exists {
+		if _, archived := collection.ArchivedEpics[epicID]; archived {
+			return fmt.Errorf("epic %s is already archived", epicID)
+		}
+		return fmt.Errorf("epic not found: %s", epicID)
+	}
+	if epic.Status != StatusCompleted && epic.Status != StatusCancelled {
+		return fmt.Errorf("cannot archive epic %s with status %s: only completed or cancelled epics can be archived", epicID, epic.Status)
+	}
+
+	if err := m.backupEpicsFile(fmt.Sprintf("Automatic backup of epics.json before archiving %s", epicID)); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	epic.ArchivedAt = &now
+	if collection.ArchivedEpics == nil {
+		collection.ArchivedEpics = make(map[string]*Epic)
+	}
+	collection.ArchivedEpics[epicID] = epic
+	delete(collection.Epics, epicID)
+	if collection.CurrentEpic == epicID {
+		collection.CurrentEpic = ""
+	}
+	collection.Metadata.TotalEpics = len(collection.Epics)
+
+	return m.saveEpicCollection(collection)
+}
+
+// UnarchiveEpic moves an archived epic back to the epics of epics.json, after
+// backing up the file
+func (m *Manager) UnarchiveEpic(epicID string) error {
+	collection, err := m.loadEpicCollection()
+	if err != nil {
+		return fmt.Errorf("failed to load epic collection: %w", err)
+	}
+
+	epic, archived := collection.ArchivedEpics[epicID]
+	if !archived {
+		if _, exists := collection.Epics[epicID]; exists {
+			return fmt.Errorf("epic %s is not archived", epicID)
+		}
+		return fmt.Errorf("epic not found: %s", epicID)
+	}
+
+	if err := m.backupEpicsFile(fmt.Sprintf("Automatic backup of epics.json before unarchiving %s", epicID)); err != nil {
+		return err
+	}
+
+	epic.ArchivedAt = nil
+	collection.Epics[epicID] = epic
+	delete(collection.ArchivedEpics, epicID)
+	collection.Metadata.TotalEpics = len(collection.Epics)
+
+	return m.saveEpicCollection(collection)
+}
+
+// backupEpicsFile backs up epics.json before it is archived from or restored to
+func (m *Manager) backupEpicsFile(description string) error {
+	epicsPath := filepath.Join(m.rootPath, "docs", "1-project", EpicsFileName)
+	if _, err := backup.BackupFile(epicsPath, backup.BackupTypeAutomatic, description); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", EpicsFileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package epic
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInsufficientBurndownData is returned when an epic has no stories, or no
+// start date, state history or story completion to build a burndown from
+var ErrInsufficientBurndownData = errors.New("insufficient data: the epic needs stories and a start date, state history or completed stories")
+
+// BurndownStory is a story counted by a burndown. CompletedAt is nil for
+// stories that are not completed, or whose completion time is unknown.
+type BurndownStory struct {
+	ID          string
+	Points      int
+	Completed   bool
+	StartedAt   *time.Time // When the story went in progress, for cycle times
+	CompletedAt *time.Time
+}
+
+// BurndownDay is the remaining work at the end of one day
+type BurndownDay struct {
+	Date      time.Time
+	Completed int     // Work completed that day
+	Remaining int     // Work remaining at the end of the day
+	Ideal     float64 // Remaining work on a straight line from the start to the target, -1 without target
+}
+
+// Burndown is the day-by-day remaining work of an epic
+type Burndown struct {
+	EpicID  string
+	Unit    string // "points", or "stories" when no story has points
+	Total   int
+	Start   time.Time
+	Target  *time.Time
+	Undated int // Completed stories without completion time, counted as done from the start
+	Days    []BurndownDay
+}
+
+// ComputeBurndown builds the burndown of an epic from its start (the start
+// date, or the first transition to in progress) to its end date, or now for an
+// open epic. The ideal line goes down to zero at target, when given.
+func ComputeBurndown(epic *Epic, history []StateTransition, stories []BurndownStory, target *time.Time, now time.Time) (*Burndown, error) {
+	burndown := &Burndown{EpicID: epic.ID, Unit: "points", Target: target}
+
+	usePoints := false
+	for _, story := range stories {
+		if story.Points > 0 {
+			usePoints = true
+			break
+		}
+	}
+	if !usePoints {
+		burndown.Unit = "stories"
+	}
+	weight := func(story BurndownStory) int {
+		if usePoints {
+			return story.Points
+		}
+		return 1
+	}
+
+	start, ok := burndownStart(epic, history, stories)
+	if !ok || len(stories) == 0 {
+		return nil, ErrInsufficientBurndownData
+	}
+	burndown.Start = start
+
+	end := now
+	if epic.EndDate != nil {
+		end = *epic.EndDate
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	// Work completed per day; undated completions count from the first day,
+	// completions outside of the burndown from its first or last day
+	first, last := startOfDay(start), startOfDay(end)
+	completedByDay := make(map[time.Time]int)
+	remaining := 0
+	for _, story := range stories {
+		burndown.Total += weight(story)
+		remaining += weight(story)
+		if !story.Completed {
+			continue
+		}
+		day := first
+		if story.CompletedAt != nil {
+			day = startOfDay(*story.CompletedAt)
+			if day.Before(first) {
+				day = first
+			}
+			if day.After(last) {
+				day = last
+			}
+		} else {
+			burndown.Undated++
+		}
+		completedByDay[day] += weight(story)
+	}
+
+	var targetDays float64
+	if target != nil && target.After(start) {
+		targetDays = startOfDay(*target).Sub(first).Hours() / 24
+	}
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		completed := completedByDay[day]
+		remaining -= completed
+
+		ideal := -1.0
+		if targetDays > 0 {
+			elapsed := day.Sub(first).Hours() / 24
+			ideal = float64(burndown.Total) * (1 - elapsed/targetDays)
+			if ideal < 0 {
+				ideal = 0
+			}
+		}
+
+		burndown.Days = append(burndown.Days, BurndownDay{
+			Date:      day,
+			Completed: completed,
+			Remaining: remaining,
+			Ideal:     ideal,
+		})
+	}
+
+	return burndown, nil
+}
+
+// burndownStart returns when work on an epic started: its start date, its
+// first transition to in progress, or its first story completion
+func burndownStart(epic *Epic, history []StateTransition, stories []BurndownStory) (time.Time, bool) {
+	if epic.StartDate != nil {
+		return *epic.StartDate, true
+	}
+	for _, transition := range history {
+		if transition.ToStatus == StatusInProgress {
+			return transition.Timestamp, true
+		}
+	}
+
+	var first *time.Time
+	for _, story := range stories {
+		if story.CompletedAt != nil && (first == nil || story.CompletedAt.Before(*first)) {
+			first = story.CompletedAt
+		}
+	}
+	if first == nil {
+		return time.Time{}, false
+	}
+	return *first, true
+}
+
+// startOfDay truncates t to midnight local time, so that days can be compared
+// whatever the time zone t was recorded in
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Local().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,69 @@
+package epic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeBurndown(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.Local)
+	day := func(n int) *time.Time {
+		d := start.AddDate(0, 0, n).Add(3 * time.Hour)
+		return &d
+	}
+	target := start.AddDate(0, 0, 4)
+
+	ep := &Epic{ID: "EPIC-001", StartDate: &start}
+	stories := []BurndownStory{
+		{ID: "S1", Points: 3, Completed: true, CompletedAt: day(1)},
+		{ID: "S2", Points: 2, Completed: true, CompletedAt: day(1)},
+		{ID: "S3", Points: 5, Completed: true, CompletedAt: day(3)},
+		{ID: "S4", Points: 2},
+	}
+
+	burndown, err := ComputeBurndown(ep, nil, stories, &target, *day(3))
+	require.NoError(t, err)
+
+	assert.Equal(t, "points", burndown.Unit)
+	assert.Equal(t, 12, burndown.Total)
+	require.Len(t, burndown.Days, 4)
+
+	remaining := []int{12, 7, 7, 2}
+	ideal := []float64{12, 9, 6, 3}
+	for i, d := range burndown.Days {
+		assert.Equal(t, remaining[i], d.Remaining, "day %d", i)
+		assert.InDelta(t, ideal[i], d.Ideal, 0.001, "day %d", i)
+	}
+	assert.Equal(t, 5, burndown.Days[1].Completed)
+}
+
+func TestComputeBurndown_CountsStoriesWithoutPoints(t *testing.T) {
+	ep := &Epic{ID: "EPIC-001"}
+	started := time.Date(2025, 3, 1, 9, 0, 0, 0, time.Local)
+	history := []StateTransition{{FromStatus: StatusPlanned, ToStatus: StatusInProgress, Timestamp: started}}
+	stories := []BurndownStory{{ID: "S1", Completed: true}, {ID: "S2"}}
+
+	burndown, err := ComputeBurndown(ep, history, stories, nil, started.AddDate(0, 0, 1))
+	require.NoError(t, err)
+
+	assert.Equal(t, "stories", burndown.Unit)
+	assert.Equal(t, 1, burndown.Undated)
+	require.Len(t, burndown.Days, 2)
+	assert.Equal(t, 1, burndown.Days[0].Remaining)
+	assert.Equal(t, -1.0, burndown.Days[0].Ideal)
+}
+
+func TestComputeBurndown_InsufficientData(t *testing.T) {
+	ep := &Epic{ID: "EPIC-001"}
+
+	_, err := ComputeBurndown(ep, nil, []BurndownStory{{ID: "S1", Points: 3}}, nil, time.Now())
+	assert.ErrorIs(t, err, ErrInsufficientBurndownData)
+
+	now := time.Now()
+	ep.StartDate = &now
+	_, err = ComputeBurndown(ep, nil, nil, nil, now)
+	assert.ErrorIs(t, err, ErrInsufficientBurndownData)
+}
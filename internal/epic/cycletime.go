@@ -0,0 +1,72 @@
+package epic
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MinCycleTimeSample is the number of completed stories needed for cycle
+// time statistics and throughput
+const MinCycleTimeSample = 2
+
+// applyCycleTime sets the story cycle time statistics and the throughput of
+// metrics from the start and completion times of stories. With fewer than
+// MinCycleTimeSample completed stories, they are left unset and the reason is
+// recorded in CycleTimeNote.
+func applyCycleTime(metrics *AdvancedMetrics, epic *Epic, stories []BurndownStory, now time.Time) {
+	var cycleTimes []time.Duration
+	completed := 0
+	for _, story := range stories {
+		if !story.Completed {
+			continue
+		}
+		completed++
+		if story.StartedAt != nil && story.CompletedAt != nil && !story.CompletedAt.Before(*story.StartedAt) {
+			cycleTimes = append(cycleTimes, story.CompletedAt.Sub(*story.StartedAt))
+		}
+	}
+
+	if completed < MinCycleTimeSample {
+		metrics.CycleTimeNote = fmt.Sprintf("only %d completed stories (need %d)", completed, MinCycleTimeSample)
+		return
+	}
+
+	if epic.StartDate != nil {
+		end := now
+		if epic.EndDate != nil {
+			end = *epic.EndDate
+		}
+		// Count at least one week, so that a young epic's throughput is not inflated
+		weeks := math.Max(end.Sub(*epic.StartDate).Hours()/(24*7), 1)
+		metrics.ThroughputPerWeek = float64(completed) / weeks
+	}
+
+	if len(cycleTimes) < MinCycleTimeSample {
+		metrics.CycleTimeNote = fmt.Sprintf("only %d completed stories have start and completion times (need %d)",
+			len(cycleTimes), MinCycleTimeSample)
+		return
+	}
+
+	metrics.CycleTimeSample = len(cycleTimes)
+	metrics.MinCycleTime, metrics.MaxCycleTime = cycleTimes[0], cycleTimes[0]
+	var total time.Duration
+	for _, cycleTime := range cycleTimes {
+		total += cycleTime
+		if cycleTime < metrics.MinCycleTime {
+			metrics.MinCycleTime = cycleTime
+		}
+		if cycleTime > metrics.MaxCycleTime {
+			metrics.MaxCycleTime = cycleTime
+		}
+	}
+	metrics.AvgCycleTime = total / time.Duration(len(cycleTimes))
+
+	// Population standard deviation
+	var variance float64
+	for _, cycleTime := range cycleTimes {
+		deviation := float64(cycleTime - metrics.AvgCycleTime)
+		variance += deviation * deviation
+	}
+	metrics.CycleTimeStdDev = time.Duration(math.Sqrt(variance / float64(len(cycleTimes))))
+}
@@ -0,0 +1,75 @@
+package epic
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyCycleTime(t *testing.T) {
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 21)
+	at := func(days int) *time.Time {
+		d := start.AddDate(0, 0, days)
+		return &d
+	}
+
+	ep := &Epic{ID: "EPIC-001", Status: StatusInProgress, StartDate: &start}
+	stories := []BurndownStory{
+		{ID: "S1", Completed: true, StartedAt: at(0), CompletedAt: at(2)},
+		{ID: "S2", Completed: true, StartedAt: at(3), CompletedAt: at(7)},
+		{ID: "S3", Completed: true, StartedAt: at(8), CompletedAt: at(14)},
+		{ID: "S4", Completed: true, CompletedAt: at(15)}, // No start time
+		{ID: "S5", StartedAt: at(16)},
+	}
+
+	metrics := &AdvancedMetrics{}
+	applyCycleTime(metrics, ep, stories, now)
+
+	day := 24 * time.Hour
+	assert.Empty(t, metrics.CycleTimeNote)
+	assert.Equal(t, 3, metrics.CycleTimeSample)
+	assert.Equal(t, 4*day, metrics.AvgCycleTime)
+	assert.Equal(t, 2*day, metrics.MinCycleTime)
+	assert.Equal(t, 6*day, metrics.MaxCycleTime)
+	// Deviations of -2, 0 and +2 days
+	assert.InDelta(t, float64(day)*math.Sqrt(8.0/3.0), float64(metrics.CycleTimeStdDev), float64(time.Second))
+	// 4 completed stories in 3 weeks
+	assert.InDelta(t, 4.0/3.0, metrics.ThroughputPerWeek, 0.001)
+}
+
+func TestApplyCycleTime_NotEnoughData(t *testing.T) {
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	completedAt := start.AddDate(0, 0, 2)
+	ep := &Epic{ID: "EPIC-001", Status: StatusInProgress, StartDate: &start}
+
+	metrics := &AdvancedMetrics{}
+	applyCycleTime(metrics, ep, []BurndownStory{
+		{ID: "S1", Completed: true, StartedAt: &start, CompletedAt: &completedAt},
+		{ID: "S2"},
+	}, start.AddDate(0, 0, 7))
+
+	assert.Equal(t, "only 1 completed stories (need 2)", metrics.CycleTimeNote)
+	assert.Zero(t, metrics.CycleTimeSample)
+	assert.Zero(t, metrics.AvgCycleTime)
+	assert.Zero(t, metrics.ThroughputPerWeek)
+}
+
+func TestApplyCycleTime_WithoutStartTimes(t *testing.T) {
+	start := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	now := start.AddDate(0, 0, 3)
+	ep := &Epic{ID: "EPIC-001", Status: StatusInProgress, StartDate: &start}
+
+	metrics := &AdvancedMetrics{}
+	applyCycleTime(metrics, ep, []BurndownStory{
+		{ID: "S1", Completed: true, CompletedAt: &now},
+		{ID: "S2", Completed: true},
+	}, now)
+
+	assert.Contains(t, metrics.CycleTimeNote, "only 0 completed stories have start and completion times")
+	assert.Zero(t, metrics.CycleTimeSample)
+	// Throughput only needs completed stories, over at least one week
+	assert.InDelta(t, 2.0, metrics.ThroughputPerWeek, 0.001)
+}
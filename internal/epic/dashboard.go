@@ -2,41 +2,89 @@ package epic
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 	"time"
+
+	"claude-wm-cli/internal/metrics"
 )
 
 // Dashboard provides epic progress visualization and analytics
 type Dashboard struct {
 	manager *Manager
+	stories func(epic *Epic) ([]BurndownStory, error) // Stories of an epic for its burndown chart
+	now     func() time.Time
 }
 
 // NewDashboard creates a new epic dashboard
 func NewDashboard(manager *Manager) *Dashboard {
 	return &Dashboard{
 		manager: manager,
+		stories: userStoriesForBurndown,
+		now:     time.Now,
 	}
 }
 
+// SetStorySource sets where the burndown charts get the stories of an epic
+// with their completion times. By default the epic's user stories are used,
+// which have no completion times.
+func (d *Dashboard) SetStorySource(source func(epic *Epic) ([]BurndownStory, error)) {
+	d.stories = source
+}
+
+// DashboardReport is the dashboard data for all epics, computed independently
+// of its rendering so that it can be displayed or exported as JSON
+type DashboardReport struct {
+	GeneratedAt     time.Time            `json:"generated_at"`
+	Summary         DashboardSummary     `json:"summary"`
+	Epics           []*EpicDashboardData `json:"epics"`
+	Risks           DashboardRisks       `json:"risks"`
+	Recommendations []string             `json:"recommendations"`
+}
+
+// DashboardSummary is the project overview across all epics
+type DashboardSummary struct {
+	TotalEpics            int     `json:"total_epics"`
+	ActiveEpics           int     `json:"active_epics"`
+	CompletedEpics        int     `json:"completed_epics"`
+	PlannedEpics          int     `json:"planned_epics"`
+	TotalStories          int     `json:"total_stories"`
+	CompletedStories      int     `json:"completed_stories"`
+	StoriesPercentage     float64 `json:"stories_percentage"`
+	TotalStoryPoints      int     `json:"total_story_points"`
+	CompletedStoryPoints  int     `json:"completed_story_points"`
+	StoryPointsPercentage float64 `json:"story_points_percentage"`
+}
+
+// DashboardRisks lists the IDs of the epics that need attention
+type DashboardRisks struct {
+	HighRisk          []string `json:"high_risk"`
+	Overdue           []string `json:"overdue"`
+	DecliningVelocity []string `json:"declining_velocity"`
+	Blocked           []string `json:"blocked"`
+}
+
 // EpicDashboardData contains comprehensive epic progress data
 type EpicDashboardData struct {
-	Epic            *Epic
-	ProgressMetrics ProgressSummary
-	RiskLevel       RiskLevel
-	Velocity        VelocityMetrics
-	Timeline        TimelineMetrics
+	Epic            *Epic           `json:"epic"`
+	ProgressMetrics ProgressSummary `json:"progress"`
+	RiskLevel       RiskLevel       `json:"risk_level"`
+	Velocity        VelocityMetrics `json:"velocity"`
+	Timeline        TimelineMetrics `json:"timeline"`
+	BlockedBy       []string        `json:"blocked_by,omitempty"` // IDs of the uncompleted epics this epic depends on
+	Recommendations []string        `json:"recommendations,omitempty"`
 }
 
 // ProgressSummary provides detailed progress information
 type ProgressSummary struct {
-	CompletionPercentage float64
-	StoriesCompleted     int
-	StoriesInProgress    int
-	StoriesPlanned       int
-	TotalStories         int
-	StoryPointsCompleted int
-	StoryPointsTotal     int
+	CompletionPercentage float64 `json:"completion_percentage"`
+	StoriesCompleted     int     `json:"stories_completed"`
+	StoriesInProgress    int     `json:"stories_in_progress"`
+	StoriesPlanned       int     `json:"stories_planned"`
+	TotalStories         int     `json:"total_stories"`
+	StoryPointsCompleted int     `json:"story_points_completed"`
+	StoryPointsTotal     int     `json:"story_points_total"`
 }
 
 // RiskLevel indicates the risk status of an epic
@@ -51,30 +99,37 @@ const (
 
 // VelocityMetrics tracks epic velocity and productivity
 type VelocityMetrics struct {
-	StoriesPerDay     float64
-	StoryPointsPerDay float64
-	AverageStoryDays  float64
-	CompletionTrend   string // "improving", "stable", "declining"
+	StoriesPerDay     float64 `json:"stories_per_day"`
+	StoryPointsPerDay float64 `json:"story_points_per_day"`
+	AverageStoryDays  float64 `json:"average_story_days"`
+	CompletionTrend   string  `json:"completion_trend"` // "improving", "stable", "declining"
 }
 
 // TimelineMetrics provides timeline analysis
 type TimelineMetrics struct {
-	DaysActive             int
-	EstimatedDaysRemaining int
-	OriginalEstimate       string
-	IsOverdue              bool
-	DaysOverdue            int
+	DaysActive             int    `json:"days_active"`
+	EstimatedDaysRemaining int    `json:"estimated_days_remaining"`
+	OriginalEstimate       string `json:"original_estimate,omitempty"`
+	IsOverdue              bool   `json:"is_overdue"`
+	DaysOverdue            int    `json:"days_overdue"`
 }
 
+// Recommendations given for the epics that need attention
+const (
+	recommendHighRisk  = "Review high-risk epics for blockers"
+	recommendOverdue   = "Update timelines for overdue epics"
+	recommendDeclining = "Investigate velocity decline causes"
+	recommendBlocked   = "Complete prerequisite epics before starting blocked ones"
+)
+
 // DisplayEpicDashboard shows a comprehensive dashboard for all epics
 func (d *Dashboard) DisplayEpicDashboard() error {
-	// Get all epics
-	epics, err := d.manager.ListEpics(EpicListOptions{})
+	report, err := d.BuildDashboardData()
 	if err != nil {
-		return fmt.Errorf("failed to get epics: %w", err)
+		return err
 	}
 
-	if len(epics) == 0 {
+	if len(report.Epics) == 0 {
 		fmt.Println("📊 Epic Dashboard")
 		fmt.Println("=================")
 		fmt.Println()
@@ -85,20 +140,58 @@ func (d *Dashboard) DisplayEpicDashboard() error {
 		return nil
 	}
 
+	// Display header
+	fmt.Println("📊 Epic Progress Dashboard")
+	fmt.Println("==========================")
+	fmt.Println()
+
+	// Display summary
+	d.displaySummary(report.Summary)
+	fmt.Println()
+
+	// Display each epic, with the burndown of the active ones
+	for _, data := range report.Epics {
+		d.displayEpicCard(data)
+		if data.Epic.Status == StatusInProgress {
+			d.displayBurndownChart(data.Epic)
+		}
+		fmt.Println()
+	}
+
+	// Display risk analysis
+	d.displayRiskAnalysis(report)
+
+	return nil
+}
+
+// BuildDashboardData computes the dashboard of all epics: the project summary,
+// the progress, risk, velocity and recommendations of each epic, active and
+// higher priority epics first, and the epics that need attention
+func (d *Dashboard) BuildDashboardData() (*DashboardReport, error) {
+	// Get all epics
+	epics, err := d.manager.ListEpics(EpicListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epics: %w", err)
+	}
+
+	report := &DashboardReport{
+		GeneratedAt:     time.Now(),
+		Epics:           []*EpicDashboardData{},
+		Recommendations: []string{},
+	}
+
 	// Gather dashboard data for all epics
-	var dashboardData []*EpicDashboardData
 	for _, epic := range epics {
-		data := d.GetEpicDashboardData(epic)
-		dashboardData = append(dashboardData, data)
+		report.Epics = append(report.Epics, d.GetEpicDashboardData(epic))
 	}
 
 	// Sort by priority and status
-	sort.Slice(dashboardData, func(i, j int) bool {
+	sort.Slice(report.Epics, func(i, j int) bool {
 		// Active epics first
-		if dashboardData[i].Epic.Status == StatusInProgress && dashboardData[j].Epic.Status != StatusInProgress {
+		if report.Epics[i].Epic.Status == StatusInProgress && report.Epics[j].Epic.Status != StatusInProgress {
 			return true
 		}
-		if dashboardData[i].Epic.Status != StatusInProgress && dashboardData[j].Epic.Status == StatusInProgress {
+		if report.Epics[i].Epic.Status != StatusInProgress && report.Epics[j].Epic.Status == StatusInProgress {
 			return false
 		}
 
@@ -109,28 +202,23 @@ func (d *Dashboard) DisplayEpicDashboard() error {
 			PriorityMedium:   2,
 			PriorityLow:      1,
 		}
-		return priorityOrder[dashboardData[i].Epic.Priority] > priorityOrder[dashboardData[j].Epic.Priority]
+		return priorityOrder[report.Epics[i].Epic.Priority] > priorityOrder[report.Epics[j].Epic.Priority]
 	})
 
-	// Display header
-	fmt.Println("📊 Epic Progress Dashboard")
-	fmt.Println("==========================")
-	fmt.Println()
-
-	// Display summary
-	d.displaySummary(dashboardData)
-	fmt.Println()
+	report.Summary = summarizeDashboard(report.Epics)
+	report.Risks = analyzeDashboardRisks(report.Epics)
 
-	// Display each epic
-	for _, data := range dashboardData {
-		d.displayEpicCard(data)
-		fmt.Println()
+	// Project recommendations, in a fixed order
+	for _, recommendation := range []string{recommendHighRisk, recommendOverdue, recommendDeclining, recommendBlocked} {
+		for _, data := range report.Epics {
+			if containsString(data.Recommendations, recommendation) {
+				report.Recommendations = append(report.Recommendations, recommendation)
+				break
+			}
+		}
 	}
 
-	// Display risk analysis
-	d.displayRiskAnalysis(dashboardData)
-
-	return nil
+	return report, nil
 }
 
 // GetEpicDashboardData gathers comprehensive data for a specific epic
@@ -147,42 +235,118 @@ func (d *Dashboard) GetEpicDashboardData(epic *Epic) *EpicDashboardData {
 	// Calculate timeline metrics
 	timelineMetrics := d.calculateTimelineMetrics(epic, progressMetrics, velocityMetrics)
 
-	return &EpicDashboardData{
+	data := &EpicDashboardData{
 		Epic:            epic,
 		ProgressMetrics: progressMetrics,
 		RiskLevel:       riskLevel,
 		Velocity:        velocityMetrics,
 		Timeline:        timelineMetrics,
+		BlockedBy:       d.blockingDependencies(epic),
 	}
+	data.Recommendations = epicRecommendations(data)
+	return data
 }
 
-// displaySummary shows an overview of all epics
-func (d *Dashboard) displaySummary(data []*EpicDashboardData) {
-	var totalEpics, completedEpics, activeEpics, plannedEpics int
-	var totalStories, completedStories int
-	var totalPoints, completedPoints int
+// epicRecommendations returns the recommendations for an epic that needs attention
+func epicRecommendations(data *EpicDashboardData) []string {
+	var recommendations []string
+	if data.RiskLevel == RiskHigh || data.RiskLevel == RiskCritical {
+		recommendations = append(recommendations, recommendHighRisk)
+	}
+	if data.Timeline.IsOverdue {
+		recommendations = append(recommendations, recommendOverdue)
+	}
+	if isVelocityDeclining(data) {
+		recommendations = append(recommendations, recommendDeclining)
+	}
+	if len(data.BlockedBy) > 0 {
+		recommendations = append(recommendations, recommendBlocked)
+	}
+	return recommendations
+}
+
+// isVelocityDeclining reports whether an active epic is slowing down
+func isVelocityDeclining(data *EpicDashboardData) bool {
+	return data.Velocity.CompletionTrend == "declining" && data.Epic.Status == StatusInProgress
+}
+
+// blockingDependencies lists the uncompleted dependencies of an open epic
+func (d *Dashboard) blockingDependencies(epic *Epic) []string {
+	if d.manager == nil || len(epic.Dependencies) == 0 ||
+		epic.Status == StatusCompleted || epic.Status == StatusCancelled {
+		return nil
+	}
+
+	unmet, err := d.manager.GetUnmetDependencies(epic.ID)
+	if err != nil {
+		return nil
+	}
+
+	var blockedBy []string
+	for _, dependency := range unmet {
+		blockedBy = append(blockedBy, dependency.ID)
+	}
+	return blockedBy
+}
+
+// summarizeDashboard computes the project overview of all epics
+func summarizeDashboard(data []*EpicDashboardData) DashboardSummary {
+	var summary DashboardSummary
 
 	for _, epic := range data {
-		totalEpics++
-		totalStories += epic.ProgressMetrics.TotalStories
-		completedStories += epic.ProgressMetrics.StoriesCompleted
-		totalPoints += epic.ProgressMetrics.StoryPointsTotal
-		completedPoints += epic.ProgressMetrics.StoryPointsCompleted
+		summary.TotalEpics++
+		summary.TotalStories += epic.ProgressMetrics.TotalStories
+		summary.CompletedStories += epic.ProgressMetrics.StoriesCompleted
+		summary.TotalStoryPoints += epic.ProgressMetrics.StoryPointsTotal
+		summary.CompletedStoryPoints += epic.ProgressMetrics.StoryPointsCompleted
 
 		switch epic.Epic.Status {
 		case StatusCompleted:
-			completedEpics++
+			summary.CompletedEpics++
 		case StatusInProgress:
-			activeEpics++
+			summary.ActiveEpics++
 		case StatusPlanned:
-			plannedEpics++
+			summary.PlannedEpics++
+		}
+	}
+
+	summary.StoriesPercentage = percentage(summary.CompletedStories, summary.TotalStories)
+	summary.StoryPointsPercentage = percentage(summary.CompletedStoryPoints, summary.TotalStoryPoints)
+	return summary
+}
+
+// analyzeDashboardRisks lists the epics that need attention
+func analyzeDashboardRisks(data []*EpicDashboardData) DashboardRisks {
+	risks := DashboardRisks{
+		HighRisk:          []string{},
+		Overdue:           []string{},
+		DecliningVelocity: []string{},
+		Blocked:           []string{},
+	}
+
+	for _, epic := range data {
+		if epic.RiskLevel == RiskHigh || epic.RiskLevel == RiskCritical {
+			risks.HighRisk = append(risks.HighRisk, epic.Epic.ID)
+		}
+		if epic.Timeline.IsOverdue {
+			risks.Overdue = append(risks.Overdue, epic.Epic.ID)
+		}
+		if isVelocityDeclining(epic) {
+			risks.DecliningVelocity = append(risks.DecliningVelocity, epic.Epic.ID)
+		}
+		if len(epic.BlockedBy) > 0 {
+			risks.Blocked = append(risks.Blocked, epic.Epic.ID)
 		}
 	}
+	return risks
+}
 
+// displaySummary shows an overview of all epics
+func (d *Dashboard) displaySummary(summary DashboardSummary) {
 	fmt.Printf("📈 Project Overview\n")
-	fmt.Printf("   Epics:        %d total (%d active, %d completed, %d planned)\n", totalEpics, activeEpics, completedEpics, plannedEpics)
-	fmt.Printf("   Stories:      %d/%d completed (%.1f%%)\n", completedStories, totalStories, percentage(completedStories, totalStories))
-	fmt.Printf("   Story Points: %d/%d completed (%.1f%%)\n", completedPoints, totalPoints, percentage(completedPoints, totalPoints))
+	fmt.Printf("   Epics:        %d total (%d active, %d completed, %d planned)\n", summary.TotalEpics, summary.ActiveEpics, summary.CompletedEpics, summary.PlannedEpics)
+	fmt.Printf("   Stories:      %d/%d completed (%.1f%%)\n", summary.CompletedStories, summary.TotalStories, summary.StoriesPercentage)
+	fmt.Printf("   Story Points: %d/%d completed (%.1f%%)\n", summary.CompletedStoryPoints, summary.TotalStoryPoints, summary.StoryPointsPercentage)
 }
 
 // displayEpicCard shows detailed information for one epic
@@ -199,6 +363,10 @@ func (d *Dashboard) displayEpicCard(data *EpicDashboardData) {
 	fmt.Printf("│  📋 %s\n", epic.Title)
 	fmt.Printf("│  🆔 %s\n", epic.ID)
 
+	if len(data.BlockedBy) > 0 {
+		fmt.Printf("│  ⛔ Blocked by: %s\n", strings.Join(data.BlockedBy, ", "))
+	}
+
 	// Progress bar
 	progressBar := d.createProgressBar(metrics.CompletionPercentage, 30)
 	fmt.Printf("│  %s %.1f%%\n", progressBar, metrics.CompletionPercentage)
@@ -259,25 +427,188 @@ func (d *Dashboard) displayEpicCard(data *EpicDashboardData) {
 	fmt.Printf("└─\n")
 }
 
+// displayBurndownChart shows the weekly burndown of an epic as a chart of the
+// ideal and actual remaining work, and whether it is behind schedule
+func (d *Dashboard) displayBurndownChart(epic *Epic) {
+	burndown, err := d.epicBurndown(epic)
+	if err != nil {
+		return
+	}
+
+	weeks := WeeklyBurndown(burndown)
+	fmt.Printf("   📉 Burndown (remaining %s per week)\n", burndown.Unit)
+	for _, line := range RenderBurndownChart(weeks) {
+		fmt.Printf("   %s\n", line)
+	}
+	if burndown.Target == nil {
+		fmt.Printf("   💡 Set an estimated duration (epic update %s --duration \"4 weeks\") to plot the ideal line\n", epic.ID)
+	} else if BehindSchedule(weeks) {
+		fmt.Println("   ⚠️ Behind schedule")
+	}
+}
+
+// epicBurndown computes the burndown of an epic. Its ideal line reaches zero
+// at the end of the epic's estimated duration, when it has one.
+func (d *Dashboard) epicBurndown(epic *Epic) (*Burndown, error) {
+	stories, err := d.stories(epic)
+	if err != nil {
+		return nil, err
+	}
+
+	history := d.manager.GetEpicStateHistory(epic.ID)
+	var target *time.Time
+	if days, ok := parseEpicDuration(epic.Duration); ok {
+		if start, ok := burndownStart(epic, history, stories); ok {
+			end := startOfDay(start).AddDate(0, 0, days)
+			target = &end
+		}
+	}
+
+	return ComputeBurndown(epic, history, stories, target, d.now())
+}
+
+// userStoriesForBurndown returns the user stories of an epic, without
+// completion times
+func userStoriesForBurndown(epic *Epic) ([]BurndownStory, error) {
+	var stories []BurndownStory
+	for _, story := range epic.UserStories {
+		stories = append(stories, BurndownStory{
+			ID:        story.ID,
+			Points:    story.StoryPoints,
+			Completed: story.Status == StatusCompleted,
+		})
+	}
+	return stories, nil
+}
+
+// burndownBehindRatio is how far above the ideal line the remaining work
+// can be before an epic is behind schedule
+const burndownBehindRatio = 0.2
+
+// burndownChartHeight is the number of rows of a burndown chart
+const burndownChartHeight = 8
+
+// BurndownWeek is the remaining work of an epic at the start (week 0) or at
+// the end of a calendar week since the epic started
+type BurndownWeek struct {
+	Week      int
+	Date      time.Time
+	Remaining int
+	Ideal     float64 // -1 without target
+}
+
+// WeeklyBurndown samples a daily burndown at its start and at the end of
+// every week, the last one possibly partial. The ideal line goes from the
+// total down to zero at the burndown target.
+func WeeklyBurndown(burndown *Burndown) []BurndownWeek {
+	if len(burndown.Days) == 0 {
+		return nil
+	}
+
+	first := burndown.Days[0].Date
+	var targetDays float64
+	if burndown.Target != nil {
+		targetDays = startOfDay(*burndown.Target).Sub(first).Hours() / 24
+	}
+	ideal := func(elapsedDays int) float64 {
+		if targetDays <= 0 {
+			return -1
+		}
+		return math.Max(0, float64(burndown.Total)*(1-float64(elapsedDays)/targetDays))
+	}
+
+	weeks := []BurndownWeek{{Week: 0, Date: first, Remaining: burndown.Total, Ideal: ideal(0)}}
+	for week := 1; (week-1)*7 < len(burndown.Days); week++ {
+		elapsed := week * 7
+		if elapsed > len(burndown.Days) {
+			elapsed = len(burndown.Days)
+		}
+		day := burndown.Days[elapsed-1]
+		weeks = append(weeks, BurndownWeek{
+			Week:      week,
+			Date:      day.Date,
+			Remaining: day.Remaining,
+			Ideal:     ideal(elapsed),
+		})
+	}
+	return weeks
+}
+
+// BehindSchedule reports whether the latest remaining work is more than 20%
+// above the ideal line. Without ideal line, an epic is never behind.
+func BehindSchedule(weeks []BurndownWeek) bool {
+	if len(weeks) == 0 {
+		return false
+	}
+	latest := weeks[len(weeks)-1]
+	if latest.Ideal < 0 {
+		return false
+	}
+	return float64(latest.Remaining) > latest.Ideal*(1+burndownBehindRatio)
+}
+
+// RenderBurndownChart returns the lines of a chart of the ideal and actual
+// remaining work per week
+func RenderBurndownChart(weeks []BurndownWeek) []string {
+	labels := make([]string, len(weeks))
+	actual := make([]float64, len(weeks))
+	ideal := make([]float64, len(weeks))
+	for i, week := range weeks {
+		labels[i] = fmt.Sprintf("W%d", week.Week)
+		actual[i] = float64(week.Remaining)
+		ideal[i] = week.Ideal
+	}
+
+	return metrics.RenderLineChart([]metrics.ChartSeries{
+		{Name: "ideal", Marker: '.', Values: ideal},
+		{Name: "actual", Marker: 'o', Values: actual},
+	}, labels, burndownChartHeight)
+}
+
+// parseEpicDuration returns the number of days of an estimated duration such
+// as "10 days", "2 weeks" or "1 month"
+func parseEpicDuration(duration string) (int, bool) {
+	var count int
+	var unit string
+	if _, err := fmt.Sscanf(strings.ToLower(strings.TrimSpace(duration)), "%d %s", &count, &unit); err != nil || count <= 0 {
+		return 0, false
+	}
+
+	switch strings.TrimSuffix(unit, "s") {
+	case "day":
+		return count, true
+	case "week":
+		return count * 7, true
+	case "month":
+		return count * 30, true
+	default:
+		return 0, false
+	}
+}
+
 // displayRiskAnalysis shows epics that need attention
-func (d *Dashboard) displayRiskAnalysis(data []*EpicDashboardData) {
+func (d *Dashboard) displayRiskAnalysis(report *DashboardReport) {
 	var highRiskEpics []*EpicDashboardData
 	var overdueEpics []*EpicDashboardData
 	var stagnantEpics []*EpicDashboardData
+	var blockedEpics []*EpicDashboardData
 
-	for _, epic := range data {
-		if epic.RiskLevel == RiskHigh || epic.RiskLevel == RiskCritical {
+	for _, epic := range report.Epics {
+		if containsString(report.Risks.HighRisk, epic.Epic.ID) {
 			highRiskEpics = append(highRiskEpics, epic)
 		}
-		if epic.Timeline.IsOverdue {
+		if containsString(report.Risks.Overdue, epic.Epic.ID) {
 			overdueEpics = append(overdueEpics, epic)
 		}
-		if epic.Velocity.CompletionTrend == "declining" && epic.Epic.Status == StatusInProgress {
+		if containsString(report.Risks.DecliningVelocity, epic.Epic.ID) {
 			stagnantEpics = append(stagnantEpics, epic)
 		}
+		if containsString(report.Risks.Blocked, epic.Epic.ID) {
+			blockedEpics = append(blockedEpics, epic)
+		}
 	}
 
-	if len(highRiskEpics) > 0 || len(overdueEpics) > 0 || len(stagnantEpics) > 0 {
+	if len(highRiskEpics) > 0 || len(overdueEpics) > 0 || len(stagnantEpics) > 0 || len(blockedEpics) > 0 {
 		fmt.Println("⚠️  Risk Analysis")
 		fmt.Println("================")
 		fmt.Println()
@@ -306,15 +637,17 @@ func (d *Dashboard) displayRiskAnalysis(data []*EpicDashboardData) {
 			fmt.Println()
 		}
 
-		fmt.Println("💡 Recommendations:")
-		if len(highRiskEpics) > 0 {
-			fmt.Println("   • Review high-risk epics for blockers")
-		}
-		if len(overdueEpics) > 0 {
-			fmt.Println("   • Update timelines for overdue epics")
+		if len(blockedEpics) > 0 {
+			fmt.Printf("⛔ Blocked Epics (%d):\n", len(blockedEpics))
+			for _, epic := range blockedEpics {
+				fmt.Printf("   • %s - waiting on %s\n", epic.Epic.ID, strings.Join(epic.BlockedBy, ", "))
+			}
+			fmt.Println()
 		}
-		if len(stagnantEpics) > 0 {
-			fmt.Println("   • Investigate velocity decline causes")
+
+		fmt.Println("💡 Recommendations:")
+		for _, recommendation := range report.Recommendations {
+			fmt.Printf("   • %s\n", recommendation)
 		}
 	}
 }
@@ -550,3 +883,12 @@ func truncateText(text string, maxLen int) string {
 	}
 	return text[:maxLen-3] + "..."
 }
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,13 @@
 package epic
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -92,6 +97,47 @@ func TestDashboard_GetEpicDashboardData(t *testing.T) {
 	assert.Equal(t, "2 weeks", data.Timeline.OriginalEstimate)
 }
 
+func TestDashboard_BuildDashboardData(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	manager := NewManager(tempDir)
+	dashboard := NewDashboard(manager)
+
+	report, err := dashboard.BuildDashboardData()
+	require.NoError(t, err)
+	assert.Empty(t, report.Epics)
+	assert.Empty(t, report.Recommendations)
+
+	auth, err := manager.CreateEpic(EpicCreateOptions{Title: "Auth", Priority: PriorityLow})
+	require.NoError(t, err)
+	billing, err := manager.CreateEpic(EpicCreateOptions{Title: "Billing", Priority: PriorityHigh, Dependencies: []string{auth.ID}})
+	require.NoError(t, err)
+
+	report, err = dashboard.BuildDashboardData()
+	require.NoError(t, err)
+
+	// Higher priority first
+	require.Len(t, report.Epics, 2)
+	assert.Equal(t, billing.ID, report.Epics[0].Epic.ID)
+	assert.Equal(t, auth.ID, report.Epics[1].Epic.ID)
+
+	assert.Equal(t, 2, report.Summary.TotalEpics)
+	assert.Equal(t, 2, report.Summary.PlannedEpics)
+
+	assert.Equal(t, []string{auth.ID}, report.Epics[0].BlockedBy)
+	assert.Equal(t, []string{recommendBlocked}, report.Epics[0].Recommendations)
+	assert.Empty(t, report.Epics[1].Recommendations)
+	assert.Equal(t, []string{billing.ID}, report.Risks.Blocked)
+	assert.Equal(t, []string{recommendBlocked}, report.Recommendations)
+
+	// The report is exported as JSON by `epic dashboard --json`
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"blocked_by":["`+auth.ID+`"]`)
+	assert.Contains(t, string(data), `"total_epics":2`)
+}
+
 func TestDashboard_CalculateProgressMetrics(t *testing.T) {
 	tempDir := t.TempDir()
 	setupTestDirs(t, tempDir)
@@ -321,6 +367,136 @@ func TestDashboard_HelperFunctions(t *testing.T) {
 }
 
 // Helper function to setup test directories
+func captureOutput(f func()) string {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	f()
+
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
+func burndownTestDashboard(t *testing.T, completions ...int) (*Dashboard, *Epic) {
+	t.Helper()
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	epic := &Epic{ID: "EPIC-001", Status: StatusInProgress, Duration: "4 weeks", StartDate: &start}
+
+	// Four stories of 5 points, completed the given number of days after the start
+	var stories []BurndownStory
+	for i := 0; i < 4; i++ {
+		story := BurndownStory{ID: fmt.Sprintf("STORY-%d", i+1), Points: 5}
+		if i < len(completions) {
+			completedAt := start.AddDate(0, 0, completions[i])
+			story.Completed = true
+			story.CompletedAt = &completedAt
+		}
+		stories = append(stories, story)
+	}
+
+	dashboard := NewDashboard(NewManager(tempDir))
+	dashboard.SetStorySource(func(*Epic) ([]BurndownStory, error) { return stories, nil })
+	dashboard.now = func() time.Time { return start.AddDate(0, 0, 20) }
+	return dashboard, epic
+}
+
+func TestDashboard_WeeklyBurndown(t *testing.T) {
+	dashboard, epic := burndownTestDashboard(t, 2, 9)
+
+	burndown, err := dashboard.epicBurndown(epic)
+	require.NoError(t, err)
+	weeks := WeeklyBurndown(burndown)
+
+	require.Len(t, weeks, 4)
+	assert.Equal(t, []int{20, 15, 10, 10}, []int{weeks[0].Remaining, weeks[1].Remaining, weeks[2].Remaining, weeks[3].Remaining})
+	assert.Equal(t, []float64{20, 15, 10, 5}, []float64{weeks[0].Ideal, weeks[1].Ideal, weeks[2].Ideal, weeks[3].Ideal})
+	assert.Equal(t, 3, weeks[3].Week)
+	assert.True(t, BehindSchedule(weeks), "10 points remaining for 5 ideal")
+}
+
+func TestDashboard_WeeklyBurndown_OnSchedule(t *testing.T) {
+	dashboard, epic := burndownTestDashboard(t, 2, 9, 15)
+
+	burndown, err := dashboard.epicBurndown(epic)
+	require.NoError(t, err)
+	weeks := WeeklyBurndown(burndown)
+
+	require.Len(t, weeks, 4)
+	assert.Equal(t, 5, weeks[3].Remaining)
+	assert.False(t, BehindSchedule(weeks))
+}
+
+func TestDashboard_WeeklyBurndown_WithoutDuration(t *testing.T) {
+	dashboard, epic := burndownTestDashboard(t, 2)
+	epic.Duration = ""
+
+	burndown, err := dashboard.epicBurndown(epic)
+	require.NoError(t, err)
+	weeks := WeeklyBurndown(burndown)
+
+	assert.Equal(t, -1.0, weeks[1].Ideal)
+	assert.False(t, BehindSchedule(weeks))
+}
+
+func TestDashboard_RenderBurndownChart(t *testing.T) {
+	weeks := []BurndownWeek{
+		{Week: 0, Remaining: 20, Ideal: 20},
+		{Week: 1, Remaining: 15, Ideal: 15},
+		{Week: 2, Remaining: 10, Ideal: 10},
+		{Week: 3, Remaining: 10, Ideal: 5},
+	}
+
+	lines := RenderBurndownChart(weeks)
+	output := strings.Join(lines, "\n")
+	assert.Contains(t, output, "W0  W1  W2  W3")
+	assert.Contains(t, output, ". ideal   o actual")
+	assert.True(t, strings.HasPrefix(lines[0], "20 |"))
+	assert.Contains(t, lines[0], "*", "actual and ideal start together")
+}
+
+func TestDashboard_DisplayBurndownChart(t *testing.T) {
+	dashboard, epic := burndownTestDashboard(t, 2, 9)
+
+	output := captureOutput(func() { dashboard.displayBurndownChart(epic) })
+	assert.Contains(t, output, "Burndown (remaining points per week)")
+	assert.Contains(t, output, "W3")
+	assert.Contains(t, output, "⚠️ Behind schedule")
+
+	dashboard, epic = burndownTestDashboard(t, 2, 9, 15)
+	output = captureOutput(func() { dashboard.displayBurndownChart(epic) })
+	assert.NotContains(t, output, "Behind schedule")
+}
+
+func TestParseEpicDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		days     int
+		ok       bool
+	}{
+		{"10 days", 10, true},
+		{"1 week", 7, true},
+		{"2 Weeks", 14, true},
+		{"1 month", 30, true},
+		{"", 0, false},
+		{"soon", 0, false},
+		{"3 sprints", 0, false},
+	}
+
+	for _, tt := range tests {
+		days, ok := parseEpicDuration(tt.duration)
+		assert.Equal(t, tt.ok, ok, tt.duration)
+		assert.Equal(t, tt.days, days, tt.duration)
+	}
+}
+
 func setupTestDirs(t *testing.T, tempDir string) {
 	docsDir := filepath.Join(tempDir, "docs", "1-project")
 	err := os.MkdirAll(docsDir, 0755)
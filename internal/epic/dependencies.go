@@ -0,0 +1,119 @@
+package epic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// normalizeDependencies trims dependency IDs and drops empty and duplicate entries
+func normalizeDependencies(dependencies []string) []string {
+	seen := make(map[string]bool)
+	normalized := []string{}
+	for _, id := range dependencies {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		normalized = append(normalized, id)
+	}
+	return normalized
+}
+
+// validateDependencies checks that epicID may depend on dependencies: every
+// dependency must exist, and none may lead back to epicID
+func validateDependencies(collection *EpicCollection, epicID string, dependencies []string) error {
+	for _, id := range dependencies {
+		if id == epicID {
+			return fmt.Errorf("epic %s cannot depend on itself", epicID)
+		}
+		if _, exists := collection.Epics[id]; !exists {
+			return fmt.Errorf("dependency not found: %s", id)
+		}
+	}
+
+	for _, id := range dependencies {
+		if path := findDependencyPath(collection, id, epicID, map[string]bool{}); path != nil {
+			cycle := append([]string{epicID}, path...)
+			return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " → "))
+		}
+	}
+	return nil
+}
+
+// findDependencyPath returns the dependency chain from one epic to another,
+// both included, or nil when from does not (transitively) depend on to
+func findDependencyPath(collection *EpicCollection, from, to string, visited map[string]bool) []string {
+	if from == to {
+		return []string{to}
+	}
+	if visited[from] {
+		return nil
+	}
+	visited[from] = true
+
+	epic, exists := collection.Epics[from]
+	if !exists {
+		return nil
+	}
+	for _, next := range epic.Dependencies {
+		if path := findDependencyPath(collection, next, to, visited); path != nil {
+			return append([]string{from}, path...)
+		}
+	}
+	return nil
+}
+
+// dependentEpics returns the IDs of the epics that depend on epicID, sorted
+func dependentEpics(collection *EpicCollection, epicID string) []string {
+	var dependents []string
+	for id, epic := range collection.Epics {
+		for _, dependency := range epic.Dependencies {
+			if dependency == epicID {
+				dependents = append(dependents, id)
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// GetDependencies returns the epics an epic depends on, in declaration order.
+// Dependencies that no longer exist are skipped.
+func (m *Manager) GetDependencies(epicID string) ([]*Epic, error) {
+	collection, err := m.loadEpicCollection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load epic collection: %w", err)
+	}
+
+	epic, exists := collection.Epics[epicID]
+	if !exists {
+		return nil, fmt.Errorf("epic not found: %s", epicID)
+	}
+
+	var dependencies []*Epic
+	for _, id := range epic.Dependencies {
+		if dependency, exists := collection.Epics[id]; exists {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies, nil
+}
+
+// GetUnmetDependencies returns the dependencies of an epic that are not completed
+func (m *Manager) GetUnmetDependencies(epicID string) ([]*Epic, error) {
+	dependencies, err := m.GetDependencies(epicID)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmet []*Epic
+	for _, dependency := range dependencies {
+		if dependency.Status != StatusCompleted {
+			unmet = append(unmet, dependency)
+		}
+	}
+	return unmet, nil
+}
@@ -0,0 +1,76 @@
+package epic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_DependencyCycleRejected(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	manager := NewManager(tempDir)
+
+	a, err := manager.CreateEpic(EpicCreateOptions{Title: "Epic A"})
+	require.NoError(t, err)
+	b, err := manager.CreateEpic(EpicCreateOptions{Title: "Epic B", Dependencies: []string{a.ID}})
+	require.NoError(t, err)
+	c, err := manager.CreateEpic(EpicCreateOptions{Title: "Epic C", Dependencies: []string{b.ID, " " + b.ID}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{b.ID}, c.Dependencies)
+
+	// A → C would close the cycle A → C → B → A
+	deps := []string{c.ID}
+	_, err = manager.UpdateEpic(a.ID, EpicUpdateOptions{Dependencies: &deps})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency cycle: "+a.ID+" → "+c.ID+" → "+b.ID+" → "+a.ID)
+
+	self := []string{a.ID}
+	_, err = manager.UpdateEpic(a.ID, EpicUpdateOptions{Dependencies: &self})
+	assert.Error(t, err)
+
+	stored, err := manager.GetEpic(a.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.Dependencies)
+}
+
+func TestManager_DependencyNotFound(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	manager := NewManager(tempDir)
+
+	_, err := manager.CreateEpic(EpicCreateOptions{Title: "Epic A", Dependencies: []string{"EPIC-999"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency not found: EPIC-999")
+}
+
+func TestManager_GetUnmetDependencies(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	manager := NewManager(tempDir)
+
+	a, err := manager.CreateEpic(EpicCreateOptions{Title: "Epic A"})
+	require.NoError(t, err)
+	b, err := manager.CreateEpic(EpicCreateOptions{Title: "Epic B"})
+	require.NoError(t, err)
+	c, err := manager.CreateEpic(EpicCreateOptions{Title: "Epic C", Dependencies: []string{a.ID, b.ID}})
+	require.NoError(t, err)
+
+	inProgress, completed := StatusInProgress, StatusCompleted
+	_, err = manager.UpdateEpic(a.ID, EpicUpdateOptions{Status: &inProgress})
+	require.NoError(t, err)
+	_, err = manager.UpdateEpic(a.ID, EpicUpdateOptions{Status: &completed})
+	require.NoError(t, err)
+
+	unmet, err := manager.GetUnmetDependencies(c.ID)
+	require.NoError(t, err)
+	require.Len(t, unmet, 1)
+	assert.Equal(t, b.ID, unmet[0].ID)
+
+	data := NewDashboard(manager).GetEpicDashboardData(c)
+	assert.Equal(t, []string{b.ID}, data.BlockedBy)
+}
@@ -8,6 +8,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"claude-wm-cli/internal/backup"
 )
 
 const (
@@ -17,14 +19,16 @@ const (
 
 // Manager handles epic operations and state management
 type Manager struct {
-	rootPath string
-	tracker  *EpicTracker
+	rootPath     string
+	tracker      *EpicTracker
+	stateMachine *StatusMachine
 }
 
 // NewManager creates a new epic manager
 func NewManager(rootPath string) *Manager {
 	manager := &Manager{
-		rootPath: rootPath,
+		rootPath:     rootPath,
+		stateMachine: NewStatusMachine(),
 	}
 	// Initialize tracker after manager is created
 	manager.tracker = NewEpicTracker(manager)
@@ -36,6 +40,12 @@ func (m *Manager) GetTracker() *EpicTracker {
 	return m.tracker
 }
 
+// StateMachine returns the state machine validating epic status changes, to
+// register hooks on it
+func (m *Manager) StateMachine() *StatusMachine {
+	return m.stateMachine
+}
+
 // CreateEpic creates a new epic with the given options
 func (m *Manager) CreateEpic(options EpicCreateOptions) (*Epic, error) {
 	// Validate inputs
@@ -61,6 +71,11 @@ func (m *Manager) CreateEpic(options EpicCreateOptions) (*Epic, error) {
 	// Generate unique ID
 	epicID := m.generateEpicID(options.Title, collection)
 
+	dependencies := normalizeDependencies(options.Dependencies)
+	if err := validateDependencies(collection, epicID, dependencies); err != nil {
+		return nil, err
+	}
+
 	// Create the epic
 	now := time.Now()
 	epic := &Epic{
@@ -71,7 +86,7 @@ func (m *Manager) CreateEpic(options EpicCreateOptions) (*Epic, error) {
 		Status:       StatusPlanned,
 		Duration:     options.Duration,
 		Tags:         options.Tags,
-		Dependencies: options.Dependencies,
+		Dependencies: dependencies,
 		UserStories:  []UserStory{},
 		CreatedAt:    now,
 		UpdatedAt:    now,
@@ -113,6 +128,9 @@ func (m *Manager) ListEpics(options EpicListOptions) ([]*Epic, error) {
 		if options.Priority != "" && epic.Priority != options.Priority {
 			continue
 		}
+		if !MatchTags(epic.Tags, options.Tags, options.AnyTags) {
+			continue
+		}
 
 		epics = append(epics, epic)
 	}
@@ -163,20 +181,12 @@ func (m *Manager) UpdateEpic(epicID string, options EpicUpdateOptions) (*Epic, e
 			return nil, fmt.Errorf("invalid status: %s", *options.Status)
 		}
 
-		// Handle status transitions
-		if err := m.validateStatusTransition(epic, *options.Status); err != nil {
+		// Validate the transition; the state hooks set the dates
+		if err := m.stateMachine.Transition(epic.Status, *options.Status, epic); err != nil {
 			return nil, err
 		}
 
 		epic.Status = *options.Status
-
-		// Set timestamps for status changes
-		if *options.Status == StatusInProgress && epic.StartDate == nil {
-			epic.StartDate = &now
-		}
-		if *options.Status == StatusCompleted && epic.EndDate == nil {
-			epic.EndDate = &now
-		}
 	}
 
 	if options.Duration != nil {
@@ -188,7 +198,11 @@ func (m *Manager) UpdateEpic(epicID string, options EpicUpdateOptions) (*Epic, e
 	}
 
 	if options.Dependencies != nil {
-		epic.Dependencies = *options.Dependencies
+		dependencies := normalizeDependencies(*options.Dependencies)
+		if err := validateDependencies(collection, epicID, dependencies); err != nil {
+			return nil, err
+		}
+		epic.Dependencies = dependencies
 	}
 
 	epic.UpdatedAt = now
@@ -247,7 +261,8 @@ func (m *Manager) SelectEpic(epicID string) (*Epic, error) {
 	return epic, nil
 }
 
-// GetEpic returns a specific epic by ID
+// GetEpic returns a specific epic by ID, archived epics included: their
+// ArchivedAt is set
 func (m *Manager) GetEpic(epicID string) (*Epic, error) {
 	collection, err := m.loadEpicCollection()
 	if err != nil {
@@ -255,6 +270,9 @@ func (m *Manager) GetEpic(epicID string) (*Epic, error) {
 	}
 
 	epic, exists := collection.Epics[epicID]
+	if !exists {
+		epic, exists = collection.ArchivedEpics[epicID]
+	}
 	if !exists {
 		return nil, fmt.Errorf("epic not found: %s", epicID)
 	}
@@ -350,18 +368,39 @@ func (m *Manager) GetEpicAdvancedMetrics(epicID string) (*AdvancedMetrics, error
 	return m.tracker.CalculateAdvancedMetrics(epicID)
 }
 
-// DeleteEpic removes an epic from the collection
+// GetEpicAdvancedMetricsWithOptions returns advanced metrics for an epic,
+// with its completion estimated from a velocity window when options set one
+func (m *Manager) GetEpicAdvancedMetricsWithOptions(epicID string, options MetricsOptions) (*AdvancedMetrics, error) {
+	if m.tracker == nil {
+		return nil, fmt.Errorf("tracker not available")
+	}
+	return m.tracker.CalculateAdvancedMetricsWithOptions(epicID, options)
+}
+
+// DeleteEpic removes an epic from the collection. It refuses to delete an
+// epic with incomplete user stories or that other epics depend on.
 func (m *Manager) DeleteEpic(epicID string) error {
+	return m.DeleteEpicWithOptions(epicID, EpicDeleteOptions{})
+}
+
+// DeleteEpicWithOptions removes an epic from the collection, clearing the
+// current epic if it is the one being deleted. Incomplete user stories only
+// block the deletion without options.Force; dependent epics always do.
+func (m *Manager) DeleteEpicWithOptions(epicID string, options EpicDeleteOptions) error {
 	collection, err := m.loadEpicCollection()
 	if err != nil {
 		return fmt.Errorf("failed to load epic collection: %w", err)
 	}
 
-	_, exists := collection.Epics[epicID]
+	epic, exists := collection.Epics[epicID]
 	if !exists {
 		return fmt.Errorf("epic not found: %s", epicID)
 	}
 
+	if err := checkDeletable(collection, epic, options); err != nil {
+		return err
+	}
+
 	// Clear current epic if it's the one being deleted
 	if collection.CurrentEpic == epicID {
 		collection.CurrentEpic = ""
@@ -376,6 +415,36 @@ func (m *Manager) DeleteEpic(epicID string) error {
 	return m.saveEpicCollection(collection)
 }
 
+// CanDeleteEpic reports why DeleteEpicWithOptions would refuse to delete an
+// epic, or nil when it can be deleted
+func (m *Manager) CanDeleteEpic(epicID string, options EpicDeleteOptions) error {
+	collection, err := m.loadEpicCollection()
+	if err != nil {
+		return fmt.Errorf("failed to load epic collection: %w", err)
+	}
+
+	epic, exists := collection.Epics[epicID]
+	if !exists {
+		return fmt.Errorf("epic not found: %s", epicID)
+	}
+	return checkDeletable(collection, epic, options)
+}
+
+// checkDeletable refuses the deletion of epics other epics depend on, and of
+// epics with incomplete user stories unless forced
+func checkDeletable(collection *EpicCollection, epic *Epic, options EpicDeleteOptions) error {
+	if dependents := dependentEpics(collection, epic.ID); len(dependents) > 0 {
+		return fmt.Errorf("epic %s is a dependency of %s: remove it from their dependencies first (epic update <id> --depends-on ...)",
+			epic.ID, strings.Join(dependents, ", "))
+	}
+
+	if incomplete := epic.IncompleteStories(); len(incomplete) > 0 && !options.Force {
+		return fmt.Errorf("epic %s has %d incomplete user stories: complete or cancel them, or use --force",
+			epic.ID, len(incomplete))
+	}
+	return nil
+}
+
 // loadEpicCollection loads the epic collection from disk
 func (m *Manager) loadEpicCollection() (*EpicCollection, error) {
 	epicsPath := filepath.Join(m.rootPath, "docs", "1-project", EpicsFileName)
@@ -514,15 +583,9 @@ func (m *Manager) saveEpicCollection(collection *EpicCollection) error {
 		return fmt.Errorf("failed to marshal epic collection: %w", err)
 	}
 
-	// Write file atomically using temp file + rename
-	tempPath := epicsPath + ".tmp"
-	if err := os.WriteFile(tempPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp epics file: %w", err)
-	}
-
-	if err := os.Rename(tempPath, epicsPath); err != nil {
-		os.Remove(tempPath) // cleanup
-		return fmt.Errorf("failed to replace epics file: %w", err)
+	// Back up the current file, then write atomically
+	if err := backup.WriteWithBackup(epicsPath, data, backup.BackupTypeAutomatic); err != nil {
+		return fmt.Errorf("failed to write epics file: %w", err)
 	}
 
 	return nil
@@ -551,8 +614,11 @@ func (m *Manager) generateEpicID(title string, collection *EpicCollection) strin
 	counter := 1
 	epicID := fmt.Sprintf("EPIC-%03d-%s", counter, baseID)
 
+	// Archived epics keep their ID, so that they can be unarchived
 	for {
-		if _, exists := collection.Epics[epicID]; !exists {
+		_, exists := collection.Epics[epicID]
+		_, archived := collection.ArchivedEpics[epicID]
+		if !exists && !archived {
 			break
 		}
 		counter++
@@ -562,32 +628,11 @@ func (m *Manager) generateEpicID(title string, collection *EpicCollection) strin
 	return epicID
 }
 
-// validateStatusTransition checks if a status transition is valid
+// validateStatusTransition checks if a status transition is valid, without
+// firing the state hooks
 func (m *Manager) validateStatusTransition(epic *Epic, newStatus Status) error {
-	currentStatus := epic.Status
-
-	// Define valid transitions
-	validTransitions := map[Status][]Status{
-		StatusPlanned:    {StatusInProgress, StatusOnHold, StatusCancelled},
-		StatusInProgress: {StatusOnHold, StatusCompleted, StatusCancelled},
-		StatusOnHold:     {StatusInProgress, StatusCancelled},
-		StatusCompleted:  {},              // Cannot transition from completed
-		StatusCancelled:  {StatusPlanned}, // Can restart cancelled epics
-	}
-
-	allowedTransitions, exists := validTransitions[currentStatus]
-	if !exists {
-		return fmt.Errorf("unknown current status: %s", currentStatus)
-	}
-
-	// Check if transition is allowed
-	for _, allowed := range allowedTransitions {
-		if allowed == newStatus {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("invalid status transition from %s to %s", currentStatus, newStatus)
+	_, err := m.stateMachine.Validate(epic.Status, newStatus)
+	return err
 }
 
 // validateAndMigrateCollection validates and migrates the collection if needed
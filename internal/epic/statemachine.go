@@ -0,0 +1,70 @@
+package epic
+
+import (
+	"fmt"
+
+	"claude-wm-cli/internal/workflow/fsm"
+)
+
+// StatusEvent is what moves an epic from one status to another
+type StatusEvent string
+
+const (
+	StatusEventStart    StatusEvent = "start"
+	StatusEventHold     StatusEvent = "hold"
+	StatusEventResume   StatusEvent = "resume"
+	StatusEventComplete StatusEvent = "complete"
+	StatusEventCancel   StatusEvent = "cancel"
+	StatusEventRestart  StatusEvent = "restart"
+)
+
+// StatusMachine validates the status changes of epics
+type StatusMachine = fsm.StateMachine[Status, StatusEvent]
+
+// InvalidTransitionError is returned when an epic cannot move to a status
+type InvalidTransitionError = fsm.InvalidTransitionError[Status]
+
+// NewStatusMachine returns the epic lifecycle, whose hooks set the StartDate
+// and EndDate of the epic changing status. Completed epics cannot change
+// status; cancelled ones can be planned again.
+func NewStatusMachine() *StatusMachine {
+	sm := fsm.New[Status, StatusEvent](StatusPlanned).
+		AddState(StatusInProgress).
+		AddState(StatusOnHold).
+		AddState(StatusCompleted).
+		AddState(StatusCancelled).
+		AddTransition(StatusPlanned, StatusEventStart, StatusInProgress).
+		AddTransition(StatusPlanned, StatusEventHold, StatusOnHold).
+		AddTransition(StatusPlanned, StatusEventCancel, StatusCancelled).
+		AddTransition(StatusInProgress, StatusEventHold, StatusOnHold).
+		AddTransition(StatusInProgress, StatusEventComplete, StatusCompleted).
+		AddTransition(StatusInProgress, StatusEventCancel, StatusCancelled).
+		AddTransition(StatusOnHold, StatusEventResume, StatusInProgress).
+		AddTransition(StatusOnHold, StatusEventCancel, StatusCancelled).
+		AddTransition(StatusCancelled, StatusEventRestart, StatusPlanned)
+
+	sm.OnEnter[StatusInProgress] = func(ctx fsm.TransitionContext[Status, StatusEvent]) error {
+		epic, err := transitionEpic(ctx)
+		if err == nil && epic.StartDate == nil {
+			epic.StartDate = &ctx.At
+		}
+		return err
+	}
+	sm.OnEnter[StatusCompleted] = func(ctx fsm.TransitionContext[Status, StatusEvent]) error {
+		epic, err := transitionEpic(ctx)
+		if err == nil && epic.EndDate == nil {
+			epic.EndDate = &ctx.At
+		}
+		return err
+	}
+	return sm
+}
+
+// transitionEpic returns the epic changing status in a hook
+func transitionEpic(ctx fsm.TransitionContext[Status, StatusEvent]) (*Epic, error) {
+	epic, ok := ctx.Subject.(*Epic)
+	if !ok {
+		return nil, fmt.Errorf("expected an epic, got %T", ctx.Subject)
+	}
+	return epic, nil
+}
@@ -404,6 +404,12 @@ func (et *EpicTracker) GetRecentEvents(limit int) []EpicStateEvent {
 
 // CalculateAdvancedMetrics calculates advanced metrics for an epic
 func (et *EpicTracker) CalculateAdvancedMetrics(epicID string) (*AdvancedMetrics, error) {
+	return et.CalculateAdvancedMetricsWithOptions(epicID, MetricsOptions{})
+}
+
+// CalculateAdvancedMetricsWithOptions calculates advanced metrics for an epic,
+// estimating its completion from the velocity window of options when set
+func (et *EpicTracker) CalculateAdvancedMetricsWithOptions(epicID string, options MetricsOptions) (*AdvancedMetrics, error) {
 	et.mu.RLock()
 	defer et.mu.RUnlock()
 
@@ -447,8 +453,12 @@ func (et *EpicTracker) CalculateAdvancedMetrics(epicID string) (*AdvancedMetrics
 		estimatedTotal := time.Duration(float64(elapsed) / (epic.Progress.CompletionPercentage / 100.0))
 		estimatedEnd := epic.StartDate.Add(estimatedTotal)
 		metrics.EstimatedCompletion = &estimatedEnd
+		metrics.VelocityBasis = VelocityBasisHistory
 	}
 
+	applyVelocityWindow(metrics, epic, options, metrics.CalculatedAt)
+	applyCycleTime(metrics, epic, options.Stories, metrics.CalculatedAt)
+
 	return metrics, nil
 }
 
@@ -463,6 +473,19 @@ type AdvancedMetrics struct {
 	LastTransition      *StateTransition `json:"last_transition,omitempty"`
 	AvgTransitionTime   time.Duration    `json:"avg_transition_time"`
 	EstimatedCompletion *time.Time       `json:"estimated_completion,omitempty"`
+	VelocityBasis       string           `json:"velocity_basis,omitempty"`  // What EstimatedCompletion is based on
+	VelocityWindow      time.Duration    `json:"velocity_window,omitempty"` // Requested velocity window
+	VelocitySample      int              `json:"velocity_sample,omitempty"` // Stories completed within the window
+	Velocity            float64          `json:"velocity,omitempty"`        // Work completed per day within the window
+	VelocityUnit        string           `json:"velocity_unit,omitempty"`
+	VelocityNote        string           `json:"velocity_note,omitempty"`     // Why the window could not be used
+	CycleTimeSample     int              `json:"cycle_time_sample,omitempty"` // Completed stories with start and completion times
+	AvgCycleTime        time.Duration    `json:"avg_cycle_time,omitempty"`
+	MinCycleTime        time.Duration    `json:"min_cycle_time,omitempty"`
+	MaxCycleTime        time.Duration    `json:"max_cycle_time,omitempty"`
+	CycleTimeStdDev     time.Duration    `json:"cycle_time_std_dev,omitempty"`
+	ThroughputPerWeek   float64          `json:"throughput_per_week,omitempty"` // Stories completed per week since the start
+	CycleTimeNote       string           `json:"cycle_time_note,omitempty"`     // Why cycle times are missing
 }
 
 // Subscribe adds a subscriber for state change notifications
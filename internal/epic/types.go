@@ -2,6 +2,7 @@ package epic
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"claude-wm-cli/internal/model"
@@ -23,6 +24,7 @@ type Epic struct {
 	Progress     ProgressMetrics `json:"progress"`
 	CreatedAt    time.Time       `json:"created_at"`
 	UpdatedAt    time.Time       `json:"updated_at"`
+	ArchivedAt   *time.Time      `json:"archived_at,omitempty"`
 }
 
 // Priority represents the priority level of an epic
@@ -97,10 +99,11 @@ type ProgressMetrics struct {
 
 // EpicCollection represents a collection of epics with metadata
 type EpicCollection struct {
-	ProjectID   string             `json:"project_id"`
-	Epics       map[string]*Epic   `json:"epics"`
-	CurrentEpic string             `json:"current_epic,omitempty"`
-	Metadata    CollectionMetadata `json:"metadata"`
+	ProjectID     string             `json:"project_id"`
+	Epics         map[string]*Epic   `json:"epics"`
+	ArchivedEpics map[string]*Epic   `json:"archived_epics,omitempty"` // Hidden from the epic list
+	CurrentEpic   string             `json:"current_epic,omitempty"`
+	Metadata      CollectionMetadata `json:"metadata"`
 }
 
 // CollectionMetadata contains metadata about the epic collection
@@ -131,11 +134,18 @@ type EpicUpdateOptions struct {
 	Dependencies *[]string
 }
 
+// EpicDeleteOptions contains options for deleting an epic
+type EpicDeleteOptions struct {
+	Force bool // Delete even if the epic has incomplete user stories
+}
+
 // EpicListOptions contains options for listing epics
 type EpicListOptions struct {
 	Status   Status
 	Priority Priority
 	ShowAll  bool
+	Tags     []string // Only epics with all of these tags
+	AnyTags  []string // Only epics with at least one of these tags
 }
 
 // Note: String() and IsValid() methods are now available through model.Priority and model.Status
@@ -191,6 +201,41 @@ func (e *Epic) CanComplete() bool {
 	return e.Status == StatusInProgress && e.Progress.CompletionPercentage >= 100
 }
 
+// IncompleteStories returns the user stories that are neither completed nor cancelled
+func (e *Epic) IncompleteStories() []UserStory {
+	var incomplete []UserStory
+	for _, story := range e.UserStories {
+		if story.Status != StatusCompleted && story.Status != StatusCancelled {
+			incomplete = append(incomplete, story)
+		}
+	}
+	return incomplete
+}
+
+// MatchTags reports whether tags contain all the tags of all and, unless any
+// is empty, at least one of the tags of any. Tags are compared case-insensitively.
+func MatchTags(tags, all, any []string) bool {
+	has := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		has[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+
+	for _, tag := range all {
+		if !has[strings.ToLower(strings.TrimSpace(tag))] {
+			return false
+		}
+	}
+	if len(any) == 0 {
+		return true
+	}
+	for _, tag := range any {
+		if has[strings.ToLower(strings.TrimSpace(tag))] {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate validates the epic's data
 func (e *Epic) Validate() error {
 	if e.ID == "" {
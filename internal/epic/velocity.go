@@ -0,0 +1,150 @@
+package epic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinVelocitySample is the number of stories that must have been completed
+// within the velocity window for the window to be used for estimates
+const MinVelocitySample = 2
+
+// Velocity bases of the estimated completion
+const (
+	VelocityBasisWindow  = "window"  // Stories completed within the velocity window
+	VelocityBasisHistory = "history" // Progress since the epic started
+)
+
+// MetricsOptions tunes how the advanced metrics of an epic are calculated
+type MetricsOptions struct {
+	// VelocityWindow limits the velocity to the stories completed within this
+	// duration before now; 0 uses the progress since the epic started
+	VelocityWindow time.Duration
+	// Stories are the stories of the epic with their start and completion
+	// times, for the velocity window and the story cycle times
+	Stories []BurndownStory
+}
+
+// ParseVelocityWindow parses a velocity window such as "14d", "2w" or "72h".
+// A bare number is a count of days, as in the epic.velocity_window config
+// key. An empty string or "0" disables the window.
+func ParseVelocityWindow(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+
+	var window time.Duration
+	unit := value[len(value)-1]
+	if days, err := strconv.Atoi(value); err == nil {
+		window = time.Duration(days) * 24 * time.Hour
+	} else if unit == 'd' || unit == 'w' {
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid velocity window %q: expected e.g. 14d, 2w or 72h", value)
+		}
+		window = time.Duration(count) * 24 * time.Hour
+		if unit == 'w' {
+			window *= 7
+		}
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid velocity window %q: expected e.g. 14d, 2w or 72h", value)
+		}
+		window = parsed
+	}
+
+	if window < 0 {
+		return 0, fmt.Errorf("invalid velocity window %q: must not be negative", value)
+	}
+	return window, nil
+}
+
+// FormatVelocityWindow formats a velocity window in days when it is a whole
+// number of days, e.g. "14d"
+func FormatVelocityWindow(window time.Duration) string {
+	if window > 0 && window%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", window/(24*time.Hour))
+	}
+	return window.String()
+}
+
+// windowedVelocity is the velocity of an epic over its velocity window
+type windowedVelocity struct {
+	Sample    int     // Stories completed within the window
+	PerDay    float64 // Work completed per day
+	Unit      string  // "points", or "stories" when no story has points
+	Remaining int     // Work left in the epic
+}
+
+// calculateWindowedVelocity returns the work completed per day by the stories
+// completed within window before now. The window is shortened to the time
+// since the epic started, so that a young epic's velocity is not diluted.
+func calculateWindowedVelocity(epic *Epic, stories []BurndownStory, window time.Duration, now time.Time) windowedVelocity {
+	velocity := windowedVelocity{Unit: "stories"}
+	for _, story := range stories {
+		if story.Points > 0 {
+			velocity.Unit = "points"
+			break
+		}
+	}
+	weight := func(story BurndownStory) int {
+		if velocity.Unit == "points" {
+			return story.Points
+		}
+		return 1
+	}
+
+	if epic.StartDate != nil && epic.StartDate.After(now.Add(-window)) {
+		window = now.Sub(*epic.StartDate)
+	}
+	since := now.Add(-window)
+
+	completed := 0
+	for _, story := range stories {
+		if !story.Completed {
+			velocity.Remaining += weight(story)
+			continue
+		}
+		if story.CompletedAt != nil && story.CompletedAt.After(since) && !story.CompletedAt.After(now) {
+			velocity.Sample++
+			completed += weight(story)
+		}
+	}
+
+	if days := window.Hours() / 24; days > 0 {
+		velocity.PerDay = float64(completed) / days
+	}
+	return velocity
+}
+
+// applyVelocityWindow replaces the estimated completion of metrics with one
+// based on the stories completed within options.VelocityWindow. With fewer than
+// MinVelocitySample stories in the window, the estimate based on the progress
+// since the epic started is kept and the reason is recorded in VelocityNote.
+func applyVelocityWindow(metrics *AdvancedMetrics, epic *Epic, options MetricsOptions, now time.Time) {
+	if options.VelocityWindow <= 0 || epic.Status == StatusCompleted {
+		return
+	}
+	metrics.VelocityWindow = options.VelocityWindow
+
+	velocity := calculateWindowedVelocity(epic, options.Stories, options.VelocityWindow, now)
+	metrics.VelocitySample = velocity.Sample
+
+	if velocity.Sample < MinVelocitySample || velocity.PerDay <= 0 {
+		metrics.VelocityNote = fmt.Sprintf("only %d stories completed in the last %s (need %d)",
+			velocity.Sample, FormatVelocityWindow(options.VelocityWindow), MinVelocitySample)
+		return
+	}
+
+	metrics.Velocity = velocity.PerDay
+	metrics.VelocityUnit = velocity.Unit
+	metrics.VelocityBasis = VelocityBasisWindow
+
+	remainingDays := float64(velocity.Remaining) / velocity.PerDay
+	estimatedEnd := now.Add(time.Duration(remainingDays * 24 * float64(time.Hour)))
+	metrics.EstimatedCompletion = &estimatedEnd
+}
@@ -0,0 +1,87 @@
+package epic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVelocityWindow(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"0", 0},
+		{"14d", 14 * 24 * time.Hour},
+		{"14", 14 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"72h", 72 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseVelocityWindow(tt.value)
+		require.NoError(t, err, tt.value)
+		assert.Equal(t, tt.want, got, tt.value)
+	}
+
+	for _, value := range []string{"fortnight", "xd", "-3d", "-3"} {
+		_, err := ParseVelocityWindow(value)
+		assert.Error(t, err, value)
+	}
+
+	assert.Equal(t, "14d", FormatVelocityWindow(14*24*time.Hour))
+	assert.Equal(t, "36h0m0s", FormatVelocityWindow(36*time.Hour))
+}
+
+func TestApplyVelocityWindow(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	start := now.AddDate(0, 0, -60)
+	daysAgo := func(n int) *time.Time {
+		d := now.AddDate(0, 0, -n)
+		return &d
+	}
+
+	// A burst of early activity, then two stories in the last two weeks
+	ep := &Epic{ID: "EPIC-001", Status: StatusInProgress, StartDate: &start}
+	stories := []BurndownStory{
+		{ID: "S1", Points: 5, Completed: true, CompletedAt: daysAgo(58)},
+		{ID: "S2", Points: 5, Completed: true, CompletedAt: daysAgo(57)},
+		{ID: "S3", Points: 5, Completed: true, CompletedAt: daysAgo(56)},
+		{ID: "S4", Points: 4, Completed: true, CompletedAt: daysAgo(10)},
+		{ID: "S5", Points: 3, Completed: true, CompletedAt: daysAgo(3)},
+		{ID: "S6", Points: 7},
+	}
+
+	metrics := &AdvancedMetrics{}
+	applyVelocityWindow(metrics, ep, MetricsOptions{VelocityWindow: 14 * 24 * time.Hour, Stories: stories}, now)
+
+	assert.Equal(t, VelocityBasisWindow, metrics.VelocityBasis)
+	assert.Equal(t, 2, metrics.VelocitySample)
+	assert.Equal(t, "points", metrics.VelocityUnit)
+	assert.InDelta(t, 0.5, metrics.Velocity, 0.001)
+	require.NotNil(t, metrics.EstimatedCompletion)
+	assert.Equal(t, now.AddDate(0, 0, 14), *metrics.EstimatedCompletion)
+}
+
+func TestApplyVelocityWindow_FallsBackWithoutEnoughStories(t *testing.T) {
+	now := time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	start := now.AddDate(0, 0, -60)
+	completedAt := now.AddDate(0, 0, -30)
+	historyEstimate := now.AddDate(0, 0, 60)
+
+	ep := &Epic{ID: "EPIC-001", Status: StatusInProgress, StartDate: &start}
+	stories := []BurndownStory{
+		{ID: "S1", Completed: true, CompletedAt: &completedAt},
+		{ID: "S2"},
+	}
+
+	metrics := &AdvancedMetrics{EstimatedCompletion: &historyEstimate, VelocityBasis: VelocityBasisHistory}
+	applyVelocityWindow(metrics, ep, MetricsOptions{VelocityWindow: 7 * 24 * time.Hour, Stories: stories}, now)
+
+	assert.Equal(t, VelocityBasisHistory, metrics.VelocityBasis)
+	assert.Equal(t, historyEstimate, *metrics.EstimatedCompletion)
+	assert.Equal(t, 0, metrics.VelocitySample)
+	assert.Contains(t, metrics.VelocityNote, "only 0 stories completed in the last 7d")
+}
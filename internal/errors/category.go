@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"context"
+	goerrors "errors"
+	"io/fs"
+	"net"
+)
+
+// ErrorCategory is the kind of failure of a CLIError
+type ErrorCategory string
+
+const (
+	CategoryIO         ErrorCategory = "io"         // Reading or writing files
+	CategoryValidation ErrorCategory = "validation" // Invalid input, files or state
+	CategoryNetwork    ErrorCategory = "network"    // Network calls and remote services
+	CategoryConfig     ErrorCategory = "config"     // Configuration and environment
+	CategoryAuth       ErrorCategory = "auth"       // Credentials and access rights
+)
+
+// Error makes a category usable as the target of errors.Is
+func (c ErrorCategory) Error() string {
+	return string(c) + " error"
+}
+
+// Category sentinels, matched by errors.Is against the Category of a CLIError
+var (
+	ErrCategoryIO         error = CategoryIO
+	ErrCategoryValidation error = CategoryValidation
+	ErrCategoryNetwork    error = CategoryNetwork
+	ErrCategoryConfig     error = CategoryConfig
+	ErrCategoryAuth       error = CategoryAuth
+)
+
+// classify infers the exit code and category of an error that is not a
+// CLIError from the standard errors in its chain
+func classify(err error) (ExitCode, ErrorCategory) {
+	var coded interface{ ExitCode() int }
+	var netErr net.Error
+
+	switch {
+	case goerrors.As(err, &coded):
+		return ExitCode(coded.ExitCode()), ""
+	case goerrors.Is(err, fs.ErrNotExist):
+		return ExitNotFound, CategoryIO
+	case goerrors.Is(err, fs.ErrPermission):
+		return ExitPermissionDenied, CategoryIO
+	case goerrors.Is(err, context.DeadlineExceeded):
+		return ExitTimeout, ""
+	case goerrors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ExitTimeout, CategoryNetwork
+		}
+		return ExitNetwork, CategoryNetwork
+	}
+	return ExitGeneric, ""
+}
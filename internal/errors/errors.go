@@ -1,34 +1,75 @@
 package errors
 
 import (
+	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strings"
 	"time"
 )
 
-// CLIError represents an error with additional context
+// CLIError represents an error with additional context. Its Code is the
+// exit code of the CLI, its Category the kind of failure callers can match
+// with errors.Is(err, ErrCategoryValidation).
 type CLIError struct {
 	Message    string
-	Code       int
+	Code       ExitCode
+	Category   ErrorCategory
 	Suggestion string
 	Details    string
 	Timestamp  time.Time
-	Context    map[string]interface{}
+	Context    map[string]string
+	Cause      error
 }
 
 func (e *CLIError) Error() string {
-	return e.Message
+	if e.Cause == nil || e.Cause.Error() == e.Message {
+		return e.Message
+	}
+	return e.Message + ": " + e.Cause.Error()
+}
+
+// Unwrap returns the cause of the error
+func (e *CLIError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is the category of the error
+func (e *CLIError) Is(target error) bool {
+	category, ok := target.(ErrorCategory)
+	return ok && category != "" && e.Category == category
 }
 
 // NewCLIError creates a new CLI error
-func NewCLIError(message string, code int) *CLIError {
+func NewCLIError(message string, code ExitCode) *CLIError {
 	return &CLIError{
 		Message:   message,
 		Code:      code,
 		Timestamp: time.Now(),
-		Context:   make(map[string]interface{}),
+		Context:   make(map[string]string),
+	}
+}
+
+// Wrap returns an error with message caused by err, keeping the exit code,
+// category and suggestion of a CLIError in its chain. It returns nil for a nil
+// err.
+func Wrap(err error, message string) *CLIError {
+	if err == nil {
+		return nil
+	}
+
+	wrapped := AsCLIError(err)
+	return &CLIError{
+		Message:    message,
+		Code:       wrapped.Code,
+		Category:   wrapped.Category,
+		Suggestion: wrapped.Suggestion,
+		Timestamp:  time.Now(),
+		Context:    make(map[string]string),
+		Cause:      err,
 	}
 }
 
@@ -46,7 +87,22 @@ func (e *CLIError) WithDetails(details string) *CLIError {
 
 // WithContext adds context information
 func (e *CLIError) WithContext(key string, value interface{}) *CLIError {
-	e.Context[key] = value
+	if e.Context == nil {
+		e.Context = make(map[string]string)
+	}
+	e.Context[key] = fmt.Sprint(value)
+	return e
+}
+
+// WithCategory sets the category of the error
+func (e *CLIError) WithCategory(category ErrorCategory) *CLIError {
+	e.Category = category
+	return e
+}
+
+// WithCause sets the error that caused this one
+func (e *CLIError) WithCause(cause error) *CLIError {
+	e.Cause = cause
 	return e
 }
 
@@ -56,7 +112,8 @@ func HandleError(err error, verbose bool) {
 		return
 	}
 
-	if cliErr, ok := err.(*CLIError); ok {
+	var cliErr *CLIError
+	if goerrors.As(err, &cliErr) {
 		handleCLIError(cliErr, verbose)
 	} else {
 		handleGenericError(err, verbose)
@@ -65,7 +122,7 @@ func HandleError(err error, verbose bool) {
 
 func handleCLIError(err *CLIError, verbose bool) {
 	// Print main error message
-	fmt.Fprintf(os.Stderr, "❌ %s\n", err.Message)
+	fmt.Fprintf(os.Stderr, "❌ %s\n", err.Error())
 
 	// Print suggestion if available
 	if err.Suggestion != "" {
@@ -91,7 +148,53 @@ func handleCLIError(err *CLIError, verbose bool) {
 	}
 
 	fmt.Fprintf(os.Stderr, "\n📖 Use --help for more information.\n")
-	os.Exit(err.Code)
+	Exit(err.Code)
+}
+
+// JSONError is the JSON form of a CLIError, for wrappers and CI to classify
+// failures
+type JSONError struct {
+	Message    string            `json:"message"`
+	Code       ExitCode          `json:"code"`
+	Category   ErrorCategory     `json:"category,omitempty"`
+	Details    string            `json:"details,omitempty"`
+	Suggestion string            `json:"suggestion,omitempty"`
+	Context    map[string]string `json:"context,omitempty"`
+	Cause      string            `json:"cause,omitempty"`
+	Timestamp  time.Time         `json:"timestamp"`
+}
+
+// MarshalJSON encodes the error as a JSONError
+func (e *CLIError) MarshalJSON() ([]byte, error) {
+	jsonErr := JSONError{
+		Message:    e.Message,
+		Code:       e.Code,
+		Category:   e.Category,
+		Details:    e.Details,
+		Suggestion: e.Suggestion,
+		Context:    e.Context,
+		Timestamp:  e.Timestamp,
+	}
+	if e.Cause != nil && e.Cause.Error() != e.Message {
+		jsonErr.Cause = e.Cause.Error()
+	}
+	return json.Marshal(jsonErr)
+}
+
+// AsCLIError returns the first CLIError in the chain of err, or a CLIError
+// caused by err with the exit code and category of err
+func AsCLIError(err error) *CLIError {
+	var cliErr *CLIError
+	if goerrors.As(err, &cliErr) {
+		return cliErr
+	}
+	code, category := classify(err)
+	return NewCLIError(err.Error(), code).WithCategory(category).WithCause(err)
+}
+
+// WriteJSON writes err to w as a JSONError on a single line
+func WriteJSON(w io.Writer, err error) error {
+	return json.NewEncoder(w).Encode(AsCLIError(err))
 }
 
 func handleGenericError(err error, verbose bool) {
@@ -105,7 +208,7 @@ func handleGenericError(err error, verbose bool) {
 	}
 
 	fmt.Fprintf(os.Stderr, "\n📖 Use --help for more information.\n")
-	os.Exit(1)
+	Exit(ExitGeneric)
 }
 
 // Common error constructors
@@ -114,16 +217,19 @@ func handleGenericError(err error, verbose bool) {
 func ErrInvalidInput(field, value, message string) *CLIError {
 	return NewCLIError(
 		fmt.Sprintf("Invalid %s: %s", field, message),
-		2,
-	).WithContext("field", field).WithContext("value", value)
+		ExitUsage,
+	).WithCategory(CategoryValidation).
+		WithContext("field", field).
+		WithContext("value", value)
 }
 
 // ErrFileNotFound creates a file not found error
 func ErrFileNotFound(path string) *CLIError {
 	return NewCLIError(
 		fmt.Sprintf("File not found: %s", path),
-		3,
-	).WithSuggestion("Check that the file path is correct and the file exists").
+		ExitNotFound,
+	).WithCategory(CategoryIO).
+		WithSuggestion("Check that the file path is correct and the file exists").
 		WithContext("path", path)
 }
 
@@ -131,8 +237,9 @@ func ErrFileNotFound(path string) *CLIError {
 func ErrPermissionDenied(path string) *CLIError {
 	return NewCLIError(
 		fmt.Sprintf("Permission denied: %s", path),
-		4,
-	).WithSuggestion("Check file permissions or run with appropriate privileges").
+		ExitPermissionDenied,
+	).WithCategory(CategoryIO).
+		WithSuggestion("Check file permissions or run with appropriate privileges").
 		WithContext("path", path)
 }
 
@@ -140,7 +247,7 @@ func ErrPermissionDenied(path string) *CLIError {
 func ErrTimeout(operation string, duration time.Duration) *CLIError {
 	return NewCLIError(
 		fmt.Sprintf("Operation timed out: %s", operation),
-		5,
+		ExitTimeout,
 	).WithSuggestion(fmt.Sprintf("Try increasing the timeout (current: %v) or check your network connection", duration)).
 		WithContext("operation", operation).
 		WithContext("timeout", duration.String())
@@ -150,8 +257,10 @@ func ErrTimeout(operation string, duration time.Duration) *CLIError {
 func ErrNetworkFailure(operation string, cause error) *CLIError {
 	return NewCLIError(
 		fmt.Sprintf("Network failure during %s", operation),
-		6,
-	).WithSuggestion("Check your internet connection and try again").
+		ExitNetwork,
+	).WithCategory(CategoryNetwork).
+		WithCause(cause).
+		WithSuggestion("Check your internet connection and try again").
 		WithDetails(cause.Error()).
 		WithContext("operation", operation)
 }
@@ -160,7 +269,7 @@ func ErrNetworkFailure(operation string, cause error) *CLIError {
 func ErrCommandFailed(command string, exitCode int, stderr string) *CLIError {
 	err := NewCLIError(
 		fmt.Sprintf("Command failed with exit code %d", exitCode),
-		1,
+		ExitGeneric,
 	).WithContext("command", command).
 		WithContext("exit_code", exitCode)
 
@@ -183,6 +292,30 @@ func ErrCommandFailed(command string, exitCode int, stderr string) *CLIError {
 	return err
 }
 
+// ErrValidationFailed creates an error for project files or configuration
+// that failed validation
+func ErrValidationFailed(message string) *CLIError {
+	return NewCLIError(message, ExitValidationFailed).
+		WithCategory(CategoryValidation).
+		WithSuggestion("Fix the reported files, or run 'claude-wm-cli config validate' for details")
+}
+
+// ErrClaudeUnavailable creates an error for a Claude CLI that is missing or
+// cannot run
+func ErrClaudeUnavailable(cause error) *CLIError {
+	return NewCLIError("Claude CLI not available", ExitClaudeUnavailable).
+		WithCategory(CategoryConfig).
+		WithCause(cause).
+		WithDetails(cause.Error()).
+		WithSuggestion("Install the Claude CLI and make sure 'claude' is in your PATH")
+}
+
+// ErrBlocked creates an error for work that cannot proceed, such as a refused
+// status transition or a blocked task
+func ErrBlocked(message string) *CLIError {
+	return NewCLIError(message, ExitBlocked)
+}
+
 // PrintWarning prints a warning message
 func PrintWarning(message string) {
 	fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", message)
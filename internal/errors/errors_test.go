@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJSON_CLIError(t *testing.T) {
+	cliErr := ErrFileNotFound("epics.json").WithDetails("open epics.json: no such file")
+
+	var out bytes.Buffer
+	require.NoError(t, WriteJSON(&out, fmt.Errorf("loading: %w", cliErr)))
+
+	var decoded JSONError
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "File not found: epics.json", decoded.Message)
+	assert.Equal(t, ExitNotFound, decoded.Code)
+	assert.Equal(t, "open epics.json: no such file", decoded.Details)
+	assert.Equal(t, "Check that the file path is correct and the file exists", decoded.Suggestion)
+	assert.Equal(t, CategoryIO, decoded.Category)
+	assert.Equal(t, map[string]string{"path": "epics.json"}, decoded.Context)
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("\n")), "one line per error")
+}
+
+func TestWriteJSON_GenericError(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteJSON(&out, fmt.Errorf("unknown flag: --bogus")))
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "unknown flag: --bogus", decoded["message"])
+	assert.Equal(t, float64(1), decoded["code"])
+	assert.NotContains(t, decoded, "suggestion")
+	assert.NotContains(t, decoded, "context")
+	assert.NotContains(t, decoded, "cause", "the cause is the message")
+}
+
+func TestWriteJSON_Cause(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, WriteJSON(&out, Wrap(fmt.Errorf("disk full"), "Failed to save ticket").WithContext("ticket", "TICKET-001")))
+
+	var decoded JSONError
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "Failed to save ticket", decoded.Message)
+	assert.Equal(t, "disk full", decoded.Cause)
+	assert.Equal(t, map[string]string{"ticket": "TICKET-001"}, decoded.Context)
+}
+
+func TestCLIError_IsCategory(t *testing.T) {
+	err := fmt.Errorf("loading: %w", ErrInvalidInput("priority", "asap", "unknown priority"))
+
+	assert.True(t, errors.Is(err, ErrCategoryValidation))
+	assert.False(t, errors.Is(err, ErrCategoryIO))
+	assert.False(t, errors.Is(fmt.Errorf("boom"), ErrCategoryValidation))
+	assert.False(t, errors.Is(NewCLIError("uncategorized", ExitGeneric), CategoryIO))
+}
+
+func TestWrap(t *testing.T) {
+	assert.Nil(t, Wrap(nil, "ignored"))
+
+	inner := ErrBlocked("Task is blocked").WithCategory(CategoryValidation).WithSuggestion("Unblock it first")
+	wrapped := Wrap(inner, "Cannot start story")
+	assert.Equal(t, "Cannot start story: Task is blocked", wrapped.Error())
+	assert.Equal(t, ExitBlocked, wrapped.Code)
+	assert.Equal(t, "Unblock it first", wrapped.Suggestion)
+	assert.True(t, errors.Is(wrapped, ErrCategoryValidation))
+	assert.Same(t, inner, AsCLIError(errors.Unwrap(wrapped)))
+
+	notFound := Wrap(fmt.Errorf("reading epics: %w", os.ErrNotExist), "Cannot load epics")
+	assert.Equal(t, ExitNotFound, notFound.Code)
+	assert.True(t, errors.Is(notFound, ErrCategoryIO))
+	assert.True(t, errors.Is(notFound, os.ErrNotExist))
+}
+
+func TestAsExitCode(t *testing.T) {
+	assert.Equal(t, 0, AsExitCode(nil))
+	assert.Equal(t, 1, AsExitCode(fmt.Errorf("boom")))
+	assert.Equal(t, 6, AsExitCode(fmt.Errorf("wrapped: %w", Wrap(ErrFileNotFound("x"), "outer"))))
+	assert.Equal(t, 7, AsExitCode(fmt.Errorf("open: %w", fs.ErrPermission)))
+	assert.Equal(t, 8, AsExitCode(fmt.Errorf("waiting: %w", context.DeadlineExceeded)))
+}
+
+// codedError is an error of another package carrying an exit code
+type codedError struct{}
+
+func (codedError) Error() string { return "locked" }
+func (codedError) ExitCode() int { return int(ExitBlocked) }
+
+func TestExitCodeOf(t *testing.T) {
+	assert.Equal(t, ExitSuccess, ExitCodeOf(nil))
+	assert.Equal(t, ExitGeneric, ExitCodeOf(fmt.Errorf("boom")))
+	assert.Equal(t, ExitTimeout, ExitCodeOf(fmt.Errorf("wrapped: %w", NewCLIError("timed out", ExitTimeout))))
+	assert.Equal(t, ExitBlocked, ExitCodeOf(fmt.Errorf("wrapped: %w", codedError{})))
+	assert.Equal(t, ExitBlocked, AsCLIError(codedError{}).Code)
+}
+
+func TestExitCodes_Stable(t *testing.T) {
+	// Scripts depend on these values: never renumber them
+	expected := map[ExitCode]int{
+		ExitSuccess:           0,
+		ExitGeneric:           1,
+		ExitUsage:             2,
+		ExitValidationFailed:  3,
+		ExitClaudeUnavailable: 4,
+		ExitBlocked:           5,
+		ExitNotFound:          6,
+		ExitPermissionDenied:  7,
+		ExitTimeout:           8,
+		ExitNetwork:           9,
+	}
+	assert.Len(t, ExitCodes(), len(expected))
+	for _, code := range ExitCodes() {
+		assert.Equal(t, expected[code], int(code), code.String())
+		assert.NotEmpty(t, code.Description(), code.String())
+	}
+	assert.Equal(t, "blocked", ExitBlocked.String())
+	assert.Equal(t, "exit_42", ExitCode(42).String())
+}
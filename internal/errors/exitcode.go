@@ -0,0 +1,96 @@
+package errors
+
+import (
+	goerrors "errors"
+	"fmt"
+	"os"
+)
+
+// ExitCode is the exit status of the CLI. The values are stable: scripts and
+// CI rely on them to tell failures apart, so never renumber them.
+type ExitCode int
+
+const (
+	ExitSuccess           ExitCode = 0 // Command succeeded
+	ExitGeneric           ExitCode = 1 // Any other failure
+	ExitUsage             ExitCode = 2 // Invalid arguments, flags or input values
+	ExitValidationFailed  ExitCode = 3 // Project files or configuration failed validation
+	ExitClaudeUnavailable ExitCode = 4 // The Claude CLI is missing or could not run
+	ExitBlocked           ExitCode = 5 // Work is blocked: refused transition, blocked task or policy
+	ExitNotFound          ExitCode = 6 // Epic, story, ticket or file not found
+	ExitPermissionDenied  ExitCode = 7 // Not allowed to read or write a file
+	ExitTimeout           ExitCode = 8 // An operation timed out
+	ExitNetwork           ExitCode = 9 // A network call failed
+)
+
+// exitCodeDescriptions documents each exit code, in order
+var exitCodeDescriptions = []struct {
+	Code        ExitCode
+	Name        string
+	Description string
+}{
+	{ExitSuccess, "success", "Command succeeded"},
+	{ExitGeneric, "generic", "Any failure without a more specific code"},
+	{ExitUsage, "usage", "Invalid arguments, flags or input values"},
+	{ExitValidationFailed, "validation_failed", "Project files or configuration failed validation"},
+	{ExitClaudeUnavailable, "claude_unavailable", "The Claude CLI is missing or could not run"},
+	{ExitBlocked, "blocked", "Work is blocked: refused status transition, blocked task or policy"},
+	{ExitNotFound, "not_found", "Epic, story, ticket or file not found"},
+	{ExitPermissionDenied, "permission_denied", "Not allowed to read or write a file"},
+	{ExitTimeout, "timeout", "An operation timed out"},
+	{ExitNetwork, "network", "A network call failed"},
+}
+
+// String returns the name of the exit code, such as "blocked"
+func (c ExitCode) String() string {
+	for _, desc := range exitCodeDescriptions {
+		if desc.Code == c {
+			return desc.Name
+		}
+	}
+	return fmt.Sprintf("exit_%d", int(c))
+}
+
+// Description returns what the exit code means
+func (c ExitCode) Description() string {
+	for _, desc := range exitCodeDescriptions {
+		if desc.Code == c {
+			return desc.Description
+		}
+	}
+	return ""
+}
+
+// ExitCodes returns all exit codes in increasing order
+func ExitCodes() []ExitCode {
+	codes := make([]ExitCode, 0, len(exitCodeDescriptions))
+	for _, desc := range exitCodeDescriptions {
+		codes = append(codes, desc.Code)
+	}
+	return codes
+}
+
+// ExitCodeOf returns the exit code of err: the code of the first CLIError in
+// its chain, or else the code inferred from the error (ExitNotFound for
+// fs.ErrNotExist, an error with an ExitCode method...), and ExitSuccess for nil
+func ExitCodeOf(err error) ExitCode {
+	if err == nil {
+		return ExitSuccess
+	}
+	var cliErr *CLIError
+	if goerrors.As(err, &cliErr) {
+		return cliErr.Code
+	}
+	code, _ := classify(err)
+	return code
+}
+
+// AsExitCode returns the exit code of err for os.Exit
+func AsExitCode(err error) int {
+	return int(ExitCodeOf(err))
+}
+
+// Exit exits the process with code
+func Exit(code ExitCode) {
+	os.Exit(int(code))
+}
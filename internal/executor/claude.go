@@ -1,171 +1,380 @@
 package executor
 
 import (
-	"bytes"
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"claude-wm-cli/internal/debug"
 )
 
+// DefaultClaudeTimeout is the per-command timeout applied when none is configured
+const DefaultClaudeTimeout = 10 * time.Minute
+
+// DryRunMode makes the executor print Claude commands instead of running them.
+// It is set by the global --dry-run flag.
+var DryRunMode = false
+
+// IsDryRun reports whether Claude commands are printed instead of executed
+func IsDryRun() bool {
+	return DryRunMode
+}
+
+// ErrClaudeCancelled is returned when a Claude command is cancelled (Ctrl-C or parent context)
+var ErrClaudeCancelled = errors.New("claude command cancelled")
+
+// ClaudeTimeoutError is returned when a Claude command exceeds its timeout.
+// Callers can detect it with IsTimeoutError to decide whether to retry or abort.
+type ClaudeTimeoutError struct {
+	Command string
+	Timeout time.Duration
+}
+
+// Error implements the error interface
+func (e *ClaudeTimeoutError) Error() string {
+	return fmt.Sprintf("claude command %q timed out after %v", e.Command, e.Timeout)
+}
+
+// IsTimeoutError reports whether err is (or wraps) a ClaudeTimeoutError
+func IsTimeoutError(err error) bool {
+	var timeoutErr *ClaudeTimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
 // ClaudeExecutor handles execution of Claude commands
 type ClaudeExecutor struct {
-	timeout time.Duration
+	timeout        time.Duration
+	timeoutSet     bool
+	logDir         string
+	lastLogPath    string
+	model          string
+	modelOverrides map[string]string
+	retryPolicy    RetryPolicy
+
+	outputHandler      func(line string)
+	errorHandler       func(line string)
+	firstOutputLatency time.Duration
 }
 
-// NewClaudeExecutor creates a new Claude command executor
+// NewClaudeExecutor creates a new Claude command executor.
+// ExecuteSlashCommand does not retry failures; see NewClaudeExecutorWithRetry.
 func NewClaudeExecutor() *ClaudeExecutor {
 	return &ClaudeExecutor{
-		timeout: 30 * time.Minute, // Default 30 minutes timeout for dev - should be enough for any command
+		timeout:     DefaultClaudeTimeout,
+		retryPolicy: NoRetryPolicy(),
 	}
 }
 
-// SetTimeout sets the timeout for Claude command execution
+// NewClaudeExecutorWithRetry creates a Claude command executor whose
+// ExecuteSlashCommand retries failures according to policy
+func NewClaudeExecutorWithRetry(policy RetryPolicy) *ClaudeExecutor {
+	ce := NewClaudeExecutor()
+	ce.SetRetryPolicy(policy)
+	return ce
+}
+
+// SetRetryPolicy sets the retry policy used by ExecuteSlashCommand
+func (ce *ClaudeExecutor) SetRetryPolicy(policy RetryPolicy) {
+	ce.retryPolicy = policy
+}
+
+// SetOutputHandler sets the function receiving each line of Claude's stdout as
+// it arrives. A nil handler restores the default, which prints the line.
+func (ce *ClaudeExecutor) SetOutputHandler(fn func(line string)) {
+	ce.outputHandler = fn
+}
+
+// SetErrorHandler sets the function receiving each line of Claude's stderr as
+// it arrives. A nil handler restores the default, which prints the line to stderr.
+func (ce *ClaudeExecutor) SetErrorHandler(fn func(line string)) {
+	ce.errorHandler = fn
+}
+
+// FirstOutputLatency returns how long the most recent command took to print its
+// first line, or 0 if it printed nothing
+func (ce *ClaudeExecutor) FirstOutputLatency() time.Duration {
+	return ce.firstOutputLatency
+}
+
+func (ce *ClaudeExecutor) emitOutput(line string) {
+	if ce.outputHandler != nil {
+		ce.outputHandler(line)
+		return
+	}
+	fmt.Fprintln(os.Stdout, line)
+}
+
+func (ce *ClaudeExecutor) emitError(line string) {
+	if ce.errorHandler != nil {
+		ce.errorHandler(line)
+		return
+	}
+	fmt.Fprintln(os.Stderr, line)
+}
+
+// SetTimeout sets the timeout for Claude command execution.
+// An explicitly set timeout is enforced even in development mode.
 func (ce *ClaudeExecutor) SetTimeout(timeout time.Duration) {
 	ce.timeout = timeout
+	ce.timeoutSet = true
 }
 
-// ExecutePrompt executes a Claude prompt command
-func (ce *ClaudeExecutor) ExecutePrompt(prompt, description string) error {
-	debug.LogClaudeCommand(prompt, description)
-	debug.LogExecution("CLAUDE", "execute prompt", fmt.Sprintf("Long-running Claude analysis with MCP tools (timeout: %v)", ce.timeout))
-	
-	// Build the command
-	cmd := exec.Command("claude", "-p", prompt)
-	
-	// Set up environment and output
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	
-	// In development mode, run without timeout to avoid interrupting long analyses
-	if debug.DevMode {
+// Timeout returns the configured per-command timeout
+func (ce *ClaudeExecutor) Timeout() time.Duration {
+	return ce.timeout
+}
+
+// SetModel sets the Claude model used for every command (e.g. "opus", "sonnet").
+// An empty model lets the Claude CLI choose its default.
+func (ce *ClaudeExecutor) SetModel(model string) {
+	ce.model = model
+}
+
+// SetModelOverrides sets per-command models keyed by slash command
+// (e.g. "/4-task:2-execute:3-Implement"), taking precedence over SetModel.
+// Keys are matched case-insensitively since config loaders lowercase them.
+func (ce *ClaudeExecutor) SetModelOverrides(overrides map[string]string) {
+	ce.modelOverrides = make(map[string]string, len(overrides))
+	for command, model := range overrides {
+		ce.modelOverrides[strings.ToLower(command)] = model
+	}
+}
+
+// modelFor returns the model to use for a prompt, or "" for the CLI default
+func (ce *ClaudeExecutor) modelFor(prompt string) string {
+	if model, ok := ce.modelOverrides[strings.ToLower(strings.TrimSpace(prompt))]; ok && model != "" {
+		return model
+	}
+	return ce.model
+}
+
+// claudeArgs builds the Claude CLI arguments for a prompt
+func (ce *ClaudeExecutor) claudeArgs(prompt string) []string {
+	args := []string{"-p", prompt}
+	if model := ce.modelFor(prompt); model != "" {
+		args = append(args, "--model", model)
+	}
+	return args
+}
+
+// effectiveTimeout returns the timeout to enforce, or 0 for none.
+// Development mode disables the default timeout to avoid interrupting long analyses.
+func (ce *ClaudeExecutor) effectiveTimeout() time.Duration {
+	if debug.DevMode && !ce.timeoutSet {
+		return 0
+	}
+	return ce.timeout
+}
+
+// runClaude runs `claude -p <prompt> [--model <model>]` bound to ctx, the configured timeout and
+// SIGINT/SIGTERM so that Ctrl-C cleanly kills the Claude process.
+// Each line of stdout and stderr is passed to the stdout and stderr callbacks as soon
+// as it arrives. When output logs are enabled, the lines are also written to a log file.
+// In dry-run mode the command is passed to stdout and not run.
+// It returns a *ClaudeTimeoutError on timeout, ErrClaudeCancelled on
+// cancellation, or the raw exec error otherwise.
+func (ce *ClaudeExecutor) runClaude(parent context.Context, prompt string, stdout, stderr func(line string)) error {
+	if IsDryRun() {
+		stdout(fmt.Sprintf("[DRY-RUN] Would execute: %s", prompt))
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	timeout := ce.effectiveTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	} else {
 		debug.LogExecution("CLAUDE", "dev mode", "Running without timeout - kill manually if needed (Ctrl+C)")
-		err := cmd.Run()
-		if err != nil {
-			debug.LogResult("CLAUDE", "execute prompt", fmt.Sprintf("Command failed: %v", err), false)
-			return fmt.Errorf("claude command failed: %w", err)
+	}
+
+	started := time.Now()
+	ce.lastLogPath = ""
+	ce.firstOutputLatency = 0
+	logFile := ce.openCommandLog(prompt, started)
+
+	cmd := exec.CommandContext(ctx, "claude", ce.claudeArgs(prompt)...)
+	cmd.Stdin = os.Stdin
+	err := ce.streamCommand(cmd, started, logFile, stdout, stderr)
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		err = &ClaudeTimeoutError{Command: prompt, Timeout: timeout}
+	case errors.Is(ctx.Err(), context.Canceled):
+		err = ErrClaudeCancelled
+	}
+
+	if logFile != nil {
+		closeCommandLog(logFile, started, err)
+	}
+	return err
+}
+
+// streamCommand starts cmd and passes each line of its output to the stdout and
+// stderr callbacks, and to logFile when not nil, until the command exits
+func (ce *ClaudeExecutor) streamCommand(cmd *exec.Cmd, started time.Time, logFile *os.File, stdout, stderr func(line string)) error {
+	stdoutPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderrPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	var logMu sync.Mutex
+	var firstOutput sync.Once
+	emit := func(handle func(string)) func(string) {
+		return func(line string) {
+			firstOutput.Do(func() { ce.firstOutputLatency = time.Since(started) })
+			if logFile != nil {
+				logMu.Lock()
+				fmt.Fprintln(logFile, line)
+				logMu.Unlock()
+			}
+			handle(line)
 		}
-		debug.LogResult("CLAUDE", "execute prompt", "Command completed successfully", true)
-		return nil
 	}
-	
-	// Production mode with timeout
-	done := make(chan error, 1)
-	go func() {
-		done <- cmd.Run()
-	}()
-	
-	select {
-	case err := <-done:
-		if err != nil {
-			debug.LogResult("CLAUDE", "execute prompt", fmt.Sprintf("Command failed: %v", err), false)
-			return fmt.Errorf("claude command failed: %w", err)
+
+	// Both pipes must be drained before Wait closes them
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go streamLines(stdoutPipe, emit(stdout), &wg)
+	go streamLines(stderrPipe, emit(stderr), &wg)
+	wg.Wait()
+
+	return cmd.Wait()
+}
+
+// maxOutputLineLength bounds a single line of Claude output
+const maxOutputLineLength = 1024 * 1024
+
+// streamLines passes each line read from r to handle until EOF. The rest of an
+// unreadable stream (e.g. a line over maxOutputLineLength) is discarded so the
+// command never blocks on a full pipe.
+func streamLines(r io.Reader, handle func(line string), wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxOutputLineLength)
+	for scanner.Scan() {
+		handle(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		debug.LogResult("CLAUDE", "stream output", fmt.Sprintf("Failed to read output: %v", err), false)
+		io.Copy(io.Discard, r)
+	}
+}
+
+// runClaudeCaptured runs Claude like runClaude while also capturing stdout and
+// stderr, still passing each line to the output and error handlers
+func (ce *ClaudeExecutor) runClaudeCaptured(ctx context.Context, prompt string) (string, string, error) {
+	var stdoutBuf, stderrBuf strings.Builder
+	var mu sync.Mutex
+	capture := func(buf *strings.Builder, handler func(string)) func(string) {
+		return func(line string) {
+			mu.Lock()
+			buf.WriteString(line + "\n")
+			mu.Unlock()
+			handler(line)
 		}
-		debug.LogResult("CLAUDE", "execute prompt", "Command completed successfully", true)
-		return nil
-		
-	case <-time.After(ce.timeout):
-		if cmd.Process != nil {
-			cmd.Process.Kill()
+	}
+
+	err := ce.runClaude(ctx, prompt, capture(&stdoutBuf, ce.emitOutput), capture(&stderrBuf, ce.emitError))
+	return stdoutBuf.String(), stderrBuf.String(), err
+}
+
+// ExecutePrompt executes a Claude prompt command
+func (ce *ClaudeExecutor) ExecutePrompt(prompt, description string) error {
+	return ce.ExecutePromptContext(context.Background(), prompt, description)
+}
+
+// ExecutePromptContext executes a Claude prompt command bound to ctx
+func (ce *ClaudeExecutor) ExecutePromptContext(ctx context.Context, prompt, description string) error {
+	debug.LogClaudeCommand(prompt, description)
+	debug.LogExecution("CLAUDE", "execute prompt", fmt.Sprintf("Long-running Claude analysis with MCP tools (timeout: %v)", ce.effectiveTimeout()))
+
+	err := ce.runClaude(ctx, prompt, ce.emitOutput, ce.emitError)
+	if err != nil {
+		debug.LogResult("CLAUDE", "execute prompt", fmt.Sprintf("Command failed: %v", err), false)
+		if IsTimeoutError(err) || errors.Is(err, ErrClaudeCancelled) {
+			return err
 		}
-		debug.LogResult("CLAUDE", "execute prompt", fmt.Sprintf("Command timed out after %v", ce.timeout), false)
-		return fmt.Errorf("claude command timed out after %v", ce.timeout)
+		return fmt.Errorf("claude command failed: %w", err)
 	}
+
+	debug.LogResult("CLAUDE", "execute prompt", "Command completed successfully", true)
+	return nil
 }
 
-// ExecuteSlashCommand executes a Claude slash command
+// ExecuteSlashCommand executes a Claude slash command, retrying failures
+// according to the executor's retry policy
 func (ce *ClaudeExecutor) ExecuteSlashCommand(slashCommand, description string) error {
+	return ce.ExecuteSlashCommandContext(context.Background(), slashCommand, description)
+}
+
+// ExecuteSlashCommandContext executes a Claude slash command bound to ctx
+func (ce *ClaudeExecutor) ExecuteSlashCommandContext(ctx context.Context, slashCommand, description string) error {
 	// Slash commands are passed directly as prompts
-	return ce.ExecutePrompt(slashCommand, description)
+	return ce.ExecuteSlashCommandWithRetryContext(ctx, slashCommand, description, ce.retryPolicy)
 }
 
 // ExecuteSlashCommandWithExitCode executes a Claude slash command and returns the exit code
 func (ce *ClaudeExecutor) ExecuteSlashCommandWithExitCode(slashCommand, description string) (int, error) {
+	return ce.ExecuteSlashCommandWithExitCodeContext(context.Background(), slashCommand, description)
+}
+
+// ExecuteSlashCommandWithExitCodeContext executes a Claude slash command bound to ctx and returns the exit code
+func (ce *ClaudeExecutor) ExecuteSlashCommandWithExitCodeContext(ctx context.Context, slashCommand, description string) (int, error) {
 	debug.LogClaudeCommand(slashCommand, description)
-	debug.LogExecution("CLAUDE", "execute slash command with exit code", fmt.Sprintf("Claude command with exit code tracking (timeout: %v)", ce.timeout))
-	
-	// Build the command
-	cmd := exec.Command("claude", "-p", slashCommand)
-	
-	// Create pipes to capture both stdout and stderr while still showing output to user
-	var stdoutBuf, stderrBuf bytes.Buffer
-	
-	// Create multiwriters to tee output to both buffer and terminal
-	stdoutWriter := io.MultiWriter(os.Stdout, &stdoutBuf)
-	stderrWriter := io.MultiWriter(os.Stderr, &stderrBuf)
-	
-	cmd.Stdout = stdoutWriter
-	cmd.Stderr = stderrWriter
-	cmd.Stdin = os.Stdin
-	
-	// In development mode, run without timeout
-	if debug.DevMode {
-		debug.LogExecution("CLAUDE", "dev mode", "Running without timeout - kill manually if needed (Ctrl+C)")
-		err := cmd.Run()
-		
-		// Parse Claude's output for EXIT_CODE
-		claudeExitCode := parseClaudeExitCode(stdoutBuf.String(), stderrBuf.String())
-		if claudeExitCode != -1 {
-			debug.LogResult("CLAUDE", "execute slash command with exit code", 
-				fmt.Sprintf("Command completed with exit code: %d", claudeExitCode), claudeExitCode == 0)
-			return claudeExitCode, nil
-		}
-		
-		// Fallback to system exit code if Claude didn't specify one
-		systemExitCode := getExitCode(err)
-		debug.LogResult("CLAUDE", "execute slash command with exit code", 
-			fmt.Sprintf("Command completed with exit code: %d", systemExitCode), err == nil)
-		
-		return systemExitCode, nil
-	}
-	
-	// Run with timeout in production mode
-	done := make(chan error, 1)
-	go func() {
-		done <- cmd.Run()
-	}()
-	
-	select {
-	case err := <-done:
-		// Parse Claude's output for EXIT_CODE
-		claudeExitCode := parseClaudeExitCode(stdoutBuf.String(), stderrBuf.String())
-		if claudeExitCode != -1 {
-			debug.LogResult("CLAUDE", "execute slash command with exit code", 
-				fmt.Sprintf("Command completed with exit code: %d", claudeExitCode), claudeExitCode == 0)
-			return claudeExitCode, nil
-		}
-		
-		// Fallback to system exit code if Claude didn't specify one
-		systemExitCode := getExitCode(err)
-		debug.LogResult("CLAUDE", "execute slash command with exit code", 
-			fmt.Sprintf("Command completed with exit code: %d", systemExitCode), err == nil)
-		return systemExitCode, nil
-		
-	case <-time.After(ce.timeout):
-		if cmd.Process != nil {
-			cmd.Process.Kill()
-		}
-		debug.LogResult("CLAUDE", "execute slash command with exit code", 
-			fmt.Sprintf("Command timed out after %v", ce.timeout), false)
-		return -1, fmt.Errorf("claude command timed out after %v", ce.timeout)
+	debug.LogExecution("CLAUDE", "execute slash command with exit code", fmt.Sprintf("Claude command with exit code tracking (timeout: %v)", ce.effectiveTimeout()))
+
+	stdout, stderr, err := ce.runClaudeCaptured(ctx, slashCommand)
+	if IsTimeoutError(err) || errors.Is(err, ErrClaudeCancelled) {
+		debug.LogResult("CLAUDE", "execute slash command with exit code", err.Error(), false)
+		return -1, err
 	}
+
+	// Parse Claude's output for EXIT_CODE
+	claudeExitCode := parseClaudeExitCode(stdout, stderr)
+	if claudeExitCode != -1 {
+		debug.LogResult("CLAUDE", "execute slash command with exit code",
+			fmt.Sprintf("Command completed with exit code: %d", claudeExitCode), claudeExitCode == 0)
+		return claudeExitCode, nil
+	}
+
+	// Fallback to system exit code if Claude didn't specify one
+	systemExitCode := getExitCode(err)
+	debug.LogResult("CLAUDE", "execute slash command with exit code",
+		fmt.Sprintf("Command completed with exit code: %d", systemExitCode), err == nil)
+	return systemExitCode, nil
 }
 
 // parseClaudeExitCode parses Claude Code's output for EXIT_CODE=X pattern
 func parseClaudeExitCode(stdout, stderr string) int {
 	// Combine both stdout and stderr for parsing
 	combined := stdout + "\n" + stderr
-	
+
 	// Pattern to match EXIT_CODE=X where X is a number
 	pattern := regexp.MustCompile(`EXIT_CODE=(\d+)`)
-	
+
 	// Find the last occurrence (in case there are multiple)
 	matches := pattern.FindAllStringSubmatch(combined, -1)
 	if len(matches) > 0 {
@@ -176,7 +385,7 @@ func parseClaudeExitCode(stdout, stderr string) int {
 			}
 		}
 	}
-	
+
 	// No EXIT_CODE found in output
 	return -1
 }
@@ -186,28 +395,44 @@ func getExitCode(err error) int {
 	if err == nil {
 		return 0
 	}
-	
-	if exitError, ok := err.(*exec.ExitError); ok {
-		return exitError.ExitCode()
+
+	if exitCode, ok := exitCodeOf(err); ok {
+		return exitCode
 	}
-	
+
 	// If we can't determine the exit code, assume failure
 	return 1
 }
 
-// ValidateClaudeAvailable checks if Claude CLI is available
+// exitCodeOf returns the exit code of a Claude process that ran and exited,
+// or false when err (or the error it wraps) is not an *exec.ExitError
+func exitCodeOf(err error) (int, bool) {
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return exitError.ExitCode(), true
+	}
+	return 0, false
+}
+
+// ValidateClaudeAvailable checks if Claude CLI is available.
+// The check is skipped in dry-run mode since Claude is never invoked.
 func (ce *ClaudeExecutor) ValidateClaudeAvailable() error {
+	if IsDryRun() {
+		debug.LogExecution("CLAUDE", "validate availability", "Skipped in dry-run mode")
+		return nil
+	}
+
 	debug.LogExecution("CLAUDE", "validate availability", "Check if claude command is in PATH")
-	
+
 	cmd := exec.Command("claude", "--version")
 	output, err := cmd.Output()
-	
+
 	if err != nil {
 		debug.LogResult("CLAUDE", "validate availability", "Claude CLI not found in PATH", false)
 		return fmt.Errorf("claude CLI not found: %w", err)
 	}
-	
+
 	version := strings.TrimSpace(string(output))
 	debug.LogResult("CLAUDE", "validate availability", fmt.Sprintf("Claude CLI found: %s", version), true)
 	return nil
-}
\ No newline at end of file
+}
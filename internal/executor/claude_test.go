@@ -0,0 +1,136 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"claude-wm-cli/internal/debug"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaudeArgsModel(t *testing.T) {
+	ce := NewClaudeExecutor()
+	assert.Equal(t, []string{"-p", "/status"}, ce.claudeArgs("/status"))
+
+	ce.SetModel("sonnet")
+	assert.Equal(t, []string{"-p", "/status", "--model", "sonnet"}, ce.claudeArgs("/status"))
+
+	ce.SetModelOverrides(map[string]string{"/4-task:2-execute:3-implement": "opus"})
+	assert.Equal(t, []string{"-p", "/4-task:2-execute:3-Implement", "--model", "opus"},
+		ce.claudeArgs("/4-task:2-execute:3-Implement"))
+	assert.Equal(t, "sonnet", ce.modelFor("/status"))
+}
+
+func TestDryRunSkipsClaude(t *testing.T) {
+	DryRunMode = true
+	defer func() { DryRunMode = false }()
+	t.Setenv("PATH", t.TempDir())
+
+	ce := NewClaudeExecutor()
+	assert.True(t, IsDryRun())
+	assert.NoError(t, ce.ValidateClaudeAvailable())
+
+	var stdout []string
+	collect := func(line string) { stdout = append(stdout, line) }
+	assert.NoError(t, ce.runClaude(context.Background(), "/4-task:2-execute:1-Plan-Ticket", collect, func(string) {}))
+	assert.Equal(t, []string{"[DRY-RUN] Would execute: /4-task:2-execute:1-Plan-Ticket"}, stdout)
+
+	exitCode, err := ce.ExecuteSlashCommandWithExitCode("/4-task:2-execute:4-Validate-Task", "dry run")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+}
+
+func TestOutputHandlersReceiveLines(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake claude script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho first\necho oops >&2\nprintf 'EXIT_CODE=1'\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "claude"), []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var stdout, stderr []string
+	ce := NewClaudeExecutor()
+	ce.SetOutputHandler(func(line string) { stdout = append(stdout, line) })
+	ce.SetErrorHandler(func(line string) { stderr = append(stderr, line) })
+	ce.SetLogDir(filepath.Join(dir, "logs"))
+
+	exitCode, err := ce.ExecuteSlashCommandWithExitCode("/4-task:2-execute:4-Validate-Task", "stream test")
+	require.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, []string{"first", "EXIT_CODE=1"}, stdout)
+	assert.Equal(t, []string{"oops"}, stderr)
+	assert.Greater(t, ce.FirstOutputLatency(), time.Duration(0))
+
+	log, err := os.ReadFile(ce.LastLogPath())
+	require.NoError(t, err)
+	assert.Contains(t, string(log), "first\n")
+	assert.Contains(t, string(log), "oops\n")
+}
+
+// installSleepingClaude puts a claude script on PATH that sleeps until killed
+func installSleepingClaude(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake claude script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho started\nexec sleep 10\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "claude"), []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestClaudeTimeout(t *testing.T) {
+	installSleepingClaude(t)
+
+	ce := NewClaudeExecutorWithRetry(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond})
+	ce.SetOutputHandler(func(string) {})
+	ce.SetTimeout(200 * time.Millisecond)
+
+	started := time.Now()
+	err := ce.ExecuteSlashCommand("/status", "timeout test")
+	assert.Less(t, time.Since(started), 5*time.Second, "timeouts are not retried")
+
+	var timeoutErr *ClaudeTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.True(t, IsTimeoutError(err))
+	assert.Equal(t, "/status", timeoutErr.Command)
+	assert.Equal(t, 200*time.Millisecond, timeoutErr.Timeout)
+
+	exitCode, err := ce.ExecuteSlashCommandWithExitCode("/status", "timeout test")
+	assert.True(t, IsTimeoutError(err))
+	assert.Equal(t, -1, exitCode)
+}
+
+func TestClaudeCancelled(t *testing.T) {
+	installSleepingClaude(t)
+
+	ce := NewClaudeExecutorWithRetry(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond})
+	ce.SetOutputHandler(func(string) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(200*time.Millisecond, cancel)
+
+	started := time.Now()
+	err := ce.ExecuteSlashCommandContext(ctx, "/status", "cancel test")
+	assert.Less(t, time.Since(started), 5*time.Second, "cancelled commands are not retried")
+	assert.ErrorIs(t, err, ErrClaudeCancelled)
+	assert.False(t, IsTimeoutError(err))
+}
+
+func TestEffectiveTimeoutInDevMode(t *testing.T) {
+	devMode := debug.DevMode
+	debug.DevMode = true
+	defer func() { debug.DevMode = devMode }()
+
+	ce := NewClaudeExecutor()
+	assert.Equal(t, time.Duration(0), ce.effectiveTimeout(), "dev mode runs without the default timeout")
+
+	ce.SetTimeout(time.Minute)
+	assert.Equal(t, time.Minute, ce.effectiveTimeout(), "an explicit timeout is enforced in dev mode")
+}
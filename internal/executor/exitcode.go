@@ -0,0 +1,53 @@
+package executor
+
+// ExitOutcome is the meaning of an exit code returned by a Claude slash command
+type ExitOutcome int
+
+const (
+	OutcomeSuccess    ExitOutcome = iota // Step passed
+	OutcomeIterate                       // Step needs another iteration
+	OutcomeBlocked                       // Task is blocked
+	OutcomeUnexpected                    // Code not covered by the mapping
+)
+
+// String returns a human-readable outcome name
+func (o ExitOutcome) String() string {
+	switch o {
+	case OutcomeSuccess:
+		return "success"
+	case OutcomeIterate:
+		return "iterate"
+	case OutcomeBlocked:
+		return "blocked"
+	default:
+		return "unexpected"
+	}
+}
+
+// ExitCodeMapping maps the EXIT_CODE values emitted by prompt templates to outcomes
+type ExitCodeMapping struct {
+	Success int
+	Iterate int
+	Blocked int
+}
+
+// DefaultExitCodeMapping returns the mapping used by the bundled templates:
+// 0 = success, 1 = iterate, 2 = blocked
+func DefaultExitCodeMapping() ExitCodeMapping {
+	return ExitCodeMapping{Success: 0, Iterate: 1, Blocked: 2}
+}
+
+// InterpretExitCode maps an exit code to its outcome. Codes not present in the
+// mapping are always OutcomeUnexpected.
+func InterpretExitCode(code int, mapping ExitCodeMapping) ExitOutcome {
+	switch code {
+	case mapping.Success:
+		return OutcomeSuccess
+	case mapping.Iterate:
+		return OutcomeIterate
+	case mapping.Blocked:
+		return OutcomeBlocked
+	default:
+		return OutcomeUnexpected
+	}
+}
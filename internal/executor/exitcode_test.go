@@ -0,0 +1,22 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpretExitCode(t *testing.T) {
+	defaults := DefaultExitCodeMapping()
+	assert.Equal(t, OutcomeSuccess, InterpretExitCode(0, defaults))
+	assert.Equal(t, OutcomeIterate, InterpretExitCode(1, defaults))
+	assert.Equal(t, OutcomeBlocked, InterpretExitCode(2, defaults))
+	assert.Equal(t, OutcomeUnexpected, InterpretExitCode(3, defaults))
+	assert.Equal(t, OutcomeUnexpected, InterpretExitCode(-1, defaults))
+
+	custom := ExitCodeMapping{Success: 0, Iterate: 10, Blocked: 20}
+	assert.Equal(t, OutcomeIterate, InterpretExitCode(10, custom))
+	assert.Equal(t, OutcomeBlocked, InterpretExitCode(20, custom))
+	assert.Equal(t, OutcomeUnexpected, InterpretExitCode(1, custom))
+	assert.Equal(t, "unexpected", InterpretExitCode(2, custom).String())
+}
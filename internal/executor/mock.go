@@ -0,0 +1,145 @@
+package executor
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// MockResponse is the canned result of a Claude command run by MockClaudeExecutor
+type MockResponse struct {
+	ExitCode int
+	Output   string
+	Delay    time.Duration
+}
+
+// MockClaudeExecutor replaces ClaudeExecutor in tests. It answers each slash
+// command or prompt with a canned MockResponse and records the calls, without
+// starting any subprocess.
+type MockClaudeExecutor struct {
+	mu        sync.Mutex
+	responses map[string]MockResponse
+	queued    map[string][]MockResponse
+	calls     []string
+	output    io.Writer
+	latency   time.Duration
+}
+
+// NewMockClaudeExecutor creates a mock executor answering commands with the
+// given responses, keyed by slash command or prompt
+func NewMockClaudeExecutor(responses map[string]MockResponse) *MockClaudeExecutor {
+	mock := &MockClaudeExecutor{
+		responses: make(map[string]MockResponse, len(responses)),
+		queued:    make(map[string][]MockResponse),
+		output:    io.Discard,
+	}
+	for command, response := range responses {
+		mock.responses[command] = response
+	}
+	return mock
+}
+
+// QueueResponses makes the next calls to command return responses in order,
+// before falling back to the response given to NewMockClaudeExecutor
+func (m *MockClaudeExecutor) QueueResponses(command string, responses ...MockResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.queued[command] = append(m.queued[command], responses...)
+}
+
+// SetOutput sets where response output is written (discarded by default)
+func (m *MockClaudeExecutor) SetOutput(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.output = w
+}
+
+// RecordedCalls returns the commands and prompts executed so far, in order
+func (m *MockClaudeExecutor) RecordedCalls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// ValidateClaudeAvailable always succeeds
+func (m *MockClaudeExecutor) ValidateClaudeAvailable() error {
+	return nil
+}
+
+// LastLogPath returns "" since the mock never saves output logs
+func (m *MockClaudeExecutor) LastLogPath() string {
+	return ""
+}
+
+// FirstOutputLatency returns the delay of the last response, or 0 if it had no output
+func (m *MockClaudeExecutor) FirstOutputLatency() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.latency
+}
+
+// ExecutePrompt returns an error if the prompt's response has a non-zero exit code
+func (m *MockClaudeExecutor) ExecutePrompt(prompt, description string) error {
+	return m.run(prompt)
+}
+
+// ExecuteSlashCommand returns an error if the command's response has a non-zero exit code
+func (m *MockClaudeExecutor) ExecuteSlashCommand(slashCommand, description string) error {
+	return m.run(slashCommand)
+}
+
+// ExecuteSlashCommandWithRetry behaves like ExecuteSlashCommand; canned
+// responses are never retried
+func (m *MockClaudeExecutor) ExecuteSlashCommandWithRetry(slashCommand, description string, policy RetryPolicy) error {
+	return m.run(slashCommand)
+}
+
+// ExecuteSlashCommandWithExitCode returns the exit code of the command's response
+func (m *MockClaudeExecutor) ExecuteSlashCommandWithExitCode(slashCommand, description string) (int, error) {
+	response, err := m.respond(slashCommand)
+	if err != nil {
+		return -1, err
+	}
+	return response.ExitCode, nil
+}
+
+func (m *MockClaudeExecutor) run(command string) error {
+	response, err := m.respond(command)
+	if err != nil {
+		return err
+	}
+	if response.ExitCode != 0 {
+		return fmt.Errorf("claude command %q failed with exit code %d", command, response.ExitCode)
+	}
+	return nil
+}
+
+// respond records the call, then waits for and writes the next response to command
+func (m *MockClaudeExecutor) respond(command string) (MockResponse, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, command)
+	response, ok := m.responses[command]
+	if queue := m.queued[command]; len(queue) > 0 {
+		response, ok = queue[0], true
+		m.queued[command] = queue[1:]
+	}
+	output := m.output
+	m.mu.Unlock()
+
+	if !ok {
+		return MockResponse{}, fmt.Errorf("mock claude executor: no response for %q", command)
+	}
+
+	time.Sleep(response.Delay)
+	latency := time.Duration(0)
+	if response.Output != "" {
+		fmt.Fprintln(output, response.Output)
+		latency = response.Delay
+	}
+
+	m.mu.Lock()
+	m.latency = latency
+	m.mu.Unlock()
+	return response, nil
+}
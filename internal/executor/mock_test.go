@@ -0,0 +1,49 @@
+package executor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockClaudeExecutor(t *testing.T) {
+	mock := NewMockClaudeExecutor(map[string]MockResponse{
+		"/4-task:2-execute:3-Implement":     {Output: "implemented"},
+		"/4-task:2-execute:4-Validate-Task": {ExitCode: 0},
+	})
+	mock.QueueResponses("/4-task:2-execute:4-Validate-Task", MockResponse{ExitCode: 1}, MockResponse{ExitCode: 2})
+
+	var output bytes.Buffer
+	mock.SetOutput(&output)
+
+	assert.NoError(t, mock.ValidateClaudeAvailable())
+	assert.NoError(t, mock.ExecuteSlashCommandWithRetry("/4-task:2-execute:3-Implement", "implement", DefaultRetryPolicy()))
+	assert.Equal(t, "implemented\n", output.String())
+
+	for _, expected := range []int{1, 2, 0, 0} {
+		exitCode, err := mock.ExecuteSlashCommandWithExitCode("/4-task:2-execute:4-Validate-Task", "validate")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, exitCode)
+	}
+
+	_, err := mock.ExecuteSlashCommandWithExitCode("/unknown", "unknown")
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{
+		"/4-task:2-execute:3-Implement",
+		"/4-task:2-execute:4-Validate-Task",
+		"/4-task:2-execute:4-Validate-Task",
+		"/4-task:2-execute:4-Validate-Task",
+		"/4-task:2-execute:4-Validate-Task",
+		"/unknown",
+	}, mock.RecordedCalls())
+}
+
+func TestMockClaudeExecutorNonZeroExitIsError(t *testing.T) {
+	mock := NewMockClaudeExecutor(map[string]MockResponse{"/status": {ExitCode: 3}})
+
+	err := mock.ExecuteSlashCommand("/status", "status")
+	assert.EqualError(t, err, `claude command "/status" failed with exit code 3`)
+	assert.Empty(t, mock.LastLogPath())
+}
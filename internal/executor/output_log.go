@@ -0,0 +1,80 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"claude-wm-cli/internal/debug"
+)
+
+// DefaultLogDir is the directory Claude output logs are written to when enabled
+const DefaultLogDir = ".claude-wm/logs"
+
+// maxLogNameLength bounds the command part of a log file name
+const maxLogNameLength = 60
+
+var logNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// SetLogDir enables saving each command's stdout and stderr to a log file
+// under dir. An empty dir disables output logs.
+func (ce *ClaudeExecutor) SetLogDir(dir string) {
+	ce.logDir = dir
+}
+
+// LastLogPath returns the log file of the most recent command, or "" when
+// output logs are disabled or no command has run yet
+func (ce *ClaudeExecutor) LastLogPath() string {
+	return ce.lastLogPath
+}
+
+// logFileName builds "<timestamp>-<command>.log" from a prompt
+func logFileName(prompt string, at time.Time) string {
+	name := strings.Trim(logNameSanitizer.ReplaceAllString(prompt, "-"), "-")
+	if len(name) > maxLogNameLength {
+		name = strings.TrimRight(name[:maxLogNameLength], "-")
+	}
+	if name == "" {
+		name = "prompt"
+	}
+	return fmt.Sprintf("%s-%s.log", at.Format("20060102-150405.000"), name)
+}
+
+// openCommandLog creates the log file for a command and writes its header.
+// It returns nil when output logs are disabled or the file cannot be created,
+// since a missing log must never fail the command itself.
+func (ce *ClaudeExecutor) openCommandLog(prompt string, started time.Time) *os.File {
+	if ce.logDir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(ce.logDir, 0755); err != nil {
+		debug.LogResult("CLAUDE", "save logs", fmt.Sprintf("Failed to create log directory: %v", err), false)
+		return nil
+	}
+
+	path := filepath.Join(ce.logDir, logFileName(prompt, started))
+	file, err := os.Create(path)
+	if err != nil {
+		debug.LogResult("CLAUDE", "save logs", fmt.Sprintf("Failed to create log file: %v", err), false)
+		return nil
+	}
+
+	fmt.Fprintf(file, "# command: %s\n# started: %s\n\n", prompt, started.Format(time.RFC3339))
+	ce.lastLogPath = path
+	return file
+}
+
+// closeCommandLog writes the outcome of the command and closes its log file
+func closeCommandLog(file *os.File, started time.Time, runErr error) {
+	outcome := "success"
+	if runErr != nil {
+		outcome = runErr.Error()
+	}
+	fmt.Fprintf(file, "\n# finished: %s (duration %v, result: %s)\n",
+		time.Now().Format(time.RFC3339), time.Since(started).Round(time.Millisecond), outcome)
+	file.Close()
+}
@@ -0,0 +1,46 @@
+package executor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogFileName(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	assert.Equal(t, "20250102-030405.000-4-task-2-execute-4-Validate-Task.log",
+		logFileName("/4-task:2-execute:4-Validate-Task", at))
+	assert.Equal(t, "20250102-030405.000-prompt.log", logFileName("///", at))
+
+	long := logFileName(strings.Repeat("word ", 40), at)
+	assert.LessOrEqual(t, len(long), len("20250102-030405.000-")+maxLogNameLength+len(".log"))
+}
+
+func TestCommandLog(t *testing.T) {
+	ce := NewClaudeExecutor()
+	assert.Nil(t, ce.openCommandLog("/cmd", time.Now()))
+	assert.Empty(t, ce.LastLogPath())
+
+	ce.SetLogDir(filepath.Join(t.TempDir(), "logs"))
+	started := time.Now()
+	file := ce.openCommandLog("/1-project:3-epics:1-Plan-Epics", started)
+	require.NotNil(t, file)
+
+	_, err := file.WriteString("EXIT_CODE=1\n")
+	require.NoError(t, err)
+	closeCommandLog(file, started, errors.New("exit status 1"))
+
+	data, err := os.ReadFile(ce.LastLogPath())
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "# command: /1-project:3-epics:1-Plan-Epics")
+	assert.Contains(t, content, "EXIT_CODE=1")
+	assert.Contains(t, content, "result: exit status 1")
+}
@@ -0,0 +1,158 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"time"
+
+	"claude-wm-cli/internal/debug"
+)
+
+// Default retry settings for failed Claude commands
+const (
+	DefaultClaudeMaxAttempts   = 3
+	DefaultClaudeRetryDelay    = time.Second
+	DefaultClaudeMaxRetryDelay = 30 * time.Second
+	DefaultClaudeBackoffFactor = 2.0
+)
+
+// RetryPolicy configures retries of failed Claude commands
+type RetryPolicy struct {
+	MaxAttempts        int           // Total number of runs, including the first; 1 or less disables retries
+	InitialDelay       time.Duration // Delay before the first retry
+	MaxDelay           time.Duration // Upper bound for the delay between attempts; 0 means none
+	BackoffFactor      float64       // Multiplier applied to the delay after each retry; below 1 keeps it constant
+	RetryableExitCodes []int         // Claude CLI exit codes retried even when the failure does not look transient
+}
+
+// DefaultRetryPolicy returns the default retry configuration: 3 attempts,
+// 1s initial delay doubling up to 30s, retrying transient failures and exit code 1
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:        DefaultClaudeMaxAttempts,
+		InitialDelay:       DefaultClaudeRetryDelay,
+		MaxDelay:           DefaultClaudeMaxRetryDelay,
+		BackoffFactor:      DefaultClaudeBackoffFactor,
+		RetryableExitCodes: []int{1},
+	}
+}
+
+// NoRetryPolicy returns a policy that runs each command once
+func NoRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// Delay returns the jittered delay before retry number retry (1 for the first
+// retry): InitialDelay * BackoffFactor^(retry-1), capped at MaxDelay, then
+// randomised between half and the full value so that parallel runs spread out
+func (p RetryPolicy) Delay(retry int) time.Duration {
+	factor := math.Max(p.BackoffFactor, 1)
+	delay := float64(p.InitialDelay)
+	for i := 1; i < retry; i++ {
+		delay *= factor
+		if p.MaxDelay > 0 && delay >= float64(p.MaxDelay) {
+			break
+		}
+	}
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	return time.Duration(delay/2 + rand.Float64()*delay/2)
+}
+
+// isRetryable reports whether a failed Claude run should be retried: transient
+// failures always are, and so are RetryableExitCodes unless Claude reported an
+// explicit EXIT_CODE
+func (p RetryPolicy) isRetryable(stdout, stderr string, err error) bool {
+	if IsTransientFailure(stdout, stderr, err) {
+		return true
+	}
+	if parseClaudeExitCode(stdout, stderr) != -1 {
+		return false
+	}
+	exitCode, exited := exitCodeOf(err)
+	return exited && slices.Contains(p.RetryableExitCodes, exitCode)
+}
+
+// transientPatterns match the Claude CLI API errors and network failures that
+// indicate a transient failure. They are matched against stderr and the run
+// error only: stdout is Claude's answer and may quote any of these words.
+var transientPatterns = []*regexp.Regexp{
+	// API Error: 429 {"type":"error","error":{"type":"rate_limit_error",...}}
+	regexp.MustCompile(`(?im)^(error: )?api error:? \(?(429|500|502|503|504|529)\b`),
+	regexp.MustCompile(`(?im)^(error: )?api error\b.*\b(rate_limit_error|overloaded_error|connection error)`),
+	regexp.MustCompile(`(?im)^(error: )?(429 too many requests|502 bad gateway|503 service unavailable|504 gateway timeout)\b`),
+	regexp.MustCompile(`(?i)\b(connection refused|connection reset by peer|econnrefused|econnreset|etimedout|eai_again)\b`),
+}
+
+// IsTransientFailure classifies a failed Claude run as transient (worth retrying)
+// from its stderr and error. Runs where Claude reported an explicit EXIT_CODE
+// are semantic results (e.g. 1 = needs iteration, 2 = blocked) and are never transient.
+func IsTransientFailure(stdout, stderr string, err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrClaudeCancelled) || IsTimeoutError(err) {
+		return false
+	}
+	if parseClaudeExitCode(stdout, stderr) != -1 {
+		return false
+	}
+
+	diagnostics := stderr + "\n" + err.Error()
+	for _, pattern := range transientPatterns {
+		if pattern.MatchString(diagnostics) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExecuteSlashCommandWithRetry executes a Claude slash command, retrying
+// transient failures and retryable exit codes with exponential backoff
+func (ce *ClaudeExecutor) ExecuteSlashCommandWithRetry(slashCommand, description string, policy RetryPolicy) error {
+	return ce.ExecuteSlashCommandWithRetryContext(context.Background(), slashCommand, description, policy)
+}
+
+// ExecuteSlashCommandWithRetryContext is ExecuteSlashCommandWithRetry bound to ctx
+func (ce *ClaudeExecutor) ExecuteSlashCommandWithRetryContext(ctx context.Context, slashCommand, description string, policy RetryPolicy) error {
+	debug.LogClaudeCommand(slashCommand, description)
+	maxAttempts := max(policy.MaxAttempts, 1)
+
+	for attempt := 1; ; attempt++ {
+		debug.LogExecution("CLAUDE", "execute slash command with retry",
+			fmt.Sprintf("Attempt %d/%d (timeout: %v)", attempt, maxAttempts, ce.effectiveTimeout()))
+
+		stdout, stderr, err := ce.runClaudeCaptured(ctx, slashCommand)
+		if err == nil {
+			debug.LogResult("CLAUDE", "execute slash command with retry", "Command completed successfully", true)
+			return nil
+		}
+
+		if attempt >= maxAttempts || !policy.isRetryable(stdout, stderr, err) {
+			debug.LogResult("CLAUDE", "execute slash command with retry", fmt.Sprintf("Command failed: %v", err), false)
+			if IsTimeoutError(err) || errors.Is(err, ErrClaudeCancelled) {
+				return err
+			}
+			if attempt > 1 {
+				return fmt.Errorf("claude command failed after %d attempts: %w", attempt, err)
+			}
+			return fmt.Errorf("claude command failed: %w", err)
+		}
+
+		delay := policy.Delay(attempt)
+		debug.LogExecution("CLAUDE", "retry", fmt.Sprintf("Attempt %d/%d failed (%v), retrying in %v",
+			attempt, maxAttempts, err, delay.Round(time.Millisecond)))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ErrClaudeCancelled
+		}
+	}
+}
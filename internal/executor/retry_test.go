@@ -0,0 +1,154 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransientFailure(t *testing.T) {
+	failure := errors.New("exit status 1")
+
+	tests := []struct {
+		name   string
+		stdout string
+		stderr string
+		err    error
+		want   bool
+	}{
+		{"success", "", "", nil, false},
+		{"rate limited", "", "Error: 429 Too Many Requests", failure, true},
+		{"api rate limit", "", `API Error: 429 {"type":"error","error":{"type":"rate_limit_error"}}`, failure, true},
+		{"api overloaded", "", `API Error: 529 {"type":"error","error":{"type":"overloaded_error"}}`, failure, true},
+		{"api connection error", "", "API Error (Connection error.)", failure, true},
+		{"network blip", "", "connection reset by peer", failure, true},
+		{"network error in err", "", "", errors.New("read tcp: connection refused"), true},
+		{"api words in stdout", "Handle the rate limit, 429 and 503 when the API is overloaded or the network is down", "", failure, false},
+		{"api words in stderr prose", "", "warning: network settings changed, 503 requests queued", failure, false},
+		{"semantic needs iteration", "Rate limit notes\nEXIT_CODE=1", "", failure, false},
+		{"semantic blocked", "EXIT_CODE=2", "", failure, false},
+		{"generic failure", "", "invalid prompt", failure, false},
+		{"cancelled", "", "network", ErrClaudeCancelled, false},
+		{"timeout", "", "network", &ClaudeTimeoutError{Command: "/x", Timeout: time.Second}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransientFailure(tt.stdout, tt.stderr, tt.err))
+		})
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	assert.Equal(t, RetryPolicy{
+		MaxAttempts:        3,
+		InitialDelay:       time.Second,
+		MaxDelay:           30 * time.Second,
+		BackoffFactor:      2,
+		RetryableExitCodes: []int{1},
+	}, DefaultRetryPolicy())
+	assert.Equal(t, 1, NoRetryPolicy().MaxAttempts)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   map[int]time.Duration
+	}{
+		{"default", DefaultRetryPolicy(), map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: 30 * time.Second}},
+		{"factor 3", RetryPolicy{InitialDelay: time.Second, MaxDelay: 30 * time.Second, BackoffFactor: 3}, map[int]time.Duration{1: time.Second, 2: 3 * time.Second, 3: 9 * time.Second, 4: 27 * time.Second}},
+		{"no factor keeps the delay", RetryPolicy{InitialDelay: time.Second}, map[int]time.Duration{1: time.Second, 5: time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for retry, full := range tt.want {
+				delay := tt.policy.Delay(retry)
+				assert.GreaterOrEqual(t, delay, full/2, "retry %d", retry)
+				assert.LessOrEqual(t, delay, full, "retry %d", retry)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	exitOne := exec.Command("sh", "-c", "exit 1").Run()
+	require.Error(t, exitOne)
+	exitTwo := exec.Command("sh", "-c", "exit 2").Run()
+	require.Error(t, exitTwo)
+	transientOnly := RetryPolicy{MaxAttempts: 3}
+
+	assert.True(t, DefaultRetryPolicy().isRetryable("", "", exitOne))
+	assert.False(t, DefaultRetryPolicy().isRetryable("", "", exitTwo))
+	assert.False(t, DefaultRetryPolicy().isRetryable("EXIT_CODE=1", "", exitOne))
+	assert.False(t, transientOnly.isRetryable("", "", exitOne))
+	assert.True(t, transientOnly.isRetryable("", "Error: 429 Too Many Requests", exitOne))
+}
+
+// installFakeClaude puts a claude script on PATH that exits with the given
+// codes on successive runs and counts its runs in the returned file
+func installFakeClaude(t *testing.T, exitCodes ...int) string {
+	t.Helper()
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "runs")
+
+	script := "#!/bin/sh\nn=$(cat " + counter + " 2>/dev/null || echo 0)\nn=$((n+1))\necho $n > " + counter + "\ncase $n in\n"
+	for i, code := range exitCodes {
+		script += fmt.Sprintf("%d) exit %d ;;\n", i+1, code)
+	}
+	script += "esac\nexit 0\n"
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "claude"), []byte(script), 0755))
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return counter
+}
+
+func runCount(t *testing.T, counter string) string {
+	t.Helper()
+	data, err := os.ReadFile(counter)
+	require.NoError(t, err)
+	return strings.TrimSpace(string(data))
+}
+
+func TestExecuteSlashCommandRetriesRetryableExitCodes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake claude script requires a POSIX shell")
+	}
+	policy := DefaultRetryPolicy()
+	policy.InitialDelay = time.Millisecond
+	policy.MaxDelay = time.Millisecond
+
+	counter := installFakeClaude(t, 1, 1)
+	require.NoError(t, NewClaudeExecutorWithRetry(policy).ExecuteSlashCommand("/status", "retry test"))
+	assert.Equal(t, "3", runCount(t, counter))
+
+	counter = installFakeClaude(t, 1, 1, 1)
+	err := NewClaudeExecutorWithRetry(policy).ExecuteSlashCommand("/status", "retry test")
+	assert.ErrorContains(t, err, "after 3 attempts")
+	assert.Equal(t, "3", runCount(t, counter))
+
+	counter = installFakeClaude(t, 2)
+	err = NewClaudeExecutorWithRetry(policy).ExecuteSlashCommand("/status", "retry test")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "attempts")
+	assert.Equal(t, "1", runCount(t, counter))
+
+	// Without retryable exit codes only transient failures are retried
+	counter = installFakeClaude(t, 1)
+	assert.Error(t, NewClaudeExecutorWithRetry(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}).ExecuteSlashCommand("/status", "retry test"))
+	assert.Equal(t, "1", runCount(t, counter))
+
+	counter = installFakeClaude(t, 1)
+	assert.Error(t, NewClaudeExecutor().ExecuteSlashCommand("/status", "retry test"))
+	assert.Equal(t, "1", runCount(t, counter))
+}
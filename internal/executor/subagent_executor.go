@@ -11,21 +11,28 @@ import (
 	"claude-wm-cli/internal/subagents"
 )
 
+// PromptExecutor is the part of ClaudeExecutor the subagent-aware executor
+// falls back to, so that a MockClaudeExecutor can stand in for it
+type PromptExecutor interface {
+	ExecutePrompt(prompt, description string) error
+	ExecuteSlashCommandWithExitCode(slashCommand, description string) (int, error)
+}
+
 // SubagentAwareExecutor extends the ClaudeExecutor with subagent capabilities
 type SubagentAwareExecutor struct {
-	*ClaudeExecutor
+	PromptExecutor
 	subagentExecutor *subagents.SubagentExecutor
 	enabled          bool
 }
 
 // NewSubagentAwareExecutor creates a new executor with subagent support
-func NewSubagentAwareExecutor(claudeExecutor *ClaudeExecutor, subagentConfigPath string) (*SubagentAwareExecutor, error) {
+func NewSubagentAwareExecutor(claudeExecutor PromptExecutor, subagentConfigPath string) (*SubagentAwareExecutor, error) {
 	// Initialize subagent manager
 	manager, err := subagents.NewSubagentManager(subagentConfigPath)
 	if err != nil {
 		debug.LogResult("SUBAGENT", "initialization", fmt.Sprintf("Failed to initialize subagent manager: %v", err), false)
 		return &SubagentAwareExecutor{
-			ClaudeExecutor: claudeExecutor,
+			PromptExecutor: claudeExecutor,
 			enabled:       false,
 		}, nil // Graceful degradation - continue without subagents
 	}
@@ -40,7 +47,7 @@ func NewSubagentAwareExecutor(claudeExecutor *ClaudeExecutor, subagentConfigPath
 		fmt.Sprintf("Subagent system initialized with %d subagents", len(manager.ListSubagents())), true)
 
 	return &SubagentAwareExecutor{
-		ClaudeExecutor:   claudeExecutor,
+		PromptExecutor:   claudeExecutor,
 		subagentExecutor: subagentExecutor,
 		enabled:          true,
 	}, nil
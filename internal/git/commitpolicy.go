@@ -0,0 +1,116 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultCommitTypes are the conventional commit types accepted when
+// git.commit.types is not set
+var defaultCommitTypes = []string{"feat", "fix", "docs", "style", "refactor", "test", "chore", "perf", "ci", "build", "revert"}
+
+// conventionalSubject splits a conventional commit subject into its type and
+// optional scope
+var conventionalSubject = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]+)\))?!?: .+`)
+
+// issueReference matches an issue reference footer such as "Refs: #123",
+// "Closes #42" or "Fixes: PROJ-7"
+var issueReference = regexp.MustCompile(`(?mi)^(refs?|closes|fixes|resolves):?\s+(#\d+|[A-Z][A-Z0-9]+-\d+)\s*$`)
+
+// CommitPolicy holds the commit message convention loaded from git.commit in
+// .claude-wm/config.json. Without it, a subject that isn't a conventional
+// commit is only a warning.
+type CommitPolicy struct {
+	Types           []string // Allowed types, defaultCommitTypes when empty
+	Scopes          []string // Allowed scopes, any when empty
+	RequireIssueRef bool     // Require an issue reference footer such as "Refs: #123"
+	Strict          bool     // A subject breaking the convention is an error
+}
+
+// LoadCommitPolicy reads git.commit.types, git.commit.scopes,
+// git.commit.require_issue_ref and git.commit.strict from the project
+// configuration
+func LoadCommitPolicy(projectPath string) CommitPolicy {
+	var policy CommitPolicy
+
+	settings, _ := loadGitSettings(projectPath)["commit"].(map[string]interface{})
+	policy.Types = stringList(settings["types"])
+	policy.Scopes = stringList(settings["scopes"])
+	policy.RequireIssueRef, _ = settings["require_issue_ref"].(bool)
+	policy.Strict, _ = settings["strict"].(bool)
+	return policy
+}
+
+// stringList returns the strings of a JSON array
+func stringList(value interface{}) []string {
+	items, _ := value.([]interface{})
+	var list []string
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// SetCommitPolicy replaces the commit policy loaded from the project
+// configuration
+func (v *Validator) SetCommitPolicy(policy CommitPolicy) {
+	v.commits = policy
+}
+
+// validateCommitConvention checks the subject against the allowed types and
+// scopes, and the body for an issue reference when required. It reports
+// whether the subject is a conventional commit.
+func (v *Validator) validateCommitConvention(subject, message string) bool {
+	policy := v.commits
+	types := policy.Types
+	if len(types) == 0 {
+		types = defaultCommitTypes
+	}
+
+	if policy.RequireIssueRef && !issueReference.MatchString(message) {
+		v.fail(ValidationFailure{Rule: RuleIssueReference,
+			Message: "Commit message must reference an issue in a footer such as \"Refs: #123\" (git.commit.require_issue_ref)"})
+	}
+
+	matches := conventionalSubject.FindStringSubmatch(subject)
+	if matches == nil {
+		v.conventionViolation(fmt.Sprintf("Commit subject should follow <type>(<scope>): <description> with type one of %s",
+			strings.Join(types, ", ")))
+		return false
+	}
+
+	commitType, scope := matches[1], matches[2]
+	if !containsString(types, commitType) {
+		v.conventionViolation(fmt.Sprintf("Commit type %q is not allowed (use one of %s)", commitType, strings.Join(types, ", ")))
+		return false
+	}
+	if len(policy.Scopes) > 0 && scope != "" && !containsString(policy.Scopes, scope) {
+		v.conventionViolation(fmt.Sprintf("Commit scope %q is not allowed (use one of %s)", scope, strings.Join(policy.Scopes, ", ")))
+	}
+	return true
+}
+
+// conventionViolation reports a subject breaking the convention: an error in
+// strict mode, a warning otherwise. Without a configured policy, subjects that
+// aren't conventional commits are accepted silently, as they always were.
+func (v *Validator) conventionViolation(message string) {
+	switch {
+	case v.commits.Strict:
+		v.fail(ValidationFailure{Rule: RuleCommitMessage, Message: message})
+	case len(v.commits.Types) > 0 || len(v.commits.Scopes) > 0:
+		v.warnings = append(v.warnings, message)
+	}
+}
+
+// containsString reports whether list holds s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCommitMessage_DefaultPolicy(t *testing.T) {
+	v := &Validator{}
+	assert.True(t, v.ValidateCommitMessage("Add the login form"))
+	assert.Empty(t, v.warnings)
+
+	// Only the existing capital letter warning, the convention isn't enforced
+	assert.True(t, v.ValidateCommitMessage("wip: try something"))
+	assert.Empty(t, v.errors)
+	assert.Equal(t, []string{"Commit message should start with capital letter"}, v.warnings)
+}
+
+func TestValidateCommitMessage_TypesAndScopes(t *testing.T) {
+	policy := CommitPolicy{Types: []string{"feat", "fix"}, Scopes: []string{"auth", "ui"}}
+
+	v := &Validator{commits: policy}
+	assert.True(t, v.ValidateCommitMessage("feat(auth): add the login form"))
+	assert.Empty(t, v.warnings)
+
+	v = &Validator{commits: policy}
+	v.ValidateCommitMessage("docs: explain the login form")
+	v.ValidateCommitMessage("fix(db): close the connection")
+	assert.Empty(t, v.errors, "violations are warnings unless strict")
+	assert.Contains(t, v.warnings, `Commit type "docs" is not allowed (use one of feat, fix)`)
+	assert.Contains(t, v.warnings, `Commit scope "db" is not allowed (use one of auth, ui)`)
+
+	policy.Strict = true
+	v = &Validator{commits: policy}
+	v.ValidateCommitMessage("Add the login form")
+	require.Len(t, v.failures, 1)
+	assert.Equal(t, RuleCommitMessage, v.failures[0].Rule)
+	assert.Contains(t, v.errors[0], "<type>(<scope>): <description>")
+}
+
+func TestValidateCommitMessage_IssueReference(t *testing.T) {
+	v := &Validator{commits: CommitPolicy{RequireIssueRef: true}}
+	v.ValidateCommitMessage("feat: add the login form")
+	require.Len(t, v.failures, 1)
+	assert.Equal(t, RuleIssueReference, v.failures[0].Rule)
+
+	for _, footer := range []string{"Refs: #123", "Closes #42", "Fixes: PROJ-7"} {
+		v = &Validator{commits: CommitPolicy{RequireIssueRef: true}}
+		v.ValidateCommitMessage("feat: add the login form\n\nBody text.\n\n" + footer)
+		assert.Empty(t, v.errors, footer)
+	}
+}
+
+func TestLoadCommitPolicy(t *testing.T) {
+	t.Setenv("CLAUDE_WM_PROFILE", "")
+	dir := t.TempDir()
+	assert.Equal(t, CommitPolicy{}, LoadCommitPolicy(dir))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude-wm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude-wm", "config.json"), []byte(`{"version": "1.0", "git": {"commit":
+		{"types": ["feat", "fix"], "scopes": ["auth"], "require_issue_ref": true, "strict": true}}}`), 0644))
+	assert.Equal(t, CommitPolicy{Types: []string{"feat", "fix"}, Scopes: []string{"auth"}, RequireIssueRef: true, Strict: true},
+		LoadCommitPolicy(dir))
+}
@@ -0,0 +1,158 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxFileMBEnv overrides git.max_file_mb, the size in MB above which a
+	// staged file is reported as large
+	MaxFileMBEnv = "GIT_VALIDATOR_MAX_FILE_MB"
+
+	// MaxStagedEnv overrides git.max_staged_files, the number of staged files
+	// above which the commit is reported as too large
+	MaxStagedEnv = "GIT_VALIDATOR_MAX_STAGED"
+
+	// DefaultMaxFileMB is the large file warning threshold
+	DefaultMaxFileMB = 10
+
+	// DefaultBlockFileMB is the size above which a staged file blocks the
+	// commit, the largest file GitHub accepts
+	DefaultBlockFileMB = 100
+
+	// DefaultMaxStagedFiles is the staged file count warning threshold
+	DefaultMaxStagedFiles = 50
+)
+
+// SizeLimits holds the thresholds of the staged files check
+type SizeLimits struct {
+	MaxFileMB      float64 // Warn about files larger than this
+	BlockFileMB    float64 // Fail on files larger than this, never when 0
+	MaxStagedFiles int     // Warn when more files are staged, never when 0
+	SuggestLFS     bool    // Suggest the git lfs track command of each large file type
+}
+
+// LoadSizeLimits reads git.max_file_mb, git.block_file_mb,
+// git.max_staged_files and git.suggest_lfs from the project configuration.
+// GIT_VALIDATOR_MAX_FILE_MB and GIT_VALIDATOR_MAX_STAGED take precedence.
+func LoadSizeLimits(projectPath string) SizeLimits {
+	limits := SizeLimits{
+		MaxFileMB:      DefaultMaxFileMB,
+		BlockFileMB:    DefaultBlockFileMB,
+		MaxStagedFiles: DefaultMaxStagedFiles,
+	}
+
+	gitSettings := loadGitSettings(projectPath)
+	if mb, ok := gitSettings["max_file_mb"].(float64); ok && mb > 0 {
+		limits.MaxFileMB = mb
+	}
+	if mb, ok := gitSettings["block_file_mb"].(float64); ok && mb >= 0 {
+		limits.BlockFileMB = mb
+	}
+	if max, ok := gitSettings["max_staged_files"].(float64); ok && max >= 0 {
+		limits.MaxStagedFiles = int(max)
+	}
+	limits.SuggestLFS, _ = gitSettings["suggest_lfs"].(bool)
+
+	if mb, err := strconv.ParseFloat(os.Getenv(MaxFileMBEnv), 64); err == nil && mb > 0 {
+		limits.MaxFileMB = mb
+	}
+	if max, err := strconv.Atoi(os.Getenv(MaxStagedEnv)); err == nil && max >= 0 {
+		limits.MaxStagedFiles = max
+	}
+
+	// The blocking threshold can't be below the warning one
+	if limits.BlockFileMB > 0 && limits.BlockFileMB < limits.MaxFileMB {
+		limits.BlockFileMB = limits.MaxFileMB
+	}
+	return limits
+}
+
+// SetSizeLimits replaces the size limits loaded from the project configuration
+func (v *Validator) SetSizeLimits(limits SizeLimits) {
+	v.limits = limits
+}
+
+// stagedFile is a staged file and its size in bytes
+type stagedFile struct {
+	path string
+	size int64
+}
+
+// validateSizes warns when more than MaxStagedFiles files are staged or files
+// are larger than MaxFileMB, and fails on files larger than BlockFileMB
+func (v *Validator) validateSizes(files []string) bool {
+	limits := v.limits
+	if limits.MaxStagedFiles > 0 && len(files) > limits.MaxStagedFiles {
+		v.warnings = append(v.warnings,
+			fmt.Sprintf("%d files staged (>%d), consider splitting the commit", len(files), limits.MaxStagedFiles))
+	}
+	if limits.MaxFileMB <= 0 {
+		return true
+	}
+
+	var large, blocked []stagedFile
+	for _, filePath := range files {
+		info, err := os.Stat(filepath.Join(v.repoRoot, filePath))
+		if err != nil {
+			continue
+		}
+		size := info.Size()
+		switch {
+		case limits.BlockFileMB > 0 && size > megabytes(limits.BlockFileMB):
+			blocked = append(blocked, stagedFile{filePath, size})
+		case size > megabytes(limits.MaxFileMB):
+			large = append(large, stagedFile{filePath, size})
+		}
+	}
+
+	if len(large) > 0 {
+		v.warnings = append(v.warnings, fmt.Sprintf("Large files detected (>%gMB):", limits.MaxFileMB))
+		for _, file := range large {
+			v.warnings = append(v.warnings, fmt.Sprintf("  - %s (%.1fMB)", file.path, float64(file.size)/(1024*1024)))
+		}
+		v.warnings = append(v.warnings, "Consider Git LFS for large files")
+	}
+	for _, file := range blocked {
+		v.fail(ValidationFailure{Rule: RuleLargeFile, File: file.path,
+			Message: fmt.Sprintf("File too large: %s (%.1fMB > %gMB)", file.path, float64(file.size)/(1024*1024), limits.BlockFileMB)})
+	}
+
+	if limits.SuggestLFS && len(large)+len(blocked) > 0 {
+		for _, command := range lfsTrackCommands(append(large, blocked...)) {
+			v.warnings = append(v.warnings, "Track with Git LFS: "+command)
+		}
+	}
+	return len(blocked) == 0
+}
+
+// lfsTrackCommands returns the git lfs track command of each type of files,
+// or of the file itself when it has no extension
+func lfsTrackCommands(files []stagedFile) []string {
+	seen := make(map[string]bool)
+	var commands []string
+	for _, file := range files {
+		pattern := file.path
+		if ext := path.Ext(file.path); ext != "" {
+			pattern = "*" + strings.ToLower(ext)
+		}
+		if seen[pattern] {
+			continue
+		}
+		seen[pattern] = true
+		commands = append(commands, fmt.Sprintf("git lfs track %q", pattern))
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// megabytes converts mb to bytes
+func megabytes(mb float64) int64 {
+	return int64(mb * 1024 * 1024)
+}
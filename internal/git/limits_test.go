@@ -0,0 +1,69 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSizeLimits(t *testing.T) {
+	t.Setenv("CLAUDE_WM_PROFILE", "")
+	t.Setenv(MaxFileMBEnv, "")
+	t.Setenv(MaxStagedEnv, "")
+	dir := t.TempDir()
+
+	assert.Equal(t, SizeLimits{MaxFileMB: 10, BlockFileMB: 100, MaxStagedFiles: 50}, LoadSizeLimits(dir))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude-wm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude-wm", "config.json"), []byte(`{"version": "1.0", "git":
+		{"max_file_mb": 5, "block_file_mb": 20, "max_staged_files": 30, "suggest_lfs": true}}`), 0644))
+	assert.Equal(t, SizeLimits{MaxFileMB: 5, BlockFileMB: 20, MaxStagedFiles: 30, SuggestLFS: true}, LoadSizeLimits(dir))
+
+	// The environment takes precedence, and the blocking threshold follows
+	t.Setenv(MaxFileMBEnv, "25")
+	t.Setenv(MaxStagedEnv, "0")
+	assert.Equal(t, SizeLimits{MaxFileMB: 25, BlockFileMB: 25, MaxStagedFiles: 0, SuggestLFS: true}, LoadSizeLimits(dir))
+
+	t.Setenv(MaxFileMBEnv, "lots")
+	assert.Equal(t, 5.0, LoadSizeLimits(dir).MaxFileMB)
+}
+
+func TestValidateSizes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]int{"small.txt": 100, "assets/video.MP4": 3000, "model.bin": 3000, "dump": 6000}
+	for name, size := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(strings.Repeat("x", size)), 0644))
+	}
+	staged := []string{"assets/video.MP4", "dump", "model.bin", "small.txt"}
+
+	// 2KB warns, 5KB blocks
+	limits := SizeLimits{MaxFileMB: 2.0 / 1024, BlockFileMB: 5.0 / 1024, MaxStagedFiles: 3}
+	v := &Validator{repoRoot: dir, limits: limits}
+	assert.False(t, v.validateSizes(staged))
+	require.Len(t, v.failures, 1)
+	assert.Equal(t, RuleLargeFile, v.failures[0].Rule)
+	assert.Equal(t, "dump", v.failures[0].File)
+	assert.Contains(t, v.warnings, "4 files staged (>3), consider splitting the commit")
+	assert.Contains(t, v.warnings, "  - assets/video.MP4 (0.0MB)")
+	assert.Contains(t, v.warnings, "  - model.bin (0.0MB)")
+	for _, warning := range v.warnings {
+		assert.NotContains(t, warning, "git lfs track")
+	}
+
+	limits.SuggestLFS = true
+	v = &Validator{repoRoot: dir, limits: limits}
+	v.validateSizes(staged)
+	assert.Contains(t, v.warnings, `Track with Git LFS: git lfs track "*.bin"`)
+	assert.Contains(t, v.warnings, `Track with Git LFS: git lfs track "*.mp4"`)
+	assert.Contains(t, v.warnings, `Track with Git LFS: git lfs track "dump"`)
+
+	// Without a blocking threshold, large files are only warnings
+	v = &Validator{repoRoot: dir, limits: SizeLimits{MaxFileMB: 2.0 / 1024}}
+	assert.True(t, v.validateSizes(staged))
+	assert.Empty(t, v.errors)
+}
@@ -0,0 +1,188 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// zeroSHA is the object name git uses for a ref that does not exist
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// PushRef is one ref update of a push, as passed to the pre-push hook on stdin
+type PushRef struct {
+	LocalRef  string
+	LocalSHA  string
+	RemoteRef string
+	RemoteSHA string
+}
+
+// ParsePrePushInput parses the "<local ref> <local sha> <remote ref> <remote sha>"
+// lines git writes to the stdin of the pre-push hook. Input without lines, as
+// for a push with nothing to update, gives an empty but non-nil list.
+func ParsePrePushInput(r io.Reader) ([]PushRef, error) {
+	refs := []PushRef{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected pre-push input line: %q", scanner.Text())
+		}
+		refs = append(refs, PushRef{LocalRef: fields[0], LocalSHA: fields[1], RemoteRef: fields[2], RemoteSHA: fields[3]})
+	}
+	return refs, scanner.Err()
+}
+
+// SetPushRefs sets the ref updates of the push checked by RunPrePushValidation.
+// nil, for a hook run by hand, checks the current branch instead.
+func (v *Validator) SetPushRefs(refs []PushRef) {
+	v.pushRefs = refs
+}
+
+// CommitsToPush lists the commits the ref updates send to remote: the commits
+// not yet on the remote branch, or on any branch of remote for new branches
+func (v *Validator) CommitsToPush(remote string, refs []PushRef) ([]string, error) {
+	seen := make(map[string]bool)
+	var commits []string
+	for _, ref := range refs {
+		if ref.LocalSHA == zeroSHA {
+			continue // branch deletion
+		}
+
+		args := []string{"rev-list", ref.LocalSHA}
+		if ref.RemoteSHA == zeroSHA {
+			args = append(args, "--not", "--remotes="+remote)
+		} else {
+			args = append(args, "^"+ref.RemoteSHA)
+		}
+
+		cmd := exec.Command("git", args...)
+		cmd.Dir = v.repoRoot
+		output, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list commits of %s: %w", ref.LocalRef, err)
+		}
+		for _, sha := range strings.Fields(string(output)) {
+			if !seen[sha] {
+				seen[sha] = true
+				commits = append(commits, sha)
+			}
+		}
+	}
+	return commits, nil
+}
+
+// RunPrePushValidation validates the commits pushed to remote: their messages
+// must follow the commit conventions without Co-Authored-By lines, they must
+// not add forbidden files, and the push must not target a protected branch
+// unless git.allow_direct_main_commits is true
+func (v *Validator) RunPrePushValidation(remote, url string, commits []string) bool {
+	v.validatePushTargets(remote)
+
+	for _, sha := range commits {
+		commit, err := v.repo.CommitObject(plumbing.NewHash(sha))
+		if err != nil {
+			v.fail(ValidationFailure{Rule: RuleRepository, Commit: sha, Message: fmt.Sprintf("Could not read commit %s: %v", sha, err)})
+			continue
+		}
+		short := commit.Hash.String()[:7]
+
+		errorCount, warningCount, failureCount := len(v.errors), len(v.warnings), len(v.failures)
+		v.ValidateCommitMessage(commit.Message)
+		for i := errorCount; i < len(v.errors); i++ {
+			v.errors[i] = fmt.Sprintf("%s: %s", short, v.errors[i])
+		}
+		for i := failureCount; i < len(v.failures); i++ {
+			v.failures[i].Commit = short
+		}
+		for i := warningCount; i < len(v.warnings); i++ {
+			v.warnings[i] = fmt.Sprintf("%s: %s", short, v.warnings[i])
+		}
+
+		added, err := addedFiles(commit)
+		if err != nil {
+			v.warnings = append(v.warnings, fmt.Sprintf("%s: could not list files: %v", short, err))
+			continue
+		}
+		v.scanned = append(v.scanned, added...)
+		for _, file := range added {
+			if forbidden, _ := v.classifyFile(file); forbidden {
+				v.fail(ValidationFailure{Rule: RuleForbiddenFile, File: file, Commit: short, Pattern: v.forbiddenPattern(file),
+					Message: fmt.Sprintf("%s: adds forbidden file %s", short, file)})
+			}
+		}
+	}
+
+	if len(v.errors) > 0 {
+		v.errors = append(v.errors, fmt.Sprintf("Push to %s (%s) blocked - fix the commits with 'git rebase -i'", remote, url))
+	}
+	return len(v.errors) == 0
+}
+
+// validatePushTargets blocks pushes to protected branches. Without push refs
+// (nil, the hook was run by hand) the current branch is assumed to be pushed
+// under the same name; an empty list from git updates nothing and passes.
+func (v *Validator) validatePushTargets(remote string) {
+	if v.branches.AllowDirectMainCommits {
+		return
+	}
+
+	var targets []string
+	for _, ref := range v.pushRefs {
+		if ref.LocalSHA != zeroSHA {
+			targets = append(targets, plumbing.ReferenceName(ref.RemoteRef).Short())
+		}
+	}
+	if v.pushRefs == nil {
+		if head, err := v.repo.Head(); err == nil && head.Name().IsBranch() {
+			targets = append(targets, head.Name().Short())
+		}
+	}
+
+	for _, target := range targets {
+		if v.branches.IsProtected(target) {
+			v.fail(ValidationFailure{Rule: RuleProtectedBranch, Message: fmt.Sprintf(
+				"Pushing directly to %s/%s is not allowed - open a pull request (or set git.allow_direct_main_commits)", remote, target)})
+		}
+	}
+}
+
+// addedFiles lists the files a commit adds compared to its first parent
+func addedFiles(commit *object.Commit) ([]string, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	parentTree := &object.Tree{}
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, change := range changes {
+		if change.From.Name == "" && change.To.Name != "" {
+			files = append(files, change.To.Name)
+		}
+	}
+	return files, nil
+}
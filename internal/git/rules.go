@@ -0,0 +1,256 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FileRulesFile is the file, relative to the project root, adding patterns to
+// the built-in forbidden and warning files
+const FileRulesFile = ".claude-wm/hooks/git-rules.json"
+
+// AllowEnv lists, comma-separated, glob patterns of files exempted from the
+// forbidden and warning checks, in addition to git.allow_files
+const AllowEnv = "GIT_VALIDATOR_ALLOW"
+
+// Forbidden files patterns specific to claude-wm-cli
+var forbiddenPatterns = []string{
+	`^\.git/`,       // Git internal files
+	`^\.claude-wm/`, // Claude WM internal files
+	`\.log$`,        // Log files
+	`^\.env$`,       // Environment files
+	`\.DS_Store$`,   // macOS system files
+	`.*\.backup$`,   // Backup files
+	`.*\.bak$`,      // Backup files
+	`.*\.tmp$`,      // Temporary files
+	`.*~$`,          // Editor backup files
+}
+
+// Warning files patterns
+var warningPatterns = []string{
+	`config\.(json|yml|yaml)$`,
+	`settings\.(json|yml|yaml)$`,
+	`.*\.sql$`,
+	`debug\.txt$`,
+	`error\.txt$`,
+}
+
+// FileCategory is a category of files the validator reports: files matching
+// one of its patterns, unless they match one of its allow patterns
+type FileCategory struct {
+	Enabled  bool     `json:"enabled"`
+	Patterns []string `json:"patterns"`
+	Allow    []string `json:"allow,omitempty"`
+}
+
+// Matches reports whether path belongs to the category
+func (c FileCategory) Matches(path string) bool {
+	_, ok := c.Match(path)
+	return ok
+}
+
+// Match returns the pattern through which path belongs to the category
+func (c FileCategory) Match(path string) (string, bool) {
+	path = NormalizePath(path)
+	if !c.Enabled || matchesAny(c.Allow, path) {
+		return "", false
+	}
+	for _, pattern := range c.Patterns {
+		if matched, _ := regexp.MatchString(pattern, path); matched {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// FileRules holds the files that block commits and pushes (Forbidden), the
+// files only reported (Warning), and the glob patterns of files exempted from
+// both by policy (AllowGlobs)
+type FileRules struct {
+	Forbidden  FileCategory `json:"forbidden"`
+	Warning    FileCategory `json:"warning"`
+	AllowGlobs []string     `json:"allow_globs,omitempty"`
+}
+
+// AllowedByPolicy returns the allow-list glob matching path, if any. Globs
+// without a slash match the file name at any depth, and ** matches any
+// number of directories, so "*.pem" and "testdata/**/*.pem" both allow
+// testdata/certs/server.pem.
+func (r FileRules) AllowedByPolicy(file string) (string, bool) {
+	file = NormalizePath(file)
+	for _, glob := range r.AllowGlobs {
+		if matchGlob(glob, file) {
+			return glob, true
+		}
+	}
+	return "", false
+}
+
+// NormalizePath returns path with forward slashes and without a leading
+// "./", the form the patterns are written for. Backslashes are converted on
+// every platform: go-git reports Windows paths with them, and paths coming
+// from another machine (hook input, pushed commits) may too.
+func NormalizePath(path string) string {
+	path = strings.ReplaceAll(filepath.ToSlash(path), `\`, "/")
+	for strings.HasPrefix(path, "./") {
+		path = path[2:]
+	}
+	return path
+}
+
+// matchGlob reports whether file matches glob
+func matchGlob(glob, file string) bool {
+	if !strings.Contains(glob, "/") {
+		matched, _ := path.Match(glob, path.Base(file))
+		return matched
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			pattern.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			pattern.WriteString(".*")
+			i++
+		case c == '*':
+			pattern.WriteString("[^/]*")
+		case c == '?':
+			pattern.WriteString("[^/]")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	pattern.WriteString("$")
+
+	matched, _ := regexp.MatchString(pattern.String(), file)
+	return matched
+}
+
+// DefaultFileRules returns the built-in forbidden and warning files
+func DefaultFileRules() FileRules {
+	return FileRules{
+		Forbidden: FileCategory{Enabled: true, Patterns: append([]string(nil), forbiddenPatterns...)},
+		Warning:   FileCategory{Enabled: true, Patterns: append([]string(nil), warningPatterns...)},
+	}
+}
+
+// fileCategoryOverride is a category of .claude-wm/hooks/git-rules.json
+type fileCategoryOverride struct {
+	Enabled  *bool    `json:"enabled"`
+	Patterns []string `json:"patterns"`
+	Allow    []string `json:"allow"`
+}
+
+// LoadFileRules merges .claude-wm/hooks/git-rules.json into the built-in
+// rules: its patterns and allow patterns are added to those of each category,
+// and "enabled": false turns a category off. The allow-list globs come from
+// git.allow_files in the project configuration and GIT_VALIDATOR_ALLOW.
+// Without the file, or with an invalid one, the built-in rules are returned.
+func LoadFileRules(projectRoot string) (FileRules, error) {
+	rules := DefaultFileRules()
+	rules.AllowGlobs = loadAllowGlobs(projectRoot)
+
+	data, err := os.ReadFile(filepath.Join(projectRoot, FileRulesFile))
+	if os.IsNotExist(err) {
+		return rules, nil
+	}
+	invalid := func(format string, args ...interface{}) (FileRules, error) {
+		defaults := DefaultFileRules()
+		defaults.AllowGlobs = rules.AllowGlobs
+		return defaults, fmt.Errorf(format, args...)
+	}
+	if err != nil {
+		return invalid("failed to read %s: %w", FileRulesFile, err)
+	}
+
+	var overrides map[string]fileCategoryOverride
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return invalid("invalid %s: %w", FileRulesFile, err)
+	}
+
+	for name, override := range overrides {
+		var category *FileCategory
+		switch name {
+		case "forbidden":
+			category = &rules.Forbidden
+		case "warning":
+			category = &rules.Warning
+		default:
+			return invalid("invalid %s: unknown category %q (use forbidden or warning)", FileRulesFile, name)
+		}
+
+		for _, pattern := range append(append([]string(nil), override.Patterns...), override.Allow...) {
+			if _, err := regexp.Compile(pattern); err != nil {
+				return invalid("invalid %s: %s pattern %q: %w", FileRulesFile, name, pattern, err)
+			}
+		}
+		if override.Enabled != nil {
+			category.Enabled = *override.Enabled
+		}
+		category.Patterns = append(category.Patterns, override.Patterns...)
+		category.Allow = append(category.Allow, override.Allow...)
+	}
+	return rules, nil
+}
+
+// loadAllowGlobs returns the globs of git.allow_files and GIT_VALIDATOR_ALLOW
+func loadAllowGlobs(projectRoot string) []string {
+	var globs []string
+	if files, ok := loadGitSettings(projectRoot)["allow_files"].([]interface{}); ok {
+		for _, file := range files {
+			if glob, ok := file.(string); ok && glob != "" {
+				globs = append(globs, glob)
+			}
+		}
+	}
+	for _, glob := range strings.Split(os.Getenv(AllowEnv), ",") {
+		if glob = strings.TrimSpace(glob); glob != "" {
+			globs = append(globs, glob)
+		}
+	}
+	return globs
+}
+
+// classifyFile reports whether file is forbidden or a warning file. Files
+// exempted by the allow-list are neither, and are recorded as allowed by
+// policy.
+func (v *Validator) classifyFile(file string) (forbidden, warning bool) {
+	rules := v.fileRules()
+	forbidden, warning = rules.Forbidden.Matches(file), rules.Warning.Matches(file)
+	if !forbidden && !warning {
+		return false, false
+	}
+	if glob, ok := rules.AllowedByPolicy(file); ok {
+		v.allowed = append(v.allowed, fmt.Sprintf("%s (%s)", file, glob))
+		return false, false
+	}
+	return forbidden, warning
+}
+
+// forbiddenPattern returns the forbidden pattern file matches
+func (v *Validator) forbiddenPattern(file string) string {
+	pattern, _ := v.fileRules().Forbidden.Match(file)
+	return pattern
+}
+
+// SetFileRules replaces the forbidden and warning files loaded from the project
+func (v *Validator) SetFileRules(rules FileRules) {
+	v.files = &rules
+}
+
+// fileRules returns the rules of the validator, the built-in ones when none
+// were loaded
+func (v *Validator) fileRules() FileRules {
+	if v.files == nil {
+		return DefaultFileRules()
+	}
+	return *v.files
+}
@@ -0,0 +1,187 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFileRules(t *testing.T, dir, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude-wm", "hooks"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FileRulesFile), []byte(content), 0644))
+}
+
+func TestLoadFileRules_Defaults(t *testing.T) {
+	rules, err := LoadFileRules(t.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultFileRules(), rules)
+	assert.True(t, rules.Forbidden.Matches("debug.log"))
+	assert.True(t, rules.Warning.Matches("schema.sql"))
+	assert.False(t, rules.Forbidden.Matches("main.go"))
+}
+
+func TestLoadFileRules_Merge(t *testing.T) {
+	dir := t.TempDir()
+	writeFileRules(t, dir, `{
+		"forbidden": {"patterns": ["^secrets/", "\\.pem$"], "allow": ["^fixtures/.*\\.log$"]},
+		"warning": {"enabled": false}
+	}`)
+
+	rules, err := LoadFileRules(dir)
+	require.NoError(t, err)
+
+	assert.True(t, rules.Forbidden.Matches("secrets/prod.yaml"))
+	assert.True(t, rules.Forbidden.Matches("certs/server.pem"))
+	assert.True(t, rules.Forbidden.Matches("debug.log"), "built-in patterns are kept")
+	assert.False(t, rules.Forbidden.Matches("fixtures/sample.log"), "allow patterns override")
+	assert.False(t, rules.Warning.Matches("schema.sql"), "disabled category")
+}
+
+func TestLoadFileRules_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFileRules(t, dir, `{"forbidden": {"patterns": ["("]}}`)
+	rules, err := LoadFileRules(dir)
+	assert.ErrorContains(t, err, `forbidden pattern "("`)
+	assert.Equal(t, DefaultFileRules(), rules)
+
+	writeFileRules(t, dir, `{"secrets": {"enabled": false}}`)
+	_, err = LoadFileRules(dir)
+	assert.ErrorContains(t, err, `unknown category "secrets"`)
+
+	writeFileRules(t, dir, `not json`)
+	_, err = LoadFileRules(dir)
+	assert.ErrorContains(t, err, "invalid "+FileRulesFile)
+}
+
+func TestValidateStagedFilesUsesFileRules(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	workTree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	for _, name := range []string{"server.pem", "debug.log", "schema.sql"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("content\n"), 0644))
+		_, err = workTree.Add(name)
+		require.NoError(t, err)
+	}
+
+	v := &Validator{repo: repo, workTree: workTree, repoRoot: dir, currentDir: dir, noSecrets: true, allowTODOs: true}
+	v.SetFileRules(FileRules{
+		Forbidden: FileCategory{Enabled: true, Patterns: []string{`\.pem$`, `\.log$`}, Allow: []string{`^debug\.log$`}},
+		Warning:   FileCategory{Enabled: false, Patterns: []string{`\.sql$`}},
+	})
+
+	assert.False(t, v.ValidateStagedFiles())
+	assert.Equal(t, []string{"Forbidden files detected in staging:", "  - server.pem", "Use 'git reset HEAD <file>' to unstage"}, v.errors)
+	assert.Empty(t, v.warnings)
+
+	result := v.GetResult()
+	assert.Equal(t, []string{"debug.log", "schema.sql", "server.pem"}, result.ScannedFiles)
+	assert.Equal(t, []ValidationFailure{{Rule: RuleForbiddenFile, File: "server.pem", Pattern: `\.pem$`, Message: "Forbidden file staged: server.pem"}},
+		result.Failures)
+}
+
+func TestAllowedByPolicy(t *testing.T) {
+	rules := FileRules{AllowGlobs: []string{"*.pem", "testdata/**/config.json", "fixtures/*.log"}}
+
+	for file, glob := range map[string]string{
+		"server.pem":                "*.pem",
+		"testdata/certs/server.pem": "*.pem",
+		"testdata/config.json":      "testdata/**/config.json",
+		"testdata/a/b/config.json":  "testdata/**/config.json",
+		"fixtures/app.log":          "fixtures/*.log",
+	} {
+		matched, ok := rules.AllowedByPolicy(file)
+		assert.True(t, ok, file)
+		assert.Equal(t, glob, matched, file)
+	}
+	for _, file := range []string{"config.json", "fixtures/nested/app.log", "src/testdata/config.json"} {
+		_, ok := rules.AllowedByPolicy(file)
+		assert.False(t, ok, file)
+	}
+}
+
+func TestLoadFileRules_AllowGlobs(t *testing.T) {
+	t.Setenv("CLAUDE_WM_PROFILE", "")
+	t.Setenv(AllowEnv, "*.crt, testdata/**")
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude-wm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude-wm", "config.json"),
+		[]byte(`{"version": "1.0", "git": {"allow_files": ["*.pem"]}}`), 0644))
+
+	rules, err := LoadFileRules(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*.pem", "*.crt", "testdata/**"}, rules.AllowGlobs)
+
+	writeFileRules(t, dir, `not json`)
+	rules, err = LoadFileRules(dir)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"*.pem", "*.crt", "testdata/**"}, rules.AllowGlobs, "kept with an invalid rules file")
+}
+
+func TestValidateStagedFilesAllowedByPolicy(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	workTree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "testdata"), 0755))
+	for _, name := range []string{"testdata/server.pem", "config.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("content\n"), 0644))
+		_, err = workTree.Add(name)
+		require.NoError(t, err)
+	}
+
+	rules := DefaultFileRules()
+	rules.Forbidden.Patterns = append(rules.Forbidden.Patterns, `\.pem$`)
+	rules.AllowGlobs = []string{"testdata/**/*.pem", "config.json"}
+	v := &Validator{repo: repo, workTree: workTree, repoRoot: dir, currentDir: dir, noSecrets: true, allowTODOs: true}
+	v.SetFileRules(rules)
+
+	assert.True(t, v.ValidateStagedFiles())
+	assert.Empty(t, v.warnings)
+	assert.ElementsMatch(t, []string{"testdata/server.pem (testdata/**/*.pem)", "config.json (config.json)"}, v.GetResult().Allowed)
+}
+
+func TestNormalizePath(t *testing.T) {
+	assert.Equal(t, ".claude-wm/state/nav.json", NormalizePath(`.claude-wm\state\nav.json`))
+	assert.Equal(t, "src/app/config.json", NormalizePath(`.\src\app\config.json`))
+	assert.Equal(t, "cmd/main.go", NormalizePath("./cmd/main.go"))
+	assert.Equal(t, ".env", NormalizePath(".env"))
+}
+
+func TestFileRules_WindowsPaths(t *testing.T) {
+	rules := DefaultFileRules()
+	rules.Forbidden.Patterns = append(rules.Forbidden.Patterns, `(^|/)node_modules/`)
+	rules.Forbidden.Allow = []string{`^fixtures/.*\.log$`}
+	rules.AllowGlobs = []string{"testdata/**/*.bak"}
+
+	// Sensitive files are caught whatever the separator
+	for _, file := range []string{`.git\config`, `.claude-wm\state\nav.json`, `web\node_modules\left-pad\index.js`, `.\.env`} {
+		assert.True(t, rules.Forbidden.Matches(file), file)
+	}
+
+	// Legit files are not flagged
+	for _, file := range []string{`docs\git\config.md`, `src\claude-wm\main.go`} {
+		assert.False(t, rules.Forbidden.Matches(file), file)
+	}
+	assert.False(t, rules.Forbidden.Matches(`fixtures\run\debug.log`), "allow patterns apply too")
+	_, allowed := rules.AllowedByPolicy(`testdata\old\dump.bak`)
+	assert.True(t, allowed)
+
+	v := &Validator{}
+	v.SetFileRules(rules)
+	forbidden, _ := v.classifyFile(`.claude-wm\tickets\current.json`)
+	assert.True(t, forbidden)
+	assert.Equal(t, `^\.claude-wm/`, v.forbiddenPattern(`.claude-wm\tickets\current.json`))
+	_, warning := v.classifyFile(`deploy\settings.yaml`)
+	assert.True(t, warning)
+}
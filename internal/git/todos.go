@@ -0,0 +1,138 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxNewTODOs is the number of new TODO comments a commit may add
+// before they become errors, used when git.max_new_todos is not set
+const DefaultMaxNewTODOs = 3
+
+// TODOComment is a TODO, FIXME or HACK comment added by the staged changes
+type TODOComment struct {
+	File string
+	Line int
+	Text string
+}
+
+// todoPattern matches the comments that mark unfinished work
+var todoPattern = regexp.MustCompile(`^(//|/\*)\s*(TODO|FIXME|HACK)\b`)
+
+// hunkHeaderPattern extracts the new-file start line of a unified diff hunk
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// LoadMaxNewTODOs reads git.max_new_todos from the project configuration,
+// falling back to DefaultMaxNewTODOs
+func LoadMaxNewTODOs(projectPath string) int {
+	if max, ok := loadGitSettings(projectPath)["max_new_todos"].(float64); ok && max >= 0 {
+		return int(max)
+	}
+	return DefaultMaxNewTODOs
+}
+
+// SetAllowTODOs skips the scan for new TODO comments
+func (v *Validator) SetAllowTODOs(allow bool) {
+	v.allowTODOs = allow
+}
+
+// SetMaxNewTODOs sets the number of new TODO comments allowed before they
+// become errors
+func (v *Validator) SetMaxNewTODOs(max int) {
+	v.maxTODOs = max
+}
+
+// ScanForTODOComments warns about the TODO, FIXME and HACK comments added to
+// the staged Go files, and fails when there are more than git.max_new_todos
+func (v *Validator) ScanForTODOComments(files []string) bool {
+	var goFiles []string
+	for _, file := range files {
+		if strings.HasSuffix(file, ".go") {
+			goFiles = append(goFiles, file)
+		}
+	}
+	if len(goFiles) == 0 {
+		return true
+	}
+
+	args := append([]string{"diff", "--cached", "-U0", "--no-color", "--no-ext-diff", "--"}, goFiles...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = v.repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		v.warnings = append(v.warnings, fmt.Sprintf("Could not scan staged changes for TODO comments: %v", err))
+		return true
+	}
+
+	todos := parseAddedTODOs(string(output))
+	if len(todos) == 0 {
+		return true
+	}
+
+	v.warnings = append(v.warnings, fmt.Sprintf("New TODO comments (%d):", len(todos)))
+	for _, todo := range todos {
+		v.warnings = append(v.warnings, fmt.Sprintf("  - %s:%d: %s", todo.File, todo.Line, todo.Text))
+	}
+
+	if len(todos) > v.maxTODOs {
+		v.fail(ValidationFailure{Rule: RuleMaxTODOs, Message: fmt.Sprintf(
+			"%d new TODO comments exceed git.max_new_todos (%d) - resolve them or commit with --allow-todos",
+			len(todos), v.maxTODOs)})
+		return false
+	}
+	return true
+}
+
+// parseAddedTODOs returns the TODO comments on the lines added by a
+// `git diff -U0` output
+func parseAddedTODOs(diff string) []TODOComment {
+	var todos []TODOComment
+	var file string
+	line := 0
+
+	lines := bufio.NewScanner(strings.NewReader(diff))
+	lines.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for lines.Scan() {
+		text := lines.Text()
+		switch {
+		case strings.HasPrefix(text, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(text, "+++ "), "b/")
+		case strings.HasPrefix(text, "@@"):
+			if matches := hunkHeaderPattern.FindStringSubmatch(text); matches != nil {
+				line, _ = strconv.Atoi(matches[1])
+			}
+		case strings.HasPrefix(text, "+"):
+			if comment, ok := findTODOComment(text[1:]); ok && file != "/dev/null" {
+				todos = append(todos, TODOComment{File: file, Line: line, Text: comment})
+			}
+			line++
+		}
+	}
+	return todos
+}
+
+// findTODOComment returns the first TODO comment of a line of Go source.
+// go/scanner tells comments apart from "// TODO" inside string literals.
+func findTODOComment(src string) (string, bool) {
+	fileSet := token.NewFileSet()
+	file := fileSet.AddFile("", fileSet.Base(), len(src))
+
+	var s scanner.Scanner
+	// Errors are expected: a single line is rarely a complete Go file
+	s.Init(file, []byte(src), func(token.Position, string) {}, scanner.ScanComments)
+	for {
+		_, tok, literal := s.Scan()
+		if tok == token.EOF {
+			return "", false
+		}
+		if tok == token.COMMENT && todoPattern.MatchString(literal) {
+			return strings.TrimSpace(literal), true
+		}
+	}
+}
@@ -3,21 +3,65 @@ package git
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"claude-wm-cli/internal/config"
+	"claude-wm-cli/internal/secrets"
+
 	"github.com/go-git/go-git/v5"
 )
 
 // ValidationResult represents the output of git validation
 type ValidationResult struct {
-	Success  bool     `json:"success"`
-	Errors   []string `json:"errors"`
-	Warnings []string `json:"warnings"`
-	Duration int64    `json:"duration_ms"`
+	Success      bool                `json:"success"`
+	Errors       []string            `json:"errors"`
+	Warnings     []string            `json:"warnings"`
+	Failures     []ValidationFailure `json:"failures,omitempty"` // The rule behind each error
+	ScannedFiles []string            `json:"scanned_files"`
+	Allowed      []string            `json:"allowed,omitempty"` // Files allowed by policy
+	Duration     int64               `json:"duration_ms"`
+}
+
+// ValidationFailure explains an error: the rule that failed and, when it
+// applies to a file or commit, which one and the pattern it matched
+type ValidationFailure struct {
+	Rule    string `json:"rule"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Commit  string `json:"commit,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+	Message string `json:"message"`
+}
+
+// Rules reported in ValidationFailure
+const (
+	RuleRepository      = "repository"
+	RuleForbiddenFile   = "forbidden_file"
+	RuleSecret          = "secret"
+	RuleInvalidJSON     = "invalid_json"
+	RuleCoAuthored      = "co_authored"
+	RuleCommitMessage   = "commit_message"
+	RuleBranchName      = "branch_name"
+	RuleProtectedBranch = "protected_branch"
+	RuleMaxTODOs        = "max_new_todos"
+	RuleIssueReference  = "issue_reference"
+	RuleLargeFile       = "large_file"
+)
+
+// OutputEnv set to "json" makes the git validation hook write its
+// ValidationResult as JSON to stdout, like --json
+const OutputEnv = "GIT_VALIDATOR_OUTPUT"
+
+// JSONOutputFromEnv reports whether GIT_VALIDATOR_OUTPUT asks for JSON output
+func JSONOutputFromEnv() bool {
+	return strings.EqualFold(os.Getenv(OutputEnv), "json")
 }
 
 // Validator provides Git validation functionality for claude-wm-cli
@@ -29,30 +73,73 @@ type Validator struct {
 	errors     []string
 	warnings   []string
 	startTime  time.Time
+	branches   BranchPolicy
+	commits    CommitPolicy
+	dryRun     bool
+	noSecrets  bool
+	allowTODOs bool
+	maxTODOs   int
+	limits     SizeLimits
+	pushRefs   []PushRef
+	files      *FileRules // Forbidden and warning files, DefaultFileRules when nil
+	allowed    []string   // Files exempted by the allow-list
+	scanned    []string   // Files checked against the rules
+	failures   []ValidationFailure
 }
 
-// Forbidden files patterns specific to claude-wm-cli
-var forbiddenPatterns = []string{
-	`^\.git/`,           // Git internal files
-	`^\.claude-wm/`,     // Claude WM internal files
-	`\.log$`,            // Log files
-	`^\.env$`,           // Environment files
-	`\.DS_Store$`,       // macOS system files
-	`.*\.backup$`,       // Backup files
-	`.*\.bak$`,          // Backup files
-	`.*\.tmp$`,          // Temporary files
-	`.*~$`,              // Editor backup files
+// DefaultBranchPattern is the branch naming convention used when
+// git.branch_pattern is not set in .claude-wm/config.json
+const DefaultBranchPattern = `^(main|develop|feature/.+|fix/.+|hotfix/.+|release/.+)$`
+
+// BranchNaming is how branch names not matching the pattern are reported
+type BranchNaming string
+
+const (
+	BranchNamingError   BranchNaming = "error"   // Block the operation (default)
+	BranchNamingWarning BranchNaming = "warning" // Report the name and proceed
+	BranchNamingOff     BranchNaming = "off"     // Don't check branch names
+)
+
+// BranchPolicy holds the branch naming rules loaded from the git section of
+// .claude-wm/config.json
+type BranchPolicy struct {
+	Pattern                string
+	Naming                 BranchNaming // defaults to BranchNamingError when empty
+	AllowDirectMainCommits bool
+	ProtectedBranches      []string // defaults to protectedBranches when nil
+}
+
+// protectedBranches must not receive commits or pushes directly unless the
+// policy allows it, used when git.protected_branches is not set
+var protectedBranches = []string{"main", "develop"}
+
+// IsProtected reports whether branch is one of the protected branches
+func (p BranchPolicy) IsProtected(branch string) bool {
+	protected := p.ProtectedBranches
+	if protected == nil {
+		protected = protectedBranches
+	}
+	for _, name := range protected {
+		if branch == name {
+			return true
+		}
+	}
+	return false
 }
 
-// Warning files patterns
-var warningPatterns = []string{
-	`config\.(json|yml|yaml)$`,
-	`settings\.(json|yml|yaml)$`,
-	`.*\.sql$`,
-	`debug\.txt$`,
-	`error\.txt$`,
+// newBranchPattern matches the branch created by `git checkout -b` or `git switch -c`
+var newBranchPattern = regexp.MustCompile(`git\s+(?:checkout\s+-b|switch\s+(?:-c|--create))\s+([^\s;&|]+)`)
+
+// Files whose content is not scanned for secrets (checksums look like keys)
+var secretScanSkipPatterns = []string{
+	`(^|/)go\.sum$`,
+	`(^|/)package-lock\.json$`,
+	`\.lock$`,
 }
 
+// maxSecretScanSize is the number of bytes of each staged file scanned for secrets
+const maxSecretScanSize = 1024 * 1024
+
 // NewValidator creates a new Git validator instance
 func NewValidator() (*Validator, error) {
 	v := &Validator{
@@ -94,9 +181,76 @@ func NewValidator() (*Validator, error) {
 		return nil, fmt.Errorf("failed to get worktree: %v", err)
 	}
 
+	v.branches = LoadBranchPolicy(v.repoRoot)
+	v.commits = LoadCommitPolicy(v.repoRoot)
+	v.maxTODOs = LoadMaxNewTODOs(v.repoRoot)
+	v.limits = LoadSizeLimits(v.repoRoot)
+
+	rules, err := LoadFileRules(v.repoRoot)
+	if err != nil {
+		v.warnings = append(v.warnings, fmt.Sprintf("Ignoring file rules: %v", err))
+	}
+	v.files = &rules
+
 	return v, nil
 }
 
+// LoadBranchPolicy reads git.branch_pattern, git.branch_naming,
+// git.allow_direct_main_commits and git.protected_branches from the project
+// configuration, falling back to DefaultBranchPattern and main/develop
+func LoadBranchPolicy(projectPath string) BranchPolicy {
+	policy := BranchPolicy{Pattern: DefaultBranchPattern}
+
+	gitSettings := loadGitSettings(projectPath)
+	if pattern, ok := gitSettings["branch_pattern"].(string); ok && pattern != "" {
+		policy.Pattern = pattern
+	}
+	if naming, ok := gitSettings["branch_naming"].(string); ok {
+		policy.Naming = BranchNaming(naming)
+	}
+	if allow, ok := gitSettings["allow_direct_main_commits"].(bool); ok {
+		policy.AllowDirectMainCommits = allow
+	}
+	if branches, ok := gitSettings["protected_branches"].([]interface{}); ok {
+		policy.ProtectedBranches = []string{}
+		for _, branch := range branches {
+			if name, ok := branch.(string); ok {
+				policy.ProtectedBranches = append(policy.ProtectedBranches, name)
+			}
+		}
+	}
+	return policy
+}
+
+// loadGitSettings returns the git section of the project configuration, or an
+// empty map when it is missing or unreadable
+func loadGitSettings(projectPath string) map[string]interface{} {
+	settings, err := config.NewManager(projectPath).LoadConfig()
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	gitSettings, ok := settings["git"].(map[string]interface{})
+	if !ok {
+		return map[string]interface{}{}
+	}
+	return gitSettings
+}
+
+// SetBranchPolicy replaces the branch policy loaded from the project configuration
+func (v *Validator) SetBranchPolicy(policy BranchPolicy) {
+	v.branches = policy
+}
+
+// SetDryRun skips the branch checks, which only guard real git operations
+func (v *Validator) SetDryRun(dryRun bool) {
+	v.dryRun = dryRun
+}
+
+// SetSecretScan enables or disables the scan of staged file contents for secrets
+func (v *Validator) SetSecretScan(enabled bool) {
+	v.noSecrets = !enabled
+}
+
 // ValidateRepositoryContext validates git repository context and status
 func (v *Validator) ValidateRepositoryContext() bool {
 	// Check if we're at repository root
@@ -108,7 +262,7 @@ func (v *Validator) ValidateRepositoryContext() bool {
 	// Check repository health
 	_, err := v.repo.Head()
 	if err != nil {
-		v.errors = append(v.errors, fmt.Sprintf("Repository head error: %v", err))
+		v.fail(ValidationFailure{Rule: RuleRepository, Message: fmt.Sprintf("Repository head error: %v", err)})
 		return false
 	}
 
@@ -139,29 +293,31 @@ func (v *Validator) ValidateRepositoryContext() bool {
 func (v *Validator) ValidateStagedFiles() bool {
 	status, err := v.workTree.Status()
 	if err != nil {
-		v.errors = append(v.errors, fmt.Sprintf("Failed to get git status: %v", err))
+		v.fail(ValidationFailure{Rule: RuleRepository, Message: fmt.Sprintf("Failed to get git status: %v", err)})
 		return false
 	}
 
 	var stagedFiles []string
 	for file, fileStatus := range status {
 		if fileStatus.Staging != git.Unmodified {
-			stagedFiles = append(stagedFiles, file)
+			stagedFiles = append(stagedFiles, NormalizePath(file))
 		}
 	}
 
 	if len(stagedFiles) == 0 {
 		return true
 	}
+	sort.Strings(stagedFiles)
+	v.scanned = append(v.scanned, stagedFiles...)
 
-	// Check for forbidden files
-	var forbiddenFiles []string
+	// Check for forbidden and warning files
+	var forbiddenFiles, warningFiles []string
 	for _, filePath := range stagedFiles {
-		for _, pattern := range forbiddenPatterns {
-			if matched, _ := regexp.MatchString(pattern, filePath); matched {
-				forbiddenFiles = append(forbiddenFiles, filePath)
-				break
-			}
+		forbidden, warning := v.classifyFile(filePath)
+		if forbidden {
+			forbiddenFiles = append(forbiddenFiles, filePath)
+		} else if warning {
+			warningFiles = append(warningFiles, filePath)
 		}
 	}
 
@@ -169,22 +325,13 @@ func (v *Validator) ValidateStagedFiles() bool {
 		v.errors = append(v.errors, "Forbidden files detected in staging:")
 		for _, file := range forbiddenFiles {
 			v.errors = append(v.errors, fmt.Sprintf("  - %s", file))
+			v.explain(ValidationFailure{Rule: RuleForbiddenFile, File: file, Pattern: v.forbiddenPattern(file),
+				Message: "Forbidden file staged: " + file})
 		}
 		v.errors = append(v.errors, "Use 'git reset HEAD <file>' to unstage")
 		return false
 	}
 
-	// Check for warning files
-	var warningFiles []string
-	for _, filePath := range stagedFiles {
-		for _, pattern := range warningPatterns {
-			if matched, _ := regexp.MatchString(pattern, filePath); matched {
-				warningFiles = append(warningFiles, filePath)
-				break
-			}
-		}
-	}
-
 	if len(warningFiles) > 0 {
 		v.warnings = append(v.warnings, "Warning files detected:")
 		for _, file := range warningFiles {
@@ -192,37 +339,100 @@ func (v *Validator) ValidateStagedFiles() bool {
 		}
 	}
 
-	// Check file sizes
-	var largeFiles []struct {
-		path string
-		size int64
+	// Check the number and sizes of the staged files
+	sizesValid := v.validateSizes(stagedFiles)
+
+	// Check claude-wm-cli specific JSON files
+	v.validateClaudeWMFiles(stagedFiles)
+
+	valid := sizesValid
+	if !v.noSecrets {
+		valid = v.ScanFileContents(stagedFiles) && valid
 	}
-	for _, filePath := range stagedFiles {
-		fullPath := filepath.Join(v.repoRoot, filePath)
-		if info, err := os.Stat(fullPath); err == nil {
-			size := info.Size()
-			if size > 10*1024*1024 { // 10MB
-				largeFiles = append(largeFiles, struct {
-					path string
-					size int64
-				}{filePath, size})
+	if !v.allowTODOs {
+		valid = v.ScanForTODOComments(stagedFiles) && valid
+	}
+
+	return valid
+}
+
+// ScanFileContents scans the first 1MB of the staged version of each file for
+// API keys and high-entropy strings: what gets committed, not the working tree.
+// Findings listed in .claude-wm/secret-ignore.json are ignored.
+func (v *Validator) ScanFileContents(files []string) bool {
+	allowlist, err := secrets.LoadAllowlist(v.repoRoot)
+	if err != nil {
+		v.warnings = append(v.warnings, fmt.Sprintf("Ignoring secret allowlist: %v", err))
+		allowlist = &secrets.Allowlist{}
+	}
+
+	var found []string
+	for _, file := range files {
+		if matchesAny(secretScanSkipPatterns, file) {
+			continue
+		}
+
+		content, err := readStagedHead(v.repoRoot, file, maxSecretScanSize)
+		if err != nil {
+			// Deleted files have no staged content to scan
+			continue
+		}
+
+		for _, finding := range secrets.ScanContent(content) {
+			if allowlist.Allows(file, finding.Line) {
+				continue
 			}
+			found = append(found, fmt.Sprintf("  - %s:%d: %s (%s)", file, finding.Line, finding.Kind, finding.Redacted()))
+			v.explain(ValidationFailure{Rule: RuleSecret, File: file, Line: finding.Line, Pattern: finding.Kind,
+				Message: fmt.Sprintf("Possible %s (%s)", finding.Kind, finding.Redacted())})
 		}
 	}
 
-	if len(largeFiles) > 0 {
-		v.warnings = append(v.warnings, "Large files detected (>10MB):")
-		for _, file := range largeFiles {
-			v.warnings = append(v.warnings,
-				fmt.Sprintf("  - %s (%.1fMB)", file.path, float64(file.size)/(1024*1024)))
+	if len(found) > 0 {
+		v.errors = append(v.errors, "Possible secrets detected in staged files:")
+		v.errors = append(v.errors, found...)
+		v.errors = append(v.errors, fmt.Sprintf("Remove them, or list false positives as {\"file\", \"line\"} entries in %s", secrets.AllowlistFile))
+		return false
+	}
+	return true
+}
+
+// matchesAny reports whether path matches one of the patterns
+func matchesAny(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := regexp.MatchString(pattern, path); matched {
+			return true
 		}
-		v.warnings = append(v.warnings, "Consider Git LFS for large files")
 	}
+	return false
+}
 
-	// Check claude-wm-cli specific JSON files
-	v.validateClaudeWMFiles(stagedFiles)
+// readStagedHead reads at most limit bytes from the start of the staged blob
+// of a file, i.e. `git cat-file blob :<file>`
+func readStagedHead(repoRoot, file string, limit int64) ([]byte, error) {
+	cmd := exec.Command("git", "cat-file", "blob", ":"+filepath.ToSlash(file))
+	cmd.Dir = repoRoot
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
 
-	return true
+	content, readErr := io.ReadAll(io.LimitReader(stdout, limit))
+	truncated := int64(len(content)) >= limit
+	if truncated {
+		// The rest of the blob is not needed
+		cmd.Process.Kill()
+	}
+	if err := cmd.Wait(); err != nil && !truncated {
+		return nil, err
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	return content, nil
 }
 
 // validateClaudeWMFiles validates claude-wm-cli specific JSON files
@@ -251,7 +461,7 @@ func (v *Validator) validateJSONStructure(file string) {
 
 	var data interface{}
 	if err := json.Unmarshal(content, &data); err != nil {
-		v.errors = append(v.errors, fmt.Sprintf("Invalid JSON in %s: %v", file, err))
+		v.fail(ValidationFailure{Rule: RuleInvalidJSON, File: file, Message: fmt.Sprintf("Invalid JSON in %s: %v", file, err)})
 	}
 }
 
@@ -263,17 +473,17 @@ func (v *Validator) ValidateCommitMessage(message string) bool {
 
 	// Block Co-authored commits and Claude signatures
 	if strings.Contains(message, "Co-Authored-By") || strings.Contains(strings.ToLower(message), "co-authored-by") {
-		v.errors = append(v.errors, "Co-authored commits are not allowed per project rules")
+		v.fail(ValidationFailure{Rule: RuleCoAuthored, Message: "Co-authored commits are not allowed per project rules"})
 	}
 
 	if strings.Contains(message, "🤖 Generated with [Claude Code]") || strings.Contains(message, "🤖 Generated with Claude") {
-		v.errors = append(v.errors, "Remove Claude signature from commit messages")
+		v.fail(ValidationFailure{Rule: RuleCommitMessage, Message: "Remove Claude signature from commit messages"})
 	}
 
 	// Extract main message
 	lines := strings.Split(strings.TrimSpace(message), "\n")
 	if len(lines) == 0 {
-		v.errors = append(v.errors, "Empty commit message")
+		v.fail(ValidationFailure{Rule: RuleCommitMessage, Message: "Empty commit message"})
 		return false
 	}
 
@@ -284,12 +494,11 @@ func (v *Validator) ValidateCommitMessage(message string) bool {
 		v.warnings = append(v.warnings,
 			fmt.Sprintf("First line should be ≤72 characters (current: %d)", len(mainMessage)))
 	} else if len(mainMessage) < 10 {
-		v.errors = append(v.errors, "Commit message too short (minimum 10 characters)")
+		v.fail(ValidationFailure{Rule: RuleCommitMessage, Message: "Commit message too short (minimum 10 characters)"})
 	}
 
 	// Check conventional commit format
-	conventionalPattern := `^(feat|fix|docs|style|refactor|test|chore|perf|ci|build|revert)(\(.+\))?: .+`
-	if matched, _ := regexp.MatchString(conventionalPattern, mainMessage); matched {
+	if v.validateCommitConvention(mainMessage, message) {
 		lowercasePattern := `^[a-z]+(\(.+\))?: [a-z]`
 		if matched, _ := regexp.MatchString(lowercasePattern, mainMessage); !matched {
 			v.warnings = append(v.warnings, "Conventional commits should start with lowercase after type")
@@ -303,6 +512,80 @@ func (v *Validator) ValidateCommitMessage(message string) bool {
 	return true
 }
 
+// ValidateBranchName validates a branch name against the configured pattern.
+// Protected branches are always accepted. A mismatch is an error, or only a
+// warning when git.branch_naming is "warning"; "off" disables the check.
+func (v *Validator) ValidateBranchName(branch string) bool {
+	if v.branches.Naming == BranchNamingOff || v.branches.IsProtected(branch) {
+		return true
+	}
+
+	pattern := v.branches.Pattern
+	if pattern == "" {
+		pattern = DefaultBranchPattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		v.fail(ValidationFailure{Rule: RuleBranchName, Pattern: pattern, Message: fmt.Sprintf("Invalid git.branch_pattern %q: %v", pattern, err)})
+		return false
+	}
+
+	if !re.MatchString(branch) {
+		message := fmt.Sprintf(
+			"Branch name %q does not match the naming convention %s (e.g. feature/login-form, fix/crash-on-start)",
+			branch, pattern)
+		if v.branches.Naming == BranchNamingWarning {
+			v.warnings = append(v.warnings, message)
+			return true
+		}
+		v.fail(ValidationFailure{Rule: RuleBranchName, Pattern: pattern, Message: message})
+		return false
+	}
+	return true
+}
+
+// ValidateCurrentBranchName validates the name of the checked out branch, so
+// that commits on branches created outside the hook follow the convention too
+func (v *Validator) ValidateCurrentBranchName() bool {
+	head, err := v.repo.Head()
+	if err != nil || !head.Name().IsBranch() {
+		return true
+	}
+	return v.ValidateBranchName(head.Name().Short())
+}
+
+// ValidateCommitBranch blocks commits made directly on a protected branch
+// (main and develop by default) unless git.allow_direct_main_commits is true
+func (v *Validator) ValidateCommitBranch() bool {
+	if v.branches.AllowDirectMainCommits {
+		return true
+	}
+
+	head, err := v.repo.Head()
+	if err != nil || !head.Name().IsBranch() {
+		return true
+	}
+
+	branch := head.Name().Short()
+	if v.branches.IsProtected(branch) {
+		v.fail(ValidationFailure{Rule: RuleProtectedBranch, Message: fmt.Sprintf(
+			"Direct commits to %s are not allowed - commit on a feature branch (or set git.allow_direct_main_commits)", branch)})
+		return false
+	}
+	return true
+}
+
+// ExtractNewBranchFromCommand extracts the branch created by a git checkout -b
+// or git switch -c command
+func (v *Validator) ExtractNewBranchFromCommand(command string) string {
+	matches := newBranchPattern.FindStringSubmatch(command)
+	if len(matches) > 1 {
+		return strings.Trim(matches[1], `"'`)
+	}
+	return ""
+}
+
 // ExtractCommitMessageFromCommand extracts commit message from git commit command
 func (v *Validator) ExtractCommitMessageFromCommand(command string) string {
 	patterns := []string{
@@ -348,20 +631,30 @@ func (v *Validator) ValidateTool(toolName string, toolInput map[string]interface
 
 				// Validate staged files
 				v.ValidateStagedFiles()
+
+				if !v.dryRun {
+					v.ValidateCommitBranch()
+					v.ValidateCurrentBranchName()
+				}
 			} else if strings.Contains(command, "git add") {
 				// Git add validation
 				v.ValidateStagedFiles()
 			}
+
+			// Branch naming validation
+			if branch := v.ExtractNewBranchFromCommand(command); branch != "" && !v.dryRun {
+				v.ValidateBranchName(branch)
+			}
 		}
 	} else if toolName == "Write" {
 		// Check if creating potentially sensitive files
 		if filePath, ok := toolInput["file_path"].(string); ok {
 			relPath, _ := filepath.Rel(v.repoRoot, filePath)
-			for _, pattern := range forbiddenPatterns {
-				if matched, _ := regexp.MatchString(pattern, relPath); matched {
-					v.errors = append(v.errors, fmt.Sprintf("Forbidden file creation: %s", relPath))
-					break
-				}
+			relPath = NormalizePath(relPath)
+			v.scanned = append(v.scanned, relPath)
+			if forbidden, _ := v.classifyFile(relPath); forbidden {
+				v.fail(ValidationFailure{Rule: RuleForbiddenFile, File: relPath, Pattern: v.forbiddenPattern(relPath),
+					Message: fmt.Sprintf("Forbidden file creation: %s", relPath)})
 			}
 		}
 	}
@@ -371,14 +664,33 @@ func (v *Validator) ValidateTool(toolName string, toolInput map[string]interface
 
 // GetResult returns the validation result
 func (v *Validator) GetResult() ValidationResult {
+	scanned := v.scanned
+	if scanned == nil {
+		scanned = []string{}
+	}
 	return ValidationResult{
-		Success:  len(v.errors) == 0,
-		Errors:   v.errors,
-		Warnings: v.warnings,
-		Duration: time.Since(v.startTime).Milliseconds(),
+		Success:      len(v.errors) == 0,
+		Errors:       v.errors,
+		Warnings:     v.warnings,
+		Failures:     v.failures,
+		ScannedFiles: scanned,
+		Allowed:      v.allowed,
+		Duration:     time.Since(v.startTime).Milliseconds(),
 	}
 }
 
+// fail records an error and the rule behind it
+func (v *Validator) fail(failure ValidationFailure) {
+	v.errors = append(v.errors, failure.Message)
+	v.failures = append(v.failures, failure)
+}
+
+// explain records the rule behind an error reported as part of a list of
+// errors, such as one of the forbidden files staged
+func (v *Validator) explain(failure ValidationFailure) {
+	v.failures = append(v.failures, failure)
+}
+
 // PrintResults prints validation results to stderr
 func (v *Validator) PrintResults() {
 	if len(v.errors) > 0 {
@@ -395,6 +707,13 @@ func (v *Validator) PrintResults() {
 		}
 	}
 
+	if len(v.allowed) > 0 {
+		fmt.Fprintf(os.Stderr, "\nℹ️  Allowed by policy:\n")
+		for _, file := range v.allowed {
+			fmt.Fprintf(os.Stderr, "ℹ️  %s\n", file)
+		}
+	}
+
 	if len(v.errors) > 0 {
 		fmt.Fprintf(os.Stderr, "\n❌ Git operation blocked due to validation errors\n")
 		fmt.Fprintf(os.Stderr, "Please fix the errors above and try again.\n")
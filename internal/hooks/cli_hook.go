@@ -6,9 +6,12 @@ import (
 	"io"
 	"os"
 
+	"claude-wm-cli/internal/executor"
 	"claude-wm-cli/internal/formatting"
 	"claude-wm-cli/internal/git"
 	"claude-wm-cli/internal/validation"
+
+	"golang.org/x/term"
 )
 
 // ToolInput represents input from Claude Code hooks
@@ -19,7 +22,10 @@ type ToolInput struct {
 
 // HookHandler handles hook execution for claude-wm-cli
 type HookHandler struct {
-	projectRoot string
+	projectRoot  string
+	noSecretScan bool
+	allowTODOs   bool
+	jsonOutput   bool
 }
 
 // NewHookHandler creates a new hook handler
@@ -29,6 +35,40 @@ func NewHookHandler(projectRoot string) *HookHandler {
 	}
 }
 
+// SetSecretScan enables or disables the secret scan of staged file contents
+// during git validation
+func (h *HookHandler) SetSecretScan(enabled bool) {
+	h.noSecretScan = !enabled
+}
+
+// SetAllowTODOs skips the check for new TODO comments during git validation
+func (h *HookHandler) SetAllowTODOs(allow bool) {
+	h.allowTODOs = allow
+}
+
+// SetJSONOutput writes the git validation result as JSON to stdout, the
+// human-readable report staying on stderr. GIT_VALIDATOR_OUTPUT=json does the
+// same.
+func (h *HookHandler) SetJSONOutput(enabled bool) {
+	h.jsonOutput = enabled
+}
+
+// printValidationResults prints the report of validator to stderr, and its
+// result as JSON to stdout in JSON mode
+func (h *HookHandler) printValidationResults(validator *git.Validator) {
+	validator.PrintResults()
+	if !h.jsonOutput && !git.JSONOutputFromEnv() {
+		return
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(validator.GetResult()); err != nil {
+		fmt.Fprintf(os.Stderr, "error encoding validation result: %v\n", err)
+	}
+}
+
 // HandleGitValidation handles git validation hooks
 func (h *HookHandler) HandleGitValidation() error {
 	// Read input from stdin
@@ -48,11 +88,16 @@ func (h *HookHandler) HandleGitValidation() error {
 		return fmt.Errorf("error initializing git validator: %v", err)
 	}
 
+	// Branch checks only guard real git operations
+	validator.SetDryRun(executor.IsDryRun())
+	validator.SetSecretScan(!h.noSecretScan)
+	validator.SetAllowTODOs(h.allowTODOs)
+
 	// Run validation
 	success := validator.ValidateTool(input.ToolName, input.ToolInput)
 
 	// Print results
-	validator.PrintResults()
+	h.printValidationResults(validator)
 
 	// Exit with appropriate code
 	if success {
@@ -63,6 +108,39 @@ func (h *HookHandler) HandleGitValidation() error {
 	}
 }
 
+// HandlePrePushValidation validates the commits of a push, reading the pushed
+// refs from stdin as passed by git to the pre-push hook
+func (h *HookHandler) HandlePrePushValidation(remote, url string) error {
+	// git always pipes the ref updates, possibly none; run by hand from a
+	// terminal, the hook checks the current branch instead
+	var refs []git.PushRef
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		var err error
+		if refs, err = git.ParsePrePushInput(os.Stdin); err != nil {
+			return fmt.Errorf("error reading pre-push input: %v", err)
+		}
+	}
+
+	validator, err := git.NewValidator()
+	if err != nil {
+		return fmt.Errorf("error initializing git validator: %v", err)
+	}
+
+	commits, err := validator.CommitsToPush(remote, refs)
+	if err != nil {
+		return err
+	}
+
+	validator.SetPushRefs(refs)
+	success := validator.RunPrePushValidation(remote, url, commits)
+	h.printValidationResults(validator)
+
+	if !success {
+		os.Exit(2)
+	}
+	return nil
+}
+
 // HandleAutoFormat handles auto-formatting hooks
 func (h *HookHandler) HandleAutoFormat() error {
 	formatter := formatting.NewFormatter(h.projectRoot)
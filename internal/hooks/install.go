@@ -0,0 +1,435 @@
+package hooks
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/build/constraint"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// HookBuildTag is the build tag marking the Go sources compiled into git hooks
+	HookBuildTag = "hook"
+	// HookSourcesDir holds the hook sources, relative to the repository root
+	HookSourcesDir = ".claude-wm/runtime/hooks"
+	// installedHooksFile records the installed hooks inside .git/hooks
+	installedHooksFile = ".claude-wm-hooks.json"
+	// builtinSource is the source recorded for the built-in pre-push script
+	builtinSource = "built-in"
+)
+
+// HookSource is a Go hook source, compiled to .git/hooks/<Name>
+type HookSource struct {
+	Name string
+	Path string
+}
+
+// InstalledHook describes a hook installed by the installer
+type InstalledHook struct {
+	Name           string    `json:"name"`
+	Source         string    `json:"source"`
+	SourceChecksum string    `json:"source_checksum,omitempty"`
+	Checksum       string    `json:"checksum"`
+	Size           int64     `json:"size"`
+	InstalledAt    time.Time `json:"installed_at"`
+}
+
+// HookState tells how an installed hook compares to its source
+type HookState string
+
+const (
+	HookUpToDate      HookState = "up to date"
+	HookSourceChanged HookState = "source changed"
+	HookModified      HookState = "modified"
+	HookNotInstalled  HookState = "not installed"
+	HookMissing       HookState = "missing"
+	HookOrphaned      HookState = "source removed"
+)
+
+// HookStatus is the state of one hook
+type HookStatus struct {
+	Name  string
+	State HookState
+}
+
+// Installer compiles the hook sources of a repository into .git/hooks
+type Installer struct {
+	repoRoot string
+}
+
+// NewInstaller creates a hook installer for the repository at repoRoot
+func NewInstaller(repoRoot string) *Installer {
+	return &Installer{repoRoot: repoRoot}
+}
+
+// HooksDir returns the git hooks directory
+func (i *Installer) HooksDir() string {
+	return filepath.Join(i.repoRoot, ".git", "hooks")
+}
+
+// SourcesDir returns the directory of the hook sources
+func (i *Installer) SourcesDir() string {
+	return filepath.Join(i.repoRoot, HookSourcesDir)
+}
+
+// HookPath returns the installed path of a hook
+func (i *Installer) HookPath(name string) string {
+	return filepath.Join(i.HooksDir(), name)
+}
+
+// FindSources lists the *.go files of the sources directory with the hook
+// build tag. A "-hook" suffix is dropped from the hook name, so
+// pre-commit-hook.go is installed as .git/hooks/pre-commit.
+func (i *Installer) FindSources() ([]HookSource, error) {
+	entries, err := os.ReadDir(i.SourcesDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", i.SourcesDir(), err)
+	}
+
+	var sources []HookSource
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".go" || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		path := filepath.Join(i.SourcesDir(), entry.Name())
+		tagged, err := hasHookBuildTag(path)
+		if err != nil {
+			return nil, err
+		}
+		if !tagged {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimSuffix(entry.Name(), ".go"), "-hook")
+		sources = append(sources, HookSource{Name: name, Path: path})
+	}
+	return sources, nil
+}
+
+// hasHookBuildTag reports whether the //go:build line of a Go file is
+// satisfied by the hook build tag
+func hasHookBuildTag(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		if !constraint.IsGoBuild(line) {
+			continue
+		}
+		expr, err := constraint.Parse(line)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", path, err)
+		}
+		return expr.Eval(func(tag string) bool { return tag == HookBuildTag }), nil
+	}
+	return false, scanner.Err()
+}
+
+// IsManaged reports whether the hook at .git/hooks/<name> is unchanged since
+// the installer wrote it, so it can be replaced without confirmation
+func (i *Installer) IsManaged(name string) bool {
+	installed, err := i.loadInstalled()
+	if err != nil {
+		return false
+	}
+	hook, ok := installed[name]
+	if !ok {
+		return false
+	}
+	checksum, _, err := fileChecksum(i.HookPath(name))
+	return err == nil && checksum == hook.Checksum
+}
+
+// Exists reports whether a hook file is present in .git/hooks
+func (i *Installer) Exists(name string) bool {
+	_, err := os.Stat(i.HookPath(name))
+	return err == nil
+}
+
+// Install compiles a hook source with `go build -tags hook` into .git/hooks.
+// An existing hook that was not installed by the installer is backed up to
+// <name>.bak.
+func (i *Installer) Install(source HookSource) (*InstalledHook, error) {
+	if err := i.checkHooksDir(); err != nil {
+		return nil, err
+	}
+
+	tmpPath := i.HookPath("." + source.Name + ".tmp")
+	cmd := exec.Command("go", "build", "-tags", HookBuildTag, "-o", tmpPath, filepath.Base(source.Path))
+	cmd.Dir = filepath.Dir(source.Path)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to build %s: %w\n%s", filepath.Base(source.Path), err, strings.TrimSpace(string(output)))
+	}
+
+	sourceChecksum, _, err := fileChecksum(source.Path)
+	if err != nil {
+		os.Remove(tmpPath)
+		return nil, err
+	}
+	rel, err := filepath.Rel(i.repoRoot, source.Path)
+	if err != nil {
+		rel = source.Path
+	}
+	return i.place(source.Name, tmpPath, rel, sourceChecksum)
+}
+
+// InstallPrePush installs the built-in pre-push script
+func (i *Installer) InstallPrePush() (*InstalledHook, error) {
+	if err := i.checkHooksDir(); err != nil {
+		return nil, err
+	}
+
+	tmpPath := i.HookPath(".pre-push.tmp")
+	if err := os.WriteFile(tmpPath, []byte(PrePushHookScript), 0755); err != nil {
+		return nil, fmt.Errorf("failed to write hook file: %w", err)
+	}
+	return i.place("pre-push", tmpPath, builtinSource, "")
+}
+
+// place moves a built hook to .git/hooks/<name> and records it
+func (i *Installer) place(name, tmpPath, source, sourceChecksum string) (*InstalledHook, error) {
+	hookPath := i.HookPath(name)
+	if i.Exists(name) && !i.IsManaged(name) {
+		if err := copyFile(hookPath, hookPath+".bak"); err != nil {
+			os.Remove(tmpPath)
+			return nil, fmt.Errorf("failed to backup existing hook: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, hookPath); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to install %s: %w", name, err)
+	}
+	// Set executable permissions explicitly to override umask
+	if err := os.Chmod(hookPath, 0755); err != nil {
+		return nil, fmt.Errorf("failed to set executable permissions: %w", err)
+	}
+
+	checksum, size, err := fileChecksum(hookPath)
+	if err != nil {
+		return nil, err
+	}
+	hook := &InstalledHook{
+		Name:           name,
+		Source:         source,
+		SourceChecksum: sourceChecksum,
+		Checksum:       checksum,
+		Size:           size,
+		InstalledAt:    time.Now(),
+	}
+
+	installed, err := i.loadInstalled()
+	if err != nil {
+		return nil, err
+	}
+	installed[name] = hook
+	if err := i.saveInstalled(installed); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// List returns the installed hooks with the current size and checksum of
+// their files, sorted by name. Hooks whose file was deleted are skipped.
+func (i *Installer) List() ([]InstalledHook, error) {
+	installed, err := i.loadInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	var hooks []InstalledHook
+	for _, hook := range installed {
+		checksum, size, err := fileChecksum(i.HookPath(hook.Name))
+		if err != nil {
+			continue
+		}
+		current := *hook
+		current.Checksum = checksum
+		current.Size = size
+		hooks = append(hooks, current)
+	}
+	sort.Slice(hooks, func(a, b int) bool { return hooks[a].Name < hooks[b].Name })
+	return hooks, nil
+}
+
+// Status compares the installed hooks to the hook sources
+func (i *Installer) Status() ([]HookStatus, error) {
+	sources, err := i.FindSources()
+	if err != nil {
+		return nil, err
+	}
+	installed, err := i.loadInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var statuses []HookStatus
+	for _, source := range sources {
+		seen[source.Name] = true
+		hook, ok := installed[source.Name]
+		if !ok || hook.Source == builtinSource {
+			statuses = append(statuses, HookStatus{Name: source.Name, State: HookNotInstalled})
+			continue
+		}
+		statuses = append(statuses, HookStatus{Name: source.Name, State: i.compare(hook, source.Path)})
+	}
+
+	for name, hook := range installed {
+		if seen[name] {
+			continue
+		}
+		state := HookOrphaned
+		if hook.Source == builtinSource {
+			state = i.compare(hook, "")
+		}
+		statuses = append(statuses, HookStatus{Name: name, State: state})
+	}
+
+	sort.Slice(statuses, func(a, b int) bool { return statuses[a].Name < statuses[b].Name })
+	return statuses, nil
+}
+
+// compare returns the state of an installed hook built from sourcePath, or of
+// a built-in hook when sourcePath is empty
+func (i *Installer) compare(hook *InstalledHook, sourcePath string) HookState {
+	checksum, _, err := fileChecksum(i.HookPath(hook.Name))
+	if err != nil {
+		return HookMissing
+	}
+	if checksum != hook.Checksum {
+		return HookModified
+	}
+	if sourcePath != "" {
+		sourceChecksum, _, err := fileChecksum(sourcePath)
+		if err != nil || sourceChecksum != hook.SourceChecksum {
+			return HookSourceChanged
+		}
+	}
+	return HookUpToDate
+}
+
+// Uninstall removes an installed hook, or all installed hooks when name is
+// empty, and returns the names of the removed hooks. Hooks not installed by
+// the installer are left alone.
+func (i *Installer) Uninstall(name string) ([]string, error) {
+	installed, err := i.loadInstalled()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if name != "" {
+		if _, ok := installed[name]; !ok {
+			return nil, fmt.Errorf("hook %s was not installed by 'hooks install'", name)
+		}
+		names = []string{name}
+	} else {
+		for installedName := range installed {
+			names = append(names, installedName)
+		}
+		sort.Strings(names)
+	}
+
+	for _, hookName := range names {
+		if err := os.Remove(i.HookPath(hookName)); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove %s: %w", hookName, err)
+		}
+		delete(installed, hookName)
+	}
+	return names, i.saveInstalled(installed)
+}
+
+// checkHooksDir fails outside of a git repository
+func (i *Installer) checkHooksDir() error {
+	if _, err := os.Stat(i.HooksDir()); os.IsNotExist(err) {
+		return fmt.Errorf("not a Git repository (no .git/hooks directory found)")
+	}
+	return nil
+}
+
+// loadInstalled reads the record of installed hooks
+func (i *Installer) loadInstalled() (map[string]*InstalledHook, error) {
+	installed := make(map[string]*InstalledHook)
+	path := filepath.Join(i.HooksDir(), installedHooksFile)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return installed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &installed); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return installed, nil
+}
+
+// saveInstalled writes the record of installed hooks, removing it when empty
+func (i *Installer) saveInstalled(installed map[string]*InstalledHook) error {
+	path := filepath.Join(i.HooksDir(), installedHooksFile)
+	if len(installed) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(installed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal installed hooks: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// fileChecksum returns the SHA-256 checksum and size of a file
+func fileChecksum(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// copyFile copies src to dst, keeping the permissions of src
+func copyFile(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, info.Mode().Perm())
+}
@@ -0,0 +1,67 @@
+package hooks
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstaller_InstallStatusUninstall(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "hooks"), 0755))
+	sourcesDir := filepath.Join(root, HookSourcesDir)
+	require.NoError(t, os.MkdirAll(sourcesDir, 0755))
+
+	source := filepath.Join(sourcesDir, "pre-commit-hook.go")
+	require.NoError(t, os.WriteFile(source, []byte("//go:build hook\n\npackage main\n\nfunc main() {}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(sourcesDir, "helper.go"), []byte("//go:build ignore\n\npackage main\n"), 0644))
+
+	installer := NewInstaller(root)
+	sources, err := installer.FindSources()
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, "pre-commit", sources[0].Name)
+
+	hook, err := installer.Install(sources[0])
+	require.NoError(t, err)
+	assert.FileExists(t, installer.HookPath("pre-commit"))
+	assert.True(t, installer.IsManaged("pre-commit"))
+	assert.Equal(t, filepath.Join(HookSourcesDir, "pre-commit-hook.go"), hook.Source)
+
+	statuses, err := installer.Status()
+	require.NoError(t, err)
+	assert.Equal(t, []HookStatus{{Name: "pre-commit", State: HookUpToDate}}, statuses)
+
+	require.NoError(t, os.WriteFile(source, []byte("//go:build hook\n\npackage main\n\nfunc main() { println() }\n"), 0644))
+	statuses, err = installer.Status()
+	require.NoError(t, err)
+	assert.Equal(t, HookSourceChanged, statuses[0].State)
+
+	removed, err := installer.Uninstall("")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pre-commit"}, removed)
+	assert.NoFileExists(t, installer.HookPath("pre-commit"))
+}
+
+func TestInstaller_InstallPrePushBacksUpForeignHook(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git", "hooks"), 0755))
+
+	installer := NewInstaller(root)
+	existing := installer.HookPath("pre-push")
+	require.NoError(t, os.WriteFile(existing, []byte("#!/bin/sh\necho custom\n"), 0755))
+	assert.False(t, installer.IsManaged("pre-push"))
+
+	_, err := installer.InstallPrePush()
+	require.NoError(t, err)
+
+	backup, err := os.ReadFile(existing + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\necho custom\n", string(backup))
+	installed, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, PrePushHookScript, string(installed))
+}
@@ -0,0 +1,28 @@
+package hooks
+
+// PrePushHookScript is the git pre-push hook running the pre-push validation
+const PrePushHookScript = `#!/bin/sh
+# Claude WM CLI Pre-push Hook
+#
+# This hook runs 'claude-wm-cli hook git-validation --pre-push' before each push.
+# It blocks pushes to protected branches and commits that break the commit rules.
+#
+# Installation: claude-wm-cli hooks install
+# Manual installation: copy this file to .git/hooks/pre-push and chmod +x
+
+REPO_ROOT=$(git rev-parse --show-toplevel 2>/dev/null || echo "")
+
+if [ -n "$REPO_ROOT" ] && [ -x "$REPO_ROOT/claude-wm-cli" ]; then
+    CLAUDE_WM_CLI="$REPO_ROOT/claude-wm-cli"
+elif [ -n "$REPO_ROOT" ] && [ -x "$REPO_ROOT/build/claude-wm-cli" ]; then
+    CLAUDE_WM_CLI="$REPO_ROOT/build/claude-wm-cli"
+elif command -v claude-wm-cli >/dev/null 2>&1; then
+    CLAUDE_WM_CLI="claude-wm-cli"
+else
+    echo "Warning: claude-wm-cli not found, skipping pre-push validation" >&2
+    exit 0
+fi
+
+# git passes the pushed refs on stdin, which the validation reads
+exec "$CLAUDE_WM_CLI" hook git-validation --pre-push "$1" "$2"
+`
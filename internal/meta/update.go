@@ -0,0 +1,214 @@
+package meta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// LatestReleaseURL is the GitHub API endpoint of the latest release
+	LatestReleaseURL = "https://api.github.com/repos/pezzos/claude-wm-cli/releases/latest"
+
+	// UpdateCheckInterval is how long the result of an update check is reused
+	UpdateCheckInterval = 24 * time.Hour
+
+	// NoUpdateCheckEnv disables the automatic update check when set to 1
+	NoUpdateCheckEnv = "CLAUDE_WM_NO_UPDATE_CHECK"
+)
+
+// UpdateCheck is the result of comparing the running version to the latest
+// release, cached in ~/.claude-wm/update-check.json
+type UpdateCheck struct {
+	Current    string    `json:"current"`
+	Latest     string    `json:"latest"`
+	ReleaseURL string    `json:"release_url,omitempty"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+// UpdateAvailable reports whether the latest release is newer than the
+// running version. Development builds are never out of date.
+func (c UpdateCheck) UpdateAvailable() bool {
+	if IsDevelopmentBuild(c.Current) || IsDevelopmentBuild(c.Latest) {
+		return false
+	}
+	return CompareVersions(c.Latest, c.Current) > 0
+}
+
+// UpdateChecker fetches the latest release and caches the result
+type UpdateChecker struct {
+	URL       string // Latest release endpoint, LatestReleaseURL by default
+	CachePath string // Cache file, none when empty
+	Client    *http.Client
+}
+
+// NewUpdateChecker returns a checker of the GitHub releases with requests
+// timing out after timeout, caching its result in the user's home directory
+func NewUpdateChecker(timeout time.Duration) *UpdateChecker {
+	checker := &UpdateChecker{
+		URL:    LatestReleaseURL,
+		Client: &http.Client{Timeout: timeout},
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		checker.CachePath = filepath.Join(home, ".claude-wm", "update-check.json")
+	}
+	return checker
+}
+
+// UpdateCheckDisabled reports whether CLAUDE_WM_NO_UPDATE_CHECK=1 disables
+// the automatic update check
+func UpdateCheckDisabled() bool {
+	return os.Getenv(NoUpdateCheckEnv) == "1"
+}
+
+// Check compares current to the latest release, reusing the cached result
+// of a check of the same version made less than UpdateCheckInterval ago
+func (c *UpdateChecker) Check(ctx context.Context, current string) (*UpdateCheck, error) {
+	if cached := c.cached(current); cached != nil {
+		return cached, nil
+	}
+
+	latest, releaseURL, err := c.fetchLatest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &UpdateCheck{Current: current, Latest: latest, ReleaseURL: releaseURL, CheckedAt: time.Now()}
+	c.save(check)
+	return check, nil
+}
+
+// fetchLatest returns the tag and page of the latest release
+func (c *UpdateChecker) fetchLatest(ctx context.Context) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "claude-wm-cli/"+Version)
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("failed to fetch latest release: %s", resp.Status)
+	}
+
+	var release struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return "", "", fmt.Errorf("failed to decode latest release: %w", err)
+	}
+	if release.TagName == "" {
+		return "", "", fmt.Errorf("latest release has no tag")
+	}
+	return release.TagName, release.HTMLURL, nil
+}
+
+// cached returns the cached check of current if it is recent enough
+func (c *UpdateChecker) cached(current string) *UpdateCheck {
+	if c.CachePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(c.CachePath)
+	if err != nil {
+		return nil
+	}
+
+	var check UpdateCheck
+	if err := json.Unmarshal(data, &check); err != nil {
+		return nil
+	}
+	if check.Current != current || time.Since(check.CheckedAt) > UpdateCheckInterval {
+		return nil
+	}
+	return &check
+}
+
+// save caches check, ignoring failures: the next invocation checks again
+func (c *UpdateChecker) save(check *UpdateCheck) {
+	if c.CachePath == "" {
+		return
+	}
+	data, err := json.MarshalIndent(check, "", "  ")
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.CachePath), 0755); err != nil {
+		return
+	}
+	os.WriteFile(c.CachePath, data, 0644)
+}
+
+// CompareVersions compares two semantic versions such as "v1.2.3" or
+// "1.3.0-rc.1", returning -1, 0 or 1. A pre-release is older than its release.
+func CompareVersions(a, b string) int {
+	aCore, aPre := splitVersion(a)
+	bCore, bPre := splitVersion(b)
+
+	for i := 0; i < len(aCore) || i < len(bCore); i++ {
+		var x, y int
+		if i < len(aCore) {
+			x = aCore[i]
+		}
+		if i < len(bCore) {
+			y = bCore[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	case aPre < bPre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// splitVersion returns the numbers and the pre-release of version
+func splitVersion(version string) ([]int, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		version = version[:i]
+	}
+	core, pre, _ := strings.Cut(version, "-")
+
+	var numbers []int
+	for _, part := range strings.Split(core, ".") {
+		n, _ := strconv.Atoi(part)
+		numbers = append(numbers, n)
+	}
+	return numbers, pre
+}
+
+// IsDevelopmentBuild reports whether version is not a release: "dev", or the
+// pseudo-version Go stamps on builds of a commit (v0.0.0-20250102150405-1a2b3c4d5e6f)
+func IsDevelopmentBuild(version string) bool {
+	version = strings.TrimPrefix(version, "v")
+	if version == "" || version[0] < '0' || version[0] > '9' {
+		return true
+	}
+	return strings.HasPrefix(version, "0.0.0-")
+}
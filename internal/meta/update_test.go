@@ -0,0 +1,97 @@
+package meta
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.2.3", "v1.2.3", 0},
+		{"v1.3.0", "v1.2.3", 1},
+		{"1.2.3", "v1.10.0", -1},
+		{"v2.0", "v2.0.0", 0},
+		{"v1.3.0-rc.1", "v1.3.0", -1},
+		{"v1.3.0-rc.2", "v1.3.0-rc.1", 1},
+		{"v1.3.0+build.5", "v1.3.0", 0},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, CompareVersions(tt.a, tt.b), "%s vs %s", tt.a, tt.b)
+	}
+}
+
+func TestUpdateCheck_UpdateAvailable(t *testing.T) {
+	assert.True(t, UpdateCheck{Current: "v1.2.3", Latest: "v1.3.0"}.UpdateAvailable())
+	assert.False(t, UpdateCheck{Current: "v1.3.0", Latest: "v1.3.0"}.UpdateAvailable())
+	assert.False(t, UpdateCheck{Current: "dev", Latest: "v1.3.0"}.UpdateAvailable())
+	assert.False(t, UpdateCheck{Current: "v0.0.0-20250102150405-1a2b3c4d5e6f+dirty", Latest: "v1.3.0"}.UpdateAvailable())
+}
+
+func TestUpdateChecker_Check(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		json.NewEncoder(w).Encode(map[string]string{
+			"tag_name": "v1.3.0",
+			"html_url": "https://github.com/pezzos/claude-wm-cli/releases/tag/v1.3.0",
+		})
+	}))
+	defer server.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "update-check.json")
+	checker := &UpdateChecker{URL: server.URL, CachePath: cachePath, Client: server.Client()}
+
+	check, err := checker.Check(context.Background(), "v1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.3.0", check.Latest)
+	assert.Equal(t, "https://github.com/pezzos/claude-wm-cli/releases/tag/v1.3.0", check.ReleaseURL)
+	assert.True(t, check.UpdateAvailable())
+
+	// A recent check of the same version is reused
+	_, err = checker.Check(context.Background(), "v1.2.3")
+	require.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	// Another version or an old check asks again
+	_, err = checker.Check(context.Background(), "v1.3.0")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	stale := UpdateCheck{Current: "v1.3.0", Latest: "v1.3.0", CheckedAt: time.Now().Add(-UpdateCheckInterval - time.Minute)}
+	data, err := json.Marshal(stale)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cachePath, data, 0644))
+	_, err = checker.Check(context.Background(), "v1.3.0")
+	require.NoError(t, err)
+	assert.Equal(t, 3, requests)
+}
+
+func TestUpdateChecker_CheckError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	checker := &UpdateChecker{URL: server.URL, Client: server.Client()}
+	_, err := checker.Check(context.Background(), "v1.2.3")
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestUpdateCheckDisabled(t *testing.T) {
+	t.Setenv(NoUpdateCheckEnv, "1")
+	assert.True(t, UpdateCheckDisabled())
+	t.Setenv(NoUpdateCheckEnv, "")
+	assert.False(t, UpdateCheckDisabled())
+}
@@ -1,5 +1,79 @@
 package meta
 
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build information, set at build time with
+// -ldflags "-X claude-wm-cli/internal/meta.Version=... -X ...Commit=... -X ...BuildDate=..."
 var Version = "dev"
 var Commit = "dev"
-var BuildDate = "dev"
\ No newline at end of file
+var BuildDate = "dev"
+
+// Info identifies a build of the CLI
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"git_commit"`
+	BuildDate string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+	Compiler  string `json:"compiler"`
+	Modified  bool   `json:"modified,omitempty"` // Built from a working tree with uncommitted changes
+}
+
+// BuildInfo returns the information of the running binary. Values not set by
+// -ldflags are read from the build information Go embeds in the binary, so
+// that `go install` and `go build` builds can be identified too.
+func BuildInfo() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Compiler:  runtime.Compiler,
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		applyBuildInfo(&info, build)
+	}
+	return info
+}
+
+// applyBuildInfo fills the values of info still at "dev" from build
+func applyBuildInfo(info *Info, build *debug.BuildInfo) {
+	if info.Version == "dev" && build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Version = build.Main.Version
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "dev" && setting.Value != "" {
+				info.Commit = setting.Value
+				if len(info.Commit) > 12 {
+					info.Commit = info.Commit[:12]
+				}
+			}
+		case "vcs.time":
+			if info.BuildDate == "dev" && setting.Value != "" {
+				info.BuildDate = setting.Value
+			}
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+}
+
+// String returns the one-line build identifier to paste in bug reports, such
+// as "v1.2.0 (commit 1a2b3c4, built 2025-01-02T15:04:05Z, go1.24.0 linux/amd64)"
+func (i Info) String() string {
+	commit := i.Commit
+	if i.Modified {
+		commit += "-dirty"
+	}
+	return fmt.Sprintf("%s (commit %s, built %s, %s %s/%s)", i.Version, commit, i.BuildDate, i.GoVersion, i.OS, i.Arch)
+}
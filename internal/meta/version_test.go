@@ -0,0 +1,46 @@
+package meta
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyBuildInfo(t *testing.T) {
+	build := &debug.BuildInfo{
+		Main: debug.Module{Version: "v1.3.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123"},
+			{Key: "vcs.time", Value: "2025-02-03T04:05:06Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	info := Info{Version: "dev", Commit: "dev", BuildDate: "dev"}
+	applyBuildInfo(&info, build)
+	assert.Equal(t, "v1.3.0", info.Version)
+	assert.Equal(t, "0123456789ab", info.Commit)
+	assert.Equal(t, "2025-02-03T04:05:06Z", info.BuildDate)
+	assert.True(t, info.Modified)
+
+	// Values injected with -ldflags win
+	info = Info{Version: "v2.0.0", Commit: "abc1234", BuildDate: "2025-01-01T00:00:00Z"}
+	applyBuildInfo(&info, &debug.BuildInfo{Main: debug.Module{Version: "(devel)"}, Settings: build.Settings[:2]})
+	assert.Equal(t, Info{Version: "v2.0.0", Commit: "abc1234", BuildDate: "2025-01-01T00:00:00Z"}, info)
+}
+
+func TestInfoString(t *testing.T) {
+	info := Info{
+		Version:   "v1.2.0",
+		Commit:    "1a2b3c4",
+		BuildDate: "2025-01-02T15:04:05Z",
+		GoVersion: "go1.24.0",
+		OS:        "linux",
+		Arch:      "amd64",
+	}
+	assert.Equal(t, "v1.2.0 (commit 1a2b3c4, built 2025-01-02T15:04:05Z, go1.24.0 linux/amd64)", info.String())
+
+	info.Modified = true
+	assert.Contains(t, info.String(), "commit 1a2b3c4-dirty")
+}
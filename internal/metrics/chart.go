@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ChartSeries is a line drawn by RenderLineChart. Negative values are not
+// plotted.
+type ChartSeries struct {
+	Name   string
+	Marker rune
+	Values []float64
+}
+
+// chartOverlapMarker is drawn where several series have the same point
+const chartOverlapMarker = '*'
+
+// chartColumnWidth is the width of one x-axis position
+const chartColumnWidth = 4
+
+// RenderLineChart renders series as an ASCII chart of height rows, with one
+// column per label on the x-axis and a legend below. Values are scaled from
+// zero to the largest value of all series.
+func RenderLineChart(series []ChartSeries, labels []string, height int) []string {
+	if height < 2 {
+		height = 2
+	}
+
+	maxValue := 0.0
+	for _, s := range series {
+		for _, value := range s.Values {
+			maxValue = math.Max(maxValue, value)
+		}
+	}
+	if maxValue == 0 {
+		maxValue = 1
+	}
+
+	// grid[row][column], row 0 at the top
+	grid := make([][]rune, height)
+	for row := range grid {
+		grid[row] = []rune(strings.Repeat(" ", len(labels)*chartColumnWidth))
+	}
+	for _, s := range series {
+		for column, value := range s.Values {
+			if column >= len(labels) || value < 0 {
+				continue
+			}
+			row := height - 1 - int(math.Round(value/maxValue*float64(height-1)))
+			x := column*chartColumnWidth + chartColumnWidth/2
+			if grid[row][x] != ' ' && grid[row][x] != s.Marker {
+				grid[row][x] = chartOverlapMarker
+			} else {
+				grid[row][x] = s.Marker
+			}
+		}
+	}
+
+	axisWidth := len(fmt.Sprintf("%.0f", maxValue))
+	var lines []string
+	for row := range grid {
+		value := maxValue * float64(height-1-row) / float64(height-1)
+		lines = append(lines, strings.TrimRight(fmt.Sprintf("%*.0f | %s", axisWidth, value, string(grid[row])), " "))
+	}
+
+	pad := strings.Repeat(" ", axisWidth)
+	lines = append(lines, fmt.Sprintf("%s +-%s", pad, strings.Repeat("-", len(labels)*chartColumnWidth)))
+
+	var axis strings.Builder
+	for _, label := range labels {
+		fmt.Fprintf(&axis, "%-*s", chartColumnWidth, centerLabel(label, chartColumnWidth))
+	}
+	lines = append(lines, strings.TrimRight(fmt.Sprintf("%s   %s", pad, axis.String()), " "))
+
+	var legend []string
+	for _, s := range series {
+		legend = append(legend, fmt.Sprintf("%c %s", s.Marker, s.Name))
+	}
+	legend = append(legend, fmt.Sprintf("%c both", chartOverlapMarker))
+	lines = append(lines, fmt.Sprintf("%s   %s", pad, strings.Join(legend, "   ")))
+
+	return lines
+}
+
+// centerLabel centers label in width columns, cutting it if it is longer
+func centerLabel(label string, width int) string {
+	if len(label) >= width {
+		return label[:width]
+	}
+	left := (width - len(label)) / 2
+	return strings.Repeat(" ", left) + label
+}
@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderLineChart(t *testing.T) {
+	lines := RenderLineChart([]ChartSeries{
+		{Name: "ideal", Marker: '.', Values: []float64{20, 10, 0}},
+		{Name: "actual", Marker: 'o', Values: []float64{20, 15, -1}},
+	}, []string{"W0", "W1", "W2"}, 5)
+
+	require.Len(t, lines, 8)
+	assert.Equal(t, "20 |   *", lines[0])
+	assert.Equal(t, "15 |       o", lines[1])
+	assert.Equal(t, "10 |       .", lines[2])
+	assert.Equal(t, " 0 |           .", lines[4])
+	assert.Equal(t, "   +-------------", lines[5])
+	assert.Equal(t, "      W0  W1  W2", lines[6])
+	assert.Equal(t, "     . ideal   o actual   * both", lines[7])
+}
+
+func TestRenderLineChart_NoValues(t *testing.T) {
+	lines := RenderLineChart(nil, []string{"W0"}, 1)
+
+	require.Len(t, lines, 5)
+	assert.True(t, strings.HasPrefix(lines[0], "1 |"))
+	assert.True(t, strings.HasPrefix(lines[1], "0 |"))
+}
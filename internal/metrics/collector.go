@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 )
 
 // PerformanceCollector is the main collector for performance metrics
@@ -122,13 +123,32 @@ func (pc *PerformanceCollector) GetStepStats(commandName string, days int) ([]St
 	return pc.storage.GetStepStats(commandName, days)
 }
 
-// GetSlowCommands returns commands slower than threshold
-func (pc *PerformanceCollector) GetSlowCommands(thresholdMs int64, days int) ([]CommandStats, error) {
+// GetStepPercentiles returns the P50/P95/P99 latencies of each step of
+// commandName over the last days, slowest P95 first
+func (pc *PerformanceCollector) GetStepPercentiles(commandName string, days int) ([]StepPercentiles, error) {
 	if !pc.enabled {
 		return nil, fmt.Errorf("metrics collection is disabled")
 	}
-	
-	return pc.storage.GetSlowCommands(thresholdMs, days)
+
+	durations, err := pc.storage.GetStepDurations(commandName, days)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeStepPercentiles(durations), nil
+}
+
+// GetSlowCommands returns the commands whose statistic named by (mean, p50,
+// p90, p95 or p99) is above thresholdMs over the last days, slowest first
+func (pc *PerformanceCollector) GetSlowCommands(thresholdMs int64, days int, by string) ([]CommandStats, error) {
+	if !pc.enabled {
+		return nil, fmt.Errorf("metrics collection is disabled")
+	}
+
+	runs, err := pc.storage.GetCommandRuns(days)
+	if err != nil {
+		return nil, err
+	}
+	return SlowCommands(ComputeCommandStats(runs), thresholdMs, by)
 }
 
 // GetProjectComparison returns performance comparison across projects
@@ -140,6 +160,34 @@ func (pc *PerformanceCollector) GetProjectComparison(days int) ([]ProjectStats,
 	return pc.storage.GetProjectComparison(days)
 }
 
+// CompareProjects returns the P95 latency of each command run over the last
+// days in each of the project directories paths
+func (pc *PerformanceCollector) CompareProjects(paths []string, days int) (*ProjectComparison, error) {
+	if !pc.enabled {
+		return nil, fmt.Errorf("metrics collection is disabled")
+	}
+
+	return CompareProjects(pc.storage, paths, days)
+}
+
+// CompareWindows returns the statistics of each command, or only of
+// commandName when not empty, over the baseline and candidate date ranges
+func (pc *PerformanceCollector) CompareWindows(baseline, candidate DateRange, commandName string) ([]WindowComparison, error) {
+	if !pc.enabled {
+		return nil, fmt.Errorf("metrics collection is disabled")
+	}
+
+	baselineRuns, err := pc.storage.GetCommandRunsBetween(baseline.From, baseline.To, commandName)
+	if err != nil {
+		return nil, err
+	}
+	candidateRuns, err := pc.storage.GetCommandRunsBetween(candidate.From, candidate.To, commandName)
+	if err != nil {
+		return nil, err
+	}
+	return CompareWindows(baselineRuns, candidateRuns), nil
+}
+
 // GetAllCommandStats returns statistics for all commands
 func (pc *PerformanceCollector) GetAllCommandStats(days int) ([]CommandStats, error) {
 	if !pc.enabled {
@@ -149,6 +197,78 @@ func (pc *PerformanceCollector) GetAllCommandStats(days int) ([]CommandStats, er
 	return pc.storage.GetAllCommandStats(days)
 }
 
+// GetRecentCommands returns the last limit commands run in projectPath
+func (pc *PerformanceCollector) GetRecentCommands(projectPath string, limit int) ([]MetricEntry, error) {
+	if !pc.enabled {
+		return nil, fmt.Errorf("metrics collection is disabled")
+	}
+
+	return pc.storage.GetRecentCommands(projectPath, limit)
+}
+
+// GetCommandSummaries returns the count, error count and duration
+// statistics of each command run over the last days, by command name
+func (pc *PerformanceCollector) GetCommandSummaries(days int) ([]CommandSummary, error) {
+	if !pc.enabled {
+		return nil, fmt.Errorf("metrics collection is disabled")
+	}
+
+	runs, err := pc.storage.GetCommandRuns(days)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeCommands(runs), nil
+}
+
+// DBInfo returns the storage settings and sizes of the metrics database
+func (pc *PerformanceCollector) DBInfo() (*DBInfo, error) {
+	if !pc.enabled {
+		return nil, fmt.Errorf("metrics collection is disabled")
+	}
+
+	return pc.storage.DB().Info()
+}
+
+// Prune deletes the samples older than olderThan and returns the freed space
+// to the file system
+func (pc *PerformanceCollector) Prune(olderThan time.Duration) (*PruneResult, error) {
+	if !pc.enabled {
+		return nil, fmt.Errorf("metrics collection is disabled")
+	}
+
+	return pc.storage.DB().Prune(time.Now().Add(-olderThan), true)
+}
+
+// Reset deletes every sample, or only those of commandName when it isn't
+// empty
+func (pc *PerformanceCollector) Reset(commandName string) (*PruneResult, error) {
+	if !pc.enabled {
+		return nil, fmt.Errorf("metrics collection is disabled")
+	}
+
+	return pc.storage.DB().Reset(commandName)
+}
+
+// Checkpoint writes the pending samples to the database file, before it is
+// copied
+func (pc *PerformanceCollector) Checkpoint() error {
+	if !pc.enabled {
+		return fmt.Errorf("metrics collection is disabled")
+	}
+
+	return pc.storage.DB().Checkpoint()
+}
+
+// ApplyRetention deletes the samples older than retention, at most once a
+// day, and returns nil when it didn't run
+func (pc *PerformanceCollector) ApplyRetention(retention time.Duration) (*PruneResult, error) {
+	if !pc.enabled {
+		return nil, nil
+	}
+
+	return ApplyRetention(pc.storage.DB(), retention)
+}
+
 // Close closes the collector and its storage
 func (pc *PerformanceCollector) Close() error {
 	if pc.storage != nil {
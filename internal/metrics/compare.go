@@ -0,0 +1,234 @@
+package metrics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateRangeLayout is the layout of the dates of a DateRange
+const dateRangeLayout = "2006-01-02"
+
+// DegradedRatio is how many times the best P95 of a command a project's P95
+// must exceed for the project to be reported as degraded
+const DegradedRatio = 1.5
+
+// ProjectComparison holds the P95 latency of each command in each project
+type ProjectComparison struct {
+	Projects []ComparedProject   `json:"projects"` // Projects with runs, in the order compared
+	Empty    []string            `json:"empty"`    // Directories without runs
+	Commands []CommandComparison `json:"commands"` // By command name
+}
+
+// ComparedProject is a column of a ProjectComparison
+type ComparedProject struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// CommandComparison is a row of a ProjectComparison
+type CommandComparison struct {
+	CommandName string           `json:"command_name"`
+	Cells       []ComparisonCell `json:"cells"`       // One per project
+	BestP95     float64          `json:"best_p95_ms"` // Lowest P95 across projects
+}
+
+// ComparisonCell is the latency of a command in a project, Count being zero
+// when the command never ran there
+type ComparisonCell struct {
+	Count       int     `json:"count"`
+	P95Duration float64 `json:"p95_duration_ms"`
+}
+
+// Degraded reports whether the P95 of the command in the project at index is
+// more than DegradedRatio times the best one
+func (c CommandComparison) Degraded(index int) bool {
+	cell := c.Cells[index]
+	return cell.Count > 0 && cell.P95Duration > c.BestP95*DegradedRatio
+}
+
+// CompareProjects returns the P95 latency of each command run over the last
+// days in each of the project directories paths, read from storage
+func CompareProjects(storage *Storage, paths []string, days int) (*ProjectComparison, error) {
+	comparison := &ProjectComparison{}
+	stats := make(map[string][]CommandStats)
+	seen := make(map[string]bool)
+
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		if seen[absPath] {
+			continue
+		}
+		seen[absPath] = true
+
+		runs, err := storage.GetProjectCommandRuns(absPath, days)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get runs of %s: %w", absPath, err)
+		}
+		if len(runs) == 0 {
+			comparison.Empty = append(comparison.Empty, absPath)
+			continue
+		}
+		comparison.Projects = append(comparison.Projects, ComparedProject{Name: filepath.Base(absPath), Path: absPath})
+		stats[absPath] = ComputeCommandStats(runs)
+	}
+
+	rows := make(map[string]*CommandComparison)
+	for index, project := range comparison.Projects {
+		for _, stat := range stats[project.Path] {
+			row, ok := rows[stat.CommandName]
+			if !ok {
+				row = &CommandComparison{
+					CommandName: stat.CommandName,
+					Cells:       make([]ComparisonCell, len(comparison.Projects)),
+					BestP95:     stat.P95Duration,
+				}
+				rows[stat.CommandName] = row
+			}
+			row.Cells[index] = ComparisonCell{Count: stat.Count, P95Duration: stat.P95Duration}
+			if stat.P95Duration < row.BestP95 {
+				row.BestP95 = stat.P95Duration
+			}
+		}
+	}
+
+	for _, row := range rows {
+		comparison.Commands = append(comparison.Commands, *row)
+	}
+	sort.Slice(comparison.Commands, func(i, j int) bool {
+		return comparison.Commands[i].CommandName < comparison.Commands[j].CommandName
+	})
+	return comparison, nil
+}
+
+// WriteCSV writes the comparison matrix as CSV: a row per command, a column
+// of P95 milliseconds per project, left empty when the command never ran
+func (c *ProjectComparison) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"command"}
+	for _, project := range c.Projects {
+		header = append(header, project.Name)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range c.Commands {
+		record := []string{row.CommandName}
+		for _, cell := range row.Cells {
+			value := ""
+			if cell.Count > 0 {
+				value = strconv.FormatFloat(cell.P95Duration, 'f', -1, 64)
+			}
+			record = append(record, value)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// DateRange is a window of whole days, from the start of From up to but
+// excluding To
+type DateRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// ParseDateRange parses a range of local dates such as
+// "2026-01-01..2026-01-31", both days included
+func ParseDateRange(value string) (DateRange, error) {
+	first, last, ok := strings.Cut(strings.TrimSpace(value), "..")
+	if !ok {
+		return DateRange{}, fmt.Errorf("invalid date range %q: expected <date>..<date>, e.g. 2026-01-01..2026-01-31", value)
+	}
+
+	from, err := time.ParseInLocation(dateRangeLayout, first, time.Local)
+	if err != nil {
+		return DateRange{}, fmt.Errorf("invalid start date %q: expected YYYY-MM-DD", first)
+	}
+	to, err := time.ParseInLocation(dateRangeLayout, last, time.Local)
+	if err != nil {
+		return DateRange{}, fmt.Errorf("invalid end date %q: expected YYYY-MM-DD", last)
+	}
+	if to.Before(from) {
+		return DateRange{}, fmt.Errorf("invalid date range %q: ends before it starts", value)
+	}
+	return DateRange{From: from, To: to.AddDate(0, 0, 1)}, nil
+}
+
+// String formats r as ParseDateRange parses it
+func (r DateRange) String() string {
+	return r.From.Format(dateRangeLayout) + ".." + r.To.AddDate(0, 0, -1).Format(dateRangeLayout)
+}
+
+// WindowComparison holds the statistics of a command over two date ranges,
+// Count being zero when it didn't run in a range
+type WindowComparison struct {
+	CommandName string       `json:"command_name"`
+	Baseline    CommandStats `json:"baseline"`
+	Candidate   CommandStats `json:"candidate"`
+}
+
+// P95Change returns the relative change of the P95 latency from the baseline
+// to the candidate, e.g. 0.25 for 25% slower, and false when the command
+// didn't run in both ranges
+func (c WindowComparison) P95Change() (float64, bool) {
+	if c.Baseline.Count == 0 || c.Candidate.Count == 0 || c.Baseline.P95Duration == 0 {
+		return 0, false
+	}
+	return c.Candidate.P95Duration/c.Baseline.P95Duration - 1, true
+}
+
+// Regressed reports whether the P95 latency grew by more than threshold, a
+// fraction of the baseline P95
+func (c WindowComparison) Regressed(threshold float64) bool {
+	change, ok := c.P95Change()
+	return ok && change > threshold
+}
+
+// Improved reports whether the P95 latency shrank by more than threshold, a
+// fraction of the baseline P95
+func (c WindowComparison) Improved(threshold float64) bool {
+	change, ok := c.P95Change()
+	return ok && change < -threshold
+}
+
+// CompareWindows returns the statistics of each command over the baseline
+// and candidate runs, by command name
+func CompareWindows(baseline, candidate []MetricEntry) []WindowComparison {
+	rows := make(map[string]*WindowComparison)
+	row := func(command string) *WindowComparison {
+		if rows[command] == nil {
+			rows[command] = &WindowComparison{CommandName: command}
+		}
+		return rows[command]
+	}
+	for _, stat := range ComputeCommandStats(baseline) {
+		row(stat.CommandName).Baseline = stat
+	}
+	for _, stat := range ComputeCommandStats(candidate) {
+		row(stat.CommandName).Candidate = stat
+	}
+
+	comparisons := make([]WindowComparison, 0, len(rows))
+	for _, comparison := range rows {
+		comparisons = append(comparisons, *comparison)
+	}
+	sort.Slice(comparisons, func(i, j int) bool {
+		return comparisons[i].CommandName < comparisons[j].CommandName
+	})
+	return comparisons
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareProjects(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	fast, slow, idle := filepath.FromSlash("/work/fast"), filepath.FromSlash("/work/slow"), filepath.FromSlash("/work/idle")
+	record := func(project, command string, durationMs int64) {
+		require.NoError(t, storage.SaveMetric(MetricEntry{
+			Timestamp:   time.Now(),
+			ProjectPath: hashProjectPath(project),
+			ProjectName: filepath.Base(project),
+			CommandName: command,
+			DurationMs:  durationMs,
+			ToolVersion: "test",
+		}))
+	}
+	record(fast, "story list", 100)
+	record(fast, "story list", 200)
+	record(fast, "status", 50)
+	record(slow, "story list", 250)
+	record(slow, "status", 60)
+	record(slow, "interactive", 900)
+
+	comparison, err := CompareProjects(storage, []string{fast, slow, idle, fast}, 30)
+	require.NoError(t, err)
+
+	assert.Equal(t, []ComparedProject{{Name: "fast", Path: fast}, {Name: "slow", Path: slow}}, comparison.Projects)
+	assert.Equal(t, []string{idle}, comparison.Empty)
+	assert.Equal(t, []CommandComparison{
+		{CommandName: "interactive", Cells: []ComparisonCell{{}, {Count: 1, P95Duration: 900}}, BestP95: 900},
+		{CommandName: "status", Cells: []ComparisonCell{{Count: 1, P95Duration: 50}, {Count: 1, P95Duration: 60}}, BestP95: 50},
+		{CommandName: "story list", Cells: []ComparisonCell{{Count: 2, P95Duration: 200}, {Count: 1, P95Duration: 250}}, BestP95: 200},
+	}, comparison.Commands)
+
+	assert.False(t, comparison.Commands[0].Degraded(0), "no runs")
+	assert.False(t, comparison.Commands[1].Degraded(1), "20% above best")
+	comparison.Commands[2].Cells[1].P95Duration = 301
+	assert.True(t, comparison.Commands[2].Degraded(1), "more than 50% above best")
+
+	var buf bytes.Buffer
+	require.NoError(t, comparison.WriteCSV(&buf))
+	assert.Equal(t, "command,fast,slow\ninteractive,,900\nstatus,50,60\nstory list,200,301\n", buf.String())
+}
+
+func TestParseDateRange(t *testing.T) {
+	r, err := ParseDateRange("2026-01-01..2026-01-31")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), r.From)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local), r.To)
+	assert.Equal(t, "2026-01-01..2026-01-31", r.String())
+
+	r, err = ParseDateRange("2026-03-04..2026-03-04")
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, r.To.Sub(r.From))
+
+	for _, value := range []string{"", "2026-01-01", "2026-01-01..", "2026-13-01..2026-12-31", "2026-02-01..2026-01-01"} {
+		_, err := ParseDateRange(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestCompareWindows(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	record := func(day int, command string, durationMs int64) {
+		require.NoError(t, storage.SaveMetric(MetricEntry{
+			Timestamp:   time.Date(2026, 1, day, 12, 0, 0, 0, time.Local),
+			ProjectPath: "p",
+			ProjectName: "p",
+			CommandName: command,
+			DurationMs:  durationMs,
+			ToolVersion: "test",
+		}))
+	}
+	record(1, "story list", 100)
+	record(2, "story list", 200)
+	record(2, "status", 400)
+	record(3, "status", 400) // Between the two windows
+	record(5, "story list", 300)
+	record(6, "status", 100)
+	record(6, "interactive", 900)
+
+	baseline, err := ParseDateRange("2026-01-01..2026-01-02")
+	require.NoError(t, err)
+	candidate, err := ParseDateRange("2026-01-05..2026-01-06")
+	require.NoError(t, err)
+
+	baselineRuns, err := storage.GetCommandRunsBetween(baseline.From, baseline.To, "")
+	require.NoError(t, err)
+	candidateRuns, err := storage.GetCommandRunsBetween(candidate.From, candidate.To, "")
+	require.NoError(t, err)
+	comparisons := CompareWindows(baselineRuns, candidateRuns)
+	require.Len(t, comparisons, 3)
+
+	interactive, status, stories := comparisons[0], comparisons[1], comparisons[2]
+	assert.Equal(t, "interactive", interactive.CommandName)
+	assert.Zero(t, interactive.Baseline.Count)
+	_, ok := interactive.P95Change()
+	assert.False(t, ok)
+	assert.False(t, interactive.Regressed(0.1))
+
+	assert.Equal(t, 1, status.Baseline.Count)
+	change, ok := status.P95Change()
+	require.True(t, ok)
+	assert.InDelta(t, -0.75, change, 1e-9)
+	assert.True(t, status.Improved(0.1))
+
+	assert.Equal(t, 2, stories.Baseline.Count)
+	assert.Equal(t, 150.0, stories.Baseline.AvgDuration)
+	assert.Equal(t, 300.0, stories.Candidate.P95Duration)
+	assert.True(t, stories.Regressed(0.1))
+	assert.False(t, stories.Regressed(0.6))
+
+	runs, err := storage.GetCommandRunsBetween(baseline.From, candidate.To, "status")
+	require.NoError(t, err)
+	assert.Len(t, runs, 3)
+}
@@ -0,0 +1,246 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const (
+	// readPoolSize is the number of read-only connections of a MetricsDB
+	readPoolSize = 3
+
+	// busyRetries is how many times an operation is retried while SQLite
+	// reports the database busy or locked
+	busyRetries = 5
+
+	// busyRetryDelay is the wait between two retries of a busy operation
+	busyRetryDelay = 10 * time.Millisecond
+)
+
+// MetricsDB is the metrics database in WAL mode. Writes are serialized through
+// a single connection guarded by a mutex, while reads use a pool of
+// readPoolSize read-only connections, so concurrent writers and background
+// goroutines don't fail with SQLITE_BUSY.
+type MetricsDB struct {
+	mu    sync.Mutex
+	path  string
+	write *sql.DB
+	read  *sql.DB
+}
+
+// DBInfo describes the storage of the metrics database
+type DBInfo struct {
+	Path        string `json:"path"`
+	JournalMode string `json:"journal_mode"`
+	Synchronous string `json:"synchronous"`
+	AutoVacuum  string `json:"auto_vacuum"`
+	PageCount   int64  `json:"page_count"`
+	PageSize    int64  `json:"page_size"`
+	FreePages   int64  `json:"free_pages"`
+	DBSize      int64  `json:"db_size"`  // Size of the database file in bytes
+	WALSize     int64  `json:"wal_size"` // Size of the write-ahead log in bytes, 0 when checkpointed
+}
+
+var (
+	defaultDBMu sync.Mutex
+	defaultDB   *MetricsDB
+)
+
+// DefaultDBPath returns $HOME/.claude-wm/metrics/performance.db
+func DefaultDBPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".claude-wm", "metrics", "performance.db"), nil
+}
+
+// GetMetricsDB returns the metrics database at DefaultDBPath shared by the
+// process, opening it on first use
+func GetMetricsDB() (*MetricsDB, error) {
+	defaultDBMu.Lock()
+	defer defaultDBMu.Unlock()
+
+	if defaultDB != nil {
+		return defaultDB, nil
+	}
+
+	path, err := DefaultDBPath()
+	if err != nil {
+		return nil, err
+	}
+	db, err := OpenMetricsDB(path)
+	if err != nil {
+		return nil, err
+	}
+	defaultDB = db
+	return db, nil
+}
+
+// OpenMetricsDB opens the metrics database at path with
+// PRAGMA journal_mode=WAL and PRAGMA synchronous=NORMAL, creating it and its
+// schema if needed
+func OpenMetricsDB(path string) (*MetricsDB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create metrics directory: %w", err)
+	}
+
+	write, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=5000&_journal_mode=WAL&_synchronous=NORMAL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	write.SetMaxOpenConns(1)
+
+	db := &MetricsDB{path: path, write: write}
+	if _, err := db.Exec(schema); err != nil {
+		write.Close()
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	// The read-only connections need the database file, created above
+	read, err := sql.Open("sqlite3", "file:"+path+"?mode=ro&_busy_timeout=5000")
+	if err != nil {
+		write.Close()
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	read.SetMaxOpenConns(readPoolSize)
+	read.SetMaxIdleConns(readPoolSize)
+	db.read = read
+
+	return db, nil
+}
+
+// Path returns the path of the database file
+func (m *MetricsDB) Path() string {
+	return m.path
+}
+
+// Exec runs a write statement on the write connection, retrying while the
+// database is busy
+func (m *MetricsDB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var result sql.Result
+	err := retryBusy(func() error {
+		var err error
+		result, err = m.write.Exec(query, args...)
+		return err
+	})
+	return result, err
+}
+
+// Query runs a query on the read-only pool, retrying while the database is
+// busy
+func (m *MetricsDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := retryBusy(func() error {
+		var err error
+		rows, err = m.read.Query(query, args...)
+		return err
+	})
+	return rows, err
+}
+
+// QueryRow runs a query returning at most one row on the read-only pool,
+// retrying while the database is busy
+func (m *MetricsDB) QueryRow(query string, args ...interface{}) *sql.Row {
+	var row *sql.Row
+	retryBusy(func() error {
+		row = m.read.QueryRow(query, args...)
+		return row.Err()
+	})
+	return row
+}
+
+// Info returns the journal mode, vacuum settings and sizes of the database
+func (m *MetricsDB) Info() (*DBInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info := &DBInfo{Path: m.path}
+	var synchronous, autoVacuum int
+	pragmas := []struct {
+		name string
+		dest interface{}
+	}{
+		{"journal_mode", &info.JournalMode},
+		{"synchronous", &synchronous},
+		{"auto_vacuum", &autoVacuum},
+		{"page_count", &info.PageCount},
+		{"page_size", &info.PageSize},
+		{"freelist_count", &info.FreePages},
+	}
+	// PRAGMA synchronous is per connection: read it from the write connection
+	for _, pragma := range pragmas {
+		if err := m.write.QueryRow("PRAGMA " + pragma.name).Scan(pragma.dest); err != nil {
+			return nil, fmt.Errorf("failed to read PRAGMA %s: %w", pragma.name, err)
+		}
+	}
+	info.Synchronous = pragmaName(synchronous, "OFF", "NORMAL", "FULL", "EXTRA")
+	info.AutoVacuum = pragmaName(autoVacuum, "NONE", "FULL", "INCREMENTAL")
+
+	if stat, err := os.Stat(m.path); err == nil {
+		info.DBSize = stat.Size()
+	}
+	if stat, err := os.Stat(m.path + "-wal"); err == nil {
+		info.WALSize = stat.Size()
+	}
+	return info, nil
+}
+
+// Close closes the connections of the database
+func (m *MetricsDB) Close() error {
+	defaultDBMu.Lock()
+	if defaultDB == m {
+		defaultDB = nil
+	}
+	defaultDBMu.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var err error
+	if m.read != nil {
+		err = m.read.Close()
+	}
+	if closeErr := m.write.Close(); closeErr != nil {
+		err = closeErr
+	}
+	return err
+}
+
+// retryBusy runs op, retrying it up to busyRetries times every busyRetryDelay
+// while SQLite reports the database busy or locked
+func retryBusy(op func() error) error {
+	err := op()
+	for i := 0; i < busyRetries && isBusy(err); i++ {
+		time.Sleep(busyRetryDelay)
+		err = op()
+	}
+	return err
+}
+
+// isBusy reports whether err is SQLITE_BUSY or SQLITE_LOCKED
+func isBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+}
+
+// pragmaName returns the name of the value of an enumerated PRAGMA
+func pragmaName(value int, names ...string) string {
+	if value >= 0 && value < len(names) {
+		return names[value]
+	}
+	return fmt.Sprint(value)
+}
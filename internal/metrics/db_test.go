@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsDB_ConcurrentWrites(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	const writers, rows = 10, 100
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*rows)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rows; i++ {
+				errs <- storage.SaveMetric(MetricEntry{
+					Timestamp:   time.Now(),
+					ProjectPath: "project",
+					ProjectName: "project",
+					CommandName: fmt.Sprintf("writer-%d", w),
+					DurationMs:  int64(i),
+					ToolVersion: "test",
+				})
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM performance_metrics").Scan(&count))
+	assert.Equal(t, writers*rows, count)
+}
+
+func TestMetricsDB_Info(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	info, err := db.Info()
+	require.NoError(t, err)
+
+	assert.Equal(t, "wal", info.JournalMode)
+	assert.Equal(t, "NORMAL", info.Synchronous)
+	assert.Equal(t, "NONE", info.AutoVacuum)
+	assert.Positive(t, info.PageCount)
+	assert.Positive(t, info.PageSize)
+}
+
+func TestRetryBusy(t *testing.T) {
+	calls := 0
+	err := retryBusy(func() error {
+		calls++
+		return sqlite3.Error{Code: sqlite3.ErrBusy}
+	})
+
+	assert.True(t, isBusy(err))
+	assert.Equal(t, busyRetries+1, calls)
+
+	calls = 0
+	require.NoError(t, retryBusy(func() error {
+		calls++
+		if calls < 3 {
+			return sqlite3.Error{Code: sqlite3.ErrBusy}
+		}
+		return nil
+	}))
+	assert.Equal(t, 3, calls)
+}
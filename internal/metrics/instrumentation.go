@@ -171,6 +171,19 @@ func (t *Timer) ProfileClaudeExecution(command string) *StepTimer {
 	return step
 }
 
+// MetaFirstOutputLatency is the claude_execution step metadata holding how long
+// Claude took to print its first line of output
+const MetaFirstOutputLatency = "first_output_latency_ms"
+
+// RecordFirstOutputLatency records the time-to-first-line of a Claude execution step.
+// A zero latency (no output) is not recorded.
+func (s *StepTimer) RecordFirstOutputLatency(latency time.Duration) {
+	if latency <= 0 {
+		return
+	}
+	s.SetMetadata(MetaFirstOutputLatency, latency.Milliseconds())
+}
+
 // ProfileResponseProcessing profiles response processing
 func (t *Timer) ProfileResponseProcessing(responseSize int) *StepTimer {
 	step := t.ProfileStep(StepResponseProcessing)
@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// StepPercentiles is the latency distribution of a step of a command
+type StepPercentiles struct {
+	StepName string  `json:"step_name"`
+	Count    int     `json:"count"`
+	P50      float64 `json:"p50_ms"`
+	P95      float64 `json:"p95_ms"`
+	P99      float64 `json:"p99_ms"`
+	Max      float64 `json:"max_ms"`
+}
+
+// Percentile returns the p-th percentile (0 to 100) of sorted, an ascending
+// slice, using the nearest-rank method: the smallest value such that at least
+// p% of the values are less than or equal to it. It returns 0 for no values.
+func Percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// ComputeStepPercentiles returns the percentiles of the durations of each
+// step, slowest P95 first
+func ComputeStepPercentiles(durations map[string][]float64) []StepPercentiles {
+	stats := make([]StepPercentiles, 0, len(durations))
+	for step, values := range durations {
+		if len(values) == 0 {
+			continue
+		}
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		stats = append(stats, StepPercentiles{
+			StepName: step,
+			Count:    len(sorted),
+			P50:      Percentile(sorted, 50),
+			P95:      Percentile(sorted, 95),
+			P99:      Percentile(sorted, 99),
+			Max:      sorted[len(sorted)-1],
+		})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].P95 != stats[j].P95 {
+			return stats[i].P95 > stats[j].P95
+		}
+		return stats[i].StepName < stats[j].StepName
+	})
+	return stats
+}
+
+// Latency statistics commands can be ranked by
+const (
+	ByMean = "mean"
+	ByP50  = "p50"
+	ByP90  = "p90"
+	ByP95  = "p95"
+	ByP99  = "p99"
+)
+
+// LatencyStatistics lists the statistics commands can be ranked by
+var LatencyStatistics = []string{ByMean, ByP50, ByP90, ByP95, ByP99}
+
+// setPercentiles sets the P50/P90/P95/P99 durations from sorted, the
+// durations of the runs in ascending order
+func (s *CommandStats) setPercentiles(sorted []float64) {
+	s.P50Duration = Percentile(sorted, 50)
+	s.P90Duration = Percentile(sorted, 90)
+	s.P95Duration = Percentile(sorted, 95)
+	s.P99Duration = Percentile(sorted, 99)
+}
+
+// Latency returns the duration statistic named by, one of LatencyStatistics
+func (s CommandStats) Latency(by string) (float64, error) {
+	switch by {
+	case ByMean:
+		return s.AvgDuration, nil
+	case ByP50:
+		return s.P50Duration, nil
+	case ByP90:
+		return s.P90Duration, nil
+	case ByP95:
+		return s.P95Duration, nil
+	case ByP99:
+		return s.P99Duration, nil
+	}
+	return 0, fmt.Errorf("unknown latency statistic %q (valid: %s)", by, strings.Join(LatencyStatistics, ", "))
+}
+
+// runDurations groups the durations of runs by command, each in ascending
+// order
+func runDurations(runs []MetricEntry) map[string][]float64 {
+	durations := make(map[string][]float64)
+	for _, run := range runs {
+		durations[run.CommandName] = append(durations[run.CommandName], float64(run.DurationMs))
+	}
+	for _, values := range durations {
+		sort.Float64s(values)
+	}
+	return durations
+}
+
+// ComputeCommandStats aggregates runs by command, with the duration
+// percentiles of each, sorted by name
+func ComputeCommandStats(runs []MetricEntry) []CommandStats {
+	durations := runDurations(runs)
+	stats := make([]CommandStats, 0, len(durations))
+	for command, sorted := range durations {
+		total := 0.0
+		for _, value := range sorted {
+			total += value
+		}
+		stat := CommandStats{
+			CommandName: command,
+			Count:       len(sorted),
+			MinDuration: sorted[0],
+			AvgDuration: total / float64(len(sorted)),
+			MaxDuration: sorted[len(sorted)-1],
+		}
+		stat.setPercentiles(sorted)
+		stats = append(stats, stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].CommandName < stats[j].CommandName
+	})
+	return stats
+}
+
+// SlowCommands returns the commands of stats whose statistic named by is
+// above thresholdMs, slowest first
+func SlowCommands(stats []CommandStats, thresholdMs int64, by string) ([]CommandStats, error) {
+	if _, err := (CommandStats{}).Latency(by); err != nil {
+		return nil, err
+	}
+
+	var slow []CommandStats
+	for _, command := range stats {
+		if latency, _ := command.Latency(by); latency > float64(thresholdMs) {
+			slow = append(slow, command)
+		}
+	}
+
+	sort.SliceStable(slow, func(i, j int) bool {
+		a, _ := slow[i].Latency(by)
+		b, _ := slow[j].Latency(by)
+		return a > b
+	})
+	return slow, nil
+}
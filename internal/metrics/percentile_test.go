@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []float64{15, 20, 35, 40, 50}
+
+	assert.Equal(t, 15.0, Percentile(sorted, 5))
+	assert.Equal(t, 20.0, Percentile(sorted, 30))
+	assert.Equal(t, 20.0, Percentile(sorted, 40))
+	assert.Equal(t, 35.0, Percentile(sorted, 50))
+	assert.Equal(t, 50.0, Percentile(sorted, 100))
+	assert.Equal(t, 15.0, Percentile(sorted, 0))
+	assert.Equal(t, 0.0, Percentile(nil, 50))
+}
+
+func TestComputeStepPercentiles(t *testing.T) {
+	var slow []float64
+	for i := 1; i <= 100; i++ {
+		slow = append(slow, float64(i*10))
+	}
+
+	stats := ComputeStepPercentiles(map[string][]float64{
+		"fast":  {3, 1, 2},
+		"slow":  slow,
+		"empty": nil,
+	})
+
+	require.Len(t, stats, 2)
+	assert.Equal(t, StepPercentiles{StepName: "slow", Count: 100, P50: 500, P95: 950, P99: 990, Max: 1000}, stats[0])
+	assert.Equal(t, StepPercentiles{StepName: "fast", Count: 3, P50: 2, P95: 3, P99: 3, Max: 3}, stats[1])
+}
+
+func TestStorage_StepDurations(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	now := time.Now()
+	for _, command := range []string{"story list", "epic list"} {
+		id, err := storage.InsertMetric(MetricEntry{Timestamp: now, ProjectPath: "p", ProjectName: "p", CommandName: command, DurationMs: 100, ToolVersion: "test"})
+		require.NoError(t, err)
+		require.NoError(t, storage.SaveStepDuration(id, "load", 40, now))
+		require.NoError(t, storage.SaveStepDuration(id, "display", 10, now))
+	}
+	old, err := storage.InsertMetric(MetricEntry{Timestamp: now, ProjectPath: "p", ProjectName: "p", CommandName: "story list", DurationMs: 100, ToolVersion: "test"})
+	require.NoError(t, err)
+	require.NoError(t, storage.SaveStepDuration(old, "load", 900, now.AddDate(0, 0, -60)))
+
+	durations, err := storage.GetStepDurations("story list", 30)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]float64{"load": {40}, "display": {10}}, durations)
+}
+
+func TestComputeCommandStats(t *testing.T) {
+	var runs []MetricEntry
+	for i := 1; i <= 100; i++ {
+		runs = append(runs, MetricEntry{CommandName: "interactive", DurationMs: int64(i * 10)})
+	}
+	runs = append(runs, MetricEntry{CommandName: "version", DurationMs: 5})
+
+	stats := ComputeCommandStats(runs)
+	require.Len(t, stats, 2)
+	assert.Equal(t, CommandStats{CommandName: "interactive", Count: 100, MinDuration: 10, AvgDuration: 505, MaxDuration: 1000,
+		P50Duration: 500, P90Duration: 900, P95Duration: 950, P99Duration: 990}, stats[0])
+	assert.Equal(t, CommandStats{CommandName: "version", Count: 1, MinDuration: 5, AvgDuration: 5, MaxDuration: 5,
+		P50Duration: 5, P90Duration: 5, P95Duration: 5, P99Duration: 5}, stats[1])
+}
+
+func TestSlowCommands(t *testing.T) {
+	stats := []CommandStats{
+		// Mostly fast with a slow tail
+		{CommandName: "claude", AvgDuration: 800, P95Duration: 9000},
+		{CommandName: "steady", AvgDuration: 4000, P95Duration: 4500},
+		{CommandName: "fast", AvgDuration: 100, P95Duration: 200},
+	}
+
+	slow, err := SlowCommands(stats, 3000, ByMean)
+	require.NoError(t, err)
+	require.Len(t, slow, 1)
+	assert.Equal(t, "steady", slow[0].CommandName)
+
+	slow, err = SlowCommands(stats, 3000, ByP95)
+	require.NoError(t, err)
+	require.Len(t, slow, 2)
+	assert.Equal(t, "claude", slow[0].CommandName)
+	assert.Equal(t, "steady", slow[1].CommandName)
+
+	_, err = SlowCommands(nil, 3000, "p42")
+	assert.ErrorContains(t, err, `unknown latency statistic "p42"`)
+}
+
+func TestStorage_GetCommandStatsPercentiles(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	now := time.Now()
+	for i := 1; i <= 20; i++ {
+		require.NoError(t, storage.SaveMetric(MetricEntry{Timestamp: now, ProjectPath: "p", ProjectName: "p", CommandName: "interactive", DurationMs: int64(i * 100), ToolVersion: "test"}))
+	}
+	require.NoError(t, storage.SaveMetric(MetricEntry{Timestamp: now, ProjectPath: "p", ProjectName: "p", CommandName: "version", DurationMs: 50000, ToolVersion: "test"}))
+
+	stats, err := storage.GetCommandStats("interactive", 30)
+	require.NoError(t, err)
+	assert.Equal(t, 20, stats.Count)
+	assert.Equal(t, 1000.0, stats.P50Duration)
+	assert.Equal(t, 1800.0, stats.P90Duration)
+	assert.Equal(t, 1900.0, stats.P95Duration)
+	assert.Equal(t, 2000.0, stats.P99Duration)
+
+	all, err := storage.GetCommandStats("", 30)
+	require.NoError(t, err)
+	assert.Equal(t, 50000.0, all.P99Duration)
+}
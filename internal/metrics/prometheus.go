@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Names of the metrics written by WritePrometheus
+const (
+	PrometheusDurationMetric    = "claude_wm_command_duration_seconds"
+	PrometheusAvgDurationMetric = "claude_wm_command_duration_avg_seconds"
+	PrometheusErrorsMetric      = "claude_wm_command_errors_total"
+)
+
+// CommandSummary aggregates the runs of a command
+type CommandSummary struct {
+	CommandName   string  `json:"command_name"`
+	Count         int     `json:"count"`
+	Errors        int     `json:"errors"` // Runs with a non-zero exit code
+	TotalDuration float64 `json:"total_duration_ms"`
+	AvgDuration   float64 `json:"avg_duration_ms"`
+	P95Duration   float64 `json:"p95_duration_ms"`
+}
+
+// SummarizeCommands aggregates runs by command name, sorted by name
+func SummarizeCommands(runs []MetricEntry) []CommandSummary {
+	failures := make(map[string]int)
+	totals := make(map[string]float64)
+	for _, run := range runs {
+		totals[run.CommandName] += float64(run.DurationMs)
+		if run.ExitCode != 0 {
+			failures[run.CommandName]++
+		}
+	}
+
+	stats := ComputeCommandStats(runs)
+	summaries := make([]CommandSummary, len(stats))
+	for i, command := range stats {
+		summaries[i] = CommandSummary{
+			CommandName:   command.CommandName,
+			Count:         command.Count,
+			Errors:        failures[command.CommandName],
+			TotalDuration: totals[command.CommandName],
+			AvgDuration:   command.AvgDuration,
+			P95Duration:   command.P95Duration,
+		}
+	}
+	return summaries
+}
+
+// WritePrometheus writes summaries in the Prometheus text exposition format:
+// the durations as a summary with its 0.95 quantile, sum and count, the
+// average duration as a gauge and the errors as a counter, all labelled with
+// the command name. Durations are in seconds.
+func WritePrometheus(w io.Writer, summaries []CommandSummary) error {
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "# HELP %s Duration of claude-wm-cli commands.\n", PrometheusDurationMetric)
+	fmt.Fprintf(out, "# TYPE %s summary\n", PrometheusDurationMetric)
+	for _, summary := range summaries {
+		label := commandLabel(summary.CommandName)
+		fmt.Fprintf(out, "%s{%s,quantile=\"0.95\"} %s\n", PrometheusDurationMetric, label, seconds(summary.P95Duration))
+		fmt.Fprintf(out, "%s_sum{%s} %s\n", PrometheusDurationMetric, label, seconds(summary.TotalDuration))
+		fmt.Fprintf(out, "%s_count{%s} %d\n", PrometheusDurationMetric, label, summary.Count)
+	}
+
+	fmt.Fprintf(out, "# HELP %s Average duration of claude-wm-cli commands.\n", PrometheusAvgDurationMetric)
+	fmt.Fprintf(out, "# TYPE %s gauge\n", PrometheusAvgDurationMetric)
+	for _, summary := range summaries {
+		fmt.Fprintf(out, "%s{%s} %s\n", PrometheusAvgDurationMetric, commandLabel(summary.CommandName), seconds(summary.AvgDuration))
+	}
+
+	fmt.Fprintf(out, "# HELP %s Runs of claude-wm-cli commands that exited with an error.\n", PrometheusErrorsMetric)
+	fmt.Fprintf(out, "# TYPE %s counter\n", PrometheusErrorsMetric)
+	for _, summary := range summaries {
+		fmt.Fprintf(out, "%s{%s} %d\n", PrometheusErrorsMetric, commandLabel(summary.CommandName), summary.Errors)
+	}
+
+	return out.Flush()
+}
+
+// commandLabel returns the command label of a metric, escaped as the
+// exposition format requires
+func commandLabel(command string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(command)
+	return `command="` + escaped + `"`
+}
+
+// seconds formats a duration in milliseconds as seconds
+func seconds(ms float64) string {
+	return strconv.FormatFloat(ms/1000, 'g', -1, 64)
+}
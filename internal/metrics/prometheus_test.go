@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeCommands(t *testing.T) {
+	runs := []MetricEntry{
+		{CommandName: "story list", DurationMs: 300},
+		{CommandName: "interactive", DurationMs: 1000, ExitCode: 1},
+		{CommandName: "story list", DurationMs: 100},
+		{CommandName: "story list", DurationMs: 200, ExitCode: 2},
+	}
+
+	assert.Equal(t, []CommandSummary{
+		{CommandName: "interactive", Count: 1, Errors: 1, TotalDuration: 1000, AvgDuration: 1000, P95Duration: 1000},
+		{CommandName: "story list", Count: 3, Errors: 1, TotalDuration: 600, AvgDuration: 200, P95Duration: 300},
+	}, SummarizeCommands(runs))
+	assert.Empty(t, SummarizeCommands(nil))
+}
+
+func TestWritePrometheus(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WritePrometheus(&buf, []CommandSummary{
+		{CommandName: "story list", Count: 3, Errors: 1, TotalDuration: 600, AvgDuration: 200, P95Duration: 300},
+		{CommandName: `say "hi"`, Count: 1, TotalDuration: 1500, AvgDuration: 1500, P95Duration: 1500},
+	}))
+
+	assert.Equal(t, `# HELP claude_wm_command_duration_seconds Duration of claude-wm-cli commands.
+# TYPE claude_wm_command_duration_seconds summary
+claude_wm_command_duration_seconds{command="story list",quantile="0.95"} 0.3
+claude_wm_command_duration_seconds_sum{command="story list"} 0.6
+claude_wm_command_duration_seconds_count{command="story list"} 3
+claude_wm_command_duration_seconds{command="say \"hi\"",quantile="0.95"} 1.5
+claude_wm_command_duration_seconds_sum{command="say \"hi\""} 1.5
+claude_wm_command_duration_seconds_count{command="say \"hi\""} 1
+# HELP claude_wm_command_duration_avg_seconds Average duration of claude-wm-cli commands.
+# TYPE claude_wm_command_duration_avg_seconds gauge
+claude_wm_command_duration_avg_seconds{command="story list"} 0.2
+claude_wm_command_duration_avg_seconds{command="say \"hi\""} 1.5
+# HELP claude_wm_command_errors_total Runs of claude-wm-cli commands that exited with an error.
+# TYPE claude_wm_command_errors_total counter
+claude_wm_command_errors_total{command="story list"} 1
+claude_wm_command_errors_total{command="say \"hi\""} 0
+`, buf.String())
+}
+
+func TestStorage_GetCommandRuns(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	now := time.Now()
+	require.NoError(t, storage.SaveMetric(MetricEntry{Timestamp: now, ProjectPath: "p", ProjectName: "p", CommandName: "story list", DurationMs: 100, ToolVersion: "test", ExitCode: 1}))
+	require.NoError(t, storage.SaveMetric(MetricEntry{Timestamp: now, ProjectPath: "p", ProjectName: "p", CommandName: "story list", StepName: "load", DurationMs: 40, ToolVersion: "test"}))
+	require.NoError(t, storage.SaveMetric(MetricEntry{Timestamp: now.AddDate(0, 0, -60), ProjectPath: "p", ProjectName: "p", CommandName: "story list", DurationMs: 900, ToolVersion: "test"}))
+
+	runs, err := storage.GetCommandRuns(30)
+	require.NoError(t, err)
+	assert.Equal(t, []MetricEntry{{CommandName: "story list", DurationMs: 100, ExitCode: 1}}, runs)
+}
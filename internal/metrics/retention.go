@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// RetentionCheckInterval is how often ApplyRetention prunes the database
+	RetentionCheckInterval = 24 * time.Hour
+
+	// retentionStampFile is touched in the metrics directory each time
+	// ApplyRetention prunes the database
+	retentionStampFile = "last-prune"
+)
+
+// PruneResult reports what pruning the metrics database removed
+type PruneResult struct {
+	Commands       int64 `json:"commands"`        // Command samples removed
+	Steps          int64 `json:"steps"`           // Step timings removed
+	ReclaimedBytes int64 `json:"reclaimed_bytes"` // Decrease of the database and WAL sizes
+}
+
+// Removed returns the number of records removed
+func (r *PruneResult) Removed() int64 {
+	return r.Commands + r.Steps
+}
+
+// ParseRetention parses a retention window such as "30d", "12w" or "720h"
+func ParseRetention(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty retention window: expected e.g. 30d, 12w or 720h")
+	}
+
+	var window time.Duration
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid retention window %q: expected e.g. 30d, 12w or 720h", value)
+		}
+		window = time.Duration(count) * 24 * time.Hour
+		if unit == 'w' {
+			window *= 7
+		}
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid retention window %q: expected e.g. 30d, 12w or 720h", value)
+		}
+		window = parsed
+	}
+
+	if window <= 0 {
+		return 0, fmt.Errorf("invalid retention window %q: must be positive", value)
+	}
+	return window, nil
+}
+
+// Prune deletes the command samples and step timings recorded before cutoff.
+// With vacuum, the freed pages are returned to the file system; otherwise
+// SQLite reuses them for new samples.
+func (m *MetricsDB) Prune(cutoff time.Time, vacuum bool) (*PruneResult, error) {
+	before := m.size()
+	// Timestamps are stored in local time, compare them as such
+	cutoff = cutoff.Local()
+
+	steps, err := m.Exec(`
+	DELETE FROM step_durations
+	WHERE started_at < ?
+		OR command_id IN (SELECT id FROM performance_metrics WHERE timestamp < ?)
+	`, cutoff, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune step timings: %w", err)
+	}
+	commands, err := m.Exec(`DELETE FROM performance_metrics WHERE timestamp < ?`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune command samples: %w", err)
+	}
+
+	result := &PruneResult{}
+	result.Steps, _ = steps.RowsAffected()
+	result.Commands, _ = commands.RowsAffected()
+
+	if vacuum && result.Removed() > 0 {
+		if err := m.compact(); err != nil {
+			return nil, err
+		}
+	}
+
+	if reclaimed := before - m.size(); reclaimed > 0 {
+		result.ReclaimedBytes = reclaimed
+	}
+	return result, nil
+}
+
+// Reset deletes every command sample and step timing, or only those of
+// commandName when it isn't empty, and returns the freed pages to the file
+// system
+func (m *MetricsDB) Reset(commandName string) (*PruneResult, error) {
+	before := m.size()
+
+	steps, err := m.Exec(`
+	DELETE FROM step_durations
+	WHERE ? = '' OR command_id IN (SELECT id FROM performance_metrics WHERE command_name = ?)
+	`, commandName, commandName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete step timings: %w", err)
+	}
+	commands, err := m.Exec(`DELETE FROM performance_metrics WHERE ? = '' OR command_name = ?`, commandName, commandName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete command samples: %w", err)
+	}
+
+	result := &PruneResult{}
+	result.Steps, _ = steps.RowsAffected()
+	result.Commands, _ = commands.RowsAffected()
+
+	if result.Removed() > 0 {
+		if err := m.compact(); err != nil {
+			return nil, err
+		}
+	}
+
+	if reclaimed := before - m.size(); reclaimed > 0 {
+		result.ReclaimedBytes = reclaimed
+	}
+	return result, nil
+}
+
+// Checkpoint writes the WAL back into the database file, so that copying
+// the file alone captures every sample
+func (m *MetricsDB) Checkpoint() error {
+	if _, err := m.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return fmt.Errorf("failed to checkpoint database: %w", err)
+	}
+	return nil
+}
+
+// compact rebuilds the database without its free pages and truncates the WAL
+func (m *MetricsDB) compact() error {
+	if _, err := m.Exec("VACUUM"); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	return m.Checkpoint()
+}
+
+// size returns the size in bytes of the database file and its WAL
+func (m *MetricsDB) size() int64 {
+	var size int64
+	for _, path := range []string{m.path, m.path + "-wal"} {
+		if stat, err := os.Stat(path); err == nil {
+			size += stat.Size()
+		}
+	}
+	return size
+}
+
+// ApplyRetention deletes the samples of db older than retention, at most once
+// every RetentionCheckInterval. It returns nil when the database was pruned
+// less than RetentionCheckInterval ago.
+func ApplyRetention(db *MetricsDB, retention time.Duration) (*PruneResult, error) {
+	stamp := filepath.Join(filepath.Dir(db.Path()), retentionStampFile)
+	if stat, err := os.Stat(stamp); err == nil && time.Since(stat.ModTime()) < RetentionCheckInterval {
+		return nil, nil
+	}
+
+	result, err := db.Prune(time.Now().Add(-retention), false)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(stamp, []byte(time.Now().Format(time.RFC3339)+"\n"), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", stamp, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetention(t *testing.T) {
+	for value, want := range map[string]time.Duration{
+		"30d":  30 * 24 * time.Hour,
+		"2w":   14 * 24 * time.Hour,
+		"720h": 720 * time.Hour,
+	} {
+		got, err := ParseRetention(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	for _, value := range []string{"", "0d", "-1h", "thirty days", "xd"} {
+		_, err := ParseRetention(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// seedSamples records a command with a step timing at each of times
+func seedSamples(t *testing.T, storage *Storage, times ...time.Time) {
+	t.Helper()
+	for _, at := range times {
+		id, err := storage.InsertMetric(MetricEntry{Timestamp: at, ProjectPath: "p", ProjectName: "p", CommandName: "story list", DurationMs: 100, ToolVersion: "test"})
+		require.NoError(t, err)
+		require.NoError(t, storage.SaveStepDuration(id, "load", 40, at))
+	}
+}
+
+func TestMetricsDB_Prune(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	now := time.Now()
+	seedSamples(t, storage, now, now.AddDate(0, 0, -40), now.AddDate(0, 0, -400))
+
+	result, err := db.Prune(now.AddDate(0, 0, -30), true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Commands)
+	assert.Equal(t, int64(2), result.Steps)
+	assert.Equal(t, int64(4), result.Removed())
+
+	runs, err := storage.GetCommandRuns(1000)
+	require.NoError(t, err)
+	assert.Len(t, runs, 1)
+	durations, err := storage.GetStepDurations("story list", 1000)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]float64{"load": {40}}, durations)
+
+	// Nothing left to prune
+	result, err = db.Prune(now.AddDate(0, 0, -30), true)
+	require.NoError(t, err)
+	assert.Zero(t, result.Removed())
+}
+
+func TestApplyRetention(t *testing.T) {
+	dir := t.TempDir()
+	db, err := OpenMetricsDB(filepath.Join(dir, "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	now := time.Now()
+	seedSamples(t, storage, now, now.AddDate(0, 0, -100))
+
+	result, err := ApplyRetention(db, 90*24*time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, int64(1), result.Commands)
+	assert.FileExists(t, filepath.Join(dir, retentionStampFile))
+
+	// Checked less than a day ago
+	seedSamples(t, storage, now.AddDate(0, 0, -100))
+	result, err = ApplyRetention(db, 90*24*time.Hour)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+
+	stale := now.Add(-2 * RetentionCheckInterval)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, retentionStampFile), stale, stale))
+	result, err = ApplyRetention(db, 90*24*time.Hour)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, int64(1), result.Commands)
+}
+
+func TestMetricsDB_Reset(t *testing.T) {
+	db, err := OpenMetricsDB(filepath.Join(t.TempDir(), "performance.db"))
+	require.NoError(t, err)
+	defer db.Close()
+	storage := &Storage{db: db}
+
+	now := time.Now()
+	seedSamples(t, storage, now, now.AddDate(0, 0, -5))
+	_, err = storage.InsertMetric(MetricEntry{Timestamp: now, ProjectPath: "p", ProjectName: "p", CommandName: "status", DurationMs: 50, ToolVersion: "test"})
+	require.NoError(t, err)
+
+	result, err := db.Reset("story list")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), result.Commands)
+	assert.Equal(t, int64(2), result.Steps)
+
+	runs, err := storage.GetCommandRuns(1000)
+	require.NoError(t, err)
+	assert.Equal(t, []MetricEntry{{CommandName: "status", DurationMs: 50}}, runs)
+
+	result, err = db.Reset("")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.Commands)
+	runs, err = storage.GetCommandRuns(1000)
+	require.NoError(t, err)
+	assert.Empty(t, runs)
+}
@@ -4,12 +4,7 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
-	"fmt"
-	"os"
-	"path/filepath"
 	"time"
-
-	_ "github.com/mattn/go-sqlite3"
 )
 
 // MetricEntry represents a single performance metric
@@ -26,83 +21,66 @@ type MetricEntry struct {
 	ExitCode    int       `json:"exit_code"`
 }
 
+// schema is the table of the metrics database and its indexes
+const schema = `
+CREATE TABLE IF NOT EXISTS performance_metrics (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
+	project_path TEXT NOT NULL,
+	project_name TEXT NOT NULL,
+	command_name TEXT NOT NULL,
+	step_name TEXT,
+	duration_ms INTEGER NOT NULL,
+	context_data TEXT,
+	tool_version TEXT NOT NULL,
+	exit_code INTEGER DEFAULT 0,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_command_step ON performance_metrics(command_name, step_name);
+CREATE INDEX IF NOT EXISTS idx_project ON performance_metrics(project_name);
+CREATE INDEX IF NOT EXISTS idx_timestamp ON performance_metrics(timestamp);
+CREATE INDEX IF NOT EXISTS idx_duration ON performance_metrics(duration_ms);
+
+CREATE TABLE IF NOT EXISTS step_durations (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	command_id INTEGER NOT NULL REFERENCES performance_metrics(id),
+	step_name TEXT NOT NULL,
+	duration_ms INTEGER NOT NULL,
+	started_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_step_durations_command ON step_durations(command_id);
+`
+
 // Storage handles SQLite operations for metrics
 type Storage struct {
-	db       *sql.DB
-	dbPath   string
-	initOnce bool
+	db *MetricsDB
 }
 
-// NewStorage creates a new storage instance
+// NewStorage returns the storage of the metrics database shared by the
+// process, $HOME/.claude-wm/metrics/performance.db
 func NewStorage() (*Storage, error) {
-	homeDir, err := os.UserHomeDir()
+	db, err := GetMetricsDB()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
-	}
-	
-	metricsDir := filepath.Join(homeDir, ".claude-wm", "metrics")
-	if err := os.MkdirAll(metricsDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create metrics directory: %w", err)
-	}
-	
-	dbPath := filepath.Join(metricsDir, "performance.db")
-	
-	db, err := sql.Open("sqlite3", dbPath+"?_busy_timeout=5000&_journal_mode=WAL")
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
-	
-	storage := &Storage{
-		db:     db,
-		dbPath: dbPath,
-	}
-	
-	if err := storage.initialize(); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to initialize database: %w", err)
+		return nil, err
 	}
-	
-	return storage, nil
+	return &Storage{db: db}, nil
 }
 
-// initialize creates the database schema
-func (s *Storage) initialize() error {
-	if s.initOnce {
-		return nil
-	}
-	
-	schema := `
-	CREATE TABLE IF NOT EXISTS performance_metrics (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		timestamp DATETIME DEFAULT CURRENT_TIMESTAMP,
-		project_path TEXT NOT NULL,
-		project_name TEXT NOT NULL,
-		command_name TEXT NOT NULL,
-		step_name TEXT,
-		duration_ms INTEGER NOT NULL,
-		context_data TEXT,
-		tool_version TEXT NOT NULL,
-		exit_code INTEGER DEFAULT 0,
-		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-	);
-	
-	CREATE INDEX IF NOT EXISTS idx_command_step ON performance_metrics(command_name, step_name);
-	CREATE INDEX IF NOT EXISTS idx_project ON performance_metrics(project_name);
-	CREATE INDEX IF NOT EXISTS idx_timestamp ON performance_metrics(timestamp);
-	CREATE INDEX IF NOT EXISTS idx_duration ON performance_metrics(duration_ms);
-	`
-	
-	_, err := s.db.Exec(schema)
-	if err != nil {
-		return err
-	}
-	
-	s.initOnce = true
-	return nil
+// DB returns the database of the storage
+func (s *Storage) DB() *MetricsDB {
+	return s.db
 }
 
 // SaveMetric saves a single metric entry
 func (s *Storage) SaveMetric(entry MetricEntry) error {
+	_, err := s.InsertMetric(entry)
+	return err
+}
+
+// InsertMetric saves a single metric entry and returns its ID
+func (s *Storage) InsertMetric(entry MetricEntry) (int64, error) {
 	query := `
 	INSERT INTO performance_metrics (
 		timestamp, project_path, project_name, command_name, step_name,
@@ -110,7 +88,7 @@ func (s *Storage) SaveMetric(entry MetricEntry) error {
 	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	
-	_, err := s.db.Exec(query,
+	result, err := s.db.Exec(query,
 		entry.Timestamp,
 		entry.ProjectPath,
 		entry.ProjectName,
@@ -121,10 +99,50 @@ func (s *Storage) SaveMetric(entry MetricEntry) error {
 		entry.ToolVersion,
 		entry.ExitCode,
 	)
-	
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// SaveStepDuration saves the duration of a step of the command saved with ID
+// commandID
+func (s *Storage) SaveStepDuration(commandID int64, stepName string, durationMs int64, startedAt time.Time) error {
+	_, err := s.db.Exec(
+		`INSERT INTO step_durations (command_id, step_name, duration_ms, started_at) VALUES (?, ?, ?, ?)`,
+		commandID, stepName, durationMs, startedAt)
 	return err
 }
 
+// GetStepDurations returns the recorded durations in milliseconds of each
+// step of commandName over the last days
+func (s *Storage) GetStepDurations(commandName string, days int) (map[string][]float64, error) {
+	query := `
+	SELECT sd.step_name, sd.duration_ms
+	FROM step_durations sd
+	JOIN performance_metrics pm ON pm.id = sd.command_id
+	WHERE pm.command_name = ?
+		AND sd.started_at >= datetime('now', '-' || ? || ' days')
+	`
+
+	rows, err := s.db.Query(query, commandName, days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	durations := make(map[string][]float64)
+	for rows.Next() {
+		var step string
+		var durationMs float64
+		if err := rows.Scan(&step, &durationMs); err != nil {
+			return nil, err
+		}
+		durations[step] = append(durations[step], durationMs)
+	}
+	return durations, rows.Err()
+}
+
 // GetCommandStats returns statistics for a specific command
 func (s *Storage) GetCommandStats(commandName string, days int) (*CommandStats, error) {
 	var query string
@@ -184,28 +202,13 @@ func (s *Storage) GetCommandStats(commandName string, days int) (*CommandStats,
 		stats.MaxDuration = maxDuration.Float64
 	}
 	
-	// Calculate P95 separately (SQLite doesn't support PERCENTILE_CONT)
+	// Calculate the percentiles separately (SQLite doesn't support PERCENTILE_CONT)
 	if stats.Count > 0 {
-		p95Query := `
-		SELECT duration_ms FROM performance_metrics 
-		WHERE command_name = ? 
-			AND step_name = ''
-			AND timestamp >= datetime('now', '-' || ? || ' days')
-		ORDER BY duration_ms 
-		LIMIT 1 OFFSET (SELECT COUNT(*) * 95 / 100 FROM performance_metrics 
-			WHERE command_name = ? 
-				AND step_name = ''
-				AND timestamp >= datetime('now', '-' || ? || ' days'))
-		`
-		
-		var p95Duration sql.NullFloat64
-		if commandName != "" {
-			s.db.QueryRow(p95Query, commandName, days, commandName, days).Scan(&p95Duration)
-		}
-		
-		if p95Duration.Valid {
-			stats.P95Duration = p95Duration.Float64
+		durations, err := s.GetCommandDurations(commandName, days)
+		if err != nil {
+			return nil, err
 		}
+		stats.setPercentiles(durations)
 	}
 	
 	stats.CommandName = commandName
@@ -255,46 +258,152 @@ func (s *Storage) GetStepStats(commandName string, days int) ([]StepStats, error
 	return stats, nil
 }
 
-// GetSlowCommands returns the slowest commands
-func (s *Storage) GetSlowCommands(thresholdMs int64, days int) ([]CommandStats, error) {
+// GetCommandDurations returns the durations in milliseconds of the runs of
+// commandName, or of all commands when it is empty, over the last days, in
+// ascending order
+func (s *Storage) GetCommandDurations(commandName string, days int) ([]float64, error) {
 	query := `
-	SELECT 
-		command_name,
-		COUNT(*) as count,
-		MIN(duration_ms) as min_duration,
-		AVG(duration_ms) as avg_duration,
-		MAX(duration_ms) as max_duration
-	FROM performance_metrics 
+	SELECT duration_ms
+	FROM performance_metrics
+	WHERE (? = '' OR command_name = ?)
+		AND step_name = ''
+		AND timestamp >= datetime('now', '-' || ? || ' days')
+	ORDER BY duration_ms
+	`
+
+	rows, err := s.db.Query(query, commandName, commandName, days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var durations []float64
+	for rows.Next() {
+		var durationMs float64
+		if err := rows.Scan(&durationMs); err != nil {
+			return nil, err
+		}
+		durations = append(durations, durationMs)
+	}
+	return durations, rows.Err()
+}
+
+// GetRecentCommands returns the last limit commands run in projectPath,
+// most recent first, or in any project when projectPath is empty
+func (s *Storage) GetRecentCommands(projectPath string, limit int) ([]MetricEntry, error) {
+	query := `
+	SELECT timestamp, project_name, command_name, duration_ms, exit_code
+	FROM performance_metrics
+	WHERE step_name = ''
+		AND (? = '' OR project_path = ?)
+	ORDER BY timestamp DESC
+	LIMIT ?
+	`
+
+	hashed := ""
+	if projectPath != "" {
+		hashed = hashProjectPath(projectPath)
+	}
+	rows, err := s.db.Query(query, hashed, hashed, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []MetricEntry
+	for rows.Next() {
+		entry := MetricEntry{ProjectPath: hashed}
+		if err := rows.Scan(&entry.Timestamp, &entry.ProjectName, &entry.CommandName, &entry.DurationMs, &entry.ExitCode); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// GetCommandRuns returns the name, duration and exit code of each command run
+// over the last days
+func (s *Storage) GetCommandRuns(days int) ([]MetricEntry, error) {
+	query := `
+	SELECT command_name, duration_ms, exit_code
+	FROM performance_metrics
 	WHERE step_name = ''
-		AND avg_duration > ?
 		AND timestamp >= datetime('now', '-' || ? || ' days')
-	GROUP BY command_name
-	ORDER BY avg_duration DESC
 	`
-	
-	rows, err := s.db.Query(query, thresholdMs, days)
+
+	rows, err := s.db.Query(query, days)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	
-	var commands []CommandStats
+
+	var entries []MetricEntry
 	for rows.Next() {
-		var cmd CommandStats
-		err := rows.Scan(
-			&cmd.CommandName,
-			&cmd.Count,
-			&cmd.MinDuration,
-			&cmd.AvgDuration,
-			&cmd.MaxDuration,
-		)
-		if err != nil {
+		var entry MetricEntry
+		if err := rows.Scan(&entry.CommandName, &entry.DurationMs, &entry.ExitCode); err != nil {
 			return nil, err
 		}
-		commands = append(commands, cmd)
+		entries = append(entries, entry)
 	}
-	
-	return commands, nil
+	return entries, rows.Err()
+}
+
+// GetCommandRunsBetween returns the name, duration and exit code of each run
+// of commandName, or of any command when empty, from from up to but
+// excluding to
+func (s *Storage) GetCommandRunsBetween(from, to time.Time, commandName string) ([]MetricEntry, error) {
+	query := `
+	SELECT command_name, duration_ms, exit_code
+	FROM performance_metrics
+	WHERE step_name = ''
+		AND (? = '' OR command_name = ?)
+		AND timestamp >= ? AND timestamp < ?
+	`
+
+	// Timestamps are stored in local time, compare them as such
+	rows, err := s.db.Query(query, commandName, commandName, from.Local(), to.Local())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []MetricEntry
+	for rows.Next() {
+		var entry MetricEntry
+		if err := rows.Scan(&entry.CommandName, &entry.DurationMs, &entry.ExitCode); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// GetProjectCommandRuns returns the name, duration and exit code of each
+// command run in projectPath over the last days
+func (s *Storage) GetProjectCommandRuns(projectPath string, days int) ([]MetricEntry, error) {
+	query := `
+	SELECT command_name, duration_ms, exit_code
+	FROM performance_metrics
+	WHERE step_name = ''
+		AND project_path = ?
+		AND timestamp >= datetime('now', '-' || ? || ' days')
+	`
+
+	rows, err := s.db.Query(query, hashProjectPath(projectPath), days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []MetricEntry
+	for rows.Next() {
+		var entry MetricEntry
+		if err := rows.Scan(&entry.CommandName, &entry.DurationMs, &entry.ExitCode); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
 }
 
 // GetProjectComparison returns performance comparison across projects
@@ -351,7 +460,10 @@ type CommandStats struct {
 	MinDuration float64 `json:"min_duration_ms"`
 	AvgDuration float64 `json:"avg_duration_ms"`
 	MaxDuration float64 `json:"max_duration_ms"`
+	P50Duration float64 `json:"p50_duration_ms"`
+	P90Duration float64 `json:"p90_duration_ms"`
 	P95Duration float64 `json:"p95_duration_ms"`
+	P99Duration float64 `json:"p99_duration_ms"`
 }
 
 type StepStats struct {
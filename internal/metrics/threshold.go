@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"claude-wm-cli/internal/theme"
+)
+
+// ThresholdLevel indicates how a command duration compares to its thresholds
+type ThresholdLevel int
+
+const (
+	ThresholdOK ThresholdLevel = iota
+	ThresholdWarn
+	ThresholdError
+)
+
+// String returns the display name of the threshold level
+func (l ThresholdLevel) String() string {
+	switch l {
+	case ThresholdWarn:
+		return "WARN"
+	case ThresholdError:
+		return "ERROR"
+	default:
+		return "OK"
+	}
+}
+
+// CommandThreshold holds the warn/error limits for a single command
+type CommandThreshold struct {
+	WarnMs  int64 `json:"warn_ms"`
+	ErrorMs int64 `json:"error_ms"`
+}
+
+// ThresholdConfig is the MetricsThreshold block of .claude-wm/config.json,
+// keyed by command name (e.g. thresholds.interactive.warn_ms)
+type ThresholdConfig struct {
+	Thresholds map[string]CommandThreshold `json:"thresholds"`
+}
+
+// LoadThresholdConfig reads the thresholds block from <projectPath>/.claude-wm/config.json.
+// A missing file yields an empty configuration.
+func LoadThresholdConfig(projectPath string) (*ThresholdConfig, error) {
+	cfg := &ThresholdConfig{Thresholds: make(map[string]CommandThreshold)}
+
+	data, err := os.ReadFile(filepath.Join(projectPath, ".claude-wm", "config.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse thresholds: %w", err)
+	}
+	if cfg.Thresholds == nil {
+		cfg.Thresholds = make(map[string]CommandThreshold)
+	}
+
+	return cfg, nil
+}
+
+// CheckThreshold compares the elapsed time of a command against its configured
+// thresholds and returns the breached level with a human readable message.
+func CheckThreshold(command string, elapsed time.Duration, cfg *ThresholdConfig) (level ThresholdLevel, msg string) {
+	if cfg == nil {
+		return ThresholdOK, ""
+	}
+
+	threshold, exists := cfg.Thresholds[command]
+	if !exists {
+		return ThresholdOK, ""
+	}
+
+	elapsedMs := elapsed.Milliseconds()
+	switch {
+	case threshold.ErrorMs > 0 && elapsedMs >= threshold.ErrorMs:
+		return ThresholdError, fmt.Sprintf("command %q took %dms (error threshold %dms)", command, elapsedMs, threshold.ErrorMs)
+	case threshold.WarnMs > 0 && elapsedMs >= threshold.WarnMs:
+		return ThresholdWarn, fmt.Sprintf("command %q took %dms (warn threshold %dms)", command, elapsedMs, threshold.WarnMs)
+	default:
+		return ThresholdOK, ""
+	}
+}
+
+// InferThreshold derives thresholds from a command's historical baseline:
+// warn at P95 and error at twice P95.
+func InferThreshold(stats *CommandStats) CommandThreshold {
+	if stats == nil || stats.P95Duration <= 0 {
+		return CommandThreshold{}
+	}
+	p95 := int64(stats.P95Duration)
+	return CommandThreshold{WarnMs: p95, ErrorMs: 2 * p95}
+}
+
+// reportThreshold checks the timer against the project thresholds and prints
+// a coloured alert to stderr when a threshold is breached
+func (t *Timer) reportThreshold() {
+	cfg, err := LoadThresholdConfig(t.projectPath)
+	if err != nil {
+		return
+	}
+
+	level, msg := CheckThreshold(t.commandName, t.Duration(), cfg)
+	switch level {
+	case ThresholdWarn:
+		fmt.Fprintln(os.Stderr, theme.Text(theme.Warning("⚠️  Performance warning: "+msg)))
+	case ThresholdError:
+		fmt.Fprintln(os.Stderr, theme.Text(theme.Error("🔴 Performance alert: "+msg)))
+	}
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckThreshold(t *testing.T) {
+	cfg := &ThresholdConfig{Thresholds: map[string]CommandThreshold{
+		"interactive": {WarnMs: 2000, ErrorMs: 5000},
+	}}
+
+	tests := []struct {
+		name    string
+		command string
+		elapsed time.Duration
+		want    ThresholdLevel
+	}{
+		{"below warn", "interactive", 1500 * time.Millisecond, ThresholdOK},
+		{"at warn", "interactive", 2000 * time.Millisecond, ThresholdWarn},
+		{"above error", "interactive", 6 * time.Second, ThresholdError},
+		{"unknown command", "status", time.Hour, ThresholdOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, msg := CheckThreshold(tt.command, tt.elapsed, cfg)
+			assert.Equal(t, tt.want, level)
+			if tt.want == ThresholdOK {
+				assert.Empty(t, msg)
+			} else {
+				assert.Contains(t, msg, tt.command)
+			}
+		})
+	}
+}
+
+func TestCheckThreshold_NilConfig(t *testing.T) {
+	level, msg := CheckThreshold("interactive", time.Hour, nil)
+	assert.Equal(t, ThresholdOK, level)
+	assert.Empty(t, msg)
+}
+
+func TestLoadThresholdConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := LoadThresholdConfig(dir)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Thresholds)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude-wm"), 0755))
+	content := `{"thresholds": {"interactive": {"warn_ms": 2000, "error_ms": 5000}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude-wm", "config.json"), []byte(content), 0644))
+
+	cfg, err = LoadThresholdConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, CommandThreshold{WarnMs: 2000, ErrorMs: 5000}, cfg.Thresholds["interactive"])
+}
+
+func TestInferThreshold(t *testing.T) {
+	assert.Equal(t, CommandThreshold{}, InferThreshold(nil))
+	assert.Equal(t, CommandThreshold{WarnMs: 1200, ErrorMs: 2400}, InferThreshold(&CommandStats{P95Duration: 1200}))
+}
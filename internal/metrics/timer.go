@@ -88,6 +88,9 @@ func (s *StepTimer) SetMetadata(key string, value interface{}) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	
+	if s.metadata == nil {
+		s.metadata = make(map[string]interface{})
+	}
 	s.metadata[key] = value
 }
 
@@ -127,6 +130,11 @@ func (t *Timer) Stop() {
 	
 	// Save metrics synchronously to ensure data is persisted before process exits
 	t.saveMetrics()
+
+	// Alert when the command breached its configured thresholds
+	if t.collector != nil {
+		t.reportThreshold()
+	}
 }
 
 // Duration returns the total duration of the command
@@ -151,7 +159,7 @@ func (t *Timer) saveMetrics() {
 	
 	// Save main command metric
 	contextJSON, _ := json.Marshal(t.contextData)
-	err := t.collector.storage.SaveMetric(MetricEntry{
+	commandID, err := t.collector.storage.InsertMetric(MetricEntry{
 		Timestamp:    t.startTime,
 		ProjectPath:  hashProjectPath(t.projectPath),
 		ProjectName:  t.projectName,
@@ -190,6 +198,9 @@ func (t *Timer) saveMetrics() {
 				ExitCode:     stepExitCode,
 			})
 			
+			if err == nil && commandID != 0 {
+				err = t.collector.storage.SaveStepDuration(commandID, step.stepName, int64(step.Duration().Milliseconds()), step.startTime)
+			}
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: Failed to save step metric: %v\n", err)
 			}
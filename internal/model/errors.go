@@ -5,6 +5,8 @@ package model
 import (
 	"fmt"
 	"strings"
+
+	"claude-wm-cli/internal/errors"
 )
 
 // ErrorCode represents standardized error codes similar to HTTP status codes.
@@ -129,18 +131,26 @@ func (e CLIError) IsApplicationError() bool {
 	return e.Code >= 6000 && e.Code < 7000
 }
 
-// ExitCode returns the appropriate exit code for CLI applications.
+// ExitCode returns the exit code of the error, one of the errors.ExitCode values.
 func (e CLIError) ExitCode() int {
-	switch {
-	case e.IsClientError():
-		return 2 // Client error
-	case e.IsServerError():
-		return 3 // Server error
-	case e.IsApplicationError():
-		return 4 // Application error
-	default:
-		return 1 // General error
+	switch e.Code {
+	case ErrCodeValidation, ErrCodeConfigurationError:
+		return int(errors.ExitValidationFailed)
+	case ErrCodeNotFound:
+		return int(errors.ExitNotFound)
+	case ErrCodeUnauthorized, ErrCodeForbidden:
+		return int(errors.ExitPermissionDenied)
+	case ErrCodeConflict, ErrCodeLocked, ErrCodeWorkflowViolation:
+		return int(errors.ExitBlocked)
+	case ErrCodeTimeout:
+		return int(errors.ExitTimeout)
+	case ErrCodeBadGateway, ErrCodeServiceUnavailable, ErrCodeGitHubError:
+		return int(errors.ExitNetwork)
+	}
+	if e.IsClientError() {
+		return int(errors.ExitUsage)
 	}
+	return int(errors.ExitGeneric)
 }
 
 // Error constructors for common error patterns
@@ -302,6 +312,8 @@ type ErrorHandler interface {
 }
 
 // ExitCodes defines standard exit codes for CLI applications.
+//
+// Deprecated: use the errors.ExitCode values, which CLIError.ExitCode returns.
 var ExitCodes = struct {
 	Success        int
 	GeneralError   int
@@ -309,9 +321,9 @@ var ExitCodes = struct {
 	ServerError    int
 	ApplicationError int
 }{
-	Success:         0,
-	GeneralError:    1,
-	ClientError:     2,
-	ServerError:     3,
-	ApplicationError: 4,
+	Success:         int(errors.ExitSuccess),
+	GeneralError:    int(errors.ExitGeneric),
+	ClientError:     int(errors.ExitUsage),
+	ServerError:     int(errors.ExitGeneric),
+	ApplicationError: int(errors.ExitGeneric),
 }
\ No newline at end of file
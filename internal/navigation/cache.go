@@ -0,0 +1,103 @@
+package navigation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// contextCacheVersion is bumped whenever ProjectContext changes shape, so that
+// stale caches written by older binaries are ignored
+const contextCacheVersion = 1
+
+// ContextCacheFile is the context cache, relative to the project root
+const ContextCacheFile = ".claude-wm/cache/context.json"
+
+// contextTrackedPaths are the files and directories DetectContext reads. Any
+// change to their modification time or size invalidates the cache.
+var contextTrackedPaths = []string{
+	"docs",
+	"docs/1-project",
+	"docs/1-project/epics.json",
+	"docs/2-current-epic",
+	"docs/2-current-epic/current-epic.json",
+	"docs/2-current-epic/current-story.json",
+	"docs/2-current-epic/stories.json",
+	"docs/3-current-task",
+	"docs/3-current-task/current-task.json",
+}
+
+// contextCache is the on-disk format of the context cache
+type contextCache struct {
+	Version   int             `json:"version"`
+	Signature string          `json:"signature"`
+	Context   *ProjectContext `json:"context"`
+}
+
+// contextSignature returns a cheap fingerprint of the tracked state files,
+// made of their modification times and sizes
+func (cd *ContextDetector) contextSignature() string {
+	parts := make([]string, 0, len(contextTrackedPaths))
+	for _, rel := range contextTrackedPaths {
+		info, err := os.Stat(filepath.Join(cd.projectPath, rel))
+		if err != nil {
+			parts = append(parts, rel+":-")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s:%d:%d", rel, info.ModTime().UnixNano(), info.Size()))
+	}
+	return strings.Join(parts, "|")
+}
+
+// loadCachedContext returns the cached context if it was saved with signature,
+// nil otherwise
+func (cd *ContextDetector) loadCachedContext(signature string) *ProjectContext {
+	data, err := os.ReadFile(filepath.Join(cd.projectPath, ContextCacheFile))
+	if err != nil {
+		return nil
+	}
+
+	var cache contextCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil
+	}
+	if cache.Version != contextCacheVersion || cache.Signature != signature || cache.Context == nil {
+		return nil
+	}
+	if cache.Context.ProjectPath != cd.projectPath {
+		return nil
+	}
+	return cache.Context
+}
+
+// saveCachedContext writes ctx to the cache. The cache is only written in
+// projects that already have a .claude-wm directory, and failures are ignored
+// since the cache is only an optimization.
+func (cd *ContextDetector) saveCachedContext(signature string, ctx *ProjectContext) {
+	if !cd.pathExists(filepath.Join(cd.projectPath, ".claude-wm")) {
+		return
+	}
+
+	data, err := json.MarshalIndent(contextCache{
+		Version:   contextCacheVersion,
+		Signature: signature,
+		Context:   ctx,
+	}, "", "  ")
+	if err != nil {
+		return
+	}
+
+	cachePath := filepath.Join(cd.projectPath, ContextCacheFile)
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		return
+	}
+	tmpPath := cachePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return
+	}
+	if err := os.Rename(tmpPath, cachePath); err != nil {
+		os.Remove(tmpPath)
+	}
+}
@@ -0,0 +1,60 @@
+package navigation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextDetector_Cache(t *testing.T) {
+	tempDir := t.TempDir()
+	createProjectStructure(t, tempDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(tempDir, ".claude-wm"), 0755))
+
+	detector := NewContextDetector(tempDir).EnableCache(true)
+	ctx, err := detector.DetectContext()
+	require.NoError(t, err)
+	assert.Equal(t, StateProjectInitialized, ctx.State)
+	assert.FileExists(t, filepath.Join(tempDir, ContextCacheFile))
+
+	t.Run("reused while unchanged", func(t *testing.T) {
+		// Tamper with the cache to tell a cached result from a fresh one
+		signature := detector.contextSignature()
+		ctx.Issues = []string{"from cache"}
+		detector.saveCachedContext(signature, ctx)
+
+		cached, err := detector.DetectContext()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"from cache"}, cached.Issues)
+		assert.Equal(t, StateProjectInitialized, cached.State)
+
+		fresh, err := NewContextDetector(tempDir).DetectContext()
+		require.NoError(t, err)
+		assert.Empty(t, fresh.Issues)
+	})
+
+	t.Run("invalidated when a tracked file changes", func(t *testing.T) {
+		createEpicsFile(t, tempDir, false)
+		epicsPath := filepath.Join(tempDir, "docs/1-project/epics.json")
+		future := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(epicsPath, future, future))
+
+		ctx, err := detector.DetectContext()
+		require.NoError(t, err)
+		assert.Equal(t, StateHasEpics, ctx.State)
+		assert.Empty(t, ctx.Issues)
+	})
+}
+
+func TestContextDetector_CacheNotWrittenOutsideWorkspace(t *testing.T) {
+	tempDir := t.TempDir()
+	createProjectStructure(t, tempDir)
+
+	_, err := NewContextDetector(tempDir).EnableCache(true).DetectContext()
+	require.NoError(t, err)
+	assert.NoFileExists(t, filepath.Join(tempDir, ContextCacheFile))
+}
@@ -2,6 +2,7 @@ package navigation
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -90,6 +91,7 @@ type TaskContext struct {
 // ContextDetector is responsible for analyzing project state
 type ContextDetector struct {
 	projectPath string
+	useCache    bool
 }
 
 // NewContextDetector creates a new context detector for the given project path
@@ -99,20 +101,58 @@ func NewContextDetector(projectPath string) *ContextDetector {
 	}
 }
 
-// DetectContext analyzes the current project state and returns context information
+// EnableCache makes DetectContext reuse the context cached under
+// .claude-wm/cache as long as none of the tracked state files has changed
+func (cd *ContextDetector) EnableCache(enabled bool) *ContextDetector {
+	cd.useCache = enabled
+	return cd
+}
+
+// DetectContext analyzes the current project state and returns context
+// information. The state comes from the first matching rule of
+// .claude-wm/context-rules.json when the project has one, from the built-in
+// layout checks otherwise.
 func (cd *ContextDetector) DetectContext() (*ProjectContext, error) {
+	// Custom rules can test any file, so their result isn't cached
+	if !cd.useCache || cd.pathExists(filepath.Join(cd.projectPath, ContextRulesFile)) {
+		return cd.detectContext()
+	}
+
+	signature := cd.contextSignature()
+	if ctx := cd.loadCachedContext(signature); ctx != nil {
+		return ctx, nil
+	}
+
+	ctx, err := cd.detectContext()
+	if err != nil {
+		return nil, err
+	}
+	cd.saveCachedContext(signature, ctx)
+	return ctx, nil
+}
+
+// detectContext scans the project files to build the context
+func (cd *ContextDetector) detectContext() (*ProjectContext, error) {
 	ctx := &ProjectContext{
 		ProjectPath:      cd.projectPath,
 		AvailableActions: []string{},
 		Issues:           []string{},
 	}
 
+	rules, err := LoadContextRules(cd.projectPath)
+	if err != nil {
+		ctx.Issues = append(ctx.Issues, fmt.Sprintf("Ignoring custom context rules: %v", err))
+	}
+
 	// Check if docs directory exists
 	docsPath := filepath.Join(cd.projectPath, "docs")
 	if !cd.pathExists(docsPath) {
 		ctx.State = StateNotInitialized
 		ctx.AvailableActions = append(ctx.AvailableActions, "init-project")
-		return ctx, nil
+		if len(rules) == 0 {
+			return ctx, nil
+		}
+		return cd.applyContextRules(ctx, rules)
 	}
 
 	// Check project structure
@@ -124,6 +164,9 @@ func (cd *ContextDetector) DetectContext() (*ProjectContext, error) {
 	if err := cd.detectCurrentState(ctx); err != nil {
 		return nil, fmt.Errorf("failed to detect current state: %w", err)
 	}
+	if len(rules) > 0 {
+		return cd.applyContextRules(ctx, rules)
+	}
 
 	// Determine available actions based on current state
 	cd.determineAvailableActions(ctx)
@@ -131,6 +174,21 @@ func (cd *ContextDetector) DetectContext() (*ProjectContext, error) {
 	return ctx, nil
 }
 
+// applyContextRules replaces the state detected from the built-in layout by
+// the state of the first matching custom rule, keeping it when none matches
+func (cd *ContextDetector) applyContextRules(ctx *ProjectContext, rules []ContextRule) (*ProjectContext, error) {
+	state, err := EvaluateRules(rules, cd.projectPath)
+	switch {
+	case err == nil:
+		ctx.State = state
+	case !errors.Is(err, ErrNoRuleMatched):
+		ctx.Issues = append(ctx.Issues, fmt.Sprintf("Ignoring custom context rules: %v", err))
+	}
+
+	cd.determineAvailableActions(ctx)
+	return ctx, nil
+}
+
 // pathExists checks if a path exists
 func (cd *ContextDetector) pathExists(path string) bool {
 	_, err := os.Stat(path)
@@ -5,17 +5,20 @@ import (
 	"math"
 	"strings"
 	"time"
+
+	"claude-wm-cli/internal/theme"
 )
 
 // ProjectStateDisplay handles the visual representation of project state
 type ProjectStateDisplay struct {
-	width int // Terminal width for formatting
+	width   int  // Terminal width for formatting
+	compact bool // Always use the compact layout
 }
 
 // NewProjectStateDisplay creates a new project state display
 func NewProjectStateDisplay() *ProjectStateDisplay {
 	return &ProjectStateDisplay{
-		width: 80, // Default terminal width
+		width: DefaultDisplayWidth,
 	}
 }
 
@@ -24,6 +27,20 @@ func (psd *ProjectStateDisplay) SetWidth(width int) {
 	psd.width = width
 }
 
+// AutoDetectWidth sets the display width to the terminal width, read from
+// the COLUMNS environment variable or the terminal on stdout, falling back to
+// DefaultDisplayWidth, and returns it
+func (psd *ProjectStateDisplay) AutoDetectWidth() int {
+	psd.width = detectTerminalWidth()
+	return psd.width
+}
+
+// SetCompactMode forces the compact single-column layout whatever the width,
+// and cuts the quick status to the display width
+func (psd *ProjectStateDisplay) SetCompactMode(compact bool) {
+	psd.compact = compact
+}
+
 // DisplayProjectOverview shows a comprehensive overview of the project state
 func (psd *ProjectStateDisplay) DisplayProjectOverview(ctx *ProjectContext) {
 	psd.displayCompactHeader(ctx)
@@ -33,66 +50,14 @@ func (psd *ProjectStateDisplay) DisplayProjectOverview(ctx *ProjectContext) {
 	}
 }
 
-// displayCompactHeader shows a compact overview of the project state
+// displayCompactHeader shows a compact overview of the project state, laid
+// out for the display width
 func (psd *ProjectStateDisplay) displayCompactHeader(ctx *ProjectContext) {
-	fmt.Println()
-	
-	// Top separator with title
-	projectName := psd.getProjectName(ctx)
-	title := fmt.Sprintf("  🚀 %s - %s  ", projectName, ctx.State.String())
-	separatorWidth := 65
-	titleWidth := len(title)
-	leftPadding := (separatorWidth - titleWidth) / 2
-	
-	fmt.Print("═")
-	for i := 0; i < leftPadding; i++ {
-		fmt.Print("═")
-	}
-	fmt.Print(title)
-	for i := leftPadding + titleWidth; i < separatorWidth; i++ {
-		fmt.Print("═")
-	}
-	fmt.Println()
-	
-	// Project path
-	if ctx.ProjectPath != "" {
-		fmt.Printf("📂 Project Path: %s\n", ctx.ProjectPath)
+	theme.Println()
+	for _, line := range psd.overviewLines(ctx, time.Now()) {
+		theme.Println(line)
 	}
-	
-	// Epic status
-	if ctx.CurrentEpic != nil {
-		epic := ctx.CurrentEpic
-		statusIcon := psd.getStatusIcon(epic.Status)
-		priorityIcon := psd.getPriorityIcon(epic.Priority)
-		fmt.Printf("📚 Current epic status: %s %s %s (%d/%d stories)\n", 
-			statusIcon, priorityIcon, epic.Title, epic.CompletedStories, epic.TotalStories)
-	} else {
-		fmt.Printf("📚 Current epic status: No active epic\n")
-	}
-	
-	// Story status  
-	if ctx.CurrentStory != nil {
-		story := ctx.CurrentStory
-		statusIcon := psd.getStatusIcon(story.Status)
-		priorityIcon := psd.getPriorityIcon(story.Priority)
-		fmt.Printf("📖 Current story status: %s %s %s (%d/%d tasks)\n", 
-			statusIcon, priorityIcon, story.Title, story.CompletedTasks, story.TotalTasks)
-	} else if ctx.State >= StateStoryInProgress {
-		fmt.Printf("📖 Current story status: No active story\n")
-	}
-	
-	// Current step
-	fmt.Printf("📍 Current step: %s\n", ctx.State.String())
-	
-	// Timestamp
-	fmt.Printf("🕐 Last updated: %s\n", time.Now().Format("15:04:05"))
-	
-	// Bottom separator
-	for i := 0; i < separatorWidth; i++ {
-		fmt.Print("═")
-	}
-	fmt.Println()
-	fmt.Println()
+	theme.Println()
 }
 
 // displayIssues shows any project issues or warnings
@@ -101,15 +66,15 @@ func (psd *ProjectStateDisplay) displayIssues(ctx *ProjectContext) {
 		return
 	}
 
-	fmt.Printf("⚠️  Issues (%d):\n", len(ctx.Issues))
+	theme.Printf("⚠️  Issues (%d):\n", len(ctx.Issues))
 	for i, issue := range ctx.Issues {
 		if i >= 5 { // Limit to first 5 issues
-			fmt.Printf("   ... and %d more\n", len(ctx.Issues)-5)
+			theme.Printf("   ... and %d more\n", len(ctx.Issues)-5)
 			break
 		}
-		fmt.Printf("   • %s\n", issue)
+		theme.Printf("   • %s\n", issue)
 	}
-	fmt.Println()
+	theme.Println()
 }
 
 // Helper functions for visual formatting
@@ -136,19 +101,19 @@ func (psd *ProjectStateDisplay) createProgressBar(progress float64, width int) s
 func (psd *ProjectStateDisplay) getStateIcon(state WorkflowState) string {
 	switch state {
 	case StateNotInitialized:
-		return "🆕 "
+		return theme.Icon("🆕 ", "[new] ")
 	case StateProjectInitialized:
-		return "📁 "
+		return theme.Icon("📁 ", "[project] ")
 	case StateHasEpics:
-		return "📚 "
+		return theme.Icon("📚 ", "[epics] ")
 	case StateEpicInProgress:
-		return "🚧 "
+		return theme.Icon("🚧 ", "[epic] ")
 	case StateStoryInProgress:
-		return "📖 "
+		return theme.Icon("📖 ", "[story] ")
 	case StateTaskInProgress:
-		return "⚡ "
+		return theme.Icon("⚡ ", "[task] ")
 	default:
-		return "❓ "
+		return theme.Icon("❓ ", "[?] ")
 	}
 }
 
@@ -157,17 +122,17 @@ func (psd *ProjectStateDisplay) getStatusIcon(status string) string {
 	status = strings.ToLower(status)
 	switch {
 	case strings.Contains(status, "completed") || strings.Contains(status, "done"):
-		return "✅"
+		return theme.Icon("✅", "[done]")
 	case strings.Contains(status, "in_progress") || strings.Contains(status, "progress"):
-		return "🚧"
+		return theme.Icon("🚧", "[wip]")
 	case strings.Contains(status, "todo") || strings.Contains(status, "pending"):
-		return "⏳"
+		return theme.Icon("⏳", "[todo]")
 	case strings.Contains(status, "blocked"):
-		return "🚫"
+		return theme.Icon("🚫", "[blocked]")
 	case strings.Contains(status, "review"):
-		return "👀"
+		return theme.Icon("👀", "[review]")
 	default:
-		return "📋"
+		return theme.Icon("📋", "[planned]")
 	}
 }
 
@@ -176,13 +141,13 @@ func (psd *ProjectStateDisplay) getPriorityIcon(priority string) string {
 	priority = strings.ToLower(priority)
 	switch {
 	case strings.Contains(priority, "high") || priority == "p0":
-		return "🔴 "
+		return theme.Icon("🔴 ", "[high] ")
 	case strings.Contains(priority, "medium") || priority == "p1":
-		return "🟡 "
+		return theme.Icon("🟡 ", "[medium] ")
 	case strings.Contains(priority, "low") || priority == "p2":
-		return "🟢 "
+		return theme.Icon("🟢 ", "[low] ")
 	default:
-		return "⚪ "
+		return theme.Icon("⚪ ", "[-] ")
 	}
 }
 
@@ -202,19 +167,19 @@ func (psd *ProjectStateDisplay) getProjectName(ctx *ProjectContext) string {
 
 // printSeparator prints a line separator
 func (psd *ProjectStateDisplay) printSeparator(char string) {
-	fmt.Println(strings.Repeat(char, psd.width))
+	theme.Println(strings.Repeat(char, psd.width))
 }
 
 // printCentered prints text centered within the display width
 func (psd *ProjectStateDisplay) printCentered(text string) {
 	textLen := len(text)
 	if textLen >= psd.width {
-		fmt.Println(text)
+		theme.Println(text)
 		return
 	}
 
 	padding := (psd.width - textLen) / 2
-	fmt.Printf("%s%s%s\n",
+	theme.Printf("%s%s%s\n",
 		strings.Repeat(" ", padding),
 		text,
 		strings.Repeat(" ", psd.width-textLen-padding))
@@ -223,46 +188,49 @@ func (psd *ProjectStateDisplay) printCentered(text string) {
 // DisplayQuickStatus shows a compact one-line status
 func (psd *ProjectStateDisplay) DisplayQuickStatus(ctx *ProjectContext) {
 	icon := psd.getStateIcon(ctx.State)
-	fmt.Printf("%s%s", icon, ctx.State.String())
+	status := fmt.Sprintf("%s%s", icon, ctx.State.String())
 
 	if ctx.CurrentEpic != nil {
-		fmt.Printf(" | Epic: %s (%.0f%%)", ctx.CurrentEpic.Title, ctx.CurrentEpic.Progress*100)
+		status += fmt.Sprintf(" | Epic: %s (%.0f%%)", ctx.CurrentEpic.Title, ctx.CurrentEpic.Progress*100)
 	}
 
 	if ctx.CurrentStory != nil {
-		fmt.Printf(" | Story: %s", ctx.CurrentStory.Title)
+		status += fmt.Sprintf(" | Story: %s", ctx.CurrentStory.Title)
 	}
 
 	if ctx.CurrentTask != nil {
-		fmt.Printf(" | Task: %s", ctx.CurrentTask.Title)
+		status += fmt.Sprintf(" | Task: %s", ctx.CurrentTask.Title)
 	}
 
-	fmt.Println()
+	if psd.compact {
+		status = truncateToWidth(status, psd.width)
+	}
+	theme.Println(status)
 }
 
 // DisplayProgressSummary shows a summary of progress across all levels
 func (psd *ProjectStateDisplay) DisplayProgressSummary(ctx *ProjectContext) {
-	fmt.Println("\n📊 Progress Summary:")
+	theme.Println("\n📊 Progress Summary:")
 
 	if ctx.CurrentEpic != nil {
 		epic := ctx.CurrentEpic
-		fmt.Printf("   Epic: %s %.1f%% complete\n",
+		theme.Printf("   Epic: %s %.1f%% complete\n",
 			psd.createProgressBar(epic.Progress, 20), epic.Progress*100)
 	}
 
 	if ctx.CurrentStory != nil && ctx.CurrentStory.TotalTasks > 0 {
 		story := ctx.CurrentStory
 		progress := float64(story.CompletedTasks) / float64(story.TotalTasks)
-		fmt.Printf("   Story: %s %.1f%% complete\n",
+		theme.Printf("   Story: %s %.1f%% complete\n",
 			psd.createProgressBar(progress, 20), progress*100)
 	}
 
 	// Show next milestone
 	if ctx.State < StateTaskInProgress {
-		fmt.Printf("   Next: %s\n", psd.getNextMilestone(ctx))
+		theme.Printf("   Next: %s\n", psd.getNextMilestone(ctx))
 	}
 
-	fmt.Println()
+	theme.Println()
 }
 
 // getNextMilestone suggests the next major milestone
@@ -284,22 +252,22 @@ func (psd *ProjectStateDisplay) getNextMilestone(ctx *ProjectContext) string {
 // DisplayActionSummary shows available actions with formatting
 func (psd *ProjectStateDisplay) DisplayActionSummary(ctx *ProjectContext) {
 	if len(ctx.AvailableActions) == 0 {
-		fmt.Println("No actions available")
+		theme.Println("No actions available")
 		return
 	}
 
-	fmt.Printf("\n💡 Available Actions (%d):\n", len(ctx.AvailableActions))
+	theme.Printf("\n💡 Available Actions (%d):\n", len(ctx.AvailableActions))
 
 	for i, action := range ctx.AvailableActions {
 		if i >= 8 { // Limit display
-			fmt.Printf("   ... and %d more (use 'interactive' to see all)\n",
+			theme.Printf("   ... and %d more (use 'interactive' to see all)\n",
 				len(ctx.AvailableActions)-8)
 			break
 		}
-		fmt.Printf("   • %s\n", action)
+		theme.Printf("   • %s\n", action)
 	}
 
-	fmt.Println()
+	theme.Println()
 }
 
 // DisplayWithSuggestions combines state display with suggestions
@@ -307,7 +275,7 @@ func (psd *ProjectStateDisplay) DisplayWithSuggestions(ctx *ProjectContext, sugg
 	psd.DisplayProjectOverview(ctx)
 
 	if len(suggestions) > 0 {
-		fmt.Println("🎯 Recommended Actions:")
+		theme.Println("🎯 Recommended Actions:")
 
 		for i, suggestion := range suggestions {
 			if i >= 3 { // Show top 3 suggestions
@@ -315,17 +283,17 @@ func (psd *ProjectStateDisplay) DisplayWithSuggestions(ctx *ProjectContext, sugg
 			}
 
 			icon := psd.getPriorityIcon(string(suggestion.Priority))
-			fmt.Printf("   %d. %s%s\n", i+1, icon, suggestion.Action.Name)
+			theme.Printf("   %d. %s%s\n", i+1, icon, suggestion.Action.Name)
 
 			if suggestion.Reasoning != "" {
-				fmt.Printf("      %s\n", suggestion.Reasoning)
+				theme.Printf("      %s\n", suggestion.Reasoning)
 			}
 		}
 
 		if len(suggestions) > 3 {
-			fmt.Printf("   ... and %d more suggestions\n", len(suggestions)-3)
+			theme.Printf("   ... and %d more suggestions\n", len(suggestions)-3)
 		}
 
-		fmt.Println()
+		theme.Println()
 	}
 }
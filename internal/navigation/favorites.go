@@ -0,0 +1,95 @@
+package navigation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FavoritesFile stores the pinned menu items, relative to the project root
+const FavoritesFile = ".claude-wm/favorites.json"
+
+// Actions of the favorites shortcuts in menus with AllowFavorites
+const (
+	ActionToggleFavorite = "toggle-favorite" // f: pin or unpin the selected option
+	ActionFavorites      = "favorites"       // F: open the favorites menu
+)
+
+// FavoriteItem is a menu option pinned to the favorites menu
+type FavoriteItem struct {
+	MenuID  string    `json:"menu_id"` // Menu the option was pinned from
+	Label   string    `json:"label"`
+	Action  string    `json:"action"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+// FavoritesStore reads and writes the favorites of a project
+type FavoritesStore struct {
+	path string
+}
+
+// NewFavoritesStore returns the store of the favorites of the project at
+// projectPath
+func NewFavoritesStore(projectPath string) *FavoritesStore {
+	return &FavoritesStore{path: filepath.Join(projectPath, FavoritesFile)}
+}
+
+// List returns the favorites in the order they were pinned, none when the
+// file doesn't exist
+func (s *FavoritesStore) List() ([]FavoriteItem, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read favorites: %w", err)
+	}
+
+	var favorites []FavoriteItem
+	if err := json.Unmarshal(data, &favorites); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", FavoritesFile, err)
+	}
+	return favorites, nil
+}
+
+// Toggle pins item, or unpins it when a favorite with the same action is
+// already pinned. It reports whether item is pinned afterwards.
+func (s *FavoritesStore) Toggle(item FavoriteItem) (bool, error) {
+	favorites, err := s.List()
+	if err != nil {
+		return false, err
+	}
+
+	for i, favorite := range favorites {
+		if favorite.Action == item.Action {
+			favorites = append(favorites[:i], favorites[i+1:]...)
+			return false, s.save(favorites)
+		}
+	}
+
+	if item.AddedAt.IsZero() {
+		item.AddedAt = time.Now()
+	}
+	return true, s.save(append(favorites, item))
+}
+
+// save writes favorites to the favorites file
+func (s *FavoritesStore) save(favorites []FavoriteItem) error {
+	if favorites == nil {
+		favorites = []FavoriteItem{}
+	}
+	data, err := json.MarshalIndent(favorites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode favorites: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create favorites directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write favorites: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package navigation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFavoritesStore_Toggle(t *testing.T) {
+	projectPath := t.TempDir()
+	store := NewFavoritesStore(projectPath)
+
+	favorites, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, favorites)
+
+	pinned, err := store.Toggle(FavoriteItem{MenuID: "ticket", Label: "⚡ Implement", Action: "/4-task:2-execute:3-Implement"})
+	require.NoError(t, err)
+	assert.True(t, pinned)
+	pinned, err = store.Toggle(FavoriteItem{MenuID: "current-story", Label: "✅ Complete Story", Action: "story-complete"})
+	require.NoError(t, err)
+	assert.True(t, pinned)
+	assert.FileExists(t, filepath.Join(projectPath, FavoritesFile))
+
+	favorites, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, favorites, 2)
+	assert.Equal(t, "ticket", favorites[0].MenuID)
+	assert.Equal(t, "⚡ Implement", favorites[0].Label)
+	assert.False(t, favorites[0].AddedAt.IsZero())
+	assert.Equal(t, "story-complete", favorites[1].Action)
+
+	// Pinning the same action again unpins it, wherever it is pinned from
+	pinned, err = store.Toggle(FavoriteItem{MenuID: "favorites", Label: "⚡ Implement", Action: "/4-task:2-execute:3-Implement"})
+	require.NoError(t, err)
+	assert.False(t, pinned)
+
+	favorites, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, favorites, 1)
+	assert.Equal(t, "story-complete", favorites[0].Action)
+}
+
+func TestFavoritesStore_InvalidFile(t *testing.T) {
+	projectPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectPath, ".claude-wm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, FavoritesFile), []byte("{"), 0644))
+
+	store := NewFavoritesStore(projectPath)
+	_, err := store.List()
+	assert.Error(t, err)
+	_, err = store.Toggle(FavoriteItem{Label: "Status", Action: "status"})
+	assert.Error(t, err)
+}
+
+func TestMenuDisplay_ProcessInput_Favorites(t *testing.T) {
+	md := &MenuDisplay{}
+	menu := NewMenuBuilder("Tickets").
+		AddOption("plan", "Plan Ticket", "", "ticket-plan").
+		AddOption("fix", "Fix", "", "ticket-fix").
+		SetShowNumbers(true).
+		Build()
+
+	// Disabled by default
+	assert.Nil(t, md.processInput(menu, "F"))
+	assert.Nil(t, md.processInput(menu, "f 1"))
+
+	menu.AllowFavorites = true
+	assert.Equal(t, ActionFavorites, md.processInput(menu, "F").Action)
+
+	for _, input := range []string{"f 1", "f1", "f plan", "f Plan Ticket"} {
+		result := md.processInput(menu, input)
+		require.NotNil(t, result, input)
+		assert.Equal(t, ActionToggleFavorite, result.Action)
+		assert.Equal(t, "ticket-plan", result.SelectedOption.Action)
+	}
+
+	// Options starting with f are still selected by name
+	assert.Equal(t, "ticket-fix", md.processInput(menu, "fix").Action)
+	assert.Nil(t, md.processInput(menu, "f"))
+}
+
+func TestMenuSelector_Favorites(t *testing.T) {
+	menu := NewMenuBuilder("Tickets").
+		AddOption("plan", "Plan Ticket", "", "ticket-plan").
+		AddOption("fix", "Fix", "", "ticket-fix").
+		Build()
+	selector := newMenuSelector(menu)
+
+	// Without favorites, f filters
+	assert.Nil(t, selector.handle(keyRune, 'f'))
+	assert.Equal(t, "f", string(selector.filter))
+	selector.handle(keyEscape, 0)
+
+	menu.AllowFavorites = true
+	selector.handle(keyDown, 0)
+	result := selector.handle(keyRune, 'f')
+	require.NotNil(t, result)
+	assert.Equal(t, ActionToggleFavorite, result.Action)
+	assert.Equal(t, "ticket-fix", result.SelectedOption.Action)
+	assert.Equal(t, ActionFavorites, selector.handle(keyRune, 'F').Action)
+
+	// Once filtering, f is part of the filter
+	selector.handle(keyRune, 'x')
+	assert.Nil(t, selector.handle(keyRune, 'f'))
+	assert.Equal(t, "xf", string(selector.filter))
+}
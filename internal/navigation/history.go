@@ -0,0 +1,196 @@
+package navigation
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// ActionHistoryFile stores the actions run from the menus of every
+	// project as JSON lines, relative to the home directory
+	ActionHistoryFile = ".claude-wm/action-history.jsonl"
+
+	// MaxHistoryEntries is how many actions are kept per project
+	MaxHistoryEntries = 100
+)
+
+// HistoryEntry is an action run from a menu
+type HistoryEntry struct {
+	Action     string        `json:"action"`
+	Menu       string        `json:"menu"` // Menu the action was run from
+	ExecutedAt time.Time     `json:"executed_at"`
+	Duration   time.Duration `json:"duration"`
+	Success    bool          `json:"success"`
+}
+
+// historyRecord is a line of the history file: an entry and the hash of the
+// path of its project
+type historyRecord struct {
+	Project string `json:"project"`
+	HistoryEntry
+}
+
+// ActionHistory reads and writes the actions run in a project. The history
+// file holds the entries of every project, one JSON line each. Entries are
+// appended so that concurrent sessions don't overwrite each other's.
+type ActionHistory struct {
+	path    string
+	project string
+}
+
+// NewActionHistory returns the history of the project at projectPath, stored
+// in $HOME/.claude-wm/action-history.jsonl
+func NewActionHistory(projectPath string) (*ActionHistory, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return newActionHistory(filepath.Join(homeDir, ActionHistoryFile), projectPath), nil
+}
+
+func newActionHistory(path, projectPath string) *ActionHistory {
+	if absPath, err := filepath.Abs(projectPath); err == nil {
+		projectPath = absPath
+	}
+	hash := sha256.Sum256([]byte(projectPath))
+	return &ActionHistory{path: path, project: hex.EncodeToString(hash[:])[:16]}
+}
+
+// Recent returns up to limit entries of the project, most recent first
+func (h *ActionHistory) Recent(limit int) ([]HistoryEntry, error) {
+	histories, err := h.load()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := histories[h.project]
+	recent := make([]HistoryEntry, 0, min(limit, len(entries)))
+	for i := len(entries) - 1; i >= 0 && len(recent) < limit; i-- {
+		recent = append(recent, entries[i])
+	}
+	return recent, nil
+}
+
+// Record appends entry to the history of the project, then trims the history
+// file to the last MaxHistoryEntries of the project when it holds more
+func (h *ActionHistory) Record(entry HistoryEntry) error {
+	if entry.ExecutedAt.IsZero() {
+		entry.ExecutedAt = time.Now()
+	}
+	line, err := json.Marshal(historyRecord{Project: h.project, HistoryEntry: entry})
+	if err != nil {
+		return fmt.Errorf("failed to encode action history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create action history directory: %w", err)
+	}
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open action history: %w", err)
+	}
+	// A single write keeps the line whole when sessions append concurrently
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write action history: %w", err)
+	}
+
+	histories, err := h.load()
+	if err != nil {
+		return err
+	}
+	entries := histories[h.project]
+	if len(entries) <= MaxHistoryEntries {
+		return nil
+	}
+	histories[h.project] = entries[len(entries)-MaxHistoryEntries:]
+	return h.save(histories)
+}
+
+// Clear removes the history of the project, keeping the other projects'
+func (h *ActionHistory) Clear() error {
+	histories, err := h.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := histories[h.project]; !ok {
+		return nil
+	}
+
+	delete(histories, h.project)
+	return h.save(histories)
+}
+
+// load reads the histories of every project, none when the file doesn't
+// exist. Lines that can't be parsed, e.g. torn by a crash, are skipped.
+func (h *ActionHistory) load() (map[string][]HistoryEntry, error) {
+	histories := make(map[string][]HistoryEntry)
+	data, err := os.ReadFile(h.path)
+	if os.IsNotExist(err) {
+		return histories, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read action history: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var record historyRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Project == "" {
+			continue
+		}
+		histories[record.Project] = append(histories[record.Project], record.HistoryEntry)
+	}
+	return histories, nil
+}
+
+// save replaces the history file with the histories of every project. The
+// file is written next to it and renamed so that readers never see it half
+// written; entries appended by another session while saving are lost, which
+// only happens when trimming a full history or clearing.
+func (h *ActionHistory) save(histories map[string][]HistoryEntry) error {
+	var data bytes.Buffer
+	for project, entries := range histories {
+		for _, entry := range entries {
+			line, err := json.Marshal(historyRecord{Project: project, HistoryEntry: entry})
+			if err != nil {
+				return fmt.Errorf("failed to encode action history: %w", err)
+			}
+			data.Write(line)
+			data.WriteByte('\n')
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return fmt.Errorf("failed to create action history directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(h.path), filepath.Base(h.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write action history: %w", err)
+	}
+	_, err = tmp.Write(data.Bytes())
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), h.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write action history: %w", err)
+	}
+	return nil
+}
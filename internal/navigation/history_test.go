@@ -0,0 +1,122 @@
+package navigation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ActionHistoryFile)
+	history := newActionHistory(path, "/projects/a")
+	other := newActionHistory(path, "/projects/b")
+
+	entries, err := history.Recent(20)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	require.NoError(t, history.Record(HistoryEntry{Action: "ticket-plan", Menu: "ticket", Duration: time.Second, Success: true}))
+	require.NoError(t, history.Record(HistoryEntry{Action: "epic-list", Menu: "epics", Success: false}))
+	require.NoError(t, other.Record(HistoryEntry{Action: "story-list", Menu: "current-story", Success: true}))
+
+	entries, err = history.Recent(20)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "epic-list", entries[0].Action)
+	assert.False(t, entries[0].Success)
+	assert.Equal(t, "ticket-plan", entries[1].Action)
+	assert.Equal(t, time.Second, entries[1].Duration)
+	assert.False(t, entries[1].ExecutedAt.IsZero())
+
+	// Only the last MaxHistoryEntries are kept
+	for i := 0; i < MaxHistoryEntries; i++ {
+		require.NoError(t, history.Record(HistoryEntry{Action: fmt.Sprintf("action-%d", i)}))
+	}
+	entries, err = history.Recent(MaxHistoryEntries + 10)
+	require.NoError(t, err)
+	require.Len(t, entries, MaxHistoryEntries)
+	assert.Equal(t, "action-99", entries[0].Action)
+	assert.Equal(t, "action-0", entries[MaxHistoryEntries-1].Action)
+
+	entries, err = history.Recent(20)
+	require.NoError(t, err)
+	assert.Len(t, entries, 20)
+
+	// Clearing a project keeps the others
+	require.NoError(t, history.Clear())
+	entries, err = history.Recent(20)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	entries, err = other.Recent(20)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "story-list", entries[0].Action)
+}
+
+func TestActionHistory_ConcurrentRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ActionHistoryFile)
+
+	// Sessions of the same project append without losing each other's entries
+	var wg sync.WaitGroup
+	for session := 0; session < 4; session++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			history := newActionHistory(path, "/projects/a")
+			for i := 0; i < 10; i++ {
+				assert.NoError(t, history.Record(HistoryEntry{Action: fmt.Sprintf("session-%d-%d", session, i)}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := newActionHistory(path, "/projects/a").Recent(MaxHistoryEntries)
+	require.NoError(t, err)
+	assert.Len(t, entries, 40)
+}
+
+func TestActionHistory_TornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ActionHistoryFile)
+	history := newActionHistory(path, "/projects/a")
+	require.NoError(t, history.Record(HistoryEntry{Action: "epic-list"}))
+
+	// A write cut short by a crash doesn't lose the other entries
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"project": "` + history.project + `", "action": "tick` + "\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.NoError(t, history.Record(HistoryEntry{Action: "story-list"}))
+
+	entries, err := history.Recent(20)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "story-list", entries[0].Action)
+	assert.Equal(t, "epic-list", entries[1].Action)
+}
+
+func TestActionHistory_TrimmedOnEachWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ActionHistoryFile)
+	history := newActionHistory(path, "/projects/a")
+	require.NoError(t, newActionHistory(path, "/projects/b").Record(HistoryEntry{Action: "story-list"}))
+
+	for i := 0; i < MaxHistoryEntries+5; i++ {
+		require.NoError(t, history.Record(HistoryEntry{Action: fmt.Sprintf("action-%d", i)}))
+
+		histories, err := history.load()
+		require.NoError(t, err)
+		require.Len(t, histories[history.project], min(i+1, MaxHistoryEntries), "after %d writes", i+1)
+	}
+
+	histories, err := history.load()
+	require.NoError(t, err)
+	assert.Equal(t, "action-5", histories[history.project][0].Action)
+	assert.Equal(t, fmt.Sprintf("action-%d", MaxHistoryEntries+4), histories[history.project][MaxHistoryEntries-1].Action)
+	assert.Len(t, histories, 2, "other projects are kept")
+}
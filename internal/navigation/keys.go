@@ -0,0 +1,306 @@
+package navigation
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"claude-wm-cli/internal/theme"
+
+	"golang.org/x/term"
+)
+
+// menuKey is a key pressed while navigating a menu with the keyboard
+type menuKey int
+
+const (
+	keyRune menuKey = iota // A printable character, typed to filter the options
+	keyUp
+	keyDown
+	keyEnter
+	keyBackspace
+	keyEscape
+	keyInterrupt // Ctrl+C or Ctrl+D
+	keyUnknown
+)
+
+// readKey reads a single key press from a terminal in raw mode. Arrow keys
+// arrive as escape sequences, written in one go, so an escape followed by
+// nothing buffered is a bare Escape key.
+func readKey(reader *bufio.Reader) (menuKey, rune, error) {
+	r, _, err := reader.ReadRune()
+	if err != nil {
+		return keyUnknown, 0, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return keyEnter, 0, nil
+	case 127, '\b':
+		return keyBackspace, 0, nil
+	case 3, 4:
+		return keyInterrupt, 0, nil
+	case 27:
+		if reader.Buffered() == 0 {
+			return keyEscape, 0, nil
+		}
+		next, _, err := reader.ReadRune()
+		if err != nil {
+			return keyUnknown, 0, err
+		}
+		if next != '[' && next != 'O' {
+			return keyEscape, 0, nil
+		}
+		code, _, err := reader.ReadRune()
+		if err != nil {
+			return keyUnknown, 0, err
+		}
+		switch code {
+		case 'A':
+			return keyUp, 0, nil
+		case 'B':
+			return keyDown, 0, nil
+		}
+		return keyUnknown, 0, nil
+	}
+
+	if unicode.IsPrint(r) {
+		return keyRune, r, nil
+	}
+	return keyUnknown, 0, nil
+}
+
+// menuSelector is the state of a menu navigated with the keyboard: the
+// highlighted option and the type-ahead filter narrowing the options
+type menuSelector struct {
+	menu   *Menu
+	filter []rune
+	cursor int // Index in visible()
+}
+
+func newMenuSelector(menu *Menu) *menuSelector {
+	return &menuSelector{menu: menu}
+}
+
+// visible returns the indices in menu.Options of the enabled options whose
+// label contains the filter, ignoring case
+func (s *menuSelector) visible() []int {
+	filter := strings.ToLower(string(s.filter))
+	var indices []int
+	for i, option := range s.menu.Options {
+		if option.Enabled && strings.Contains(strings.ToLower(option.Label), filter) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// handle applies a key press and returns the menu result once the user has
+// made a choice, nil while navigating
+func (s *menuSelector) handle(key menuKey, r rune) *MenuResult {
+	visible := s.visible()
+
+	switch key {
+	case keyUp:
+		if s.cursor > 0 {
+			s.cursor--
+		} else if len(visible) > 0 {
+			s.cursor = len(visible) - 1
+		}
+	case keyDown:
+		if s.cursor < len(visible)-1 {
+			s.cursor++
+		} else {
+			s.cursor = 0
+		}
+	case keyEnter:
+		if s.cursor < len(visible) {
+			option := &s.menu.Options[visible[s.cursor]]
+			return &MenuResult{SelectedOption: option, Action: option.Action, Input: string(s.filter)}
+		}
+	case keyBackspace:
+		if len(s.filter) > 0 {
+			s.filter = s.filter[:len(s.filter)-1]
+			s.cursor = 0
+		}
+	case keyEscape:
+		if len(s.filter) > 0 {
+			s.filter = nil
+			s.cursor = 0
+		} else if s.menu.AllowBack {
+			return &MenuResult{Action: "back"}
+		}
+	case keyInterrupt:
+		return &MenuResult{Action: "quit"}
+	case keyRune:
+		if r == '?' && len(s.filter) == 0 && s.menu.ShowHelp {
+			return &MenuResult{Action: "help", Input: "?"}
+		}
+		if len(s.filter) == 0 && s.menu.AllowFavorites {
+			switch {
+			case r == 'F':
+				return &MenuResult{Action: ActionFavorites, Input: "F"}
+			case r == 'f' && s.cursor < len(visible):
+				option := &s.menu.Options[visible[s.cursor]]
+				return &MenuResult{SelectedOption: option, Action: ActionToggleFavorite, Input: "f"}
+			}
+		}
+		s.filter = append(s.filter, r)
+		s.cursor = 0
+	}
+
+	return nil
+}
+
+// render returns the lines of the menu, with the highlighted option marked
+// and the keyboard help in the footer
+func (s *menuSelector) render() []string {
+	var lines []string
+
+	if s.menu.Title != "" {
+		lines = append(lines, "", fmt.Sprintf("═══ %s ═══", s.menu.Title), "")
+	}
+	if len(s.filter) > 0 {
+		lines = append(lines, fmt.Sprintf("🔎 Filter: %s", string(s.filter)), "")
+	}
+
+	visible := s.visible()
+	shown := make(map[int]int, len(visible)) // Option index -> position in visible
+	for pos, i := range visible {
+		shown[i] = pos
+	}
+
+	optionNumber := 0
+	for i, option := range s.menu.Options {
+		if !option.Enabled {
+			// Section headers and separators only make sense unfiltered
+			if len(s.filter) == 0 {
+				if option.Label != "" && option.Label != "────────────────────────" {
+					lines = append(lines, "", fmt.Sprintf("═══ %s ═══", option.Label))
+				} else {
+					lines = append(lines, "")
+				}
+			}
+			continue
+		}
+		optionNumber++
+
+		pos, ok := shown[i]
+		if !ok {
+			continue
+		}
+
+		marker := "  "
+		if pos == s.cursor {
+			marker = "❯ "
+		}
+		line := marker
+		if s.menu.ShowNumbers {
+			line += fmt.Sprintf("%d) %s", optionNumber, option.Label)
+		} else {
+			line += "• " + option.Label
+		}
+		if option.Description != "" {
+			line += " - " + option.Description
+		}
+		lines = append(lines, line)
+	}
+	if len(visible) == 0 {
+		lines = append(lines, "  (no option matches the filter)")
+	}
+
+	help := []string{"↑/↓ Move", "Enter Select", "Type to filter"}
+	if len(s.filter) > 0 {
+		help = append(help, "Esc Clear filter")
+	} else if s.menu.AllowBack {
+		help = append(help, "Esc Back")
+	}
+	if s.menu.ShowHelp {
+		help = append(help, "? Help")
+	}
+	if s.menu.AllowFavorites && len(s.filter) == 0 {
+		help = append(help, "f Pin", "F Favorites")
+	}
+	if s.menu.AllowQuit {
+		help = append(help, "Ctrl+C Quit")
+	}
+	lines = append(lines, "", "  "+strings.Join(help, "  "))
+
+	return lines
+}
+
+// showWithKeys runs the menu with arrow-key navigation and type-ahead
+// filtering. It reports false when the terminal cannot be switched to raw
+// mode, so that the caller falls back to numbered selection.
+func (md *MenuDisplay) showWithKeys(menu *Menu) (*MenuResult, bool, error) {
+	fd := int(os.Stdin.Fd())
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, false, nil
+	}
+	defer term.Restore(fd, oldState)
+
+	width := 0
+	if w, _, err := term.GetSize(fd); err == nil {
+		width = w
+	}
+
+	selector := newMenuSelector(menu)
+	drawn := 0
+	for {
+		lines := selector.render()
+		if breadcrumb := md.renderBreadcrumb(); breadcrumb != "" {
+			lines = append([]string{"", breadcrumb}, lines...)
+		}
+		for i, line := range lines {
+			lines[i] = theme.Text(line)
+		}
+		drawn = redrawMenu(lines, drawn, width)
+
+		key, r, err := readKey(md.reader)
+		if err != nil {
+			fmt.Print("\r\n")
+			return nil, true, err
+		}
+		if result := selector.handle(key, r); result != nil {
+			fmt.Print("\r\n")
+			return result, true, nil
+		}
+	}
+}
+
+// redrawMenu erases the previously drawn lines and prints lines in their
+// place. Lines are cut to the terminal width so that none wraps and the count
+// of lines to erase stays exact. It returns the number of lines drawn.
+func redrawMenu(lines []string, drawn, width int) int {
+	var b strings.Builder
+	if drawn > 0 {
+		b.WriteString("\r")
+		if drawn > 1 {
+			fmt.Fprintf(&b, "\033[%dA", drawn-1)
+		}
+		b.WriteString("\033[J")
+	}
+
+	for i, line := range lines {
+		if width > 1 {
+			if runes := []rune(line); len(runes) >= width {
+				line = string(runes[:width-1])
+			}
+		}
+		if i > 0 {
+			b.WriteString("\r\n")
+		}
+		b.WriteString(line)
+	}
+
+	fmt.Print(b.String())
+	return len(lines)
+}
+
+// isTerminal reports whether stdin is an interactive terminal
+func isTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
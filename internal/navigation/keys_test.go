@@ -0,0 +1,83 @@
+package navigation
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadKey(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("\033[A\033[Bx\r\177\003"))
+
+	expected := []menuKey{keyUp, keyDown, keyRune, keyEnter, keyBackspace, keyInterrupt}
+	for _, want := range expected {
+		key, r, err := readKey(reader)
+		require.NoError(t, err)
+		assert.Equal(t, want, key)
+		if key == keyRune {
+			assert.Equal(t, 'x', r)
+		}
+	}
+}
+
+func TestMenuSelector_Navigation(t *testing.T) {
+	menu := NewMenuBuilder("Main").
+		AddOption("epics", "Epic Management", "", "epics").
+		AddSeparator().
+		AddOption("tickets", "Ticket Management", "", "tickets").
+		AddOption("status", "Project Status", "", "status").
+		SetAllowBack(true).
+		SetAllowQuit(true).
+		Build()
+	selector := newMenuSelector(menu)
+
+	// Moving wraps around both ends
+	assert.Nil(t, selector.handle(keyUp, 0))
+	assert.Nil(t, selector.handle(keyDown, 0))
+	assert.Equal(t, 0, selector.cursor)
+	assert.Nil(t, selector.handle(keyDown, 0))
+
+	result := selector.handle(keyEnter, 0)
+	require.NotNil(t, result)
+	assert.Equal(t, "tickets", result.Action)
+
+	assert.Equal(t, "back", selector.handle(keyEscape, 0).Action)
+	assert.Equal(t, "quit", selector.handle(keyInterrupt, 0).Action)
+}
+
+func TestMenuSelector_TypeAhead(t *testing.T) {
+	menu := NewMenuBuilder("Main").
+		AddOption("epics", "Epic Management", "", "epics").
+		AddOption("tickets", "Ticket Management", "", "tickets").
+		AddOption("status", "Project Status", "", "status").
+		SetShowNumbers(true).
+		SetAllowBack(true).
+		Build()
+	selector := newMenuSelector(menu)
+
+	for _, r := range "MANAG" {
+		assert.Nil(t, selector.handle(keyRune, r))
+	}
+	assert.Equal(t, []int{0, 1}, selector.visible())
+
+	selector.handle(keyRune, 'x')
+	assert.Empty(t, selector.visible())
+	assert.Nil(t, selector.handle(keyEnter, 0))
+	assert.Contains(t, strings.Join(selector.render(), "\n"), "no option matches")
+
+	selector.handle(keyBackspace, 0)
+	selector.handle(keyDown, 0)
+	lines := strings.Join(selector.render(), "\n")
+	assert.Contains(t, lines, "🔎 Filter: MANAG")
+	assert.Contains(t, lines, "❯ 2) Ticket Management")
+	assert.NotContains(t, lines, "Project Status")
+	assert.Contains(t, lines, "Esc Clear filter")
+
+	// Escape clears the filter before going back
+	assert.Nil(t, selector.handle(keyEscape, 0))
+	assert.Len(t, selector.visible(), 3)
+	assert.Contains(t, strings.Join(selector.render(), "\n"), "Esc Back")
+}
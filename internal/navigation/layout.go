@@ -0,0 +1,251 @@
+package navigation
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"claude-wm-cli/internal/theme"
+
+	"golang.org/x/term"
+)
+
+// Display widths at which the project overview changes layout
+const (
+	DefaultDisplayWidth   = 80 // Used when the terminal width cannot be detected
+	CompactLayoutMaxWidth = 60 // At or below, the overview is a short single column
+	TwoColumnMinWidth     = 80 // At or above, status and metrics are shown side by side
+)
+
+// overviewLayout is the arrangement of the project overview
+type overviewLayout int
+
+const (
+	layoutCompact overviewLayout = iota
+	layoutSingleColumn
+	layoutTwoColumn
+)
+
+// columnGap separates the status and metrics columns
+func columnGap() string {
+	return theme.Icon(" │ ", " | ")
+}
+
+// metricsColumnMinWidth fits a progress bar line such as "Epic  [██████████] 100.0%"
+const metricsColumnMinWidth = 26
+
+// layoutForWidth returns the overview layout for a display width. Compact
+// mode always uses the compact layout.
+func layoutForWidth(width int, compact bool) overviewLayout {
+	switch {
+	case compact || width <= CompactLayoutMaxWidth:
+		return layoutCompact
+	case width >= TwoColumnMinWidth:
+		return layoutTwoColumn
+	default:
+		return layoutSingleColumn
+	}
+}
+
+// detectTerminalWidth returns the width from the COLUMNS environment
+// variable, then the size of the terminal on stdout, then DefaultDisplayWidth
+func detectTerminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if width, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && width > 0 {
+		return width
+	}
+	return DefaultDisplayWidth
+}
+
+// overviewLines returns the lines of the project overview, laid out for the
+// display width
+func (psd *ProjectStateDisplay) overviewLines(ctx *ProjectContext, now time.Time) []string {
+	layout := layoutForWidth(psd.width, psd.compact)
+
+	title := theme.Text(fmt.Sprintf("🚀 %s - %s", psd.getProjectName(ctx), ctx.State.String()))
+	lines := []string{psd.titleSeparator(title)}
+
+	switch layout {
+	case layoutCompact:
+		for _, line := range themed(psd.statusLines(ctx, layout, now)) {
+			lines = append(lines, truncateToWidth(line, psd.width))
+		}
+
+	case layoutTwoColumn:
+		if ctx.ProjectPath != "" {
+			lines = append(lines, truncateToWidth(theme.Text("📂 Project Path: "+ctx.ProjectPath), psd.width))
+		}
+		rightWidth := (psd.width - textWidth(columnGap())) / 3
+		if rightWidth < metricsColumnMinWidth {
+			rightWidth = metricsColumnMinWidth
+		}
+		leftWidth := psd.width - textWidth(columnGap()) - rightWidth
+		lines = append(lines, joinColumns(themed(psd.statusLines(ctx, layout, now)), themed(psd.metricsLines(ctx)), leftWidth, rightWidth)...)
+
+	default:
+		lines = append(lines, themed(psd.statusLines(ctx, layout, now))...)
+	}
+
+	return append(lines, strings.Repeat(theme.Icon("═", "="), psd.width))
+}
+
+// themed returns lines in the output theme, before their width is measured
+func themed(lines []string) []string {
+	for i, line := range lines {
+		lines[i] = theme.Text(line)
+	}
+	return lines
+}
+
+// titleSeparator centers title in a line of "═" as wide as the display
+func (psd *ProjectStateDisplay) titleSeparator(title string) string {
+	title = "  " + truncateToWidth(title, psd.width-4) + "  "
+	fill := psd.width - textWidth(title)
+	if fill < 0 {
+		fill = 0
+	}
+	left := fill / 2
+	separator := theme.Icon("═", "=")
+	return strings.Repeat(separator, left) + title + strings.Repeat(separator, fill-left)
+}
+
+// statusLines returns the epic, story and workflow step lines. The compact
+// and two-column layouts use short labels; the project path is part of the
+// status column only outside the two-column layout.
+func (psd *ProjectStateDisplay) statusLines(ctx *ProjectContext, layout overviewLayout, now time.Time) []string {
+	short := layout != layoutSingleColumn
+	label := func(long, abbreviated string) string {
+		if short {
+			return abbreviated
+		}
+		return long
+	}
+
+	var lines []string
+	if ctx.ProjectPath != "" && layout != layoutTwoColumn {
+		lines = append(lines, fmt.Sprintf("📂 %s%s", label("Project Path: ", ""), ctx.ProjectPath))
+	}
+
+	epicLabel := label("Current epic status", "Epic")
+	if ctx.CurrentEpic != nil {
+		epic := ctx.CurrentEpic
+		lines = append(lines, fmt.Sprintf("📚 %s: %s %s %s (%d/%d stories)", epicLabel,
+			psd.getStatusIcon(epic.Status), psd.getPriorityIcon(epic.Priority), epic.Title, epic.CompletedStories, epic.TotalStories))
+	} else {
+		lines = append(lines, fmt.Sprintf("📚 %s: No active epic", epicLabel))
+	}
+
+	storyLabel := label("Current story status", "Story")
+	if ctx.CurrentStory != nil {
+		story := ctx.CurrentStory
+		lines = append(lines, fmt.Sprintf("📖 %s: %s %s %s (%d/%d tasks)", storyLabel,
+			psd.getStatusIcon(story.Status), psd.getPriorityIcon(story.Priority), story.Title, story.CompletedTasks, story.TotalTasks))
+	} else if ctx.State >= StateStoryInProgress {
+		lines = append(lines, fmt.Sprintf("📖 %s: No active story", storyLabel))
+	}
+
+	lines = append(lines, fmt.Sprintf("📍 %s: %s", label("Current step", "Step"), ctx.State.String()))
+	if layout != layoutCompact {
+		lines = append(lines, fmt.Sprintf("🕐 %s: %s", label("Last updated", "Updated"), now.Format("15:04:05")))
+	}
+	return lines
+}
+
+// metricsLines returns the progress and issue counts shown next to the
+// status in the two-column layout
+func (psd *ProjectStateDisplay) metricsLines(ctx *ProjectContext) []string {
+	lines := []string{"📊 Metrics"}
+	if ctx.CurrentEpic != nil {
+		lines = append(lines, fmt.Sprintf("Epic  %s %.1f%%", psd.createProgressBar(ctx.CurrentEpic.Progress, 10), ctx.CurrentEpic.Progress*100))
+	}
+	if ctx.CurrentStory != nil && ctx.CurrentStory.TotalTasks > 0 {
+		progress := float64(ctx.CurrentStory.CompletedTasks) / float64(ctx.CurrentStory.TotalTasks)
+		lines = append(lines, fmt.Sprintf("Story %s %.1f%%", psd.createProgressBar(progress, 10), progress*100))
+	}
+	lines = append(lines, fmt.Sprintf("Issues: %d", len(ctx.Issues)))
+	if ctx.State < StateTaskInProgress {
+		lines = append(lines, "Next: "+psd.getNextMilestone(ctx))
+	}
+	return lines
+}
+
+// joinColumns lays out left and right side by side, cutting each line to
+// its column width
+func joinColumns(left, right []string, leftWidth, rightWidth int) []string {
+	rows := len(left)
+	if len(right) > rows {
+		rows = len(right)
+	}
+
+	lines := make([]string, rows)
+	for i := range lines {
+		var l, r string
+		if i < len(left) {
+			l = truncateToWidth(left[i], leftWidth)
+		}
+		if i < len(right) {
+			r = truncateToWidth(right[i], rightWidth)
+		}
+		lines[i] = strings.TrimRight(padToWidth(l, leftWidth)+columnGap()+r, " ")
+	}
+	return lines
+}
+
+// textWidth returns the number of terminal cells text takes, counting
+// emoji and wide East Asian characters as two cells
+func textWidth(text string) int {
+	width := 0
+	for _, r := range text {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r == 0x200D || (r >= 0xFE00 && r <= 0xFE0F) || unicode.Is(unicode.Mn, r):
+		return 0 // Joiners, variation selectors and combining marks
+	case r >= 0x1F000, r >= 0x1100 && r <= 0x115F, r >= 0x2E80 && r <= 0xA4CF, r >= 0xAC00 && r <= 0xD7A3, r >= 0xF900 && r <= 0xFAFF, r >= 0xFF00 && r <= 0xFF60:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// truncateToWidth cuts text to at most width cells, ending it with an
+// ellipsis when cut
+func truncateToWidth(text string, width int) string {
+	if textWidth(text) <= width {
+		return text
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	ellipsis := theme.Icon("…", "...")
+	var b strings.Builder
+	used := 0
+	for _, r := range text {
+		w := runeWidth(r)
+		if used+w > width-textWidth(ellipsis) {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	b.WriteString(ellipsis)
+	return b.String()
+}
+
+// padToWidth pads text with spaces to width cells
+func padToWidth(text string, width int) string {
+	if w := textWidth(text); w < width {
+		return text + strings.Repeat(" ", width-w)
+	}
+	return text
+}
@@ -0,0 +1,180 @@
+package navigation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"claude-wm-cli/internal/theme"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func layoutTestContext() *ProjectContext {
+	return &ProjectContext{
+		ProjectPath: "/home/dev/projects/awesome-project",
+		State:       StateStoryInProgress,
+		CurrentEpic: &EpicContext{
+			ID:               "EPIC-001",
+			Title:            "Build Amazing Feature",
+			Status:           "in_progress",
+			Priority:         "high",
+			Progress:         0.4,
+			TotalStories:     5,
+			CompletedStories: 2,
+		},
+		CurrentStory: &StoryContext{
+			ID:             "STORY-001",
+			Title:          "Implement Core Logic",
+			Status:         "in_progress",
+			Priority:       "medium",
+			TotalTasks:     4,
+			CompletedTasks: 1,
+		},
+		Issues: []string{"Missing configuration file"},
+	}
+}
+
+func TestLayoutForWidth(t *testing.T) {
+	tests := []struct {
+		width    int
+		compact  bool
+		expected overviewLayout
+	}{
+		{40, false, layoutCompact},
+		{60, false, layoutCompact},
+		{61, false, layoutSingleColumn},
+		{79, false, layoutSingleColumn},
+		{80, false, layoutTwoColumn},
+		{120, false, layoutTwoColumn},
+		{120, true, layoutCompact},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, layoutForWidth(tt.width, tt.compact), "width %d, compact %v", tt.width, tt.compact)
+	}
+}
+
+func TestProjectStateDisplay_OverviewLines(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	for _, width := range []int{40, 60, 70, 80, 120} {
+		display := NewProjectStateDisplay()
+		display.SetWidth(width)
+		lines := display.overviewLines(layoutTestContext(), now)
+
+		for _, line := range lines {
+			assert.LessOrEqual(t, textWidth(line), width, "width %d: %q", width, line)
+		}
+		assert.Equal(t, width, textWidth(lines[0]), "title separator at width %d", width)
+		assert.Equal(t, strings.Repeat("═", width), lines[len(lines)-1])
+	}
+}
+
+func TestProjectStateDisplay_OverviewLines_Compact(t *testing.T) {
+	display := NewProjectStateDisplay()
+	display.SetWidth(60)
+	output := strings.Join(display.overviewLines(layoutTestContext(), time.Now()), "\n")
+
+	assert.Contains(t, output, "📚 Epic:")
+	assert.Contains(t, output, "📖 Story:")
+	assert.NotContains(t, output, "Current epic status")
+	assert.NotContains(t, output, "📊 Metrics")
+	assert.NotContains(t, output, "🕐")
+}
+
+func TestProjectStateDisplay_OverviewLines_SingleColumn(t *testing.T) {
+	display := NewProjectStateDisplay()
+	display.SetWidth(70)
+	output := strings.Join(display.overviewLines(layoutTestContext(), time.Now()), "\n")
+
+	assert.Contains(t, output, "📂 Project Path: /home/dev/projects/awesome-project")
+	assert.Contains(t, output, "📚 Current epic status:")
+	assert.Contains(t, output, "🕐 Last updated:")
+	assert.NotContains(t, output, "📊 Metrics")
+}
+
+func TestProjectStateDisplay_OverviewLines_TwoColumn(t *testing.T) {
+	display := NewProjectStateDisplay()
+	display.SetWidth(100)
+	lines := display.overviewLines(layoutTestContext(), time.Now())
+
+	require.GreaterOrEqual(t, len(lines), 4)
+	assert.Equal(t, "📂 Project Path: /home/dev/projects/awesome-project", lines[1])
+	assert.Contains(t, lines[2], "📚 Epic:")
+	assert.Contains(t, lines[2], columnGap()+"📊 Metrics")
+
+	output := strings.Join(lines, "\n")
+	assert.Contains(t, output, "40.0%")
+	assert.Contains(t, output, "25.0%")
+	assert.Contains(t, output, "Issues: 1")
+
+	// The metrics column starts at the same cell on every row
+	column := -1
+	for _, line := range lines[2 : len(lines)-1] {
+		index := strings.Index(line, columnGap())
+		require.GreaterOrEqual(t, index, 0, line)
+		if column < 0 {
+			column = textWidth(line[:index])
+		}
+		assert.Equal(t, column, textWidth(line[:index]), line)
+	}
+}
+
+func TestProjectStateDisplay_CompactMode(t *testing.T) {
+	display := NewProjectStateDisplay()
+	display.SetWidth(120)
+	display.SetCompactMode(true)
+
+	output := strings.Join(display.overviewLines(layoutTestContext(), time.Now()), "\n")
+	assert.NotContains(t, output, "📊 Metrics")
+
+	display.SetWidth(30)
+	quick := captureOutput(func() { display.DisplayQuickStatus(layoutTestContext()) })
+	assert.LessOrEqual(t, textWidth(strings.TrimSuffix(quick, "\n")), 30)
+	assert.True(t, strings.HasSuffix(strings.TrimSuffix(quick, "\n"), "…"))
+}
+
+func TestProjectStateDisplay_AutoDetectWidth(t *testing.T) {
+	display := NewProjectStateDisplay()
+
+	t.Setenv("COLUMNS", "132")
+	assert.Equal(t, 132, display.AutoDetectWidth())
+	assert.Equal(t, 132, display.width)
+
+	// Without a usable COLUMNS, tests run without a terminal on stdout
+	t.Setenv("COLUMNS", "wide")
+	width := display.AutoDetectWidth()
+	assert.Greater(t, width, 0)
+}
+
+func TestTruncateToWidth(t *testing.T) {
+	assert.Equal(t, "short", truncateToWidth("short", 10))
+	assert.Equal(t, "a long…", truncateToWidth("a long line", 7))
+	assert.Equal(t, "📚 E…", truncateToWidth("📚 Epic", 5))
+	assert.Equal(t, "", truncateToWidth("text", 0))
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.Equal(t, 4, textWidth("abcd"))
+	assert.Equal(t, 2, textWidth("📚"))
+	assert.Equal(t, 3, textWidth("═══"))
+}
+
+func TestProjectStateDisplay_OverviewLines_ASCII(t *testing.T) {
+	theme.SetASCII(true)
+	defer theme.SetASCII(false)
+
+	display := NewProjectStateDisplay()
+	display.SetWidth(80)
+	lines := display.overviewLines(layoutTestContext(), time.Now())
+
+	output := strings.Join(lines, "\n")
+	assert.Contains(t, output, "Epic: [wip] [high]  Build Amazing")
+	assert.Contains(t, output, "[####......]")
+	for _, line := range lines {
+		assert.Equal(t, theme.Text(line), line, "line printed as is")
+		assert.LessOrEqual(t, textWidth(line), 80, line)
+	}
+}
@@ -0,0 +1,61 @@
+package navigation
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// NavLocationFile stores the last menu location, relative to the project root
+const NavLocationFile = ".claude-wm/state/nav.json"
+
+// NavLocation is where the user was in the interactive menus
+type NavLocation struct {
+	CurrentMenu string    `json:"current_menu"`
+	MenuStack   []string  `json:"menu_stack"` // Menus to go back to, outermost first
+	SavedAt     time.Time `json:"saved_at"`
+}
+
+// LoadNavLocation returns the last saved menu location, or nil if none was saved
+func LoadNavLocation(projectPath string) (*NavLocation, error) {
+	data, err := os.ReadFile(filepath.Join(projectPath, NavLocationFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read menu location: %w", err)
+	}
+
+	var location NavLocation
+	if err := json.Unmarshal(data, &location); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", NavLocationFile, err)
+	}
+	return &location, nil
+}
+
+// SaveNavLocation saves the menu location. Nothing is saved in projects
+// without a .claude-wm directory.
+func SaveNavLocation(projectPath string, location NavLocation) error {
+	if _, err := os.Stat(filepath.Join(projectPath, ".claude-wm")); err != nil {
+		return nil
+	}
+
+	if location.SavedAt.IsZero() {
+		location.SavedAt = time.Now()
+	}
+	data, err := json.MarshalIndent(location, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode menu location: %w", err)
+	}
+
+	path := filepath.Join(projectPath, NavLocationFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write menu location: %w", err)
+	}
+	return nil
+}
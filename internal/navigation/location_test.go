@@ -0,0 +1,42 @@
+package navigation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNavLocation_SaveAndLoad(t *testing.T) {
+	projectPath := t.TempDir()
+
+	location, err := LoadNavLocation(projectPath)
+	require.NoError(t, err)
+	assert.Nil(t, location)
+
+	// Not saved outside a claude-wm project
+	require.NoError(t, SaveNavLocation(projectPath, NavLocation{CurrentMenu: "ticket"}))
+	assert.NoFileExists(t, filepath.Join(projectPath, NavLocationFile))
+
+	require.NoError(t, os.Mkdir(filepath.Join(projectPath, ".claude-wm"), 0755))
+	require.NoError(t, SaveNavLocation(projectPath, NavLocation{CurrentMenu: "ticket", MenuStack: []string{"main"}}))
+
+	location, err = LoadNavLocation(projectPath)
+	require.NoError(t, err)
+	require.NotNil(t, location)
+	assert.Equal(t, "ticket", location.CurrentMenu)
+	assert.Equal(t, []string{"main"}, location.MenuStack)
+	assert.False(t, location.SavedAt.IsZero())
+}
+
+func TestNavLocation_LoadCorrupted(t *testing.T) {
+	projectPath := t.TempDir()
+	path := filepath.Join(projectPath, NavLocationFile)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+
+	_, err := LoadNavLocation(projectPath)
+	assert.Error(t, err)
+}
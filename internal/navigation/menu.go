@@ -3,9 +3,13 @@ package navigation
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"strconv"
 	"strings"
+
+	"claude-wm-cli/internal/theme"
 )
 
 // MenuOption represents a single menu option
@@ -32,22 +36,93 @@ type Menu struct {
 	ShowHelp    bool // Whether to show help text
 	AllowBack   bool // Whether to allow back navigation
 	AllowQuit   bool // Whether to allow quit
+
+	// AllowFavorites enables the f (pin or unpin an option) and F (open the
+	// favorites) shortcuts
+	AllowFavorites bool
 }
 
 // MenuDisplay handles the presentation and interaction of menus
 type MenuDisplay struct {
-	reader *bufio.Reader
+	reader        *bufio.Reader
+	keyNavigation bool        // Arrow keys and type-ahead instead of numbered selection
+	out           io.Writer   // Terminal, and session log when set; stdout when nil
+	log           *sessionLog // Session log set by SetOutputFile
+	breadcrumb    []string    // Labels of the menus leading to the one shown
 }
 
-// NewMenuDisplay creates a new menu display handler
+// NewMenuDisplay creates a new menu display handler. Menus are navigated with
+// the arrow keys when stdin is a terminal, by number otherwise.
 func NewMenuDisplay() *MenuDisplay {
 	return &MenuDisplay{
-		reader: bufio.NewReader(os.Stdin),
+		reader:        bufio.NewReader(os.Stdin),
+		keyNavigation: isTerminal(),
+	}
+}
+
+// SetKeyNavigation enables or disables arrow-key navigation. Without it,
+// options are selected by number or name.
+func (md *MenuDisplay) SetKeyNavigation(enabled bool) {
+	md.keyNavigation = enabled && isTerminal()
+}
+
+// KeyNavigation reports whether menus are navigated with the arrow keys
+func (md *MenuDisplay) KeyNavigation() bool {
+	return md.keyNavigation
+}
+
+// printf formats like fmt.Printf and writes the result through theme.Text to
+// the output of the display
+func (md *MenuDisplay) printf(format string, args ...interface{}) {
+	fmt.Fprint(md.writer(), theme.Text(fmt.Sprintf(format, args...)))
+}
+
+// println writes the arguments like fmt.Println through theme.Text to the
+// output of the display
+func (md *MenuDisplay) println(args ...interface{}) {
+	fmt.Fprint(md.writer(), theme.Text(fmt.Sprintln(args...)))
+}
+
+// writer returns the output of the display
+func (md *MenuDisplay) writer() io.Writer {
+	if md.out == nil {
+		return os.Stdout
 	}
+	return md.out
+}
+
+// SetBreadcrumb sets the path of menus, from the main menu to the one about to
+// be shown, rendered at the top of the menu screens. An empty path hides it.
+func (md *MenuDisplay) SetBreadcrumb(crumbs []string) {
+	md.breadcrumb = append([]string(nil), crumbs...)
+}
+
+// renderBreadcrumb formats the breadcrumb as "🧭 Main > Ticket Management",
+// or returns an empty string when no path is set
+func (md *MenuDisplay) renderBreadcrumb() string {
+	if len(md.breadcrumb) == 0 {
+		return ""
+	}
+	return "🧭 " + strings.Join(md.breadcrumb, " > ")
 }
 
 // Show displays the menu and handles user interaction
 func (md *MenuDisplay) Show(menu *Menu) (*MenuResult, error) {
+	if md.keyNavigation {
+		result, ok, err := md.showWithKeys(menu)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user input: %w", err)
+		}
+		if ok {
+			if result.SelectedOption != nil {
+				md.logInput(result.SelectedOption.Label)
+			} else {
+				md.logInput(result.Action)
+			}
+			return result, nil
+		}
+	}
+
 	for {
 		// Display the menu
 		md.displayMenu(menu)
@@ -65,7 +140,7 @@ func (md *MenuDisplay) Show(menu *Menu) (*MenuResult, error) {
 		}
 
 		// If we reach here, input was invalid - show error and retry
-		fmt.Println("\n❌ Invalid selection. Please try again.")
+		md.println("\n❌ Invalid selection. Please try again.")
 	}
 }
 
@@ -74,9 +149,12 @@ func (md *MenuDisplay) displayMenu(menu *Menu) {
 	// Clear screen (optional - can be made configurable)
 	// fmt.Print("\033[2J\033[H")
 
-	// Display title
+	// Display the path to the menu, then its title
+	if breadcrumb := md.renderBreadcrumb(); breadcrumb != "" {
+		md.printf("\n%s\n", theme.Muted(breadcrumb))
+	}
 	if menu.Title != "" {
-		fmt.Printf("\n═══ %s ═══\n\n", menu.Title)
+		md.printf("\n%s\n\n", theme.Heading("═══ "+menu.Title+" ═══"))
 	}
 
 	// Display options
@@ -86,18 +164,18 @@ func (md *MenuDisplay) displayMenu(menu *Menu) {
 			if !option.Enabled {
 				// Handle disabled options (separators and section headers)
 				if option.Label != "" && option.Label != "────────────────────────" {
-					fmt.Printf("\n═══ %s ═══\n", option.Label)
+					md.printf("\n%s\n", theme.Heading("═══ "+option.Label+" ═══"))
 				} else {
-					fmt.Println() // Empty line for separator
+					md.println() // Empty line for separator
 				}
 				continue
 			}
 
-			fmt.Printf("  %d) %s", optionNumber, option.Label)
+			md.printf("  %s %s", theme.Accent(fmt.Sprintf("%d)", optionNumber)), option.Label)
 			if option.Description != "" {
-				fmt.Printf(" - %s", option.Description)
+				md.printf(" - %s", theme.Muted(option.Description))
 			}
-			fmt.Println()
+			md.println()
 			optionNumber++
 		}
 	} else {
@@ -105,23 +183,23 @@ func (md *MenuDisplay) displayMenu(menu *Menu) {
 			if !option.Enabled {
 				// Handle disabled options (separators and section headers)
 				if option.Label != "" && option.Label != "────────────────────────" {
-					fmt.Printf("\n═══ %s ═══\n", option.Label)
+					md.printf("\n%s\n", theme.Heading("═══ "+option.Label+" ═══"))
 				} else {
-					fmt.Println() // Empty line for separator
+					md.println() // Empty line for separator
 				}
 				continue
 			}
 
-			fmt.Printf("  • %s", option.Label)
+			md.printf("  • %s", option.Label)
 			if option.Description != "" {
-				fmt.Printf(" - %s", option.Description)
+				md.printf(" - %s", theme.Muted(option.Description))
 			}
-			fmt.Println()
+			md.println()
 		}
 	}
 
 	// Display navigation options
-	fmt.Println()
+	md.println()
 	var navOptions []string
 
 	if menu.AllowBack {
@@ -133,12 +211,15 @@ func (md *MenuDisplay) displayMenu(menu *Menu) {
 	if menu.ShowHelp {
 		navOptions = append(navOptions, "h) Help")
 	}
+	if menu.AllowFavorites {
+		navOptions = append(navOptions, "f<n>) Pin", "F) Favorites")
+	}
 
 	if len(navOptions) > 0 {
-		fmt.Printf("  %s\n", strings.Join(navOptions, "  "))
+		md.printf("  %s\n", theme.Muted(strings.Join(navOptions, "  ")))
 	}
 
-	fmt.Print("\nSelect an option: ")
+	md.printf("\nSelect an option: ")
 }
 
 // getUserInput reads user input from stdin
@@ -148,12 +229,27 @@ func (md *MenuDisplay) getUserInput() (string, error) {
 		return "", err
 	}
 
-	return strings.TrimSpace(input), nil
+	input = strings.TrimSpace(input)
+	md.logInput(input)
+	return input, nil
 }
 
 // processInput processes user input and returns appropriate result
 func (md *MenuDisplay) processInput(menu *Menu, input string) *MenuResult {
-	input = strings.ToLower(strings.TrimSpace(input))
+	input = strings.TrimSpace(input)
+
+	// "F" opens the favorites, "f <option>" pins or unpins an option
+	if menu.AllowFavorites {
+		if input == "F" {
+			return &MenuResult{Action: ActionFavorites, Input: input}
+		}
+		if rest, ok := strings.CutPrefix(input, "f"); ok {
+			if option := findOption(menu, strings.ToLower(strings.TrimSpace(rest))); option != nil {
+				return &MenuResult{SelectedOption: option, Action: ActionToggleFavorite, Input: input}
+			}
+		}
+	}
+	input = strings.ToLower(input)
 
 	// Handle special shortcuts
 	switch input {
@@ -174,6 +270,21 @@ func (md *MenuDisplay) processInput(menu *Menu, input string) *MenuResult {
 		return nil
 	}
 
+	if option := findOption(menu, input); option != nil {
+		return &MenuResult{SelectedOption: option, Action: option.Action, Input: input}
+	}
+
+	return nil // Invalid input
+}
+
+// findOption returns the enabled option selected by input: its number in
+// numbered menus, or its ID or label ignoring case. It returns nil when none
+// matches.
+func findOption(menu *Menu, input string) *MenuOption {
+	if input == "" {
+		return nil
+	}
+
 	// Try to parse as number (for numbered menus)
 	if menu.ShowNumbers {
 		if num, err := strconv.Atoi(input); err == nil {
@@ -183,11 +294,7 @@ func (md *MenuDisplay) processInput(menu *Menu, input string) *MenuResult {
 				if option.Enabled {
 					enabledCount++
 					if enabledCount == num {
-						return &MenuResult{
-							SelectedOption: &menu.Options[i],
-							Action:         option.Action,
-							Input:          input,
-						}
+						return &menu.Options[i]
 					}
 				}
 			}
@@ -202,40 +309,36 @@ func (md *MenuDisplay) processInput(menu *Menu, input string) *MenuResult {
 
 		if strings.EqualFold(option.ID, input) ||
 			strings.EqualFold(option.Label, input) {
-			return &MenuResult{
-				SelectedOption: &menu.Options[i],
-				Action:         option.Action,
-				Input:          input,
-			}
+			return &menu.Options[i]
 		}
 	}
 
-	return nil // Invalid input
+	return nil
 }
 
 // ShowMessage displays a message to the user
 func (md *MenuDisplay) ShowMessage(message string) {
-	fmt.Printf("\n%s\n", message)
+	md.printf("\n%s\n", message)
 }
 
 // ShowError displays an error message to the user
 func (md *MenuDisplay) ShowError(message string) {
-	fmt.Printf("\n❌ Error: %s\n", message)
+	md.printf("\n%s\n", theme.Error("❌ Error: "+message))
 }
 
 // ShowSuccess displays a success message to the user
 func (md *MenuDisplay) ShowSuccess(message string) {
-	fmt.Printf("\n✅ %s\n", message)
+	md.printf("\n%s\n", theme.Success("✅ "+message))
 }
 
 // ShowWarning displays a warning message to the user
 func (md *MenuDisplay) ShowWarning(message string) {
-	fmt.Printf("\n⚠️  Warning: %s\n", message)
+	md.printf("\n%s\n", theme.Warning("⚠️  Warning: "+message))
 }
 
 // Confirm asks the user for yes/no confirmation
 func (md *MenuDisplay) Confirm(message string) (bool, error) {
-	fmt.Printf("%s (y/N): ", message)
+	md.printf("%s (y/N): ", message)
 
 	input, err := md.getUserInput()
 	if err != nil {
@@ -248,16 +351,122 @@ func (md *MenuDisplay) Confirm(message string) (bool, error) {
 
 // PromptString prompts the user for a string input
 func (md *MenuDisplay) PromptString(prompt string) (string, error) {
-	fmt.Printf("%s: ", prompt)
+	md.printf("%s: ", prompt)
 	return md.getUserInput()
 }
 
+// DefaultMultiLineEnd ends the input of PromptMultiLine when no end marker is given
+const DefaultMultiLineEnd = "."
+
+// PromptMultiLine prompts the user for text spanning several lines, read
+// until a line holding only endMarker (DefaultMultiLineEnd if empty)
+func (md *MenuDisplay) PromptMultiLine(prompt string, endMarker string) (string, error) {
+	if endMarker == "" {
+		endMarker = DefaultMultiLineEnd
+	}
+	md.printf("%s (end with a line containing only %q):\n", prompt, endMarker)
+
+	var lines []string
+	for {
+		line, err := md.reader.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		if err == nil && strings.TrimSpace(line) == endMarker {
+			break
+		}
+		if err != nil {
+			if err == io.EOF && (len(lines) > 0 || line != "") {
+				if strings.TrimSpace(line) != endMarker {
+					lines = append(lines, line)
+				}
+				break
+			}
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+
+	for _, line := range lines {
+		md.logInput(line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// PromptEditor opens the user's editor on a temporary file and returns what
+// was saved, without the "#" comment lines holding the prompt. The editor is
+// $EDITOR, or nano then vim when it is not set.
+func (md *MenuDisplay) PromptEditor(prompt string) (string, error) {
+	editor, err := findEditor(os.Getenv("EDITOR"))
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.CreateTemp("", "claude-wm-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	var header strings.Builder
+	header.WriteString("\n")
+	for _, line := range strings.Split(prompt, "\n") {
+		fmt.Fprintf(&header, "# %s\n", line)
+	}
+	header.WriteString("# Lines starting with '#' are ignored. Save and close the editor when done.\n")
+	if _, err := file.WriteString(header.String()); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", editor[0], err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, strings.TrimRight(line, "\r"))
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), nil
+}
+
+// findEditor returns the command line of the editor to use: editorEnv, the
+// value of $EDITOR, or the first of nano and vim that is installed
+func findEditor(editorEnv string) ([]string, error) {
+	if fields := strings.Fields(editorEnv); len(fields) > 0 {
+		if _, err := exec.LookPath(fields[0]); err != nil {
+			return nil, fmt.Errorf("editor %q from $EDITOR not found: set $EDITOR to an installed editor, e.g. export EDITOR=nano", fields[0])
+		}
+		return fields, nil
+	}
+
+	for _, fallback := range []string{"nano", "vim"} {
+		if _, err := exec.LookPath(fallback); err == nil {
+			return []string{fallback}, nil
+		}
+	}
+	return nil, fmt.Errorf("no editor found: $EDITOR is not set and neither nano nor vim is installed, set $EDITOR to your editor, e.g. export EDITOR=code --wait")
+}
+
 // PromptStringWithDefault prompts for string input with a default value
 func (md *MenuDisplay) PromptStringWithDefault(prompt, defaultValue string) (string, error) {
 	if defaultValue != "" {
-		fmt.Printf("%s [%s]: ", prompt, defaultValue)
+		md.printf("%s [%s]: ", prompt, defaultValue)
 	} else {
-		fmt.Printf("%s: ", prompt)
+		md.printf("%s: ", prompt)
 	}
 
 	input, err := md.getUserInput()
@@ -278,7 +487,7 @@ func (md *MenuDisplay) WaitForKeyPress(message string) error {
 		message = "Press any key to continue..."
 	}
 
-	fmt.Printf("\n%s ", message)
+	md.printf("\n%s ", message)
 	_, err := md.reader.ReadString('\n')
 	return err
 }
@@ -2,9 +2,14 @@ package navigation
 
 import (
 	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"claude-wm-cli/internal/theme"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -271,6 +276,24 @@ func TestMenuDisplay_HelperFunctions(t *testing.T) {
 	display.ShowWarning("Test warning")
 }
 
+func TestMenuDisplay_Colors(t *testing.T) {
+	menu := NewMenuBuilder("Test Menu").
+		AddOption("opt1", "Option 1", "First option", "action1").
+		Build()
+	display := NewMenuDisplay()
+
+	plain := captureOutput(func() { display.displayMenu(menu) })
+	assert.NotContains(t, plain, "\033[", "no escape codes when output is not colored")
+	assert.Contains(t, plain, "  1) Option 1 - First option")
+
+	theme.SetColor(true)
+	defer theme.SetColor(false)
+	colored := captureOutput(func() { display.displayMenu(menu) })
+	assert.Contains(t, colored, theme.Heading("═══ Test Menu ═══"))
+	assert.Contains(t, colored, theme.Accent("1)")+" Option 1")
+	assert.Contains(t, colored, theme.Muted("First option"))
+}
+
 func TestMenuDisplay_WithMockInput(t *testing.T) {
 	// Create a string reader for mock input
 	input := "1\n"
@@ -362,3 +385,90 @@ func TestMenuDisplay_PromptStringWithDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestMenuDisplay_PromptMultiLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		endMarker string
+		expected  string
+	}{
+		{
+			name:      "default end marker",
+			input:     "first line\n  indented line\n\nlast line\n.\nignored\n",
+			endMarker: "",
+			expected:  "first line\n  indented line\n\nlast line",
+		},
+		{
+			name:      "custom end marker",
+			input:     "a line with . inside\nEOF\n",
+			endMarker: "EOF",
+			expected:  "a line with . inside",
+		},
+		{
+			name:      "end of input without marker",
+			input:     "only line",
+			endMarker: ".",
+			expected:  "only line",
+		},
+		{
+			name:      "empty text",
+			input:     ".\n",
+			endMarker: ".",
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			display := &MenuDisplay{reader: bufio.NewReader(strings.NewReader(tt.input))}
+
+			result, err := display.PromptMultiLine("Enter something", tt.endMarker)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestMenuDisplay_PromptEditor(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "editor.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf '# still a comment\\nFix the login\\nredirect\\n' >> \"$1\"\n"), 0755))
+	t.Setenv("EDITOR", script)
+
+	display := &MenuDisplay{reader: bufio.NewReader(strings.NewReader(""))}
+	result, err := display.PromptEditor("Describe the task")
+	require.NoError(t, err)
+	assert.Equal(t, "Fix the login\nredirect", result)
+}
+
+func TestFindEditor(t *testing.T) {
+	editor, err := findEditor("sh -c true")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sh", "-c", "true"}, editor)
+
+	_, err = findEditor("no-such-editor-claude-wm")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "$EDITOR")
+
+	t.Setenv("PATH", t.TempDir())
+	_, err = findEditor("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "set $EDITOR")
+}
+
+func TestMenuDisplay_Breadcrumb(t *testing.T) {
+	var out bytes.Buffer
+	md := &MenuDisplay{out: &out}
+	assert.Empty(t, md.renderBreadcrumb())
+
+	md.SetBreadcrumb([]string{"Main", "Ticket Management", "Execute"})
+	assert.Equal(t, "🧭 Main > Ticket Management > Execute", md.renderBreadcrumb())
+
+	md.displayMenu(NewMenuBuilder("⚡ Execute").AddOption("plan", "Plan", "", "plan").Build())
+	assert.Contains(t, out.String(), "🧭 Main > Ticket Management > Execute")
+	assert.Less(t, strings.Index(out.String(), "🧭"), strings.Index(out.String(), "⚡ Execute"))
+
+	md.SetBreadcrumb(nil)
+	assert.Empty(t, md.renderBreadcrumb())
+}
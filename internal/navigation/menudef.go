@@ -0,0 +1,165 @@
+package navigation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MenuDefinitionFiles are the files, relative to the project root, searched
+// in order for custom menu definitions. JSON files are read as YAML.
+var MenuDefinitionFiles = []string{
+	".claude-wm/user/menus.yaml",
+	".claude-wm/user/menus.yml",
+	".claude-wm/user/menus.json",
+}
+
+// MenuActionPrefix marks an action opening another menu, e.g. "menu:tools"
+const MenuActionPrefix = "menu:"
+
+// MenuDefinitions are the custom menus loaded from a menu definition file,
+// keyed by menu name ("main", "ticket", ... or a new name)
+type MenuDefinitions struct {
+	Menus map[string]MenuDefinition `yaml:"menus"`
+	File  string                    `yaml:"-"` // File the definitions were loaded from
+}
+
+// MenuDefinition customizes a built-in menu, or defines a new one. Its groups
+// are added after the built-in options unless Replace is set.
+type MenuDefinition struct {
+	Title   string      `yaml:"title"`
+	Replace bool        `yaml:"replace"`
+	Groups  []MenuGroup `yaml:"groups"`
+}
+
+// MenuGroup is a section of a menu, shown under its label
+type MenuGroup struct {
+	Label   string                 `yaml:"label"`
+	Options []MenuOptionDefinition `yaml:"options"`
+}
+
+// MenuOptionDefinition is a custom menu option. Its action is a built-in
+// action, a Claude slash command such as "/custom:review", or "menu:<name>".
+type MenuOptionDefinition struct {
+	ID          string `yaml:"id"`
+	Label       string `yaml:"label"`
+	Description string `yaml:"description"`
+	Action      string `yaml:"action"`
+}
+
+// LoadMenuDefinitions loads the first menu definition file found in the
+// project, or returns nil if there is none
+func LoadMenuDefinitions(projectPath string) (*MenuDefinitions, error) {
+	for _, file := range MenuDefinitionFiles {
+		data, err := os.ReadFile(filepath.Join(projectPath, file))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read menu definitions: %w", err)
+		}
+
+		var defs MenuDefinitions
+		if err := yaml.Unmarshal(data, &defs); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		defs.File = file
+		if err := defs.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", file, err)
+		}
+		return &defs, nil
+	}
+	return nil, nil
+}
+
+// Validate checks that every option has a label and an action, and that
+// every menu opened by an option is defined or built in
+func (d *MenuDefinitions) Validate() error {
+	for name, menu := range d.Menus {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("menu with an empty name")
+		}
+		for _, group := range menu.Groups {
+			for i, option := range group.Options {
+				where := fmt.Sprintf("menu %q, option %d", name, i+1)
+				if option.Label == "" {
+					return fmt.Errorf("%s: label is required", where)
+				}
+				if option.Action == "" {
+					return fmt.Errorf("%s (%s): action is required", where, option.Label)
+				}
+				if target, ok := strings.CutPrefix(option.Action, MenuActionPrefix); ok {
+					if _, defined := d.Menus[target]; !defined && !IsBuiltinMenu(target) {
+						return fmt.Errorf("%s (%s): unknown menu %q", where, option.Label, target)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// builtinMenus are the names of the menus built into the interactive navigation
+var builtinMenus = []string{"main", "project", "epics", "current-epics", "current-story", "ticket", "claude", "metrics", "favorites"}
+
+// IsBuiltinMenu reports whether name is a menu built into the interactive navigation
+func IsBuiltinMenu(name string) bool {
+	for _, builtin := range builtinMenus {
+		if name == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+// Menu returns the menu named name with its custom definition applied.
+// builtin is the built-in menu, or nil for a menu only defined here; the
+// result is nil when neither exists.
+func (d *MenuDefinitions) Menu(name string, builtin *Menu) *Menu {
+	def, ok := MenuDefinition{}, false
+	if d != nil {
+		def, ok = d.Menus[name]
+	}
+	if !ok {
+		return builtin
+	}
+
+	menu := builtin
+	if menu == nil {
+		menu = &Menu{ShowNumbers: true, ShowHelp: true, AllowBack: true, AllowQuit: true}
+	}
+	if def.Title != "" {
+		menu.Title = def.Title
+	}
+	if def.Replace {
+		menu.Options = nil
+	}
+
+	for _, group := range def.Groups {
+		if group.Label != "" {
+			menu.Options = append(menu.Options, MenuOption{
+				ID:      name + "-" + strings.ToLower(strings.ReplaceAll(group.Label, " ", "-")) + "-header",
+				Label:   group.Label,
+				Enabled: false,
+			})
+		}
+		for _, option := range group.Options {
+			id := option.ID
+			if id == "" {
+				id = option.Action
+			}
+			menu.Options = append(menu.Options, MenuOption{
+				ID:          id,
+				Label:       option.Label,
+				Description: option.Description,
+				Action:      option.Action,
+				Enabled:     true,
+			})
+		}
+	}
+
+	return menu
+}
@@ -0,0 +1,143 @@
+package navigation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeMenuDefinitions(t *testing.T, projectPath, file, content string) {
+	t.Helper()
+	path := filepath.Join(projectPath, file)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestLoadMenuDefinitions_Missing(t *testing.T) {
+	defs, err := LoadMenuDefinitions(t.TempDir())
+	require.NoError(t, err)
+	assert.Nil(t, defs)
+}
+
+func TestLoadMenuDefinitions_YAML(t *testing.T) {
+	dir := t.TempDir()
+	writeMenuDefinitions(t, dir, ".claude-wm/user/menus.yaml", `
+menus:
+  main:
+    groups:
+      - label: Team
+        options:
+          - label: Team tools
+            action: menu:team
+  team:
+    title: Team Tools
+    groups:
+      - options:
+          - id: security
+            label: Security review
+            description: Run the security review
+            action: /team:security-review
+`)
+
+	defs, err := LoadMenuDefinitions(dir)
+	require.NoError(t, err)
+	require.NotNil(t, defs)
+	assert.Equal(t, ".claude-wm/user/menus.yaml", defs.File)
+	require.Contains(t, defs.Menus, "team")
+	assert.Equal(t, "Team Tools", defs.Menus["team"].Title)
+	assert.Equal(t, "/team:security-review", defs.Menus["team"].Groups[0].Options[0].Action)
+}
+
+func TestLoadMenuDefinitions_JSON(t *testing.T) {
+	dir := t.TempDir()
+	writeMenuDefinitions(t, dir, ".claude-wm/user/menus.json",
+		`{"menus": {"ticket": {"groups": [{"label": "Custom", "options": [{"label": "Lint", "action": "/team:lint"}]}]}}}`)
+
+	defs, err := LoadMenuDefinitions(dir)
+	require.NoError(t, err)
+	require.NotNil(t, defs)
+	assert.Equal(t, "/team:lint", defs.Menus["ticket"].Groups[0].Options[0].Action)
+}
+
+func TestLoadMenuDefinitions_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"unparsable", "menus: [", "failed to parse"},
+		{"missing label", "menus:\n  main:\n    groups:\n      - options:\n          - action: /x\n", "label is required"},
+		{"missing action", "menus:\n  main:\n    groups:\n      - options:\n          - label: X\n", "action is required"},
+		{"unknown menu", "menus:\n  main:\n    groups:\n      - options:\n          - label: X\n            action: menu:nowhere\n", `unknown menu "nowhere"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeMenuDefinitions(t, dir, ".claude-wm/user/menus.yaml", tt.content)
+
+			_, err := LoadMenuDefinitions(dir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestMenuDefinitions_Menu(t *testing.T) {
+	builtin := func() *Menu {
+		return NewMenuBuilder("Built-in").AddOption("a", "Option A", "", "action-a").Build()
+	}
+	defs := &MenuDefinitions{Menus: map[string]MenuDefinition{
+		"main": {Groups: []MenuGroup{{Label: "Custom", Options: []MenuOptionDefinition{
+			{Label: "Review", Action: "/team:review"},
+		}}}},
+		"ticket": {Title: "Tickets", Replace: true, Groups: []MenuGroup{{Options: []MenuOptionDefinition{
+			{ID: "lint", Label: "Lint", Action: "/team:lint"},
+		}}}},
+		"team": {Title: "Team", Groups: []MenuGroup{{Options: []MenuOptionDefinition{
+			{Label: "Deploy", Action: "/team:deploy"},
+		}}}},
+	}}
+
+	t.Run("appends groups", func(t *testing.T) {
+		menu := defs.Menu("main", builtin())
+		require.Len(t, menu.Options, 3)
+		assert.Equal(t, "Built-in", menu.Title)
+		assert.Equal(t, "Option A", menu.Options[0].Label)
+		assert.Equal(t, "Custom", menu.Options[1].Label)
+		assert.False(t, menu.Options[1].Enabled)
+		assert.Equal(t, "/team:review", menu.Options[2].ID)
+		assert.True(t, menu.Options[2].Enabled)
+	})
+
+	t.Run("replaces options", func(t *testing.T) {
+		menu := defs.Menu("ticket", builtin())
+		require.Len(t, menu.Options, 1)
+		assert.Equal(t, "Tickets", menu.Title)
+		assert.Equal(t, "lint", menu.Options[0].ID)
+	})
+
+	t.Run("new menu", func(t *testing.T) {
+		menu := defs.Menu("team", nil)
+		require.NotNil(t, menu)
+		assert.Equal(t, "Team", menu.Title)
+		assert.True(t, menu.AllowBack)
+		require.Len(t, menu.Options, 1)
+		assert.Equal(t, "/team:deploy", menu.Options[0].Action)
+	})
+
+	t.Run("undefined menu", func(t *testing.T) {
+		menu := builtin()
+		assert.Same(t, menu, defs.Menu("epics", menu))
+		assert.Nil(t, defs.Menu("unknown", nil))
+	})
+
+	t.Run("no definitions", func(t *testing.T) {
+		var none *MenuDefinitions
+		menu := builtin()
+		assert.Same(t, menu, none.Menu("main", menu))
+	})
+}
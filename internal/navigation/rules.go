@@ -0,0 +1,185 @@
+package navigation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ContextRulesFile holds the custom project state rules, relative to the
+// project root
+const ContextRulesFile = ".claude-wm/context-rules.json"
+
+// ErrNoRuleMatched is returned by EvaluateRules when no rule matches
+var ErrNoRuleMatched = errors.New("no context rule matches")
+
+// ContextRule maps a project layout to a workflow state. Paths are relative
+// to the project root and may be glob patterns such as "docs/epics/*.md".
+type ContextRule struct {
+	State          WorkflowState
+	RequiredFiles  []string       // Each must match at least one file
+	ForbiddenFiles []string       // None may match a file
+	MinFileCount   map[string]int // Minimum number of files in a directory, or matching a pattern
+}
+
+// contextRuleJSON is a ContextRule as written in the rules file, with the
+// state by name
+type contextRuleJSON struct {
+	State          string         `json:"state"`
+	RequiredFiles  []string       `json:"required_files,omitempty"`
+	ForbiddenFiles []string       `json:"forbidden_files,omitempty"`
+	MinFileCount   map[string]int `json:"min_file_count,omitempty"`
+}
+
+// UnmarshalJSON reads a rule with its state given by name, such as
+// "has_epics" or "Epic In Progress"
+func (r *ContextRule) UnmarshalJSON(data []byte) error {
+	var raw contextRuleJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	state, err := ParseWorkflowState(raw.State)
+	if err != nil {
+		return err
+	}
+	*r = ContextRule{State: state, RequiredFiles: raw.RequiredFiles, ForbiddenFiles: raw.ForbiddenFiles, MinFileCount: raw.MinFileCount}
+	return nil
+}
+
+// MarshalJSON writes the rule with its state by name
+func (r ContextRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(contextRuleJSON{State: stateID(r.State), RequiredFiles: r.RequiredFiles,
+		ForbiddenFiles: r.ForbiddenFiles, MinFileCount: r.MinFileCount})
+}
+
+// ParseWorkflowState returns the state named name, either as displayed
+// ("Has Epics") or as an identifier ("has_epics", "has-epics")
+func ParseWorkflowState(name string) (WorkflowState, error) {
+	id := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(name)))
+	for state := StateNotInitialized; state <= StateTaskInProgress; state++ {
+		if id == stateID(state) {
+			return state, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown project state %q", name)
+}
+
+// stateID returns the identifier of state, e.g. "epic_in_progress"
+func stateID(state WorkflowState) string {
+	return strings.ReplaceAll(strings.ToLower(state.String()), " ", "_")
+}
+
+// LoadContextRules reads the custom rules of the project, or returns nil when
+// it has none
+func LoadContextRules(projectPath string) ([]ContextRule, error) {
+	data, err := os.ReadFile(filepath.Join(projectPath, ContextRulesFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read context rules: %w", err)
+	}
+
+	var rules []ContextRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ContextRulesFile, err)
+	}
+	return rules, nil
+}
+
+// EvaluateRules returns the state of the first rule matching the files of
+// workDir, rules earlier in the list taking priority. It returns
+// ErrNoRuleMatched when none matches.
+func EvaluateRules(rules []ContextRule, workDir string) (WorkflowState, error) {
+	for _, rule := range rules {
+		matched, _, err := rule.Match(workDir)
+		if err != nil {
+			return 0, err
+		}
+		if matched {
+			return rule.State, nil
+		}
+	}
+	return 0, ErrNoRuleMatched
+}
+
+// Match reports whether the files of workDir satisfy the rule, and otherwise
+// the first condition that fails
+func (r ContextRule) Match(workDir string) (bool, string, error) {
+	for _, pattern := range r.RequiredFiles {
+		matches, err := globFiles(workDir, pattern)
+		if err != nil {
+			return false, "", err
+		}
+		if len(matches) == 0 {
+			return false, "missing " + pattern, nil
+		}
+	}
+
+	for _, pattern := range r.ForbiddenFiles {
+		matches, err := globFiles(workDir, pattern)
+		if err != nil {
+			return false, "", err
+		}
+		if len(matches) > 0 {
+			return false, "found " + matches[0], nil
+		}
+	}
+
+	patterns := make([]string, 0, len(r.MinFileCount))
+	for pattern := range r.MinFileCount {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		count, err := countFiles(workDir, pattern)
+		if err != nil {
+			return false, "", err
+		}
+		if min := r.MinFileCount[pattern]; count < min {
+			return false, fmt.Sprintf("%d file(s) in %s, %d required", count, pattern, min), nil
+		}
+	}
+
+	return true, "", nil
+}
+
+// globFiles returns the paths, relative to workDir, matching pattern
+func globFiles(workDir, pattern string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(workDir, filepath.FromSlash(pattern)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	for i, match := range matches {
+		if rel, err := filepath.Rel(workDir, match); err == nil {
+			matches[i] = filepath.ToSlash(rel)
+		}
+	}
+	return matches, nil
+}
+
+// countFiles returns the number of files in the directory pattern, or
+// matching pattern when it isn't a directory
+func countFiles(workDir, pattern string) (int, error) {
+	dir := filepath.Join(workDir, filepath.FromSlash(pattern))
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read %s: %w", pattern, err)
+		}
+		count := 0
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				count++
+			}
+		}
+		return count, nil
+	}
+
+	matches, err := globFiles(workDir, pattern)
+	return len(matches), err
+}
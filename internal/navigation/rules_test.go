@@ -0,0 +1,139 @@
+package navigation
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeRuleFiles(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0644))
+	}
+}
+
+func TestParseWorkflowState(t *testing.T) {
+	for name, want := range map[string]WorkflowState{
+		"not_initialized":  StateNotInitialized,
+		"Has Epics":        StateHasEpics,
+		"epic-in-progress": StateEpicInProgress,
+		"TASK_IN_PROGRESS": StateTaskInProgress,
+	} {
+		state, err := ParseWorkflowState(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, state, name)
+	}
+
+	_, err := ParseWorkflowState("done")
+	assert.Error(t, err)
+}
+
+func TestEvaluateRules(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFiles(t, dir, "work/epics/one.md", "work/epics/two.md", "work/notes.txt")
+
+	rules := []ContextRule{
+		{State: StateTaskInProgress, RequiredFiles: []string{"work/current-task.md"}},
+		{State: StateEpicInProgress, MinFileCount: map[string]int{"work/epics": 3}},
+		{State: StateHasEpics, RequiredFiles: []string{"work/epics/*.md"}, ForbiddenFiles: []string{"work/*.lock"}},
+		{State: StateProjectInitialized, RequiredFiles: []string{"work"}},
+	}
+
+	// The first matching rule wins
+	state, err := EvaluateRules(rules, dir)
+	require.NoError(t, err)
+	assert.Equal(t, StateHasEpics, state)
+
+	matched, reason, err := rules[1].Match(dir)
+	require.NoError(t, err)
+	assert.False(t, matched)
+	assert.Equal(t, "2 file(s) in work/epics, 3 required", reason)
+
+	// A forbidden file skips the rule
+	writeRuleFiles(t, dir, "work/sync.lock")
+	state, err = EvaluateRules(rules, dir)
+	require.NoError(t, err)
+	assert.Equal(t, StateProjectInitialized, state)
+	_, reason, _ = rules[2].Match(dir)
+	assert.Equal(t, "found work/sync.lock", reason)
+
+	// Patterns count matching files
+	matched, _, err = ContextRule{MinFileCount: map[string]int{"work/epics/*.md": 2}}.Match(dir)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	_, err = EvaluateRules(rules[:1], dir)
+	assert.ErrorIs(t, err, ErrNoRuleMatched)
+
+	_, err = EvaluateRules([]ContextRule{{RequiredFiles: []string{"work/["}}}, dir)
+	assert.Error(t, err)
+}
+
+func TestLoadContextRules(t *testing.T) {
+	dir := t.TempDir()
+	rules, err := LoadContextRules(dir)
+	require.NoError(t, err)
+	assert.Nil(t, rules)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude-wm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ContextRulesFile), []byte(`[
+		{"state": "story_in_progress", "required_files": ["stories/current.md"], "min_file_count": {"stories": 1}},
+		{"state": "Has Epics", "forbidden_files": ["*.lock"]}
+	]`), 0644))
+
+	rules, err = LoadContextRules(dir)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, StateStoryInProgress, rules[0].State)
+	assert.Equal(t, []string{"stories/current.md"}, rules[0].RequiredFiles)
+	assert.Equal(t, map[string]int{"stories": 1}, rules[0].MinFileCount)
+	assert.Equal(t, StateHasEpics, rules[1].State)
+
+	data, err := json.Marshal(rules[1])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"state": "has_epics", "forbidden_files": ["*.lock"]}`, string(data))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ContextRulesFile), []byte(`[{"state": "shipped"}]`), 0644))
+	_, err = LoadContextRules(dir)
+	assert.ErrorContains(t, err, `unknown project state "shipped"`)
+}
+
+func TestContextDetector_CustomRules(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFiles(t, dir, "work/epics/one.md")
+
+	// Without rules, a project without docs/ is not initialized
+	ctx, err := NewContextDetector(dir).EnableCache(true).DetectContext()
+	require.NoError(t, err)
+	assert.Equal(t, StateNotInitialized, ctx.State)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude-wm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ContextRulesFile),
+		[]byte(`[{"state": "has_epics", "min_file_count": {"work/epics": 1}}]`), 0644))
+
+	ctx, err = NewContextDetector(dir).EnableCache(true).DetectContext()
+	require.NoError(t, err)
+	assert.Equal(t, StateHasEpics, ctx.State)
+	assert.Contains(t, ctx.AvailableActions, "start-epic")
+
+	// No matching rule falls back to the built-in detection
+	require.NoError(t, os.Remove(filepath.Join(dir, "work/epics/one.md")))
+	ctx, err = NewContextDetector(dir).EnableCache(true).DetectContext()
+	require.NoError(t, err)
+	assert.Equal(t, StateNotInitialized, ctx.State)
+
+	// An invalid rules file is reported and ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ContextRulesFile), []byte(`{`), 0644))
+	ctx, err = NewContextDetector(dir).DetectContext()
+	require.NoError(t, err)
+	assert.Equal(t, StateNotInitialized, ctx.State)
+	require.NotEmpty(t, ctx.Issues)
+	assert.Contains(t, ctx.Issues[0], "Ignoring custom context rules")
+}
@@ -0,0 +1,117 @@
+package navigation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SessionLogEnv names the file the interactive session is logged to when
+// --output-file is not given
+const SessionLogEnv = "CLAUDE_WM_SESSION_LOG"
+
+// ansiEscape matches the color and cursor escape sequences kept out of the
+// session log
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// sessionLog writes the menu output to a file, each line prefixed with its
+// RFC3339 timestamp and without terminal escape sequences
+type sessionLog struct {
+	mu          sync.Mutex
+	file        *os.File
+	w           *bufio.Writer
+	atLineStart bool
+	now         func() time.Time
+}
+
+// openSessionLog opens path for append
+func openSessionLog(path string) (*sessionLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session log: %w", err)
+	}
+	return newSessionLog(file), nil
+}
+
+func newSessionLog(file *os.File) *sessionLog {
+	return &sessionLog{file: file, w: bufio.NewWriter(file), atLineStart: true, now: time.Now}
+}
+
+// Write logs p, starting each new line with a timestamp
+func (l *sessionLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	text := ansiEscape.ReplaceAllString(string(p), "")
+	for len(text) > 0 {
+		if l.atLineStart {
+			if _, err := l.w.WriteString(l.now().Format(time.RFC3339) + " "); err != nil {
+				return 0, err
+			}
+			l.atLineStart = false
+		}
+
+		line := text
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			line = text[:i+1]
+			l.atLineStart = true
+		}
+		if _, err := l.w.WriteString(line); err != nil {
+			return 0, err
+		}
+		text = text[len(line):]
+	}
+	return len(p), nil
+}
+
+// Close flushes the log and closes its file
+func (l *sessionLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	err := l.w.Flush()
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// SetOutputFile also writes the menus, messages and prompts to the file at
+// path, appending to it with each line timestamped. User input is logged
+// after a "> " marker. Call Close to flush the file.
+func (md *MenuDisplay) SetOutputFile(path string) error {
+	log, err := openSessionLog(path)
+	if err != nil {
+		return err
+	}
+	if md.log != nil {
+		md.log.Close()
+	}
+	md.log = log
+	md.out = io.MultiWriter(os.Stdout, log)
+	return nil
+}
+
+// Close flushes and closes the file set by SetOutputFile, if any
+func (md *MenuDisplay) Close() error {
+	if md.log == nil {
+		return nil
+	}
+	err := md.log.Close()
+	md.log = nil
+	md.out = os.Stdout
+	return err
+}
+
+// logInput records what the user entered in the session log only, the
+// terminal echoing it already
+func (md *MenuDisplay) logInput(input string) {
+	if md.log != nil {
+		fmt.Fprintf(md.log, "> %s\n", input)
+	}
+}
@@ -0,0 +1,54 @@
+package navigation
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionLog_TimestampsLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.log")
+	log, err := openSessionLog(path)
+	require.NoError(t, err)
+	log.now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	_, err = log.Write([]byte("\nfirst \x1b[1mbold\x1b[0m line\nSelect: "))
+	require.NoError(t, err)
+	_, err = log.Write([]byte("2\n"))
+	require.NoError(t, err)
+	require.NoError(t, log.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-01T12:00:00Z \n"+
+		"2025-03-01T12:00:00Z first bold line\n"+
+		"2025-03-01T12:00:00Z Select: 2\n", string(data))
+}
+
+func TestMenuDisplay_SetOutputFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.log")
+	require.NoError(t, os.WriteFile(path, []byte("previous session\n"), 0644))
+
+	display := &MenuDisplay{reader: bufio.NewReader(strings.NewReader("Alice\n"))}
+	require.NoError(t, display.SetOutputFile(path))
+	display.ShowSuccess("Saved")
+	name, err := display.PromptString("Name")
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", name)
+	require.NoError(t, display.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	log := string(data)
+	assert.True(t, strings.HasPrefix(log, "previous session\n"), "the log is appended to")
+	assert.Contains(t, log, "✅ Saved")
+	assert.Contains(t, log, "Name: > Alice\n")
+
+	assert.Error(t, display.SetOutputFile(filepath.Join(t.TempDir(), "missing", "session.log")))
+}
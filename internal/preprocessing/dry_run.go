@@ -0,0 +1,164 @@
+package preprocessing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"claude-wm-cli/internal/metrics"
+	"claude-wm-cli/internal/navigation"
+)
+
+// Operations of a planned change
+const (
+	OperationCreate = "create" // A file or directory is created
+	OperationModify = "modify" // An existing file is rewritten
+	OperationDelete = "delete" // A file or directory is removed
+	OperationRemote = "remote" // A change outside the project, e.g. an issue comment
+)
+
+// PreprocessOptions tunes how the Preprocess* functions run
+type PreprocessOptions struct {
+	// DryRun collects the changes that would be made instead of making them
+	DryRun bool
+	// IdempotencyTTL skips preprocessing when the same command prepared the
+	// workspace from the same source data less than this long ago; 0 disables
+	// the check
+	IdempotencyTTL time.Duration
+	// Force runs preprocessing even when the workspace is already prepared
+	Force bool
+	// Sequential runs the preprocessing steps one after the other instead of
+	// running independent steps concurrently
+	Sequential bool
+	// Timer records the preprocessing duration when set
+	Timer *metrics.Timer
+}
+
+// PlannedChange is a change made, or planned in dry-run mode, by preprocessing
+type PlannedChange struct {
+	Operation   string `json:"operation"`
+	Path        string `json:"path"` // Relative to the project root
+	Description string `json:"description"`
+}
+
+// PreprocessResult lists the changes made by a preprocessing step, or the
+// changes it would make in dry-run mode
+type PreprocessResult struct {
+	DryRun  bool            `json:"dry_run"`
+	Skipped bool            `json:"skipped,omitempty"` // The workspace was already prepared
+	Changes []PlannedChange `json:"changes"`
+}
+
+// preprocessRun carries the options and collected changes of one
+// preprocessing step. A nil menuDisplay runs the step without output.
+type preprocessRun struct {
+	projectPath string
+	menuDisplay *navigation.MenuDisplay
+	options     PreprocessOptions
+	result      *PreprocessResult
+	mu          sync.Mutex // Guards result and the display, steps running concurrently
+}
+
+func newPreprocessRun(projectPath string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) *preprocessRun {
+	return &preprocessRun{
+		projectPath: projectPath,
+		menuDisplay: menuDisplay,
+		options:     options,
+		result:      &PreprocessResult{DryRun: options.DryRun, Changes: []PlannedChange{}},
+	}
+}
+
+// change records a change to relPath and applies it unless in dry-run mode
+func (r *preprocessRun) change(operation, relPath, description string, apply func() error) error {
+	r.mu.Lock()
+	r.result.Changes = append(r.result.Changes, PlannedChange{
+		Operation:   operation,
+		Path:        filepath.ToSlash(relPath),
+		Description: description,
+	})
+	r.mu.Unlock()
+	if r.options.DryRun {
+		return nil
+	}
+	return apply()
+}
+
+// writeOperation returns whether writing relPath creates or modifies it
+func (r *preprocessRun) writeOperation(relPath string) string {
+	if _, err := os.Stat(filepath.Join(r.projectPath, relPath)); err == nil {
+		return OperationModify
+	}
+	return OperationCreate
+}
+
+// path returns the absolute path of relPath in the project
+func (r *preprocessRun) path(relPath string) string {
+	return filepath.Join(r.projectPath, relPath)
+}
+
+// message shows an informational message
+func (r *preprocessRun) message(message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.menuDisplay != nil {
+		r.menuDisplay.ShowMessage(message)
+	}
+}
+
+// done shows the confirmation of a change, which is not made in dry-run mode
+func (r *preprocessRun) done(message string) {
+	if !r.options.DryRun {
+		r.message(message)
+	}
+}
+
+// warning shows a warning message
+func (r *preprocessRun) warning(message string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.menuDisplay != nil {
+		r.menuDisplay.ShowWarning(message)
+	}
+}
+
+// finish shows the success message of the step, or the number of planned
+// changes in dry-run mode, and returns the result
+func (r *preprocessRun) finish(success string) *PreprocessResult {
+	if r.menuDisplay != nil {
+		if r.options.DryRun {
+			r.menuDisplay.ShowMessage(fmt.Sprintf("🔍 Dry run: %d planned changes", len(r.result.Changes)))
+		} else {
+			r.menuDisplay.ShowSuccess(success)
+		}
+	}
+	return r.result
+}
+
+// DryRunFromStory returns the changes PreprocessFromStory would make
+func DryRunFromStory(projectPath string) ([]PlannedChange, error) {
+	result, err := PreprocessFromStory(projectPath, nil, PreprocessOptions{DryRun: true})
+	if err != nil {
+		return nil, err
+	}
+	return result.Changes, nil
+}
+
+// DryRunFromInput returns the changes PreprocessFromInput would make
+func DryRunFromInput(projectPath, description string) ([]PlannedChange, error) {
+	result, err := PreprocessFromInput(projectPath, description, nil, PreprocessOptions{DryRun: true})
+	if err != nil {
+		return nil, err
+	}
+	return result.Changes, nil
+}
+
+// DryRunPlanTask returns the changes PreprocessPlanTask would make
+func DryRunPlanTask(projectPath string) ([]PlannedChange, error) {
+	result, err := PreprocessPlanTask(projectPath, nil, PreprocessOptions{DryRun: true})
+	if err != nil {
+		return nil, err
+	}
+	return result.Changes, nil
+}
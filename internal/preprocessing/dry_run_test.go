@@ -0,0 +1,73 @@
+package preprocessing
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStoryProject(t *testing.T) string {
+	t.Helper()
+	projectPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectPath, "docs/2-current-epic"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(projectPath, "docs/3-current-task"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectPath, "docs/3-current-task/notes.md"), []byte("old task"), 0644))
+
+	stories := &StoriesData{
+		Stories: map[string]Story{
+			"STORY-001": {ID: "STORY-001", Tasks: []StoryTask{{ID: "TASK-001", Title: "Write parser", Status: "todo"}}},
+		},
+		EpicContext: EpicContext{ID: "EPIC-001"},
+	}
+	require.NoError(t, writeStoriesJSON(filepath.Join(projectPath, storiesFile), stories))
+	return projectPath
+}
+
+func TestDryRunFromStory(t *testing.T) {
+	projectPath := setupStoryProject(t)
+	storiesBefore, err := os.ReadFile(filepath.Join(projectPath, storiesFile))
+	require.NoError(t, err)
+
+	changes, err := DryRunFromStory(projectPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, []PlannedChange{
+		{Operation: OperationDelete, Path: currentTaskDir, Description: "Clean the current task workspace"},
+		{Operation: OperationModify, Path: storiesFile, Description: "Set task TASK-001 status to in_progress"},
+		{Operation: OperationCreate, Path: currentTaskFile, Description: "Initialize task TASK-001 from its story"},
+		{Operation: OperationCreate, Path: StampFile, Description: "Record the preprocessing stamp"},
+	}, changes)
+
+	// Nothing was written
+	storiesAfter, err := os.ReadFile(filepath.Join(projectPath, storiesFile))
+	require.NoError(t, err)
+	assert.Equal(t, storiesBefore, storiesAfter)
+	assert.FileExists(t, filepath.Join(projectPath, "docs/3-current-task/notes.md"))
+	assert.NoFileExists(t, filepath.Join(projectPath, currentTaskFile))
+}
+
+func TestPreprocessFromStory_AppliesChanges(t *testing.T) {
+	projectPath := setupStoryProject(t)
+
+	result, err := PreprocessFromStory(projectPath, nil, PreprocessOptions{})
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.Len(t, result.Changes, 4)
+
+	assert.NoFileExists(t, filepath.Join(projectPath, "docs/3-current-task/notes.md"))
+	task, err := parseTaskJSONFile(filepath.Join(projectPath, currentTaskFile))
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-001", task.ID)
+
+	stories, err := parseStoriesJSON(filepath.Join(projectPath, storiesFile))
+	require.NoError(t, err)
+	assert.Equal(t, "in_progress", stories.Stories["STORY-001"].Tasks[0].Status)
+}
+
+func TestDryRunPlanTask_MissingTemplate(t *testing.T) {
+	_, err := DryRunPlanTask(t.TempDir())
+	assert.ErrorContains(t, err, "template current-task.json not found")
+}
@@ -0,0 +1,211 @@
+package preprocessing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Supported issue provider names
+const (
+	IssueProviderGitHub = "github"
+	IssueProviderGitLab = "gitlab"
+)
+
+// IssueProvider abstracts the issue tracker used by the From-Issue workflow
+type IssueProvider interface {
+	// Name returns the provider identifier (github, gitlab)
+	Name() string
+	// ListOpen returns the open issues of the current repository
+	ListOpen() ([]*GitHubIssue, error)
+	// Assign assigns the issue to the current user
+	Assign(issueNumber int) error
+	// Comment adds a comment to the issue
+	Comment(issueNumber int, body string) error
+}
+
+// NewIssueProvider returns the provider matching name. An empty name
+// auto-detects the provider from the git remote host, defaulting to GitHub.
+func NewIssueProvider(name, projectPath string) (IssueProvider, error) {
+	if name == "" {
+		name = DetectIssueProvider(projectPath)
+	}
+
+	switch strings.ToLower(name) {
+	case IssueProviderGitHub:
+		return &GitHubIssueProvider{projectPath: projectPath}, nil
+	case IssueProviderGitLab:
+		return &GitLabIssueProvider{projectPath: projectPath}, nil
+	default:
+		return nil, fmt.Errorf("unknown issue provider %q (expected %s or %s)", name, IssueProviderGitHub, IssueProviderGitLab)
+	}
+}
+
+// DetectIssueProvider inspects the origin remote URL and returns the matching
+// provider name. GitHub is returned when the host cannot be determined.
+func DetectIssueProvider(projectPath string) string {
+	cmd := exec.Command("git", "remote", "get-url", "origin")
+	cmd.Dir = projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return IssueProviderGitHub
+	}
+	return providerFromRemoteURL(strings.TrimSpace(string(output)))
+}
+
+func providerFromRemoteURL(remoteURL string) string {
+	if strings.Contains(remoteHost(remoteURL), "gitlab") {
+		return IssueProviderGitLab
+	}
+	return IssueProviderGitHub
+}
+
+// remoteHost extracts the lowercased host from an https, ssh:// or scp-like
+// (git@host:path) remote URL. An empty string is returned when none is found.
+func remoteHost(remoteURL string) string {
+	host := strings.ToLower(remoteURL)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+		if j := strings.Index(host, "/"); j >= 0 {
+			host = host[:j]
+		}
+	} else if j := strings.Index(host, ":"); j >= 0 {
+		host = host[:j]
+	} else {
+		return ""
+	}
+
+	if i := strings.LastIndex(host, "@"); i >= 0 {
+		host = host[i+1:]
+	}
+	if i := strings.Index(host, ":"); i >= 0 {
+		host = host[:i]
+	}
+	return host
+}
+
+// GitHubIssueProvider manages issues through the gh CLI
+type GitHubIssueProvider struct {
+	projectPath string
+}
+
+// Name returns the provider identifier
+func (p *GitHubIssueProvider) Name() string { return IssueProviderGitHub }
+
+// ListOpen returns the open GitHub issues
+func (p *GitHubIssueProvider) ListOpen() ([]*GitHubIssue, error) {
+	cmd := exec.Command("gh", "issue", "list", "--state", "open", "--json", "number,title,body,labels,createdAt")
+	cmd.Dir = p.projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*GitHubIssue
+	if err := json.Unmarshal(output, &issues); err != nil {
+		return nil, err
+	}
+
+	return issues, nil
+}
+
+// Assign assigns the GitHub issue to the current user
+func (p *GitHubIssueProvider) Assign(issueNumber int) error {
+	cmd := exec.Command("gh", "issue", "edit", fmt.Sprintf("%d", issueNumber), "--add-assignee", "@me")
+	cmd.Dir = p.projectPath
+	return cmd.Run()
+}
+
+// Comment adds a comment to the GitHub issue
+func (p *GitHubIssueProvider) Comment(issueNumber int, body string) error {
+	cmd := exec.Command("gh", "issue", "comment", fmt.Sprintf("%d", issueNumber), "--body", body)
+	cmd.Dir = p.projectPath
+	return cmd.Run()
+}
+
+// GitLabIssueProvider manages issues through the glab CLI
+type GitLabIssueProvider struct {
+	projectPath string
+}
+
+// gitLabIssue mirrors the subset of the glab JSON output we consume
+type gitLabIssue struct {
+	IID         int      `json:"iid"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	State       string   `json:"state"`
+	Labels      []string `json:"labels"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// Name returns the provider identifier
+func (p *GitLabIssueProvider) Name() string { return IssueProviderGitLab }
+
+// ListOpen returns the open GitLab issues
+func (p *GitLabIssueProvider) ListOpen() ([]*GitHubIssue, error) {
+	cmd := exec.Command("glab", "issue", "list", "--output", "json")
+	cmd.Dir = p.projectPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseGitLabIssues(output)
+}
+
+// Assign assigns the GitLab issue to the current user
+func (p *GitLabIssueProvider) Assign(issueNumber int) error {
+	cmd := exec.Command("glab", "issue", "update", fmt.Sprintf("%d", issueNumber), "--assignee", "@me")
+	cmd.Dir = p.projectPath
+	return cmd.Run()
+}
+
+// Comment adds a note to the GitLab issue
+func (p *GitLabIssueProvider) Comment(issueNumber int, body string) error {
+	cmd := exec.Command("glab", "issue", "note", fmt.Sprintf("%d", issueNumber), "--message", body)
+	cmd.Dir = p.projectPath
+	return cmd.Run()
+}
+
+// parseGitLabIssues converts glab JSON output into the common issue structure
+func parseGitLabIssues(data []byte) ([]*GitHubIssue, error) {
+	var raw []gitLabIssue
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	issues := make([]*GitHubIssue, 0, len(raw))
+	for _, item := range raw {
+		labels := make([]GitHubLabel, 0, len(item.Labels))
+		for _, name := range item.Labels {
+			labels = append(labels, GitHubLabel{Name: name})
+		}
+		issues = append(issues, &GitHubIssue{
+			Number:    item.IID,
+			Title:     item.Title,
+			Body:      item.Description,
+			State:     item.State,
+			Labels:    labels,
+			CreatedAt: item.CreatedAt,
+		})
+	}
+
+	return issues, nil
+}
+
+// providerDisplayName returns the human readable provider name
+func providerDisplayName(name string) string {
+	switch name {
+	case IssueProviderGitLab:
+		return "GitLab"
+	default:
+		return "GitHub"
+	}
+}
+
+// issueReference returns how the changes to issue are listed, e.g.
+// "GitLab issue #12"
+func issueReference(provider IssueProvider, issue *GitHubIssue) string {
+	return fmt.Sprintf("%s issue #%d", providerDisplayName(provider.Name()), issue.Number)
+}
@@ -0,0 +1,76 @@
+package preprocessing
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// StampFile records the last preprocessing of the current task workspace,
+// relative to the project root
+const StampFile = "docs/3-current-task/.preprocessing-stamp"
+
+// DefaultIdempotencyTTL is how long a prepared workspace is reused when the
+// preprocessing.idempotency_ttl config key is not set
+const DefaultIdempotencyTTL = 60 * time.Second
+
+// Commands recorded in the preprocessing stamp
+const (
+	CommandFromStory = "/4-task:1-start:1-From-story"
+)
+
+// PreprocessingStamp is the content of StampFile
+type PreprocessingStamp struct {
+	StampedAt time.Time `json:"stamped_at"`
+	Command   string    `json:"command"`
+	Checksum  string    `json:"checksum"` // SHA256 of the source data
+}
+
+// preparedRecently reports whether the workspace was prepared by command from
+// the current content of sourceFile less than options.IdempotencyTTL ago
+func (r *preprocessRun) preparedRecently(command, sourceFile string) bool {
+	if r.options.Force || r.options.IdempotencyTTL <= 0 {
+		return false
+	}
+
+	data, err := os.ReadFile(r.path(StampFile))
+	if err != nil {
+		return false
+	}
+	var stamp PreprocessingStamp
+	if err := json.Unmarshal(data, &stamp); err != nil {
+		return false
+	}
+	if stamp.Command != command || time.Since(stamp.StampedAt) >= r.options.IdempotencyTTL {
+		return false
+	}
+
+	checksum, err := computeFileSHA256(r.path(sourceFile))
+	return err == nil && checksum == stamp.Checksum
+}
+
+// stamp records that command prepared the workspace from sourceFile as it is now
+func (r *preprocessRun) stamp(command, sourceFile string) error {
+	return r.change(OperationCreate, StampFile, "Record the preprocessing stamp", func() error {
+		checksum, err := computeFileSHA256(r.path(sourceFile))
+		if err != nil {
+			return fmt.Errorf("failed to checksum %s: %w", sourceFile, err)
+		}
+		return writeJSON(r.path(StampFile), PreprocessingStamp{
+			StampedAt: time.Now(),
+			Command:   command,
+			Checksum:  checksum,
+		})
+	})
+}
+
+// computeFileSHA256 returns the hex SHA256 of the content of path
+func computeFileSHA256(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data)), nil
+}
@@ -0,0 +1,95 @@
+package preprocessing
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readStamp(t *testing.T, projectPath string) PreprocessingStamp {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(projectPath, StampFile))
+	require.NoError(t, err)
+	var stamp PreprocessingStamp
+	require.NoError(t, json.Unmarshal(data, &stamp))
+	return stamp
+}
+
+func TestPreprocessFromStory_Idempotency(t *testing.T) {
+	options := PreprocessOptions{IdempotencyTTL: time.Minute}
+
+	t.Run("creates stamp", func(t *testing.T) {
+		projectPath := setupStoryProject(t)
+
+		result, err := PreprocessFromStory(projectPath, nil, options)
+		require.NoError(t, err)
+		assert.False(t, result.Skipped)
+
+		stamp := readStamp(t, projectPath)
+		assert.Equal(t, CommandFromStory, stamp.Command)
+		assert.WithinDuration(t, time.Now(), stamp.StampedAt, 5*time.Second)
+		checksum, err := computeFileSHA256(filepath.Join(projectPath, storiesFile))
+		require.NoError(t, err)
+		assert.Equal(t, checksum, stamp.Checksum)
+	})
+
+	t.Run("skips prepared workspace", func(t *testing.T) {
+		projectPath := setupStoryProject(t)
+		_, err := PreprocessFromStory(projectPath, nil, options)
+		require.NoError(t, err)
+
+		notePath := filepath.Join(projectPath, "docs/3-current-task/notes.md")
+		require.NoError(t, os.WriteFile(notePath, []byte("kept"), 0644))
+
+		result, err := PreprocessFromStory(projectPath, nil, options)
+		require.NoError(t, err)
+		assert.True(t, result.Skipped)
+		assert.Empty(t, result.Changes)
+		assert.FileExists(t, notePath)
+
+		// --force and a disabled TTL both redo the preprocessing
+		result, err = PreprocessFromStory(projectPath, nil, PreprocessOptions{IdempotencyTTL: time.Minute, Force: true})
+		require.NoError(t, err)
+		assert.False(t, result.Skipped)
+		assert.NoFileExists(t, notePath)
+
+		result, err = PreprocessFromStory(projectPath, nil, PreprocessOptions{})
+		require.NoError(t, err)
+		assert.False(t, result.Skipped)
+	})
+
+	t.Run("invalidated by source change", func(t *testing.T) {
+		projectPath := setupStoryProject(t)
+		_, err := PreprocessFromStory(projectPath, nil, options)
+		require.NoError(t, err)
+
+		storiesPath := filepath.Join(projectPath, storiesFile)
+		stories, err := parseStoriesJSON(storiesPath)
+		require.NoError(t, err)
+		stories.EpicContext.Title = "Renamed epic"
+		require.NoError(t, writeStoriesJSON(storiesPath, stories))
+
+		result, err := PreprocessFromStory(projectPath, nil, options)
+		require.NoError(t, err)
+		assert.False(t, result.Skipped)
+	})
+
+	t.Run("expires after TTL", func(t *testing.T) {
+		projectPath := setupStoryProject(t)
+		_, err := PreprocessFromStory(projectPath, nil, options)
+		require.NoError(t, err)
+
+		stamp := readStamp(t, projectPath)
+		stamp.StampedAt = time.Now().Add(-2 * time.Minute)
+		require.NoError(t, writeJSON(filepath.Join(projectPath, StampFile), stamp))
+
+		result, err := PreprocessFromStory(projectPath, nil, options)
+		require.NoError(t, err)
+		assert.False(t, result.Skipped)
+	})
+}
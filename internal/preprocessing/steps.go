@@ -0,0 +1,131 @@
+package preprocessing
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"claude-wm-cli/internal/metrics"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// PreprocessStep is one unit of a preprocessing command. A step runs once
+// all the steps named in Deps have succeeded; steps without pending
+// dependencies run concurrently. Steps hand their results to their
+// dependents through a sync.Map shared by the command.
+type PreprocessStep struct {
+	Name string
+	Deps []string
+	Run  func() error
+}
+
+// loadStepResult returns the result stored under key by a dependency of the
+// running step, or the zero value when there is none
+func loadStepResult[T any](results *sync.Map, key string) T {
+	value, _ := results.Load(key)
+	result, _ := value.(T)
+	return result
+}
+
+// stepWaves orders steps into waves: each wave only depends on the steps of
+// the previous waves, so the steps of a wave can run concurrently. Steps keep
+// their declaration order within a wave.
+func stepWaves(steps []PreprocessStep) ([][]PreprocessStep, error) {
+	declared := make(map[string]bool, len(steps))
+	for _, step := range steps {
+		if declared[step.Name] {
+			return nil, fmt.Errorf("duplicate preprocessing step %q", step.Name)
+		}
+		declared[step.Name] = true
+	}
+	for _, step := range steps {
+		for _, dep := range step.Deps {
+			if !declared[dep] {
+				return nil, fmt.Errorf("preprocessing step %q depends on unknown step %q", step.Name, dep)
+			}
+		}
+	}
+
+	done := make(map[string]bool, len(steps))
+	var waves [][]PreprocessStep
+	for remaining := steps; len(remaining) > 0; {
+		var wave, blocked []PreprocessStep
+		for _, step := range remaining {
+			if depsDone(step, done) {
+				wave = append(wave, step)
+			} else {
+				blocked = append(blocked, step)
+			}
+		}
+		if len(wave) == 0 {
+			names := make([]string, len(blocked))
+			for i, step := range blocked {
+				names[i] = step.Name
+			}
+			return nil, fmt.Errorf("preprocessing steps depend on each other: %s", strings.Join(names, ", "))
+		}
+
+		for _, step := range wave {
+			done[step.Name] = true
+		}
+		waves = append(waves, wave)
+		remaining = blocked
+	}
+	return waves, nil
+}
+
+// depsDone reports whether all the dependencies of step are in done
+func depsDone(step PreprocessStep, done map[string]bool) bool {
+	for _, dep := range step.Deps {
+		if !done[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+// runSteps runs steps wave by wave, the steps of a wave concurrently unless
+// Sequential is set, and returns the first error. Dry runs are sequential so
+// that the planned changes are listed in step order. The whole run is
+// recorded as the preprocessing step of the options' timer.
+func (r *preprocessRun) runSteps(steps []PreprocessStep) error {
+	waves, err := stepWaves(steps)
+	if err != nil {
+		return err
+	}
+
+	sequential := r.options.Sequential || r.options.DryRun
+	profile := r.options.Timer.ProfileStep(metrics.StepPreprocessing)
+	profile.SetMetadata("steps", len(steps))
+	profile.SetMetadata("waves", len(waves))
+	profile.SetMetadata("parallel", !sequential)
+
+	for _, wave := range waves {
+		if err := runWave(wave, sequential); err != nil {
+			profile.StopWithError(err)
+			return err
+		}
+	}
+	profile.Stop()
+	return nil
+}
+
+// runWave runs the steps of a wave, in order when sequential, and returns
+// the first error
+func runWave(wave []PreprocessStep, sequential bool) error {
+	if sequential || len(wave) == 1 {
+		for _, step := range wave {
+			if err := step.Run(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var group errgroup.Group
+	for _, step := range wave {
+		group.Go(step.Run)
+	}
+	return group.Wait()
+}
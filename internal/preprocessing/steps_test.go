@@ -0,0 +1,150 @@
+package preprocessing
+
+import (
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stepNames returns the names of the steps of each wave
+func stepNames(waves [][]PreprocessStep) [][]string {
+	names := make([][]string, len(waves))
+	for i, wave := range waves {
+		for _, step := range wave {
+			names[i] = append(names[i], step.Name)
+		}
+	}
+	return names
+}
+
+func TestStepWaves(t *testing.T) {
+	waves, err := stepWaves([]PreprocessStep{
+		{Name: "write", Deps: []string{"read", "branch", "clean"}},
+		{Name: "read"},
+		{Name: "clean", Deps: []string{"read"}},
+		{Name: "branch"},
+		{Name: "update", Deps: []string{"read"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"read", "branch"}, {"clean", "update"}, {"write"}}, stepNames(waves))
+}
+
+func TestStepWaves_Errors(t *testing.T) {
+	_, err := stepWaves([]PreprocessStep{{Name: "a"}, {Name: "a"}})
+	assert.ErrorContains(t, err, `duplicate preprocessing step "a"`)
+
+	_, err = stepWaves([]PreprocessStep{{Name: "a", Deps: []string{"missing"}}})
+	assert.ErrorContains(t, err, `depends on unknown step "missing"`)
+
+	_, err = stepWaves([]PreprocessStep{
+		{Name: "a", Deps: []string{"b"}},
+		{Name: "b", Deps: []string{"a"}},
+		{Name: "c"},
+	})
+	assert.ErrorContains(t, err, "depend on each other: a, b")
+}
+
+func TestRunSteps_Parallel(t *testing.T) {
+	// Each step waits for the other one, which only completes when they run
+	// concurrently
+	first, second := make(chan struct{}), make(chan struct{})
+	rendezvous := func(own, other chan struct{}) func() error {
+		return func() error {
+			close(own)
+			select {
+			case <-other:
+				return nil
+			case <-time.After(5 * time.Second):
+				return errors.New("steps did not run concurrently")
+			}
+		}
+	}
+
+	run := newPreprocessRun(t.TempDir(), nil, PreprocessOptions{})
+	err := run.runSteps([]PreprocessStep{
+		{Name: "first", Run: rendezvous(first, second)},
+		{Name: "second", Run: rendezvous(second, first)},
+	})
+	assert.NoError(t, err)
+}
+
+func TestRunSteps_Sequential(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() error {
+		return func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+			return nil
+		}
+	}
+
+	run := newPreprocessRun(t.TempDir(), nil, PreprocessOptions{Sequential: true})
+	err := run.runSteps([]PreprocessStep{
+		{Name: "c", Deps: []string{"a"}, Run: record("c")},
+		{Name: "a", Run: record("a")},
+		{Name: "b", Run: record("b")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, order)
+}
+
+func TestRunSteps_ErrorStopsDependents(t *testing.T) {
+	ran := false
+	run := newPreprocessRun(t.TempDir(), nil, PreprocessOptions{})
+	err := run.runSteps([]PreprocessStep{
+		{Name: "read", Run: func() error { return errors.New("read failed") }},
+		{Name: "branch", Run: func() error { return nil }},
+		{Name: "write", Deps: []string{"read"}, Run: func() error { ran = true; return nil }},
+	})
+	assert.EqualError(t, err, "read failed")
+	assert.False(t, ran)
+}
+
+func TestPreprocessFromStory_Sequential(t *testing.T) {
+	projectPath := setupStoryProject(t)
+
+	result, err := PreprocessFromStory(projectPath, nil, PreprocessOptions{Sequential: true})
+	require.NoError(t, err)
+	assert.Len(t, result.Changes, 4)
+
+	task, err := parseTaskJSONFile(filepath.Join(projectPath, currentTaskFile))
+	require.NoError(t, err)
+	assert.Equal(t, "TASK-001", task.ID)
+}
+
+// slowSteps returns steps each taking delay, none depending on another
+func slowSteps(count int, delay time.Duration) []PreprocessStep {
+	steps := make([]PreprocessStep, count)
+	for i := range steps {
+		steps[i] = PreprocessStep{Name: string(rune('a' + i)), Run: func() error {
+			time.Sleep(delay)
+			return nil
+		}}
+	}
+	return steps
+}
+
+func BenchmarkRunSteps(b *testing.B) {
+	for _, sequential := range []bool{false, true} {
+		name := "parallel"
+		if sequential {
+			name = "sequential"
+		}
+		b.Run(name, func(b *testing.B) {
+			run := newPreprocessRun(b.TempDir(), nil, PreprocessOptions{Sequential: sequential})
+			steps := slowSteps(3, time.Millisecond)
+			for i := 0; i < b.N; i++ {
+				if err := run.runSteps(steps); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"claude-wm-cli/internal/backup"
 	"claude-wm-cli/internal/navigation"
 )
 
@@ -190,261 +192,429 @@ type GitHubLabel struct {
 	Name string `json:"name"`
 }
 
-// PreprocessFromStory handles preprocessing for /4-task:1-start:1-From-story
-func PreprocessFromStory(projectPath string, menuDisplay *navigation.MenuDisplay) error {
-	menuDisplay.ShowMessage("📋 Preprocessing: From Story task initialization...")
-
-	// 1. Parse docs/2-current-epic/stories.json
-	storiesPath := filepath.Join(projectPath, "docs/2-current-epic/stories.json")
-	stories, err := parseStoriesJSON(storiesPath)
-	if err != nil {
-		return fmt.Errorf("failed to parse docs/2-current-epic/stories.json: %w", err)
-	}
-
-	// 2. Find next task with status != "done" based on dependencies
-	nextTask, err := findNextAvailableTask(stories)
-	if err != nil {
-		return fmt.Errorf("failed to find next available task: %w", err)
-	}
-
-	menuDisplay.ShowMessage(fmt.Sprintf("  ✓ Selected task: %s - %s", nextTask.ID, nextTask.Title))
-
-	// 3. Clean current task directory
-	if err := cleanCurrentTaskDirectory(projectPath); err != nil {
-		return fmt.Errorf("failed to clean current task directory: %w", err)
-	}
+// Paths written by preprocessing, relative to the project root
+const (
+	storiesFile     = "docs/2-current-epic/stories.json"
+	prdFile         = "docs/2-current-epic/PRD.md"
+	currentTaskDir  = "docs/3-current-task"
+	currentTaskFile = "docs/3-current-task/current-task.json"
+	iterationsFile  = "docs/3-current-task/iterations.json"
+)
 
-	// 4. Update task status to "in_progress"
-	if err := updateTaskStatus(stories, nextTask.ID, "in_progress"); err != nil {
-		return fmt.Errorf("failed to update task status: %w", err)
+// PreprocessFromStory handles preprocessing for /4-task:1-start:1-From-story
+func PreprocessFromStory(projectPath string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	run := newPreprocessRun(projectPath, menuDisplay, options)
+	run.message("📋 Preprocessing: From Story task initialization...")
+
+	if run.preparedRecently(CommandFromStory, storiesFile) {
+		run.message("  ◦ Workspace already prepared from the current stories, skipping")
+		run.result.Skipped = true
+		return run.result, nil
+	}
+
+	// The stories are read while the branch is looked up, then the task
+	// workspace is cleaned while the stories are updated
+	storiesPath := run.path(storiesFile)
+	var stepResults sync.Map
+	steps := []PreprocessStep{
+		{
+			// 1. Parse docs/2-current-epic/stories.json and find the next task
+			// with status != "done" based on dependencies
+			Name: "read-stories",
+			Run: func() error {
+				stories, err := parseStoriesJSON(storiesPath)
+				if err != nil {
+					return fmt.Errorf("failed to parse docs/2-current-epic/stories.json: %w", err)
+				}
+				nextTask, err := findNextAvailableTask(stories)
+				if err != nil {
+					return fmt.Errorf("failed to find next available task: %w", err)
+				}
+
+				run.message(fmt.Sprintf("  ✓ Selected task: %s - %s", nextTask.ID, nextTask.Title))
+				stepResults.Store("stories", stories)
+				stepResults.Store("task", nextTask)
+				return nil
+			},
+		},
+		{
+			Name: "git-branch",
+			Run: func() error {
+				stepResults.Store("branch", getCurrentGitBranch(projectPath))
+				return nil
+			},
+		},
+		{
+			// 2. Clean current task directory, once a task was selected
+			Name: "clean-task-dir",
+			Deps: []string{"read-stories"},
+			Run: func() error {
+				if err := run.change(OperationDelete, currentTaskDir, "Clean the current task workspace", func() error {
+					return cleanCurrentTaskDirectory(projectPath)
+				}); err != nil {
+					return fmt.Errorf("failed to clean current task directory: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			// 3. Update task status to "in_progress"
+			Name: "update-stories",
+			Deps: []string{"read-stories"},
+			Run: func() error {
+				stories := loadStepResult[*StoriesData](&stepResults, "stories")
+				nextTask := loadStepResult[*StoryTask](&stepResults, "task")
+				if err := updateTaskStatus(stories, nextTask.ID, "in_progress"); err != nil {
+					return fmt.Errorf("failed to update task status: %w", err)
+				}
+
+				if err := run.change(OperationModify, storiesFile, fmt.Sprintf("Set task %s status to in_progress", nextTask.ID), func() error {
+					return writeStoriesJSON(storiesPath, stories)
+				}); err != nil {
+					return fmt.Errorf("failed to write updated docs/2-current-epic/stories.json: %w", err)
+				}
+
+				run.done("  ✓ Updated task status to in_progress")
+				return nil
+			},
+		},
+		{
+			// 4. Initialize docs/3-current-task/current-task.json with context
+			Name: "write-current-task",
+			Deps: []string{"read-stories", "git-branch", "clean-task-dir"},
+			Run: func() error {
+				stories := loadStepResult[*StoriesData](&stepResults, "stories")
+				nextTask := loadStepResult[*StoryTask](&stepResults, "task")
+				currentTask := currentTaskFromStory(nextTask, stories.EpicContext, loadStepResult[string](&stepResults, "branch"))
+				if err := run.change(OperationCreate, currentTaskFile, fmt.Sprintf("Initialize task %s from its story", nextTask.ID), func() error {
+					return writeJSON(run.path(currentTaskFile), currentTask)
+				}); err != nil {
+					return fmt.Errorf("failed to initialize docs/3-current-task/current-task.json: %w", err)
+				}
+				return nil
+			},
+		},
 	}
-
-	if err := writeStoriesJSON(storiesPath, stories); err != nil {
-		return fmt.Errorf("failed to write updated docs/2-current-epic/stories.json: %w", err)
+	if err := run.runSteps(steps); err != nil {
+		return nil, err
 	}
 
-	menuDisplay.ShowMessage("  ✓ Updated task status to in_progress")
-
-	// 5. Initialize docs/3-current-task/current-task.json with context
-	if err := initializeCurrentTaskFromStory(projectPath, nextTask, stories.EpicContext); err != nil {
-		return fmt.Errorf("failed to initialize docs/3-current-task/current-task.json: %w", err)
+	// 5. Stamp the workspace so that re-entering the step does not redo it
+	if err := run.stamp(CommandFromStory, storiesFile); err != nil {
+		run.warning(fmt.Sprintf("⚠️ Failed to write %s: %v", StampFile, err))
 	}
 
-	menuDisplay.ShowSuccess("✅ From Story preprocessing completed successfully")
-	return nil
+	return run.finish("✅ From Story preprocessing completed successfully"), nil
 }
 
-// PreprocessFromIssue handles preprocessing for /4-task:1-start:2-From-issue
-func PreprocessFromIssue(projectPath string, menuDisplay *navigation.MenuDisplay) error {
-	menuDisplay.ShowMessage("🐛 Preprocessing: From Issue task initialization...")
-
-	// 1. Get open issues sorted by priority/age
-	issues, err := getOpenGitHubIssues()
+// PreprocessFromIssue handles preprocessing for /4-task:1-start:2-From-issue.
+// The issue provider is auto-detected from the git remote host.
+func PreprocessFromIssue(projectPath string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	provider, err := NewIssueProvider("", projectPath)
 	if err != nil {
-		return fmt.Errorf("failed to get GitHub issues: %w", err)
-	}
-
-	if len(issues) == 0 {
-		return fmt.Errorf("no open GitHub issues found")
-	}
-
-	selectedIssue := selectHighestPriorityIssue(issues)
-	menuDisplay.ShowMessage(fmt.Sprintf("  ✓ Selected issue #%d: %s", selectedIssue.Number, selectedIssue.Title))
-
-	// 2. Clean workspace (no branch creation - stay on current story branch)
-	if err := cleanCurrentTaskDirectory(projectPath); err != nil {
-		return fmt.Errorf("failed to clean current task directory: %w", err)
-	}
-
-	// 3. Assign and comment on issue
-	if err := assignGitHubIssue(selectedIssue.Number); err != nil {
-		menuDisplay.ShowWarning(fmt.Sprintf("Failed to assign issue: %v", err))
+		return nil, err
 	}
-
-	if err := commentOnGitHubIssue(selectedIssue.Number, "🚀 Working on this issue via claude-wm-cli"); err != nil {
-		menuDisplay.ShowWarning(fmt.Sprintf("Failed to comment on issue: %v", err))
+	return PreprocessFromIssueWithProvider(projectPath, provider, menuDisplay, options)
+}
+
+// PreprocessFromIssueWithProvider handles From-Issue preprocessing using the given issue provider
+func PreprocessFromIssueWithProvider(projectPath string, provider IssueProvider, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	run := newPreprocessRun(projectPath, menuDisplay, options)
+	run.message(fmt.Sprintf("🐛 Preprocessing: From Issue task initialization (%s)...", provider.Name()))
+
+	// The issues are listed while the branch is looked up, then the
+	// workspace is cleaned while the selected issue is assigned
+	var stepResults sync.Map
+	steps := []PreprocessStep{
+		{
+			// 1. Get open issues sorted by priority/age
+			Name: "list-issues",
+			Run: func() error {
+				issues, err := provider.ListOpen()
+				if err != nil {
+					return fmt.Errorf("failed to get %s issues: %w", provider.Name(), err)
+				}
+				if len(issues) == 0 {
+					return fmt.Errorf("no open %s issues found", provider.Name())
+				}
+
+				selectedIssue := selectHighestPriorityIssue(issues)
+				run.message(fmt.Sprintf("  ✓ Selected issue #%d: %s", selectedIssue.Number, selectedIssue.Title))
+				stepResults.Store("issue", selectedIssue)
+				return nil
+			},
+		},
+		{
+			Name: "git-branch",
+			Run: func() error {
+				stepResults.Store("branch", getCurrentGitBranch(projectPath))
+				return nil
+			},
+		},
+		{
+			// 2. Clean workspace (no branch creation - stay on current story branch)
+			Name: "clean-task-dir",
+			Deps: []string{"list-issues"},
+			Run: func() error {
+				if err := run.change(OperationDelete, currentTaskDir, "Clean the current task workspace", func() error {
+					return cleanCurrentTaskDirectory(projectPath)
+				}); err != nil {
+					return fmt.Errorf("failed to clean current task directory: %w", err)
+				}
+				return nil
+			},
+		},
+		{
+			// 3. Assign and comment on issue
+			Name: "assign-issue",
+			Deps: []string{"list-issues"},
+			Run: func() error {
+				selectedIssue := loadStepResult[*GitHubIssue](&stepResults, "issue")
+				if err := run.change(OperationRemote, issueReference(provider, selectedIssue), "Assign the issue to the current user", func() error {
+					return provider.Assign(selectedIssue.Number)
+				}); err != nil {
+					run.warning(fmt.Sprintf("Failed to assign issue: %v", err))
+				}
+				return nil
+			},
+		},
+		{
+			Name: "comment-issue",
+			Deps: []string{"list-issues"},
+			Run: func() error {
+				selectedIssue := loadStepResult[*GitHubIssue](&stepResults, "issue")
+				if err := run.change(OperationRemote, issueReference(provider, selectedIssue), "Comment that work on the issue started", func() error {
+					return provider.Comment(selectedIssue.Number, "🚀 Working on this issue via claude-wm-cli")
+				}); err != nil {
+					run.warning(fmt.Sprintf("Failed to comment on issue: %v", err))
+				}
+				return nil
+			},
+		},
+		{
+			// 4. Initialize docs/3-current-task/current-task.json with issue context
+			Name: "write-current-task",
+			Deps: []string{"list-issues", "git-branch", "clean-task-dir"},
+			Run: func() error {
+				selectedIssue := loadStepResult[*GitHubIssue](&stepResults, "issue")
+				currentTask := currentTaskFromIssue(selectedIssue, provider.Name(), loadStepResult[string](&stepResults, "branch"))
+				if err := run.change(OperationCreate, currentTaskFile, fmt.Sprintf("Initialize task %s from %s", currentTask.ID, issueReference(provider, selectedIssue)), func() error {
+					return writeJSON(run.path(currentTaskFile), currentTask)
+				}); err != nil {
+					return fmt.Errorf("failed to initialize docs/3-current-task/current-task.json: %w", err)
+				}
+				return nil
+			},
+		},
 	}
-
-	// 4. Initialize docs/3-current-task/current-task.json with issue context
-	if err := initializeCurrentTaskFromIssue(projectPath, selectedIssue); err != nil {
-		return fmt.Errorf("failed to initialize docs/3-current-task/current-task.json: %w", err)
+	if err := run.runSteps(steps); err != nil {
+		return nil, err
 	}
 
-	menuDisplay.ShowSuccess("✅ From Issue preprocessing completed successfully")
-	return nil
+	return run.finish("✅ From Issue preprocessing completed successfully"), nil
 }
 
 // PreprocessFromInput handles preprocessing for /4-task:1-start:3-From-input
-func PreprocessFromInput(projectPath string, description string, menuDisplay *navigation.MenuDisplay) error {
-	menuDisplay.ShowMessage("✏️ Preprocessing: From Input task initialization...")
+func PreprocessFromInput(projectPath string, description string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	run := newPreprocessRun(projectPath, menuDisplay, options)
+	run.message("✏️ Preprocessing: From Input task initialization...")
 
 	// 1. Clean workspace (no branch creation - stay on current story branch)
-	if err := cleanCurrentTaskDirectory(projectPath); err != nil {
-		return fmt.Errorf("failed to clean current task directory: %w", err)
+	if err := run.change(OperationDelete, currentTaskDir, "Clean the current task workspace", func() error {
+		return cleanCurrentTaskDirectory(projectPath)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to clean current task directory: %w", err)
 	}
 
 	// 2. Initialize docs/3-current-task/current-task.json with input context
-	if err := initializeCurrentTaskFromInput(projectPath, description); err != nil {
-		return fmt.Errorf("failed to initialize docs/3-current-task/current-task.json: %w", err)
+	currentTask := currentTaskFromInput(description, getCurrentGitBranch(projectPath))
+	if err := run.change(OperationCreate, currentTaskFile, fmt.Sprintf("Initialize task %s from the description", currentTask.ID), func() error {
+		return writeJSON(run.path(currentTaskFile), currentTask)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to initialize docs/3-current-task/current-task.json: %w", err)
 	}
 
-	menuDisplay.ShowSuccess("✅ From Input preprocessing completed successfully")
-	return nil
+	return run.finish("✅ From Input preprocessing completed successfully"), nil
 }
 
 // PreprocessPlanTask handles preprocessing for /4-task:2-execute:1-Plan-Task
-func PreprocessPlanTask(projectPath string, menuDisplay *navigation.MenuDisplay) error {
-	menuDisplay.ShowMessage("📝 Preprocessing: Plan Task initialization...")
+func PreprocessPlanTask(projectPath string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	run := newPreprocessRun(projectPath, menuDisplay, options)
+	run.message("📝 Preprocessing: Plan Task initialization...")
 
 	// 1. Copy JSON templates
-	if err := copyJSONTemplate(projectPath, "current-task.json"); err != nil {
-		return fmt.Errorf("failed to copy docs/3-current-task/current-task.json template: %w", err)
+	if err := copyJSONTemplate(run, "current-task.json"); err != nil {
+		return nil, fmt.Errorf("failed to copy docs/3-current-task/current-task.json template: %w", err)
 	}
 
-	if err := copyJSONTemplate(projectPath, "iterations.json"); err != nil {
-		return fmt.Errorf("failed to copy docs/3-current-task/iterations.json template: %w", err)
+	if err := copyJSONTemplate(run, "iterations.json"); err != nil {
+		return nil, fmt.Errorf("failed to copy docs/3-current-task/iterations.json template: %w", err)
 	}
 
 	// 2. Initialize with current context
 	if err := initializeTaskContext(projectPath); err != nil {
-		return fmt.Errorf("failed to initialize task context: %w", err)
+		return nil, fmt.Errorf("failed to initialize task context: %w", err)
 	}
 
-	if err := initializeIterationContext(projectPath); err != nil {
-		return fmt.Errorf("failed to initialize iteration context: %w", err)
+	iterations := newIterationContext(projectPath)
+	if err := run.change(OperationModify, iterationsFile, "Start iteration 1 of 3", func() error {
+		return writeJSON(run.path(iterationsFile), iterations)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to initialize iteration context: %w", err)
 	}
 
-	menuDisplay.ShowSuccess("✅ Plan Task preprocessing completed successfully")
-	return nil
+	return run.finish("✅ Plan Task preprocessing completed successfully"), nil
 }
 
 // PreprocessTestDesign handles preprocessing for /4-task:2-execute:2-Test-design
-func PreprocessTestDesign(projectPath string, menuDisplay *navigation.MenuDisplay) error {
-	menuDisplay.ShowMessage("🧪 Preprocessing: Test Design initialization...")
+func PreprocessTestDesign(projectPath string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	run := newPreprocessRun(projectPath, menuDisplay, options)
+	run.message("🧪 Preprocessing: Test Design initialization...")
 
 	// Create docs/3-current-task/TEST.md from template (kept as Markdown for test scenarios)
-	templatePath := filepath.Join(projectPath, "internal/config/system/commands/templates/TEST.md")
-	destPath := filepath.Join(projectPath, "docs/3-current-task/TEST.md")
+	templatePath := run.path("internal/config/system/commands/templates/TEST.md")
+	destFile := "docs/3-current-task/TEST.md"
 
-	if err := copyFile(templatePath, destPath); err != nil {
-		menuDisplay.ShowWarning("⚠️ internal/config/system/commands/templates/TEST.md template not found, will be created by Claude")
-		return nil
+	if _, err := os.Stat(templatePath); err != nil {
+		run.warning("⚠️ internal/config/system/commands/templates/TEST.md template not found, will be created by Claude")
+		return run.result, nil
 	}
 
-	menuDisplay.ShowMessage("  ✓ Copied internal/config/system/commands/templates/TEST.md template")
-	menuDisplay.ShowSuccess("✅ Test Design preprocessing completed successfully")
-	return nil
+	if err := run.change(run.writeOperation(destFile), destFile, "Copy the TEST.md template", func() error {
+		return copyFile(templatePath, run.path(destFile))
+	}); err != nil {
+		run.warning("⚠️ internal/config/system/commands/templates/TEST.md template not found, will be created by Claude")
+		return run.result, nil
+	}
+
+	run.done("  ✓ Copied internal/config/system/commands/templates/TEST.md template")
+	return run.finish("✅ Test Design preprocessing completed successfully"), nil
 }
 
 // PreprocessValidateTask handles preprocessing for /4-task:2-execute:4-Validate-Task
-func PreprocessValidateTask(projectPath string, menuDisplay *navigation.MenuDisplay) error {
-	menuDisplay.ShowMessage("✅ Preprocessing: Validate Task execution...")
+func PreprocessValidateTask(projectPath string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	run := newPreprocessRun(projectPath, menuDisplay, options)
+	run.message("✅ Preprocessing: Validate Task execution...")
 
 	// 1. Run automated tests
 	testResults := runAutomatedTests(projectPath)
-	menuDisplay.ShowMessage(fmt.Sprintf("  ◦ Automated tests: %s", getTestResultsString(testResults)))
+	run.message(fmt.Sprintf("  ◦ Automated tests: %s", getTestResultsString(testResults)))
 
 	// 2. Check performance baselines
 	perfResults := checkPerformanceBaselines(projectPath)
-	menuDisplay.ShowMessage(fmt.Sprintf("  ◦ Performance check: %s", getPerfResultsString(perfResults)))
+	run.message(fmt.Sprintf("  ◦ Performance check: %s", getPerfResultsString(perfResults)))
 
 	// 3. Handle iteration management with JSON
 	if !testResults.Success || !perfResults.Success {
-		if err := incrementIterationJSON(projectPath, testResults, perfResults); err != nil {
-			return fmt.Errorf("failed to increment iteration: %w", err)
+		iterationsPath := run.path(iterationsFile)
+		iterations, err := parseIterationsJSON(iterationsPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to increment iteration: %w", err)
 		}
 
-		iterations, err := parseIterationsJSON(filepath.Join(projectPath, "docs/3-current-task/iterations.json"))
-		if err != nil {
-			return fmt.Errorf("failed to parse docs/3-current-task/iterations.json: %w", err)
+		recordIteration(iterations, testResults, perfResults)
+		if err := run.change(OperationModify, iterationsFile, fmt.Sprintf("Record failed iteration %d", iterations.TaskContext.CurrentIteration), func() error {
+			return writeJSON(iterationsPath, iterations)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to increment iteration: %w", err)
 		}
 
 		if iterations.TaskContext.CurrentIteration >= iterations.TaskContext.MaxIterations {
-			return fmt.Errorf("max iterations reached (%d) - needs human intervention", iterations.TaskContext.MaxIterations)
+			return nil, fmt.Errorf("max iterations reached (%d) - needs human intervention", iterations.TaskContext.MaxIterations)
 		}
 
-		menuDisplay.ShowMessage(fmt.Sprintf("  ⚠️ Iteration %d/%d - continuing with Claude",
+		run.message(fmt.Sprintf("  ⚠️ Iteration %d/%d - continuing with Claude",
 			iterations.TaskContext.CurrentIteration, iterations.TaskContext.MaxIterations))
 	}
 
-	menuDisplay.ShowSuccess("✅ Validate Task preprocessing completed successfully")
-	return nil
+	return run.finish("✅ Validate Task preprocessing completed successfully"), nil
 }
 
 // PreprocessReviewTask handles preprocessing for /4-task:2-execute:5-Review-Task
-func PreprocessReviewTask(projectPath string, menuDisplay *navigation.MenuDisplay) error {
-	menuDisplay.ShowMessage("👀 Preprocessing: Review Task execution...")
+func PreprocessReviewTask(projectPath string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	run := newPreprocessRun(projectPath, menuDisplay, options)
+	run.message("👀 Preprocessing: Review Task execution...")
 
 	// 1. Run quality checks
 	qualityReport := runQualityChecks(projectPath)
-	menuDisplay.ShowMessage(fmt.Sprintf("  ◦ Quality check: %s", getQualityResultsString(qualityReport)))
+	run.message(fmt.Sprintf("  ◦ Quality check: %s", getQualityResultsString(qualityReport)))
 
 	// 2. Update task status in docs/2-current-epic/stories.json
-	currentTask, err := getCurrentTaskFromJSON(filepath.Join(projectPath, "docs/3-current-task/current-task.json"))
+	currentTask, err := getCurrentTaskFromJSON(run.path(currentTaskFile))
 	if err != nil {
-		menuDisplay.ShowWarning("⚠️ Could not load current task context")
-		menuDisplay.ShowSuccess("✅ Review Task preprocessing completed (partial)")
-		return nil
+		run.warning("⚠️ Could not load current task context")
+		return run.finish("✅ Review Task preprocessing completed (partial)"), nil
 	}
 
-	storiesPath := filepath.Join(projectPath, "docs/2-current-epic/stories.json")
+	storiesPath := run.path(storiesFile)
 	stories, err := parseStoriesJSON(storiesPath)
 	if err != nil {
-		menuDisplay.ShowWarning("⚠️ Could not update docs/2-current-epic/stories.json status")
-		menuDisplay.ShowSuccess("✅ Review Task preprocessing completed (partial)")
-		return nil
+		run.warning("⚠️ Could not update docs/2-current-epic/stories.json status")
+		return run.finish("✅ Review Task preprocessing completed (partial)"), nil
 	}
 
 	if err := updateTaskStatus(stories, currentTask.ID, "done"); err != nil {
-		menuDisplay.ShowWarning(fmt.Sprintf("⚠️ Failed to update task status: %v", err))
+		run.warning(fmt.Sprintf("⚠️ Failed to update task status: %v", err))
 	} else {
-		if err := writeStoriesJSON(storiesPath, stories); err != nil {
-			menuDisplay.ShowWarning(fmt.Sprintf("⚠️ Failed to write docs/2-current-epic/stories.json: %v", err))
+		if err := run.change(OperationModify, storiesFile, fmt.Sprintf("Set task %s status to done", currentTask.ID), func() error {
+			return writeStoriesJSON(storiesPath, stories)
+		}); err != nil {
+			run.warning(fmt.Sprintf("⚠️ Failed to write docs/2-current-epic/stories.json: %v", err))
 		} else {
-			menuDisplay.ShowMessage("  ✓ Updated task status to done")
+			run.done("  ✓ Updated task status to done")
 		}
 	}
 
 	// 3. Update PRD.md completion status
-	if err := updatePRDTaskStatus(projectPath, currentTask.ID, "✅"); err != nil {
-		menuDisplay.ShowWarning(fmt.Sprintf("⚠️ Failed to update PRD.md: %v", err))
+	prdContent, err := checkPRDTask(projectPath, currentTask.ID)
+	if err == nil {
+		err = run.change(OperationModify, prdFile, fmt.Sprintf("Check task %s off", currentTask.ID), func() error {
+			return os.WriteFile(run.path(prdFile), []byte(prdContent), 0644)
+		})
+	}
+	if err != nil {
+		run.warning(fmt.Sprintf("⚠️ Failed to update PRD.md: %v", err))
 	} else {
-		menuDisplay.ShowMessage("  ✓ Updated PRD.md completion status")
+		run.done("  ✓ Updated PRD.md completion status")
 	}
 
-	menuDisplay.ShowSuccess("✅ Review Task preprocessing completed successfully")
-	return nil
+	return run.finish("✅ Review Task preprocessing completed successfully"), nil
 }
 
 // PreprocessArchiveTask handles preprocessing for /4-task:3-complete:1-Archive-Task
-func PreprocessArchiveTask(projectPath string, menuDisplay *navigation.MenuDisplay) error {
-	menuDisplay.ShowMessage("📦 Preprocessing: Archive Task execution...")
+func PreprocessArchiveTask(projectPath string, menuDisplay *navigation.MenuDisplay, options PreprocessOptions) (*PreprocessResult, error) {
+	run := newPreprocessRun(projectPath, menuDisplay, options)
+	run.message("📦 Preprocessing: Archive Task execution...")
 
 	// 1. Archive task JSON documentation
-	currentTask, err := parseTaskJSONFile(filepath.Join(projectPath, "docs/3-current-task/current-task.json"))
+	currentTask, err := parseTaskJSONFile(run.path(currentTaskFile))
 	if err != nil {
-		return fmt.Errorf("failed to parse docs/3-current-task/current-task.json: %w", err)
+		return nil, fmt.Errorf("failed to parse docs/3-current-task/current-task.json: %w", err)
 	}
 
 	epicName := getEpicNameFromTask(currentTask)
-	archivePath := filepath.Join(projectPath, "docs/archive", epicName, "tasks",
+	archiveDir := filepath.Join("docs/archive", epicName, "tasks",
 		fmt.Sprintf("%s-%s", currentTask.ID, time.Now().Format("2006-01-02")))
 
-	if err := os.MkdirAll(archivePath, 0755); err != nil {
-		return fmt.Errorf("failed to create archive directory: %w", err)
+	if err := run.change(run.writeOperation(archiveDir), archiveDir, fmt.Sprintf("Create the archive of task %s", currentTask.ID), func() error {
+		return os.MkdirAll(run.path(archiveDir), 0755)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
 	}
 
 	// Archive JSON files instead of Markdown
 	files := []string{"current-task.json", "iterations.json", "TEST.md"}
 	for _, fileName := range files {
 		sourcePath := filepath.Join(projectPath, "docs/3-current-task", fileName)
-		destPath := filepath.Join(archivePath, fileName)
+		destFile := filepath.Join(archiveDir, fileName)
 
 		if _, err := os.Stat(sourcePath); err == nil {
-			if err := copyFile(sourcePath, destPath); err != nil {
-				menuDisplay.ShowWarning(fmt.Sprintf("⚠️ Failed to archive %s: %v", fileName, err))
+			if err := run.change(run.writeOperation(destFile), destFile, fmt.Sprintf("Archive %s", fileName), func() error {
+				return copyFile(sourcePath, run.path(destFile))
+			}); err != nil {
+				run.warning(fmt.Sprintf("⚠️ Failed to archive %s: %v", fileName, err))
 			} else {
-				menuDisplay.ShowMessage(fmt.Sprintf("  ✓ Archived %s", fileName))
+				run.done(fmt.Sprintf("  ✓ Archived %s", fileName))
 			}
 		}
 	}
@@ -452,24 +622,28 @@ func PreprocessArchiveTask(projectPath string, menuDisplay *navigation.MenuDispl
 	// 2. NO branch merge - will be done at story closure
 
 	// 3. Clean workspace
-	if err := os.RemoveAll(filepath.Join(projectPath, "docs/3-current-task")); err != nil {
-		menuDisplay.ShowWarning(fmt.Sprintf("⚠️ Failed to clean workspace: %v", err))
+	if err := run.change(OperationDelete, currentTaskDir, "Remove the current task workspace", func() error {
+		return os.RemoveAll(run.path(currentTaskDir))
+	}); err != nil {
+		run.warning(fmt.Sprintf("⚠️ Failed to clean workspace: %v", err))
 	} else {
-		menuDisplay.ShowMessage("  ✓ Cleaned current task workspace")
+		run.done("  ✓ Cleaned current task workspace")
 	}
 
 	// 4. Final status update
-	if err := finalizeTaskCompletion(currentTask.ID, projectPath); err != nil {
-		menuDisplay.ShowWarning(fmt.Sprintf("⚠️ Failed to finalize task completion: %v", err))
-	} else {
-		menuDisplay.ShowMessage("  ✓ Finalized task completion")
+	if !options.DryRun {
+		if err := finalizeTaskCompletion(currentTask.ID, projectPath); err != nil {
+			run.warning(fmt.Sprintf("⚠️ Failed to finalize task completion: %v", err))
+		} else {
+			run.message("  ✓ Finalized task completion")
+		}
 	}
 
-	menuDisplay.ShowSuccess("✅ Archive Task preprocessing completed successfully")
-	return nil
+	return run.finish("✅ Archive Task preprocessing completed successfully"), nil
 }
 
-// PreprocessStatusTask handles preprocessing for /4-task:3-complete:2-Status-Task
+// PreprocessStatusTask handles preprocessing for /4-task:3-complete:2-Status-Task.
+// It only reads the task files, so it takes no PreprocessOptions.
 func PreprocessStatusTask(projectPath string, menuDisplay *navigation.MenuDisplay) (TaskStatus, error) {
 	menuDisplay.ShowMessage("📊 Preprocessing: Status Task analysis...")
 
@@ -525,7 +699,7 @@ func writeStoriesJSON(path string, data *StoriesData) error {
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, jsonData, 0644)
+	return backup.WriteWithBackup(path, jsonData, backup.BackupTypeAutomatic)
 }
 
 func findNextAvailableTask(stories *StoriesData) (*StoryTask, error) {
@@ -564,8 +738,8 @@ func cleanCurrentTaskDirectory(projectPath string) error {
 	return os.MkdirAll(currentTaskDir, 0755)
 }
 
-func initializeCurrentTaskFromStory(projectPath string, task *StoryTask, epicContext EpicContext) error {
-	currentTaskData := CurrentTaskData{
+func currentTaskFromStory(task *StoryTask, epicContext EpicContext, branch string) CurrentTaskData {
+	return CurrentTaskData{
 		ID:          task.ID,
 		Title:       task.Title,
 		Description: task.Description,
@@ -602,17 +776,14 @@ func initializeCurrentTaskFromStory(projectPath string, task *StoryTask, epicCon
 		},
 		InterruptionContext: InterruptionContext{
 			BlockedWork: "",
-			Branch:      getCurrentGitBranch(projectPath),
+			Branch:      branch,
 			Notes:       "",
 		},
 	}
-
-	destPath := filepath.Join(projectPath, "docs/3-current-task/current-task.json")
-	return writeJSON(destPath, currentTaskData)
 }
 
-func initializeCurrentTaskFromIssue(projectPath string, issue *GitHubIssue) error {
-	currentTaskData := CurrentTaskData{
+func currentTaskFromIssue(issue *GitHubIssue, providerName, branch string) CurrentTaskData {
+	return CurrentTaskData{
 		ID:          fmt.Sprintf("TASK-%03d", issue.Number),
 		Title:       issue.Title,
 		Description: issue.Body,
@@ -649,17 +820,14 @@ func initializeCurrentTaskFromIssue(projectPath string, issue *GitHubIssue) erro
 		},
 		InterruptionContext: InterruptionContext{
 			BlockedWork: "",
-			Branch:      getCurrentGitBranch(projectPath),
-			Notes:       fmt.Sprintf("Created from GitHub issue #%d", issue.Number),
+			Branch:      branch,
+			Notes:       fmt.Sprintf("Created from %s issue #%d", providerDisplayName(providerName), issue.Number),
 		},
 	}
-
-	destPath := filepath.Join(projectPath, "docs/3-current-task/current-task.json")
-	return writeJSON(destPath, currentTaskData)
 }
 
-func initializeCurrentTaskFromInput(projectPath string, description string) error {
-	currentTaskData := CurrentTaskData{
+func currentTaskFromInput(description, branch string) CurrentTaskData {
+	return CurrentTaskData{
 		ID:          fmt.Sprintf("TASK-%d", time.Now().Unix()%1000),
 		Title:       extractTitleFromDescription(description),
 		Description: description,
@@ -696,28 +864,28 @@ func initializeCurrentTaskFromInput(projectPath string, description string) erro
 		},
 		InterruptionContext: InterruptionContext{
 			BlockedWork: "",
-			Branch:      getCurrentGitBranch(projectPath),
+			Branch:      branch,
 			Notes:       "Created from user input",
 		},
 	}
-
-	destPath := filepath.Join(projectPath, "docs/3-current-task/current-task.json")
-	return writeJSON(destPath, currentTaskData)
 }
 
-func copyJSONTemplate(projectPath, templateName string) error {
+func copyJSONTemplate(run *preprocessRun, templateName string) error {
 	// Try multiple possible template locations in order of preference
 	possiblePaths := []string{
-		filepath.Join(projectPath, "internal/config/system/commands/templates", templateName),
-		filepath.Join(projectPath, ".claude-wm/runtime/commands/templates", templateName),
-		filepath.Join(projectPath, ".claude-wm/system/commands/templates", templateName),
+		filepath.Join("internal/config/system/commands/templates", templateName),
+		filepath.Join(".claude-wm/runtime/commands/templates", templateName),
+		filepath.Join(".claude-wm/system/commands/templates", templateName),
 	}
 
-	destPath := filepath.Join(projectPath, "docs/3-current-task", templateName)
+	destFile := filepath.Join(currentTaskDir, templateName)
 
-	for _, templatePath := range possiblePaths {
+	for _, templateFile := range possiblePaths {
+		templatePath := run.path(templateFile)
 		if _, err := os.Stat(templatePath); err == nil {
-			return copyFile(templatePath, destPath)
+			return run.change(run.writeOperation(destFile), destFile, fmt.Sprintf("Copy the %s template from %s", templateName, filepath.ToSlash(templateFile)), func() error {
+				return copyFile(templatePath, run.path(destFile))
+			})
 		}
 	}
 
@@ -755,9 +923,9 @@ func initializeTaskContext(projectPath string) error {
 	return nil
 }
 
-func initializeIterationContext(projectPath string) error {
+func newIterationContext(projectPath string) IterationsData {
 	// Initialize docs/3-current-task/iterations.json with basic structure
-	iterationsData := IterationsData{
+	return IterationsData{
 		TaskContext: TaskContext{
 			TaskID:           "TASK-001",
 			Title:            "Current Task",
@@ -771,24 +939,6 @@ func initializeIterationContext(projectPath string) error {
 		FinalOutcome:    FinalOutcome{},
 		Recommendations: []string{},
 	}
-
-	destPath := filepath.Join(projectPath, "docs/3-current-task/iterations.json")
-	return writeJSON(destPath, iterationsData)
-}
-
-func getOpenGitHubIssues() ([]*GitHubIssue, error) {
-	cmd := exec.Command("gh", "issue", "list", "--state", "open", "--json", "number,title,body,labels,createdAt")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, err
-	}
-
-	var issues []*GitHubIssue
-	if err := json.Unmarshal(output, &issues); err != nil {
-		return nil, err
-	}
-
-	return issues, nil
 }
 
 func selectHighestPriorityIssue(issues []*GitHubIssue) *GitHubIssue {
@@ -799,16 +949,6 @@ func selectHighestPriorityIssue(issues []*GitHubIssue) *GitHubIssue {
 	return nil
 }
 
-func assignGitHubIssue(issueNumber int) error {
-	cmd := exec.Command("gh", "issue", "edit", fmt.Sprintf("%d", issueNumber), "--add-assignee", "@me")
-	return cmd.Run()
-}
-
-func commentOnGitHubIssue(issueNumber int, comment string) error {
-	cmd := exec.Command("gh", "issue", "comment", fmt.Sprintf("%d", issueNumber), "--body", comment)
-	return cmd.Run()
-}
-
 func getCurrentGitBranch(projectPath string) string {
 	cmd := exec.Command("git", "branch", "--show-current")
 	cmd.Dir = projectPath
@@ -882,13 +1022,14 @@ func getCurrentTaskFromJSON(path string) (*CurrentTaskData, error) {
 	return parseTaskJSONFile(path)
 }
 
-func updatePRDTaskStatus(projectPath, taskID, status string) error {
-	prdPath := filepath.Join(projectPath, "docs/2-current-epic/PRD.md")
+// checkPRDTask returns the content of PRD.md with the task checked off
+func checkPRDTask(projectPath, taskID string) (string, error) {
+	prdPath := filepath.Join(projectPath, prdFile)
 
 	// Read file
 	data, err := os.ReadFile(prdPath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	content := string(data)
@@ -897,9 +1038,7 @@ func updatePRDTaskStatus(projectPath, taskID, status string) error {
 	oldPattern := fmt.Sprintf("- [ ] %s", taskID)
 	newPattern := fmt.Sprintf("- [x] %s", taskID)
 
-	updatedContent := strings.Replace(content, oldPattern, newPattern, -1)
-
-	return os.WriteFile(prdPath, []byte(updatedContent), 0644)
+	return strings.Replace(content, oldPattern, newPattern, -1), nil
 }
 
 func getEpicNameFromTask(task *CurrentTaskData) string {
@@ -928,13 +1067,8 @@ func runQualityChecks(projectPath string) TaskStatus {
 	return TaskStatus{Success: true, Message: "Quality checks passed"}
 }
 
-func incrementIterationJSON(projectPath string, testResults, perfResults TaskStatus) error {
-	iterationsPath := filepath.Join(projectPath, "docs/3-current-task/iterations.json")
-	iterations, err := parseIterationsJSON(iterationsPath)
-	if err != nil {
-		return err
-	}
-
+// recordIteration moves iterations to the next iteration with the given results
+func recordIteration(iterations *IterationsData, testResults, perfResults TaskStatus) {
 	iterations.TaskContext.CurrentIteration++
 
 	// Add new iteration with results
@@ -959,8 +1093,6 @@ func incrementIterationJSON(projectPath string, testResults, perfResults TaskSta
 	}
 
 	iterations.Iterations = append(iterations.Iterations, newIteration)
-
-	return writeJSON(iterationsPath, iterations)
 }
 
 func getTestResultsString(status TaskStatus) string {
@@ -0,0 +1,46 @@
+package secrets
+
+import "math"
+
+// WindowSize is the length in bytes of the windows scanned for high-entropy strings
+const WindowSize = 32
+
+// ShannonEntropy returns the Shannon entropy of s in bits per byte. Random
+// base64 approaches 6, random hex 4 and English text stays around 4.
+func ShannonEntropy(s string) float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+
+	entropy := 0.0
+	length := float64(len(s))
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// MaxWindowEntropy returns the highest entropy of any WindowSize-byte window
+// of s, or the entropy of s itself when it is shorter than a window
+func MaxWindowEntropy(s string) float64 {
+	if len(s) <= WindowSize {
+		return ShannonEntropy(s)
+	}
+
+	highest := 0.0
+	for start := 0; start+WindowSize <= len(s); start++ {
+		if entropy := ShannonEntropy(s[start : start+WindowSize]); entropy > highest {
+			highest = entropy
+		}
+	}
+	return highest
+}
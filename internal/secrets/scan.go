@@ -0,0 +1,141 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// AllowlistFile is the file, relative to the project root, listing the
+// file+line combinations that must not be reported as secrets
+const AllowlistFile = ".claude-wm/secret-ignore.json"
+
+// Entropy thresholds above which a candidate string is reported. Hex strings
+// use a lower threshold since their alphabet caps entropy at 4 bits per byte;
+// as random hashes and digests reach it too, hex strings are only reported on
+// lines naming a key (see keyContextPattern).
+const (
+	Base64EntropyThreshold = 4.5
+	HexEntropyThreshold    = 3.0
+)
+
+// Finding is a likely secret found in a file
+type Finding struct {
+	Line  int
+	Kind  string
+	Match string
+}
+
+// Redacted returns the match with all but its first characters masked
+func (f Finding) Redacted() string {
+	if len(f.Match) <= 6 {
+		return strings.Repeat("*", len(f.Match))
+	}
+	return f.Match[:6] + strings.Repeat("*", 6)
+}
+
+// tokenPatterns match well-known API key formats, reported whatever their
+// entropy. When a pattern has a capture group, the group is the reported match.
+var tokenPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"AWS access key", regexp.MustCompile(`AKIA[0-9A-Z]{16}`)},
+	{"API secret key", regexp.MustCompile(`(?:^|[^A-Za-z0-9_\-])(sk-[A-Za-z0-9_\-]{20,})`)},
+	{"GitHub token", regexp.MustCompile(`ghp_[A-Za-z0-9]{36}`)},
+	{"Slack bot token", regexp.MustCompile(`xoxb-[0-9A-Za-z\-]{10,}`)},
+}
+
+var (
+	hexPattern    = regexp.MustCompile(`[0-9a-fA-F]{32,}`)
+	base64Pattern = regexp.MustCompile(`[A-Za-z0-9+/_\-]{40,}={0,2}`)
+
+	// keyContextPattern matches the names of credentials, e.g. apiKey, SECRET or client_token
+	keyContextPattern = regexp.MustCompile(`(?i)(api[_-]?key|secret|token|passw(or)?d|credential|private[_-]?key|access[_-]?key)`)
+)
+
+// ScanContent returns the likely secrets in content, at most one per line.
+// Binary content is not scanned.
+func ScanContent(content []byte) []Finding {
+	if bytes.IndexByte(content, 0) != -1 {
+		return nil
+	}
+
+	var findings []Finding
+	for index, line := range strings.Split(string(content), "\n") {
+		if finding, ok := scanLine(line); ok {
+			finding.Line = index + 1
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+func scanLine(line string) (Finding, bool) {
+	for _, token := range tokenPatterns {
+		if match := token.pattern.FindStringSubmatch(line); match != nil {
+			return Finding{Kind: token.kind, Match: match[len(match)-1]}, true
+		}
+	}
+
+	if keyContextPattern.MatchString(line) {
+		for _, match := range hexPattern.FindAllString(line, -1) {
+			if MaxWindowEntropy(match) > HexEntropyThreshold {
+				return Finding{Kind: "high-entropy hex string", Match: match}, true
+			}
+		}
+	}
+
+	for _, match := range base64Pattern.FindAllString(line, -1) {
+		if MaxWindowEntropy(match) > Base64EntropyThreshold {
+			return Finding{Kind: "high-entropy base64 string", Match: match}, true
+		}
+	}
+
+	return Finding{}, false
+}
+
+// AllowlistEntry suppresses the finding on one line of a file
+type AllowlistEntry struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+// Allowlist holds the findings known to be false positives
+type Allowlist struct {
+	Allow []AllowlistEntry `json:"allow"`
+}
+
+// LoadAllowlist reads the allowlist of the project, returning an empty
+// allowlist when the file does not exist
+func LoadAllowlist(projectRoot string) (*Allowlist, error) {
+	allowlist := &Allowlist{}
+
+	data, err := os.ReadFile(filepath.Join(projectRoot, AllowlistFile))
+	if os.IsNotExist(err) {
+		return allowlist, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", AllowlistFile, err)
+	}
+
+	if err := json.Unmarshal(data, allowlist); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", AllowlistFile, err)
+	}
+	return allowlist, nil
+}
+
+// Allows reports whether the finding on line of file is allowlisted
+func (a *Allowlist) Allows(file string, line int) bool {
+	file = filepath.ToSlash(file)
+	for _, entry := range a.Allow {
+		if filepath.ToSlash(entry.File) == file && entry.Line == line {
+			return true
+		}
+	}
+	return false
+}
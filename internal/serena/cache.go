@@ -0,0 +1,85 @@
+package serena
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	CacheFile    = "docs-cache.json"
+	cacheVersion = 1
+)
+
+// IndexCache records the state of every indexed documentation file, saved
+// to .serena/docs-cache.json, so that files whose size and modification time
+// didn't change since the last run are not hashed again
+type IndexCache struct {
+	Version int                   `json:"version"`
+	Files   map[string]CacheEntry `json:"files"` // path -> entry
+}
+
+// CacheEntry is the state of a documentation file when it was last scanned
+type CacheEntry struct {
+	SHA256    string    `json:"sha256"`
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"mod_time"`
+	IndexedAt time.Time `json:"indexed_at"` // When its content was last indexed
+}
+
+// NewIndexCache returns an empty cache
+func NewIndexCache() *IndexCache {
+	return &IndexCache{Version: cacheVersion, Files: make(map[string]CacheEntry)}
+}
+
+// Lookup returns the cached hash of path when info matches its cached size
+// and modification time
+func (c *IndexCache) Lookup(path string, info os.FileInfo) (string, bool) {
+	entry, ok := c.Files[path]
+	if !ok || entry.Size != info.Size() || !entry.ModTime.Equal(info.ModTime()) {
+		return "", false
+	}
+	return entry.SHA256, true
+}
+
+// LoadIndexCache loads the cache from .serena/docs-cache.json. It returns an
+// empty cache when the file does not exist or was written by an incompatible
+// version.
+func LoadIndexCache(root string) (*IndexCache, error) {
+	data, err := os.ReadFile(filepath.Join(root, SerenaDir, CacheFile))
+	if os.IsNotExist(err) {
+		return NewIndexCache(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index cache: %w", err)
+	}
+
+	var cache IndexCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal index cache: %w", err)
+	}
+	if cache.Version != cacheVersion || cache.Files == nil {
+		return NewIndexCache(), nil
+	}
+	return &cache, nil
+}
+
+// SaveIndexCache saves the cache to .serena/docs-cache.json
+func SaveIndexCache(root string, cache *IndexCache) error {
+	serenaDir := filepath.Join(root, SerenaDir)
+	if err := os.MkdirAll(serenaDir, 0755); err != nil {
+		return fmt.Errorf("failed to create serena directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal index cache: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(serenaDir, CacheFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write index cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package serena
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunIncrementalIndexWithOptions_Cache(t *testing.T) {
+	root := t.TempDir()
+	writeDoc(t, root, "docs/guide.md", "# Guide\n")
+	writeDoc(t, root, "docs/old.md", "# Old\n")
+	require.NoError(t, RunIncrementalIndex(root))
+
+	cache, err := LoadIndexCache(root)
+	require.NoError(t, err)
+	require.Len(t, cache.Files, 2)
+	guide := cache.Files[filepath.Join("docs", "guide.md")]
+	assert.False(t, guide.IndexedAt.IsZero())
+
+	manifest, err := LoadPrevManifest(root)
+	require.NoError(t, err)
+	assert.Equal(t, manifest[filepath.Join("docs", "guide.md")], guide.SHA256)
+
+	// Hashes of files with the cached size and modification time are reused
+	guide.SHA256 = "cached"
+	cache.Files[filepath.Join("docs", "guide.md")] = guide
+	require.NoError(t, SaveIndexCache(root, cache))
+	scan, err := scanDocs(root, nil, cache)
+	require.NoError(t, err)
+	assert.Equal(t, "cached", scan.manifest[filepath.Join("docs", "guide.md")])
+	assert.Zero(t, scan.hashed)
+
+	// Touched files are hashed again, deleted ones are pruned
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "docs/guide.md"), later, later))
+	require.NoError(t, os.Remove(filepath.Join(root, "docs/old.md")))
+	require.NoError(t, RunIncrementalIndex(root))
+
+	cache, err = LoadIndexCache(root)
+	require.NoError(t, err)
+	require.Len(t, cache.Files, 1)
+	assert.Equal(t, manifest[filepath.Join("docs", "guide.md")], cache.Files[filepath.Join("docs", "guide.md")].SHA256)
+	assert.True(t, cache.Files[filepath.Join("docs", "guide.md")].ModTime.Equal(later))
+
+	// A full rebuild reindexes every doc
+	require.NoError(t, RunIncrementalIndexWithOptions(root, IndexOptions{Full: true}))
+	rebuilt, err := LoadIndexCache(root)
+	require.NoError(t, err)
+	assert.True(t, rebuilt.Files[filepath.Join("docs", "guide.md")].IndexedAt.After(guide.IndexedAt))
+
+	searchIndex, err := LoadSearchIndex(root)
+	require.NoError(t, err)
+	assert.Len(t, searchIndex.Documents, 1)
+}
+
+func TestLoadIndexCache_Incompatible(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, SerenaDir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, SerenaDir, CacheFile), []byte(`{"version": 99, "files": {"docs/a.md": {}}}`), 0644))
+
+	cache, err := LoadIndexCache(root)
+	require.NoError(t, err)
+	assert.Empty(t, cache.Files)
+}
@@ -0,0 +1,131 @@
+package serena
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFile lists glob patterns of docs not to index, one per line, at the
+// project root
+const IgnoreFile = ".serenaignore"
+
+// Excluder matches the docs excluded from the index. Patterns are relative to
+// the project root: those without a slash match a file or directory name at
+// any depth, ** matches any number of directories, and a matching directory
+// excludes everything below it, so "archive", "docs/archive" and
+// "docs/**/archive/**" all exclude docs/archive/old.md.
+type Excluder struct {
+	patterns []*regexp.Regexp
+}
+
+// NewExcluder compiles the glob patterns
+func NewExcluder(patterns []string) (*Excluder, error) {
+	excluder := &Excluder{}
+	for _, pattern := range patterns {
+		glob := strings.Trim(filepath.ToSlash(strings.TrimSpace(pattern)), "/")
+		if glob == "" {
+			continue
+		}
+		if _, err := path.Match(glob, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		compiled, err := globRegexp(glob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+		excluder.patterns = append(excluder.patterns, compiled)
+	}
+	return excluder, nil
+}
+
+// LoadExcluder returns the excluder of the patterns of the IgnoreFile of root,
+// if any, and of extra
+func LoadExcluder(root string, extra []string) (*Excluder, error) {
+	patterns, err := readIgnoreFile(filepath.Join(root, IgnoreFile))
+	if err != nil {
+		return nil, err
+	}
+	return NewExcluder(append(patterns, extra...))
+}
+
+// Excluded reports whether the file or directory at relPath, relative to the
+// project root, is excluded
+func (e *Excluder) Excluded(relPath string) bool {
+	if e == nil || len(e.patterns) == 0 {
+		return false
+	}
+
+	// The path itself or any of its directories
+	relPath = filepath.ToSlash(relPath)
+	for candidate := relPath; candidate != "." && candidate != "/" && candidate != ""; candidate = path.Dir(candidate) {
+		for _, pattern := range e.patterns {
+			if pattern.MatchString(candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readIgnoreFile returns the patterns of the ignore file at ignorePath,
+// without blank lines and # comments, or none when it doesn't exist
+func readIgnoreFile(ignorePath string) ([]string, error) {
+	file, err := os.Open(ignorePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", IgnoreFile, err)
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			patterns = append(patterns, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", IgnoreFile, err)
+	}
+	return patterns, nil
+}
+
+// globRegexp converts glob to a regexp matching whole slash-separated paths
+func globRegexp(glob string) (*regexp.Regexp, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	if !strings.Contains(glob, "/") {
+		pattern.WriteString("(.*/)?")
+	}
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case strings.HasPrefix(glob[i:], "**/"):
+			pattern.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			pattern.WriteString(".*")
+			i++
+		case c == '*':
+			pattern.WriteString("[^/]*")
+		case c == '?':
+			pattern.WriteString("[^/]")
+		case c == '[':
+			// Same syntax in path.Match, which validated it, and regexp
+			end := i + strings.IndexByte(glob[i:], ']')
+			pattern.WriteString(glob[i : end+1])
+			i = end
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	pattern.WriteString("$")
+	return regexp.Compile(pattern.String())
+}
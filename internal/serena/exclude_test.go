@@ -0,0 +1,86 @@
+package serena
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcluder(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		excluded []string
+		kept     []string
+	}{
+		{"archive", []string{"docs/archive/old.md", "docs/a/archive/b/c.md"}, []string{"docs/archived.md"}},
+		{"docs/archive/", []string{"docs/archive/old.md"}, []string{"docs/a/archive/b.md"}},
+		{"docs/**/generated/**", []string{"docs/generated/api.md", "docs/x/y/generated/api.md"}, []string{"docs/generated.md"}},
+		{"*.draft.md", []string{"docs/notes.draft.md", "docs/a/b.draft.md"}, []string{"docs/draft.md"}},
+		{"docs/*.md", []string{"docs/readme.md"}, []string{"docs/a/readme.md"}},
+		{"docs/v[0-9].md", []string{"docs/v1.md"}, []string{"docs/vx.md"}},
+	}
+	for _, tt := range tests {
+		excluder, err := NewExcluder([]string{tt.pattern})
+		require.NoError(t, err, tt.pattern)
+		for _, path := range tt.excluded {
+			assert.True(t, excluder.Excluded(filepath.FromSlash(path)), "%s should exclude %s", tt.pattern, path)
+		}
+		for _, path := range tt.kept {
+			assert.False(t, excluder.Excluded(filepath.FromSlash(path)), "%s should keep %s", tt.pattern, path)
+		}
+	}
+
+	_, err := NewExcluder([]string{"docs/[a-"})
+	assert.ErrorContains(t, err, "invalid exclude pattern")
+
+	var none *Excluder
+	assert.False(t, none.Excluded("docs/readme.md"))
+}
+
+func TestRunIncrementalIndexWithOptions_Exclude(t *testing.T) {
+	root := t.TempDir()
+	writeDoc(t, root, "docs/guide.md", "# Guide\n")
+	writeDoc(t, root, "docs/archive/2024.md", "# Old\n")
+	writeDoc(t, root, "docs/archive/2023.md", "# Older\n")
+	writeDoc(t, root, "docs/api.generated.md", "# API\n")
+	require.NoError(t, RunIncrementalIndex(root))
+
+	indexed := func() []string {
+		manifest, err := LoadPrevManifest(root)
+		require.NoError(t, err)
+		var paths []string
+		for path := range manifest {
+			paths = append(paths, filepath.ToSlash(path))
+		}
+		sort.Strings(paths)
+		return paths
+	}
+	assert.Len(t, indexed(), 4)
+
+	// Newly excluded docs are dropped from the index
+	require.NoError(t, os.WriteFile(filepath.Join(root, IgnoreFile), []byte("# Old docs\ndocs/archive\n\n"), 0644))
+	require.NoError(t, RunIncrementalIndexWithOptions(root, IndexOptions{Exclude: []string{"*.generated.md"}}))
+	assert.Equal(t, []string{"docs/guide.md"}, indexed())
+
+	searchIndex, err := LoadSearchIndex(root)
+	require.NoError(t, err)
+	assert.Len(t, searchIndex.Documents, 1)
+
+	// .serenaignore applies without options
+	require.NoError(t, RunIncrementalIndex(root))
+	assert.Equal(t, []string{"docs/api.generated.md", "docs/guide.md"}, indexed())
+
+	// Excluded docs are never stale
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "docs/archive/2024.md"), later, later))
+	stats, err := GetIndexStats(root)
+	require.NoError(t, err)
+	assert.Empty(t, stats.StaleFiles)
+
+	assert.ErrorContains(t, RunIncrementalIndexWithOptions(root, IndexOptions{Exclude: []string{"["}}), "invalid exclude pattern")
+}
@@ -21,9 +21,34 @@ const (
 	DocsPattern   = "docs"
 )
 
+// IndexOptions tunes RunIncrementalIndexWithOptions
+type IndexOptions struct {
+	Exclude []string // Glob patterns of docs to skip, on top of .serenaignore
+	Full    bool     // Ignore the manifest and cache and reindex every doc
+}
+
 // BuildDocsManifest scans docs/ directory and computes SHA256 for all .md files
 func BuildDocsManifest(root string) (Manifest, error) {
-	manifest := make(Manifest)
+	scan, err := scanDocs(root, nil, NewIndexCache())
+	if err != nil {
+		return nil, err
+	}
+	return scan.manifest, nil
+}
+
+// docsScan is the result of scanDocs
+type docsScan struct {
+	manifest Manifest
+	cache    *IndexCache // Entries of the scanned docs only
+	skipped  int         // Docs excluded
+	hashed   int         // Docs whose hash was not found in the cache
+}
+
+// scanDocs is BuildDocsManifest skipping the docs excluded by excluder and
+// reusing the hashes from cache of the docs whose size and modification
+// time did not change
+func scanDocs(root string, excluder *Excluder, cache *IndexCache) (*docsScan, error) {
+	scan := &docsScan{manifest: make(Manifest), cache: NewIndexCache()}
 	docsPath := filepath.Join(root, DocsPattern)
 	
 	err := filepath.Walk(docsPath, func(path string, info os.FileInfo, err error) error {
@@ -47,13 +72,28 @@ func BuildDocsManifest(root string) (Manifest, error) {
 			return fmt.Errorf("failed to get relative path for %s: %w", path, err)
 		}
 		
-		// Compute SHA256
-		hash, err := computeFileSHA256(path)
-		if err != nil {
-			return fmt.Errorf("failed to compute SHA256 for %s: %w", path, err)
+		if excluder.Excluded(relPath) {
+			scan.skipped++
+			return nil
 		}
 		
-		manifest[relPath] = hash
+		// Compute SHA256 unless the file is unchanged since it was cached
+		hash, ok := cache.Lookup(relPath, info)
+		if !ok {
+			hash, err = computeFileSHA256(path)
+			if err != nil {
+				return fmt.Errorf("failed to compute SHA256 for %s: %w", path, err)
+			}
+			scan.hashed++
+		}
+		
+		scan.manifest[relPath] = hash
+		scan.cache.Files[relPath] = CacheEntry{
+			SHA256:    hash,
+			Size:      info.Size(),
+			ModTime:   info.ModTime(),
+			IndexedAt: cache.Files[relPath].IndexedAt,
+		}
 		return nil
 	})
 	
@@ -61,7 +101,7 @@ func BuildDocsManifest(root string) (Manifest, error) {
 		return nil, fmt.Errorf("failed to build docs manifest: %w", err)
 	}
 	
-	return manifest, nil
+	return scan, nil
 }
 
 // LoadPrevManifest loads the previous manifest from .serena/docs-manifest.json
@@ -170,21 +210,49 @@ func IndexWithSerena(paths []string) error {
 	return nil
 }
 
-// RunIncrementalIndex performs the complete incremental indexing workflow
+// RunIncrementalIndex performs the complete incremental indexing workflow,
+// skipping the docs excluded by .serenaignore
 func RunIncrementalIndex(root string) error {
-	log.Printf("[SERENA] Starting incremental indexing for docs/")
-	
-	// Load previous manifest
-	prevManifest, err := LoadPrevManifest(root)
+	return RunIncrementalIndexWithOptions(root, IndexOptions{})
+}
+
+// RunIncrementalIndexWithOptions is RunIncrementalIndex with options. Docs
+// excluded or deleted since the last run are removed from the index and
+// from the cache.
+func RunIncrementalIndexWithOptions(root string, options IndexOptions) error {
+	excluder, err := LoadExcluder(root, options.Exclude)
 	if err != nil {
-		return fmt.Errorf("failed to load previous manifest: %w", err)
+		return err
+	}
+	
+	// Load previous manifest and cache, unless rebuilding from scratch
+	prevManifest := make(Manifest)
+	cache := NewIndexCache()
+	if options.Full {
+		log.Printf("[SERENA] Starting full indexing for docs/")
+	} else {
+		log.Printf("[SERENA] Starting incremental indexing for docs/")
+		
+		prevManifest, err = LoadPrevManifest(root)
+		if err != nil {
+			return fmt.Errorf("failed to load previous manifest: %w", err)
+		}
+		cache, err = LoadIndexCache(root)
+		if err != nil {
+			return fmt.Errorf("failed to load index cache: %w", err)
+		}
 	}
 	
 	// Build current manifest
-	curManifest, err := BuildDocsManifest(root)
+	scan, err := scanDocs(root, excluder, cache)
 	if err != nil {
 		return fmt.Errorf("failed to build current manifest: %w", err)
 	}
+	curManifest := scan.manifest
+	if scan.skipped > 0 {
+		log.Printf("[SERENA] Skipped %d excluded files", scan.skipped)
+	}
+	log.Printf("[SERENA] Hashed %d files, %d unchanged since cached", scan.hashed, len(curManifest)-scan.hashed)
 	
 	// Calculate delta
 	delta := Delta(prevManifest, curManifest)
@@ -196,8 +264,29 @@ func RunIncrementalIndex(root string) error {
 	// Combine added and modified files for indexing
 	filesToIndex := append(delta.Added, delta.Modified...)
 	
-	if len(filesToIndex) == 0 && len(delta.Removed) == 0 {
+	// Load the search index, rebuilt from every file when missing
+	var searchIndex *SearchIndex
+	if !options.Full {
+		searchIndex, err = LoadSearchIndex(root)
+		if err != nil {
+			return fmt.Errorf("failed to load search index: %w", err)
+		}
+	}
+	searchFiles := filesToIndex
+	if searchIndex == nil {
+		searchFiles = make([]string, 0, len(curManifest))
+		for path := range curManifest {
+			searchFiles = append(searchFiles, path)
+		}
+	}
+	
+	if len(filesToIndex) == 0 && len(delta.Removed) == 0 && searchIndex != nil {
 		log.Printf("[SERENA] No changes detected - skipping indexation")
+		
+		// Keep the new modification times of touched but unchanged files
+		if err := SaveIndexCache(root, scan.cache); err != nil {
+			return fmt.Errorf("failed to save index cache: %w", err)
+		}
 		return nil
 	}
 	
@@ -217,11 +306,31 @@ func RunIncrementalIndex(root string) error {
 		return fmt.Errorf("failed to index files with Serena: %w", err)
 	}
 	
+	// Update word frequencies used by Search
+	searchIndex, err = UpdateSearchIndex(root, searchIndex, searchFiles, delta.Removed)
+	if err != nil {
+		return fmt.Errorf("failed to update search index: %w", err)
+	}
+	if err := SaveSearchIndex(root, searchIndex); err != nil {
+		return fmt.Errorf("failed to save search index: %w", err)
+	}
+	
 	// Save updated manifest
 	if err := SaveManifest(root, curManifest); err != nil {
 		return fmt.Errorf("failed to save manifest: %w", err)
 	}
 	
+	// Save the cache, with the time the changed files were indexed
+	indexedAt := time.Now()
+	for _, path := range searchFiles {
+		entry := scan.cache.Files[path]
+		entry.IndexedAt = indexedAt
+		scan.cache.Files[path] = entry
+	}
+	if err := SaveIndexCache(root, scan.cache); err != nil {
+		return fmt.Errorf("failed to save index cache: %w", err)
+	}
+	
 	log.Printf("[SERENA] Incremental indexing completed successfully")
 	return nil
 }
@@ -0,0 +1,307 @@
+package serena
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	SearchIndexFile    = "docs-index.json"
+	searchIndexVersion = 1
+	maxExcerptRunes    = 160
+)
+
+// SearchIndex holds the word frequencies of every indexed documentation file,
+// saved to .serena/docs-index.json
+type SearchIndex struct {
+	Version   int                      `json:"version"`
+	Documents map[string]DocumentTerms `json:"documents"` // path -> terms
+}
+
+// DocumentTerms is the word frequency map of a single file
+type DocumentTerms struct {
+	Terms  map[string]int `json:"terms"`
+	Length int            `json:"length"` // Total number of words
+}
+
+// SearchResult is a file matching a search query
+type SearchResult struct {
+	File       string  `json:"file"`
+	Score      float64 `json:"score"`
+	Excerpt    string  `json:"excerpt"`
+	LineNumber int     `json:"line_number"`
+}
+
+// Tokenize splits text into lowercase words, ignoring punctuation and
+// single-character words
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := fields[:0]
+	for _, field := range fields {
+		if len([]rune(field)) > 1 {
+			tokens = append(tokens, field)
+		}
+	}
+	return tokens
+}
+
+// LoadSearchIndex loads the search index from .serena/docs-index.json. It
+// returns nil without error when the index does not exist or was written by
+// an incompatible version and must be rebuilt.
+func LoadSearchIndex(root string) (*SearchIndex, error) {
+	indexPath := filepath.Join(root, SerenaDir, SearchIndexFile)
+
+	data, err := os.ReadFile(indexPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search index: %w", err)
+	}
+
+	var index SearchIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal search index: %w", err)
+	}
+	if index.Version != searchIndexVersion || index.Documents == nil {
+		return nil, nil
+	}
+
+	return &index, nil
+}
+
+// SaveSearchIndex saves the search index to .serena/docs-index.json
+func SaveSearchIndex(root string, index *SearchIndex) error {
+	serenaDir := filepath.Join(root, SerenaDir)
+	if err := os.MkdirAll(serenaDir, 0755); err != nil {
+		return fmt.Errorf("failed to create serena directory: %w", err)
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(serenaDir, SearchIndexFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write search index file: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSearchIndex recomputes the word frequencies of paths and drops the
+// removed files. A nil index is replaced by a new one.
+func UpdateSearchIndex(root string, index *SearchIndex, paths, removed []string) (*SearchIndex, error) {
+	if index == nil {
+		index = &SearchIndex{Version: searchIndexVersion, Documents: make(map[string]DocumentTerms)}
+	}
+
+	for _, path := range removed {
+		delete(index.Documents, path)
+	}
+
+	for _, path := range paths {
+		data, err := os.ReadFile(filepath.Join(root, path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		doc := DocumentTerms{Terms: make(map[string]int)}
+		for _, token := range Tokenize(string(data)) {
+			doc.Terms[token]++
+			doc.Length++
+		}
+		index.Documents[path] = doc
+	}
+
+	return index, nil
+}
+
+// Search returns the indexed files containing every word of query, sorted by
+// descending TF-IDF score. Matching is case-insensitive.
+func Search(rootPath, query string) ([]SearchResult, error) {
+	tokens := uniqueTokens(Tokenize(query))
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("search query %q has no searchable words", query)
+	}
+
+	index, err := LoadSearchIndex(rootPath)
+	if err != nil {
+		return nil, err
+	}
+	if index == nil {
+		return nil, fmt.Errorf("no search index found in %s, run serena-indexer first", filepath.Join(rootPath, SerenaDir))
+	}
+
+	// Inverse document frequency of each query word, smoothed so that a word
+	// found in every file still contributes to the score
+	total := float64(len(index.Documents))
+	idf := make(map[string]float64, len(tokens))
+	for _, token := range tokens {
+		docs := 0
+		for _, doc := range index.Documents {
+			if doc.Terms[token] > 0 {
+				docs++
+			}
+		}
+		if docs == 0 {
+			return []SearchResult{}, nil
+		}
+		idf[token] = math.Log(1 + total/float64(docs))
+	}
+
+	results := []SearchResult{}
+	for path, doc := range index.Documents {
+		score := 0.0
+		matchesAll := doc.Length > 0
+		for _, token := range tokens {
+			count := doc.Terms[token]
+			if count == 0 {
+				matchesAll = false
+				break
+			}
+			score += float64(count) / float64(doc.Length) * idf[token]
+		}
+		if !matchesAll {
+			continue
+		}
+
+		result := SearchResult{File: path, Score: score}
+		result.LineNumber, result.Excerpt = findExcerpt(filepath.Join(rootPath, path), tokens)
+		results = append(results, result)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].File < results[j].File
+	})
+
+	return results, nil
+}
+
+// Highlight wraps every occurrence of the words of query in excerpt with
+// before and after, ignoring case
+func Highlight(excerpt, query, before, after string) string {
+	tokens := uniqueTokens(Tokenize(query))
+	if len(tokens) == 0 {
+		return excerpt
+	}
+
+	var b strings.Builder
+	runes := []rune(excerpt)
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			b.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end])) {
+			end++
+		}
+		word := string(runes[i:end])
+		if containsToken(tokens, strings.ToLower(word)) {
+			b.WriteString(before + word + after)
+		} else {
+			b.WriteString(word)
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// findExcerpt returns the 1-based number and text of the first line of path
+// containing the most query words
+func findExcerpt(path string, tokens []string) (int, string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, ""
+	}
+
+	bestLine, bestText, bestMatches := 0, "", 0
+	for i, line := range strings.Split(string(data), "\n") {
+		matches := 0
+		for _, token := range uniqueTokens(Tokenize(line)) {
+			if containsToken(tokens, token) {
+				matches++
+			}
+		}
+		if matches > bestMatches {
+			bestLine, bestText, bestMatches = i+1, line, matches
+		}
+		if bestMatches == len(tokens) {
+			break
+		}
+	}
+
+	return bestLine, truncateExcerpt(strings.TrimSpace(bestText), tokens)
+}
+
+// truncateExcerpt shortens line to maxExcerptRunes around the first query word
+func truncateExcerpt(line string, tokens []string) string {
+	runes := []rune(line)
+	if len(runes) <= maxExcerptRunes {
+		return line
+	}
+
+	first := 0
+	lower := strings.ToLower(line)
+	for _, token := range tokens {
+		if pos := strings.Index(lower, token); pos >= 0 {
+			first = len([]rune(lower[:pos]))
+			break
+		}
+	}
+
+	start := first - maxExcerptRunes/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + maxExcerptRunes
+	if end > len(runes) {
+		end = len(runes)
+		start = end - maxExcerptRunes
+	}
+
+	excerpt := string(runes[start:end])
+	if start > 0 {
+		excerpt = "..." + excerpt
+	}
+	if end < len(runes) {
+		excerpt += "..."
+	}
+	return excerpt
+}
+
+func uniqueTokens(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	unique := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if !seen[token] {
+			seen[token] = true
+			unique = append(unique, token)
+		}
+	}
+	return unique
+}
+
+func containsToken(tokens []string, token string) bool {
+	for _, t := range tokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package serena
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDoc(t *testing.T, root, path, content string) {
+	t.Helper()
+	fullPath := filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+	require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+}
+
+func TestSearch(t *testing.T) {
+	root := t.TempDir()
+	writeDoc(t, root, "docs/backup.md", "# Backups\n\nIntro.\nCreate a Backup before every restore.\nBackup retention is 7 days.\n")
+	writeDoc(t, root, "docs/config.md", "# Config\n\nThe backup directory is configurable.\n")
+	writeDoc(t, root, "docs/tickets.md", "# Tickets\n\nTickets track bugs.\n")
+
+	_, err := Search(root, "backup")
+	assert.ErrorContains(t, err, "no search index found")
+
+	require.NoError(t, RunIncrementalIndex(root))
+
+	t.Run("ranks by TF-IDF", func(t *testing.T) {
+		results, err := Search(root, "BACKUP")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "docs/backup.md", filepath.ToSlash(results[0].File))
+		assert.Equal(t, 4, results[0].LineNumber)
+		assert.Equal(t, "docs/config.md", filepath.ToSlash(results[1].File))
+		assert.Greater(t, results[0].Score, results[1].Score)
+	})
+
+	t.Run("combines words with AND", func(t *testing.T) {
+		results, err := Search(root, "backup restore")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, 4, results[0].LineNumber)
+		assert.Equal(t, "Create a Backup before every restore.", results[0].Excerpt)
+
+		results, err = Search(root, "backup tickets")
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("follows incremental changes", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(root, "docs/config.md")))
+		writeDoc(t, root, "docs/tickets.md", "# Tickets\n\nRestore a backup to reopen tickets.\n")
+		require.NoError(t, RunIncrementalIndex(root))
+
+		results, err := Search(root, "backup tickets")
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "docs/tickets.md", filepath.ToSlash(results[0].File))
+	})
+
+	t.Run("rejects queries without words", func(t *testing.T) {
+		_, err := Search(root, " - ")
+		assert.Error(t, err)
+	})
+}
+
+func TestHighlight(t *testing.T) {
+	assert.Equal(t, "Create a [Backup] before every [restore].",
+		Highlight("Create a Backup before every restore.", "backup RESTORE", "[", "]"))
+	assert.Equal(t, "backups are kept", Highlight("backups are kept", "backup", "[", "]"))
+}
@@ -0,0 +1,134 @@
+package serena
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IndexStats describes the docs index of a project
+type IndexStats struct {
+	FileCount      int       `json:"file_count"`       // Files in the manifest
+	TotalSizeBytes int64     `json:"total_size_bytes"` // Current size of the indexed files
+	LastIndexed    time.Time `json:"last_indexed"`     // When the manifest was last saved
+	StaleFiles     []string  `json:"stale_files"`      // Docs modified or added since LastIndexed
+	IndexVersion   string    `json:"index_version"`    // Search index format, empty when missing
+}
+
+// DanglingReferencesError lists the indexed files that no longer exist
+type DanglingReferencesError struct {
+	Files []string
+}
+
+func (e *DanglingReferencesError) Error() string {
+	return fmt.Sprintf("%d indexed files no longer exist: %s", len(e.Files), strings.Join(e.Files, ", "))
+}
+
+// GetIndexStats returns the statistics of the docs index of root. Docs
+// excluded by .serenaignore are never stale. It fails with an error wrapping
+// os.ErrNotExist when the docs were never indexed.
+func GetIndexStats(root string) (*IndexStats, error) {
+	manifestPath := filepath.Join(root, SerenaDir, ManifestFile)
+	info, err := os.Stat(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	manifest, err := LoadPrevManifest(root)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &IndexStats{
+		FileCount:   len(manifest),
+		LastIndexed: info.ModTime(),
+		StaleFiles:  make([]string, 0),
+	}
+	for path := range manifest {
+		if fileInfo, err := os.Stat(filepath.Join(root, path)); err == nil {
+			stats.TotalSizeBytes += fileInfo.Size()
+		}
+	}
+
+	searchIndex, err := LoadSearchIndex(root)
+	if err != nil {
+		return nil, err
+	}
+	if searchIndex != nil {
+		stats.IndexVersion = strconv.Itoa(searchIndex.Version)
+	}
+
+	excluder, err := LoadExcluder(root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	docsPath := filepath.Join(root, DocsPattern)
+	err = filepath.Walk(docsPath, func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fileInfo.IsDir() || !strings.HasSuffix(strings.ToLower(path), ".md") || strings.Contains(path, SerenaDir) {
+			return nil
+		}
+		if !fileInfo.ModTime().After(stats.LastIndexed) {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path for %s: %w", path, err)
+		}
+		if excluder.Excluded(relPath) {
+			return nil
+		}
+		stats.StaleFiles = append(stats.StaleFiles, relPath)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to scan docs: %w", err)
+	}
+	sort.Strings(stats.StaleFiles)
+
+	return stats, nil
+}
+
+// ValidateIndex checks that every file referenced by the manifest and the
+// search index of root still exists. It returns a *DanglingReferencesError
+// listing the missing files.
+func ValidateIndex(root string) error {
+	manifest, err := LoadPrevManifest(root)
+	if err != nil {
+		return err
+	}
+	searchIndex, err := LoadSearchIndex(root)
+	if err != nil {
+		return err
+	}
+
+	referenced := make(map[string]bool, len(manifest))
+	for path := range manifest {
+		referenced[path] = true
+	}
+	if searchIndex != nil {
+		for path := range searchIndex.Documents {
+			referenced[path] = true
+		}
+	}
+
+	var dangling []string
+	for path := range referenced {
+		if _, err := os.Stat(filepath.Join(root, path)); os.IsNotExist(err) {
+			dangling = append(dangling, path)
+		}
+	}
+	if len(dangling) > 0 {
+		sort.Strings(dangling)
+		return &DanglingReferencesError{Files: dangling}
+	}
+	return nil
+}
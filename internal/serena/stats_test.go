@@ -0,0 +1,78 @@
+package serena
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// indexedDocs writes five docs to a temp project, indexes them and returns
+// the project root
+func indexedDocs(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeDoc(t, root, "docs/README.md", "# Readme\n")
+	writeDoc(t, root, "docs/1-project/vision.md", "# Vision\n")
+	writeDoc(t, root, "docs/1-project/roadmap.md", "# Roadmap\n")
+	writeDoc(t, root, "docs/2-current-epic/epic.md", "# Epic\n")
+	writeDoc(t, root, "docs/3-current-task/task.md", "# Task\n")
+	writeDoc(t, root, "docs/notes.txt", "not markdown")
+	require.NoError(t, RunIncrementalIndex(root))
+
+	// Index after every doc was last written
+	indexed := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(root, SerenaDir, ManifestFile), indexed, indexed))
+	return root
+}
+
+func TestGetIndexStats(t *testing.T) {
+	_, err := GetIndexStats(t.TempDir())
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	root := indexedDocs(t)
+	stats, err := GetIndexStats(root)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.FileCount)
+	assert.Equal(t, int64(9+9+10+7+7), stats.TotalSizeBytes)
+	assert.Equal(t, "1", stats.IndexVersion)
+	assert.Empty(t, stats.StaleFiles)
+
+	// Modified and new docs are stale until the next run
+	later := stats.LastIndexed.Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(root, "docs/1-project/vision.md"), later, later))
+	writeDoc(t, root, "docs/2-current-epic/stories.md", "# Stories\n")
+	require.NoError(t, os.Chtimes(filepath.Join(root, "docs/2-current-epic/stories.md"), later, later))
+
+	stats, err = GetIndexStats(root)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.FileCount)
+	assert.Equal(t, []string{
+		filepath.FromSlash("docs/1-project/vision.md"),
+		filepath.FromSlash("docs/2-current-epic/stories.md"),
+	}, stats.StaleFiles)
+}
+
+func TestValidateIndex(t *testing.T) {
+	root := indexedDocs(t)
+	require.NoError(t, ValidateIndex(root))
+
+	require.NoError(t, os.Remove(filepath.Join(root, "docs/1-project/roadmap.md")))
+	require.NoError(t, os.Remove(filepath.Join(root, "docs/README.md")))
+
+	err := ValidateIndex(root)
+	var dangling *DanglingReferencesError
+	require.ErrorAs(t, err, &dangling)
+	assert.Equal(t, []string{
+		filepath.FromSlash("docs/1-project/roadmap.md"),
+		filepath.FromSlash("docs/README.md"),
+	}, dangling.Files)
+
+	// Re-indexing drops the missing files
+	require.NoError(t, RunIncrementalIndex(root))
+	assert.NoError(t, ValidateIndex(root))
+}
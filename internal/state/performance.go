@@ -559,7 +559,7 @@ func (osm *OptimizedStateManager) checkMemoryUsage(operation string) error {
 		runtime.ReadMemStats(&m)
 
 		if m.Alloc > osm.memoryLimit {
-			return errors.NewCLIError("Memory limit exceeded", 1).
+			return errors.NewCLIError("Memory limit exceeded", errors.ExitGeneric).
 				WithDetails(fmt.Sprintf("Current usage: %d bytes, limit: %d bytes", m.Alloc, osm.memoryLimit)).
 				WithContext("operation", operation).
 				WithSuggestion("Reduce the size of state files or increase memory limit")
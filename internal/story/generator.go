@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"claude-wm-cli/internal/backup"
 	"claude-wm-cli/internal/epic"
 )
 
@@ -350,6 +351,99 @@ func (g *Generator) DeleteStory(storyID string) error {
 	return g.saveStoryCollection(collection)
 }
 
+// ArchiveStoriesForEpic moves the stories of an archived epic to the archived
+// stories of stories.json, after backing up the file, and returns how many
+// were archived
+func (g *Generator) ArchiveStoriesForEpic(epicID string) (int, error) {
+	collection, err := g.loadStoryCollection()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load story collection: %w", err)
+	}
+
+	var ids []string
+	for id, story := range collection.Stories {
+		if story.EpicID == epicID {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := g.backupStoriesFile(fmt.Sprintf("Automatic backup of stories.json before archiving the stories of %s", epicID)); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	if collection.ArchivedStories == nil {
+		collection.ArchivedStories = make(map[string]*Story)
+	}
+	for _, id := range ids {
+		story := collection.Stories[id]
+		story.ArchivedAt = &now
+		collection.ArchivedStories[id] = story
+		delete(collection.Stories, id)
+		if collection.CurrentStory == id {
+			collection.CurrentStory = ""
+		}
+	}
+	collection.Metadata.TotalStories = len(collection.Stories)
+	collection.Metadata.TotalTasks = g.countTotalTasks(collection)
+
+	if err := g.saveStoryCollection(collection); err != nil {
+		return 0, fmt.Errorf("failed to save story collection: %w", err)
+	}
+	return len(ids), nil
+}
+
+// UnarchiveStoriesForEpic moves the archived stories of an epic back to the
+// stories of stories.json, after backing up the file, and returns how many
+// were restored
+func (g *Generator) UnarchiveStoriesForEpic(epicID string) (int, error) {
+	collection, err := g.loadStoryCollection()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load story collection: %w", err)
+	}
+
+	var ids []string
+	for id, story := range collection.ArchivedStories {
+		if story.EpicID == epicID {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := g.backupStoriesFile(fmt.Sprintf("Automatic backup of stories.json before unarchiving the stories of %s", epicID)); err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		story := collection.ArchivedStories[id]
+		story.ArchivedAt = nil
+		collection.Stories[id] = story
+		delete(collection.ArchivedStories, id)
+	}
+	collection.Metadata.TotalStories = len(collection.Stories)
+	collection.Metadata.TotalTasks = g.countTotalTasks(collection)
+
+	if err := g.saveStoryCollection(collection); err != nil {
+		return 0, fmt.Errorf("failed to save story collection: %w", err)
+	}
+	return len(ids), nil
+}
+
+// backupStoriesFile backs up stories.json before stories are archived from
+// or restored to it
+func (g *Generator) backupStoriesFile(description string) error {
+	storiesPath := filepath.Join(g.rootPath, "docs", "2-current-epic", StoriesFileName)
+	if _, err := backup.BackupFile(storiesPath, backup.BackupTypeAutomatic, description); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", StoriesFileName, err)
+	}
+	return nil
+}
+
 // loadStoryCollection loads the story collection from disk
 func (g *Generator) loadStoryCollection() (*StoryCollection, error) {
 	storiesPath := filepath.Join(g.rootPath, "docs", "2-current-epic", StoriesFileName)
@@ -478,8 +572,11 @@ func (g *Generator) generateStoryID(title string, collection *StoryCollection) s
 	counter := 1
 	storyID := fmt.Sprintf("STORY-%03d-%s", counter, baseID)
 
+	// Archived stories keep their ID, so that they can be unarchived
 	for {
-		if _, exists := collection.Stories[storyID]; !exists {
+		_, exists := collection.Stories[storyID]
+		_, archived := collection.ArchivedStories[storyID]
+		if !exists && !archived {
 			break
 		}
 		counter++
@@ -420,3 +420,48 @@ func setupTestDirs(t *testing.T, tempDir string) {
 	err = os.MkdirAll(currentTaskDir, 0755)
 	require.NoError(t, err)
 }
+
+func TestGenerator_ArchiveStoriesForEpic(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	generator := NewGenerator(tempDir)
+
+	epicManager := epic.NewManager(tempDir)
+	archivedEpic, err := epicManager.CreateEpic(epic.EpicCreateOptions{Title: "Archived Epic"})
+	require.NoError(t, err)
+	otherEpic, err := epicManager.CreateEpic(epic.EpicCreateOptions{Title: "Other Epic"})
+	require.NoError(t, err)
+
+	first, err := generator.CreateStory(StoryCreateOptions{Title: "Story One", EpicID: archivedEpic.ID})
+	require.NoError(t, err)
+	_, err = generator.CreateStory(StoryCreateOptions{Title: "Story Two", EpicID: archivedEpic.ID})
+	require.NoError(t, err)
+	_, err = generator.CreateStory(StoryCreateOptions{Title: "Story Three", EpicID: otherEpic.ID})
+	require.NoError(t, err)
+
+	archived, err := generator.ArchiveStoriesForEpic(archivedEpic.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, archived)
+
+	stories, err := generator.ListStories("", "")
+	require.NoError(t, err)
+	require.Len(t, stories, 1)
+	assert.Equal(t, otherEpic.ID, stories[0].EpicID)
+
+	// Archived IDs aren't reused
+	again, err := generator.CreateStory(StoryCreateOptions{Title: "Story One", EpicID: otherEpic.ID})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, again.ID)
+
+	restored, err := generator.UnarchiveStoriesForEpic(archivedEpic.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+	stories, err = generator.ListStories("", "")
+	require.NoError(t, err)
+	assert.Len(t, stories, 4)
+
+	none, err := generator.UnarchiveStoriesForEpic(archivedEpic.ID)
+	require.NoError(t, err)
+	assert.Zero(t, none)
+}
@@ -28,6 +28,7 @@ type Story struct {
 	UpdatedAt          time.Time  `json:"updated_at"`
 	StartedAt          *time.Time `json:"started_at,omitempty"`
 	CompletedAt        *time.Time `json:"completed_at,omitempty"`
+	ArchivedAt         *time.Time `json:"archived_at,omitempty"`
 }
 
 // Task represents a task within a story (generated from acceptance criteria)
@@ -43,9 +44,10 @@ type Task struct {
 
 // StoryCollection represents the collection of all stories
 type StoryCollection struct {
-	Stories      map[string]*Story  `json:"stories"`
-	CurrentStory string             `json:"current_story,omitempty"`
-	Metadata     CollectionMetadata `json:"metadata"`
+	Stories         map[string]*Story  `json:"stories"`
+	ArchivedStories map[string]*Story  `json:"archived_stories,omitempty"` // Stories of archived epics
+	CurrentStory    string             `json:"current_story,omitempty"`
+	Metadata        CollectionMetadata `json:"metadata"`
 }
 
 // CollectionMetadata contains metadata about the story collection
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// annotationOutputLines is how many lines of a failed command's output are
+// included in its annotation
+const annotationOutputLines = 20
+
+// WriteGitHubAnnotations writes GitHub Actions workflow commands for the
+// results: an ::error for each failed or timed out level and a ::warning for
+// each skipped one, so that they show up in the workflow summary
+func WriteGitHubAnnotations(w io.Writer, results []TestResult, levels []TestLevel) {
+	names := make(map[string]string, len(levels))
+	for _, level := range levels {
+		names[level.Level] = level.Name
+	}
+
+	for _, result := range results {
+		title := strings.TrimSpace(result.Level + " " + names[result.Level])
+		switch {
+		case result.Skipped:
+			fmt.Fprintf(w, "::warning title=%s skipped::%s\n", escapeAnnotationProperty(title), escapeAnnotationData(result.Error))
+		case result.TimedOut:
+			fmt.Fprintf(w, "::error title=%s timed out::%s\n", escapeAnnotationProperty(title), escapeAnnotationData(result.Error))
+		case !result.Success:
+			message := result.Error
+			if tail := lastLines(result.Output, annotationOutputLines); tail != "" {
+				message += "\n" + tail
+			}
+			fmt.Fprintf(w, "::error title=%s failed::%s\n", escapeAnnotationProperty(title), escapeAnnotationData(message))
+		}
+	}
+}
+
+// lastLines returns the last n lines of output
+func lastLines(output string, n int) string {
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escapeAnnotationData escapes the message of a workflow command
+func escapeAnnotationData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command
+func escapeAnnotationProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
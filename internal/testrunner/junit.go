@@ -0,0 +1,127 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite reports one test level
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase reports the command of a test level
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Output  string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteJUnitReport writes the results as a JUnit XML report to outputPath, with
+// one test suite per level and one test case per level command. Levels without
+// result, e.g. after a failure in sequential mode, are reported as skipped.
+func WriteJUnitReport(results []TestResult, levels []TestLevel, outputPath string) error {
+	byLevel := make(map[string]TestResult, len(results))
+	for _, result := range results {
+		byLevel[result.Level] = result
+	}
+
+	report := junitTestSuites{Name: "claude-wm-cli"}
+	var total float64
+	for _, level := range levels {
+		result, ran := byLevel[level.Level]
+		if !ran {
+			result = TestResult{Level: level.Level, Skipped: true, Error: "not run"}
+		}
+
+		seconds := result.Duration.Seconds()
+		total += seconds
+		testCase := junitTestCase{
+			Name:      strings.Join(level.Commands, " "),
+			ClassName: level.Level,
+			Time:      junitTime(seconds),
+		}
+		suite := junitTestSuite{
+			Name:  fmt.Sprintf("%s %s", level.Level, level.Name),
+			Tests: 1,
+			Time:  junitTime(seconds),
+		}
+
+		switch {
+		case result.Skipped:
+			testCase.Skipped = &junitSkipped{Message: result.Error}
+			suite.Skipped++
+		case !result.Success:
+			failureType := "failure"
+			if result.TimedOut {
+				failureType = "timeout"
+			}
+			output := result.Output
+			if output == "" {
+				output = result.Error
+			}
+			testCase.Failure = &junitFailure{Message: result.Error, Type: failureType, Output: output}
+			suite.Failures++
+		}
+
+		suite.TestCases = []junitTestCase{testCase}
+		report.Suites = append(report.Suites, suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+	}
+	report.Time = junitTime(total)
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+
+	if dir := filepath.Dir(outputPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	data = append([]byte(xml.Header), append(data, '\n')...)
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+// junitTime formats seconds the way JUnit reports expect them
+func junitTime(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJUnitReport(t *testing.T) {
+	levels := NewTestRunner().levels[:3]
+	results := []TestResult{
+		{Level: "L0", Success: true, Duration: 1500 * time.Millisecond},
+		{Level: "L1", Error: "exit status 2", Output: "--- FAIL: TestSomething\n", Duration: time.Second},
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	require.NoError(t, WriteJUnitReport(results, levels, outputPath))
+
+	data, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal(data, &report))
+	assert.Equal(t, 3, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, "2.500", report.Time)
+
+	require.Len(t, report.Suites, 3)
+	assert.Equal(t, "L0 Smoke Tests", report.Suites[0].Name)
+	assert.Equal(t, "make test-smoke", report.Suites[0].TestCases[0].Name)
+	assert.Nil(t, report.Suites[0].TestCases[0].Failure)
+
+	failure := report.Suites[1].TestCases[0].Failure
+	require.NotNil(t, failure)
+	assert.Equal(t, "exit status 2", failure.Message)
+	assert.Contains(t, failure.Output, "--- FAIL: TestSomething")
+
+	// L2 never ran, as the sequential run stopped at L1
+	require.NotNil(t, report.Suites[2].TestCases[0].Skipped)
+}
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	results := []TestResult{
+		{Level: "L0", Success: true},
+		{Level: "L1", Error: "exit status 2", Output: "50% done\nFAIL"},
+		{Level: "L2", Error: "command timed out after 5m0s", TimedOut: true},
+		{Level: "L4", Skipped: true, Error: "skipped: dependency L2 failed"},
+	}
+
+	var out bytes.Buffer
+	WriteGitHubAnnotations(&out, results, NewTestRunner().levels)
+
+	assert.Equal(t,
+		"::error title=L1 Unit Tests failed::exit status 2%0A50%25 done%0AFAIL\n"+
+			"::error title=L2 Integration Tests timed out::command timed out after 5m0s\n"+
+			"::warning title=L4 System Tests skipped::skipped: dependency L2 failed\n",
+		out.String())
+}
@@ -1,20 +1,32 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"os/exec"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"text/tabwriter"
 	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // TestLevel represents a testing level in the L0-L3 protocol
 type TestLevel struct {
-	Level       string
-	Name        string
-	Description string
-	Commands    []string
-	Timeout     time.Duration
+	Level        string
+	Name         string
+	Description  string
+	Commands     []string
+	Timeout      time.Duration
+	Dependencies []string // Levels that must pass before this one runs in parallel mode
 }
 
 // TestResult represents the result of running a test level
@@ -24,13 +36,26 @@ type TestResult struct {
 	Output  string
 	Error   string
 	Duration time.Duration
+	Skipped bool // Not run because a dependency failed
+	TimedOut bool // Killed after exceeding the level timeout
+	Attempts int // Runs of the level, including retries
 }
 
+// errCommandTimeout marks a test command killed after exceeding its timeout
+var errCommandTimeout = errors.New("timed out")
+
 // TestRunner orchestrates the complete test suite
 type TestRunner struct {
 	levels []TestLevel
 	results []TestResult
 	verbose bool
+	parallel bool
+	workers  int
+	skipManifest bool
+	elapsed  time.Duration // Wall-clock time of the levels in parallel mode
+	retryCount         int
+	retryDelay         time.Duration
+	retryOnlyOnTimeout bool
 }
 
 // NewTestRunner creates a new test runner with default configuration
@@ -45,35 +70,41 @@ func NewTestRunner() *TestRunner {
 				Timeout:     30 * time.Second,
 			},
 			{
-				Level:       "L1",
-				Name:        "Unit Tests",
-				Description: "Component testing",
-				Commands:    []string{"make", "test-unit"},
-				Timeout:     2 * time.Minute,
+				Level:        "L1",
+				Name:         "Unit Tests",
+				Description:  "Component testing",
+				Commands:     []string{"make", "test-unit"},
+				Timeout:      2 * time.Minute,
+				Dependencies: []string{"L0"},
 			},
 			{
-				Level:       "L2",
-				Name:        "Integration Tests",
-				Description: "Component interaction testing",
-				Commands:    []string{"make", "test-integration"},
-				Timeout:     5 * time.Minute,
+				Level:        "L2",
+				Name:         "Integration Tests",
+				Description:  "Component interaction testing",
+				Commands:     []string{"make", "test-integration"},
+				Timeout:      5 * time.Minute,
+				Dependencies: []string{"L1"},
 			},
 			{
-				Level:       "L3",
-				Name:        "Guard/Hook Tests",
-				Description: "Guard and hook validation",
-				Commands:    []string{"make", "test-guard"},
-				Timeout:     3 * time.Minute,
+				Level:        "L3",
+				Name:         "Guard/Hook Tests",
+				Description:  "Guard and hook validation",
+				Commands:     []string{"make", "test-guard"},
+				Timeout:      3 * time.Minute,
+				Dependencies: []string{"L1"},
 			},
 			{
-				Level:       "L4",
-				Name:        "System Tests",
-				Description: "End-to-end system validation",
-				Commands:    []string{"make", "test-system"},
-				Timeout:     10 * time.Minute,
+				Level:        "L4",
+				Name:         "System Tests",
+				Description:  "End-to-end system validation",
+				Commands:     []string{"make", "test-system"},
+				Timeout:      10 * time.Minute,
+				Dependencies: []string{"L2", "L3"},
 			},
 		},
 		verbose: false,
+		workers: runtime.NumCPU(),
+		retryDelay: 5 * time.Second,
 	}
 }
 
@@ -84,19 +115,27 @@ func (tr *TestRunner) Run() error {
 	fmt.Println()
 
 	// Generate manifest first
-	fmt.Println("📋 Generating system manifest...")
-	if err := tr.runCommand([]string{"make", "manifest"}, 30*time.Second); err != nil {
-		fmt.Printf("❌ Failed to generate manifest: %v\n", err)
-		return err
+	if tr.skipManifest {
+		fmt.Println("⏭️  Skipping manifest generation")
+	} else {
+		fmt.Println("📋 Generating system manifest...")
+		if err := tr.runCommand([]string{"make", "manifest"}, 30*time.Second, os.Stdout, nil); err != nil {
+			fmt.Printf("❌ Failed to generate manifest: %v\n", err)
+			return err
+		}
+		fmt.Println("✅ Manifest generated successfully")
 	}
-	fmt.Println("✅ Manifest generated successfully")
 	fmt.Println()
 
 	startTime := time.Now()
+
+	if tr.parallel {
+		return tr.runParallel(startTime)
+	}
 	
 	// Run each test level
 	for _, level := range tr.levels {
-		result := tr.runTestLevel(level)
+		result := tr.runTestLevel(level, os.Stdout)
 		tr.results = append(tr.results, result)
 		
 		if !result.Success {
@@ -115,44 +154,193 @@ func (tr *TestRunner) Run() error {
 	return nil
 }
 
-// runTestLevel executes a single test level
-func (tr *TestRunner) runTestLevel(level TestLevel) TestResult {
-	fmt.Printf("🧪 Running %s: %s\n", level.Level, level.Name)
-	fmt.Printf("   %s\n", level.Description)
-	
-	startTime := time.Now()
-	
-	err := tr.runCommand(level.Commands, level.Timeout)
-	duration := time.Since(startTime)
-	
-	result := TestResult{
-		Level:    level.Level,
-		Success:  err == nil,
-		Duration: duration,
-	}
-	
+// runParallel executes the levels in waves sorted by their dependencies: the
+// levels of a wave run concurrently, at most tr.workers at a time, with their
+// output buffered and printed once they finish. A level whose dependency failed
+// is skipped; every level gets a result, even after failures.
+func (tr *TestRunner) runParallel(startTime time.Time) error {
+	waves, err := executionWaves(tr.levels)
 	if err != nil {
-		result.Error = err.Error()
-		fmt.Printf("   ❌ Failed in %v: %s\n", duration.Round(time.Millisecond), err.Error())
-	} else {
-		fmt.Printf("   ✅ Passed in %v\n", duration.Round(time.Millisecond))
+		fmt.Printf("❌ Invalid level dependencies: %v\n", err)
+		return err
 	}
+
+	workers := tr.workers
+	if workers < 1 {
+		workers = 1
+	}
+	fmt.Printf("⚡ Running levels in parallel (%d workers, %d waves)\n", workers, len(waves))
+	fmt.Println()
+
+	completed := make(map[string]TestResult)
+	var mu sync.Mutex // Serializes output and results of concurrent levels
+
+	for _, wave := range waves {
+		var g errgroup.Group
+		g.SetLimit(workers)
+		for _, level := range wave {
+			if failedDep, _ := dependencyStatus(level, completed); failedDep != "" {
+				result := TestResult{Level: level.Level, Skipped: true, Error: fmt.Sprintf("skipped: dependency %s failed", failedDep)}
+				fmt.Printf("⏭️  Skipping %s: dependency %s failed\n", level.Level, failedDep)
+				completed[level.Level] = result
+				tr.results = append(tr.results, result)
+				continue
+			}
+
+			// A failed level is a result, not an error: the other levels of the wave keep running
+			g.Go(func() error {
+				var output syncBuffer
+				result := tr.runTestLevel(level, &output)
+
+				mu.Lock()
+				defer mu.Unlock()
+				writePrefixed(os.Stdout, level.Level, output.String())
+				completed[level.Level] = result
+				tr.results = append(tr.results, result)
+				return nil
+			})
+		}
+		_ = g.Wait()
+	}
+
+	tr.elapsed = time.Since(startTime)
+	tr.sortResults()
+
+	var failed []string
+	for _, result := range tr.results {
+		if !result.Success {
+			failed = append(failed, result.Level)
+		}
+	}
+
+	fmt.Println()
+	if len(failed) > 0 {
+		fmt.Printf("❌ Test suite failed at %s\n", strings.Join(failed, ", "))
+		tr.printSummary(false)
+		return fmt.Errorf("tests failed at %s", strings.Join(failed, ", "))
+	}
+
+	fmt.Printf("🎉 All tests completed successfully in %v\n", tr.elapsed.Round(time.Second))
+	tr.printSummary(true)
+	return nil
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, as the output of a
+// killed command may still be copied while it is read
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// writePrefixed writes output to w with every line prefixed by the level name,
+// so that the output of concurrent levels can be told apart
+func writePrefixed(w io.Writer, level, output string) {
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		fmt.Fprintf(w, "[%s] %s\n", level, line)
+	}
+}
+
+// dependencyStatus returns the first failed dependency of level, or whether
+// all its dependencies have passed
+func dependencyStatus(level TestLevel, completed map[string]TestResult) (failedDep string, ready bool) {
+	for _, dep := range level.Dependencies {
+		result, ok := completed[dep]
+		if !ok {
+			return "", false
+		}
+		if !result.Success {
+			return dep, false
+		}
+	}
+	return "", true
+}
+
+// sortResults orders results by their level's position in the suite
+func (tr *TestRunner) sortResults() {
+	position := make(map[string]int, len(tr.levels))
+	for i, level := range tr.levels {
+		position[level.Level] = i
+	}
+	sort.SliceStable(tr.results, func(i, j int) bool {
+		return position[tr.results[i].Level] < position[tr.results[j].Level]
+	})
+}
+
+// runTestLevel executes a single test level, writing its progress to out. A
+// failed level is retried according to the retry settings; its duration is the
+// sum of all attempts.
+func (tr *TestRunner) runTestLevel(level TestLevel, out io.Writer) TestResult {
+	fmt.Fprintf(out, "🧪 Running %s: %s\n", level.Level, level.Name)
+	fmt.Fprintf(out, "   %s\n", level.Description)
+	
+	maxAttempts := tr.retryCount + 1
+	result := TestResult{Level: level.Level}
 	
-	return result
+	for {
+		result.Attempts++
+		startTime := time.Now()
+		
+		var output syncBuffer
+		err := tr.runCommand(level.Commands, level.Timeout, out, &output)
+		duration := time.Since(startTime)
+		result.Duration += duration
+		result.Success = err == nil
+		result.Output = output.String()
+		result.Error = ""
+		result.TimedOut = false
+		
+		if errors.Is(err, errCommandTimeout) {
+			result.Error = fmt.Sprintf("%s (%s) timed out after %v", level.Level, level.Name, level.Timeout)
+			result.TimedOut = true
+			fmt.Fprintf(out, "   ⏱️  %s timed out after %v (raise it with --timeout-%s or --timeout-scale)\n", level.Level, level.Timeout, strings.ToLower(level.Level))
+		} else if err != nil {
+			result.Error = err.Error()
+			fmt.Fprintf(out, "   ❌ Failed in %v: %s\n", duration.Round(time.Millisecond), err.Error())
+		} else {
+			fmt.Fprintf(out, "   ✅ Passed in %v\n", duration.Round(time.Millisecond))
+		}
+		
+		if result.Success || result.Attempts >= maxAttempts || (tr.retryOnlyOnTimeout && !result.TimedOut) {
+			return result
+		}
+		
+		fmt.Fprintf(out, "   ⟳ Retrying %s (attempt %d/%d) in %v\n", level.Level, result.Attempts+1, maxAttempts, tr.retryDelay)
+		time.Sleep(tr.retryDelay)
+	}
 }
 
-// runCommand executes a command with timeout
-func (tr *TestRunner) runCommand(args []string, timeout time.Duration) error {
+// runCommand executes a command with timeout. In verbose mode the command and
+// its output are written to out; the output is also captured in capture, if set.
+func (tr *TestRunner) runCommand(args []string, timeout time.Duration, out io.Writer, capture io.Writer) error {
 	if len(args) == 0 {
 		return fmt.Errorf("no command specified")
 	}
 	
 	cmd := exec.Command(args[0], args[1:]...)
 	
+	var writers []io.Writer
 	if tr.verbose {
-		fmt.Printf("   → Running: %s\n", strings.Join(args, " "))
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+		fmt.Fprintf(out, "   → Running: %s\n", strings.Join(args, " "))
+		writers = append(writers, out)
+	}
+	if capture != nil {
+		writers = append(writers, capture)
+	}
+	if len(writers) > 0 {
+		cmd.Stdout = io.MultiWriter(writers...)
+		cmd.Stderr = cmd.Stdout
 	}
 	
 	// Start the command
@@ -174,7 +362,7 @@ func (tr *TestRunner) runCommand(args []string, timeout time.Duration) error {
 		if cmd.Process != nil {
 			cmd.Process.Kill()
 		}
-		return fmt.Errorf("command timed out after %v", timeout)
+		return fmt.Errorf("command %w after %v", errCommandTimeout, timeout)
 	}
 }
 
@@ -211,13 +399,36 @@ func (tr *TestRunner) printSummary(allPassed bool) {
 		status := "❌"
 		if result.Success {
 			status = "✅"
+		} else if result.Skipped {
+			status = "⏭️ skipped"
+		} else if result.TimedOut {
+			status = "⏱️ timed out"
 		}
 		
-		fmt.Printf("%-*s %-*s %s (%v)\n", 
+		attempts := ""
+		if result.Attempts > 1 {
+			attempts = fmt.Sprintf(" (%d attempts)", result.Attempts)
+		}
+		
+		fmt.Printf("%-*s %-*s %s (%v)%s\n", 
 			maxLevelWidth, result.Level,
 			maxNameWidth, levelName,
 			status, 
-			result.Duration.Round(time.Millisecond))
+			result.Duration.Round(time.Millisecond),
+			attempts)
+	}
+	
+	if tr.parallel && tr.elapsed > 0 {
+		var sum time.Duration
+		for _, result := range tr.results {
+			sum += result.Duration
+		}
+		fmt.Println()
+		fmt.Printf("⏱️  Wall-clock: %v, sum of levels: %v", tr.elapsed.Round(time.Millisecond), sum.Round(time.Millisecond))
+		if tr.elapsed < sum {
+			fmt.Printf(" (%.1fx faster than sequential)", float64(sum)/float64(tr.elapsed))
+		}
+		fmt.Println()
 	}
 	
 	fmt.Println()
@@ -243,17 +454,225 @@ func (tr *TestRunner) SetVerbose(verbose bool) {
 	tr.verbose = verbose
 }
 
+// SetParallel enables running independent levels concurrently with the given
+// number of workers (0 keeps the default of one per CPU)
+func (tr *TestRunner) SetParallel(parallel bool, workers int) {
+	tr.parallel = parallel
+	if workers > 0 {
+		tr.workers = workers
+	}
+}
+
+// SetRetry retries a failed level up to count times, waiting delay before each
+// retry; with onlyOnTimeout, only levels that timed out are retried
+func (tr *TestRunner) SetRetry(count int, delay time.Duration, onlyOnTimeout bool) {
+	tr.retryCount = count
+	tr.retryDelay = delay
+	tr.retryOnlyOnTimeout = onlyOnTimeout
+}
+
+// SetSkipManifest disables the `make manifest` step run before the levels
+func (tr *TestRunner) SetSkipManifest(skip bool) {
+	tr.skipManifest = skip
+}
+
+// SetLevelTimeout overrides the timeout of a level (e.g. "L4")
+func (tr *TestRunner) SetLevelTimeout(name string, timeout time.Duration) error {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	index := tr.levelIndex(name)
+	if index < 0 {
+		return fmt.Errorf("unknown test level %q (available: %s)", name, tr.levelNames())
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("timeout for %s must be positive, got %v", name, timeout)
+	}
+	tr.levels[index].Timeout = timeout
+	return nil
+}
+
+// ScaleTimeouts multiplies the timeout of every level by factor, e.g. 2 on a
+// slow CI machine
+func (tr *TestRunner) ScaleTimeouts(factor float64) error {
+	if factor <= 0 || math.IsInf(factor, 0) || math.IsNaN(factor) {
+		return fmt.Errorf("timeout scale must be a positive number, got %v", factor)
+	}
+	for i := range tr.levels {
+		tr.levels[i].Timeout = time.Duration(float64(tr.levels[i].Timeout) * factor).Round(time.Second)
+	}
+	return nil
+}
+
+// PrintTimeouts prints the effective timeout of every level
+func (tr *TestRunner) PrintTimeouts(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LEVEL\tNAME\tTIMEOUT")
+	for _, level := range tr.levels {
+		fmt.Fprintf(tw, "%s\t%s\t%v\n", level.Level, level.Name, level.Timeout)
+	}
+	tw.Flush()
+}
+
+// SelectLevels keeps only the named levels (e.g. "L1", "L3"), in suite order
+func (tr *TestRunner) SelectLevels(names []string) error {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if tr.levelIndex(name) < 0 {
+			return fmt.Errorf("unknown test level %q (available: %s)", name, tr.levelNames())
+		}
+		wanted[name] = true
+	}
+	if len(wanted) == 0 {
+		return fmt.Errorf("no test levels selected (available: %s)", tr.levelNames())
+	}
+
+	var selected []TestLevel
+	for _, level := range tr.levels {
+		if wanted[level.Level] {
+			selected = append(selected, level)
+		}
+	}
+	tr.setLevels(selected)
+	return nil
+}
+
+// SelectFrom keeps the named level and every level after it
+func (tr *TestRunner) SelectFrom(name string) error {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	index := tr.levelIndex(name)
+	if index < 0 {
+		return fmt.Errorf("unknown test level %q (available: %s)", name, tr.levelNames())
+	}
+	tr.setLevels(tr.levels[index:])
+	return nil
+}
+
+// setLevels replaces the levels to run, dropping dependencies on levels that
+// were filtered out so that parallel mode does not wait for them
+func (tr *TestRunner) setLevels(levels []TestLevel) {
+	kept := make(map[string]bool, len(levels))
+	for _, level := range levels {
+		kept[level.Level] = true
+	}
+
+	selected := make([]TestLevel, len(levels))
+	for i, level := range levels {
+		var deps []string
+		for _, dep := range level.Dependencies {
+			if kept[dep] {
+				deps = append(deps, dep)
+			}
+		}
+		level.Dependencies = deps
+		selected[i] = level
+	}
+	tr.levels = selected
+}
+
+func (tr *TestRunner) levelIndex(name string) int {
+	for i, level := range tr.levels {
+		if level.Level == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (tr *TestRunner) levelNames() string {
+	names := make([]string, len(tr.levels))
+	for i, level := range tr.levels {
+		names[i] = level.Level
+	}
+	return strings.Join(names, ", ")
+}
+
 // GetResults returns the test results
 func (tr *TestRunner) GetResults() []TestResult {
 	return tr.results
 }
 
+// GetLevels returns the selected test levels
+func (tr *TestRunner) GetLevels() []TestLevel {
+	return tr.levels
+}
+
 // main is the entry point for the test runner
 func main() {
 	runner := NewTestRunner()
+	var levels, from, junitOutput string
+	var githubAnnotations, listTimeouts, retryOnlyOnTimeout bool
+	retryCount, retryDelay := 0, 5*time.Second
+	timeoutScale := 1.0
+	var timeouts [][2]string // level, duration
 	
-	// Check for verbose flag
-	for _, arg := range os.Args[1:] {
+	// Check for flags
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--parallel":
+			runner.SetParallel(true, 0)
+		case strings.HasPrefix(arg, "--workers="):
+			workers, err := strconv.Atoi(strings.TrimPrefix(arg, "--workers="))
+			if err != nil || workers < 1 {
+				fmt.Fprintf(os.Stderr, "Invalid %s: must be a positive number\n", arg)
+				os.Exit(2)
+			}
+			runner.SetParallel(true, workers)
+		case arg == "--levels" || strings.HasPrefix(arg, "--levels="):
+			levels = flagValue(args, &i, "--levels")
+		case arg == "--from" || strings.HasPrefix(arg, "--from="):
+			from = flagValue(args, &i, "--from")
+		case arg == "--skip-manifest":
+			runner.SetSkipManifest(true)
+		case arg == "--junit-output" || strings.HasPrefix(arg, "--junit-output="):
+			junitOutput = flagValue(args, &i, "--junit-output")
+		case arg == "--github-annotations":
+			githubAnnotations = true
+		case arg == "--timeout" || strings.HasPrefix(arg, "--timeout="):
+			spec := flagValue(args, &i, "--timeout")
+			level, duration, ok := strings.Cut(spec, "=")
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Invalid --timeout %q: expected level=duration (e.g. L4=20m)\n", spec)
+				os.Exit(2)
+			}
+			timeouts = append(timeouts, [2]string{level, duration})
+		case arg == "--timeout-scale" || strings.HasPrefix(arg, "--timeout-scale="):
+			value := flagValue(args, &i, "--timeout-scale")
+			scale, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --timeout-scale %q: expected a number (e.g. 1.5)\n", value)
+				os.Exit(2)
+			}
+			timeoutScale = scale
+		case arg == "--list-timeouts":
+			listTimeouts = true
+		case arg == "--retry-count" || strings.HasPrefix(arg, "--retry-count="):
+			value := flagValue(args, &i, "--retry-count")
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 0 {
+				fmt.Fprintf(os.Stderr, "Invalid --retry-count %q: must be zero or a positive number\n", value)
+				os.Exit(2)
+			}
+			retryCount = count
+		case arg == "--retry-delay" || strings.HasPrefix(arg, "--retry-delay="):
+			value := flagValue(args, &i, "--retry-delay")
+			delay, err := time.ParseDuration(value)
+			if err != nil || delay < 0 {
+				fmt.Fprintf(os.Stderr, "Invalid --retry-delay %q: expected a duration (e.g. 10s)\n", value)
+				os.Exit(2)
+			}
+			retryDelay = delay
+		case arg == "--retry-only-on-timeout":
+			retryOnlyOnTimeout = true
+		case strings.HasPrefix(arg, "--timeout-"):
+			name, _, _ := strings.Cut(arg, "=")
+			timeouts = append(timeouts, [2]string{strings.TrimPrefix(name, "--timeout-"), flagValue(args, &i, name)})
+		}
+
 		switch arg {
 		case "-v", "--verbose":
 			runner.SetVerbose(true)
@@ -262,23 +681,103 @@ func main() {
 			os.Exit(0)
 		}
 	}
+
+	// Apply timeouts before level selection so they can name any level
+	for _, override := range timeouts {
+		timeout, err := time.ParseDuration(override[1])
+		if err == nil {
+			err = runner.SetLevelTimeout(override[0], timeout)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid timeout for %s: %v\n", override[0], err)
+			os.Exit(2)
+		}
+	}
+	runner.SetRetry(retryCount, retryDelay, retryOnlyOnTimeout)
+	if err := runner.ScaleTimeouts(timeoutScale); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --timeout-scale: %v\n", err)
+		os.Exit(2)
+	}
+
+	var err error
+	switch {
+	case levels != "" && from != "":
+		err = fmt.Errorf("--levels and --from cannot be combined")
+	case levels != "":
+		err = runner.SelectLevels(strings.Split(levels, ","))
+	case from != "":
+		err = runner.SelectFrom(from)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid level selection: %v\n", err)
+		os.Exit(2)
+	}
+
+	if listTimeouts {
+		runner.PrintTimeouts(os.Stdout)
+		return
+	}
 	
-	if err := runner.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Test runner failed: %v\n", err)
+	runErr := runner.Run()
+
+	// Reports cover failed runs too, so that CI shows what failed
+	if junitOutput != "" {
+		if err := WriteJUnitReport(runner.GetResults(), runner.GetLevels(), junitOutput); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write JUnit report: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("📄 JUnit report written to %s\n", junitOutput)
+	}
+	if githubAnnotations {
+		WriteGitHubAnnotations(os.Stdout, runner.GetResults(), runner.GetLevels())
+	}
+
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "Test runner failed: %v\n", runErr)
 		os.Exit(1)
 	}
 }
 
+// flagValue returns the value of a flag given as "--name=value" or "--name value",
+// advancing i past a separate value argument
+func flagValue(args []string, i *int, name string) string {
+	if value, ok := strings.CutPrefix(args[*i], name+"="); ok {
+		return value
+	}
+	if *i+1 >= len(args) {
+		fmt.Fprintf(os.Stderr, "Missing value for %s\n", name)
+		os.Exit(2)
+	}
+	*i++
+	return args[*i]
+}
+
 // printHelp prints usage information
 func printHelp() {
 	fmt.Println("Claude WM CLI Test Suite Runner")
 	fmt.Println()
 	fmt.Println("Usage:")
-	fmt.Println("  go run ./internal/testrunner/main.go [flags]")
+	fmt.Println("  go run ./internal/testrunner [flags]")
 	fmt.Println("  make test-runner")
 	fmt.Println()
 	fmt.Println("Flags:")
 	fmt.Println("  -v, --verbose    Enable verbose output")
+	fmt.Println("  --parallel       Run independent levels concurrently")
+	fmt.Println("  --workers=N      Worker pool size for --parallel (default: CPU count)")
+	fmt.Println("  --levels L1,L3   Run only the listed levels")
+	fmt.Println("  --from L2        Run the given level and every level after it")
+	fmt.Println("  --skip-manifest  Skip the 'make manifest' step")
+	fmt.Println("  --timeout-l4 20m Override a level's timeout (also --timeout L4=20m, repeatable)")
+	fmt.Println("  --timeout-scale 2")
+	fmt.Println("                   Multiply every timeout, after overrides (e.g. on slow CI)")
+	fmt.Println("  --list-timeouts  Print the effective timeout of each level and exit")
+	fmt.Println("  --retry-count N  Retry a failed level up to N times (default: 0)")
+	fmt.Println("  --retry-delay 5s Wait between retries (default: 5s)")
+	fmt.Println("  --retry-only-on-timeout")
+	fmt.Println("                   Only retry levels that timed out")
+	fmt.Println("  --junit-output F Write a JUnit XML report to F for CI test result tabs")
+	fmt.Println("  --github-annotations")
+	fmt.Println("                   Emit ::error/::warning annotations for GitHub Actions")
 	fmt.Println("  -h, --help       Show this help message")
 	fmt.Println()
 	fmt.Println("Test Levels:")
@@ -289,5 +788,8 @@ func printHelp() {
 	fmt.Println("  L4: System Tests      - End-to-end testing (< 10m)")
 	fmt.Println()
 	fmt.Println("The runner executes tests sequentially and stops on first failure.")
+	fmt.Println("With --parallel, levels run in waves sorted by their dependencies")
+	fmt.Println("(L0; then L1 and L3; then L2; then L4), the levels of a wave concurrently")
+	fmt.Println("with their output prefixed by level, and all results are reported.")
 	fmt.Println("Use 'make test-all' for direct Make-based execution.")
 }
\ No newline at end of file
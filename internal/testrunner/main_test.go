@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTestRunner_ScaleTimeouts(t *testing.T) {
+	runner := NewTestRunner()
+	require.NoError(t, runner.SetLevelTimeout("l4", 20*time.Minute))
+	require.NoError(t, runner.ScaleTimeouts(1.5))
+
+	assert.Equal(t, 45*time.Second, runner.levels[0].Timeout)
+	assert.Equal(t, 30*time.Minute, runner.levels[4].Timeout)
+
+	assert.Error(t, runner.ScaleTimeouts(0))
+	assert.Error(t, runner.ScaleTimeouts(-2))
+
+	var out bytes.Buffer
+	require.NoError(t, runner.SelectLevels([]string{"L4"}))
+	runner.PrintTimeouts(&out)
+	assert.Equal(t, "LEVEL  NAME          TIMEOUT\nL4     System Tests  30m0s\n", out.String())
+}
+
+func TestTestRunner_RetriesFailedLevel(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "attempted")
+	level := TestLevel{
+		Level:    "L2",
+		Name:     "Flaky Tests",
+		Commands: []string{"sh", "-c", "test -f " + marker + " || { touch " + marker + "; exit 1; }"},
+		Timeout:  10 * time.Second,
+	}
+
+	runner := NewTestRunner()
+	runner.SetRetry(2, 0, false)
+
+	var out bytes.Buffer
+	result := runner.runTestLevel(level, &out)
+	assert.True(t, result.Success)
+	assert.Equal(t, 2, result.Attempts)
+	assert.Contains(t, out.String(), "⟳ Retrying L2 (attempt 2/3)")
+
+	// Only timeouts are retried with --retry-only-on-timeout
+	require.NoError(t, os.Remove(marker))
+	runner.SetRetry(2, 0, true)
+	result = runner.runTestLevel(level, &out)
+	assert.False(t, result.Success)
+	assert.Equal(t, 1, result.Attempts)
+}
+
+// levelDeps returns the dependencies of each level, keyed by level
+func levelDeps(levels []TestLevel) map[string][]string {
+	deps := make(map[string][]string, len(levels))
+	for _, level := range levels {
+		deps[level.Level] = level.Dependencies
+	}
+	return deps
+}
+
+func TestTestRunner_SelectLevels(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  map[string][]string
+		err   string
+	}{
+		{
+			name:  "single level drops its dependencies",
+			names: []string{"L2"},
+			want:  map[string][]string{"L2": nil},
+		},
+		{
+			name:  "suite order and case-insensitive names",
+			names: []string{"l4", " L2 ", "L1"},
+			want:  map[string][]string{"L1": nil, "L2": {"L1"}, "L4": {"L2"}},
+		},
+		{
+			name:  "kept dependencies",
+			names: []string{"L1", "L3", "L4"},
+			want:  map[string][]string{"L1": nil, "L3": {"L1"}, "L4": {"L3"}},
+		},
+		{
+			name:  "empty names are ignored",
+			names: []string{"", "L0"},
+			want:  map[string][]string{"L0": nil},
+		},
+		{name: "unknown level", names: []string{"L1", "L9"}, err: `unknown test level "L9" (available: L0, L1, L2, L3, L4)`},
+		{name: "no level", names: []string{" "}, err: "no test levels selected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewTestRunner()
+			err := runner.SelectLevels(tt.names)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				assert.Len(t, runner.GetLevels(), 5, "levels are unchanged on error")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, levelDeps(runner.GetLevels()))
+			for i := 1; i < len(runner.GetLevels()); i++ {
+				assert.Less(t, runner.GetLevels()[i-1].Level, runner.GetLevels()[i].Level, "levels keep the suite order")
+			}
+		})
+	}
+}
+
+func TestTestRunner_SelectFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		want map[string][]string
+		err  string
+	}{
+		{name: "first level keeps everything", from: "L0", want: map[string][]string{"L0": nil, "L1": {"L0"}, "L2": {"L1"}, "L3": {"L1"}, "L4": {"L2", "L3"}}},
+		{name: "prunes earlier levels", from: "l2", want: map[string][]string{"L2": nil, "L3": nil, "L4": {"L2", "L3"}}},
+		{name: "last level", from: "L4", want: map[string][]string{"L4": nil}},
+		{name: "unknown level", from: "L7", err: `unknown test level "L7"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewTestRunner()
+			err := runner.SelectFrom(tt.from)
+			if tt.err != "" {
+				assert.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, levelDeps(runner.GetLevels()))
+		})
+	}
+}
+
+func TestTestRunner_SetLevelsDoesNotModifyTheOriginalLevels(t *testing.T) {
+	runner := NewTestRunner()
+	levels := runner.GetLevels()[2:]
+
+	runner.setLevels(levels)
+	assert.Equal(t, []string{"L1"}, levels[0].Dependencies)
+	assert.Nil(t, runner.GetLevels()[0].Dependencies)
+}
+
+func TestTestRunner_SetLevelTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		timeout time.Duration
+		index   int
+		err     string
+	}{
+		{name: "override", level: "L4", timeout: 20 * time.Minute, index: 4},
+		{name: "case-insensitive name", level: " l0 ", timeout: time.Minute, index: 0},
+		{name: "unknown level", level: "L5", timeout: time.Minute, err: `unknown test level "L5" (available: L0, L1, L2, L3, L4)`},
+		{name: "zero timeout", level: "L1", timeout: 0, err: "timeout for L1 must be positive, got 0s"},
+		{name: "negative timeout", level: "L2", timeout: -time.Second, err: "timeout for L2 must be positive, got -1s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := NewTestRunner()
+			defaults := levelTimeouts(runner.GetLevels())
+
+			err := runner.SetLevelTimeout(tt.level, tt.timeout)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				assert.Equal(t, defaults, levelTimeouts(runner.GetLevels()), "timeouts are unchanged on error")
+				return
+			}
+			require.NoError(t, err)
+
+			want := defaults
+			want[tt.index] = tt.timeout
+			assert.Equal(t, want, levelTimeouts(runner.GetLevels()), "only the level's timeout changes")
+		})
+	}
+}
+
+// levelTimeouts returns the timeout of each level
+func levelTimeouts(levels []TestLevel) []time.Duration {
+	timeouts := make([]time.Duration, len(levels))
+	for i, level := range levels {
+		timeouts[i] = level.Timeout
+	}
+	return timeouts
+}
+
+func TestTestRunner_TimedOutLevel(t *testing.T) {
+	level := TestLevel{Level: "L2", Name: "Integration Tests", Commands: []string{"sleep", "5"}, Timeout: 100 * time.Millisecond}
+	runner := NewTestRunner()
+	runner.setLevels([]TestLevel{level})
+
+	var out bytes.Buffer
+	result := runner.runTestLevel(level, &out)
+	assert.False(t, result.Success)
+	assert.True(t, result.TimedOut)
+	assert.Equal(t, "L2 (Integration Tests) timed out after 100ms", result.Error)
+	assert.Contains(t, out.String(), "raise it with --timeout-l2 or --timeout-scale")
+
+	runner.results = []TestResult{result}
+	summary := captureStdout(t, func() { runner.printSummary(false) })
+	assert.Contains(t, summary, "L2 Integration Tests ⏱️ timed out")
+	assert.Contains(t, summary, "Some tests failed")
+}
+
+// captureStdout returns what fn writes to os.Stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	require.NoError(t, w.Close())
+	var out bytes.Buffer
+	_, err = out.ReadFrom(r)
+	require.NoError(t, err)
+	return out.String()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// executionWaves sorts levels topologically into waves: every level of a wave
+// only depends on levels of earlier waves, so the levels of a wave can run
+// concurrently. Levels keep their suite order within a wave.
+func executionWaves(levels []TestLevel) ([][]TestLevel, error) {
+	position := make(map[string]int, len(levels))
+	for i, level := range levels {
+		if _, exists := position[level.Level]; exists {
+			return nil, fmt.Errorf("duplicate test level %s", level.Level)
+		}
+		position[level.Level] = i
+	}
+
+	// Number of unmet dependencies per level, and the levels waiting on each one
+	pending := make(map[string]int, len(levels))
+	dependents := make(map[string][]string, len(levels))
+	for _, level := range levels {
+		for _, dep := range level.Dependencies {
+			if _, exists := position[dep]; !exists {
+				return nil, fmt.Errorf("level %s depends on unknown level %s", level.Level, dep)
+			}
+			if dep == level.Level {
+				return nil, fmt.Errorf("level %s cannot depend on itself", level.Level)
+			}
+			pending[level.Level]++
+			dependents[dep] = append(dependents[dep], level.Level)
+		}
+	}
+
+	var ready []string
+	for _, level := range levels {
+		if pending[level.Level] == 0 {
+			ready = append(ready, level.Level)
+		}
+	}
+
+	var waves [][]TestLevel
+	scheduled := 0
+	for len(ready) > 0 {
+		wave := make([]TestLevel, len(ready))
+		var next []string
+		for i, name := range ready {
+			wave[i] = levels[position[name]]
+			for _, dependent := range dependents[name] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		sort.Slice(next, func(i, j int) bool { return position[next[i]] < position[next[j]] })
+
+		waves = append(waves, wave)
+		scheduled += len(wave)
+		ready = next
+	}
+
+	if scheduled < len(levels) {
+		var cyclic []string
+		for _, level := range levels {
+			if pending[level.Level] > 0 {
+				cyclic = append(cyclic, level.Level)
+			}
+		}
+		return nil, fmt.Errorf("dependency cycle between levels %s", strings.Join(cyclic, ", "))
+	}
+	return waves, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func waveNames(waves [][]TestLevel) [][]string {
+	names := make([][]string, len(waves))
+	for i, wave := range waves {
+		for _, level := range wave {
+			names[i] = append(names[i], level.Level)
+		}
+	}
+	return names
+}
+
+func TestExecutionWaves_DefaultLevels(t *testing.T) {
+	waves, err := executionWaves(NewTestRunner().levels)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"L0"}, {"L1"}, {"L2", "L3"}, {"L4"}}, waveNames(waves))
+}
+
+func TestExecutionWaves_SelectedLevels(t *testing.T) {
+	runner := NewTestRunner()
+	require.NoError(t, runner.SelectLevels([]string{"L2", "L3", "L4"}))
+
+	waves, err := executionWaves(runner.levels)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"L2", "L3"}, {"L4"}}, waveNames(waves))
+}
+
+func TestExecutionWaves_InvalidDependencies(t *testing.T) {
+	_, err := executionWaves([]TestLevel{{Level: "L0", Dependencies: []string{"L9"}}})
+	assert.ErrorContains(t, err, "unknown level L9")
+
+	_, err = executionWaves([]TestLevel{{Level: "L0", Dependencies: []string{"L0"}}})
+	assert.ErrorContains(t, err, "cannot depend on itself")
+
+	_, err = executionWaves([]TestLevel{
+		{Level: "L0"},
+		{Level: "L1", Dependencies: []string{"L2"}},
+		{Level: "L2", Dependencies: []string{"L1"}},
+	})
+	assert.ErrorContains(t, err, "dependency cycle between levels L1, L2")
+}
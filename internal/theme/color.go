@@ -0,0 +1,209 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/term"
+)
+
+// ColorMode chooses when output is colored
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"   // Colored when writing to a terminal, unless NO_COLOR is set
+	ColorAlways ColorMode = "always" // Colored even when piped
+	ColorNever  ColorMode = "never"
+)
+
+// ParseColorMode parses the value of --color. An empty value is auto.
+func ParseColorMode(value string) (ColorMode, error) {
+	switch mode := ColorMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return ColorAuto, nil
+	case ColorAuto, ColorAlways, ColorNever:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid color mode %q: use auto, always or never", value)
+	}
+}
+
+// ColorEnabled reports whether output to out is colored in mode. In auto
+// mode, output is colored when out is a terminal other than TERM=dumb and
+// NO_COLOR is not set.
+func ColorEnabled(mode ColorMode, out *os.File) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" || out == nil {
+		return false
+	}
+	return term.IsTerminal(int(out.Fd()))
+}
+
+// Role is the meaning of a piece of colored text. The palette decides its
+// color.
+type Role int
+
+const (
+	RoleHeading Role = iota
+	RoleSuccess
+	RoleWarning
+	RoleError
+	RoleInfo
+	RoleMuted
+	RoleAccent
+)
+
+// Palette maps roles to ANSI SGR parameters, such as "1;36" for bold cyan
+type Palette struct {
+	Name   string
+	Styles map[Role]string
+}
+
+// Palette names
+const (
+	DefaultPalette      = "default"
+	HighContrastPalette = "high-contrast"
+)
+
+// palettes are the available themes. The high-contrast theme only uses bold
+// bright colors and avoids dim text.
+var palettes = map[string]Palette{
+	DefaultPalette: {Name: DefaultPalette, Styles: map[Role]string{
+		RoleHeading: "1;36",
+		RoleSuccess: "32",
+		RoleWarning: "33",
+		RoleError:   "31",
+		RoleInfo:    "34",
+		RoleMuted:   "2",
+		RoleAccent:  "36",
+	}},
+	HighContrastPalette: {Name: HighContrastPalette, Styles: map[Role]string{
+		RoleHeading: "1;97",
+		RoleSuccess: "1;92",
+		RoleWarning: "1;93",
+		RoleError:   "1;91",
+		RoleInfo:    "1;96",
+		RoleMuted:   "37",
+		RoleAccent:  "1;95",
+	}},
+}
+
+var (
+	colorMode atomic.Bool
+
+	paletteMu sync.RWMutex
+	palette   = palettes[DefaultPalette]
+)
+
+// SetColor switches colored output on or off
+func SetColor(enabled bool) {
+	colorMode.Store(enabled)
+}
+
+// Color reports whether output is colored
+func Color() bool {
+	return colorMode.Load()
+}
+
+// Palettes returns the names of the available themes
+func Palettes() []string {
+	names := make([]string, 0, len(palettes))
+	for name := range palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetPalette selects the theme used for colored output. An empty name selects
+// the default theme.
+func SetPalette(name string) error {
+	if name == "" {
+		name = DefaultPalette
+	}
+	selected, ok := palettes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q: use %s", name, strings.Join(Palettes(), " or "))
+	}
+
+	paletteMu.Lock()
+	defer paletteMu.Unlock()
+	palette = selected
+	return nil
+}
+
+// CurrentPalette returns the theme used for colored output
+func CurrentPalette() Palette {
+	paletteMu.RLock()
+	defer paletteMu.RUnlock()
+	return palette
+}
+
+// Style returns text colored for role, or unchanged when output is not
+// colored
+func Style(role Role, text string) string {
+	if !Color() || text == "" {
+		return text
+	}
+	sgr, ok := CurrentPalette().Styles[role]
+	if !ok {
+		return text
+	}
+	return "\033[" + sgr + "m" + text + "\033[0m"
+}
+
+// Heading returns text styled as a title
+func Heading(text string) string { return Style(RoleHeading, text) }
+
+// Success returns text styled as a success
+func Success(text string) string { return Style(RoleSuccess, text) }
+
+// Warning returns text styled as a warning
+func Warning(text string) string { return Style(RoleWarning, text) }
+
+// Error returns text styled as an error
+func Error(text string) string { return Style(RoleError, text) }
+
+// Info returns text styled as information
+func Info(text string) string { return Style(RoleInfo, text) }
+
+// Muted returns text styled as secondary information
+func Muted(text string) string { return Style(RoleMuted, text) }
+
+// Accent returns text styled as a highlight, such as a menu number
+func Accent(text string) string { return Style(RoleAccent, text) }
+
+// StatusRole returns the role of a status or priority value such as
+// "completed", "in_progress" or "critical"
+func StatusRole(status string) (Role, bool) {
+	switch strings.ToLower(strings.ReplaceAll(status, " ", "_")) {
+	case "completed", "done", "resolved", "closed", "ok", "low":
+		return RoleSuccess, true
+	case "in_progress", "active", "review", "medium":
+		return RoleInfo, true
+	case "blocked", "failed", "error", "critical", "urgent":
+		return RoleError, true
+	case "on_hold", "warning", "high":
+		return RoleWarning, true
+	case "cancelled", "archived":
+		return RoleMuted, true
+	}
+	return 0, false
+}
+
+// Status returns a status or priority value colored by its meaning
+func Status(status string) string {
+	if role, ok := StatusRole(status); ok {
+		return Style(role, status)
+	}
+	return status
+}
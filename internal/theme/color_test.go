@@ -0,0 +1,103 @@
+package theme
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withColor(t *testing.T, enabled bool, paletteName string) {
+	t.Helper()
+	previous, previousPalette := Color(), CurrentPalette()
+	SetColor(enabled)
+	require.NoError(t, SetPalette(paletteName))
+	t.Cleanup(func() {
+		SetColor(previous)
+		SetPalette(previousPalette.Name)
+	})
+}
+
+func TestParseColorMode(t *testing.T) {
+	for input, expected := range map[string]ColorMode{"": ColorAuto, "auto": ColorAuto, "Always": ColorAlways, " never ": ColorNever} {
+		mode, err := ParseColorMode(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, mode, input)
+	}
+
+	_, err := ParseColorMode("sometimes")
+	assert.ErrorContains(t, err, "use auto, always or never")
+}
+
+func TestColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "output"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	assert.False(t, ColorEnabled(ColorAuto, file), "a file is not a terminal")
+	assert.True(t, ColorEnabled(ColorAlways, file))
+	assert.False(t, ColorEnabled(ColorNever, file))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.False(t, ColorEnabled(ColorAuto, os.Stdout))
+	assert.True(t, ColorEnabled(ColorAlways, file), "--color always overrides NO_COLOR")
+}
+
+func TestStyle(t *testing.T) {
+	withColor(t, false, DefaultPalette)
+	assert.Equal(t, "done", Success("done"))
+
+	withColor(t, true, DefaultPalette)
+	assert.Equal(t, "\033[32mdone\033[0m", Success("done"))
+	assert.Equal(t, "\033[31mblocked\033[0m", Status("blocked"))
+	assert.Equal(t, "todo", Status("todo"), "statuses without meaning are not colored")
+	assert.Equal(t, "", Heading(""))
+
+	withColor(t, true, HighContrastPalette)
+	assert.Equal(t, "\033[1;92mdone\033[0m", Success("done"))
+	assert.NotContains(t, Muted("note"), "\033[2m", "no dim text in high contrast")
+}
+
+func TestSetPalette(t *testing.T) {
+	withColor(t, false, DefaultPalette)
+
+	assert.Equal(t, []string{"default", "high-contrast"}, Palettes())
+	require.NoError(t, SetPalette(""))
+	assert.Equal(t, DefaultPalette, CurrentPalette().Name)
+
+	err := SetPalette("neon")
+	assert.ErrorContains(t, err, `unknown theme "neon"`)
+	assert.Equal(t, DefaultPalette, CurrentPalette().Name)
+}
+
+func TestTable(t *testing.T) {
+	rows := func() string {
+		var out bytes.Buffer
+		table := NewTable(&out)
+		table.Row(Heading, "ID\tSTATUS\tTITLE\n")
+		table.Row(StatusStyle("completed"), "T-1\t%s\t%s\n", "completed", "First")
+		table.Row(StatusStyle("todo"), "T-10\t%s\t%s\n", "todo", "Second")
+		require.NoError(t, table.Flush())
+		return out.String()
+	}
+
+	withColor(t, false, DefaultPalette)
+	plain := rows()
+	assert.Equal(t, "ID    STATUS     TITLE\nT-1   completed  First\nT-10  todo       Second\n", plain)
+
+	withColor(t, true, DefaultPalette)
+	colored := rows()
+	assert.Contains(t, colored, "\033[32mT-1   completed  First\033[0m\n")
+	assert.Contains(t, colored, "\nT-10  todo       Second\n")
+
+	// Colors do not change the layout
+	stripped := strings.NewReplacer("\033[32m", "", "\033[1;36m", "", "\033[0m", "").Replace(colored)
+	assert.Equal(t, plain, stripped)
+}
@@ -0,0 +1,67 @@
+package theme
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Table lays out tab-separated rows like a text/tabwriter and colors each
+// row once laid out, so that escape codes do not break the alignment
+type Table struct {
+	out    io.Writer
+	buf    bytes.Buffer
+	tw     *tabwriter.Writer
+	styles []func(string) string // Style of each row, nil for none
+}
+
+// NewTable returns a table written to out on Flush, with the padding used by
+// the CLI listings
+func NewTable(out io.Writer) *Table {
+	t := &Table{out: out}
+	t.tw = tabwriter.NewWriter(&t.buf, 0, 0, 2, ' ', 0)
+	return t
+}
+
+// Row adds the tab-separated cells of format, ending with a newline, styled
+// by style (nil for none)
+func (t *Table) Row(style func(string) string, format string, args ...interface{}) {
+	row := fmt.Sprintf(format, args...)
+	for i := strings.Count(row, "\n"); i > 0; i-- {
+		t.styles = append(t.styles, style)
+	}
+	fmt.Fprint(t.tw, row)
+}
+
+// Flush lays out the rows and writes them
+func (t *Table) Flush() error {
+	if err := t.tw.Flush(); err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(t.buf.String(), "\n")
+	for i, line := range lines {
+		if i < len(t.styles) && t.styles[i] != nil {
+			text := strings.TrimSuffix(line, "\n")
+			line = t.styles[i](strings.TrimRight(text, " ")) + line[len(text):]
+		}
+		if _, err := io.WriteString(t.out, line); err != nil {
+			return err
+		}
+	}
+	t.buf.Reset()
+	t.styles = nil
+	return nil
+}
+
+// StatusStyle returns the style of rows whose status or priority is status,
+// or nil when it has no color
+func StatusStyle(status string) func(string) string {
+	role, ok := StatusRole(status)
+	if !ok {
+		return nil
+	}
+	return func(text string) string { return Style(role, text) }
+}
@@ -0,0 +1,140 @@
+// Package theme chooses between emoji and plain ASCII markers for CLI output,
+// so that terminals and CI logs without emoji support stay readable.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// ASCIIEnvVar forces ASCII-only output when set to a true value
+const ASCIIEnvVar = "CLAUDE_WM_ASCII"
+
+var asciiMode atomic.Bool
+
+// SetASCII switches ASCII-only output on or off
+func SetASCII(enabled bool) {
+	asciiMode.Store(enabled)
+}
+
+// ASCII reports whether output is ASCII-only
+func ASCII() bool {
+	return asciiMode.Load()
+}
+
+// ASCIIFromEnv reports whether the environment asks for ASCII-only output:
+// NO_COLOR set to any non-empty value (see no-color.org), or CLAUDE_WM_ASCII
+// set to a true value
+func ASCIIFromEnv() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return true
+	}
+	enabled, err := strconv.ParseBool(os.Getenv(ASCIIEnvVar))
+	return err == nil && enabled
+}
+
+// Icon returns emoji, or its ASCII replacement in ASCII-only mode
+func Icon(emoji, ascii string) string {
+	if ASCII() {
+		return ascii
+	}
+	return emoji
+}
+
+// replacements are the ASCII markers for the emoji and symbols the CLI
+// prints most. Emoji not listed here are dropped by Text.
+var replacements = strings.NewReplacer(
+	"✅", "[ok]",
+	"❌", "[x]",
+	"⚠️", "[!]",
+	"⚠", "[!]",
+	"💡", "[hint]",
+	"🔍", "[?]",
+	"❓", "[?]",
+	"🚀", "->",
+	"➡️", "->",
+	"→", "->",
+	"←", "<-",
+	"↑", "^",
+	"↓", "v",
+	"❯", ">",
+	"•", "*",
+	"…", "...",
+	"═", "=",
+	"─", "-",
+	"│", "|",
+	"█", "#",
+	"░", ".",
+	"✓", "[ok]",
+	"✗", "[x]",
+	"🔵", "[open]",
+	"🟡", "[medium]",
+	"🟠", "[high]",
+	"🔴", "[critical]",
+	"🟢", "[low]",
+	"⚪", "[-]",
+	"🚧", "[wip]",
+	"📋", "[todo]",
+	"⏳", "[todo]",
+	"🚫", "[blocked]",
+	"⏸️", "[hold]",
+	"📦", "[backlog]",
+)
+
+// Text returns text unchanged, or in ASCII-only mode with the known emoji
+// replaced by ASCII markers and the other emoji removed
+func Text(text string) string {
+	if !ASCII() {
+		return text
+	}
+
+	text = replacements.Replace(text)
+
+	// Drop the other emoji with the space following them, as in "📂 Path"
+	var b strings.Builder
+	b.Grow(len(text))
+	dropped := false
+	for _, r := range text {
+		switch {
+		case isEmoji(r):
+			dropped = true
+		case r == ' ' && dropped:
+			dropped = false
+		default:
+			dropped = false
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Printf formats like fmt.Printf and prints the result through Text
+func Printf(format string, args ...interface{}) {
+	fmt.Print(Text(fmt.Sprintf(format, args...)))
+}
+
+// Println prints the arguments like fmt.Println, through Text
+func Println(args ...interface{}) {
+	fmt.Print(Text(fmt.Sprintln(args...)))
+}
+
+// isEmoji reports whether r is an emoji, a pictographic symbol or one of the
+// invisible characters joining them
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Emoji and pictographs
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF: // Arrows and shapes such as ⭐
+		return true
+	case r == 0x200D || (r >= 0xFE00 && r <= 0xFE0F): // Joiners and variation selectors
+		return true
+	case r == 0x231A || r == 0x231B || r == 0x23F0 || r == 0x23F3 || (r >= 0x23E9 && r <= 0x23FA):
+		return true
+	}
+	return false
+}
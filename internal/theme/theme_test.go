@@ -0,0 +1,71 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func withASCII(t *testing.T, enabled bool) {
+	t.Helper()
+	previous := ASCII()
+	SetASCII(enabled)
+	t.Cleanup(func() { SetASCII(previous) })
+}
+
+func TestIcon(t *testing.T) {
+	withASCII(t, false)
+	assert.Equal(t, "🔵", Icon("🔵", "[open]"))
+
+	withASCII(t, true)
+	assert.Equal(t, "[open]", Icon("🔵", "[open]"))
+}
+
+func TestText(t *testing.T) {
+	withASCII(t, false)
+	assert.Equal(t, "✅ Done → next", Text("✅ Done → next"))
+
+	withASCII(t, true)
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"✅ Task completed", "[ok] Task completed"},
+		{"⚠️  Warning: careful", "[!]  Warning: careful"},
+		{"❌ Error: failed", "[x] Error: failed"},
+		{"📂 Project Path: /tmp", "Project Path: /tmp"},
+		{"Plan → Test → Review", "Plan -> Test -> Review"},
+		{"═══ 🧭 Navigation ═══", "=== Navigation ==="},
+		{"[████░░] 60%", "[####..] 60%"},
+		{"plain text", "plain text"},
+		{"café", "café"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Text(tt.input))
+		})
+	}
+}
+
+func TestASCIIFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		noColor  string
+		ascii    string
+		expected bool
+	}{
+		{"unset", "", "", false},
+		{"NO_COLOR", "1", "", true},
+		{"CLAUDE_WM_ASCII true", "", "true", true},
+		{"CLAUDE_WM_ASCII 1", "", "1", true},
+		{"CLAUDE_WM_ASCII false", "", "false", false},
+		{"CLAUDE_WM_ASCII invalid", "", "maybe", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv(ASCIIEnvVar, tt.ascii)
+			assert.Equal(t, tt.expected, ASCIIFromEnv())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ticket
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"claude-wm-cli/internal/backup"
 	"claude-wm-cli/internal/epic"
 )
 
@@ -17,20 +19,31 @@ const (
 	StoriesVersion  = "1.0.0"
 )
 
+// ErrTicketNotFound is returned for an unknown ticket ID
+var ErrTicketNotFound = errors.New("ticket not found")
+
 // Manager handles ticket operations and persistence
 type Manager struct {
-	rootPath    string
-	epicManager *epic.Manager
+	rootPath     string
+	epicManager  *epic.Manager
+	stateMachine *StatusMachine
 }
 
 // NewManager creates a new ticket manager
 func NewManager(rootPath string) *Manager {
 	return &Manager{
-		rootPath:    rootPath,
-		epicManager: epic.NewManager(rootPath),
+		rootPath:     rootPath,
+		epicManager:  epic.NewManager(rootPath),
+		stateMachine: NewStatusMachine(),
 	}
 }
 
+// StateMachine returns the state machine validating ticket status changes,
+// to register hooks on it
+func (m *Manager) StateMachine() *StatusMachine {
+	return m.stateMachine
+}
+
 // CreateTicket creates a new ticket
 func (m *Manager) CreateTicket(options TicketCreateOptions) (*Ticket, error) {
 	// Validate inputs
@@ -116,7 +129,7 @@ func (m *Manager) UpdateTicket(ticketID string, options TicketUpdateOptions) (*T
 
 	ticket, exists := collection.Tickets[ticketID]
 	if !exists {
-		return nil, fmt.Errorf("ticket not found: %s", ticketID)
+		return nil, fmt.Errorf("%w: %s", ErrTicketNotFound, ticketID)
 	}
 
 	// Apply updates
@@ -145,25 +158,13 @@ func (m *Manager) UpdateTicket(ticketID string, options TicketUpdateOptions) (*T
 			return nil, fmt.Errorf("invalid ticket status: %s", *options.Status)
 		}
 
-		// Validate status transition
-		if err := m.validateStatusTransition(ticket, *options.Status); err != nil {
+		// Validate the transition; the state hooks set the timestamps
+		oldStatus := ticket.Status
+		if err := m.stateMachine.Transition(oldStatus, *options.Status, ticket); err != nil {
 			return nil, err
 		}
-
-		// Handle status change timestamps
-		oldStatus := ticket.Status
 		ticket.Status = *options.Status
 
-		if *options.Status == TicketStatusInProgress && ticket.StartedAt == nil {
-			ticket.StartedAt = &now
-		}
-		if *options.Status == TicketStatusResolved && ticket.ResolvedAt == nil {
-			ticket.ResolvedAt = &now
-		}
-		if *options.Status == TicketStatusClosed && ticket.ClosedAt == nil {
-			ticket.ClosedAt = &now
-		}
-
 		// Log activity
 		m.logTicketActivity(collection, ticketID, "status_changed", oldStatus, *options.Status, now)
 	}
@@ -238,7 +239,7 @@ func (m *Manager) GetTicket(ticketID string) (*Ticket, error) {
 
 	ticket, exists := collection.Tickets[ticketID]
 	if !exists {
-		return nil, fmt.Errorf("ticket not found: %s", ticketID)
+		return nil, fmt.Errorf("%w: %s", ErrTicketNotFound, ticketID)
 	}
 
 	return ticket, nil
@@ -251,9 +252,19 @@ func (m *Manager) ListTickets(options TicketListOptions) ([]*Ticket, error) {
 		return nil, fmt.Errorf("failed to load ticket collection: %w", err)
 	}
 
+	var sprint *Sprint
+	if options.SprintID != "" {
+		if sprint, err = NewSprintManager(m.rootPath).GetSprint(options.SprintID); err != nil {
+			return nil, err
+		}
+	}
+
 	var tickets []*Ticket
 	for _, ticket := range collection.Tickets {
 		// Apply filters
+		if sprint != nil && !sprint.HasTicket(ticket.ID) {
+			continue
+		}
 		if options.Status != "" && ticket.Status != options.Status {
 			continue
 		}
@@ -316,7 +327,7 @@ func (m *Manager) SetCurrentTicket(ticketID string) (*Ticket, error) {
 	if ticketID != "" {
 		ticket, exists := collection.Tickets[ticketID]
 		if !exists {
-			return nil, fmt.Errorf("ticket not found: %s", ticketID)
+			return nil, fmt.Errorf("%w: %s", ErrTicketNotFound, ticketID)
 		}
 
 		// Auto-start ticket if it's open
@@ -420,6 +431,25 @@ func (m *Manager) GetTicketStats() (*TicketStats, error) {
 
 	stats.OldestOpenTicket = oldestOpen
 
+	// Age the open tickets against the SLA of their priority
+	tickets := make([]*Ticket, 0, len(collection.Tickets))
+	for _, ticket := range collection.Tickets {
+		tickets = append(tickets, ticket)
+	}
+	stats.AgedTickets = AgeTickets(tickets, LoadSLAPolicy(m.rootPath), time.Now())
+	for _, aged := range stats.AgedTickets {
+		switch aged.SLAStatus {
+		case SLABreached:
+			stats.SLABreaches++
+		case SLAApproaching:
+			stats.NearSLABreaches++
+		}
+	}
+
+	if stats.SprintVelocity, err = NewSprintManager(m.rootPath).GetVelocity(); err != nil {
+		return nil, fmt.Errorf("failed to get sprint velocity: %w", err)
+	}
+
 	return stats, nil
 }
 
@@ -432,7 +462,7 @@ func (m *Manager) DeleteTicket(ticketID string) error {
 
 	_, exists := collection.Tickets[ticketID]
 	if !exists {
-		return fmt.Errorf("ticket not found: %s", ticketID)
+		return fmt.Errorf("%w: %s", ErrTicketNotFound, ticketID)
 	}
 
 	// Clear current ticket if it's the one being deleted
@@ -504,15 +534,9 @@ func (m *Manager) saveTicketCollection(collection *TicketCollection) error {
 		return fmt.Errorf("failed to marshal ticket collection: %w", err)
 	}
 
-	// Write file atomically using temp file + rename
-	tempPath := ticketsPath + ".tmp"
-	if err := os.WriteFile(tempPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp tickets file: %w", err)
-	}
-
-	if err := os.Rename(tempPath, ticketsPath); err != nil {
-		os.Remove(tempPath) // cleanup
-		return fmt.Errorf("failed to replace tickets file: %w", err)
+	// Back up the current file, then write atomically
+	if err := backup.WriteWithBackup(ticketsPath, data, backup.BackupTypeAutomatic); err != nil {
+		return fmt.Errorf("failed to write tickets file: %w", err)
 	}
 
 	return nil
@@ -551,32 +575,6 @@ func (m *Manager) generateTicketID(title string, collection *TicketCollection) s
 	return ticketID
 }
 
-func (m *Manager) validateStatusTransition(ticket *Ticket, newStatus TicketStatus) error {
-	currentStatus := ticket.Status
-
-	// Define valid transitions
-	validTransitions := map[TicketStatus][]TicketStatus{
-		TicketStatusOpen:       {TicketStatusInProgress, TicketStatusClosed},
-		TicketStatusInProgress: {TicketStatusResolved, TicketStatusOpen, TicketStatusClosed},
-		TicketStatusResolved:   {TicketStatusClosed, TicketStatusInProgress}, // Can reopen
-		TicketStatusClosed:     {TicketStatusOpen},                           // Can reopen
-	}
-
-	allowedTransitions, exists := validTransitions[currentStatus]
-	if !exists {
-		return fmt.Errorf("unknown current status: %s", currentStatus)
-	}
-
-	// Check if transition is allowed
-	for _, allowed := range allowedTransitions {
-		if allowed == newStatus {
-			return nil
-		}
-	}
-
-	return fmt.Errorf("invalid status transition from %s to %s", currentStatus, newStatus)
-}
-
 func (m *Manager) updateCollectionMetadata(collection *TicketCollection) {
 	collection.Metadata.TotalTickets = len(collection.Tickets)
 	collection.Metadata.OpenTickets = 0
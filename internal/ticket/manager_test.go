@@ -1,6 +1,7 @@
 package ticket
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -203,6 +204,11 @@ func TestManager_InvalidStatusTransition(t *testing.T) {
 	_, err = manager.UpdateTicket(updatedTicket.ID, updateOptions)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid status transition")
+
+	var transitionErr *InvalidTransitionError
+	require.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, TicketStatusResolved, transitionErr.From)
+	assert.Equal(t, []TicketStatus{TicketStatusInProgress, TicketStatusClosed}, transitionErr.ValidTargets)
 }
 
 func TestManager_ListTickets(t *testing.T) {
@@ -0,0 +1,116 @@
+package ticket
+
+import (
+	"sort"
+	"time"
+
+	"claude-wm-cli/internal/config"
+)
+
+// SLAStatus tells how an open ticket stands against the resolution deadline
+// of its priority
+type SLAStatus string
+
+const (
+	SLANone        SLAStatus = "none"        // No deadline for the priority
+	SLAWithin      SLAStatus = "within"      // Within the deadline
+	SLAApproaching SLAStatus = "approaching" // Past SLANearRatio of the deadline
+	SLABreached    SLAStatus = "breached"    // Past the deadline
+)
+
+// SLANearRatio is the share of its deadline after which a ticket is
+// approaching its SLA
+const SLANearRatio = 0.8
+
+// DefaultSLAHours are the resolution deadlines of the priorities without
+// ticket.sla.<priority>_hours in the project configuration
+var DefaultSLAHours = map[TicketPriority]float64{
+	TicketPriorityUrgent:   4,
+	TicketPriorityCritical: 24,
+}
+
+// SLAPolicy maps priorities to their resolution deadline. Priorities without
+// deadline have no SLA.
+type SLAPolicy map[TicketPriority]time.Duration
+
+// DefaultSLAPolicy returns the policy of DefaultSLAHours
+func DefaultSLAPolicy() SLAPolicy {
+	policy := SLAPolicy{}
+	for priority, hours := range DefaultSLAHours {
+		policy[priority] = time.Duration(hours * float64(time.Hour))
+	}
+	return policy
+}
+
+// LoadSLAPolicy reads ticket.sla.<priority>_hours from the project
+// configuration on top of DefaultSLAHours. A value of 0 removes the deadline
+// of a priority.
+func LoadSLAPolicy(projectPath string) SLAPolicy {
+	policy := DefaultSLAPolicy()
+
+	settings, err := config.NewManager(projectPath).LoadConfig()
+	if err != nil {
+		return policy
+	}
+	ticketSettings, _ := settings["ticket"].(map[string]interface{})
+	slaSettings, _ := ticketSettings["sla"].(map[string]interface{})
+
+	for _, priority := range []TicketPriority{
+		TicketPriorityUrgent, TicketPriorityCritical, TicketPriorityHigh, TicketPriorityMedium, TicketPriorityLow,
+	} {
+		hours, ok := slaSettings[string(priority)+"_hours"].(float64)
+		if !ok || hours < 0 {
+			continue
+		}
+		if hours == 0 {
+			delete(policy, priority)
+			continue
+		}
+		policy[priority] = time.Duration(hours * float64(time.Hour))
+	}
+	return policy
+}
+
+// Status returns how a ticket of priority open for age stands against its SLA
+func (p SLAPolicy) Status(priority TicketPriority, age time.Duration) SLAStatus {
+	deadline, ok := p[priority]
+	if !ok || deadline <= 0 {
+		return SLANone
+	}
+	switch {
+	case age > deadline:
+		return SLABreached
+	case float64(age) >= float64(deadline)*SLANearRatio:
+		return SLAApproaching
+	default:
+		return SLAWithin
+	}
+}
+
+// AgeTickets returns the open and in progress tickets with their age at now
+// and SLA status, oldest first
+func AgeTickets(tickets []*Ticket, policy SLAPolicy, now time.Time) []AgedTicket {
+	var aged []AgedTicket
+	for _, ticket := range tickets {
+		if ticket.Status != TicketStatusOpen && ticket.Status != TicketStatusInProgress {
+			continue
+		}
+		age := now.Sub(ticket.CreatedAt)
+		aged = append(aged, AgedTicket{
+			ID:        ticket.ID,
+			Title:     ticket.Title,
+			Age:       age,
+			Priority:  ticket.Priority,
+			SLA:       policy[ticket.Priority],
+			SLAStatus: policy.Status(ticket.Priority, age),
+		})
+	}
+
+	sort.SliceStable(aged, func(i, j int) bool {
+		if aged[i].Age != aged[j].Age {
+			return aged[i].Age > aged[j].Age
+		}
+		return aged[i].ID < aged[j].ID
+	})
+	return aged
+}
@@ -0,0 +1,63 @@
+package ticket
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSLAPolicy_Status(t *testing.T) {
+	policy := DefaultSLAPolicy()
+
+	assert.Equal(t, SLAWithin, policy.Status(TicketPriorityUrgent, time.Hour))
+	assert.Equal(t, SLAApproaching, policy.Status(TicketPriorityUrgent, 3*time.Hour+12*time.Minute))
+	assert.Equal(t, SLABreached, policy.Status(TicketPriorityUrgent, 5*time.Hour))
+	assert.Equal(t, SLAWithin, policy.Status(TicketPriorityCritical, 5*time.Hour))
+	assert.Equal(t, SLANone, policy.Status(TicketPriorityLow, 1000*time.Hour))
+}
+
+func TestAgeTickets(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tickets := []*Ticket{
+		{ID: "TICKET-001", Title: "Outage", Priority: TicketPriorityUrgent, Status: TicketStatusOpen, CreatedAt: now.Add(-6 * time.Hour)},
+		{ID: "TICKET-002", Title: "Crash", Priority: TicketPriorityCritical, Status: TicketStatusInProgress, CreatedAt: now.Add(-20 * time.Hour)},
+		{ID: "TICKET-003", Title: "Typo", Priority: TicketPriorityLow, Status: TicketStatusOpen, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "TICKET-004", Title: "Done", Priority: TicketPriorityUrgent, Status: TicketStatusResolved, CreatedAt: now.Add(-72 * time.Hour)},
+	}
+
+	aged := AgeTickets(tickets, DefaultSLAPolicy(), now)
+	require.Len(t, aged, 3)
+
+	assert.Equal(t, "TICKET-003", aged[0].ID)
+	assert.Equal(t, 48*time.Hour, aged[0].Age)
+	assert.Equal(t, SLANone, aged[0].SLAStatus)
+	assert.Zero(t, aged[0].SLA)
+
+	assert.Equal(t, "TICKET-002", aged[1].ID)
+	assert.Equal(t, SLAApproaching, aged[1].SLAStatus)
+	assert.Equal(t, 24*time.Hour, aged[1].SLA)
+
+	assert.Equal(t, "TICKET-001", aged[2].ID)
+	assert.Equal(t, SLABreached, aged[2].SLAStatus)
+}
+
+func TestLoadSLAPolicy(t *testing.T) {
+	t.Setenv("CLAUDE_WM_PROFILE", "")
+	dir := t.TempDir()
+	assert.Equal(t, DefaultSLAPolicy(), LoadSLAPolicy(dir))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".claude-wm"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".claude-wm", "config.json"),
+		[]byte(`{"version": "1.0", "ticket": {"sla": {"critical_hours": 0, "high_hours": 72, "urgent_hours": 1.5}}}`), 0644))
+
+	policy := LoadSLAPolicy(dir)
+	assert.Equal(t, SLAPolicy{
+		TicketPriorityUrgent: 90 * time.Minute,
+		TicketPriorityHigh:   72 * time.Hour,
+	}, policy)
+	assert.Equal(t, SLANone, policy.Status(TicketPriorityCritical, 100*time.Hour))
+}
@@ -0,0 +1,394 @@
+package ticket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"claude-wm-cli/internal/backup"
+)
+
+const (
+	SprintsFileName = "sprints.json"
+	SprintsVersion  = "1.0.0"
+
+	// BacklogSprintID is the sprint collecting the tickets left incomplete
+	// by completed sprints, created by the first CompleteSprint
+	BacklogSprintID = "SPRINT-BACKLOG"
+)
+
+// ErrSprintNotFound is returned for an unknown sprint ID
+var ErrSprintNotFound = errors.New("sprint not found")
+
+// Sprint is a time box of tickets
+type Sprint struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"` // Last day of the sprint
+	TicketIDs []string  `json:"ticket_ids"`
+
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	CompletedPoints int        `json:"completed_points,omitempty"` // Story points done when completed
+}
+
+// IsBacklog reports whether s is the backlog sprint
+func (s *Sprint) IsBacklog() bool {
+	return s.ID == BacklogSprintID
+}
+
+// IsActive reports whether s is an open sprint running at now
+func (s *Sprint) IsActive(now time.Time) bool {
+	return !s.IsBacklog() && s.CompletedAt == nil &&
+		!now.Before(s.StartDate) && now.Before(s.EndDate.AddDate(0, 0, 1))
+}
+
+// HasTicket reports whether ticketID is planned in s
+func (s *Sprint) HasTicket(ticketID string) bool {
+	for _, id := range s.TicketIDs {
+		if id == ticketID {
+			return true
+		}
+	}
+	return false
+}
+
+// SprintCollection holds the sprints of the project
+type SprintCollection struct {
+	Sprints  map[string]*Sprint `json:"sprints"`
+	Metadata SprintMetadata     `json:"metadata"`
+}
+
+// SprintMetadata contains collection-level information
+type SprintMetadata struct {
+	Version     string    `json:"version"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+// SprintVelocity is the story points done in a completed sprint
+type SprintVelocity struct {
+	SprintID string `json:"sprint_id"`
+	Name     string `json:"name"`
+	Points   int    `json:"points"`
+}
+
+// SprintManager handles sprint operations and persistence
+type SprintManager struct {
+	rootPath      string
+	ticketManager *Manager
+}
+
+// NewSprintManager creates a new sprint manager
+func NewSprintManager(rootPath string) *SprintManager {
+	return &SprintManager{
+		rootPath:      rootPath,
+		ticketManager: NewManager(rootPath),
+	}
+}
+
+// CreateSprint creates an empty sprint running from start to end, both days
+// included
+func (m *SprintManager) CreateSprint(name string, start, end time.Time) (*Sprint, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("sprint name cannot be empty")
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("sprint cannot end (%s) before it starts (%s)", end.Format("2006-01-02"), start.Format("2006-01-02"))
+	}
+
+	collection, err := m.loadSprintCollection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sprint collection: %w", err)
+	}
+
+	sprint := &Sprint{
+		ID:        m.generateSprintID(collection),
+		Name:      strings.TrimSpace(name),
+		StartDate: start,
+		EndDate:   end,
+		TicketIDs: []string{},
+	}
+	collection.Sprints[sprint.ID] = sprint
+
+	if err := m.saveSprintCollection(collection); err != nil {
+		return nil, fmt.Errorf("failed to save sprint collection: %w", err)
+	}
+	return sprint, nil
+}
+
+// GetSprint retrieves a specific sprint by ID
+func (m *SprintManager) GetSprint(sprintID string) (*Sprint, error) {
+	collection, err := m.loadSprintCollection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sprint collection: %w", err)
+	}
+
+	sprint, exists := collection.Sprints[sprintID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSprintNotFound, sprintID)
+	}
+	return sprint, nil
+}
+
+// ListSprints returns the sprints by start date, the backlog last
+func (m *SprintManager) ListSprints() ([]*Sprint, error) {
+	collection, err := m.loadSprintCollection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sprint collection: %w", err)
+	}
+
+	sprints := make([]*Sprint, 0, len(collection.Sprints))
+	for _, sprint := range collection.Sprints {
+		sprints = append(sprints, sprint)
+	}
+	sort.Slice(sprints, func(i, j int) bool {
+		if sprints[i].IsBacklog() != sprints[j].IsBacklog() {
+			return sprints[j].IsBacklog()
+		}
+		if !sprints[i].StartDate.Equal(sprints[j].StartDate) {
+			return sprints[i].StartDate.Before(sprints[j].StartDate)
+		}
+		return sprints[i].ID < sprints[j].ID
+	})
+	return sprints, nil
+}
+
+// GetActiveSprint returns the open sprint running today, the earliest
+// started one when they overlap, or nil without one
+func (m *SprintManager) GetActiveSprint() (*Sprint, error) {
+	sprints, err := m.ListSprints()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, sprint := range sprints {
+		if sprint.IsActive(now) {
+			return sprint, nil
+		}
+	}
+	return nil, nil
+}
+
+// AddTicketToSprint plans a ticket in a sprint, moving it out of the sprint
+// it was planned in
+func (m *SprintManager) AddTicketToSprint(sprintID, ticketID string) error {
+	if _, err := m.ticketManager.GetTicket(ticketID); err != nil {
+		return err
+	}
+
+	collection, err := m.loadSprintCollection()
+	if err != nil {
+		return fmt.Errorf("failed to load sprint collection: %w", err)
+	}
+
+	sprint, exists := collection.Sprints[sprintID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSprintNotFound, sprintID)
+	}
+	if sprint.CompletedAt != nil {
+		return fmt.Errorf("sprint %s is completed", sprintID)
+	}
+	if sprint.HasTicket(ticketID) {
+		return fmt.Errorf("ticket %s is already in sprint %s", ticketID, sprintID)
+	}
+
+	for _, other := range collection.Sprints {
+		if other.CompletedAt == nil {
+			other.TicketIDs = removeTicketID(other.TicketIDs, ticketID)
+		}
+	}
+	sprint.TicketIDs = append(sprint.TicketIDs, ticketID)
+
+	if err := m.saveSprintCollection(collection); err != nil {
+		return fmt.Errorf("failed to save sprint collection: %w", err)
+	}
+	return nil
+}
+
+// RemoveTicketFromSprint takes a ticket out of a sprint
+func (m *SprintManager) RemoveTicketFromSprint(sprintID, ticketID string) error {
+	collection, err := m.loadSprintCollection()
+	if err != nil {
+		return fmt.Errorf("failed to load sprint collection: %w", err)
+	}
+
+	sprint, exists := collection.Sprints[sprintID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSprintNotFound, sprintID)
+	}
+	if sprint.CompletedAt != nil {
+		return fmt.Errorf("sprint %s is completed", sprintID)
+	}
+	if !sprint.HasTicket(ticketID) {
+		return fmt.Errorf("ticket %s is not in sprint %s", ticketID, sprintID)
+	}
+	sprint.TicketIDs = removeTicketID(sprint.TicketIDs, ticketID)
+
+	if err := m.saveSprintCollection(collection); err != nil {
+		return fmt.Errorf("failed to save sprint collection: %w", err)
+	}
+	return nil
+}
+
+// CompleteSprint closes a sprint, recording the story points of its resolved
+// and closed tickets as its velocity, and moves its other tickets to the
+// backlog sprint, created if absent. It returns the IDs of the moved tickets.
+func (m *SprintManager) CompleteSprint(sprintID string) ([]string, error) {
+	collection, err := m.loadSprintCollection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sprint collection: %w", err)
+	}
+
+	sprint, exists := collection.Sprints[sprintID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSprintNotFound, sprintID)
+	}
+	if sprint.IsBacklog() {
+		return nil, fmt.Errorf("the backlog sprint cannot be completed")
+	}
+	if sprint.CompletedAt != nil {
+		return nil, fmt.Errorf("sprint %s is already completed", sprintID)
+	}
+
+	var done, moved []string
+	points := 0
+	for _, ticketID := range sprint.TicketIDs {
+		ticket, err := m.ticketManager.GetTicket(ticketID)
+		if errors.Is(err, ErrTicketNotFound) {
+			continue // Deleted since it was planned
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ticket.Status == TicketStatusResolved || ticket.Status == TicketStatusClosed {
+			done = append(done, ticketID)
+			points += ticket.Estimations.StoryPoints
+		} else {
+			moved = append(moved, ticketID)
+		}
+	}
+
+	if len(moved) > 0 {
+		backlog, exists := collection.Sprints[BacklogSprintID]
+		if !exists {
+			backlog = &Sprint{ID: BacklogSprintID, Name: "Backlog", TicketIDs: []string{}}
+			collection.Sprints[BacklogSprintID] = backlog
+		}
+		backlog.TicketIDs = append(backlog.TicketIDs, moved...)
+	}
+
+	now := time.Now()
+	sprint.TicketIDs = done
+	if sprint.TicketIDs == nil {
+		sprint.TicketIDs = []string{}
+	}
+	sprint.CompletedAt = &now
+	sprint.CompletedPoints = points
+
+	if err := m.saveSprintCollection(collection); err != nil {
+		return nil, fmt.Errorf("failed to save sprint collection: %w", err)
+	}
+	return moved, nil
+}
+
+// GetVelocity returns the story points done in each completed sprint, by
+// start date
+func (m *SprintManager) GetVelocity() ([]SprintVelocity, error) {
+	sprints, err := m.ListSprints()
+	if err != nil {
+		return nil, err
+	}
+
+	var velocity []SprintVelocity
+	for _, sprint := range sprints {
+		if sprint.CompletedAt != nil {
+			velocity = append(velocity, SprintVelocity{SprintID: sprint.ID, Name: sprint.Name, Points: sprint.CompletedPoints})
+		}
+	}
+	return velocity, nil
+}
+
+// Helper methods
+
+func (m *SprintManager) sprintsPath() string {
+	return filepath.Join(m.rootPath, "docs", "2-current-epic", SprintsFileName)
+}
+
+func (m *SprintManager) loadSprintCollection() (*SprintCollection, error) {
+	data, err := os.ReadFile(m.sprintsPath())
+	if os.IsNotExist(err) {
+		return &SprintCollection{
+			Sprints:  make(map[string]*Sprint),
+			Metadata: SprintMetadata{Version: SprintsVersion, LastUpdated: time.Now()},
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sprints file: %w", err)
+	}
+
+	var collection SprintCollection
+	if err := json.Unmarshal(data, &collection); err != nil {
+		return nil, fmt.Errorf("failed to parse sprints file: %w", err)
+	}
+	if collection.Sprints == nil {
+		collection.Sprints = make(map[string]*Sprint)
+	}
+	for id, sprint := range collection.Sprints {
+		if sprint == nil {
+			delete(collection.Sprints, id)
+			continue
+		}
+		if sprint.ID == "" {
+			sprint.ID = id
+		}
+	}
+	return &collection, nil
+}
+
+func (m *SprintManager) saveSprintCollection(collection *SprintCollection) error {
+	sprintsPath := m.sprintsPath()
+	if err := os.MkdirAll(filepath.Dir(sprintsPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	collection.Metadata.LastUpdated = time.Now()
+	collection.Metadata.Version = SprintsVersion
+
+	data, err := json.MarshalIndent(collection, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sprint collection: %w", err)
+	}
+
+	// Back up the current file, then write atomically
+	if err := backup.WriteWithBackup(sprintsPath, data, backup.BackupTypeAutomatic); err != nil {
+		return fmt.Errorf("failed to write sprints file: %w", err)
+	}
+	return nil
+}
+
+// generateSprintID numbers sprints in creation order
+func (m *SprintManager) generateSprintID(collection *SprintCollection) string {
+	for counter := 1; ; counter++ {
+		sprintID := fmt.Sprintf("SPRINT-%03d", counter)
+		if _, exists := collection.Sprints[sprintID]; !exists {
+			return sprintID
+		}
+	}
+}
+
+// removeTicketID returns ids without ticketID
+func removeTicketID(ids []string, ticketID string) []string {
+	kept := ids[:0]
+	for _, id := range ids {
+		if id != ticketID {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
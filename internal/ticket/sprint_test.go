@@ -0,0 +1,133 @@
+package ticket
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSprintManager_CreateAndList(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	manager := NewSprintManager(tempDir)
+	today := time.Now().Truncate(24 * time.Hour)
+
+	_, err := manager.CreateSprint(" ", today, today)
+	assert.Error(t, err)
+	_, err = manager.CreateSprint("Backwards", today, today.AddDate(0, 0, -1))
+	assert.Error(t, err)
+
+	later, err := manager.CreateSprint("Sprint 2", today.AddDate(0, 0, 14), today.AddDate(0, 0, 27))
+	require.NoError(t, err)
+	current, err := manager.CreateSprint("Sprint 1", today.AddDate(0, 0, -3), today)
+	require.NoError(t, err)
+	assert.Equal(t, "SPRINT-001", later.ID)
+	assert.Equal(t, "SPRINT-002", current.ID)
+
+	sprints, err := manager.ListSprints()
+	require.NoError(t, err)
+	require.Len(t, sprints, 2)
+	assert.Equal(t, current.ID, sprints[0].ID)
+
+	// The last day of a sprint is included
+	active, err := manager.GetActiveSprint()
+	require.NoError(t, err)
+	require.NotNil(t, active)
+	assert.Equal(t, current.ID, active.ID)
+
+	_, err = manager.GetSprint("SPRINT-404")
+	assert.True(t, errors.Is(err, ErrSprintNotFound))
+}
+
+func TestSprintManager_Tickets(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	tickets := NewManager(tempDir)
+	manager := NewSprintManager(tempDir)
+	today := time.Now().Truncate(24 * time.Hour)
+
+	first, err := manager.CreateSprint("Sprint 1", today, today.AddDate(0, 0, 13))
+	require.NoError(t, err)
+	second, err := manager.CreateSprint("Sprint 2", today.AddDate(0, 0, 14), today.AddDate(0, 0, 27))
+	require.NoError(t, err)
+	bug, err := tickets.CreateTicket(TicketCreateOptions{Title: "Bug", StoryPoints: 3})
+	require.NoError(t, err)
+
+	assert.True(t, errors.Is(manager.AddTicketToSprint(first.ID, "TICKET-404"), ErrTicketNotFound))
+	require.NoError(t, manager.AddTicketToSprint(first.ID, bug.ID))
+	assert.Error(t, manager.AddTicketToSprint(first.ID, bug.ID))
+
+	// A ticket is planned in one sprint at a time
+	require.NoError(t, manager.AddTicketToSprint(second.ID, bug.ID))
+	listed, err := tickets.ListTickets(TicketListOptions{SprintID: first.ID})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	listed, err = tickets.ListTickets(TicketListOptions{SprintID: second.ID})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, bug.ID, listed[0].ID)
+
+	_, err = tickets.ListTickets(TicketListOptions{SprintID: "SPRINT-404"})
+	assert.True(t, errors.Is(err, ErrSprintNotFound))
+
+	require.NoError(t, manager.RemoveTicketFromSprint(second.ID, bug.ID))
+	assert.Error(t, manager.RemoveTicketFromSprint(second.ID, bug.ID))
+}
+
+func TestSprintManager_CompleteSprint(t *testing.T) {
+	tempDir := t.TempDir()
+	setupTestDirs(t, tempDir)
+
+	tickets := NewManager(tempDir)
+	manager := NewSprintManager(tempDir)
+	today := time.Now().Truncate(24 * time.Hour)
+
+	sprint, err := manager.CreateSprint("Sprint 1", today, today.AddDate(0, 0, 13))
+	require.NoError(t, err)
+
+	var ids []string
+	for i, points := range []int{3, 5, 8} {
+		created, err := tickets.CreateTicket(TicketCreateOptions{Title: "Ticket", StoryPoints: points})
+		require.NoError(t, err)
+		if i < 2 {
+			for _, status := range []TicketStatus{TicketStatusInProgress, TicketStatusResolved} {
+				_, err = tickets.UpdateTicket(created.ID, TicketUpdateOptions{Status: &status})
+				require.NoError(t, err)
+			}
+		}
+		require.NoError(t, manager.AddTicketToSprint(sprint.ID, created.ID))
+		ids = append(ids, created.ID)
+	}
+
+	moved, err := manager.CompleteSprint(sprint.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ids[2]}, moved)
+
+	_, err = manager.CompleteSprint(sprint.ID)
+	assert.Error(t, err)
+	assert.Error(t, manager.AddTicketToSprint(sprint.ID, ids[2]))
+
+	completed, err := manager.GetSprint(sprint.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, completed.CompletedAt)
+	assert.Equal(t, ids[:2], completed.TicketIDs)
+
+	backlog, err := manager.GetSprint(BacklogSprintID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ids[2]}, backlog.TicketIDs)
+	_, err = manager.CompleteSprint(BacklogSprintID)
+	assert.Error(t, err)
+
+	active, err := manager.GetActiveSprint()
+	require.NoError(t, err)
+	assert.Nil(t, active)
+
+	stats, err := tickets.GetTicketStats()
+	require.NoError(t, err)
+	assert.Equal(t, []SprintVelocity{{SprintID: sprint.ID, Name: "Sprint 1", Points: 8}}, stats.SprintVelocity)
+}
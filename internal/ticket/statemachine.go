@@ -0,0 +1,75 @@
+package ticket
+
+import (
+	"fmt"
+
+	"claude-wm-cli/internal/workflow/fsm"
+)
+
+// TicketEvent is what moves a ticket from one status to another
+type TicketEvent string
+
+const (
+	TicketEventStart   TicketEvent = "start"
+	TicketEventStop    TicketEvent = "stop"
+	TicketEventResolve TicketEvent = "resolve"
+	TicketEventClose   TicketEvent = "close"
+	TicketEventReopen  TicketEvent = "reopen"
+)
+
+// StatusMachine validates the status changes of tickets
+type StatusMachine = fsm.StateMachine[TicketStatus, TicketEvent]
+
+// InvalidTransitionError is returned when a ticket cannot move to a status
+type InvalidTransitionError = fsm.InvalidTransitionError[TicketStatus]
+
+// NewStatusMachine returns the ticket lifecycle
+// (open → in_progress → resolved → closed, with reopening), whose hooks set
+// the StartedAt, ResolvedAt and ClosedAt timestamps of the ticket changing
+// status
+func NewStatusMachine() *StatusMachine {
+	sm := fsm.New[TicketStatus, TicketEvent](TicketStatusOpen).
+		AddState(TicketStatusInProgress).
+		AddState(TicketStatusResolved).
+		AddState(TicketStatusClosed).
+		AddTransition(TicketStatusOpen, TicketEventStart, TicketStatusInProgress).
+		AddTransition(TicketStatusOpen, TicketEventClose, TicketStatusClosed).
+		AddTransition(TicketStatusInProgress, TicketEventResolve, TicketStatusResolved).
+		AddTransition(TicketStatusInProgress, TicketEventStop, TicketStatusOpen).
+		AddTransition(TicketStatusInProgress, TicketEventClose, TicketStatusClosed).
+		AddTransition(TicketStatusResolved, TicketEventClose, TicketStatusClosed).
+		AddTransition(TicketStatusResolved, TicketEventReopen, TicketStatusInProgress).
+		AddTransition(TicketStatusClosed, TicketEventReopen, TicketStatusOpen)
+
+	sm.OnEnter[TicketStatusInProgress] = func(ctx fsm.TransitionContext[TicketStatus, TicketEvent]) error {
+		ticket, err := transitionTicket(ctx)
+		if err == nil && ticket.StartedAt == nil {
+			ticket.StartedAt = &ctx.At
+		}
+		return err
+	}
+	sm.OnEnter[TicketStatusResolved] = func(ctx fsm.TransitionContext[TicketStatus, TicketEvent]) error {
+		ticket, err := transitionTicket(ctx)
+		if err == nil && ticket.ResolvedAt == nil {
+			ticket.ResolvedAt = &ctx.At
+		}
+		return err
+	}
+	sm.OnEnter[TicketStatusClosed] = func(ctx fsm.TransitionContext[TicketStatus, TicketEvent]) error {
+		ticket, err := transitionTicket(ctx)
+		if err == nil && ticket.ClosedAt == nil {
+			ticket.ClosedAt = &ctx.At
+		}
+		return err
+	}
+	return sm
+}
+
+// transitionTicket returns the ticket changing status in a hook
+func transitionTicket(ctx fsm.TransitionContext[TicketStatus, TicketEvent]) (*Ticket, error) {
+	ticket, ok := ctx.Subject.(*Ticket)
+	if !ok {
+		return nil, fmt.Errorf("expected a ticket, got %T", ctx.Subject)
+	}
+	return ticket, nil
+}
@@ -196,6 +196,7 @@ type TicketListOptions struct {
 	AssignedTo     string
 	RelatedEpicID  string
 	RelatedStoryID string
+	SprintID       string // Only tickets planned in this sprint
 	ShowClosed     bool
 	Limit          int
 }
@@ -209,6 +210,20 @@ type TicketStats struct {
 	AverageResolutionTime time.Duration          `json:"avg_resolution_time"`
 	OldestOpenTicket      *time.Time             `json:"oldest_open_ticket,omitempty"`
 	RecentActivity        []TicketActivity       `json:"recent_activity"`
+	AgedTickets           []AgedTicket           `json:"aged_tickets"`      // Open tickets, oldest first
+	SLABreaches           int                    `json:"sla_breaches"`      // Open tickets past their SLA
+	NearSLABreaches       int                    `json:"near_sla_breaches"` // Open tickets approaching their SLA
+	SprintVelocity        []SprintVelocity       `json:"sprint_velocity"`   // Points done per completed sprint
+}
+
+// AgedTicket is an open ticket with its age and SLA status
+type AgedTicket struct {
+	ID        string         `json:"id"`
+	Title     string         `json:"title"`
+	Age       time.Duration  `json:"age"`
+	Priority  TicketPriority `json:"priority"`
+	SLA       time.Duration  `json:"sla,omitempty"` // Resolution deadline, 0 without SLA
+	SLAStatus SLAStatus      `json:"sla_status"`
 }
 
 // TicketActivity represents a change in ticket state
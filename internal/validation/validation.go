@@ -5,19 +5,21 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"claude-wm-cli/internal/errors"
 )
 
-// ErrorCode represents standard CLI error codes
-type ErrorCode int
+// ErrorCode represents standard CLI error codes, used as exit codes
+type ErrorCode = errors.ExitCode
 
 const (
-	ErrorSuccess          ErrorCode = 0
-	ErrorGeneral          ErrorCode = 1
-	ErrorInvalidInput     ErrorCode = 2
-	ErrorFileNotFound     ErrorCode = 3
-	ErrorPermissionDenied ErrorCode = 4
-	ErrorTimeout          ErrorCode = 5
-	ErrorNetworkFailure   ErrorCode = 6
+	ErrorSuccess          = errors.ExitSuccess
+	ErrorGeneral          = errors.ExitGeneric
+	ErrorInvalidInput     = errors.ExitUsage
+	ErrorFileNotFound     = errors.ExitNotFound
+	ErrorPermissionDenied = errors.ExitPermissionDenied
+	ErrorTimeout          = errors.ExitTimeout
+	ErrorNetworkFailure   = errors.ExitNetwork
 )
 
 // ValidationError represents a validation error with context
@@ -0,0 +1,172 @@
+// Package fsm provides the state machines that validate the status changes
+// of tickets and epics. It has no dependency on the domain packages so that
+// their managers can use it.
+package fsm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TransitionContext describes a transition to the hooks it fires
+type TransitionContext[S comparable, E comparable] struct {
+	From    S
+	To      S
+	Event   E
+	Subject interface{} // Entity changing state, such as a *ticket.Ticket
+	At      time.Time
+}
+
+// Hook runs when a state is entered or exited. An error from an OnExit hook
+// cancels the transition.
+type Hook[S comparable, E comparable] func(ctx TransitionContext[S, E]) error
+
+// InvalidTransitionError is returned for a transition the table does not
+// allow
+type InvalidTransitionError[S comparable] struct {
+	From         S
+	To           S
+	ValidTargets []S
+}
+
+func (e *InvalidTransitionError[S]) Error() string {
+	return fmt.Sprintf("invalid status transition from %v to %v", e.From, e.To)
+}
+
+// StateMachine validates transitions between states of type S triggered by
+// events of type E, and fires the hooks of the states left and entered
+type StateMachine[S comparable, E comparable] struct {
+	Initial     S
+	Transitions map[S]map[E]S
+	OnEnter     map[S]Hook[S, E]
+	OnExit      map[S]Hook[S, E]
+
+	states []S // States in order of declaration, for stable output
+}
+
+// New returns a state machine starting in initial, without transitions
+func New[S comparable, E comparable](initial S) *StateMachine[S, E] {
+	sm := &StateMachine[S, E]{
+		Initial:     initial,
+		Transitions: make(map[S]map[E]S),
+		OnEnter:     make(map[S]Hook[S, E]),
+		OnExit:      make(map[S]Hook[S, E]),
+	}
+	sm.addState(initial)
+	return sm
+}
+
+// AddTransition allows event to move from one state to another
+func (sm *StateMachine[S, E]) AddTransition(from S, event E, to S) *StateMachine[S, E] {
+	sm.addState(from)
+	sm.addState(to)
+	if sm.Transitions[from] == nil {
+		sm.Transitions[from] = make(map[E]S)
+	}
+	sm.Transitions[from][event] = to
+	return sm
+}
+
+// AddState declares a state, so that states without transitions are known
+func (sm *StateMachine[S, E]) AddState(state S) *StateMachine[S, E] {
+	sm.addState(state)
+	return sm
+}
+
+func (sm *StateMachine[S, E]) addState(state S) {
+	for _, known := range sm.states {
+		if known == state {
+			return
+		}
+	}
+	sm.states = append(sm.states, state)
+}
+
+// States returns the states of the machine in order of declaration
+func (sm *StateMachine[S, E]) States() []S {
+	return append([]S(nil), sm.states...)
+}
+
+// ValidTargets returns the states reachable from state in one transition, in
+// order of declaration
+func (sm *StateMachine[S, E]) ValidTargets(from S) []S {
+	reachable := make(map[S]bool)
+	for _, to := range sm.Transitions[from] {
+		reachable[to] = true
+	}
+
+	var targets []S
+	for _, state := range sm.states {
+		if reachable[state] {
+			targets = append(targets, state)
+		}
+	}
+	return targets
+}
+
+// Validate returns the event moving from one state to another, or an
+// *InvalidTransitionError when the table does not allow it
+func (sm *StateMachine[S, E]) Validate(from, to S) (E, error) {
+	for _, event := range sm.events(from) {
+		if sm.Transitions[from][event] == to {
+			return event, nil
+		}
+	}
+	var none E
+	return none, &InvalidTransitionError[S]{From: from, To: to, ValidTargets: sm.ValidTargets(from)}
+}
+
+// Transition validates the move from one state to another and fires the
+// OnExit hook of from, then the OnEnter hook of to, with subject
+func (sm *StateMachine[S, E]) Transition(from, to S, subject interface{}) error {
+	event, err := sm.Validate(from, to)
+	if err != nil {
+		return err
+	}
+
+	ctx := TransitionContext[S, E]{From: from, To: to, Event: event, Subject: subject, At: time.Now()}
+	if hook := sm.OnExit[from]; hook != nil {
+		if err := hook(ctx); err != nil {
+			return fmt.Errorf("leaving %v: %w", from, err)
+		}
+	}
+	if hook := sm.OnEnter[to]; hook != nil {
+		if err := hook(ctx); err != nil {
+			return fmt.Errorf("entering %v: %w", to, err)
+		}
+	}
+	return nil
+}
+
+// events returns the events leaving state, sorted by name
+func (sm *StateMachine[S, E]) events(state S) []E {
+	events := make([]E, 0, len(sm.Transitions[state]))
+	for event := range sm.Transitions[state] {
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return fmt.Sprint(events[i]) < fmt.Sprint(events[j]) })
+	return events
+}
+
+// Visualize returns the machine as a Mermaid state diagram. States without
+// outgoing transitions are drawn as final states.
+func (sm *StateMachine[S, E]) Visualize() string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	fmt.Fprintf(&b, "    [*] --> %v\n", sm.Initial)
+
+	for _, from := range sm.states {
+		for _, event := range sm.events(from) {
+			fmt.Fprintf(&b, "    %v --> %v: %v\n", from, sm.Transitions[from][event], event)
+		}
+	}
+
+	for _, state := range sm.states {
+		if len(sm.Transitions[state]) == 0 {
+			fmt.Fprintf(&b, "    %v --> [*]\n", state)
+		}
+	}
+	return b.String()
+}
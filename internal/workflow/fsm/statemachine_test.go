@@ -0,0 +1,72 @@
+package fsm
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func doorMachine() *StateMachine[string, string] {
+	return New[string, string]("closed").
+		AddTransition("closed", "open", "opened").
+		AddTransition("opened", "close", "closed").
+		AddTransition("closed", "lock", "locked").
+		AddTransition("locked", "break", "broken")
+}
+
+func TestStateMachine_Transition(t *testing.T) {
+	sm := doorMachine()
+
+	var fired []string
+	sm.OnExit["closed"] = func(ctx TransitionContext[string, string]) error {
+		fired = append(fired, "exit "+ctx.From+" on "+ctx.Event)
+		return nil
+	}
+	sm.OnEnter["opened"] = func(ctx TransitionContext[string, string]) error {
+		fired = append(fired, "enter "+ctx.To+" with "+ctx.Subject.(string))
+		return nil
+	}
+
+	require.NoError(t, sm.Transition("closed", "opened", "door"))
+	assert.Equal(t, []string{"exit closed on open", "enter opened with door"}, fired)
+}
+
+func TestStateMachine_InvalidTransition(t *testing.T) {
+	sm := doorMachine()
+	sm.OnEnter["broken"] = func(ctx TransitionContext[string, string]) error {
+		t.Fatal("hook fired for an invalid transition")
+		return nil
+	}
+
+	err := sm.Transition("closed", "broken", nil)
+	require.Error(t, err)
+	assert.Equal(t, "invalid status transition from closed to broken", err.Error())
+
+	var transitionErr *InvalidTransitionError[string]
+	require.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, []string{"opened", "locked"}, transitionErr.ValidTargets)
+}
+
+func TestStateMachine_HookError(t *testing.T) {
+	sm := doorMachine()
+	sm.OnExit["closed"] = func(ctx TransitionContext[string, string]) error {
+		return errors.New("jammed")
+	}
+
+	err := sm.Transition("closed", "opened", nil)
+	assert.EqualError(t, err, "leaving closed: jammed")
+}
+
+func TestStateMachine_Visualize(t *testing.T) {
+	expected := `stateDiagram-v2
+    [*] --> closed
+    closed --> locked: lock
+    closed --> opened: open
+    opened --> closed: close
+    locked --> broken: break
+    broken --> [*]
+`
+	assert.Equal(t, expected, doorMachine().Visualize())
+}
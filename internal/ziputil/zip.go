@@ -6,11 +6,18 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 )
 
 // CreateBackup creates a ZIP archive of the source directory
 func CreateBackup(sourceDir, backupPath string) error {
+	return CreateArchive(backupPath, map[string]string{"": sourceDir})
+}
+
+// CreateArchive creates a ZIP archive holding several directories, each stored
+// under its key as a path prefix ("" stores the directory at the archive root)
+func CreateArchive(backupPath string, dirs map[string]string) error {
 	// Create the backup directory if it doesn't exist
 	if err := os.MkdirAll(filepath.Dir(backupPath), 0755); err != nil {
 		return fmt.Errorf("failed to create backup directory: %w", err)
@@ -27,8 +34,25 @@ func CreateBackup(sourceDir, backupPath string) error {
 	zipWriter := zip.NewWriter(zipFile)
 	defer zipWriter.Close()
 
+	prefixes := make([]string, 0, len(dirs))
+	for prefix := range dirs {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	for _, prefix := range prefixes {
+		if err := addDirectory(zipWriter, dirs[prefix], prefix); err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// addDirectory writes every file of sourceDir to the archive under prefix
+func addDirectory(zipWriter *zip.Writer, sourceDir, prefix string) error {
 	// Walk through the source directory
-	err = filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
+	return filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -46,6 +70,9 @@ func CreateBackup(sourceDir, backupPath string) error {
 
 		// Normalize path separators for ZIP format (always use forward slashes)
 		relPath = filepath.ToSlash(relPath)
+		if prefix != "" {
+			relPath = prefix + "/" + relPath
+		}
 
 		// Create file in ZIP
 		zipEntry, err := zipWriter.Create(relPath)
@@ -67,12 +94,6 @@ func CreateBackup(sourceDir, backupPath string) error {
 
 		return nil
 	})
-
-	if err != nil {
-		return fmt.Errorf("failed to create backup: %w", err)
-	}
-
-	return nil
 }
 
 // CreateTimestampedBackup creates a backup with a timestamp in the filename